@@ -0,0 +1,114 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// persistTimeout bounds the background writes of extracted postings, which outlive the search request
+const persistTimeout = 30 * time.Second
+
+// JobCache stores extracted postings keyed by canonical URL so repeat searches skip fetching and extraction
+type JobCache interface {
+	// GetCachedJobs returns the unexpired postings for the given keys; missing keys are omitted
+	GetCachedJobs(ctx context.Context, keys []string) (map[string]models.JobPosting, error)
+	// CacheJobs stores postings by key for the given TTL
+	CacheJobs(ctx context.Context, jobs map[string]models.JobPosting, ttl time.Duration) error
+}
+
+// lookupCachedJobs splits search result URLs into postings already in the cache and URLs still to fetch
+func (a *JobAgent) lookupCachedJobs(ctx context.Context, urls []string) ([]models.JobPosting, []string) {
+	if a.jobCache == nil || len(urls) == 0 {
+		return nil, urls
+	}
+
+	keys := make([]string, len(urls))
+	for i, u := range urls {
+		keys[i] = utils.CanonicalURL(u)
+	}
+
+	cached, err := a.jobCache.GetCachedJobs(ctx, keys)
+	if err != nil {
+		// The cache is an optimization; fall back to fetching everything
+		agentLog.WarnContext(ctx, "Job cache lookup failed", "error", err)
+		return nil, urls
+	}
+
+	// The same posting may appear under several tracking URLs; only the first is used
+	seen := make(map[string]bool, len(urls))
+	hits := make([]models.JobPosting, 0, len(cached))
+	misses := make([]string, 0, len(urls))
+	for i, u := range urls {
+		if seen[keys[i]] {
+			continue
+		}
+		seen[keys[i]] = true
+
+		if job, ok := cached[keys[i]]; ok {
+			hits = append(hits, job)
+		} else {
+			misses = append(misses, u)
+		}
+	}
+	return hits, misses
+}
+
+// cacheExtractedJobs stores freshly extracted postings for later searches, in the background so the
+// write doesn't add to search latency
+func (a *JobAgent) cacheExtractedJobs(ctx context.Context, jobs []models.JobPosting) {
+	if a.jobCache == nil || len(jobs) == 0 {
+		return
+	}
+
+	entries := make(map[string]models.JobPosting, len(jobs))
+	for _, job := range jobs {
+		if job.URL != "" {
+			entries[utils.CanonicalURL(job.URL)] = job
+		}
+	}
+
+	go func() {
+		cacheCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), persistTimeout)
+		defer cancel()
+		if err := a.jobCache.CacheJobs(cacheCtx, entries, a.jobCacheTTL); err != nil {
+			agentLog.WarnContext(cacheCtx, "Failed to cache extracted jobs", "error", err)
+		}
+	}()
+}
+
+// JobStore keeps extracted postings under their stable IDs, building the corpus behind job details,
+// insights and similar jobs
+type JobStore interface {
+	// SaveJobs stores postings, refreshing postings that are already stored
+	SaveJobs(ctx context.Context, jobs []models.JobPosting) error
+}
+
+// storeExtractedJobs adds freshly extracted postings to the jobs collection, in the background so the
+// write doesn't add to search latency
+func (a *JobAgent) storeExtractedJobs(ctx context.Context, jobs []models.JobPosting) {
+	if a.jobStore == nil || len(jobs) == 0 {
+		return
+	}
+
+	postings := make([]models.JobPosting, 0, len(jobs))
+	for _, job := range jobs {
+		if job.URL == "" {
+			continue
+		}
+		if job.ID == "" {
+			job.ID = utils.JobID(job.URL)
+		}
+		postings = append(postings, job)
+	}
+
+	go func() {
+		storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), persistTimeout)
+		defer cancel()
+		if err := a.jobStore.SaveJobs(storeCtx, postings); err != nil {
+			agentLog.WarnContext(storeCtx, "Failed to store extracted jobs", "error", err)
+		}
+	}()
+}
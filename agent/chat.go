@@ -0,0 +1,331 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// maxChatSearchResults bounds the jobs a search started from the chat returns
+const maxChatSearchResults = 10
+
+// chatRegistryTools are the registered tools the assistant may call besides its own functions
+var chatRegistryTools = []string{"company_research", "tailor_cv"}
+
+// chatInstruction is the system instruction for the career assistant
+const chatInstruction = `You are a career assistant for a job seeker in Indonesia. Answer their career questions
+(roles to target, skills to learn, how to present their experience, salary expectations) and help them find jobs.
+
+Use the tools when they help: get_profile for their skills and preferences, list_saved_jobs for the jobs they
+bookmarked, search_jobs to run a job search (refine the query and filters as the conversation narrows down what
+they want), company_research to look up an employer and tailor_cv to adapt their CV to a posting. Do not run a
+search for questions you can answer directly. The candidate's profile is passed to tailor_cv automatically.
+
+Answer in the language of the user's last message, in concise markdown. Mention each posting you recommend with
+its title, company, match score and URL. Never invent postings, companies or profile details.`
+
+// chatFunctions are the assistant's own functions, backed by the conversation's state instead of registered tools
+var chatFunctions = []gemini.FunctionDeclaration{
+	{
+		Name:        "get_profile",
+		Description: "Get the user's structured profile: skills, experience, preferred roles, locations and work modes.",
+	},
+	{
+		Name:        "list_saved_jobs",
+		Description: "List the jobs the user bookmarked, with their match scores.",
+	},
+	{
+		Name:        "search_jobs",
+		Description: "Run a job search against the user's profile and return the best matching postings with match scores. Leave query empty to search for the profile's preferred roles.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Search query, e.g. 'remote golang backend engineer'",
+				},
+				"locations": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Locations to search in",
+				},
+				"remote_modes": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string", "enum": []string{"WFH", "WFO", "Hybrid"}},
+					"description": "Accepted work modes",
+				},
+				"job_types": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string", "enum": []string{"full_time", "part_time", "contract", "internship", "freelance"}},
+					"description": "Accepted job types",
+				},
+				"exclude_keywords": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Words that must not appear in postings, e.g. 'crypto'",
+				},
+				"exclude_companies": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Companies to leave out",
+				},
+			},
+		},
+	},
+}
+
+// ChatInput is a user message to the career assistant with the conversation so far
+type ChatInput struct {
+	Message   string
+	History   []models.ChatMessage // Earlier messages, oldest first
+	Profile   *models.UserProfile  // Saved structured profile (nil if the user has none)
+	SavedJobs []models.SavedJob
+	Feedback  []models.JobFeedback
+	Filters   models.JobSearchFilter // Account-level filters applied to every search (e.g. blocked companies)
+	Budget    *models.LLMBudget
+	Incognito bool
+}
+
+// ChatOutput is the assistant's reply
+type ChatOutput struct {
+	Reply          string
+	Steps          []models.AgentStep
+	Jobs           []models.RankedJob // Results of the last search the assistant ran
+	Usage          models.LLMUsage    // Includes the searches the assistant ran
+	StepsExhausted bool
+}
+
+// chatState holds the state of one assistant turn
+type chatState struct {
+	orchestration
+	input ChatInput
+	jobs  []models.RankedJob
+	usage models.LLMUsage // Usage of the searches run during the turn
+}
+
+// Chat answers a message of a career assistant conversation. Gemini may read the user's profile and
+// saved jobs, research companies, tailor the CV and run job searches before replying.
+func (a *JobAgent) Chat(ctx context.Context, input ChatInput) (*ChatOutput, error) {
+	if input.Message == "" {
+		return nil, fmt.Errorf("a message is required")
+	}
+
+	var limits models.LLMBudget
+	if input.Budget != nil {
+		limits = *input.Budget
+	}
+	budget := gemini.NewBudget(limits)
+	ctx = gemini.WithBudget(ctx, budget)
+
+	profile := input.Profile
+	if input.Incognito {
+		profile = utils.RedactProfile(profile)
+	}
+	state := &chatState{
+		orchestration: orchestration{profile: profile, feedback: input.Feedback, pages: make(map[string]string)},
+		input:         input,
+	}
+
+	functions := append([]gemini.FunctionDeclaration{}, chatFunctions...)
+	for _, fn := range a.orchestratedFunctions() {
+		if isChatRegistryTool(fn.Name) {
+			functions = append(functions, fn)
+		}
+	}
+	chat := a.geminiClient.NewToolChat(chatInstruction, functions)
+	chat.SetHistory(chatTurns(input.History))
+
+	output := &ChatOutput{Steps: make([]models.AgentStep, 0)}
+	reply, calls, err := chat.Send(ctx, input.Message)
+	for step := 1; err == nil && len(calls) > 0; step++ {
+		results := a.runChatToolCalls(ctx, state, calls, output)
+
+		note := ""
+		if step >= a.cfg.AgentMaxSteps {
+			output.StepsExhausted = true
+			note = "The tool budget is used up. Answer now with the information you have; do not call more tools."
+		}
+		reply, calls, err = chat.SendResults(ctx, results, note)
+		if output.StepsExhausted {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("chat failed: %w", err)
+	}
+
+	output.Reply = reply
+	if output.Reply == "" && output.StepsExhausted {
+		output.Reply = "I ran out of steps before finishing. Could you narrow down the question?"
+	}
+	output.Jobs = state.jobs
+	output.Usage = addUsage(budget.Usage(), state.usage)
+	agentLog.InfoContext(ctx, "Chat turn finished", "tool_calls", len(output.Steps))
+
+	return output, nil
+}
+
+// runChatToolCalls executes the assistant's tool calls in order, recording each as a step
+func (a *JobAgent) runChatToolCalls(ctx context.Context, state *chatState, calls []gemini.FunctionCall, output *ChatOutput) []gemini.FunctionResult {
+	results := make([]gemini.FunctionResult, 0, len(calls))
+	for _, call := range calls {
+		step := models.AgentStep{Tool: call.Name, Arguments: call.Arguments}
+
+		var result map[string]interface{}
+		var err error
+		switch call.Name {
+		case "get_profile":
+			result = state.profileResult()
+		case "list_saved_jobs":
+			result = state.savedJobsResult()
+		case "search_jobs":
+			result, err = a.chatSearch(ctx, state, call.Arguments)
+		default:
+			if isChatRegistryTool(call.Name) {
+				result, err = a.runToolCall(ctx, &state.orchestration, call)
+			} else {
+				err = fmt.Errorf("unknown tool %q", call.Name)
+			}
+		}
+
+		if err != nil {
+			step.Error = err.Error()
+			result = map[string]interface{}{"success": false, "error": err.Error()}
+		} else {
+			step.Success, _ = result["success"].(bool)
+			if !step.Success {
+				step.Error, _ = result["error"].(string)
+			}
+		}
+		agentLog.InfoContext(ctx, "Chat tool called", "tool", call.Name, "success", step.Success)
+
+		output.Steps = append(output.Steps, step)
+		results = append(results, gemini.FunctionResult{Name: call.Name, Response: result})
+	}
+	return results
+}
+
+// chatSearch runs a job search requested by the assistant and returns a compact list of the results
+func (a *JobAgent) chatSearch(ctx context.Context, state *chatState, arguments json.RawMessage) (map[string]interface{}, error) {
+	var args struct {
+		Query            string   `json:"query"`
+		Locations        []string `json:"locations"`
+		RemoteModes      []string `json:"remote_modes"`
+		JobTypes         []string `json:"job_types"`
+		ExcludeKeywords  []string `json:"exclude_keywords"`
+		ExcludeCompanies []string `json:"exclude_companies"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if args.Query == "" && state.input.Profile == nil {
+		return map[string]interface{}{"success": false, "error": "the user has no saved profile; a query is required"}, nil
+	}
+
+	filters := state.input.Filters
+	filters.Locations = args.Locations
+	filters.RemoteModes = args.RemoteModes
+	filters.JobTypes = args.JobTypes
+	filters.ExcludeKeywords = append(append([]string{}, filters.ExcludeKeywords...), args.ExcludeKeywords...)
+	filters = filters.WithExcludedCompanies(args.ExcludeCompanies)
+
+	output, err := a.SearchJobs(ctx, SearchJobsInput{
+		Profile:    state.input.Profile,
+		Query:      args.Query,
+		Filters:    filters,
+		Budget:     state.input.Budget,
+		Incognito:  state.input.Incognito,
+		MaxResults: maxChatSearchResults,
+		Feedback:   state.feedback,
+	})
+	if err != nil {
+		return nil, err
+	}
+	state.jobs = output.Results
+	state.usage = addUsage(state.usage, output.Usage)
+
+	jobs := make([]map[string]interface{}, 0, len(output.Results))
+	for _, job := range output.Results {
+		jobs = append(jobs, map[string]interface{}{
+			"id":           job.ID,
+			"title":        job.Title,
+			"company":      job.Company,
+			"location":     job.Location,
+			"site_setting": job.SiteSetting,
+			"salary":       job.Salary,
+			"match_score":  job.MatchScore,
+			"match_reason": job.MatchReason,
+			"url":          job.URL,
+		})
+	}
+	return map[string]interface{}{"success": true, "data": map[string]interface{}{"jobs": jobs, "total": len(jobs)}}, nil
+}
+
+// profileResult returns the user's profile to the model
+func (s *chatState) profileResult() map[string]interface{} {
+	if s.profile == nil {
+		return map[string]interface{}{"success": false, "error": "the user has no saved profile; suggest uploading a CV"}
+	}
+	data, err := json.Marshal(s.profile)
+	if err != nil {
+		return map[string]interface{}{"success": false, "error": err.Error()}
+	}
+	var profile interface{}
+	_ = json.Unmarshal(data, &profile)
+	return map[string]interface{}{"success": true, "data": profile}
+}
+
+// savedJobsResult returns the user's bookmarked jobs to the model
+func (s *chatState) savedJobsResult() map[string]interface{} {
+	jobs := make([]map[string]interface{}, 0, len(s.input.SavedJobs))
+	for _, saved := range s.input.SavedJobs {
+		jobs = append(jobs, map[string]interface{}{
+			"id":          saved.Job.ID,
+			"title":       saved.Job.Title,
+			"company":     saved.Job.Company,
+			"location":    saved.Job.Location,
+			"match_score": saved.Job.MatchScore,
+			"url":         saved.Job.URL,
+		})
+	}
+	return map[string]interface{}{"success": true, "data": map[string]interface{}{"jobs": jobs, "total": len(jobs)}}
+}
+
+// chatTurns converts stored messages to the model's conversation history
+func chatTurns(messages []models.ChatMessage) []gemini.ChatTurn {
+	turns := make([]gemini.ChatTurn, 0, len(messages))
+	for _, m := range messages {
+		role := "user"
+		if m.Role == models.ChatRoleAssistant {
+			role = "model"
+		}
+		turns = append(turns, gemini.ChatTurn{Role: role, Text: m.Content})
+	}
+	return turns
+}
+
+// isChatRegistryTool reports whether the assistant may call a registered tool
+func isChatRegistryTool(name string) bool {
+	for _, tool := range chatRegistryTools {
+		if tool == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addUsage sums the usage of two sets of Gemini calls
+func addUsage(a, b models.LLMUsage) models.LLMUsage {
+	return models.LLMUsage{
+		Calls:            a.Calls + b.Calls,
+		PromptTokens:     a.PromptTokens + b.PromptTokens,
+		OutputTokens:     a.OutputTokens + b.OutputTokens,
+		EstimatedCostUSD: a.EstimatedCostUSD + b.EstimatedCostUSD,
+		BudgetExhausted:  a.BudgetExhausted || b.BudgetExhausted,
+		FallbackScored:   a.FallbackScored + b.FallbackScored,
+	}
+}
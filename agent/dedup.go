@@ -0,0 +1,182 @@
+package agent
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// titleSimilarityThreshold is the minimum word overlap for two titles at the same company to be the same role
+const titleSimilarityThreshold = 0.8
+
+// companySuffixes are legal-entity words ignored when comparing company names
+var companySuffixes = map[string]bool{
+	"pt": true, "tbk": true, "cv": true, "inc": true, "ltd": true, "llc": true,
+	"corp": true, "corporation": true, "co": true, "limited": true, "persero": true,
+}
+
+// genericCompanyWords are words shared by many unrelated companies; a name is never reduced to one of them
+// alone, so excluding "PT Bank Tbk" doesn't hide every bank
+var genericCompanyWords = map[string]bool{
+	"bank": true, "group": true, "company": true, "indonesia": true, "asia": true, "international": true,
+	"global": true, "holdings": true, "services": true, "solutions": true, "technology": true, "consulting": true,
+}
+
+// dedupeJobs merges postings of the same role found on different portals (e.g. LinkedIn, JobStreet and Glints).
+// Postings are duplicates if their canonical URLs match, or if they are at the same company with near-identical
+// titles and compatible locations. Each merged posting keeps the most complete description and lists every
+// source in Links. Returns the merged postings and how many duplicates were folded in.
+func dedupeJobs(jobs []models.JobPosting) ([]models.JobPosting, int) {
+	type group struct {
+		job     models.JobPosting
+		company string
+		title   map[string]bool
+		links   []models.JobLink
+	}
+
+	groups := make([]*group, 0, len(jobs))
+	byURL := make(map[string]*group, len(jobs))
+	merged := 0
+
+	for _, job := range jobs {
+		canonical := utils.CanonicalURL(job.URL)
+		company := normalizeCompany(job.Company)
+		title := wordSet(job.Title)
+
+		target := byURL[canonical]
+		if target == nil && company != "" {
+			for _, g := range groups {
+				if g.company == company && jaccard(g.title, title) >= titleSimilarityThreshold &&
+					locationsCompatible(g.job.Location, job.Location) {
+					target = g
+					break
+				}
+			}
+		}
+
+		link := models.JobLink{Source: job.Source, URL: job.URL}
+		if target == nil {
+			g := &group{job: job, company: company, title: title, links: []models.JobLink{link}}
+			groups = append(groups, g)
+			if canonical != "" {
+				byURL[canonical] = g
+			}
+			continue
+		}
+
+		merged++
+		if canonical != "" {
+			byURL[canonical] = target
+		}
+		if !hasLink(target.links, job.URL) {
+			target.links = append(target.links, link)
+		}
+		target.job = mergePostings(target.job, job)
+	}
+
+	result := make([]models.JobPosting, 0, len(groups))
+	for _, g := range groups {
+		if len(g.links) > 1 {
+			g.job.Links = g.links
+		}
+		result = append(result, g.job)
+	}
+	return result, merged
+}
+
+// mergePostings keeps the posting with the longer description and fills its empty fields from the other
+func mergePostings(a, b models.JobPosting) models.JobPosting {
+	primary, other := a, b
+	if len(b.Description) > len(a.Description) {
+		primary, other = b, a
+	}
+
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	fill(&primary.Salary, other.Salary)
+	fill(&primary.DatePosted, other.DatePosted)
+	fill(&primary.ApplicationURL, other.ApplicationURL)
+	fill(&primary.Requirements, other.Requirements)
+	fill(&primary.ExperienceLevel, other.ExperienceLevel)
+	fill(&primary.Location, other.Location)
+	if len(primary.Benefits) == 0 {
+		primary.Benefits = other.Benefits
+	}
+	if len(primary.Tags) == 0 {
+		primary.Tags = other.Tags
+	}
+	return primary
+}
+
+// normalizeCompany lowercases a company name and drops punctuation and legal-entity words ("PT", "Tbk", "Inc").
+// If only a generic word (or nothing) would be left, the legal-entity words are kept.
+func normalizeCompany(name string) string {
+	all := splitWords(name)
+	words := make([]string, 0, len(all))
+	for _, w := range all {
+		if !companySuffixes[w] {
+			words = append(words, w)
+		}
+	}
+	if len(words) == 0 || (len(words) == 1 && genericCompanyWords[words[0]]) {
+		words = all
+	}
+	return strings.Join(words, " ")
+}
+
+// locationsCompatible reports whether two locations could be the same posting:
+// either is unknown, or they share a word ("Jakarta" and "Jakarta Selatan")
+func locationsCompatible(a, b string) bool {
+	wa, wb := wordSet(a), wordSet(b)
+	if len(wa) == 0 || len(wb) == 0 {
+		return true
+	}
+	for w := range wa {
+		if wb[w] {
+			return true
+		}
+	}
+	return false
+}
+
+func wordSet(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range splitWords(s) {
+		set[w] = true
+	}
+	return set
+}
+
+func splitWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// jaccard returns the word overlap of two sets (intersection over union)
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+func hasLink(links []models.JobLink, url string) bool {
+	for _, l := range links {
+		if l.URL == url {
+			return true
+		}
+	}
+	return false
+}
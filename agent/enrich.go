@@ -0,0 +1,100 @@
+package agent
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// CompanyCache stores researched company information keyed by normalized company name
+type CompanyCache interface {
+	// GetCachedCompanies returns the unexpired entries for the given keys; missing keys are omitted
+	GetCachedCompanies(ctx context.Context, keys []string) (map[string]models.Company, error)
+	// CacheCompanies stores company information by key for the given TTL
+	CacheCompanies(ctx context.Context, companies map[string]models.Company, ttl time.Duration) error
+}
+
+// enrichCompanies attaches company information to ranked jobs, researching companies not in the cache.
+// Returns how many jobs were enriched; research failures leave a job without company info.
+func (a *JobAgent) enrichCompanies(ctx context.Context, jobs []models.RankedJob) int {
+	// One lookup per company, using the location of its first posting to disambiguate
+	locations := make(map[string]string)
+	names := make(map[string]string)
+	keys := make([]string, 0)
+	for _, job := range jobs {
+		key := normalizeCompany(job.Company)
+		if key == "" {
+			continue
+		}
+		if _, ok := names[key]; !ok {
+			names[key] = strings.TrimSpace(job.Company)
+			locations[key] = job.Location
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return 0
+	}
+
+	companies := make(map[string]models.Company, len(keys))
+	if a.companyCache != nil {
+		cached, err := a.companyCache.GetCachedCompanies(ctx, keys)
+		if err != nil {
+			agentLog.WarnContext(ctx, "Company cache lookup failed", "error", err)
+		}
+		for key, company := range cached {
+			companies[key] = company
+		}
+	}
+
+	// Research the remaining companies concurrently
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, a.maxConcurrent)
+	researched := make(map[string]models.Company)
+	for _, key := range keys {
+		if _, ok := companies[key]; ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			company, err := a.companyTool.Research(ctx, names[key], locations[key])
+			if err != nil {
+				agentLog.WarnContext(ctx, "Failed to research company", "company", names[key], "error", err)
+				return
+			}
+
+			mu.Lock()
+			researched[key] = *company
+			mu.Unlock()
+		}(key)
+	}
+	wg.Wait()
+
+	for key, company := range researched {
+		companies[key] = company
+	}
+	if a.companyCache != nil && len(researched) > 0 {
+		if err := a.companyCache.CacheCompanies(ctx, researched, a.companyTTL); err != nil {
+			agentLog.WarnContext(ctx, "Failed to cache companies", "error", err)
+		}
+	}
+
+	enriched := 0
+	for i := range jobs {
+		if company, ok := companies[normalizeCompany(jobs[i].Company)]; ok {
+			jobs[i].CompanyInfo = &company
+			enriched++
+		}
+	}
+	return enriched
+}
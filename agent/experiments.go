@@ -0,0 +1,42 @@
+package agent
+
+import (
+	"math/rand/v2"
+	"strings"
+
+	"github.com/myjobmatch/backend/gemini"
+)
+
+// experimentVariants keeps the configured prompt experiment variants that are defined, logging the others.
+// An experiment needs at least two variants to compare, so fewer disables it.
+func experimentVariants(names []string) []string {
+	var variants []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !gemini.IsPromptVariant(name) {
+			agentLog.Warn("Ignoring unknown prompt variant", "variant", name, "defined", gemini.PromptVariants())
+			continue
+		}
+		if !seen[name] {
+			seen[name] = true
+			variants = append(variants, name)
+		}
+	}
+	if len(variants) < 2 {
+		if len(names) > 0 {
+			agentLog.Warn("Prompt experiment disabled: needs at least two variants", "variants", variants)
+		}
+		return nil
+	}
+	agentLog.Info("Prompt experiment running", "variants", variants)
+	return variants
+}
+
+// assignPromptVariant picks the prompt variant for a search uniformly at random, or "" when no experiment is running
+func (a *JobAgent) assignPromptVariant() string {
+	if len(a.promptVariants) == 0 {
+		return ""
+	}
+	return a.promptVariants[rand.IntN(len(a.promptVariants))]
+}
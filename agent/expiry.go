@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"strings"
+	"time"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// closedPostingMarkers are phrases job pages show once a posting stops accepting applications
+var closedPostingMarkers = []string{
+	"no longer accepting applications",
+	"this job has expired",
+	"this job is no longer available",
+	"this position has been filled",
+	"this job posting has closed",
+	"applications are closed",
+	"job is closed",
+	"lowongan ini sudah ditutup",
+	"lowongan ini telah ditutup",
+	"lowongan sudah tidak tersedia",
+	"lowongan kerja ini sudah kedaluwarsa",
+}
+
+// hasClosedMarker reports whether page text says the posting no longer accepts applications
+func hasClosedMarker(page string) bool {
+	lower := strings.ToLower(page)
+	for _, marker := range closedPostingMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExpired reports whether a posting is closed, past its application deadline, or posted longer ago than maxAge
+// (0 = no age limit). Postings without a recognizable date are kept.
+func isExpired(job *models.JobPosting, maxAge time.Duration, now time.Time) bool {
+	if job.Expired {
+		return true
+	}
+	if deadline, err := time.Parse("2006-01-02", job.ValidThrough); err == nil && now.After(deadline.AddDate(0, 0, 1)) {
+		return true
+	}
+	if maxAge > 0 {
+		if posted, ok := utils.ParsePostedDate(job.DatePosted, now); ok && now.Sub(posted) > maxAge {
+			return true
+		}
+	}
+	return false
+}
+
+// dropExpiredJobs removes expired postings, returning the kept postings and how many were dropped
+func (a *JobAgent) dropExpiredJobs(jobs []models.JobPosting) ([]models.JobPosting, int) {
+	now := time.Now()
+	maxAge := time.Duration(a.cfg.JobMaxAgeDays) * 24 * time.Hour
+
+	kept := make([]models.JobPosting, 0, len(jobs))
+	for i := range jobs {
+		if !isExpired(&jobs[i], maxAge, now) {
+			kept = append(kept, jobs[i])
+		}
+	}
+	return kept, len(jobs) - len(kept)
+}
@@ -0,0 +1,55 @@
+package agent
+
+import (
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// maxFeedbackExamplesPerRating bounds how many rated jobs of each kind are added to scoring prompts
+const maxFeedbackExamplesPerRating = 5
+
+// feedbackExamples picks the most recent positive and negative ratings to use as scoring examples.
+// feedback must be ordered most recent first; already-applied jobs count as positive examples.
+func feedbackExamples(feedback []models.JobFeedback) []models.JobFeedback {
+	examples := make([]models.JobFeedback, 0, 2*maxFeedbackExamplesPerRating)
+	positive, negative := 0, 0
+	for _, f := range feedback {
+		switch {
+		case f.Rating == models.FeedbackNotRelevant && negative < maxFeedbackExamplesPerRating:
+			negative++
+		case f.Rating != models.FeedbackNotRelevant && positive < maxFeedbackExamplesPerRating:
+			positive++
+		default:
+			continue
+		}
+		examples = append(examples, f)
+	}
+	return examples
+}
+
+// excludeRatedJobs drops postings the user marked as not relevant or already applied to,
+// returning the remaining postings and how many were dropped
+func excludeRatedJobs(jobs []models.JobPosting, feedback []models.JobFeedback) ([]models.JobPosting, int) {
+	hidden := make(map[string]bool)
+	for _, f := range feedback {
+		if f.Rating == models.FeedbackNotRelevant || f.Rating == models.FeedbackAlreadyApplied {
+			hidden[f.JobID] = true
+		}
+	}
+	if len(hidden) == 0 {
+		return jobs, 0
+	}
+
+	kept := make([]models.JobPosting, 0, len(jobs))
+	for _, job := range jobs {
+		id := job.ID
+		if id == "" && job.URL != "" {
+			id = utils.JobID(job.URL)
+		}
+		if hidden[id] {
+			continue
+		}
+		kept = append(kept, job)
+	}
+	return kept, len(jobs) - len(kept)
+}
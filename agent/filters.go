@@ -0,0 +1,161 @@
+package agent
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// negativeTermPattern matches -"a phrase" or -word at the start of a query token
+var negativeTermPattern = regexp.MustCompile(`(?:^|\s)-(?:"([^"]+)"|([^\s"-][^\s"]*))`)
+
+// normalizeSalary fills the numeric salary fields and period from the free-text salary, if not already set
+func normalizeSalary(job *models.JobPosting) {
+	if job.Salary == "" {
+		return
+	}
+	if job.SalaryMin == 0 && job.SalaryMax == 0 {
+		if min, max, currency, ok := utils.ParseSalary(job.Salary); ok {
+			job.SalaryMin = min
+			job.SalaryMax = max
+			job.SalaryCurrency = currency
+		}
+	}
+	if job.SalaryPeriod == "" {
+		job.SalaryPeriod = utils.ParseSalaryPeriod(job.Salary, job.SalaryMin, job.SalaryCurrency)
+	}
+}
+
+// effectiveMinScore returns the request's minimum match score, clamped to 0-100, or the default
+func effectiveMinScore(filters models.JobSearchFilter) int {
+	if filters.MinScore == nil {
+		return defaultMinMatchScore
+	}
+	switch score := *filters.MinScore; {
+	case score < 0:
+		return 0
+	case score > 100:
+		return 100
+	default:
+		return score
+	}
+}
+
+// applyFilters drops postings whose extracted details don't match the request's filters:
+// excluded companies or keywords, salary outside the requested range, or posted before the date_posted window. Postings missing
+// a detail are kept, since extraction often can't find it. Returns the kept postings and how
+// many were dropped.
+func applyFilters(jobs []models.JobPosting, filters models.JobSearchFilter) ([]models.JobPosting, int) {
+	now := time.Now()
+	maxAge, filterByDate := utils.PostedWithin(filters.DatePosted)
+
+	excluded := make([]string, 0, len(filters.ExcludeCompanies))
+	for _, company := range filters.ExcludeCompanies {
+		if name := normalizeCompany(company); name != "" {
+			excluded = append(excluded, name)
+		}
+	}
+
+	keywords := make([]string, 0, len(filters.ExcludeKeywords))
+	for _, keyword := range filters.ExcludeKeywords {
+		if words := strings.Join(splitWords(keyword), " "); words != "" {
+			keywords = append(keywords, words)
+		}
+	}
+
+	kept := make([]models.JobPosting, 0, len(jobs))
+	for _, job := range jobs {
+		if isExcludedCompany(job.Company, excluded) || mentionsKeyword(&job, keywords) {
+			continue
+		}
+
+		normalizeSalary(&job)
+
+		if (filters.MinSalary > 0 || filters.MaxSalary > 0) &&
+			!utils.SalaryInRange(job.SalaryMin, job.SalaryMax, job.SalaryCurrency, job.SalaryPeriod, filters.MinSalary, filters.MaxSalary, filters.Currency) {
+			continue
+		}
+
+		if filterByDate {
+			if posted, ok := utils.ParsePostedDate(job.DatePosted, now); ok && now.Sub(posted) > maxAge {
+				continue
+			}
+		}
+
+		kept = append(kept, job)
+	}
+	return kept, len(jobs) - len(kept)
+}
+
+// wantsRemote reports whether the filters or profile ask for WFH postings
+func wantsRemote(profile *models.UserProfile, filters models.JobSearchFilter) bool {
+	modes := filters.RemoteModes
+	if len(modes) == 0 && profile != nil {
+		modes = profile.PreferredRemoteModes
+	}
+	for _, mode := range modes {
+		if models.NormalizeSiteSetting(mode) == models.SiteSettingWFH {
+			return true
+		}
+	}
+	return false
+}
+
+// isExcludedCompany reports whether a posting's company matches one of the normalized excluded names.
+// A match is the excluded name appearing as whole words, so "Gojek" also hides "PT GoTo Gojek Tokopedia".
+func isExcludedCompany(company string, excluded []string) bool {
+	if len(excluded) == 0 {
+		return false
+	}
+
+	name := " " + normalizeCompany(company) + " "
+	for _, e := range excluded {
+		if strings.Contains(name, " "+e+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// mentionsKeyword reports whether a posting's title, company, description, requirements or tags
+// contain one of the normalized keywords as whole words
+func mentionsKeyword(job *models.JobPosting, keywords []string) bool {
+	if len(keywords) == 0 {
+		return false
+	}
+
+	fields := []string{job.Title, job.Company, job.Description, job.Requirements}
+	fields = append(fields, job.Tags...)
+	text := " " + strings.Join(splitWords(strings.Join(fields, " ")), " ") + " "
+
+	for _, keyword := range keywords {
+		if strings.Contains(text, " "+keyword+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// splitNegativeTerms removes "-term" and -"some phrase" tokens from a query, returning the cleaned
+// query and the excluded terms, so "golang developer -gambling -crypto" excludes gambling and crypto
+func splitNegativeTerms(query string) (string, []string) {
+	matches := negativeTermPattern.FindAllStringSubmatch(query, -1)
+	if len(matches) == 0 {
+		return query, nil
+	}
+
+	terms := make([]string, 0, len(matches))
+	for _, m := range matches {
+		term := m[1]
+		if term == "" {
+			term = m[2]
+		}
+		terms = append(terms, term)
+	}
+
+	cleaned := strings.Join(strings.Fields(negativeTermPattern.ReplaceAllString(query, " ")), " ")
+	return cleaned, terms
+}
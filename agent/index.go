@@ -0,0 +1,96 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"github.com/myjobmatch/backend/matching"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// maxIndexedJobs bounds how many stored postings a search reads from the job index
+const maxIndexedJobs = 200
+
+// JobIndex is the local store of postings gathered by the crawler and earlier searches
+type JobIndex interface {
+	// SearchJobIndex returns up to limit postings seen since the given time that have any of the keywords
+	SearchJobIndex(ctx context.Context, keywords []string, since time.Time, limit int) ([]models.JobPosting, error)
+}
+
+// seniorityWords are title words too generic to look postings up by
+var seniorityWords = map[string]bool{
+	"senior": true, "junior": true, "mid": true, "entry": true, "level": true,
+	"staff": true, "principal": true, "sr": true, "jr": true,
+}
+
+// lookupIndexedJobs reads stored postings matching the profile's roles and skills.
+// It returns the postings and how many of them pass the filters with a heuristic score at or above
+// the minimum score, which decides whether live search is still needed.
+func (a *JobAgent) lookupIndexedJobs(ctx context.Context, profile *models.UserProfile, filters models.JobSearchFilter) ([]models.JobPosting, int) {
+	if a.jobIndex == nil {
+		return nil, 0
+	}
+
+	since := time.Now().Add(-time.Duration(a.cfg.JobIndexMaxAgeHours) * time.Hour)
+	jobs, err := a.jobIndex.SearchJobIndex(ctx, profileIndexKeywords(profile), since, maxIndexedJobs)
+	if err != nil {
+		// The index is an optimization; fall back to live search
+		agentLog.WarnContext(ctx, "Job index lookup failed", "error", err)
+		return nil, 0
+	}
+
+	kept, _ := applyFilters(jobs, filters)
+	minScore := effectiveMinScore(filters)
+	relevant := 0
+	for i := range kept {
+		if matching.Score(profile, &kept[i]).Score >= minScore {
+			relevant++
+		}
+	}
+	agentLog.InfoContext(ctx, "Looked up job index", "postings", len(jobs), "likely_matches", relevant)
+
+	return jobs, relevant
+}
+
+// profileIndexKeywords returns the role and skill words a profile's postings are looked up by
+func profileIndexKeywords(profile *models.UserProfile) []string {
+	texts := append([]string{profile.Title}, profile.PreferredRoles...)
+	texts = append(texts, profile.Skills...)
+
+	keywords := make([]string, 0)
+	for _, keyword := range models.IndexKeywords(texts...) {
+		if !seniorityWords[keyword] {
+			keywords = append(keywords, keyword)
+		}
+	}
+	return keywords
+}
+
+// CrawlJobs searches, fetches and extracts postings for a role and location without scoring them,
+// for the background crawler to store in the job index. Postings are deduplicated and given stable IDs.
+func (a *JobAgent) CrawlJobs(ctx context.Context, role, location string, maxPages int) ([]models.JobPosting, error) {
+	var filters models.JobSearchFilter
+	if location != "" {
+		filters.Locations = []string{location}
+	}
+	profile := models.NewUserProfileFromQuery(role, filters)
+	profile.PreferredRoles = []string{role}
+
+	var stats SearchStats
+	jobs, err := a.collectJobs(ctx, &profile, role, []string{role}, filters, maxPages, &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, _ = dedupeJobs(jobs)
+	for i := range jobs {
+		if jobs[i].ID == "" && jobs[i].URL != "" {
+			jobs[i].ID = utils.JobID(jobs[i].URL)
+		}
+	}
+	agentLog.InfoContext(ctx, "Crawled postings", "postings", len(jobs), "role", role, "location", location,
+		"pages_fetched", stats.PagesFetched, "cache_hits", stats.CacheHits)
+
+	return jobs, nil
+}
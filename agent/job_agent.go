@@ -2,18 +2,27 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/myjobmatch/backend/config"
 	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/matching"
 	"github.com/myjobmatch/backend/models"
 	"github.com/myjobmatch/backend/tools"
+	"github.com/myjobmatch/backend/utils"
 )
 
+var agentLog = logging.Component("Agent")
+
+// ErrPageUnavailable is returned when a posting page could not be fetched
+var ErrPageUnavailable = errors.New("job page could not be fetched")
+
 // JobAgent orchestrates the job search process using MCP tools
 type JobAgent struct {
 	cfg           *config.Config
@@ -23,17 +32,37 @@ type JobAgent struct {
 	extractTool   *tools.ExtractJobTool
 	scoreTool     *tools.ScoreJobTool
 	parseCVTool   *tools.ParseCVTool
+	atsTool       *tools.ATSBoardsTool
+	remoteTool    *tools.RemoteBoardsTool
+	tailorTool    *tools.TailorCVTool
+	companyTool   *tools.CompanyResearchTool
 	toolRegistry  *tools.ToolRegistry
+	jobCache      JobCache
+	jobIndex      JobIndex
+	jobStore      JobStore // Stores every extracted posting (nil = not stored)
+	sessionStore  SearchSessionStore
+	sessionTTL    time.Duration
+	jobCacheTTL   time.Duration
+	companyCache  CompanyCache
+	companyTTL    time.Duration
+	vectors       *matching.VectorIndex // Embedding cache for pre-ranking (nil unless embeddings are enabled)
 	maxConcurrent int
+
+	promptVariants []string // Prompt experiment variants searches are assigned to (empty when no experiment is running)
 }
 
-// NewJobAgent creates a new job search agent
-func NewJobAgent(ctx context.Context, cfg *config.Config) (*JobAgent, error) {
+// NewJobAgent creates a new job search agent.
+// jobCache may be nil to always fetch and extract pages, jobIndex nil to always search live,
+// companyCache nil to research companies on every search, and debugStore nil to not record Gemini calls.
+func NewJobAgent(ctx context.Context, cfg *config.Config, jobCache JobCache, jobIndex JobIndex, companyCache CompanyCache, debugStore gemini.DebugStore) (*JobAgent, error) {
 	// Initialize Gemini client
 	geminiClient, err := gemini.NewClient(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
 	}
+	if debugStore != nil {
+		geminiClient.SetDebugStore(debugStore, time.Duration(cfg.LLMDebugLogTTLHours)*time.Hour)
+	}
 
 	// Initialize tools
 	searchTool := tools.NewSearchWebTool(cfg)
@@ -41,6 +70,10 @@ func NewJobAgent(ctx context.Context, cfg *config.Config) (*JobAgent, error) {
 	extractTool := tools.NewExtractJobTool(geminiClient)
 	scoreTool := tools.NewScoreJobTool(geminiClient)
 	parseCVTool := tools.NewParseCVTool(geminiClient)
+	atsTool := tools.NewATSBoardsTool(cfg)
+	remoteTool := tools.NewRemoteBoardsTool(cfg)
+	tailorTool := tools.NewTailorCVTool(geminiClient)
+	companyTool := tools.NewCompanyResearchTool(searchTool, geminiClient)
 
 	// Register tools
 	registry := tools.NewToolRegistry()
@@ -49,6 +82,21 @@ func NewJobAgent(ctx context.Context, cfg *config.Config) (*JobAgent, error) {
 	registry.Register(extractTool)
 	registry.Register(scoreTool)
 	registry.Register(parseCVTool)
+	registry.Register(atsTool)
+	registry.Register(remoteTool)
+	registry.Register(tailorTool)
+	registry.Register(companyTool)
+	if err := registry.SetTimeouts(cfg); err != nil {
+		return nil, fmt.Errorf("failed to configure tool timeouts: %w", err)
+	}
+	if err := registry.SetCaches(cfg); err != nil {
+		return nil, fmt.Errorf("failed to configure tool caches: %w", err)
+	}
+
+	var vectors *matching.VectorIndex
+	if cfg.EmbeddingsEnabled {
+		vectors = matching.NewVectorIndex(cfg.EmbeddingIndexSize)
+	}
 
 	return &JobAgent{
 		cfg:           cfg,
@@ -58,8 +106,20 @@ func NewJobAgent(ctx context.Context, cfg *config.Config) (*JobAgent, error) {
 		extractTool:   extractTool,
 		scoreTool:     scoreTool,
 		parseCVTool:   parseCVTool,
+		atsTool:       atsTool,
+		remoteTool:    remoteTool,
+		tailorTool:    tailorTool,
+		companyTool:   companyTool,
 		toolRegistry:  registry,
+		jobCache:      jobCache,
+		jobIndex:      jobIndex,
+		jobCacheTTL:   time.Duration(cfg.JobCacheTTLHours) * time.Hour,
+		companyCache:  companyCache,
+		companyTTL:    time.Duration(cfg.CompanyCacheTTLDays) * 24 * time.Hour,
+		vectors:       vectors,
 		maxConcurrent: 5, // Max concurrent page fetches
+
+		promptVariants: experimentVariants(cfg.PromptExperimentVariants),
 	}, nil
 }
 
@@ -68,13 +128,27 @@ func (a *JobAgent) Close() error {
 	return a.geminiClient.Close()
 }
 
+// defaultMinMatchScore is the minimum match score returned when the request doesn't set one
+const defaultMinMatchScore = 50
+
+// jobsToScorePerPage bounds how many postings are scored per page the search may process
+const jobsToScorePerPage = 3
+
 // SearchJobsInput represents the input for the job search process
 type SearchJobsInput struct {
+	Profile    *models.UserProfile    `json:"-"` // Saved structured profile (skips CV parsing)
 	CVText     string                 `json:"cv_text,omitempty"`
 	CVFileData []byte                 `json:"-"` // PDF/DOC file bytes
 	CVFileName string                 `json:"-"` // Original filename
 	Query      string                 `json:"query,omitempty"`
 	Filters    models.JobSearchFilter `json:"filters,omitempty"`
+	Budget     *models.LLMBudget      `json:"budget,omitempty"`               // Optional caps on Gemini usage
+	Incognito  bool                   `json:"incognito,omitempty"`            // Redact name, email and phone before the profile is sent to the model
+	MaxResults int                    `json:"max_results,omitempty"`          // Results to return (0 = server default, capped by MAX_JOB_RESULTS)
+	MaxPages   int                    `json:"max_pages_to_process,omitempty"` // Pages to extract (0 = server default, capped by MAX_PAGES_TO_PROCESS)
+	Feedback   []models.JobFeedback   `json:"-"`                              // User's ratings of earlier matches, most recent first
+	Model      string                 `json:"model,omitempty"`                // Gemini model for this search (empty = GEMINI_MODEL); check with ValidateModel
+	UserID     string                 `json:"-"`                              // Owner of the recorded search session (empty = anonymous)
 }
 
 // SearchJobsOutput represents the output of the job search process
@@ -82,100 +156,133 @@ type SearchJobsOutput struct {
 	Results []models.RankedJob  `json:"results"`
 	Profile *models.UserProfile `json:"profile,omitempty"`
 	Stats   SearchStats         `json:"stats"`
+	Usage   models.LLMUsage     `json:"usage"`
+
+	// Prompt experiment variant the search was assigned to (empty when no experiment is running)
+	PromptVariant string `json:"prompt_variant,omitempty"`
+
+	// SessionID is the recorded search session (empty when sessions are not recorded)
+	SessionID string `json:"session_id,omitempty"`
 }
 
 // SearchStats provides statistics about the search
-type SearchStats struct {
-	URLsFound     int `json:"urls_found"`
-	PagesFetched  int `json:"pages_fetched"`
-	JobsExtracted int `json:"jobs_extracted"`
-	JobsScored    int `json:"jobs_scored"`
-	JobsReturned  int `json:"jobs_returned"`
-	FetchErrors   int `json:"fetch_errors"`
-	ExtractErrors int `json:"extract_errors"`
-}
+type SearchStats = models.SearchStats
 
 // SearchJobs performs the complete job search flow
 func (a *JobAgent) SearchJobs(ctx context.Context, input SearchJobsInput) (*SearchJobsOutput, error) {
-	log.Printf("[Agent] Starting job search with query=%q, hasCVText=%v, hasCVFile=%v",
-		input.Query, input.CVText != "", len(input.CVFileData) > 0)
+	agentLog.InfoContext(ctx, "Starting job search", "query", input.Query,
+		"has_cv_text", input.CVText != "", "has_cv_file", len(input.CVFileData) > 0)
+	startedAt := time.Now()
+
+	// Negative terms typed into the query ("golang -gambling") become keyword exclusions
+	if query, negatives := splitNegativeTerms(input.Query); len(negatives) > 0 {
+		input.Query = query
+		input.Filters.ExcludeKeywords = append(append([]string{}, input.Filters.ExcludeKeywords...), negatives...)
+	}
+
+	// Track (and optionally cap) Gemini usage for this search
+	var limits models.LLMBudget
+	if input.Budget != nil {
+		limits = *input.Budget
+	}
+	budget := gemini.NewBudget(limits)
+	ctx = gemini.WithBudget(ctx, budget)
+	ctx = gemini.WithModel(ctx, input.Model)
+	variant := a.assignPromptVariant()
+	ctx = gemini.WithPromptVariant(ctx, variant)
 
 	var profile *models.UserProfile
 	var err error
 
 	// Step 1: Build user profile based on input mode
+	utils.ReportProgress(ctx, "Building profile")
 	profile, err = a.buildUserProfile(ctx, input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build user profile: %w", err)
 	}
-	log.Printf("[Agent] Built user profile: skills=%v, locations=%v", profile.Skills, profile.PreferredLocations)
+	agentLog.DebugContext(ctx, "Built user profile", "skills", profile.Skills, "locations", profile.PreferredLocations)
 
 	// Determine the effective search query
 	effectiveQuery := input.Query
 	if effectiveQuery == "" {
 		effectiveQuery = profile.GenerateSearchQuery()
 	}
-	log.Printf("[Agent] Effective search query: %s", effectiveQuery)
+	agentLog.InfoContext(ctx, "Effective search query", "query", effectiveQuery)
 
-	// Step 2: Search for job URLs using PSE
-	searchResp, err := a.searchTool.SearchWithProfile(ctx, profile, effectiveQuery, input.Filters)
-	if err != nil {
-		return nil, fmt.Errorf("web search failed: %w", err)
-	}
-	log.Printf("[Agent] Found %d URLs from web search", len(searchResp.URLs))
+	maxPages, maxResults := a.searchLimits(input)
+	var stats SearchStats
+	var queries []string
+
+	// Step 1b: Start from postings already in the local index (crawled or returned by recent searches)
+	jobs, relevant := a.lookupIndexedJobs(ctx, profile, input.Filters)
+	stats.IndexedJobs = len(jobs)
 
-	stats := SearchStats{
-		URLsFound: len(searchResp.URLs),
+	// Steps 2-4b: Search, fetch and extract live postings unless the index already covers the request
+	if relevant >= maxResults {
+		agentLog.InfoContext(ctx, "Local index has enough relevant postings, skipping live search", "relevant", relevant)
+	} else {
+		queries = a.planQueries(ctx, profile, effectiveQuery)
+		live, err := a.collectJobs(ctx, profile, effectiveQuery, queries, input.Filters, maxPages, &stats)
+		if err != nil {
+			return nil, err
+		}
+		jobs = appendUniqueJobs(jobs, live)
 	}
 
-	if len(searchResp.URLs) == 0 {
-		return &SearchJobsOutput{
-			Results: []models.RankedJob{},
-			Profile: profile,
-			Stats:   stats,
-		}, nil
+	// Step 4c: Merge the same role posted on several portals so it is scored and returned once
+	jobs, stats.DuplicatesMerged = dedupeJobs(jobs)
+	if stats.DuplicatesMerged > 0 {
+		agentLog.InfoContext(ctx, "Merged duplicate postings", "postings", stats.DuplicatesMerged)
 	}
 
-	// Step 3: Fetch pages concurrently
-	fetchedPages := a.fetchPagesConcurrently(ctx, searchResp.URLs)
-	stats.PagesFetched = len(fetchedPages)
-	log.Printf("[Agent] Fetched %d pages", len(fetchedPages))
+	// Drop postings that stopped accepting applications or are too old, including cached and indexed ones
+	jobs, stats.ExpiredJobs = a.dropExpiredJobs(jobs)
+	if stats.ExpiredJobs > 0 {
+		agentLog.InfoContext(ctx, "Dropped expired postings", "postings", stats.ExpiredJobs)
+	}
 
-	// Count fetch errors
-	for _, page := range fetchedPages {
-		if page.Error != "" {
-			stats.FetchErrors++
-		}
+	// Step 4d: Drop postings whose extracted details fall outside the filters (e.g. salary range)
+	jobs, stats.FilteredOut = applyFilters(jobs, input.Filters)
+	if stats.FilteredOut > 0 {
+		agentLog.InfoContext(ctx, "Filtered out postings not matching the search filters", "postings", stats.FilteredOut)
 	}
 
-	// Step 4: Extract jobs from HTML concurrently
-	jobs := a.extractJobsConcurrently(ctx, fetchedPages)
-	stats.JobsExtracted = len(jobs)
-	log.Printf("[Agent] Extracted %d jobs", len(jobs))
+	// Step 4e: Hide postings the user already rated as not relevant or applied to
+	jobs, stats.HiddenByFeedback = excludeRatedJobs(jobs, input.Feedback)
+	if stats.HiddenByFeedback > 0 {
+		agentLog.InfoContext(ctx, "Hid postings based on user feedback", "postings", stats.HiddenByFeedback)
+	}
 
 	if len(jobs) == 0 {
-		return &SearchJobsOutput{
-			Results: []models.RankedJob{},
-			Profile: profile,
-			Stats:   stats,
-		}, nil
+		output := &SearchJobsOutput{
+			Results:       []models.RankedJob{},
+			Profile:       profile,
+			Stats:         stats,
+			Usage:         budget.Usage(),
+			PromptVariant: variant,
+		}
+		a.recordSearchSession(ctx, input, effectiveQuery, queries, output, startedAt)
+		return output, nil
 	}
 
-	maxJobsToScore := 30
+	// Leave room for cached and ATS postings beyond the extracted pages
+	maxJobsToScore := maxPages * jobsToScorePerPage
 	if len(jobs) > maxJobsToScore {
-		log.Printf("[Agent] Limiting jobs to score from %d to %d", len(jobs), maxJobsToScore)
-		jobs = jobs[:maxJobsToScore]
+		// Pre-rank so the LLM scores the most promising postings
+		agentLog.InfoContext(ctx, "Pre-ranking and limiting jobs to score", "jobs", len(jobs), "limit", maxJobsToScore)
+		jobs = a.preRankJobs(ctx, profile, jobs, maxJobsToScore)
 	}
 
 	// Step 5: Score jobs against profile concurrently
-	rankedJobs := a.scoreJobsConcurrently(ctx, profile, jobs)
+	rankedJobs := a.scoreJobsConcurrently(ctx, profile, jobs, feedbackExamples(input.Feedback))
 	stats.JobsScored = len(rankedJobs)
-	log.Printf("[Agent] Scored %d jobs", len(rankedJobs))
+	agentLog.InfoContext(ctx, "Scored jobs", "jobs", len(rankedJobs))
 
-	// Step 6: Filter jobs with match score >= 50
+	// Step 6: Filter jobs below the minimum match score
+	minScore := effectiveMinScore(input.Filters)
 	filteredJobs := make([]models.RankedJob, 0, len(rankedJobs))
 	for _, job := range rankedJobs {
-		if job.MatchScore >= 50 {
+		if job.MatchScore >= minScore {
 			filteredJobs = append(filteredJobs, job)
 		}
 	}
@@ -187,19 +294,127 @@ func (a *JobAgent) SearchJobs(ctx context.Context, input SearchJobsInput) (*Sear
 	})
 
 	// Limit to max results
-	maxResults := a.cfg.MaxJobResults
 	if len(rankedJobs) > maxResults {
 		rankedJobs = rankedJobs[:maxResults]
 	}
 	stats.JobsReturned = len(rankedJobs)
 
-	log.Printf("[Agent] Returning %d ranked jobs", len(rankedJobs))
+	// Step 7: Attach company information to the returned jobs
+	if a.cfg.CompanyEnrichmentEnabled {
+		stats.JobsEnriched = a.enrichCompanies(ctx, rankedJobs)
+		agentLog.InfoContext(ctx, "Enriched jobs with company info", "jobs", stats.JobsEnriched)
+	}
 
-	return &SearchJobsOutput{
-		Results: rankedJobs,
-		Profile: profile,
-		Stats:   stats,
-	}, nil
+	usage := budget.Usage()
+	agentLog.InfoContext(ctx, "Job search finished", "jobs", len(rankedJobs), "duration_ms", time.Since(startedAt).Milliseconds(),
+		"gemini_calls", usage.Calls, "tokens", usage.PromptTokens+usage.OutputTokens,
+		"estimated_cost_usd", usage.EstimatedCostUSD, "budget_exhausted", usage.BudgetExhausted)
+
+	output := &SearchJobsOutput{
+		Results:       rankedJobs,
+		Profile:       profile,
+		Stats:         stats,
+		Usage:         usage,
+		PromptVariant: variant,
+	}
+	a.recordSearchSession(ctx, input, effectiveQuery, queries, output, startedAt)
+	return output, nil
+}
+
+// collectJobs gathers live postings for the queries: web search, cached or freshly extracted pages,
+// and ATS and remote boards. Counts are recorded in stats. Fails only if every web search fails.
+func (a *JobAgent) collectJobs(ctx context.Context, profile *models.UserProfile, query string, queries []string, filters models.JobSearchFilter, maxPages int, stats *SearchStats) ([]models.JobPosting, error) {
+	// Step 2: Search for job URLs using PSE, fanning out to planned query variants
+	utils.ReportProgress(ctx, "Searching the web (%d queries)", len(queries))
+	searchResp, err := a.searchQueries(ctx, profile, queries, filters)
+	if err != nil {
+		return nil, fmt.Errorf("web search failed: %w", err)
+	}
+	agentLog.InfoContext(ctx, "Found URLs from web search", "urls", len(searchResp.URLs), "queries", len(queries))
+	utils.ReportProgress(ctx, "Found %d job URLs", len(searchResp.URLs))
+	stats.QueriesSearched = len(queries)
+	stats.URLsFound = len(searchResp.URLs)
+
+	// Step 3: Reuse postings extracted by recent searches
+	jobs, urlsToFetch := a.lookupCachedJobs(ctx, searchResp.URLs)
+	if jobs == nil {
+		jobs = make([]models.JobPosting, 0)
+	}
+	if a.jobCache != nil {
+		stats.CacheHits = len(jobs)
+		stats.CacheMisses = len(urlsToFetch)
+		agentLog.InfoContext(ctx, "Looked up job cache", "hits", stats.CacheHits, "misses", stats.CacheMisses)
+	}
+
+	if len(urlsToFetch) > 0 {
+		// Step 3b: Fetch pages concurrently
+		fetchedPages := a.fetchPagesConcurrently(ctx, urlsToFetch)
+		stats.PagesFetched = len(fetchedPages)
+		agentLog.InfoContext(ctx, "Fetched pages", "pages", len(fetchedPages))
+
+		// Count fetch errors
+		for _, page := range fetchedPages {
+			if page.Error != "" {
+				stats.FetchErrors++
+			}
+		}
+
+		// Step 4: Extract jobs from HTML concurrently
+		extracted, structured := a.extractJobsConcurrently(ctx, fetchedPages, maxPages)
+		stats.StructuredJobs = structured
+		agentLog.InfoContext(ctx, "Extracted jobs", "jobs", len(extracted), "without_gemini", structured)
+		a.cacheExtractedJobs(ctx, extracted)
+		a.storeExtractedJobs(ctx, extracted)
+		jobs = append(jobs, extracted...)
+	}
+	stats.JobsExtracted = len(jobs)
+
+	// Step 4b: Discover structured postings from ATS boards (no fetch/extraction needed)
+	utils.ReportProgress(ctx, "Searching job boards")
+	atsJobs, err := a.atsTool.SearchWithProfile(ctx, profile, query)
+	if err != nil {
+		agentLog.WarnContext(ctx, "ATS board discovery failed", "error", err)
+	}
+	stats.ATSJobsFound = len(atsJobs)
+	if len(atsJobs) > 0 {
+		agentLog.InfoContext(ctx, "Found jobs on ATS boards", "jobs", len(atsJobs))
+		a.storeExtractedJobs(ctx, atsJobs)
+		jobs = appendUniqueJobs(jobs, atsJobs)
+	}
+
+	// Remote-only boards are searched when the user wants WFH, since PSE indexes few of them
+	if wantsRemote(profile, filters) {
+		remoteJobs, err := a.remoteTool.SearchWithProfile(ctx, profile, query)
+		if err != nil {
+			agentLog.WarnContext(ctx, "Remote board discovery failed", "error", err)
+		}
+		stats.RemoteJobsFound = len(remoteJobs)
+		if len(remoteJobs) > 0 {
+			agentLog.InfoContext(ctx, "Found jobs on remote boards", "jobs", len(remoteJobs))
+			a.storeExtractedJobs(ctx, remoteJobs)
+			jobs = appendUniqueJobs(jobs, remoteJobs)
+		}
+	}
+
+	return jobs, nil
+}
+
+// searchLimits returns the number of pages to extract and results to return for a search,
+// applying server defaults and caps to the requested values
+func (a *JobAgent) searchLimits(input SearchJobsInput) (maxPages, maxResults int) {
+	maxPages = a.cfg.DefaultPagesToProcess
+	if input.MaxPages > 0 {
+		maxPages = input.MaxPages
+	}
+	if maxPages > a.cfg.MaxPagesToProcess {
+		maxPages = a.cfg.MaxPagesToProcess
+	}
+
+	maxResults = a.cfg.MaxJobResults
+	if input.MaxResults > 0 && input.MaxResults < maxResults {
+		maxResults = input.MaxResults
+	}
+	return maxPages, maxResults
 }
 
 // buildUserProfile builds a user profile based on input mode
@@ -207,41 +422,72 @@ func (a *JobAgent) buildUserProfile(ctx context.Context, input SearchJobsInput)
 	var profile *models.UserProfile
 	var err error
 
-	// Mode 1: PDF file provided - use Gemini multimodal to parse
-	if len(input.CVFileData) > 0 && isPDFFile(input.CVFileName) {
-		log.Printf("[Agent] Parsing PDF CV using Gemini multimodal: %s", input.CVFileName)
+	// Word and text files are converted to text so they go through CV text parsing (Mode 2)
+	if input.Profile == nil && len(input.CVFileData) > 0 && !isPDFFile(input.CVFileName) && input.CVText == "" {
+		agentLog.InfoContext(ctx, "Converting CV file to text", "file", input.CVFileName)
+		input.CVText, err = utils.ExtractCVText(input.CVFileName, input.CVFileData)
+		if err != nil {
+			return nil, fmt.Errorf("CV file conversion failed: %w", err)
+		}
+	}
+
+	// Mode 0: Saved structured profile provided - use it instead of re-parsing the CV
+	if input.Profile != nil {
+		agentLog.InfoContext(ctx, "Using saved structured profile")
+		saved := *input.Profile
+		profile = a.prepareProfile(ctx, &saved, input)
+
+		// If query is also provided, refine profile with query intent
+		if input.Query != "" {
+			agentLog.InfoContext(ctx, "Refining profile with query intent")
+			refined, err := a.geminiClient.RefineProfileWithQuery(ctx, profile, input.Query)
+			if err != nil {
+				agentLog.WarnContext(ctx, "Failed to refine profile with query", "error", err)
+			} else {
+				profile = refined
+			}
+		}
+	} else if len(input.CVFileData) > 0 && isPDFFile(input.CVFileName) {
+		// Mode 1: PDF file provided - use Gemini multimodal to parse
+		agentLog.InfoContext(ctx, "Parsing PDF CV using Gemini multimodal", "file", input.CVFileName)
 		profile, err = a.geminiClient.ParseCVFromPDF(ctx, input.CVFileData, input.CVFileName)
 		if err != nil {
 			return nil, fmt.Errorf("CV PDF parsing failed: %w", err)
 		}
+		profile = a.prepareProfile(ctx, profile, input)
 
 		// If query is also provided, refine profile with query intent
 		if input.Query != "" {
-			log.Printf("[Agent] Refining profile with query intent")
-			profile, err = a.geminiClient.RefineProfileWithQuery(ctx, profile, input.Query)
+			agentLog.InfoContext(ctx, "Refining profile with query intent")
+			refined, err := a.geminiClient.RefineProfileWithQuery(ctx, profile, input.Query)
 			if err != nil {
-				log.Printf("[Agent] Warning: failed to refine profile with query: %v", err)
+				agentLog.WarnContext(ctx, "Failed to refine profile with query", "error", err)
+			} else {
+				profile = refined
 			}
 		}
 	} else if input.CVText != "" {
 		// Mode 2: CV text provided
-		log.Printf("[Agent] Parsing CV text to build profile")
+		agentLog.InfoContext(ctx, "Parsing CV text to build profile")
 		profile, err = a.parseCVTool.ParseCV(ctx, input.CVText)
 		if err != nil {
 			return nil, fmt.Errorf("CV parsing failed: %w", err)
 		}
+		profile = a.prepareProfile(ctx, profile, input)
 
 		// If query is also provided, refine profile with query intent
 		if input.Query != "" {
-			log.Printf("[Agent] Refining profile with query intent")
-			profile, err = a.geminiClient.RefineProfileWithQuery(ctx, profile, input.Query)
+			agentLog.InfoContext(ctx, "Refining profile with query intent")
+			refined, err := a.geminiClient.RefineProfileWithQuery(ctx, profile, input.Query)
 			if err != nil {
-				log.Printf("[Agent] Warning: failed to refine profile with query: %v", err)
+				agentLog.WarnContext(ctx, "Failed to refine profile with query", "error", err)
+			} else {
+				profile = refined
 			}
 		}
 	} else if input.Query != "" {
 		// Mode 2: Only query provided
-		log.Printf("[Agent] Deriving profile from query")
+		agentLog.InfoContext(ctx, "Deriving profile from query")
 		profile, err = a.geminiClient.DeriveProfileFromQuery(ctx, input.Query)
 		if err != nil {
 			// Create minimal profile
@@ -258,6 +504,34 @@ func (a *JobAgent) buildUserProfile(ctx context.Context, input SearchJobsInput)
 	return profile, nil
 }
 
+// prepareProfile applies translation and, for incognito searches, redaction
+// to a parsed or saved profile before it is refined, searched and scored
+func (a *JobAgent) prepareProfile(ctx context.Context, profile *models.UserProfile, input SearchJobsInput) *models.UserProfile {
+	profile = a.translateProfile(ctx, profile)
+	if input.Incognito {
+		agentLog.InfoContext(ctx, "Incognito search: redacting personal details from profile")
+		profile = utils.RedactProfile(profile)
+	}
+	return profile
+}
+
+// translateProfile translates search terms of a non-English CV profile to English.
+// The original profile is returned if translation is disabled or fails.
+func (a *JobAgent) translateProfile(ctx context.Context, profile *models.UserProfile) *models.UserProfile {
+	lang := strings.ToLower(strings.TrimSpace(profile.Language))
+	if !a.cfg.CVTranslationEnabled || lang == "" || lang == "en" || profile.Translated {
+		return profile
+	}
+
+	agentLog.InfoContext(ctx, "Translating search terms to English", "language", lang)
+	translated, err := a.geminiClient.TranslateProfile(ctx, profile)
+	if err != nil {
+		agentLog.WarnContext(ctx, "Failed to translate profile", "error", err)
+		return profile
+	}
+	return translated
+}
+
 // fetchPagesConcurrently fetches multiple pages in parallel
 func (a *JobAgent) fetchPagesConcurrently(ctx context.Context, urls []string) []models.FetchPageResponse {
 	results := make([]models.FetchPageResponse, 0, len(urls))
@@ -294,17 +568,18 @@ func (a *JobAgent) fetchPagesConcurrently(ctx context.Context, urls []string) []
 	// Collect results
 	for resp := range resultsChan {
 		results = append(results, resp)
+		utils.ReportProgress(ctx, "%d/%d pages fetched", len(results), len(urls))
 	}
 
 	return results
 }
 
-// extractJobsConcurrently extracts jobs from HTML pages in parallel (max 10 jobs)
-func (a *JobAgent) extractJobsConcurrently(ctx context.Context, pages []models.FetchPageResponse) []models.JobPosting {
-	const maxJobsToExtract = 10
-
+// extractJobsConcurrently extracts jobs from up to maxJobsToExtract HTML pages in parallel.
+// Pages with a schema.org JobPosting in their JSON-LD or from a known job board are read directly;
+// the rest go to Gemini. It also returns how many jobs were read without Gemini.
+func (a *JobAgent) extractJobsConcurrently(ctx context.Context, pages []models.FetchPageResponse, maxJobsToExtract int) ([]models.JobPosting, int) {
 	jobs := make([]models.JobPosting, 0, maxJobsToExtract)
-	jobsChan := make(chan *models.JobPosting, len(pages))
+	jobsChan := make(chan []models.JobPosting, len(pages))
 
 	// Filter valid pages first
 	validPages := make([]models.FetchPageResponse, 0)
@@ -316,14 +591,22 @@ func (a *JobAgent) extractJobsConcurrently(ctx context.Context, pages []models.F
 
 	// Limit pages to process for performance
 	if len(validPages) > maxJobsToExtract {
-		log.Printf("[Agent] Limiting pages to extract from %d to %d", len(validPages), maxJobsToExtract)
+		agentLog.InfoContext(ctx, "Limiting pages to extract", "pages", len(validPages), "limit", maxJobsToExtract)
 		validPages = validPages[:maxJobsToExtract]
 	}
 
 	var wg sync.WaitGroup
+	structured := 0
 	sem := make(chan struct{}, a.maxConcurrent)
 
 	for _, page := range validPages {
+		if job := a.extractTool.ExtractStructured(page); job != nil {
+			structured++
+			job.Expired = job.Expired || hasClosedMarker(page.HTML)
+			jobsChan <- []models.JobPosting{*job}
+			continue
+		}
+
 		wg.Add(1)
 		go func(p models.FetchPageResponse) {
 			defer wg.Done()
@@ -331,14 +614,19 @@ func (a *JobAgent) extractJobsConcurrently(ctx context.Context, pages []models.F
 			sem <- struct{}{}
 			defer func() { <-sem }()
 
-			job, err := a.extractTool.ExtractFromHTML(ctx, p.HTML, p.URL)
+			extracted, err := a.extractTool.ExtractFromHTML(ctx, p.HTML, p.URL)
 			if err != nil {
-				log.Printf("[Agent] Failed to extract job from %s: %v", p.URL, err)
+				agentLog.WarnContext(ctx, "Failed to extract job", "url", p.URL, "error", err)
+				jobsChan <- nil // Still counts towards progress
 				return
 			}
-			if job != nil && job.Title != "" {
-				jobsChan <- job
+			// A closed marker on a listing page may belong to any one of its postings
+			if len(extracted) == 1 {
+				extracted[0].Expired = extracted[0].Expired || hasClosedMarker(p.HTML)
+			} else {
+				agentLog.InfoContext(ctx, "Extracted postings from listing page", "url", p.URL, "postings", len(extracted))
 			}
+			jobsChan <- extracted
 		}(page)
 	}
 
@@ -347,20 +635,25 @@ func (a *JobAgent) extractJobsConcurrently(ctx context.Context, pages []models.F
 		close(jobsChan)
 	}()
 
-	for job := range jobsChan {
-		if job != nil {
-			jobs = append(jobs, *job)
-		}
+	pagesDone := 0
+	for extracted := range jobsChan {
+		jobs = append(jobs, extracted...)
+		pagesDone++
+		utils.ReportProgress(ctx, "%d/%d pages extracted", pagesDone, len(validPages))
 	}
 
-	return jobs
+	return jobs, structured
 }
 
-// scoreJobsConcurrently scores jobs against profile in parallel
-func (a *JobAgent) scoreJobsConcurrently(ctx context.Context, profile *models.UserProfile, jobs []models.JobPosting) []models.RankedJob {
+// scoreJobsConcurrently scores jobs against profile in parallel, using rated jobs as examples of the user's preferences
+func (a *JobAgent) scoreJobsConcurrently(ctx context.Context, profile *models.UserProfile, jobs []models.JobPosting, feedback []models.JobFeedback) []models.RankedJob {
 	rankedJobs := make([]models.RankedJob, 0, len(jobs))
 	rankedChan := make(chan models.RankedJob, len(jobs))
 
+	// The profile and feedback are shared by every scoring call; the session sends them once
+	session := a.scoreTool.NewSession(ctx, profile, feedback, len(jobs))
+	defer session.Close(context.WithoutCancel(ctx))
+
 	var wg sync.WaitGroup
 	sem := make(chan struct{}, a.maxConcurrent)
 
@@ -372,19 +665,34 @@ func (a *JobAgent) scoreJobsConcurrently(ctx context.Context, profile *models.Us
 			sem <- struct{}{}
 			defer func() { <-sem }()
 
-			score, reason, err := a.scoreTool.ScoreJob(ctx, profile, &j)
-			if err != nil {
-				log.Printf("[Agent] Failed to score job %s: %v", j.Title, err)
-				// Default score if scoring fails
-				score = 50
-				reason = "Unable to calculate match score"
+			if j.ID == "" && j.URL != "" {
+				j.ID = utils.JobID(j.URL)
 			}
-
-			rankedChan <- models.RankedJob{
-				JobPosting:  j,
-				MatchScore:  score,
-				MatchReason: reason,
+			ranked := models.RankedJob{JobPosting: j}
+
+			heuristic := matching.Score(profile, &j)
+			ranked.HeuristicScore = heuristic.Score
+			ranked.SkillsMatch = heuristic.SkillsMatch
+
+			result, err := session.Score(ctx, &j)
+			budget := gemini.BudgetFromContext(ctx)
+			switch {
+			case err == nil:
+				ranked.AIScore = result.MatchScore
+				ranked.MatchScore = matching.Blend(result.MatchScore, heuristic.Score, a.cfg.ScoreAIWeight, a.cfg.ScoreHeuristicWeight)
+				ranked.MatchReason = result.MatchReason
+				ranked.ScoreMethod = models.ScoreMethodAI
+				ranked.ScoreBreakdown = result.Breakdown
+			case budget != nil && budget.Exhausted():
+				// Budget reached: score deterministically instead of calling Gemini
+				estimateScore(&ranked, heuristic, "AI scoring budget reached")
+				budget.RecordFallback()
+			default:
+				agentLog.WarnContext(ctx, "Failed to score job, using heuristic estimate", "job", j.Title, "error", err)
+				estimateScore(&ranked, heuristic, "AI scoring unavailable")
 			}
+
+			rankedChan <- ranked
 		}(job)
 	}
 
@@ -395,16 +703,146 @@ func (a *JobAgent) scoreJobsConcurrently(ctx context.Context, profile *models.Us
 
 	for ranked := range rankedChan {
 		rankedJobs = append(rankedJobs, ranked)
+		utils.ReportProgress(ctx, "%d/%d jobs scored", len(rankedJobs), len(jobs))
 	}
 
 	return rankedJobs
 }
 
+// BuildProfile builds a user profile from CV and/or query input without running a search
+func (a *JobAgent) BuildProfile(ctx context.Context, input SearchJobsInput) (*models.UserProfile, error) {
+	return a.buildUserProfile(gemini.WithModel(ctx, input.Model), input)
+}
+
+// GeminiClient returns the agent's Gemini client, for tools registered outside the agent
+func (a *JobAgent) GeminiClient() *gemini.Client {
+	return a.geminiClient
+}
+
+// ValidateModel checks that a model requested for a search is allowed (see GEMINI_ALLOWED_MODELS)
+func (a *JobAgent) ValidateModel(name string) error {
+	return a.geminiClient.ValidateModel(name)
+}
+
+// ScoreJobs scores jobs against a profile concurrently
+func (a *JobAgent) ScoreJobs(ctx context.Context, profile *models.UserProfile, jobs []models.JobPosting) []models.RankedJob {
+	return a.scoreJobsConcurrently(ctx, profile, jobs, nil)
+}
+
+// TailorCV rewrites the profile's summary and skills section for a job posting
+func (a *JobAgent) TailorCV(ctx context.Context, profile *models.UserProfile, job *models.JobPosting) (*models.TailoredCV, error) {
+	return a.tailorTool.TailorCV(ctx, profile, job)
+}
+
+// DiscoverCompanyJobs returns the current postings of a watched company,
+// read from its ATS board when configured or extracted from its careers page otherwise
+func (a *JobAgent) DiscoverCompanyJobs(ctx context.Context, company models.WatchedCompany) ([]models.JobPosting, error) {
+	if company.ATSProvider != "" && company.ATSToken != "" {
+		jobs, err := a.atsTool.FetchBoard(ctx, tools.ATSBoard{
+			Provider: company.ATSProvider,
+			Token:    company.ATSToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		a.storeExtractedJobs(ctx, jobs)
+		return jobs, nil
+	}
+
+	if company.CareersURL == "" {
+		return nil, fmt.Errorf("company %s has no careers URL or ATS board", company.CompanyName)
+	}
+
+	page, err := a.fetchTool.FetchURL(ctx, company.CareersURL)
+	if err != nil {
+		return nil, err
+	}
+	if page.Error != "" {
+		return nil, fmt.Errorf("failed to fetch careers page: %s", page.Error)
+	}
+
+	jobs, _ := a.extractJobsConcurrently(ctx, []models.FetchPageResponse{*page}, 1)
+	a.storeExtractedJobs(ctx, jobs)
+	return jobs, nil
+}
+
+// SummarizeJob condenses a posting into bullets for small screens. The page at pageURL is fetched
+// unless the posting's text is given as description.
+func (a *JobAgent) SummarizeJob(ctx context.Context, pageURL, description string) (*models.JobSummary, error) {
+	content := description
+	if content == "" {
+		page, err := a.fetchTool.FetchURL(ctx, pageURL)
+		if err != nil {
+			return nil, err
+		}
+		if page.Error != "" {
+			return nil, fmt.Errorf("%w: %s", ErrPageUnavailable, page.Error)
+		}
+		if page.HTML == "" {
+			return nil, fmt.Errorf("%w: empty page", ErrPageUnavailable)
+		}
+		content = page.HTML
+	}
+
+	return a.geminiClient.SummarizeJob(ctx, content, pageURL)
+}
+
+// SetToolAuditStore records the tool calls Gemini makes during agent search and chat to store, keeping records for ttl
+func (a *JobAgent) SetToolAuditStore(store tools.AuditStore, ttl time.Duration) {
+	a.toolRegistry.SetAuditStore(store, models.ToolAuditSourceAgent, ttl)
+}
+
+// SetToolResultCache keeps the agent's cached tool results (web search, page fetches) in a cache shared
+// across instances
+func (a *JobAgent) SetToolResultCache(shared tools.ResultCache) {
+	a.toolRegistry.SetSharedCache(shared)
+}
+
+// SetJobStore makes the agent store every posting it extracts in the jobs collection
+func (a *JobAgent) SetJobStore(store JobStore) {
+	a.jobStore = store
+}
+
+// SetSearchSessionStore makes the agent record every search as a session kept for ttl
+func (a *JobAgent) SetSearchSessionStore(store SearchSessionStore, ttl time.Duration) {
+	a.sessionStore = store
+	a.sessionTTL = ttl
+}
+
+// SetToolSwitches makes the agent's tool calls honor the runtime tool switches
+func (a *JobAgent) SetToolSwitches(switches *tools.ToolSwitches) {
+	a.toolRegistry.SetSwitches(switches)
+}
+
 // GetToolDefinitions returns the tool definitions for external use
 func (a *JobAgent) GetToolDefinitions() []map[string]interface{} {
 	return a.toolRegistry.GetToolDefinitions()
 }
 
+// estimateScore fills in the heuristic match score, noting why Gemini wasn't used
+func estimateScore(ranked *models.RankedJob, result matching.Result, why string) {
+	ranked.MatchScore = result.Score
+	ranked.MatchReason = fmt.Sprintf("Estimated (%s): %s", why, result.Reason)
+	ranked.ScoreMethod = models.ScoreMethodEstimated
+	ranked.ScoreBreakdown = &result.Breakdown
+}
+
+// appendUniqueJobs appends jobs whose URL is not already present
+func appendUniqueJobs(jobs []models.JobPosting, extra []models.JobPosting) []models.JobPosting {
+	seen := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		seen[job.URL] = true
+	}
+	for _, job := range extra {
+		if job.URL != "" && seen[job.URL] {
+			continue
+		}
+		seen[job.URL] = true
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
 // isPDFFile checks if the filename indicates a PDF file
 func isPDFFile(filename string) bool {
 	lower := strings.ToLower(filename)
@@ -0,0 +1,306 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/tools"
+)
+
+// maxToolResultChars bounds a tool result returned to the model; longer results are truncated
+const maxToolResultChars = 12000
+
+// maxPagePreviewChars bounds the page HTML shown to the model; the full page stays available to extraction
+const maxPagePreviewChars = 3000
+
+// orchestratedTools are the registered tools the model may call, in the order they are declared.
+// parse_cv is left out because the profile is built before the conversation starts.
+var orchestratedTools = []string{
+	"search_web_for_jobs",
+	"search_ats_boards",
+	"search_remote_boards",
+	"fetch_page_html",
+	"extract_job_from_html",
+	"score_job_match",
+	"company_research",
+	"tailor_cv",
+}
+
+// injectedParams are tool parameters filled in from the conversation instead of by the model
+var injectedParams = map[string]bool{"profile": true, "feedback": true}
+
+// orchestratorInstruction is the system instruction for orchestrated searches
+const orchestratorInstruction = `You are a job search assistant for a candidate in Indonesia. Answer the candidate's request by
+calling the available tools: search for postings (web search, company ATS boards, remote job boards), fetch
+and extract pages found by web search, score postings against the candidate and research companies.
+
+Work in few steps: search broadly first, then only fetch, extract and score the most promising postings.
+ATS and remote board results are already structured and can be scored directly. The candidate's profile is
+passed to scoring and tailoring tools automatically; do not include it in arguments. To extract a fetched
+page, pass only its url.
+
+When you have enough information, answer in the language of the request, in concise markdown. Mention
+each posting you recommend with its title, company, match score and URL. Never invent postings.
+
+CANDIDATE PROFILE:
+%s`
+
+// OrchestrateInput is the input for an orchestrated search
+type OrchestrateInput struct {
+	Search   SearchJobsInput // Request (Query), profile or CV, budget and feedback
+	MaxSteps int             // Model turns allowed (0 = server default, capped by AGENT_MAX_STEPS)
+}
+
+// OrchestrateOutput is the result of an orchestrated search
+type OrchestrateOutput struct {
+	Answer         string              `json:"answer"`
+	Steps          []models.AgentStep  `json:"steps"`
+	Profile        *models.UserProfile `json:"profile,omitempty"`
+	Usage          models.LLMUsage     `json:"usage"`
+	StepsExhausted bool                `json:"steps_exhausted"`
+}
+
+// orchestration holds the state of one orchestrated search
+type orchestration struct {
+	profile  *models.UserProfile
+	feedback []models.JobFeedback
+	pages    map[string]string // Fetched page HTML by URL, passed to extraction
+}
+
+// Orchestrate answers a request by letting Gemini decide which tools to call, in a loop bounded by a
+// step budget, instead of running the fixed search pipeline. This handles open-ended requests such as
+// "compare my fit for fintech vs e-commerce roles".
+func (a *JobAgent) Orchestrate(ctx context.Context, input OrchestrateInput) (*OrchestrateOutput, error) {
+	if input.Search.Query == "" {
+		return nil, fmt.Errorf("a request is required")
+	}
+	agentLog.InfoContext(ctx, "Starting orchestrated search", "query", input.Search.Query)
+
+	var limits models.LLMBudget
+	if input.Search.Budget != nil {
+		limits = *input.Search.Budget
+	}
+	budget := gemini.NewBudget(limits)
+	ctx = gemini.WithBudget(ctx, budget)
+
+	// The request is an instruction, not a search query, so it only shapes the profile when there is no CV
+	profileInput := input.Search
+	if profileInput.Profile != nil || profileInput.CVText != "" || len(profileInput.CVFileData) > 0 {
+		profileInput.Query = ""
+	}
+	profile, err := a.buildUserProfile(ctx, profileInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user profile: %w", err)
+	}
+
+	state := &orchestration{profile: profile, feedback: input.Search.Feedback, pages: make(map[string]string)}
+	profileJSON, _ := json.Marshal(profile)
+	chat := a.geminiClient.NewToolChat(fmt.Sprintf(orchestratorInstruction, profileJSON), a.orchestratedFunctions())
+
+	maxSteps := a.cfg.AgentMaxSteps
+	if input.MaxSteps > 0 && input.MaxSteps < maxSteps {
+		maxSteps = input.MaxSteps
+	}
+
+	output := &OrchestrateOutput{Profile: profile, Steps: make([]models.AgentStep, 0)}
+	answer, calls, err := chat.Send(ctx, input.Search.Query)
+	for step := 1; err == nil && len(calls) > 0; step++ {
+		results := a.runToolCalls(ctx, state, calls, output)
+
+		note := ""
+		if step >= maxSteps {
+			output.StepsExhausted = true
+			note = "The tool budget is used up. Answer now with the information you have; do not call more tools."
+		}
+		answer, calls, err = chat.SendResults(ctx, results, note)
+		if output.StepsExhausted {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("orchestration failed: %w", err)
+	}
+
+	output.Answer = answer
+	if output.Answer == "" && output.StepsExhausted {
+		output.Answer = "The step budget ran out before an answer was ready. Try a narrower request or more steps."
+	}
+	output.Usage = budget.Usage()
+	agentLog.InfoContext(ctx, "Orchestrated search finished", "tool_calls", len(output.Steps), "steps_exhausted", output.StepsExhausted)
+
+	return output, nil
+}
+
+// orchestratedFunctions declares the orchestrated tools to the model, without the injected parameters
+func (a *JobAgent) orchestratedFunctions() []gemini.FunctionDeclaration {
+	functions := make([]gemini.FunctionDeclaration, 0, len(orchestratedTools))
+	for _, name := range orchestratedTools {
+		tool, ok := a.toolRegistry.Get(name)
+		if !ok || !a.toolRegistry.Enabled(name) {
+			continue
+		}
+		functions = append(functions, gemini.FunctionDeclaration{
+			Name:        tool.Name(),
+			Description: tool.Description(),
+			Parameters:  modelParameters(tool.InputSchema()),
+		})
+	}
+	return functions
+}
+
+// modelParameters removes injected parameters from a tool's input schema, and makes extraction's HTML
+// optional since fetched pages are passed by URL
+func modelParameters(schema map[string]interface{}) map[string]interface{} {
+	properties, _ := schema["properties"].(map[string]interface{})
+	kept := make(map[string]interface{}, len(properties))
+	for name, prop := range properties {
+		if !injectedParams[name] && name != "html" {
+			kept[name] = prop
+		}
+	}
+
+	var required []string
+	if list, ok := schema["required"].([]string); ok {
+		for _, name := range list {
+			if _, ok := kept[name]; ok {
+				required = append(required, name)
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": kept,
+		"required":   required,
+	}
+}
+
+// runToolCalls executes the model's tool calls in order, recording each as a step
+func (a *JobAgent) runToolCalls(ctx context.Context, state *orchestration, calls []gemini.FunctionCall, output *OrchestrateOutput) []gemini.FunctionResult {
+	results := make([]gemini.FunctionResult, 0, len(calls))
+	for _, call := range calls {
+		step := models.AgentStep{Tool: call.Name, Arguments: call.Arguments}
+		result, err := a.runToolCall(ctx, state, call)
+		if err != nil {
+			step.Error = err.Error()
+			result = map[string]interface{}{"success": false, "error": err.Error()}
+		} else {
+			step.Success, _ = result["success"].(bool)
+			if !step.Success {
+				step.Error, _ = result["error"].(string)
+			}
+		}
+		agentLog.InfoContext(ctx, "Tool called", "tool", call.Name, "success", step.Success)
+
+		output.Steps = append(output.Steps, step)
+		results = append(results, gemini.FunctionResult{Name: call.Name, Response: result})
+	}
+	return results
+}
+
+// runToolCall executes one tool call, filling in injected parameters, and returns the result to show the model
+func (a *JobAgent) runToolCall(ctx context.Context, state *orchestration, call gemini.FunctionCall) (map[string]interface{}, error) {
+	tool, ok := a.toolRegistry.Get(call.Name)
+	if !ok || !isOrchestratedTool(call.Name) {
+		return nil, fmt.Errorf("unknown tool %q", call.Name)
+	}
+
+	var args map[string]interface{}
+	if err := json.Unmarshal(call.Arguments, &args); err != nil || args == nil {
+		args = make(map[string]interface{})
+	}
+	properties, _ := tool.InputSchema()["properties"].(map[string]interface{})
+	if _, ok := properties["profile"]; ok {
+		args["profile"] = state.profile
+	}
+	if _, ok := properties["feedback"]; ok && len(state.feedback) > 0 {
+		args["feedback"] = state.feedback
+	}
+	if _, ok := properties["html"]; ok {
+		if pageURL, _ := args["url"].(string); pageURL != "" {
+			if html, ok := state.pages[pageURL]; ok {
+				args["html"] = html
+			}
+		}
+	}
+
+	input, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	raw, err := a.toolRegistry.Execute(ctx, call.Name, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var result tools.ToolResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("invalid tool result: %w", err)
+	}
+	if call.Name == "fetch_page_html" && result.Success {
+		result.Data = state.keepPage(result.Data)
+	}
+
+	return modelResult(result), nil
+}
+
+// keepPage stores a fetched page for extraction and returns the fetch result with a shortened preview of the HTML
+func (s *orchestration) keepPage(data json.RawMessage) json.RawMessage {
+	var page models.FetchPageResponse
+	if err := json.Unmarshal(data, &page); err != nil {
+		return data
+	}
+	s.pages[page.URL] = page.HTML
+
+	page.HTML = truncateRunes(page.HTML, maxPagePreviewChars)
+	page.StructuredData = nil
+	preview, err := json.Marshal(page)
+	if err != nil {
+		return data
+	}
+	return preview
+}
+
+// modelResult converts a tool result to the response shown to the model, truncating large data
+func modelResult(result tools.ToolResult) map[string]interface{} {
+	response := map[string]interface{}{"success": result.Success}
+	if result.Error != "" {
+		response["error"] = result.Error
+	}
+	if len(result.Data) == 0 {
+		return response
+	}
+
+	if len(result.Data) > maxToolResultChars {
+		response["data"] = truncateRunes(string(result.Data), maxToolResultChars)
+		response["truncated"] = true
+		return response
+	}
+	var data interface{}
+	if err := json.Unmarshal(result.Data, &data); err == nil {
+		response["data"] = data
+	}
+	return response
+}
+
+// isOrchestratedTool reports whether the model may call the tool
+func isOrchestratedTool(name string) bool {
+	for _, tool := range orchestratedTools {
+		if tool == name {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateRunes shortens s to at most n runes
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// planQueries returns the queries to search: the effective query first, followed by
+// Gemini-planned variants when the planner is enabled. Planning failures are logged and ignored.
+func (a *JobAgent) planQueries(ctx context.Context, profile *models.UserProfile, query string) []string {
+	queries := []string{query}
+	if !a.cfg.QueryPlannerEnabled || a.cfg.MaxPlannedQueries <= 0 {
+		return queries
+	}
+
+	planned, err := a.geminiClient.PlanSearchQueries(ctx, profile, query, a.cfg.MaxPlannedQueries)
+	if err != nil {
+		agentLog.WarnContext(ctx, "Query planning failed", "error", err)
+		return queries
+	}
+
+	seen := map[string]bool{strings.ToLower(query): true}
+	for _, q := range planned {
+		key := strings.ToLower(q)
+		if !seen[key] {
+			seen[key] = true
+			queries = append(queries, q)
+		}
+	}
+	agentLog.InfoContext(ctx, "Planned search queries", "queries", queries)
+	return queries
+}
+
+// searchQueries runs each query against web search concurrently and merges the unique URLs,
+// keeping the results of earlier queries first. Fails only if every query fails.
+func (a *JobAgent) searchQueries(ctx context.Context, profile *models.UserProfile, queries []string, filters models.JobSearchFilter) (*models.WebSearchResponse, error) {
+	responses := make([]*models.WebSearchResponse, len(queries))
+	errs := make([]error, len(queries))
+
+	var wg sync.WaitGroup
+	for i, q := range queries {
+		wg.Add(1)
+		go func(i int, q string) {
+			defer wg.Done()
+			responses[i], errs[i] = a.searchTool.SearchWithProfile(ctx, profile, q, filters)
+		}(i, q)
+	}
+	wg.Wait()
+
+	merged := &models.WebSearchResponse{}
+	seen := make(map[string]bool)
+	failed := 0
+	for i, resp := range responses {
+		if errs[i] != nil {
+			agentLog.WarnContext(ctx, "Web search failed", "query", queries[i], "error", errs[i])
+			failed++
+			continue
+		}
+		for _, result := range resp.Results {
+			if !seen[result.URL] {
+				seen[result.URL] = true
+				merged.URLs = append(merged.URLs, result.URL)
+				merged.Results = append(merged.Results, result)
+			}
+		}
+	}
+
+	if failed == len(queries) {
+		return nil, fmt.Errorf("all %d searches failed: %w", failed, errs[0])
+	}
+	return merged, nil
+}
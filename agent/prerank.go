@@ -0,0 +1,138 @@
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/matching"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// maxEmbeddingTextChars bounds the posting text sent to the embedding model
+const maxEmbeddingTextChars = 4000
+
+// preRankJobs keeps the limit postings most likely to match the profile before LLM scoring.
+// Postings are ranked by embedding similarity when embeddings are enabled, and by the
+// heuristic scorer otherwise or if embedding fails.
+func (a *JobAgent) preRankJobs(ctx context.Context, profile *models.UserProfile, jobs []models.JobPosting, limit int) []models.JobPosting {
+	if a.vectors != nil {
+		ranked, err := a.rankByEmbedding(ctx, profile, jobs)
+		if err == nil {
+			agentLog.InfoContext(ctx, "Pre-ranked jobs by embedding similarity", "jobs", len(jobs))
+			return ranked[:limit]
+		}
+		agentLog.WarnContext(ctx, "Embedding pre-rank failed, using heuristic", "error", err)
+	}
+
+	return matching.Rank(profile, jobs)[:limit]
+}
+
+// rankByEmbedding orders jobs by cosine similarity between their embeddings and the profile's.
+// Embeddings are kept in the agent's vector index so repeat postings and profiles aren't re-embedded.
+func (a *JobAgent) rankByEmbedding(ctx context.Context, profile *models.UserProfile, jobs []models.JobPosting) ([]models.JobPosting, error) {
+	profileText := profileEmbeddingText(profile)
+	profileKey := "profile:" + hashText(profileText)
+	profileVector, ok := a.vectors.Get(profileKey)
+	if !ok {
+		var err error
+		profileVector, err = a.geminiClient.EmbedText(ctx, profileText, gemini.EmbeddingTaskQuery)
+		if err != nil {
+			return nil, err
+		}
+		a.vectors.Put(profileKey, profileVector)
+	}
+
+	jobVectors := make([][]float32, len(jobs))
+	missing := make([]int, 0, len(jobs))
+	for i := range jobs {
+		if v, ok := a.vectors.Get(jobEmbeddingKey(&jobs[i])); ok {
+			jobVectors[i] = v
+		} else {
+			missing = append(missing, i)
+		}
+	}
+
+	if len(missing) > 0 {
+		texts := make([]string, len(missing))
+		for i, idx := range missing {
+			texts[i] = jobEmbeddingText(&jobs[idx])
+		}
+		vectors, err := a.geminiClient.EmbedTexts(ctx, texts, gemini.EmbeddingTaskDocument)
+		if err != nil {
+			return nil, err
+		}
+		for i, idx := range missing {
+			jobVectors[idx] = vectors[i]
+			if key := jobEmbeddingKey(&jobs[idx]); key != "" {
+				a.vectors.Put(key, vectors[i])
+			}
+		}
+	}
+
+	similarity := make([]float64, len(jobs))
+	order := make([]int, len(jobs))
+	for i := range jobs {
+		similarity[i] = matching.Cosine(profileVector, jobVectors[i])
+		order[i] = i
+	}
+	sort.SliceStable(order, func(x, y int) bool {
+		return similarity[order[x]] > similarity[order[y]]
+	})
+
+	ranked := make([]models.JobPosting, len(jobs))
+	for i, idx := range order {
+		ranked[i] = jobs[idx]
+	}
+	return ranked, nil
+}
+
+// jobEmbeddingKey identifies a posting's embedding by its stable job ID ("" if it has no URL)
+func jobEmbeddingKey(job *models.JobPosting) string {
+	id := job.ID
+	if id == "" && job.URL != "" {
+		id = utils.JobID(job.URL)
+	}
+	if id == "" {
+		return ""
+	}
+	return "job:" + id
+}
+
+// jobEmbeddingText is the posting text embedded for similarity ranking
+func jobEmbeddingText(job *models.JobPosting) string {
+	text := strings.Join([]string{
+		job.Title,
+		job.Company,
+		job.Location,
+		strings.Join(job.Tags, ", "),
+		job.Requirements,
+		job.Description,
+	}, "\n")
+	if len(text) > maxEmbeddingTextChars {
+		// Cutting may split a multi-byte character, which the API rejects
+		text = strings.ToValidUTF8(text[:maxEmbeddingTextChars], "")
+	}
+	return text
+}
+
+// profileEmbeddingText is the profile text embedded for similarity ranking
+func profileEmbeddingText(profile *models.UserProfile) string {
+	return strings.Join([]string{
+		profile.Title,
+		strings.Join(profile.PreferredRoles, ", "),
+		strings.Join(profile.Skills, ", "),
+		strings.Join(profile.TechnicalStack, ", "),
+		profile.Summary,
+	}, "\n")
+}
+
+// hashText returns a short content hash used as a cache key
+func hashText(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,146 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/tools"
+	"github.com/myjobmatch/backend/validation"
+)
+
+// SearchJobsTool exposes the complete search pipeline (search, fetch, extract, score) as a single
+// MCP tool, so external agents don't have to orchestrate the individual tools themselves.
+// It lives in the agent package because the pipeline does; it is registered on the MCP server only.
+type SearchJobsTool struct {
+	agent *JobAgent
+}
+
+// NewSearchJobsTool creates the end-to-end job search tool
+func NewSearchJobsTool(agent *JobAgent) *SearchJobsTool {
+	return &SearchJobsTool{
+		agent: agent,
+	}
+}
+
+func (t *SearchJobsTool) Name() string {
+	return "search_jobs"
+}
+
+func (t *SearchJobsTool) Version() string {
+	return "1.0.0"
+}
+
+func (t *SearchJobsTool) Description() string {
+	return `Run a complete job search: build a profile from the CV text, profile and/or query, search the web,
+job boards and ATS boards, read the postings and score each against the profile.
+Input needs at least one of query, cv_text or profile, plus optional filters.
+Returns the ranked jobs (best match first) with match scores and reasons, the profile used and search statistics.
+Takes up to a minute; progress is reported when the call carries a progress token.`
+}
+
+func (t *SearchJobsTool) InputSchema() map[string]interface{} {
+	stringList := func(description string) map[string]interface{} {
+		return map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": description,
+		}
+	}
+
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": "Search query, e.g. 'remote golang backend engineer'; negative terms like '-crypto' exclude postings",
+			},
+			"cv_text": map[string]interface{}{
+				"type":        "string",
+				"description": "Plain text of the candidate's CV, parsed into a profile",
+			},
+			"profile": map[string]interface{}{
+				"type":        "object",
+				"description": "Structured profile (e.g. from parse_cv) with skills, experience and preferences; used instead of cv_text",
+			},
+			"filters": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"locations": stringList("Locations to search in"),
+					"remote_modes": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string", "enum": []string{"WFH", "WFO", "Hybrid"}},
+						"description": "Accepted work modes",
+					},
+					"job_types": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string", "enum": []string{"full_time", "part_time", "contract", "internship", "freelance"}},
+						"description": "Accepted job types",
+					},
+					"min_salary": map[string]interface{}{"type": "integer", "description": "Minimum monthly salary"},
+					"max_salary": map[string]interface{}{"type": "integer", "description": "Maximum monthly salary"},
+					"currency":   map[string]interface{}{"type": "string", "description": "Salary currency, e.g. IDR"},
+					"date_posted": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"last_24h", "last_week", "last_month"},
+						"description": "Only postings published within this period",
+					},
+					"min_score": map[string]interface{}{
+						"type":        "integer",
+						"description": "Minimum match score to return (default 50; 0 returns everything)",
+					},
+					"exclude_companies": stringList("Companies to leave out"),
+					"exclude_keywords":  stringList("Words that must not appear in postings, e.g. 'crypto'"),
+				},
+				"description": "Optional search filters",
+			},
+			"max_results": map[string]interface{}{
+				"type":        "integer",
+				"description": "Number of jobs to return (server default and cap apply)",
+			},
+			"max_pages_to_process": map[string]interface{}{
+				"type":        "integer",
+				"description": "Number of pages to read; more pages find more jobs but take longer (server default and cap apply)",
+			},
+		},
+	}
+}
+
+// SearchJobsToolInput represents the input for the end-to-end job search tool
+type SearchJobsToolInput struct {
+	Query      string                 `json:"query,omitempty"`
+	CVText     string                 `json:"cv_text,omitempty"`
+	Profile    *models.UserProfile    `json:"profile,omitempty"`
+	Filters    models.JobSearchFilter `json:"filters,omitempty"`
+	MaxResults int                    `json:"max_results,omitempty"`
+	MaxPages   int                    `json:"max_pages_to_process,omitempty"`
+}
+
+func (t *SearchJobsTool) Execute(ctx context.Context, input json.RawMessage) (json.RawMessage, error) {
+	var searchInput SearchJobsToolInput
+	if err := json.Unmarshal(input, &searchInput); err != nil {
+		return tools.NewErrorResult(fmt.Sprintf("invalid input: %v", err))
+	}
+	if err := validation.Search(&searchInput.Query, &searchInput.Filters); err != nil {
+		return tools.NewErrorResult(fmt.Sprintf("invalid input: %v", err))
+	}
+	if strings.TrimSpace(searchInput.Query) == "" && strings.TrimSpace(searchInput.CVText) == "" && searchInput.Profile == nil {
+		return tools.NewErrorResult("one of query, cv_text or profile is required")
+	}
+
+	output, err := t.agent.SearchJobs(ctx, SearchJobsInput{
+		Profile:    searchInput.Profile,
+		CVText:     searchInput.CVText,
+		Query:      searchInput.Query,
+		Filters:    searchInput.Filters,
+		MaxResults: searchInput.MaxResults,
+		MaxPages:   searchInput.MaxPages,
+	})
+	if err != nil {
+		return tools.NewGenerationErrorResult("job search failed", err)
+	}
+
+	return tools.NewSuccessResult(output)
+}
@@ -0,0 +1,58 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// SearchSessionStore records searches so their input, profile, stats and results can be reviewed later
+type SearchSessionStore interface {
+	// SaveSearchSession stores a new session, assigning its ID; it expires ttl after creation
+	SaveSearchSession(ctx context.Context, session *models.SearchSession, ttl time.Duration) error
+}
+
+// recordSearchSession stores what went into a finished search and what it returned, setting the
+// output's session ID. Failures are only logged.
+func (a *JobAgent) recordSearchSession(ctx context.Context, input SearchJobsInput, effectiveQuery string, queries []string, output *SearchJobsOutput, startedAt time.Time) {
+	if a.sessionStore == nil {
+		return
+	}
+
+	model := input.Model
+	if model == "" {
+		model = a.cfg.GeminiModel
+	}
+
+	session := &models.SearchSession{
+		UserID:         input.UserID,
+		Query:          input.Query,
+		EffectiveQuery: effectiveQuery,
+		Queries:        queries,
+		Filters:        input.Filters,
+		Incognito:      input.Incognito,
+		Model:          model,
+		Profile:        output.Profile,
+		Stats:          output.Stats,
+		Usage:          output.Usage,
+		PromptVariant:  output.PromptVariant,
+		Results:        make([]models.SearchSessionResult, len(output.Results)),
+		DurationMs:     time.Since(startedAt).Milliseconds(),
+	}
+	for i, job := range output.Results {
+		session.Results[i] = models.SearchSessionResult{
+			JobID:       job.ID,
+			MatchScore:  job.MatchScore,
+			MatchReason: job.MatchReason,
+			ScoreMethod: job.ScoreMethod,
+		}
+	}
+
+	// The search already finished; record it even if the caller gave up waiting
+	if err := a.sessionStore.SaveSearchSession(context.WithoutCancel(ctx), session, a.sessionTTL); err != nil {
+		agentLog.WarnContext(ctx, "Failed to record search session", "error", err)
+		return
+	}
+	output.SessionID = session.ID
+}
@@ -0,0 +1,63 @@
+package agent
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// minSimilarity is the lowest similarity for a posting to count as similar
+const minSimilarity = 0.15
+
+// RankSimilarJobs orders candidates by keyword overlap with target (tags weigh more than title words)
+// and returns up to limit of them. The target itself and the same role at the same company are skipped.
+func RankSimilarJobs(target models.JobPosting, candidates []models.JobPosting, limit int) []models.SimilarJob {
+	targetTags := tagSet(target.Tags)
+	targetTitle := wordSet(target.Title)
+	targetKey := normalizeCompany(target.Company) + "|" + strings.ToLower(strings.TrimSpace(target.Title))
+
+	similar := make([]models.SimilarJob, 0, len(candidates))
+	seen := map[string]bool{target.ID: true, targetKey: true}
+	for _, job := range candidates {
+		key := normalizeCompany(job.Company) + "|" + strings.ToLower(strings.TrimSpace(job.Title))
+		if seen[job.ID] || seen[key] {
+			continue
+		}
+
+		tags := tagSet(job.Tags)
+		score := 0.6*jaccard(targetTags, tags) + 0.4*jaccard(targetTitle, wordSet(job.Title))
+		if score < minSimilarity {
+			continue
+		}
+		seen[job.ID] = true
+		seen[key] = true
+
+		shared := make([]string, 0)
+		for _, tag := range job.Tags {
+			if targetTags[strings.ToLower(strings.TrimSpace(tag))] {
+				shared = append(shared, tag)
+			}
+		}
+		similar = append(similar, models.SimilarJob{JobPosting: job, Similarity: score, SharedTags: shared})
+	}
+
+	sort.SliceStable(similar, func(i, j int) bool {
+		return similar[i].Similarity > similar[j].Similarity
+	})
+	if len(similar) > limit {
+		similar = similar[:limit]
+	}
+	return similar
+}
+
+// tagSet lowercases tags into a set
+func tagSet(tags []string) map[string]bool {
+	set := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			set[tag] = true
+		}
+	}
+	return set
+}
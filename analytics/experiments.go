@@ -0,0 +1,99 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// scoreBucketWidth is the width of the score distribution buckets
+const scoreBucketWidth = 20
+
+// BuildPromptExperimentReport compares prompt variants by the searches run with each one and the
+// feedback on the jobs they scored, covering from..to
+func BuildPromptExperimentReport(records []models.PromptExperimentRecord, feedback []models.JobFeedback, from, to time.Time) *models.PromptExperimentReport {
+	stats := make(map[string]*models.PromptVariantStats)
+	scores := make(map[string][]int)
+	estimated := make(map[string]int)
+	extractErrors := make(map[string]int)
+
+	variant := func(name string) *models.PromptVariantStats {
+		s, ok := stats[name]
+		if !ok {
+			s = &models.PromptVariantStats{Variant: name, ScoreDistribution: make(map[string]int)}
+			stats[name] = s
+		}
+		return s
+	}
+
+	for _, r := range records {
+		s := variant(r.Variant)
+		s.Searches++
+		s.JobsExtracted += r.JobsExtracted
+		extractErrors[r.Variant] += r.ExtractErrors
+		estimated[r.Variant] += r.Estimated
+		scores[r.Variant] = append(scores[r.Variant], r.AIScores...)
+	}
+
+	for _, f := range feedback {
+		s := variant(f.PromptVariant)
+		switch f.Rating {
+		case models.FeedbackRelevant:
+			s.FeedbackRelevant++
+		case models.FeedbackNotRelevant:
+			s.FeedbackNotRelevant++
+		case models.FeedbackAlreadyApplied:
+			s.FeedbackApplied++
+		}
+	}
+
+	report := &models.PromptExperimentReport{From: from, To: to, Variants: make([]models.PromptVariantStats, 0, len(stats))}
+	for name, s := range stats {
+		variantScores := scores[name]
+		sort.Ints(variantScores)
+		s.JobsScored = len(variantScores)
+		if len(variantScores) > 0 {
+			sum := 0
+			for _, score := range variantScores {
+				sum += score
+				s.ScoreDistribution[scoreBucket(score)]++
+			}
+			s.MeanScore = round1(float64(sum) / float64(len(variantScores)))
+			s.MedianScore = median(variantScores)
+		}
+		if returned := s.JobsScored + estimated[name]; returned > 0 {
+			s.EstimatedRate = round2(float64(estimated[name]) / float64(returned))
+		}
+		if s.JobsExtracted > 0 {
+			s.ExtractErrorRate = round2(float64(extractErrors[name]) / float64(s.JobsExtracted))
+		}
+		if rated := s.FeedbackRelevant + s.FeedbackNotRelevant; rated > 0 {
+			s.RelevantRate = round2(float64(s.FeedbackRelevant) / float64(rated))
+		}
+		report.Variants = append(report.Variants, *s)
+	}
+
+	sort.Slice(report.Variants, func(i, j int) bool {
+		return report.Variants[i].Variant < report.Variants[j].Variant
+	})
+	return report
+}
+
+// scoreBucket names the distribution bucket of a 0-100 score, e.g. "60-79"; 100 falls in "80-100"
+func scoreBucket(score int) string {
+	lo := min(max(score, 0)/scoreBucketWidth*scoreBucketWidth, 100-scoreBucketWidth)
+	hi := lo + scoreBucketWidth - 1
+	if hi == 99 {
+		hi = 100
+	}
+	return fmt.Sprintf("%d-%d", lo, hi)
+}
+
+// round1 rounds to one decimal place
+func round1(v float64) float64 { return math.Round(v*10) / 10 }
+
+// round2 rounds to two decimal places
+func round2(v float64) float64 { return math.Round(v*100) / 100 }
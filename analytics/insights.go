@@ -0,0 +1,230 @@
+// Package analytics aggregates stored job data into market insights and prompt experiment reports
+package analytics
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+const (
+	// maxTopSkills is how many skills the report lists
+	maxTopSkills = 20
+	// maxSalaryGroups is how many role/location salary groups the report lists
+	maxSalaryGroups = 20
+)
+
+// seniorityWords are dropped from titles so "Senior Backend Engineer" and "Backend Engineer" share a role
+var seniorityWords = map[string]bool{
+	"senior": true, "sr": true, "junior": true, "jr": true, "mid": true, "middle": true, "level": true,
+	"lead": true, "principal": true, "staff": true, "intern": true, "internship": true, "magang": true,
+	"i": true, "ii": true, "iii": true, "iv": true,
+}
+
+// Filter narrows the jobs an insights report covers
+type Filter struct {
+	Role     string // Case-insensitive substring of the job title
+	Location string // Case-insensitive substring of the job location
+}
+
+// Matches reports whether a posting falls within the filter
+func (f Filter) Matches(job models.JobPosting) bool {
+	return containsFold(job.Title, f.Role) && containsFold(job.Location, f.Location)
+}
+
+// BuildInsights aggregates the jobs matching filter into a report covering from..to
+func BuildInsights(jobs []models.StoredJob, filter Filter, from, to time.Time) *models.InsightsReport {
+	report := &models.InsightsReport{
+		From:          from,
+		To:            to,
+		Role:          filter.Role,
+		Location:      filter.Location,
+		TopSkills:     []models.SkillDemand{},
+		SalaryRanges:  []models.SalaryInsight{},
+		PostingVolume: []models.VolumePoint{},
+		WorkTypes:     make(map[string]int),
+		SiteSettings:  make(map[string]int),
+	}
+
+	skills := make(map[string]int)
+	salaries := make(map[salaryKey]*salaryGroup)
+	volume := make(map[string]int)
+
+	for _, stored := range jobs {
+		job := stored.Job
+		if !filter.Matches(job) {
+			continue
+		}
+		report.TotalJobs++
+
+		// A posting counts once per skill however its tags are cased
+		seen := make(map[string]bool)
+		for _, tag := range job.Tags {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if tag != "" && !seen[tag] {
+				seen[tag] = true
+				skills[tag]++
+			}
+		}
+
+		if job.WorkType != "" {
+			report.WorkTypes[job.WorkType]++
+		}
+		if job.SiteSetting != "" {
+			report.SiteSettings[job.SiteSetting]++
+		}
+
+		if job.SalaryMin > 0 && job.SalaryCurrency != "" {
+			key := salaryKey{role: roleKey(job.Title), location: locationKey(job.Location), currency: job.SalaryCurrency}
+			if key.role != "" {
+				group, ok := salaries[key]
+				if !ok {
+					group = &salaryGroup{}
+					salaries[key] = group
+				}
+				// Ranges are compared as monthly amounts; postings stored before periods were parsed get one inferred
+				period := job.SalaryPeriod
+				if period == "" {
+					period = utils.ParseSalaryPeriod(job.Salary, job.SalaryMin, job.SalaryCurrency)
+				}
+				group.add(utils.MonthlySalary(job.SalaryMin, period), utils.MonthlySalary(job.SalaryMax, period))
+			}
+		}
+
+		if !stored.FirstSeenAt.IsZero() {
+			volume[weekStart(stored.FirstSeenAt)]++
+		}
+	}
+
+	report.TopSkills = topSkills(skills, report.TotalJobs)
+	report.SalaryRanges = salaryInsights(salaries)
+	for week, count := range volume {
+		report.PostingVolume = append(report.PostingVolume, models.VolumePoint{WeekStart: week, Count: count})
+	}
+	sort.Slice(report.PostingVolume, func(i, j int) bool {
+		return report.PostingVolume[i].WeekStart < report.PostingVolume[j].WeekStart
+	})
+
+	return report
+}
+
+// topSkills returns the most demanded skills, most frequent first
+func topSkills(counts map[string]int, total int) []models.SkillDemand {
+	skills := make([]models.SkillDemand, 0, len(counts))
+	for skill, count := range counts {
+		skills = append(skills, models.SkillDemand{
+			Skill: skill,
+			Count: count,
+			Share: float64(count) / float64(total),
+		})
+	}
+	sort.Slice(skills, func(i, j int) bool {
+		if skills[i].Count != skills[j].Count {
+			return skills[i].Count > skills[j].Count
+		}
+		return skills[i].Skill < skills[j].Skill
+	})
+	if len(skills) > maxTopSkills {
+		skills = skills[:maxTopSkills]
+	}
+	return skills
+}
+
+type salaryKey struct {
+	role     string
+	location string
+	currency string
+}
+
+// salaryGroup collects the advertised ranges of one role/location/currency
+type salaryGroup struct {
+	mins []int
+	maxs []int
+}
+
+func (g *salaryGroup) add(lo, hi int) {
+	if hi < lo {
+		hi = lo
+	}
+	g.mins = append(g.mins, lo)
+	g.maxs = append(g.maxs, hi)
+}
+
+// salaryInsights summarizes salary groups, best-sampled first
+func salaryInsights(groups map[salaryKey]*salaryGroup) []models.SalaryInsight {
+	insights := make([]models.SalaryInsight, 0, len(groups))
+	for key, group := range groups {
+		sort.Ints(group.mins)
+		sort.Ints(group.maxs)
+		insights = append(insights, models.SalaryInsight{
+			Role:       key.role,
+			Location:   key.location,
+			Currency:   key.currency,
+			MedianMin:  median(group.mins),
+			MedianMax:  median(group.maxs),
+			LowestMin:  group.mins[0],
+			HighestMax: group.maxs[len(group.maxs)-1],
+			SampleSize: len(group.mins),
+		})
+	}
+	sort.Slice(insights, func(i, j int) bool {
+		if insights[i].SampleSize != insights[j].SampleSize {
+			return insights[i].SampleSize > insights[j].SampleSize
+		}
+		return insights[i].Role < insights[j].Role
+	})
+	if len(insights) > maxSalaryGroups {
+		insights = insights[:maxSalaryGroups]
+	}
+	return insights
+}
+
+// median returns the middle value of sorted values
+func median(sorted []int) int {
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// roleKey reduces a job title to its role, e.g. "Sr. Backend Engineer (Golang)" -> "backend engineer golang"
+func roleKey(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	kept := make([]string, 0, len(words))
+	for _, w := range words {
+		if !seniorityWords[w] {
+			kept = append(kept, w)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// locationKey reduces a location to its first part, e.g. "Jakarta Selatan, DKI Jakarta" -> "Jakarta Selatan"
+func locationKey(location string) string {
+	location = strings.TrimSpace(location)
+	if i := strings.IndexAny(location, ",/("); i >= 0 {
+		location = strings.TrimSpace(location[:i])
+	}
+	if location == "" {
+		return "Unknown"
+	}
+	return location
+}
+
+// weekStart returns the Monday of t's week as YYYY-MM-DD
+func weekStart(t time.Time) string {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return t.AddDate(0, 0, -offset).Format("2006-01-02")
+}
+
+func containsFold(s, substr string) bool {
+	return substr == "" || strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
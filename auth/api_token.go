@@ -0,0 +1,214 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// APITokenPrefix identifies personal access tokens so they can be told apart from JWTs
+const APITokenPrefix = "mjm_pat_"
+
+// Personal access token scopes
+const (
+	ScopeSearch      = "search"
+	ScopeReadProfile = "read-profile"
+	ScopeMCP         = "mcp"
+)
+
+// AuthAPITokenKey is the key used to store the personal access token in gin context
+const AuthAPITokenKey = "auth_api_token"
+
+// lastUsedResolution limits how often last-used timestamps are written for a busy token
+const lastUsedResolution = time.Minute
+
+// APITokenStore looks up personal access tokens and their owners, and records their use
+type APITokenStore interface {
+	GetAPITokenByHash(ctx context.Context, tokenHash string) (*models.APIToken, error)
+	TouchAPIToken(ctx context.Context, id string, usedAt time.Time) error
+	GetUserByID(ctx context.Context, id string) (*models.User, error)
+}
+
+// GenerateAPIToken creates a new random personal access token and returns it with its hash
+func GenerateAPIToken() (token string, tokenHash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token = APITokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashAPIToken(token), nil
+}
+
+// HashAPIToken returns the hex SHA-256 of a token; only hashes are stored
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAPIToken reports whether a bearer token is a personal access token
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
+
+// TokenAuthMiddleware authenticates with either a JWT or a personal access token.
+// Personal access tokens must grant the given scope.
+func TokenAuthMiddleware(jwtService *JWTService, store APITokenStore, scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString, ok := bearerToken(c)
+		if !ok || !IsAPIToken(tokenString) {
+			AuthMiddleware(jwtService)(c)
+			return
+		}
+
+		claims, status, message := authenticateAPIToken(c, store, tokenString, scope)
+		if claims == nil {
+			c.JSON(status, models.ErrorResponse{
+				Error: message,
+				Code:  status,
+			})
+			c.Abort()
+			return
+		}
+		if rejectSuspended(c, jwtService, claims) {
+			return
+		}
+
+		setAuthClaims(c, claims)
+		c.Next()
+	}
+}
+
+// OptionalTokenAuthMiddleware is like OptionalAuthMiddleware but also accepts personal access tokens.
+// A personal access token that is invalid or lacks the scope is rejected rather than ignored,
+// so scripts don't silently fall back to anonymous limits.
+func OptionalTokenAuthMiddleware(jwtService *JWTService, store APITokenStore, scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString, ok := bearerToken(c)
+		if !ok || !IsAPIToken(tokenString) {
+			OptionalAuthMiddleware(jwtService)(c)
+			return
+		}
+
+		claims, status, message := authenticateAPIToken(c, store, tokenString, scope)
+		if claims == nil {
+			c.JSON(status, models.ErrorResponse{
+				Error: message,
+				Code:  status,
+			})
+			c.Abort()
+			return
+		}
+		if rejectSuspended(c, jwtService, claims) {
+			return
+		}
+
+		setAuthClaims(c, claims)
+		c.Next()
+	}
+}
+
+// GetAPIToken retrieves the personal access token used for the request, if any
+func GetAPIToken(c *gin.Context) *models.APIToken {
+	token, exists := c.Get(AuthAPITokenKey)
+	if !exists {
+		return nil
+	}
+	return token.(*models.APIToken)
+}
+
+// Authenticate validates a bearer token for requests served outside gin, such as the gRPC API: a JWT,
+// or a personal access token granting scope. With an empty scope only JWTs are accepted. On failure it
+// returns nil claims with the HTTP status and error message the REST API answers with.
+func Authenticate(ctx context.Context, jwtService *JWTService, store APITokenStore, tokenString, scope string) (*Claims, *models.APIToken, int, string) {
+	var claims *Claims
+	var token *models.APIToken
+	if IsAPIToken(tokenString) {
+		if scope == "" {
+			return nil, nil, http.StatusForbidden, "API tokens can't be used for this request"
+		}
+		var status int
+		var message string
+		if token, status, message = checkAPIToken(ctx, store, tokenString, scope); token == nil {
+			return nil, nil, status, message
+		}
+		if claims, status, message = apiTokenClaims(ctx, store, token); claims == nil {
+			return nil, nil, status, message
+		}
+	} else {
+		var err error
+		if claims, err = jwtService.validateSession(ctx, tokenString); err != nil {
+			return nil, nil, http.StatusUnauthorized, "Invalid or expired token"
+		}
+	}
+
+	if jwtService.suspensions != nil && jwtService.suspensions.IsSuspended(ctx, claims.UserID) {
+		return nil, nil, http.StatusForbidden, "Account suspended"
+	}
+	return claims, token, http.StatusOK, ""
+}
+
+// authenticateAPIToken validates a personal access token and returns claims for its owner.
+// On failure it returns nil claims with the HTTP status and error message to send.
+func authenticateAPIToken(c *gin.Context, store APITokenStore, tokenString, scope string) (*Claims, int, string) {
+	token, status, message := checkAPIToken(c.Request.Context(), store, tokenString, scope)
+	if token == nil {
+		return nil, status, message
+	}
+
+	claims, status, message := apiTokenClaims(c.Request.Context(), store, token)
+	if claims == nil {
+		return nil, status, message
+	}
+	c.Set(AuthAPITokenKey, token)
+	return claims, http.StatusOK, ""
+}
+
+// apiTokenClaims returns claims for the owner of a personal access token, with the owner's current email.
+// On failure it returns nil claims with the HTTP status and error message to send.
+func apiTokenClaims(ctx context.Context, store APITokenStore, token *models.APIToken) (*Claims, int, string) {
+	user, err := store.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		return nil, http.StatusUnauthorized, "Invalid API token"
+	}
+	return &Claims{UserID: user.ID, Email: user.Email, Nama: user.Nama, TenantID: utils.TenantFromContext(ctx)}, http.StatusOK, ""
+}
+
+// checkAPIToken looks up a personal access token and checks it is current and grants scope, recording
+// its use. On failure it returns a nil token with the HTTP status and error message to send.
+func checkAPIToken(ctx context.Context, store APITokenStore, tokenString, scope string) (*models.APIToken, int, string) {
+	token, err := store.GetAPITokenByHash(ctx, HashAPIToken(tokenString))
+	if err != nil {
+		return nil, http.StatusUnauthorized, "Invalid API token"
+	}
+	if token.IsExpired() {
+		return nil, http.StatusUnauthorized, "API token has expired"
+	}
+	if !token.HasScope(scope) {
+		return nil, http.StatusForbidden, "API token is missing the required scope: " + scope
+	}
+
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedResolution {
+		// Recorded in the background so the request isn't slowed by the write
+		go func(id string) {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+			defer cancel()
+			if err := store.TouchAPIToken(ctx, id, now); err != nil {
+				log.Printf("[Auth] Failed to record API token use: %v", err)
+			}
+		}(token.ID)
+	}
+	return token, http.StatusOK, ""
+}
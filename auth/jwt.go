@@ -14,13 +14,15 @@ import (
 type JWTService struct {
 	secretKey   []byte
 	expiryHours int
+	suspensions *SuspensionChecker
 }
 
 // Claims represents JWT claims
 type Claims struct {
-	UserID string `json:"userId"`
-	Email  string `json:"email"`
-	Nama   string `json:"nama"`
+	UserID   string `json:"userId"`
+	Email    string `json:"email"`
+	Nama     string `json:"nama"`
+	TenantID string `json:"tenantId,omitempty"` // Empty for the default tenant
 	jwt.RegisteredClaims
 }
 
@@ -32,14 +34,21 @@ func NewJWTService(cfg *config.Config) *JWTService {
 	}
 }
 
+// CheckSuspensions makes the auth middlewares refuse tokens of suspended accounts, and sessions revoked
+// by an email change
+func (s *JWTService) CheckSuspensions(checker *SuspensionChecker) {
+	s.suspensions = checker
+}
+
 // GenerateToken generates a JWT token for a user
 func (s *JWTService) GenerateToken(user *models.User) (string, error) {
 	expirationTime := time.Now().Add(time.Duration(s.expiryHours) * time.Hour)
 
 	claims := &Claims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Nama:   user.Nama,
+		UserID:   user.ID,
+		Email:    user.Email,
+		Nama:     user.Nama,
+		TenantID: user.TenantID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -56,8 +65,8 @@ func (s *JWTService) GenerateToken(user *models.User) (string, error) {
 	return tokenString, nil
 }
 
-// ValidateToken validates a JWT token and returns the claims
-func (s *JWTService) ValidateToken(tokenString string) (*Claims, error) {
+// ValidateToken validates a JWT token issued for the given tenant and returns the claims
+func (s *JWTService) ValidateToken(tokenString, tenantID string) (*Claims, error) {
 	claims := &Claims{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
@@ -74,13 +83,16 @@ func (s *JWTService) ValidateToken(tokenString string) (*Claims, error) {
 	if !token.Valid {
 		return nil, errors.New("invalid token")
 	}
+	if claims.TenantID != tenantID {
+		return nil, errors.New("token was issued for another tenant")
+	}
 
 	return claims, nil
 }
 
 // RefreshToken generates a new token with extended expiry
-func (s *JWTService) RefreshToken(tokenString string) (string, error) {
-	claims, err := s.ValidateToken(tokenString)
+func (s *JWTService) RefreshToken(tokenString, tenantID string) (string, error) {
+	claims, err := s.ValidateToken(tokenString, tenantID)
 	if err != nil {
 		return "", err
 	}
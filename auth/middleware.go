@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
 )
 
 const (
@@ -43,7 +44,7 @@ func AuthMiddleware(jwtService *JWTService) gin.HandlerFunc {
 		tokenString := parts[1]
 
 		// Validate token
-		claims, err := jwtService.ValidateToken(tokenString)
+		claims, err := jwtService.validateSession(c.Request.Context(), tokenString)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, models.ErrorResponse{
 				Error:   "Invalid or expired token",
@@ -53,9 +54,12 @@ func AuthMiddleware(jwtService *JWTService) gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		if rejectSuspended(c, jwtService, claims) {
+			return
+		}
 
 		// Store claims in context
-		c.Set(AuthClaimsKey, claims)
+		setAuthClaims(c, claims)
 		c.Next()
 	}
 }
@@ -78,17 +82,65 @@ func OptionalAuthMiddleware(jwtService *JWTService) gin.HandlerFunc {
 		}
 
 		tokenString := parts[1]
-		claims, err := jwtService.ValidateToken(tokenString)
+		claims, err := jwtService.validateSession(c.Request.Context(), tokenString)
 		if err != nil {
 			c.Next()
 			return
 		}
+		if rejectSuspended(c, jwtService, claims) {
+			return
+		}
+
+		setAuthClaims(c, claims)
+		c.Next()
+	}
+}
+
+// setAuthClaims stores the authenticated user in the gin context, and the caller in the request
+// context so tool calls made for the request are attributed to the user in the audit log
+func setAuthClaims(c *gin.Context, claims *Claims) {
+	c.Set(AuthClaimsKey, claims)
+
+	caller := utils.Caller{Email: claims.Email, ClientIP: c.ClientIP()}
+	if token := GetAPIToken(c); token != nil {
+		caller.APITokenID = token.ID
+	}
+	c.Request = c.Request.WithContext(utils.WithCaller(c.Request.Context(), caller))
+}
+
+// AdminMiddleware restricts a route to the administrators listed in ADMIN_EMAILS.
+// It must run after AuthMiddleware; with no administrators configured every request is refused.
+// Administrators are accounts of the default tenant only: any client can pick another tenant with
+// X-Tenant-ID and register an ADMIN_EMAILS address there.
+func AdminMiddleware(adminEmails []string) gin.HandlerFunc {
+	admins := make(map[string]bool, len(adminEmails))
+	for _, email := range adminEmails {
+		admins[strings.ToLower(email)] = true
+	}
 
-		c.Set(AuthClaimsKey, claims)
+	return func(c *gin.Context) {
+		claims := GetAuthClaims(c)
+		if claims == nil || claims.TenantID != "" || !admins[strings.ToLower(claims.Email)] {
+			c.JSON(http.StatusForbidden, models.ErrorResponse{
+				Error: "Administrator access required",
+				Code:  http.StatusForbidden,
+			})
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
 
+// bearerToken extracts the token from a "Bearer" Authorization header
+func bearerToken(c *gin.Context) (string, bool) {
+	parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return "", false
+	}
+	return parts[1], true
+}
+
 // GetAuthClaims retrieves auth claims from gin context
 func GetAuthClaims(c *gin.Context) *Claims {
 	claims, exists := c.Get(AuthClaimsKey)
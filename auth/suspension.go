@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// SuspensionStore looks up the account behind a token
+type SuspensionStore interface {
+	GetUserByID(ctx context.Context, id string) (*models.User, error)
+}
+
+// SuspensionChecker reports whether an account is suspended or a session of it was revoked, caching
+// each answer for a while so that authenticated requests don't all read the user. Suspending an account
+// or changing its email takes effect through Forget immediately on this instance and within the cache
+// TTL on the others.
+type SuspensionChecker struct {
+	store SuspensionStore
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	entries map[string]suspensionEntry
+}
+
+type suspensionEntry struct {
+	found      bool
+	suspended  bool
+	validSince time.Time // Sessions issued earlier are revoked
+	checkedAt  time.Time
+}
+
+// errTokenRevoked is returned for a valid token whose session was revoked
+var errTokenRevoked = errors.New("token was revoked")
+
+// NewSuspensionChecker creates a checker that caches answers for ttl
+func NewSuspensionChecker(store SuspensionStore, ttl time.Duration) *SuspensionChecker {
+	return &SuspensionChecker{
+		store:   store,
+		ttl:     ttl,
+		entries: make(map[string]suspensionEntry),
+	}
+}
+
+// IsSuspended reports whether the account with the user ID is suspended in the request's tenant.
+// Lookup failures are logged and treated as not suspended, so a storage outage doesn't lock everyone out.
+func (s *SuspensionChecker) IsSuspended(ctx context.Context, userID string) bool {
+	entry, ok := s.lookup(ctx, userID)
+	return ok && entry.suspended
+}
+
+// IsRevoked reports whether a session of the user ID issued at issuedAt was revoked: the account is gone
+// (or was moved to a new ID by MigrateUserIDs) or its email was changed since. Lookup failures are
+// treated as not revoked, like in IsSuspended.
+func (s *SuspensionChecker) IsRevoked(ctx context.Context, userID string, issuedAt time.Time) bool {
+	entry, ok := s.lookup(ctx, userID)
+	if !ok {
+		return false
+	}
+	// Token times are whole seconds
+	return !entry.found || issuedAt.Before(entry.validSince.Truncate(time.Second))
+}
+
+// lookup returns the account's cached state, reading the user when it is missing or stale. It reports
+// false when the user couldn't be read.
+func (s *SuspensionChecker) lookup(ctx context.Context, userID string) (suspensionEntry, bool) {
+	key := suspensionKey(ctx, userID)
+	now := time.Now()
+
+	s.mu.Lock()
+	entry, ok := s.entries[key]
+	s.mu.Unlock()
+	if ok && now.Sub(entry.checkedAt) < s.ttl {
+		return entry, true
+	}
+
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil && !errors.Is(err, storage.ErrUserNotFound) {
+		log.Printf("[Auth] Failed to check suspension of %s: %v", userID, err)
+		return suspensionEntry{}, false
+	}
+
+	entry = suspensionEntry{found: user != nil, checkedAt: now}
+	if user != nil {
+		entry.suspended = user.Suspended
+		entry.validSince = user.CreatedAt
+		if user.EmailChangedAt != nil && user.EmailChangedAt.After(entry.validSince) {
+			entry.validSince = *user.EmailChangedAt
+		}
+	}
+	s.mu.Lock()
+	s.entries[key] = entry
+	s.mu.Unlock()
+	return entry, true
+}
+
+// Forget drops the cached answer for an account, after it was suspended or reactivated or its email changed
+func (s *SuspensionChecker) Forget(ctx context.Context, userID string) {
+	s.mu.Lock()
+	delete(s.entries, suspensionKey(ctx, userID))
+	s.mu.Unlock()
+}
+
+func suspensionKey(ctx context.Context, userID string) string {
+	return utils.TenantFromContext(ctx) + "|" + userID
+}
+
+// validateSession validates a token like ValidateToken for the request's tenant, also refusing it when
+// its session was revoked or it predates user IDs in claims
+func (s *JWTService) validateSession(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := s.ValidateToken(tokenString, utils.TenantFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if claims.UserID == "" {
+		return nil, errTokenRevoked
+	}
+	if s.suspensions != nil && claims.IssuedAt != nil && s.suspensions.IsRevoked(ctx, claims.UserID, claims.IssuedAt.Time) {
+		return nil, errTokenRevoked
+	}
+	return claims, nil
+}
+
+// rejectSuspended aborts the request with 403 if the authenticated account is suspended
+func rejectSuspended(c *gin.Context, jwtService *JWTService, claims *Claims) bool {
+	if jwtService.suspensions == nil || !jwtService.suspensions.IsSuspended(c.Request.Context(), claims.UserID) {
+		return false
+	}
+	c.JSON(http.StatusForbidden, models.ErrorResponse{
+		Error: "Account suspended",
+		Code:  http.StatusForbidden,
+	})
+	c.Abort()
+	return true
+}
@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+)
+
+// GenerateVerificationToken creates a random one-time token (e.g. to confirm an email change) and
+// returns it with its hash; only the hash is stored
+func GenerateVerificationToken() (token string, tokenHash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashVerificationToken(token), nil
+}
+
+// HashVerificationToken returns the hash a verification token is stored under
+func HashVerificationToken(token string) string {
+	return HashAPIToken(token)
+}
@@ -0,0 +1,24 @@
+// Package cache keeps short-lived shared state (cached postings and tool results, rate limit counters) in
+// Redis, so hot paths skip Firestore round-trips and every instance sees the same entries
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores short-lived values by key. It is safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key, reporting false when it is missing or expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// GetMulti returns the values stored under keys; missing or expired keys are omitted
+	GetMulti(ctx context.Context, keys []string) (map[string][]byte, error)
+	// Set stores a value for ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// SetMulti stores values by key for ttl
+	SetMulti(ctx context.Context, values map[string][]byte, ttl time.Duration) error
+	// Incr increments a counter that expires window after its first increment, returning the new count
+	Incr(ctx context.Context, key string, window time.Duration) (int64, error)
+	// Close releases the connection
+	Close() error
+}
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// jobKeyPrefix namespaces cached postings
+const jobKeyPrefix = "job:"
+
+// jobKey returns the Redis key of a cached posting, within the context's tenant like the store's job cache
+func jobKey(ctx context.Context, key string) string {
+	if tenantID := utils.TenantFromContext(ctx); tenantID != "" {
+		return jobKeyPrefix + tenantID + ":" + key
+	}
+	return jobKeyPrefix + key
+}
+
+// JobStore is the durable job cache used when Redis is unavailable (the Firestore or Postgres store)
+type JobStore interface {
+	GetCachedJobs(ctx context.Context, keys []string) (map[string]models.JobPosting, error)
+	CacheJobs(ctx context.Context, jobs map[string]models.JobPosting, ttl time.Duration) error
+}
+
+// JobCache caches extracted postings in Redis, falling back to the store when Redis fails.
+// It implements agent.JobCache.
+type JobCache struct {
+	cache    Cache
+	fallback JobStore
+}
+
+// NewJobCache creates a job cache; fallback may be nil to treat Redis errors as misses
+func NewJobCache(cache Cache, fallback JobStore) *JobCache {
+	return &JobCache{
+		cache:    cache,
+		fallback: fallback,
+	}
+}
+
+// GetCachedJobs returns the cached postings for the given keys
+func (c *JobCache) GetCachedJobs(ctx context.Context, keys []string) (map[string]models.JobPosting, error) {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = jobKey(ctx, key)
+	}
+
+	values, err := c.cache.GetMulti(ctx, prefixed)
+	if err != nil {
+		if c.fallback == nil {
+			return nil, err
+		}
+		log.Printf("[Cache] Redis job cache read failed, using the store: %v", err)
+		return c.fallback.GetCachedJobs(ctx, keys)
+	}
+
+	jobs := make(map[string]models.JobPosting, len(values))
+	for i, key := range keys {
+		value, ok := values[prefixed[i]]
+		if !ok {
+			continue
+		}
+		var job models.JobPosting
+		if err := json.Unmarshal(value, &job); err != nil {
+			log.Printf("[Cache] Dropping unreadable cached job %s: %v", key, err)
+			continue
+		}
+		jobs[key] = job
+	}
+	return jobs, nil
+}
+
+// CacheJobs stores postings by key for ttl
+func (c *JobCache) CacheJobs(ctx context.Context, jobs map[string]models.JobPosting, ttl time.Duration) error {
+	values := make(map[string][]byte, len(jobs))
+	for key, job := range jobs {
+		value, err := json.Marshal(job)
+		if err != nil {
+			return err
+		}
+		values[jobKey(ctx, key)] = value
+	}
+
+	if err := c.cache.SetMulti(ctx, values, ttl); err != nil {
+		if c.fallback == nil {
+			return err
+		}
+		log.Printf("[Cache] Redis job cache write failed, using the store: %v", err)
+		return c.fallback.CacheJobs(ctx, jobs, ttl)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/myjobmatch/backend/config"
+)
+
+// RedisCache is a Cache backed by Redis. Keys are namespaced with REDIS_KEY_PREFIX so several
+// deployments can share a server.
+type RedisCache struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisCache connects to REDIS_URL (e.g. redis://:password@localhost:6379/0)
+func NewRedisCache(ctx context.Context, cfg *config.Config) (*RedisCache, error) {
+	opts, err := redis.ParseURL(cfg.RedisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REDIS_URL: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &RedisCache{
+		client: client,
+		prefix: cfg.RedisKeyPrefix,
+	}, nil
+}
+
+// Close closes the connection pool
+func (r *RedisCache) Close() error {
+	return r.client.Close()
+}
+
+// Ping checks that Redis is reachable
+func (r *RedisCache) Ping(ctx context.Context) error {
+	if err := r.client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to reach Redis: %w", err)
+	}
+	return nil
+}
+
+// Get returns the value stored under key
+func (r *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := r.client.Get(ctx, r.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read cache: %w", err)
+	}
+	return value, true, nil
+}
+
+// GetMulti returns the values stored under keys in one round trip
+func (r *RedisCache) GetMulti(ctx context.Context, keys []string) (map[string][]byte, error) {
+	values := make(map[string][]byte, len(keys))
+	if len(keys) == 0 {
+		return values, nil
+	}
+
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = r.prefix + key
+	}
+	results, err := r.client.MGet(ctx, prefixed...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	for i, result := range results {
+		if value, ok := result.(string); ok {
+			values[keys[i]] = []byte(value)
+		}
+	}
+	return values, nil
+}
+
+// Set stores a value for ttl
+func (r *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := r.client.Set(ctx, r.prefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	return nil
+}
+
+// SetMulti stores values by key for ttl in one round trip
+func (r *RedisCache) SetMulti(ctx context.Context, values map[string][]byte, ttl time.Duration) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	pipe := r.client.Pipeline()
+	for key, value := range values {
+		pipe.Set(ctx, r.prefix+key, value, ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	return nil
+}
+
+// Incr increments a counter, starting its expiry window on the first increment
+func (r *RedisCache) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	pipe := r.client.TxPipeline()
+	count := pipe.Incr(ctx, r.prefix+key)
+	pipe.ExpireNX(ctx, r.prefix+key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to increment counter: %w", err)
+	}
+	return count.Val(), nil
+}
@@ -23,48 +23,36 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/cv": {
-            "post": {
+        "/admin/backups": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload a CV file (PDF, DOC, DOCX) to user's profile",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "List the completed backups in BACKUP_BUCKET_NAME, newest first, with the documents exported per collection. Requires an administrator (ADMIN_EMAILS).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
-                ],
-                "summary": "Upload CV",
-                "parameters": [
-                    {
-                        "type": "file",
-                        "description": "CV file (PDF, DOC, DOCX)",
-                        "name": "cv_file",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "Admin"
                 ],
+                "summary": "List backups",
                 "responses": {
                     "200": {
-                        "description": "CV uploaded successfully",
+                        "description": "Completed backups",
                         "schema": {
-                            "$ref": "#/definitions/models.CVUploadResponse"
+                            "$ref": "#/definitions/models.BackupListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid file",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Not an administrator",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -74,49 +62,50 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "501": {
+                        "description": "Backups are not configured",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/auth/google": {
+            },
             "post": {
-                "description": "Login or register using Google SSO ID token",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Export the users (with the indexes that find them), profiles and jobs collections to BACKUP_BUCKET_NAME as JSONL, in a folder named by the backup ID. Runs to completion even if the client disconnects; backups past BACKUP_RETENTION_DAYS are deleted afterwards. Requires an administrator (ADMIN_EMAILS).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Login with Google",
-                "parameters": [
-                    {
-                        "description": "Google auth request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                "summary": "Take a backup",
+                "responses": {
+                    "201": {
+                        "description": "Completed backup",
                         "schema": {
-                            "$ref": "#/definitions/models.GoogleAuthRequest"
+                            "$ref": "#/definitions/models.BackupManifest"
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Login successful",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/models.AuthResponse"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "403": {
+                        "description": "Not an administrator",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Invalid Google token",
+                    "409": {
+                        "description": "A backup or restore is already running",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -126,13 +115,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "501": {
+                        "description": "Backups are not configured",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/auth/login": {
+        "/admin/backups/{id}/restore": {
             "post": {
-                "description": "Login with email and password to get JWT token",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Write the documents of a backup's collections (all of them unless listed) back to the database, replacing documents with the same IDs; documents created since the backup are kept. Restore users together with user_emails and user_google_ids on Firestore, or restored users can't sign in. Backups restore only into the storage backend they were taken from. Requires an administrator (ADMIN_EMAILS).",
                 "consumes": [
                     "application/json"
                 ],
@@ -140,35 +140,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Login user",
+                "summary": "Restore a backup",
                 "parameters": [
                     {
-                        "description": "Login request",
+                        "type": "string",
+                        "description": "Backup ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Collections to restore",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
+                            "$ref": "#/definitions/models.RestoreBackupRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful",
+                        "description": "Documents restored",
                         "schema": {
-                            "$ref": "#/definitions/models.AuthResponse"
+                            "$ref": "#/definitions/models.RestoreBackupResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body, or a collection the backup doesn't hold",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an administrator",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Backup not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A backup or restore is already running, or the backup is from another storage backend",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -178,30 +202,45 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "501": {
+                        "description": "Backups are not configured",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/auth/profile": {
+        "/admin/llm-debug/{requestId}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the authenticated user's profile information",
+                "description": "Get the redacted prompts and raw Gemini responses recorded for a request, oldest first. The request ID is returned in the X-Request-ID header of every response. Records are only kept when LLM_DEBUG_LOG_ENABLED=true, for LLM_DEBUG_LOG_TTL_HOURS. Requires an administrator (ADMIN_EMAILS).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
+                ],
+                "summary": "Get LLM debug records",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Request ID (X-Request-ID)",
+                        "name": "requestId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
-                        "description": "User profile",
+                        "description": "Recorded Gemini calls",
                         "schema": {
-                            "$ref": "#/definitions/models.ProfileResponse"
+                            "$ref": "#/definitions/models.LLMDebugResponse"
                         }
                     },
                     "401": {
@@ -210,57 +249,65 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not an administrator",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "User not found",
+                        "description": "No records for the request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/prompt-experiments": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the authenticated user's profile (name)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Compare the scoring and extraction prompt variants searches were assigned to (PROMPT_EXPERIMENT_VARIANTS) over the last N days: searches, extraction error rate, Gemini score distribution, heuristic fallback rate and user feedback on the jobs each variant scored. Requires an administrator (ADMIN_EMAILS).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Update user profile",
+                "summary": "Get prompt experiment report",
                 "parameters": [
                     {
-                        "description": "Update profile request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateProfileRequest"
-                        }
+                        "type": "integer",
+                        "description": "Days to cover (default 30, max 180)",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Profile updated",
+                        "description": "Metrics per prompt variant",
                         "schema": {
-                            "$ref": "#/definitions/models.ProfileResponse"
+                            "$ref": "#/definitions/models.PromptExperimentReport"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Not an administrator",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -274,45 +321,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Register a new user with email and password",
-                "consumes": [
-                    "application/json"
+        "/admin/tool-audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Get recorded MCP and agent tool calls, newest first, with caller, duration, outcome and redacted arguments, plus call totals per tool. Filter by tool, caller email or API token. Calls are recorded when TOOL_AUDIT_ENABLED=true, for TOOL_AUDIT_TTL_DAYS. Requires an administrator (ADMIN_EMAILS).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Register a new user",
+                "summary": "Get tool audit log",
                 "parameters": [
                     {
-                        "description": "Registration request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.RegisterRequest"
-                        }
+                        "type": "string",
+                        "description": "Tool name",
+                        "name": "tool",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "API token ID",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days to cover (default 30, max 180)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Records to return (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Registration successful",
+                    "200": {
+                        "description": "Recorded tool calls",
                         "schema": {
-                            "$ref": "#/definitions/models.AuthResponse"
+                            "$ref": "#/definitions/models.ToolAuditResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "User already exists",
+                    "403": {
+                        "description": "Not an administrator",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -326,176 +396,379 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
+        "/admin/tools": {
             "get": {
-                "description": "Check if the server is running and healthy",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every registered MCP tool with its version and whether it is enabled, with the reason for disabled tools. Requires an administrator (ADMIN_EMAILS).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
+                    "Admin"
                 ],
-                "summary": "Health check",
+                "summary": "List tool switches",
                 "responses": {
                     "200": {
-                        "description": "Server is healthy",
+                        "description": "Registered tools",
                         "schema": {
-                            "$ref": "#/definitions/models.HealthResponse"
+                            "$ref": "#/definitions/models.ToolStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an administrator",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/parse-cv": {
-            "post": {
-                "description": "Parse a CV file or text and extract structured profile information using AI",
+        "/admin/tools/{name}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turn a tool on or off for MCP clients, agent search and the career assistant without redeploying, e.g. stop fetch_page_html when a job board objects to scraping. The switch overrides DISABLED_TOOLS, applies immediately on this instance and reaches the others within TOOL_SETTINGS_REFRESH_SECONDS. Calls to a disabled tool fail with the tool_disabled code. Requires an administrator (ADMIN_EMAILS).",
                 "consumes": [
-                    "application/json",
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "CV"
+                    "Admin"
                 ],
-                "summary": "Parse CV",
+                "summary": "Enable or disable a tool",
                 "parameters": [
                     {
-                        "description": "CV parse request (JSON)",
+                        "type": "string",
+                        "description": "Tool name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Whether the tool is enabled, and why",
                         "name": "request",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CVParseRequest"
+                            "$ref": "#/definitions/models.UpdateToolSettingRequest"
                         }
-                    },
-                    {
-                        "type": "file",
-                        "description": "CV file to parse",
-                        "name": "cv_file",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "CV text content",
-                        "name": "cv_text",
-                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Parsed CV profile",
+                        "description": "Updated switch",
                         "schema": {
-                            "$ref": "#/definitions/models.CVParseResponse"
+                            "$ref": "#/definitions/models.ToolSetting"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Parsing failed",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
-                    }
+                    },
+                    "403": {
+                        "description": "Not an administrator",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tool not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "/search-jobs": {
-            "post": {
+        "/admin/users": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Search for jobs using CV file/text and/or search query. Supports multipart/form-data for CV upload. Authentication optional - if authenticated and save_cv=true, CV will be saved to profile.",
-                "consumes": [
-                    "application/json",
-                    "multipart/form-data"
-                ],
+                "description": "List user accounts, newest first, filtered by sign-in provider, creation date, whether a CV is uploaded and whether the account is suspended. Pass next_cursor from a response as cursor to get the next page. Requires an administrator (ADMIN_EMAILS).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Jobs"
+                    "Admin"
                 ],
-                "summary": "Search for jobs",
+                "summary": "List users",
                 "parameters": [
                     {
-                        "description": "Search request (JSON)",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/models.SearchJobsRequest"
-                        }
+                        "enum": [
+                            "email",
+                            "google"
+                        ],
+                        "type": "string",
+                        "description": "Sign-in provider",
+                        "name": "provider",
+                        "in": "query"
                     },
                     {
-                        "type": "file",
-                        "description": "CV file (PDF, DOC, DOCX, TXT) - processed by AI",
-                        "name": "cv_file",
-                        "in": "formData"
+                        "type": "string",
+                        "description": "Created on or after this date (YYYY-MM-DD, UTC)",
+                        "name": "created_after",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "CV text content",
-                        "name": "cv_text",
-                        "in": "formData"
+                        "description": "Created on or before this date (YYYY-MM-DD, UTC)",
+                        "name": "created_before",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Search query",
-                        "name": "query",
-                        "in": "formData"
+                        "type": "boolean",
+                        "description": "Only users with (true) or without (false) a CV",
+                        "name": "has_cv",
+                        "in": "query"
                     },
                     {
                         "type": "boolean",
-                        "description": "Save CV to profile (requires authentication)",
-                        "name": "save_cv",
-                        "in": "formData"
+                        "description": "Only suspended (true) or active (false) users",
+                        "name": "suspended",
+                        "in": "query"
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Location filters",
-                        "name": "locations",
-                        "in": "formData"
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Remote mode filters (remote, hybrid, onsite)",
-                        "name": "remote_modes",
-                        "in": "formData"
+                        "type": "integer",
+                        "description": "Users per page (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users",
+                        "schema": {
+                            "$ref": "#/definitions/models.AdminUserListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an administrator",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/migrate-ids": {
+            "post": {
+                "security": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Job type filters (full-time, part-time, contract)",
-                        "name": "job_types",
-                        "in": "formData"
+                        "BearerAuth": []
                     }
                 ],
+                "description": "Move user accounts created before user IDs (whose documents are keyed by their email) to random IDs with an email index entry. Safe to run repeatedly and while the API is serving; accounts are also moved when their email changes. Requires an administrator (ADMIN_EMAILS).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Migrate user IDs",
                 "responses": {
                     "200": {
-                        "description": "Search results",
+                        "description": "Users moved",
                         "schema": {
-                            "$ref": "#/definitions/models.SearchJobsResponse"
+                            "$ref": "#/definitions/models.UserIDMigrationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an administrator",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/reactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lift the suspension of a user account, so it can sign in and use its API tokens again. Requires an administrator (ADMIN_EMAILS).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Reactivate user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reactivated user",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an administrator",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/suspend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Suspend a user account: it can no longer sign in, and its sessions and API tokens are refused with 403 (on other instances within SUSPENSION_CHECK_CACHE_SECONDS). The account's data is kept. Administrators can't suspend themselves. Requires an administrator (ADMIN_EMAILS).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Suspend user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Why the account is suspended",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuspendUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Suspended user",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request body or own account",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an administrator",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -509,364 +782,6786 @@ const docTemplate = `{
                 }
             }
         },
-        "/tools": {
-            "get": {
-                "description": "Get a list of all available MCP tools for AI agents",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Tools"
-                ],
-                "summary": "List available tools",
-                "responses": {
-                    "200": {
-                        "description": "List of tools",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "models.AuthResponse": {
-            "description": "Authentication response with JWT token",
+        "/alerts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated user's saved searches that are rerun on a schedule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "List job alerts",
+                "responses": {
+                    "200": {
+                        "description": "Job alerts",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobAlertResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Save a query and filters to be rerun daily or weekly. Each run is scored against the saved CV and only postings not seen in earlier runs, scoring at or above minScore, are emailed. The query may be omitted if the user has a saved CV.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Create a job alert",
+                "parameters": [
+                    {
+                        "description": "Search to save",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateJobAlertRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Job alert created",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobAlertResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or alert limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/alerts/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop a saved search from being rerun",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Delete a job alert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job alert ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job alert deleted",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobAlertResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job alert not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/applications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated user's tracked job applications, most recently updated first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "List applications",
+                "parameters": [
+                    {
+                        "enum": [
+                            "applied",
+                            "interviewing",
+                            "offer",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Applications",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApplicationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record a job the user has applied to. Status defaults to applied and appliedAt defaults to now.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Track an application",
+                "parameters": [
+                    {
+                        "description": "Application to track",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateApplicationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Application created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApplicationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/applications/board": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated user's applications grouped into one column per status (applied, interviewing, offer, rejected), suitable for a kanban view",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Application board",
+                "responses": {
+                    "200": {
+                        "description": "Applications grouped by status",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApplicationBoardResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/applications/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one of the authenticated user's tracked applications, including its status history",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Get an application",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Application",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApplicationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Application not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an application from the authenticated user's tracker",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Delete an application",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Application deleted",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApplicationListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Application not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move an application to a new status or edit its notes and dates. Only fields present in the body are changed; status changes are appended to the status history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Applications"
+                ],
+                "summary": "Update an application",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateApplicationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Application updated",
+                        "schema": {
+                            "$ref": "#/definitions/models.ApplicationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Application not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/cv": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a CV file (PDF, DOC, DOCX, TXT) to user's profile. The file content must match its extension.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Upload CV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CV file (PDF, DOC, DOCX, TXT)",
+                        "name": "cv_file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CV uploaded successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.CVUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported file type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/cv/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check a CV uploaded through POST /auth/cv/upload-url and save it to the user's profile, like POST /auth/cv. Files that are too large or whose content doesn't match their extension are deleted and rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Confirm a direct CV upload",
+                "parameters": [
+                    {
+                        "description": "Uploaded CV",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CVUploadConfirmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CV uploaded successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.CVUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Uploaded CV not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported file type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Direct uploads not supported by the CV storage",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/cv/upload-url": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a signed URL that uploads a CV (PDF, DOC, DOCX, TXT) straight from the browser to Cloud Storage, so large files don't pass through the API. POST to uploadUrl with the returned headers and an empty body to start a resumable upload, PUT the file to the session URL in the response's Location header, then call POST /auth/cv/confirm with cvUrl. The bucket rejects files larger than maxBytes. Only available with the gcs CV storage driver.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get a direct CV upload URL",
+                "parameters": [
+                    {
+                        "description": "CV file name",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CVUploadURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Upload URL",
+                        "schema": {
+                            "$ref": "#/definitions/models.CVUploadURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported file type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Direct uploads not supported by the CV storage",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/email": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start changing the authenticated user's email. A verification token is emailed to the new address (as a link to EMAIL_CHANGE_CONFIRM_URL when set) and the change takes effect once it is confirmed with POST /auth/email/confirm. Accounts with a password must confirm it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Request an email change",
+                "parameters": [
+                    {
+                        "description": "New email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangeEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Verification email sent",
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangeEmailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or wrong password",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already in use",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/email/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Complete an email change with the token from the verification email. The account moves to the new email and keeps its saved profile and everything it owns; a new token is returned, and sessions signed in before the change must sign in again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Confirm an email change",
+                "parameters": [
+                    {
+                        "description": "Verification token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ConfirmEmailChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Email changed",
+                        "schema": {
+                            "$ref": "#/definitions/models.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already in use",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/google": {
+            "post": {
+                "description": "Login or register using Google SSO ID token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login with Google",
+                "parameters": [
+                    {
+                        "description": "Google auth request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GoogleAuthRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "$ref": "#/definitions/models.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid Google token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Account suspended",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Google account is already linked to another user",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Login with email and password to get JWT token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "$ref": "#/definitions/models.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Account suspended",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/photo": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a profile photo (JPEG, PNG, GIF). The image is center-cropped and resized to a square JPEG avatar; any previous photo is replaced.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Upload profile photo",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Photo file (JPEG, PNG, GIF)",
+                        "name": "photo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Photo uploaded successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.PhotoUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported file type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the authenticated user's profile photo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Delete profile photo",
+                "responses": {
+                    "200": {
+                        "description": "Photo removed",
+                        "schema": {
+                            "$ref": "#/definitions/models.PhotoUploadResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No photo to remove",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated user's profile information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "User profile",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the authenticated user's profile (name, incognito search setting)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "description": "Update profile request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user with email and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "Registration request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegisterRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes a retry of this request return a new token for the account it created",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Registration successful",
+                        "schema": {
+                            "$ref": "#/definitions/models.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists, or a request with the same Idempotency-Key is running",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key already used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/blocked-companies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the companies hidden from the authenticated user's searches and alerts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blocklist"
+                ],
+                "summary": "Get blocked companies",
+                "responses": {
+                    "200": {
+                        "description": "Blocked companies",
+                        "schema": {
+                            "$ref": "#/definitions/models.BlockedCompaniesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the list of companies hidden from the authenticated user's searches and alerts, e.g. a current employer. Postings from these companies are dropped before scoring. Names match ignoring case and legal suffixes such as \"PT\" or \"Tbk\".",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blocklist"
+                ],
+                "summary": "Update blocked companies",
+                "parameters": [
+                    {
+                        "description": "Companies to block",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BlockedCompaniesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Blocked companies updated",
+                        "schema": {
+                            "$ref": "#/definitions/models.BlockedCompaniesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a message to the career assistant. Gemini answers career questions and may read the user's saved profile and saved jobs, research companies, tailor the CV and run job searches (refining the query and filters as the conversation goes) before replying. Omit session_id to start a conversation; the reply's session_id continues it. Conversations are stored for CHAT_SESSION_TTL_DAYS after the last message, and the last CHAT_HISTORY_MESSAGES messages are sent with each turn.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chat"
+                ],
+                "summary": "Chat with the career assistant",
+                "parameters": [
+                    {
+                        "description": "Message",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChatRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Assistant reply",
+                        "schema": {
+                            "$ref": "#/definitions/models.ChatResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Message blocked by the AI safety filters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Gemini rate limit or quota reached; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "AI service temporarily unavailable; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a career assistant conversation with its messages, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chat"
+                ],
+                "summary": "Get a conversation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Conversation",
+                        "schema": {
+                            "$ref": "#/definitions/models.ChatSession"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/cv/tailor": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rewrite the professional summary and reorder skills to fit a job posting, and list experience highlights and missing skills. Uses cv_text when provided, otherwise the authenticated user's saved profile.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CV"
+                ],
+                "summary": "Tailor CV to a job",
+                "parameters": [
+                    {
+                        "description": "Job posting and optional CV text",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TailorCVRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tailored CV sections",
+                        "schema": {
+                            "$ref": "#/definitions/models.TailorCVResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "CV or posting blocked by the AI safety filters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Gemini rate limit or quota reached; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Tailoring failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "AI service temporarily unavailable; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Report the server's health and the latest probe of each dependency (database, CV storage, LLM provider, PSE, Redis). Probe results are cached briefly. Always 200; status is degraded when a non-critical dependency is down and unhealthy when a critical one is.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "Server and dependency health",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Check that the server is running. Dependencies are not probed, so an outage elsewhere doesn't get instances restarted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "Server is running",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Probe the dependencies (results are cached briefly) and fail with 503 while a critical dependency (database, CV storage) is down. A non-critical outage (LLM provider, PSE, Redis) is reported as degraded with 200.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "Ready to serve traffic",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "A critical dependency is down",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/insights": {
+            "get": {
+                "description": "Aggregate jobs returned by searches in the last N days into the most demanded skills, salary ranges by role and location, weekly posting volume and work type / site setting mix. Optionally narrowed by role (title keyword) and location.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Insights"
+                ],
+                "summary": "Get job market insights",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Days to cover (default 30, max 180)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only jobs whose title contains this text, e.g. backend",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only jobs whose location contains this text, e.g. jakarta",
+                        "name": "location",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Insights report",
+                        "schema": {
+                            "$ref": "#/definitions/models.InsightsReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "get": {
+                "description": "List jobs from the stored corpus of extracted postings seen in the last N days, most recently seen first. Company, work type, site setting and source must match exactly; location matches postings whose location contains the text. With url, only the posting stored for that URL is returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "List stored jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Posting URL",
+                        "name": "url",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Company name",
+                        "name": "company",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text the location contains, e.g. jakarta",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "full_time, part_time, contract or internship",
+                        "name": "work_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "WFH, WFO or Hybrid",
+                        "name": "site_setting",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Source, e.g. web or greenhouse",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days to cover (default 30, max 180)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum jobs to return (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stored jobs",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/saved": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the jobs the authenticated user bookmarked, most recently saved first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Jobs"
+                ],
+                "summary": "List saved jobs",
+                "responses": {
+                    "200": {
+                        "description": "Saved jobs",
+                        "schema": {
+                            "$ref": "#/definitions/models.SavedJobsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bookmark a job as returned by a search, including its match score. Saving a job already saved (same URL) returns the existing entry.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Jobs"
+                ],
+                "summary": "Save a job",
+                "parameters": [
+                    {
+                        "description": "Job to save",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SaveJobRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job already saved",
+                        "schema": {
+                            "$ref": "#/definitions/models.SavedJobsResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Job saved",
+                        "schema": {
+                            "$ref": "#/definitions/models.SavedJobsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or saved job limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/saved/rescore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Re-run match scoring for saved jobs against the current structured profile, e.g. after uploading a new CV or editing the profile. Each request re-scores up to 25 jobs, least recently scored first; call again while remaining is above 0. Jobs that fail to score keep their previous score.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Jobs"
+                ],
+                "summary": "Re-score saved jobs",
+                "responses": {
+                    "200": {
+                        "description": "Saved jobs re-scored",
+                        "schema": {
+                            "$ref": "#/definitions/models.RescoreSavedJobsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No profile or CV saved",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/saved/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a job from the authenticated user's saved jobs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Jobs"
+                ],
+                "summary": "Remove a saved job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Saved job removed",
+                        "schema": {
+                            "$ref": "#/definitions/models.SavedJobsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Saved job not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/summarize": {
+            "post": {
+                "description": "Condense a long posting into a one-sentence overview and short bullets (responsibilities, must-have and nice-to-have skills, red flags) for mobile display. The page is fetched unless description holds the posting's text. Summaries are cached for JOB_SUMMARY_CACHE_TTL_HOURS by URL, and by URL and text when description is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Summarize a job posting",
+                "parameters": [
+                    {
+                        "description": "Posting to summarize",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.JobSummaryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Posting summary",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Posting blocked by the AI safety filters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Gemini rate limit or quota reached; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Posting page could not be fetched",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "AI service temporarily unavailable; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the full posting of a job returned by a search, by the stable ID in its \"id\" field. Authenticated users also get their latest match score and score breakdown for the job.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get a job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Job details",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobDetailResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/feedback": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a job from search results as relevant, not_relevant or already_applied. Jobs marked not relevant or already applied are hidden from later searches, and recent ratings are used as examples when scoring new matches. Rating a job again replaces the earlier rating.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Rate a job match",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rating",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.JobFeedbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Feedback recorded",
+                        "schema": {
+                            "$ref": "#/definitions/models.JobFeedbackResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/similar": {
+            "get": {
+                "description": "Find other jobs seen in searches that share skills/tags and title keywords with a job, most similar first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get similar jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum jobs to return (default 10, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Similar jobs",
+                        "schema": {
+                            "$ref": "#/definitions/models.SimilarJobsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/parse-cv": {
+            "post": {
+                "description": "Parse a CV file or text and extract structured profile information using AI",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "CV"
+                ],
+                "summary": "Parse CV",
+                "parameters": [
+                    {
+                        "description": "CV parse request (JSON)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.CVParseRequest"
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "CV file to parse",
+                        "name": "cv_file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CV text content",
+                        "name": "cv_text",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gemini model for parsing, from GEMINI_ALLOWED_MODELS (default GEMINI_MODEL)",
+                        "name": "model",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Parsed CV profile",
+                        "schema": {
+                            "$ref": "#/definitions/models.CVParseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "CV blocked by the AI safety filters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Gemini rate limit or quota reached; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Parsing failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "AI service temporarily unavailable; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/structured": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the structured profile extracted from the user's CV, including any corrections made by the user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Get structured profile",
+                "responses": {
+                    "200": {
+                        "description": "Structured profile",
+                        "schema": {
+                            "$ref": "#/definitions/models.StructuredProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No profile or CV saved",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Correct AI-extracted profile fields. Only fields present in the body are updated; list fields replace the stored list. Edited profiles are used in searches instead of re-parsing the CV.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Update structured profile",
+                "parameters": [
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateStructuredProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated",
+                        "schema": {
+                            "$ref": "#/definitions/models.StructuredProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated user's past searches (query, filters, stats and result count), newest first. Rerun a search by posting its query and filters to /search-jobs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search History"
+                ],
+                "summary": "List search history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum entries to return (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search history",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchHistoryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search-history/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a past search from the authenticated user's history",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search History"
+                ],
+                "summary": "Delete search history entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search history entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Entry deleted",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchHistoryResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search-jobs": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Search for jobs using CV file/text and/or search query. Supports multipart/form-data for CV upload. Authentication optional - if authenticated and save_cv=true, CV will be saved to profile.",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Search for jobs",
+                "parameters": [
+                    {
+                        "description": "Search request (JSON)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchJobsRequest"
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "CV file (PDF, DOC, DOCX, TXT) - processed by AI",
+                        "name": "cv_file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CV text content",
+                        "name": "cv_text",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "query",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Save CV to profile (requires authentication)",
+                        "name": "save_cv",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Location filters",
+                        "name": "locations",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Remote mode filters (remote, hybrid, onsite)",
+                        "name": "remote_modes",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Job type filters (full-time, part-time, contract)",
+                        "name": "job_types",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Strip name, email and phone from the profile before scoring",
+                        "name": "incognito",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max Gemini calls for this search",
+                        "name": "max_gemini_calls",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max Gemini tokens for this search",
+                        "name": "max_tokens",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Max estimated Gemini cost (USD) for this search",
+                        "name": "max_cost_usd",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max results to return (capped by server limit)",
+                        "name": "max_results",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max pages to fetch and extract (capped by server limit)",
+                        "name": "max_pages_to_process",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gemini model for this search, from GEMINI_ALLOWED_MODELS (default GEMINI_MODEL)",
+                        "name": "model",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request replay its response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search results",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchJobsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or model not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A request with the same Idempotency-Key is running",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "CV file too large",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported CV file type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "CV or query blocked by the AI safety filters, or Idempotency-Key already used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Gemini rate limit or quota reached; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "AI service temporarily unavailable; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search-jobs/agent": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Answer an open-ended request (e.g. \"compare my fit for fintech vs e-commerce roles\") by letting Gemini call the search, fetch, extract, scoring and company research tools in a loop, instead of running the fixed search pipeline. The loop is bounded by max_steps (capped by AGENT_MAX_STEPS) and the LLM budget. Authenticated users' saved profile and feedback are used unless cvText is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Agent job search",
+                "parameters": [
+                    {
+                        "description": "Agent search request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AgentSearchRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request replay its response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Answer and tool calls",
+                        "schema": {
+                            "$ref": "#/definitions/models.AgentSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A request with the same Idempotency-Key is running",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Request blocked by the AI safety filters, or Idempotency-Key already used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Gemini rate limit or quota reached; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "AI service temporarily unavailable; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search-jobs/async": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Same input as POST /search-jobs, but returns a search ID immediately and runs the search in the background. Poll GET /search-jobs/{id} for status and results, or have the finished search posted to callback_url (signed with the returned callback_secret) or, without one, to the user's webhook.",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Start a background job search",
+                "parameters": [
+                    {
+                        "description": "Search request (JSON)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchJobsRequest"
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "CV file (PDF, DOC, DOCX, TXT) - processed by AI",
+                        "name": "cv_file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "CV text content",
+                        "name": "cv_text",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "query",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "URL the finished search is posted to",
+                        "name": "callback_url",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request replay its response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Search queued",
+                        "schema": {
+                            "$ref": "#/definitions/models.AsyncSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or callback URL",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A request with the same Idempotency-Key is running",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "CV file too large",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported CV file type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key already used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many searches in progress",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search-jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Poll a background search. Results are included once status is \"completed\"; error is set if it \"failed\". Searches started by an authenticated user are only visible to that user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get background search status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search status",
+                        "schema": {
+                            "$ref": "#/definitions/models.AsyncSearch"
+                        }
+                    },
+                    "404": {
+                        "description": "Search not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search-jobs/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the ranked results of a completed background search as a CSV file (title, company, location, work type, site setting, salary, score, reason, URL) for tracking applications in a spreadsheet. The file opens directly in Excel and Google Sheets.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Export search results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Export format (only csv is supported)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Unsupported format",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Search not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Search has not completed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search-jobs/{id}/resume": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Searches still running when an instance shuts down are stopped with status \"interrupted\". Those with \"resumable\" set can be queued again under the same ID with the input they were started with; poll GET /search-jobs/{id} as before. Searches with an uploaded CV file, and incognito searches with CV text, are not resumable.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Resume an interrupted background search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Search queued",
+                        "schema": {
+                            "$ref": "#/definitions/models.AsyncSearchResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Search not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Search is not resumable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Too many searches in progress, or the server is shutting down",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search-sessions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get what went into a search and what came out of it: the input, the resolved profile, the planned web queries, pipeline stats, LLM usage and the returned jobs in rank order with their scores and reasons. The session ID is returned as session_id by searches. Sessions of searches by an authenticated user are only visible to that user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search History"
+                ],
+                "summary": "Get a search session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search session",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchSession"
+                        }
+                    },
+                    "404": {
+                        "description": "Search session not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search-sessions/{id}/results": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the returned jobs of a recorded search in rank order, with the scores they had in that search. Postings are read from the stored jobs, so results whose posting is no longer stored are skipped.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search History"
+                ],
+                "summary": "Page through a search session's results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum results to return (default 10, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of results",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchSessionResultsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Search session not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shared": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the search result snapshots the authenticated user has shared, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sharing"
+                ],
+                "summary": "List share links",
+                "responses": {
+                    "200": {
+                        "description": "Share links",
+                        "schema": {
+                            "$ref": "#/definitions/models.SharedSearchListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publish a read-only snapshot of ranked jobs (by the \"id\" of jobs returned by a search) that anyone with the link can view, e.g. a mentor. Scores are the user's latest scores for each job. With includeProfile, skills and experience are included without name, contact details or salary expectations.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sharing"
+                ],
+                "summary": "Share search results",
+                "parameters": [
+                    {
+                        "description": "Jobs to share",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateSharedSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Share link created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SharedSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, unknown jobs or share limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shared/{token}": {
+            "get": {
+                "description": "Get a shared snapshot of search results by its token. No authentication required.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sharing"
+                ],
+                "summary": "View shared search results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shared results",
+                        "schema": {
+                            "$ref": "#/definitions/models.SharedSearch"
+                        }
+                    },
+                    "404": {
+                        "description": "Share link not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke one of the authenticated user's share links; the link stops working immediately",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sharing"
+                ],
+                "summary": "Delete share link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Share link deleted",
+                        "schema": {
+                            "$ref": "#/definitions/models.SharedSearchListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Share link not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated user's personal access tokens with their scopes, expiry and last use. Secrets are never returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Tokens"
+                ],
+                "summary": "List API tokens",
+                "responses": {
+                    "200": {
+                        "description": "API tokens",
+                        "schema": {
+                            "$ref": "#/definitions/models.APITokenListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mint a personal access token for scripting against the API. Send it as \"Authorization: Bearer \u003ctoken\u003e\". Scopes: search (POST /search-jobs), read-profile (GET /profile/structured), mcp (the /mcp endpoints, optionally limited to mcpTools and mcpRateLimitPerMinute). The token is shown only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Tokens"
+                ],
+                "summary": "Create API token",
+                "parameters": [
+                    {
+                        "description": "Token name, scopes and expiry",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateAPITokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Token created",
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateAPITokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or token limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke a personal access token; requests using it are rejected immediately",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Tokens"
+                ],
+                "summary": "Revoke API token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token revoked",
+                        "schema": {
+                            "$ref": "#/definitions/models.APITokenListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tools": {
+            "get": {
+                "description": "Get a list of all available MCP tools for AI agents, with each tool's version and, for deprecated tools, the deprecation notice",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tools"
+                ],
+                "summary": "List available tools",
+                "responses": {
+                    "200": {
+                        "description": "List of tools",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/watchlist": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the companies the authenticated user follows for new-posting alerts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist"
+                ],
+                "summary": "List watched companies",
+                "responses": {
+                    "200": {
+                        "description": "Watched companies",
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchlistResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Follow a company by careers page URL or ATS board (greenhouse, lever, workable). New postings scoring at or above minScore are sent as alerts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist"
+                ],
+                "summary": "Follow a company",
+                "parameters": [
+                    {
+                        "description": "Company to follow",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchCompanyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Company added",
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchlistResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/watchlist/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a company from the authenticated user's watchlist",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watchlist"
+                ],
+                "summary": "Unfollow a company",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watched company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Company removed",
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchlistResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Watched company not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the URL the authenticated user's finished background searches are posted to. The signing secret is only returned when the webhook is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get webhook",
+                "responses": {
+                    "200": {
+                        "description": "Webhook",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No webhook set",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Post the authenticated user's finished background searches (POST /search-jobs/async without a callback_url) to a URL, e.g. a Zapier catch hook. Each delivery is a JSON event signed with the returned secret in the X-MyJobMatch-Signature header. Setting the webhook again replaces the secret.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Set webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook URL",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook set, with its signing secret",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or webhook URL",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop posting the authenticated user's finished background searches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete webhook",
+                "responses": {
+                    "200": {
+                        "description": "Webhook deleted",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.APIToken": {
+            "description": "Personal access token metadata (the secret is only returned on creation)",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f2a9c1e"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "mcpRateLimitPerMinute": {
+                    "description": "MCP tool calls per minute; 0 uses the server limit",
+                    "type": "integer",
+                    "example": 10
+                },
+                "mcpTools": {
+                    "description": "MCP tools the token may call; empty allows all",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "search_web_for_jobs",
+                        "score_job_match"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "nightly search script"
+                },
+                "prefix": {
+                    "description": "First characters of the token, for identification",
+                    "type": "string",
+                    "example": "mjm_pat_Ab3x"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "search",
+                        "read-profile"
+                    ]
+                }
+            }
+        },
+        "models.APITokenListResponse": {
+            "description": "Personal access tokens",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "tokens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.APIToken"
+                    }
+                }
+            }
+        },
+        "models.AdminUserListResponse": {
+            "description": "Users matching the filters, newest first; pass next_cursor as cursor to get the next page",
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "Empty on the last page",
+                    "type": "string",
+                    "example": "7b0e3c5a-2f4d-4c1e-9a8b-5d6f7e8a9b0c"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
+                }
+            }
+        },
+        "models.AgentSearchRequest": {
+            "type": "object",
+            "required": [
+                "query"
+            ],
+            "properties": {
+                "budget": {
+                    "description": "Optional caps on Gemini usage",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LLMBudget"
+                        }
+                    ]
+                },
+                "cvText": {
+                    "description": "Optional; authenticated users' saved profile is used otherwise",
+                    "type": "string"
+                },
+                "incognito": {
+                    "description": "Strip name, email and phone before the profile is sent to the model",
+                    "type": "boolean",
+                    "example": false
+                },
+                "max_steps": {
+                    "description": "Tool-calling turns (capped by server limit)",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 6
+                },
+                "query": {
+                    "type": "string",
+                    "example": "Compare my fit for fintech vs e-commerce backend roles in Jakarta"
+                }
+            }
+        },
+        "models.AgentSearchResponse": {
+            "description": "Agent search answer and tool calls",
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "llm_usage": {
+                    "$ref": "#/definitions/models.LLMUsage"
+                },
+                "profile": {
+                    "$ref": "#/definitions/models.UserProfile"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AgentStep"
+                    }
+                },
+                "steps_exhausted": {
+                    "description": "True if the step budget ran out before the model finished",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.AgentStep": {
+            "description": "Tool call made during an agent search",
+            "type": "object",
+            "properties": {
+                "arguments": {
+                    "type": "object"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "tool": {
+                    "type": "string",
+                    "example": "search_web_for_jobs"
+                }
+            }
+        },
+        "models.Application": {
+            "description": "Job application in the user's tracker",
+            "type": "object",
+            "properties": {
+                "appliedAt": {
+                    "type": "string"
+                },
+                "company": {
+                    "type": "string",
+                    "example": "TechCorp"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "f3a9c1e2"
+                },
+                "jobTitle": {
+                    "type": "string",
+                    "example": "Senior Golang Backend Engineer"
+                },
+                "jobUrl": {
+                    "type": "string",
+                    "example": "https://example.com/job/123"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Jakarta"
+                },
+                "matchScore": {
+                    "type": "integer",
+                    "example": 92
+                },
+                "nextStepAt": {
+                    "description": "Next interview or follow-up date",
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Referred by Budi; tech interview with the payments team"
+                },
+                "status": {
+                    "description": "applied, interviewing, offer, rejected",
+                    "type": "string",
+                    "example": "interviewing"
+                },
+                "statusHistory": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ApplicationStatusChange"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ApplicationBoardColumn": {
+            "type": "object",
+            "properties": {
+                "applications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Application"
+                    }
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "status": {
+                    "type": "string",
+                    "example": "interviewing"
+                }
+            }
+        },
+        "models.ApplicationBoardResponse": {
+            "description": "Applications grouped by status, in pipeline order",
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ApplicationBoardColumn"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "models.ApplicationListResponse": {
+            "description": "Job applications",
+            "type": "object",
+            "properties": {
+                "applications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Application"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ApplicationResponse": {
+            "description": "Job application",
+            "type": "object",
+            "properties": {
+                "application": {
+                    "$ref": "#/definitions/models.Application"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Application updated"
+                }
+            }
+        },
+        "models.ApplicationStatusChange": {
+            "type": "object",
+            "properties": {
+                "changedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "interviewing"
+                }
+            }
+        },
+        "models.AsyncSearch": {
+            "description": "Background job search status and results",
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "Kq3xN0aB7cD9eF1gH2iJ"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "golang developer jakarta"
+                },
+                "result": {
+                    "$ref": "#/definitions/models.SearchJobsResponse"
+                },
+                "resumable": {
+                    "description": "Interrupted, and can be resumed with POST /search-jobs/{id}/resume",
+                    "type": "boolean"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending, running, completed, failed, interrupted",
+                    "type": "string",
+                    "example": "completed"
+                }
+            }
+        },
+        "models.AsyncSearchResponse": {
+            "description": "Queued background search",
+            "type": "object",
+            "properties": {
+                "callback_secret": {
+                    "description": "Signs the delivery to the request's callback_url; only returned when one was given",
+                    "type": "string",
+                    "example": "whsec_q1w2e3r4t5y6u7i8o9p0a1s2d3f4g5h6j7k8l9z0x1c"
+                },
+                "search_id": {
+                    "type": "string",
+                    "example": "Kq3xN0aB7cD9eF1gH2iJ"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "status_url": {
+                    "type": "string",
+                    "example": "/api/search-jobs/Kq3xN0aB7cD9eF1gH2iJ"
+                }
+            }
+        },
+        "models.AuthResponse": {
+            "description": "Authentication response with JWT token",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Login successful"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                }
+            }
+        },
+        "models.BackupListResponse": {
+            "description": "Completed backups, newest first",
+            "type": "object",
+            "properties": {
+                "backups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BackupManifest"
+                    }
+                }
+            }
+        },
+        "models.BackupManifest": {
+            "description": "A database backup: documents exported per collection",
+            "type": "object",
+            "properties": {
+                "backend": {
+                    "description": "STORAGE_BACKEND the backup restores into",
+                    "type": "string",
+                    "example": "firestore"
+                },
+                "collections": {
+                    "description": "Documents exported per collection",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "completedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Start time (UTC), also the backup's folder",
+                    "type": "string",
+                    "example": "20260115T030000Z"
+                },
+                "startedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BlockedCompaniesRequest": {
+            "description": "Companies to hide from all searches and alerts",
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "PT Current Employer Tbk"
+                    ]
+                }
+            }
+        },
+        "models.BlockedCompaniesResponse": {
+            "description": "Companies hidden from all searches and alerts",
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "PT Current Employer Tbk"
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Blocked companies updated"
+                }
+            }
+        },
+        "models.CVParseRequest": {
+            "description": "CV parsing request",
+            "type": "object",
+            "properties": {
+                "cv_text": {
+                    "type": "string",
+                    "example": "John Doe\nSoftware Engineer\nExperience: 5 years in Go, Python..."
+                },
+                "model": {
+                    "description": "Gemini model for parsing, from GEMINI_ALLOWED_MODELS (default GEMINI_MODEL)",
+                    "type": "string",
+                    "example": "gemini-2.5-pro"
+                }
+            }
+        },
+        "models.CVParseResponse": {
+            "description": "Parsed CV profile information",
+            "type": "object",
+            "properties": {
+                "profile": {
+                    "$ref": "#/definitions/models.UserProfile"
+                },
+                "raw": {
+                    "description": "Raw Gemini response for debugging",
+                    "type": "string"
+                }
+            }
+        },
+        "models.CVUploadConfirmRequest": {
+            "description": "The cvUrl returned with the upload URL",
+            "type": "object",
+            "required": [
+                "cvUrl"
+            ],
+            "properties": {
+                "cvUrl": {
+                    "type": "string",
+                    "example": "https://storage.googleapis.com/bucket/cvs/6f1c2a9e-3b7d-4e5f-8a10-2c4d6e8f0a1b/1700000000.pdf"
+                }
+            }
+        },
+        "models.CVUploadResponse": {
+            "description": "CV upload response",
+            "type": "object",
+            "properties": {
+                "cvUrl": {
+                    "type": "string",
+                    "example": "gs://bucket/cvs/user@example.com/resume.pdf"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "CV uploaded successfully"
+                }
+            }
+        },
+        "models.CVUploadURLRequest": {
+            "description": "Name of the CV file to upload directly to storage",
+            "type": "object",
+            "required": [
+                "filename"
+            ],
+            "properties": {
+                "filename": {
+                    "description": ".pdf, .doc, .docx or .txt",
+                    "type": "string",
+                    "example": "resume.pdf"
+                }
+            }
+        },
+        "models.CVUploadURLResponse": {
+            "description": "Start a resumable upload by POSTing to uploadUrl with the headers and an empty body, upload the file to the session URL returned in the Location header, then confirm with cvUrl",
+            "type": "object",
+            "properties": {
+                "cvUrl": {
+                    "type": "string",
+                    "example": "https://storage.googleapis.com/bucket/cvs/6f1c2a9e-3b7d-4e5f-8a10-2c4d6e8f0a1b/1700000000.pdf"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "maxBytes": {
+                    "type": "integer",
+                    "example": 5242880
+                },
+                "uploadUrl": {
+                    "type": "string",
+                    "example": "https://storage.googleapis.com/bucket/cvs/6f1c2a9e-3b7d-4e5f-8a10-2c4d6e8f0a1b/1700000000.pdf?X-Goog-Signature=..."
+                }
+            }
+        },
+        "models.ChangeEmailRequest": {
+            "description": "New email address; accounts with a password must confirm it",
+            "type": "object",
+            "required": [
+                "newEmail"
+            ],
+            "properties": {
+                "newEmail": {
+                    "type": "string",
+                    "example": "new@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "models.ChangeEmailResponse": {
+            "description": "Verification email sent to the new address",
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Verification email sent"
+                }
+            }
+        },
+        "models.ChatMessage": {
+            "description": "Message in a career assistant conversation",
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "Here are three backend roles in Jakarta that fit your Go experience..."
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "user or assistant",
+                    "type": "string",
+                    "example": "assistant"
+                },
+                "tools": {
+                    "description": "Tools the assistant called to answer",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "search_jobs"
+                    ]
+                }
+            }
+        },
+        "models.ChatRequest": {
+            "description": "Message to the career assistant; omit session_id to start a new conversation",
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "budget": {
+                    "description": "Optional caps on Gemini usage for this message",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LLMBudget"
+                        }
+                    ]
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Find me remote Go roles, but skip crypto companies"
+                },
+                "session_id": {
+                    "type": "string",
+                    "example": "c4Hq8ZpR2mXy"
+                }
+            }
+        },
+        "models.ChatResponse": {
+            "description": "Career assistant reply",
+            "type": "object",
+            "properties": {
+                "jobs": {
+                    "description": "Results of the last job search the assistant ran, if any",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RankedJob"
+                    }
+                },
+                "llm_usage": {
+                    "$ref": "#/definitions/models.LLMUsage"
+                },
+                "reply": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string",
+                    "example": "c4Hq8ZpR2mXy"
+                },
+                "steps": {
+                    "description": "Tool calls made to answer",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AgentStep"
+                    }
+                }
+            }
+        },
+        "models.ChatSession": {
+            "description": "Career assistant conversation",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "c4Hq8ZpR2mXy"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ChatMessage"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Company": {
+            "type": "object",
+            "properties": {
+                "industry": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rating": {
+                    "description": "Employee review rating out of 5, when found",
+                    "type": "number"
+                },
+                "size": {
+                    "description": "Employee count range, e.g. \"51-200\"",
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ConfirmEmailChangeRequest": {
+            "description": "Token from the verification email",
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "Zk3p9Q..."
+                }
+            }
+        },
+        "models.CreateAPITokenRequest": {
+            "description": "Personal access token creation request",
+            "type": "object",
+            "required": [
+                "mcpTools",
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expiresInDays": {
+                    "description": "Omit for a non-expiring token",
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1,
+                    "example": 90
+                },
+                "mcpRateLimitPerMinute": {
+                    "description": "Omit for the server limit; capped by it",
+                    "type": "integer",
+                    "maximum": 600,
+                    "minimum": 1,
+                    "example": 10
+                },
+                "mcpTools": {
+                    "description": "MCP tool allowlist and rate limit; only meaningful with the mcp scope",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "search_web_for_jobs",
+                        "score_job_match"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "nightly search script"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "search",
+                        "read-profile"
+                    ]
+                }
+            }
+        },
+        "models.CreateAPITokenResponse": {
+            "description": "Newly created personal access token",
+            "type": "object",
+            "properties": {
+                "details": {
+                    "$ref": "#/definitions/models.APIToken"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Store this token securely, it will not be shown again"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "mjm_pat_Ab3xQ9..."
+                }
+            }
+        },
+        "models.CreateApplicationRequest": {
+            "description": "Track a new job application",
+            "type": "object",
+            "required": [
+                "company",
+                "jobTitle"
+            ],
+            "properties": {
+                "appliedAt": {
+                    "description": "Defaults to now",
+                    "type": "string"
+                },
+                "company": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "TechCorp"
+                },
+                "jobTitle": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Senior Golang Backend Engineer"
+                },
+                "jobUrl": {
+                    "type": "string",
+                    "example": "https://example.com/job/123"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Jakarta"
+                },
+                "matchScore": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 92
+                },
+                "nextStepAt": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 5000,
+                    "example": "Applied via referral"
+                },
+                "status": {
+                    "description": "Defaults to applied",
+                    "type": "string",
+                    "enum": [
+                        "applied",
+                        "interviewing",
+                        "offer",
+                        "rejected"
+                    ],
+                    "example": "applied"
+                }
+            }
+        },
+        "models.CreateJobAlertRequest": {
+            "description": "Save a query and filters to be rerun on a schedule",
+            "type": "object",
+            "required": [
+                "frequency",
+                "name"
+            ],
+            "properties": {
+                "filters": {
+                    "$ref": "#/definitions/models.JobSearchFilter"
+                },
+                "frequency": {
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekly"
+                    ],
+                    "example": "daily"
+                },
+                "minScore": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 70
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Golang roles in Jakarta"
+                },
+                "query": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "golang developer jakarta"
+                }
+            }
+        },
+        "models.CreateSharedSearchRequest": {
+            "description": "Results to share, by the \"id\" of jobs returned by a search",
+            "type": "object",
+            "required": [
+                "jobIds"
+            ],
+            "properties": {
+                "expiresInDays": {
+                    "description": "Defaults to 30 days",
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1,
+                    "example": 30
+                },
+                "includeProfile": {
+                    "description": "Include skills and experience, without name, contact details or salary expectations",
+                    "type": "boolean"
+                },
+                "jobIds": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "4f2a9c0e7b1d3a5f8e6c2b9d0a1f3e7c"
+                    ]
+                },
+                "query": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "golang developer jakarta"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Golang roles in Jakarta"
+                }
+            }
+        },
+        "models.DependencyStatus": {
+            "description": "Result of probing a dependency (database, cv_storage, llm, pse or redis)",
+            "type": "object",
+            "properties": {
+                "checkedAt": {
+                    "type": "string"
+                },
+                "critical": {
+                    "description": "Readiness fails while a critical dependency is down",
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "latencyMs": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "up",
+                        "down"
+                    ],
+                    "example": "up"
+                }
+            }
+        },
+        "models.Education": {
+            "type": "object",
+            "properties": {
+                "degree": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "institution": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "description": "Standard error response",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "details": {
+                    "type": "string",
+                    "example": "email is required"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "Invalid request body"
+                },
+                "error_code": {
+                    "description": "Stable code of the error, for clients that translate messages themselves",
+                    "type": "string",
+                    "example": "invalid_request_body"
+                },
+                "fields": {
+                    "description": "Fields that failed validation, when the request was rejected for them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldError"
+                    }
+                }
+            }
+        },
+        "models.FieldError": {
+            "description": "Request field that failed validation",
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Path of the field, with list indexes",
+                    "type": "string",
+                    "example": "filters.remote_modes[0]"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "must be one of WFH, WFO, Hybrid"
+                }
+            }
+        },
+        "models.GoogleAuthRequest": {
+            "description": "Google SSO authentication request",
+            "type": "object",
+            "required": [
+                "idToken"
+            ],
+            "properties": {
+                "idToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "models.HealthResponse": {
+            "description": "Server health status",
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.DependencyStatus"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "healthy",
+                        "degraded",
+                        "unhealthy"
+                    ],
+                    "example": "healthy"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                }
+            }
+        },
+        "models.InsightsReport": {
+            "description": "Job market insights aggregated from stored jobs",
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string",
+                    "example": "jakarta"
+                },
+                "posting_volume": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VolumePoint"
+                    }
+                },
+                "role": {
+                    "type": "string",
+                    "example": "backend"
+                },
+                "salary_ranges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SalaryInsight"
+                    }
+                },
+                "site_settings": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "top_skills": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SkillDemand"
+                    }
+                },
+                "total_jobs": {
+                    "type": "integer",
+                    "example": 412
+                },
+                "work_types": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.JobAlert": {
+            "description": "Saved search with scheduled re-runs",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "filters": {
+                    "$ref": "#/definitions/models.JobSearchFilter"
+                },
+                "frequency": {
+                    "description": "daily, weekly",
+                    "type": "string",
+                    "example": "daily"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "k2J9x0Qm"
+                },
+                "lastRunAt": {
+                    "type": "string"
+                },
+                "minScore": {
+                    "type": "integer",
+                    "example": 70
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Golang roles in Jakarta"
+                },
+                "nextRunAt": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "golang developer jakarta"
+                }
+            }
+        },
+        "models.JobAlertResponse": {
+            "description": "Job alerts",
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JobAlert"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Job alert created"
+                }
+            }
+        },
+        "models.JobDetailResponse": {
+            "description": "Full job posting; score is included when the user has seen the job in a search",
+            "type": "object",
+            "properties": {
+                "first_seen_at": {
+                    "type": "string"
+                },
+                "job": {
+                    "$ref": "#/definitions/models.JobPosting"
+                },
+                "last_seen_at": {
+                    "description": "Last time a search or the crawler returned the posting",
+                    "type": "string"
+                },
+                "score": {
+                    "$ref": "#/definitions/models.JobScore"
+                }
+            }
+        },
+        "models.JobFeedback": {
+            "description": "User feedback on a job match",
+            "type": "object",
+            "properties": {
+                "company": {
+                    "type": "string",
+                    "example": "TechCorp"
+                },
+                "jobId": {
+                    "type": "string",
+                    "example": "4f2a9c0e7b1d3a5f8e6c2b9d0a1f3e7c"
+                },
+                "rating": {
+                    "description": "relevant, not_relevant, already_applied",
+                    "type": "string",
+                    "example": "not_relevant"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "golang",
+                        "backend"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Senior Golang Backend Engineer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.JobFeedbackRequest": {
+            "description": "Feedback on a job match",
+            "type": "object",
+            "required": [
+                "rating"
+            ],
+            "properties": {
+                "rating": {
+                    "type": "string",
+                    "enum": [
+                        "relevant",
+                        "not_relevant",
+                        "already_applied"
+                    ],
+                    "example": "not_relevant"
+                }
+            }
+        },
+        "models.JobFeedbackResponse": {
+            "description": "Recorded job feedback",
+            "type": "object",
+            "properties": {
+                "feedback": {
+                    "$ref": "#/definitions/models.JobFeedback"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Feedback recorded"
+                }
+            }
+        },
+        "models.JobLink": {
+            "type": "object",
+            "properties": {
+                "source": {
+                    "description": "web, linkedin, etc.",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.JobListResponse": {
+            "description": "Stored jobs matching the filters, most recently seen first",
+            "type": "object",
+            "properties": {
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StoredJob"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 25
+                }
+            }
+        },
+        "models.JobPosting": {
+            "type": "object",
+            "properties": {
+                "application_url": {
+                    "type": "string"
+                },
+                "benefits": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "company": {
+                    "type": "string"
+                },
+                "company_info": {
+                    "description": "CompanyInfo is added by the company enrichment step when enabled",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Company"
+                        }
+                    ]
+                },
+                "date_posted": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "experience_level": {
+                    "description": "entry, mid, senior, lead",
+                    "type": "string"
+                },
+                "expired": {
+                    "description": "The page says the posting no longer accepts applications",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "Stable ID derived from the canonical URL",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "ISO 639-1 language of the posting, e.g. id",
+                    "type": "string"
+                },
+                "links": {
+                    "description": "Links lists every portal the posting was found on when duplicates were merged",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JobLink"
+                    }
+                },
+                "location": {
+                    "type": "string"
+                },
+                "requirements": {
+                    "type": "string"
+                },
+                "salary": {
+                    "description": "Optional fields",
+                    "type": "string"
+                },
+                "salary_currency": {
+                    "description": "ISO 4217, e.g. IDR",
+                    "type": "string"
+                },
+                "salary_max": {
+                    "description": "Parsed from Salary",
+                    "type": "integer"
+                },
+                "salary_min": {
+                    "description": "Parsed from Salary",
+                    "type": "integer"
+                },
+                "salary_period": {
+                    "description": "hour, day, week, month, year",
+                    "type": "string"
+                },
+                "site_setting": {
+                    "description": "WFH, WFO, Hybrid, Unknown",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "web, linkedin, etc.",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "valid_through": {
+                    "description": "Application deadline (YYYY-MM-DD), when the page states one",
+                    "type": "string"
+                },
+                "work_type": {
+                    "description": "full_time, part_time, contract, internship",
+                    "type": "string"
+                }
+            }
+        },
+        "models.JobScore": {
+            "type": "object",
+            "properties": {
+                "ai_score": {
+                    "type": "integer"
+                },
+                "heuristic_score": {
+                    "type": "integer"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "match_reason": {
+                    "type": "string"
+                },
+                "match_score": {
+                    "type": "integer"
+                },
+                "score_breakdown": {
+                    "$ref": "#/definitions/models.ScoreBreakdown"
+                },
+                "score_method": {
+                    "type": "string"
+                },
+                "scored_at": {
+                    "type": "string"
+                },
+                "skills_match": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.JobSearchFilter": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "date_posted": {
+                    "description": "last_24h, last_week, last_month",
+                    "type": "string"
+                },
+                "exclude_companies": {
+                    "description": "Postings from these companies are never scored or returned (matched ignoring case and \"PT\"/\"Tbk\"/\"Inc\")",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "exclude_keywords": {
+                    "description": "Postings mentioning any of these words in the title, company, description or requirements are dropped;\nthey are also sent to web search as negative terms (\"-gambling\")",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "job_types": {
+                    "description": "full_time, part_time, contract, internship",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_salary": {
+                    "description": "Monthly amount",
+                    "type": "integer"
+                },
+                "min_salary": {
+                    "description": "Monthly amount; postings paid per hour, day, week or year are converted",
+                    "type": "integer"
+                },
+                "min_score": {
+                    "description": "Minimum match score to return (default 50; 0 returns everything)",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "remote_modes": {
+                    "description": "WFH, WFO, Hybrid",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.JobSummary": {
+            "description": "Bullet-point summary of a job posting",
+            "type": "object",
+            "properties": {
+                "company": {
+                    "type": "string",
+                    "example": "TechCorp"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "must_have_skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Go",
+                        "PostgreSQL"
+                    ]
+                },
+                "nice_to_have_skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Kubernetes"
+                    ]
+                },
+                "overview": {
+                    "description": "One sentence",
+                    "type": "string",
+                    "example": "Build payment APIs for a fast-growing fintech."
+                },
+                "red_flags": {
+                    "description": "Warning signs such as unpaid trials or fees",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Unpaid trial period"
+                    ]
+                },
+                "responsibilities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Design and build payment APIs in Go"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Senior Golang Backend Engineer"
+                }
+            }
+        },
+        "models.JobSummaryRequest": {
+            "description": "Posting to summarize; the page is fetched unless its text is given",
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Posting text, if the client already has it; skips fetching the page",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.jobstreet.co.id/job/12345678"
+                }
+            }
+        },
+        "models.JobSummaryResponse": {
+            "description": "Job posting summary",
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "description": "True if the summary was served from the cache",
+                    "type": "boolean"
+                },
+                "summary": {
+                    "$ref": "#/definitions/models.JobSummary"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.jobstreet.co.id/job/12345678"
+                }
+            }
+        },
+        "models.LLMBudget": {
+            "description": "Per-request Gemini usage caps",
+            "type": "object",
+            "properties": {
+                "max_calls": {
+                    "description": "Max Gemini calls",
+                    "type": "integer",
+                    "example": 20
+                },
+                "max_cost_usd": {
+                    "description": "Max estimated cost in USD",
+                    "type": "number",
+                    "example": 0.05
+                },
+                "max_tokens": {
+                    "description": "Max total (prompt + output) tokens",
+                    "type": "integer",
+                    "example": 200000
+                }
+            }
+        },
+        "models.LLMDebugRecord": {
+            "description": "A recorded Gemini prompt and raw response",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 2300
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a1B2c3D4e5F6g7H8i9J0"
+                },
+                "model": {
+                    "type": "string",
+                    "example": "gemini-2.5-flash"
+                },
+                "output_tokens": {
+                    "type": "integer",
+                    "example": 350
+                },
+                "prompt": {
+                    "type": "string"
+                },
+                "prompt_tokens": {
+                    "type": "integer",
+                    "example": 5200
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "5f2b8c0e9a1d4e7f6a3b2c1d"
+                },
+                "response": {
+                    "description": "Raw model output, before JSON cleanup",
+                    "type": "string"
+                },
+                "system_instruction": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LLMDebugResponse": {
+            "description": "Recorded Gemini calls of a request",
+            "type": "object",
+            "properties": {
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LLMDebugRecord"
+                    }
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "5f2b8c0e9a1d4e7f6a3b2c1d"
+                }
+            }
+        },
+        "models.LLMUsage": {
+            "description": "Gemini usage and budget status for a request",
+            "type": "object",
+            "properties": {
+                "budget_exhausted": {
+                    "type": "boolean"
+                },
+                "calls": {
+                    "type": "integer",
+                    "example": 14
+                },
+                "estimated_cost_usd": {
+                    "type": "number",
+                    "example": 0.0435
+                },
+                "fallback_scored": {
+                    "description": "Jobs scored deterministically after the budget ran out",
+                    "type": "integer",
+                    "example": 6
+                },
+                "output_tokens": {
+                    "type": "integer",
+                    "example": 3000
+                },
+                "prompt_tokens": {
+                    "type": "integer",
+                    "example": 120000
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "description": "User login request",
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "models.PhotoUploadResponse": {
+            "description": "Profile photo upload response",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Photo uploaded successfully"
+                },
+                "photoUrl": {
+                    "type": "string",
+                    "example": "https://storage.googleapis.com/bucket/photos/6f1c2a9e-3b7d-4e5f-8a10-2c4d6e8f0a1b/1700000000.jpg"
+                }
+            }
+        },
+        "models.ProfileResponse": {
+            "description": "User profile response",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Profile updated successfully"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                }
+            }
+        },
+        "models.PromptExperimentReport": {
+            "description": "Outcome metrics of each prompt experiment variant",
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PromptVariantStats"
+                    }
+                }
+            }
+        },
+        "models.PromptVariantStats": {
+            "type": "object",
+            "properties": {
+                "estimated_rate": {
+                    "description": "Share of returned jobs that fell back to heuristic scores",
+                    "type": "number",
+                    "example": 0.02
+                },
+                "extract_error_rate": {
+                    "description": "Failed extractions per extracted job",
+                    "type": "number",
+                    "example": 0.04
+                },
+                "feedback_already_applied": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "feedback_not_relevant": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "feedback_relevant": {
+                    "type": "integer",
+                    "example": 84
+                },
+                "jobs_extracted": {
+                    "type": "integer",
+                    "example": 1840
+                },
+                "jobs_scored": {
+                    "description": "Returned jobs scored by Gemini",
+                    "type": "integer",
+                    "example": 1020
+                },
+                "mean_score": {
+                    "type": "number",
+                    "example": 67.4
+                },
+                "median_score": {
+                    "type": "integer",
+                    "example": 70
+                },
+                "relevant_rate": {
+                    "description": "relevant / (relevant + not_relevant)",
+                    "type": "number",
+                    "example": 0.8
+                },
+                "score_distribution": {
+                    "description": "Gemini scores in 20-point buckets, e.g. \"60-79\"",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "searches": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "variant": {
+                    "type": "string",
+                    "example": "strict"
+                }
+            }
+        },
+        "models.RankedJob": {
+            "type": "object",
+            "properties": {
+                "ai_score": {
+                    "description": "Gemini's judgment, 0-100 (absent when not scored by Gemini)",
+                    "type": "integer"
+                },
+                "application_url": {
+                    "type": "string"
+                },
+                "benefits": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "company": {
+                    "type": "string"
+                },
+                "company_info": {
+                    "description": "CompanyInfo is added by the company enrichment step when enabled",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Company"
+                        }
+                    ]
+                },
+                "date_posted": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "experience_level": {
+                    "description": "entry, mid, senior, lead",
+                    "type": "string"
+                },
+                "expired": {
+                    "description": "The page says the posting no longer accepts applications",
+                    "type": "boolean"
+                },
+                "heuristic_score": {
+                    "description": "Deterministic score, 0-100",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "Stable ID derived from the canonical URL",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "ISO 639-1 language of the posting, e.g. id",
+                    "type": "string"
+                },
+                "links": {
+                    "description": "Links lists every portal the posting was found on when duplicates were merged",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JobLink"
+                    }
+                },
+                "location": {
+                    "type": "string"
+                },
+                "match_reason": {
+                    "description": "1-2 sentence explanation",
+                    "type": "string"
+                },
+                "match_score": {
+                    "description": "0-100, composite of the AI and heuristic scores",
+                    "type": "integer"
+                },
+                "requirements": {
+                    "type": "string"
+                },
+                "salary": {
+                    "description": "Optional fields",
+                    "type": "string"
+                },
+                "salary_currency": {
+                    "description": "ISO 4217, e.g. IDR",
+                    "type": "string"
+                },
+                "salary_max": {
+                    "description": "Parsed from Salary",
+                    "type": "integer"
+                },
+                "salary_min": {
+                    "description": "Parsed from Salary",
+                    "type": "integer"
+                },
+                "salary_period": {
+                    "description": "hour, day, week, month, year",
+                    "type": "string"
+                },
+                "score_breakdown": {
+                    "$ref": "#/definitions/models.ScoreBreakdown"
+                },
+                "score_method": {
+                    "type": "string"
+                },
+                "site_setting": {
+                    "description": "WFH, WFO, Hybrid, Unknown",
+                    "type": "string"
+                },
+                "skills_match": {
+                    "description": "Percentage of profile skills mentioned in the posting",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "web, linkedin, etc.",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "valid_through": {
+                    "description": "Application deadline (YYYY-MM-DD), when the page states one",
+                    "type": "string"
+                },
+                "work_type": {
+                    "description": "full_time, part_time, contract, internship",
+                    "type": "string"
+                }
+            }
+        },
+        "models.RegisterRequest": {
+            "description": "User registration request",
+            "type": "object",
+            "required": [
+                "email",
+                "nama",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "nama": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "password123"
+                }
+            }
+        },
+        "models.RescoreSavedJobsResponse": {
+            "description": "Saved jobs re-scored against the current profile",
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "description": "Jobs whose previous score was kept because scoring failed",
+                    "type": "integer",
+                    "example": 0
+                },
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SavedJob"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Re-scored 12 saved jobs"
+                },
+                "remaining": {
+                    "description": "Jobs left for the next request, past the per-request cap",
+                    "type": "integer",
+                    "example": 0
+                },
+                "rescored": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "models.RestoreBackupRequest": {
+            "description": "Collections to restore; all of the backup's collections when empty",
+            "type": "object",
+            "properties": {
+                "collections": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "users",
+                        "user_emails"
+                    ]
+                }
+            }
+        },
+        "models.RestoreBackupResponse": {
+            "description": "Documents restored per collection",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "20260115T030000Z"
+                },
+                "restored": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.SalaryInsight": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
+                },
+                "highest_max": {
+                    "type": "integer",
+                    "example": 35000000
+                },
+                "location": {
+                    "type": "string",
+                    "example": "Jakarta"
+                },
+                "lowest_min": {
+                    "type": "integer",
+                    "example": 8000000
+                },
+                "median_max": {
+                    "type": "integer",
+                    "example": 20000000
+                },
+                "median_min": {
+                    "type": "integer",
+                    "example": 12000000
+                },
+                "role": {
+                    "type": "string",
+                    "example": "backend engineer"
+                },
+                "sample_size": {
+                    "type": "integer",
+                    "example": 14
+                }
+            }
+        },
+        "models.SaveJobRequest": {
+            "description": "Job to bookmark, as returned by a search",
+            "type": "object",
+            "properties": {
+                "job": {
+                    "$ref": "#/definitions/models.RankedJob"
+                }
+            }
+        },
+        "models.SavedJob": {
+            "description": "Bookmarked job with its match score against the user's profile",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "p7Qw2LmZ"
+                },
+                "job": {
+                    "$ref": "#/definitions/models.RankedJob"
+                },
+                "savedAt": {
+                    "type": "string"
+                },
+                "scoredAt": {
+                    "description": "When MatchScore was last computed",
+                    "type": "string"
+                }
+            }
+        },
+        "models.SavedJobsResponse": {
+            "description": "Saved jobs",
+            "type": "object",
+            "properties": {
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SavedJob"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Job saved"
+                }
+            }
+        },
+        "models.ScoreBreakdown": {
+            "type": "object",
+            "properties": {
+                "domain": {
+                    "description": "Rated by Gemini only",
+                    "type": "integer"
+                },
+                "experience": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "integer"
+                },
+                "skills": {
+                    "type": "integer"
+                },
+                "title": {
+                    "description": "Rated by the heuristic scorer only",
+                    "type": "integer"
+                },
+                "work_type": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SearchHistoryEntry": {
+            "description": "A past job search",
+            "type": "object",
+            "properties": {
+                "cache_hits": {
+                    "description": "Postings reused from the job cache",
+                    "type": "integer",
+                    "example": 6
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "filters": {
+                    "$ref": "#/definitions/models.JobSearchFilter"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a1B2c3D4e5F6g7H8i9J0"
+                },
+                "incognito": {
+                    "type": "boolean"
+                },
+                "jobs_extracted": {
+                    "type": "integer",
+                    "example": 18
+                },
+                "jobs_scored": {
+                    "type": "integer",
+                    "example": 18
+                },
+                "llm_calls": {
+                    "type": "integer",
+                    "example": 29
+                },
+                "prompt_variant": {
+                    "description": "Prompt experiment variant, if any",
+                    "type": "string",
+                    "example": "strict"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "golang developer jakarta"
+                },
+                "result_count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "session_id": {
+                    "description": "Recorded search session, while it is kept",
+                    "type": "string",
+                    "example": "s7Kq2ZpR4mXy9Ab1Cd3E"
+                },
+                "source": {
+                    "description": "query, cv_text, cv_file, saved_profile",
+                    "type": "string",
+                    "example": "saved_profile"
+                },
+                "urls_found": {
+                    "type": "integer",
+                    "example": 25
+                }
+            }
+        },
+        "models.SearchHistoryResponse": {
+            "description": "Search history",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "searches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchHistoryEntry"
+                    }
+                }
+            }
+        },
+        "models.SearchJobsRequest": {
+            "description": "Job search request with CV and/or query",
+            "type": "object",
+            "properties": {
+                "budget": {
+                    "description": "Optional caps on Gemini usage for this search",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LLMBudget"
+                        }
+                    ]
+                },
+                "callback_url": {
+                    "description": "Background searches only: the finished search is posted here instead of to the user's webhook",
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/hooks/myjobmatch"
+                },
+                "cvText": {
+                    "type": "string",
+                    "example": "John Doe\nSoftware Engineer with 5 years experience..."
+                },
+                "filters": {
+                    "$ref": "#/definitions/models.JobSearchFilter"
+                },
+                "incognito": {
+                    "description": "Strip name, email and phone before scoring (always on if enabled in account settings)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "max_pages_to_process": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 10
+                },
+                "max_results": {
+                    "description": "Search depth: fewer results/pages for a quick search, more for a deep one (capped by server limits)",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 20
+                },
+                "model": {
+                    "description": "Gemini model for this search, from GEMINI_ALLOWED_MODELS (default GEMINI_MODEL)",
+                    "type": "string",
+                    "example": "gemini-2.5-pro"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "golang developer jakarta"
+                },
+                "saveCV": {
+                    "description": "Save CV to profile if authenticated",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "models.SearchJobsResponse": {
+            "description": "Job search results with ranked jobs and extracted profile",
+            "type": "object",
+            "properties": {
+                "cvSaved": {
+                    "description": "True if CV was saved to profile",
+                    "type": "boolean"
+                },
+                "llm_usage": {
+                    "$ref": "#/definitions/models.LLMUsage"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Found 10 matching jobs"
+                },
+                "profile": {
+                    "$ref": "#/definitions/models.UserProfile"
+                },
+                "prompt_variant": {
+                    "description": "Prompt experiment variant the search was assigned to, if an experiment is running",
+                    "type": "string",
+                    "example": "strict"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RankedJob"
+                    }
+                },
+                "session_id": {
+                    "description": "Recorded search session, for reviewing the search later (GET /search-sessions/{id})",
+                    "type": "string",
+                    "example": "s7Kq2ZpR4mXy9Ab1Cd3E"
+                },
+                "total_results": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "models.SearchSession": {
+            "description": "A recorded job search, for reviewing why it returned its results",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 18250
+                },
+                "effective_query": {
+                    "description": "Query used when none was given",
+                    "type": "string",
+                    "example": "golang developer jakarta"
+                },
+                "filters": {
+                    "$ref": "#/definitions/models.JobSearchFilter"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "s7Kq2ZpR4mXy9Ab1Cd3E"
+                },
+                "incognito": {
+                    "type": "boolean"
+                },
+                "model": {
+                    "type": "string",
+                    "example": "gemini-2.5-flash"
+                },
+                "profile": {
+                    "description": "Redacted for incognito searches",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.UserProfile"
+                        }
+                    ]
+                },
+                "prompt_variant": {
+                    "type": "string"
+                },
+                "queries": {
+                    "description": "Web search variants planned from it",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "query": {
+                    "type": "string",
+                    "example": "golang developer jakarta"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchSessionResult"
+                    }
+                },
+                "stats": {
+                    "$ref": "#/definitions/models.SearchStats"
+                },
+                "usage": {
+                    "$ref": "#/definitions/models.LLMUsage"
+                }
+            }
+        },
+        "models.SearchSessionResult": {
+            "type": "object",
+            "properties": {
+                "job_id": {
+                    "type": "string",
+                    "example": "3f9a1c2b7d4e8f60"
+                },
+                "match_reason": {
+                    "type": "string",
+                    "example": "Strong Go and Kubernetes overlap"
+                },
+                "match_score": {
+                    "type": "integer",
+                    "example": 85
+                },
+                "score_method": {
+                    "type": "string",
+                    "example": "ai"
+                }
+            }
+        },
+        "models.SearchSessionResultsResponse": {
+            "description": "Returned jobs of a recorded search in rank order, with the scores they had in that search",
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RankedJob"
+                    }
+                },
+                "session_id": {
+                    "type": "string",
+                    "example": "s7Kq2ZpR4mXy9Ab1Cd3E"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 25
+                }
+            }
+        },
+        "models.SearchStats": {
+            "type": "object",
+            "properties": {
+                "ats_jobs_found": {
+                    "type": "integer"
+                },
+                "cache_hits": {
+                    "type": "integer"
+                },
+                "cache_misses": {
+                    "type": "integer"
+                },
+                "duplicates_merged": {
+                    "type": "integer"
+                },
+                "expired_jobs": {
+                    "description": "Closed, past their deadline or older than JOB_MAX_AGE_DAYS",
+                    "type": "integer"
+                },
+                "extract_errors": {
+                    "type": "integer"
+                },
+                "fetch_errors": {
+                    "type": "integer"
+                },
+                "filtered_out": {
+                    "type": "integer"
+                },
+                "hidden_by_feedback": {
+                    "type": "integer"
+                },
+                "indexed_jobs": {
+                    "description": "Read from the local job index before live search",
+                    "type": "integer"
+                },
+                "jobs_enriched": {
+                    "type": "integer"
+                },
+                "jobs_extracted": {
+                    "type": "integer"
+                },
+                "jobs_returned": {
+                    "type": "integer"
+                },
+                "jobs_scored": {
+                    "type": "integer"
+                },
+                "pages_fetched": {
+                    "type": "integer"
+                },
+                "queries_searched": {
+                    "type": "integer"
+                },
+                "remote_jobs_found": {
+                    "type": "integer"
+                },
+                "structured_jobs": {
+                    "description": "Extracted from JSON-LD or by a site adapter, without Gemini",
+                    "type": "integer"
+                },
+                "urls_found": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SharedSearch": {
+            "description": "Shared snapshot of search results",
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "profile": {
+                    "description": "Redacted; only included when requested",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.UserProfile"
+                        }
+                    ]
+                },
+                "query": {
+                    "type": "string",
+                    "example": "golang developer jakarta"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RankedJob"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Golang roles in Jakarta"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "q3xN0aB7cD9eF1gH2iJkLmN4oP5qR6sT"
+                }
+            }
+        },
+        "models.SharedSearchListResponse": {
+            "description": "Share links published by the user",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "shares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SharedSearch"
+                    }
+                }
+            }
+        },
+        "models.SharedSearchResponse": {
+            "description": "Shared search snapshot and its public URL",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Share link created"
+                },
+                "share": {
+                    "$ref": "#/definitions/models.SharedSearch"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/api/shared/q3xN0aB7cD9eF1gH2iJkLmN4oP5qR6sT"
+                }
+            }
+        },
+        "models.SimilarJob": {
+            "type": "object",
+            "properties": {
+                "application_url": {
+                    "type": "string"
+                },
+                "benefits": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "company": {
+                    "type": "string"
+                },
+                "company_info": {
+                    "description": "CompanyInfo is added by the company enrichment step when enabled",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Company"
+                        }
+                    ]
+                },
+                "date_posted": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "experience_level": {
+                    "description": "entry, mid, senior, lead",
+                    "type": "string"
+                },
+                "expired": {
+                    "description": "The page says the posting no longer accepts applications",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "Stable ID derived from the canonical URL",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "ISO 639-1 language of the posting, e.g. id",
+                    "type": "string"
+                },
+                "links": {
+                    "description": "Links lists every portal the posting was found on when duplicates were merged",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JobLink"
+                    }
+                },
+                "location": {
+                    "type": "string"
+                },
+                "requirements": {
+                    "type": "string"
+                },
+                "salary": {
+                    "description": "Optional fields",
+                    "type": "string"
+                },
+                "salary_currency": {
+                    "description": "ISO 4217, e.g. IDR",
+                    "type": "string"
+                },
+                "salary_max": {
+                    "description": "Parsed from Salary",
+                    "type": "integer"
+                },
+                "salary_min": {
+                    "description": "Parsed from Salary",
+                    "type": "integer"
+                },
+                "salary_period": {
+                    "description": "hour, day, week, month, year",
+                    "type": "string"
+                },
+                "shared_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "golang",
+                        "kubernetes"
+                    ]
+                },
+                "similarity": {
+                    "description": "0-1, from shared tags and title words",
+                    "type": "number",
+                    "example": 0.42
+                },
+                "site_setting": {
+                    "description": "WFH, WFO, Hybrid, Unknown",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "web, linkedin, etc.",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "valid_through": {
+                    "description": "Application deadline (YYYY-MM-DD), when the page states one",
+                    "type": "string"
+                },
+                "work_type": {
+                    "description": "full_time, part_time, contract, internship",
+                    "type": "string"
+                }
+            }
+        },
+        "models.SimilarJobsResponse": {
+            "description": "Jobs similar to a stored job, most similar first",
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Login successful"
-                },
-                "token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "user": {
-                    "$ref": "#/definitions/models.User"
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SimilarJob"
+                    }
                 }
             }
         },
-        "models.CVParseRequest": {
-            "description": "CV parsing request",
+        "models.SkillDemand": {
             "type": "object",
             "properties": {
-                "cv_text": {
+                "count": {
+                    "type": "integer",
+                    "example": 87
+                },
+                "share": {
+                    "description": "Fraction of postings mentioning the skill",
+                    "type": "number",
+                    "example": 0.21
+                },
+                "skill": {
                     "type": "string",
-                    "example": "John Doe\nSoftware Engineer\nExperience: 5 years in Go, Python..."
+                    "example": "golang"
                 }
             }
         },
-        "models.CVParseResponse": {
-            "description": "Parsed CV profile information",
+        "models.StoredJob": {
             "type": "object",
             "properties": {
-                "profile": {
-                    "$ref": "#/definitions/models.UserProfile"
+                "first_seen_at": {
+                    "type": "string"
                 },
-                "raw": {
-                    "description": "Raw Gemini response for debugging",
+                "job": {
+                    "$ref": "#/definitions/models.JobPosting"
+                },
+                "last_seen_at": {
+                    "description": "Last time a search or the crawler returned the posting",
                     "type": "string"
                 }
             }
         },
-        "models.CVUploadResponse": {
-            "description": "CV upload response",
+        "models.StructuredProfileResponse": {
+            "description": "Structured profile response",
             "type": "object",
             "properties": {
-                "cvUrl": {
-                    "type": "string",
-                    "example": "gs://bucket/cvs/user@example.com/resume.pdf"
+                "edited": {
+                    "description": "True once the user has corrected any field",
+                    "type": "boolean"
                 },
                 "message": {
                     "type": "string",
-                    "example": "CV uploaded successfully"
-                }
-            }
-        },
-        "models.Education": {
-            "type": "object",
-            "properties": {
-                "degree": {
-                    "type": "string"
+                    "example": "Profile updated successfully"
                 },
-                "field": {
-                    "type": "string"
+                "profile": {
+                    "$ref": "#/definitions/models.UserProfile"
                 },
-                "institution": {
+                "updatedAt": {
                     "type": "string"
-                },
-                "year": {
-                    "type": "integer"
                 }
             }
         },
-        "models.ErrorResponse": {
-            "description": "Standard error response",
+        "models.SuspendUserRequest": {
+            "description": "Why the account is suspended",
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 400
-                },
-                "details": {
-                    "type": "string",
-                    "example": "email is required"
-                },
-                "error": {
+                "reason": {
                     "type": "string",
-                    "example": "Invalid request body"
+                    "maxLength": 500,
+                    "example": "Scraping the API with shared credentials"
                 }
             }
         },
-        "models.GoogleAuthRequest": {
-            "description": "Google SSO authentication request",
+        "models.TailorCVRequest": {
+            "description": "CV tailoring request. Uses the saved profile when authenticated and cv_text is omitted.",
             "type": "object",
-            "required": [
-                "idToken"
-            ],
             "properties": {
-                "idToken": {
+                "cv_text": {
                     "type": "string",
-                    "example": "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "John Doe\nSoftware Engineer\nExperience: 5 years in Go, Python..."
+                },
+                "job": {
+                    "$ref": "#/definitions/models.JobPosting"
                 }
             }
         },
-        "models.HealthResponse": {
-            "description": "Server health status",
+        "models.TailorCVResponse": {
+            "description": "Tailored CV sections",
             "type": "object",
             "properties": {
-                "status": {
-                    "type": "string",
-                    "example": "healthy"
-                },
-                "timestamp": {
-                    "type": "string",
-                    "example": "2024-01-15T10:30:00Z"
+                "profile": {
+                    "$ref": "#/definitions/models.UserProfile"
                 },
-                "version": {
-                    "type": "string",
-                    "example": "1.0.0"
+                "tailored": {
+                    "$ref": "#/definitions/models.TailoredCV"
                 }
             }
         },
-        "models.JobSearchFilter": {
+        "models.TailoredCV": {
+            "description": "CV sections optimized for a job posting",
             "type": "object",
             "properties": {
-                "currency": {
-                    "type": "string"
-                },
-                "date_posted": {
-                    "description": "last_24h, last_week, last_month",
-                    "type": "string"
-                },
-                "job_types": {
-                    "description": "full_time, part_time, contract, internship",
+                "highlights": {
+                    "description": "Experience worth emphasizing for this job",
                     "type": "array",
                     "items": {
                         "type": "string"
-                    }
+                    },
+                    "example": [
+                        "Led migration of payment ledger to Go microservices"
+                    ]
                 },
-                "locations": {
+                "missing_skills": {
+                    "description": "Job requirements not found in the CV",
                     "type": "array",
                     "items": {
                         "type": "string"
-                    }
-                },
-                "max_salary": {
-                    "type": "integer"
-                },
-                "min_salary": {
-                    "type": "integer"
+                    },
+                    "example": [
+                        "Kubernetes"
+                    ]
                 },
-                "remote_modes": {
-                    "description": "WFH, WFO, Hybrid",
+                "skills": {
+                    "description": "Candidate's skills, most relevant to the job first",
                     "type": "array",
                     "items": {
                         "type": "string"
-                    }
-                }
-            }
-        },
-        "models.LoginRequest": {
-            "description": "User login request",
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
+                    },
+                    "example": [
+                        "Go",
+                        "PostgreSQL",
+                        "Kafka",
+                        "Docker"
+                    ]
                 },
-                "password": {
+                "summary": {
                     "type": "string",
-                    "example": "password123"
+                    "example": "Backend engineer with 5 years building high-throughput payment APIs in Go..."
                 }
             }
         },
-        "models.ProfileResponse": {
-            "description": "User profile response",
+        "models.ToolAuditRecord": {
+            "description": "A recorded MCP or agent tool call",
             "type": "object",
             "properties": {
-                "message": {
+                "api_token_id": {
                     "type": "string",
-                    "example": "Profile updated successfully"
+                    "example": "3f2a9c1e"
                 },
-                "user": {
-                    "$ref": "#/definitions/models.User"
-                }
-            }
-        },
-        "models.RankedJob": {
-            "type": "object",
-            "properties": {
-                "application_url": {
+                "arguments": {
+                    "description": "Redacted and truncated",
                     "type": "string"
                 },
-                "benefits": {
-                    "type": "string"
+                "caller_email": {
+                    "description": "Empty for anonymous calls",
+                    "type": "string",
+                    "example": "user@example.com"
                 },
-                "company": {
-                    "type": "string"
+                "client_ip": {
+                    "type": "string",
+                    "example": "203.0.113.7"
                 },
-                "date_posted": {
+                "created_at": {
                     "type": "string"
                 },
-                "description": {
-                    "type": "string"
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 2300
                 },
-                "experience_level": {
-                    "description": "entry, mid, senior, lead",
+                "error": {
                     "type": "string"
                 },
-                "location": {
-                    "type": "string"
+                "error_code": {
+                    "type": "string",
+                    "example": "capacity_exceeded"
                 },
-                "match_reason": {
-                    "description": "1-2 sentence explanation",
-                    "type": "string"
+                "id": {
+                    "type": "string",
+                    "example": "a1B2c3D4e5F6g7H8i9J0"
                 },
-                "match_score": {
-                    "description": "0-100",
-                    "type": "integer"
+                "request_id": {
+                    "type": "string",
+                    "example": "5f2b8c0e9a1d4e7f6a3b2c1d"
                 },
-                "requirements": {
-                    "type": "string"
+                "source": {
+                    "type": "string",
+                    "example": "mcp"
                 },
-                "salary": {
-                    "description": "Optional fields",
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
                 },
-                "site_setting": {
-                    "description": "WFH, WFO, Hybrid, Unknown",
-                    "type": "string"
+                "tool": {
+                    "type": "string",
+                    "example": "search_web_for_jobs"
+                }
+            }
+        },
+        "models.ToolAuditResponse": {
+            "description": "Recorded tool calls with totals per tool",
+            "type": "object",
+            "properties": {
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ToolAuditRecord"
+                    }
                 },
-                "source": {
-                    "description": "web, linkedin, etc.",
-                    "type": "string"
+                "totals": {
+                    "description": "Over the returned records",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ToolAuditTotal"
+                    }
+                }
+            }
+        },
+        "models.ToolAuditTotal": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "failures": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "tool": {
+                    "type": "string",
+                    "example": "search_jobs"
+                },
+                "total_duration_ms": {
+                    "type": "integer",
+                    "example": 950000
+                }
+            }
+        },
+        "models.ToolSetting": {
+            "description": "Runtime on/off switch for a tool",
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "name": {
+                    "type": "string",
+                    "example": "fetch_page_html"
                 },
-                "title": {
-                    "type": "string"
+                "reason": {
+                    "type": "string",
+                    "example": "Job board asked us to stop scraping"
                 },
-                "url": {
+                "updatedAt": {
                     "type": "string"
                 },
-                "work_type": {
-                    "description": "full_time, part_time, contract, internship",
-                    "type": "string"
+                "updatedBy": {
+                    "type": "string",
+                    "example": "admin@example.com"
                 }
             }
         },
-        "models.RegisterRequest": {
-            "description": "User registration request",
+        "models.ToolStatus": {
+            "description": "Registered tool with its on/off state",
             "type": "object",
-            "required": [
-                "email",
-                "nama",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "fetch_page_html"
                 },
-                "nama": {
+                "reason": {
+                    "description": "Why the tool is disabled",
                     "type": "string",
-                    "example": "John Doe"
+                    "example": "Job board asked us to stop scraping"
                 },
-                "password": {
+                "version": {
                     "type": "string",
-                    "minLength": 6,
-                    "example": "password123"
+                    "example": "1.0.0"
                 }
             }
         },
-        "models.SearchJobsRequest": {
-            "description": "Job search request with CV and/or query",
+        "models.ToolStatusResponse": {
+            "description": "Registered tools",
             "type": "object",
             "properties": {
-                "cvText": {
-                    "type": "string",
-                    "example": "John Doe\nSoftware Engineer with 5 years experience..."
+                "tools": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ToolStatus"
+                    }
+                }
+            }
+        },
+        "models.UpdateApplicationRequest": {
+            "description": "Partial application update",
+            "type": "object",
+            "properties": {
+                "appliedAt": {
+                    "type": "string"
                 },
-                "filters": {
-                    "$ref": "#/definitions/models.JobSearchFilter"
+                "nextStepAt": {
+                    "type": "string"
                 },
-                "query": {
+                "notes": {
                     "type": "string",
-                    "example": "golang developer jakarta"
+                    "maxLength": 5000,
+                    "example": "Tech interview scheduled"
                 },
-                "saveCV": {
-                    "description": "Save CV to profile if authenticated",
-                    "type": "boolean",
-                    "example": false
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "applied",
+                        "interviewing",
+                        "offer",
+                        "rejected"
+                    ],
+                    "example": "interviewing"
                 }
             }
         },
-        "models.SearchJobsResponse": {
-            "description": "Job search results with ranked jobs and extracted profile",
+        "models.UpdateProfileRequest": {
+            "description": "Profile update request",
             "type": "object",
             "properties": {
-                "cvSaved": {
-                    "description": "True if CV was saved to profile",
-                    "type": "boolean"
+                "incognito": {
+                    "description": "Search without sending name, email and phone to the model",
+                    "type": "boolean",
+                    "example": true
                 },
-                "message": {
+                "nama": {
                     "type": "string",
-                    "example": "Found 10 matching jobs"
+                    "example": "John Smith"
+                }
+            }
+        },
+        "models.UpdateStructuredProfileRequest": {
+            "description": "Partial structured profile update",
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "IDR"
                 },
-                "profile": {
-                    "$ref": "#/definitions/models.UserProfile"
+                "experience_years": {
+                    "type": "number",
+                    "maximum": 60,
+                    "minimum": 0,
+                    "example": 5
                 },
-                "results": {
+                "max_salary": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "min_salary": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "preferred_job_types": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.RankedJob"
+                        "type": "string"
                     }
                 },
-                "total_results": {
-                    "type": "integer",
-                    "example": 10
+                "preferred_locations": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "preferred_remote_modes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "preferred_roles": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "skills": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "summary": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "technical_stack": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Backend Engineer"
+                },
+                "work_history": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/models.WorkExperience"
+                    }
                 }
             }
         },
-        "models.UpdateProfileRequest": {
-            "description": "Profile update request",
+        "models.UpdateToolSettingRequest": {
+            "description": "Tool switch update",
             "type": "object",
+            "required": [
+                "enabled"
+            ],
             "properties": {
-                "nama": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "reason": {
                     "type": "string",
-                    "example": "John Smith"
+                    "maxLength": 500,
+                    "example": "Job board asked us to stop scraping"
                 }
             }
         },
@@ -874,6 +7569,13 @@ const docTemplate = `{
             "description": "User account information",
             "type": "object",
             "properties": {
+                "blockedCompanies": {
+                    "description": "Never shown in search results or alerts",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "createdAt": {
                     "type": "string"
                 },
@@ -887,19 +7589,56 @@ const docTemplate = `{
                 },
                 "id": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "7b0e3c5a-2f4d-4c1e-9a8b-5d6f7e8a9b0c"
+                },
+                "incognito": {
+                    "description": "Strip name, email and phone from profiles sent to the model",
+                    "type": "boolean"
                 },
                 "nama": {
                     "type": "string",
                     "example": "John Doe"
                 },
+                "photoUrl": {
+                    "type": "string",
+                    "example": "https://storage.googleapis.com/bucket/photos/6f1c2a9e-3b7d-4e5f-8a10-2c4d6e8f0a1b/1700000000.jpg"
+                },
                 "provider": {
                     "description": "\"email\" or \"google\"",
                     "type": "string",
                     "example": "email"
                 },
+                "suspended": {
+                    "description": "Suspended users can't sign in or use their sessions and tokens",
+                    "type": "boolean"
+                },
+                "suspendedAt": {
+                    "type": "string"
+                },
+                "suspendedReason": {
+                    "type": "string"
+                },
+                "tenantId": {
+                    "description": "Empty for the default tenant",
+                    "type": "string",
+                    "example": "acme"
+                },
                 "updatedAt": {
                     "type": "string"
+                },
+                "webhookUrl": {
+                    "description": "Receives the user's finished background searches",
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserIDMigrationResponse": {
+            "description": "Number of users moved from email-keyed documents to user IDs",
+            "type": "object",
+            "properties": {
+                "migrated": {
+                    "type": "integer",
+                    "example": 42
                 }
             }
         },
@@ -933,7 +7672,11 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "experience_years": {
-                    "type": "integer"
+                    "type": "number"
+                },
+                "language": {
+                    "description": "Language of the CV (ISO 639-1, e.g. \"en\", \"id\"). Translated is true when\ntitle, skills and roles were translated to English for searching.",
+                    "type": "string"
                 },
                 "languages": {
                     "type": "array",
@@ -1001,6 +7744,9 @@ const docTemplate = `{
                 "title": {
                     "type": "string"
                 },
+                "translated": {
+                    "type": "boolean"
+                },
                 "work_history": {
                     "description": "Work History",
                     "type": "array",
@@ -1010,6 +7756,143 @@ const docTemplate = `{
                 }
             }
         },
+        "models.VolumePoint": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 58
+                },
+                "week_start": {
+                    "description": "Monday, YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2025-01-06"
+                }
+            }
+        },
+        "models.WatchCompanyRequest": {
+            "description": "Follow a company by careers page or ATS board",
+            "type": "object",
+            "required": [
+                "companyName"
+            ],
+            "properties": {
+                "atsProvider": {
+                    "type": "string",
+                    "enum": [
+                        "greenhouse",
+                        "lever",
+                        "workable"
+                    ],
+                    "example": "lever"
+                },
+                "atsToken": {
+                    "type": "string",
+                    "example": "xendit"
+                },
+                "careersUrl": {
+                    "type": "string",
+                    "example": "https://www.xendit.co/en/careers/"
+                },
+                "companyName": {
+                    "type": "string",
+                    "example": "Xendit"
+                },
+                "minScore": {
+                    "description": "WATCHLIST_MIN_SCORE when omitted; 0 alerts on every posting",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 70
+                }
+            }
+        },
+        "models.WatchedCompany": {
+            "description": "Company on the user's watchlist",
+            "type": "object",
+            "properties": {
+                "atsProvider": {
+                    "description": "greenhouse, lever, workable",
+                    "type": "string",
+                    "example": "lever"
+                },
+                "atsToken": {
+                    "type": "string",
+                    "example": "xendit"
+                },
+                "careersUrl": {
+                    "type": "string",
+                    "example": "https://www.xendit.co/en/careers/"
+                },
+                "companyName": {
+                    "type": "string",
+                    "example": "Xendit"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "c8f1a2b3"
+                },
+                "lastCheckedAt": {
+                    "type": "string"
+                },
+                "minScore": {
+                    "type": "integer",
+                    "example": 70
+                }
+            }
+        },
+        "models.WatchlistResponse": {
+            "description": "Followed companies",
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WatchedCompany"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Company added to watchlist"
+                }
+            }
+        },
+        "models.WebhookRequest": {
+            "description": "Webhook URL to set",
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://hooks.zapier.com/hooks/catch/123456/abcdef/"
+                }
+            }
+        },
+        "models.WebhookResponse": {
+            "description": "The user's webhook",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Webhook set"
+                },
+                "secret": {
+                    "description": "Signing secret, only returned when the webhook is set",
+                    "type": "string",
+                    "example": "whsec_q1w2e3r4t5y6u7i8o9p0a1s2d3f4g5h6j7k8l9z0x1c"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://hooks.zapier.com/hooks/catch/123456/abcdef/"
+                }
+            }
+        },
         "models.WorkExperience": {
             "type": "object",
             "properties": {
@@ -0,0 +1,91 @@
+package gemini
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// ErrBudgetExhausted is returned when a request's LLM budget does not allow another Gemini call
+var ErrBudgetExhausted = errors.New("LLM budget exhausted")
+
+type budgetContextKey struct{}
+
+// Budget tracks Gemini usage for a single request and enforces its caps.
+// It is safe for concurrent use by the agent's worker goroutines.
+type Budget struct {
+	mu        sync.Mutex
+	limits    models.LLMBudget
+	usage     models.LLMUsage
+	exhausted bool
+}
+
+// NewBudget creates a usage tracker with the given caps (zero values mean unlimited)
+func NewBudget(limits models.LLMBudget) *Budget {
+	return &Budget{limits: limits}
+}
+
+// WithBudget attaches a budget to the context; Gemini calls made with it are counted and capped
+func WithBudget(ctx context.Context, b *Budget) context.Context {
+	return context.WithValue(ctx, budgetContextKey{}, b)
+}
+
+// BudgetFromContext returns the budget attached to the context, if any
+func BudgetFromContext(ctx context.Context) *Budget {
+	b, _ := ctx.Value(budgetContextKey{}).(*Budget)
+	return b
+}
+
+// reserve claims one call against the budget, failing if any cap has been reached
+func (b *Budget) reserve() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	l := b.limits
+	u := b.usage
+	if (l.MaxCalls > 0 && u.Calls >= l.MaxCalls) ||
+		(l.MaxTokens > 0 && u.PromptTokens+u.OutputTokens >= l.MaxTokens) ||
+		(l.MaxCostUSD > 0 && u.EstimatedCostUSD >= l.MaxCostUSD) {
+		b.exhausted = true
+		return ErrBudgetExhausted
+	}
+
+	b.usage.Calls++
+	return nil
+}
+
+// record adds the token usage and cost of a completed call
+func (b *Budget) record(promptTokens, outputTokens int, cost float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.usage.PromptTokens += promptTokens
+	b.usage.OutputTokens += outputTokens
+	b.usage.EstimatedCostUSD += cost
+}
+
+// RecordFallback counts a job that was scored deterministically because the budget was exhausted
+func (b *Budget) RecordFallback() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.usage.FallbackScored++
+}
+
+// Exhausted reports whether a call has been refused because a cap was reached
+func (b *Budget) Exhausted() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.exhausted
+}
+
+// Usage returns a snapshot of the usage so far
+func (b *Budget) Usage() models.LLMUsage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	usage := b.usage
+	usage.BudgetExhausted = b.exhausted
+	return usage
+}
@@ -5,48 +5,195 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"cloud.google.com/go/vertexai/genai"
+	"google.golang.org/api/option"
 
 	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/matching"
 	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
 )
 
 // Client wraps the Vertex AI Gemini client
 type Client struct {
 	client    *genai.Client
+	provider  provider // Backend serving the calls (Vertex AI unless LLM_PROVIDER selects a local one)
 	model     *genai.GenerativeModel
 	projectID string
 	location  string
 	modelName string
+
+	// Models requests may select instead of the default (GEMINI_ALLOWED_MODELS), keyed by name; includes the default
+	models        map[string]*genai.GenerativeModel
+	allowedModels []string
+
+	// Recent text embeddings keyed by task type and text hash (nil unless EMBEDDINGS_ENABLED)
+	embeddingCache *matching.VectorIndex
+
+	// Process-wide rate limit and call quotas (nil = unlimited)
+	limiter *RateLimiter
+
+	// Fails calls immediately while the LLM provider is failing (nil = disabled)
+	breaker *utils.CircuitBreaker
+
+	// Pricing used to estimate request cost (USD per 1K tokens)
+	inputCostPer1K  float64
+	outputCostPer1K float64
+
+	// Context caches created by scoring sessions (TTL 0 = explicit caching disabled)
+	contextCacheTTL time.Duration
+	cacheMu         sync.Mutex
+	caches          map[string]struct{}
+
+	// Redacted prompt/response records for debugging (nil unless LLM_DEBUG_LOG_ENABLED)
+	debugStore DebugStore
+	debugTTL   time.Duration
 }
 
 // NewClient creates a new Gemini client
 func NewClient(ctx context.Context, cfg *config.Config) (*Client, error) {
-	client, err := genai.NewClient(ctx, cfg.ProjectID, cfg.Location)
+	// Local providers only use the genai client for model handles, so it needs no credentials
+	var opts []option.ClientOption
+	if cfg.LLMProvider != config.LLMProviderVertex {
+		opts = append(opts, option.WithoutAuthentication())
+	}
+	client, err := genai.NewClient(ctx, cfg.ProjectID, cfg.Location, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
 	}
+	p, err := newProvider(ctx, cfg)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
 
-	model := client.GenerativeModel(cfg.GeminiModel)
-
-	// Configure model parameters
-	model.SetTemperature(0.2) // Lower temperature for more consistent outputs
-	model.SetTopP(0.8)
-	model.SetMaxOutputTokens(8192)
+	model := newModel(client, cfg, cfg.GeminiModel)
+	models := map[string]*genai.GenerativeModel{cfg.GeminiModel: model}
+	for _, name := range cfg.GeminiAllowedModels {
+		if _, ok := models[name]; !ok {
+			models[name] = newModel(client, cfg, name)
+		}
+	}
 
-	return &Client{
+	c := &Client{
 		client:    client,
+		provider:  p,
 		model:     model,
 		projectID: cfg.ProjectID,
 		location:  cfg.Location,
 		modelName: cfg.GeminiModel,
-	}, nil
+
+		models:        models,
+		allowedModels: cfg.GeminiAllowedModels,
+
+		inputCostPer1K:  cfg.GeminiInputCostPer1K,
+		outputCostPer1K: cfg.GeminiOutputCostPer1K,
+
+		caches: make(map[string]struct{}),
+	}
+	if cfg.GeminiRateLimitPerSecond > 0 || cfg.GeminiMaxCallsPerMinute > 0 || cfg.GeminiMaxCallsPerDay > 0 {
+		c.limiter = NewRateLimiter(cfg.GeminiRateLimitPerSecond, cfg.GeminiRateLimitBurst,
+			time.Duration(cfg.GeminiRateLimitMaxWaitSeconds)*time.Second, cfg.GeminiMaxCallsPerMinute, cfg.GeminiMaxCallsPerDay)
+	}
+	c.breaker = utils.NewCircuitBreaker(providerName(cfg.LLMProvider), cfg.CircuitBreakerThreshold,
+		time.Duration(cfg.CircuitBreakerCooldownSeconds)*time.Second, vertexOutage)
+	// Context caches are a Vertex AI feature
+	if cfg.GeminiContextCacheEnabled && cfg.LLMProvider == config.LLMProviderVertex {
+		c.contextCacheTTL = time.Duration(cfg.GeminiContextCacheTTLMinutes) * time.Minute
+	}
+	if cfg.EmbeddingsEnabled {
+		c.embeddingCache = matching.NewVectorIndex(cfg.EmbeddingCacheSize)
+	}
+	if cfg.LLMProvider != config.LLMProviderVertex {
+		log.Printf("[Gemini] Serving model calls with the %s provider instead of Vertex AI", cfg.LLMProvider)
+	}
+
+	return c, nil
+}
+
+// Ping checks that the LLM provider is reachable, without a billed generation or the rate limit
+func (c *Client) Ping(ctx context.Context) error {
+	if err := c.provider.ping(ctx, c.model); err != nil {
+		return fmt.Errorf("failed to reach the LLM provider: %w", err)
+	}
+	return nil
+}
+
+// newModel creates a model handle with the shared generation parameters and safety settings
+func newModel(client *genai.Client, cfg *config.Config, name string) *genai.GenerativeModel {
+	model := client.GenerativeModel(name)
+
+	// Configure model parameters
+	model.SetTemperature(0.2) // Lower temperature for more consistent outputs
+	model.SetTopP(0.8)
+	model.SetMaxOutputTokens(8192)
+	model.SafetySettings = safetySettings(cfg.GeminiSafetyThreshold, cfg.GeminiSafetySettings)
+	return model
+}
+
+// generate calls the model selected for ctx (see WithModel), enforcing and recording the request budget attached to ctx (if any)
+func (c *Client) generate(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	return c.generateWith(ctx, c.modelFor(ctx), parts...)
+}
+
+// generateWith is generate for a model configured differently from the client's default one.
+// Calls delegated to the calling agent's model (see WithSampler) bypass the budget and rate limit.
+func (c *Client) generateWith(ctx context.Context, model *genai.GenerativeModel, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	if resp, ok := c.sample(ctx, model, parts); ok {
+		return resp, nil
+	}
+	if err := c.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	budget := BudgetFromContext(ctx)
+	if budget != nil {
+		if err := budget.reserve(); err != nil {
+			c.breaker.Release()
+			return nil, err
+		}
+	}
+	if err := c.limiter.Acquire(ctx); err != nil {
+		c.breaker.Release()
+		return nil, err
+	}
+
+	started := time.Now()
+	resp, err := c.provider.generateContent(ctx, model, nil, parts)
+	c.breaker.Record(err)
+	if err != nil {
+		err = blockedError(err)
+		c.recordCall(ctx, model, parts, "", nil, err, started)
+		return nil, err
+	}
+	c.recordCall(ctx, model, parts, responseText(resp), resp.UsageMetadata, nil, started)
+
+	c.recordUsage(budget, resp.UsageMetadata)
+	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil || len(resp.Candidates[0].Content.Parts) == 0 {
+		return nil, emptyResponseError(resp)
+	}
+	return resp, nil
+}
+
+// recordUsage adds a response's token usage and estimated cost to the budget, if any
+func (c *Client) recordUsage(budget *Budget, usage *genai.UsageMetadata) {
+	if budget == nil || usage == nil {
+		return
+	}
+	promptTokens := int(usage.PromptTokenCount)
+	outputTokens := int(usage.CandidatesTokenCount)
+	cost := float64(promptTokens)/1000*c.inputCostPer1K + float64(outputTokens)/1000*c.outputCostPer1K
+	budget.record(promptTokens, outputTokens, cost)
 }
 
-// Close closes the Gemini client
+// Close deletes any context caches still held and closes the Gemini client
 func (c *Client) Close() error {
+	c.deleteAllCaches()
+	c.provider.close()
 	return c.client.Close()
 }
 
@@ -89,7 +236,8 @@ Return a JSON object with the following fields (use null for missing data):
     }
   ],
   "certifications": ["AWS Certified", "GCP Professional"],
-  "achievements": ["Led team of 5", "Increased performance by 50%"]
+  "achievements": ["Led team of 5", "Increased performance by 50%"],
+  "language": "en"
 }
 
 IMPORTANT for experience_years:
@@ -100,6 +248,7 @@ IMPORTANT for experience_years:
 
 Infer preferred_roles based on experience and skills.
 Infer preferred_remote_modes and preferred_locations from any mentioned preferences or recent work.
+Set language to the ISO 639-1 code of the language the CV is written in (e.g. "en", "id"). Keep all values in the CV's original language.
 
 Return ONLY the JSON object, no markdown formatting, no explanation.`
 
@@ -109,21 +258,17 @@ Return ONLY the JSON object, no markdown formatting, no explanation.`
 		Data:     pdfData,
 	}
 
-	resp, err := c.model.GenerateContent(ctx, pdfBlob, genai.Text(prompt))
+	resp, err := c.generate(ctx, pdfBlob, genai.Text(prompt))
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate content: %w", err)
 	}
 
-	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
-		return nil, fmt.Errorf("no response from Gemini")
-	}
-
 	text := extractText(resp)
 	text = cleanJSON(text)
 
 	var profile models.UserProfile
-	if err := json.Unmarshal([]byte(text), &profile); err != nil {
-		log.Printf("Failed to parse CV PDF response: %s", text)
+	if err := c.decodeJSON(ctx, text, &profile); err != nil {
+		log.Printf("Failed to parse CV PDF response (request %s): %s", utils.RequestIDFromContext(ctx), text)
 		return nil, fmt.Errorf("failed to parse profile JSON: %w", err)
 	}
 
@@ -172,7 +317,8 @@ Return a JSON object with the following fields (use null for missing data):
     }
   ],
   "certifications": ["AWS Certified", "GCP Professional"],
-  "achievements": ["Led team of 5", "Increased performance by 50%%"]
+  "achievements": ["Led team of 5", "Increased performance by 50%%"],
+  "language": "en"
 }
 
 IMPORTANT for experience_years:
@@ -183,67 +329,85 @@ IMPORTANT for experience_years:
 
 Infer preferred_roles based on experience and skills.
 Infer preferred_remote_modes and preferred_locations from any mentioned preferences or recent work.
+Set language to the ISO 639-1 code of the language the CV is written in (e.g. "en", "id"). Keep all values in the CV's original language.
 
 CV TEXT:
 %s
 
 Return ONLY the JSON object, no markdown formatting, no explanation.`, cvText)
 
-	resp, err := c.model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, genai.Text(prompt))
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate content: %w", err)
 	}
 
-	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
-		return nil, fmt.Errorf("no response from Gemini")
-	}
-
 	text := extractText(resp)
 	text = cleanJSON(text)
 
 	var profile models.UserProfile
-	if err := json.Unmarshal([]byte(text), &profile); err != nil {
-		log.Printf("Failed to parse CV response: %s", text)
+	if err := c.decodeJSON(ctx, text, &profile); err != nil {
+		log.Printf("Failed to parse CV response (request %s): %s", utils.RequestIDFromContext(ctx), text)
 		return nil, fmt.Errorf("failed to parse profile JSON: %w", err)
 	}
 
 	return &profile, nil
 }
 
-// ExtractJobFromHTML extracts job posting from HTML content
-func (c *Client) ExtractJobFromHTML(ctx context.Context, html, url string) (*models.JobPosting, error) {
+// maxJobsPerPage bounds how many postings are extracted from one listing page
+const maxJobsPerPage = 10
+
+// ExtractJobsFromHTML extracts the job postings on a page: one for a posting page, or each posting
+// listed on a search results or careers page. Listed postings get their own URL when the page links to it.
+func (c *Client) ExtractJobsFromHTML(ctx context.Context, html, pageURL string) ([]models.JobPosting, error) {
 	// Truncate HTML if too long
 	maxLen := 50000
 	if len(html) > maxLen {
 		html = html[:maxLen]
 	}
 
-	prompt := fmt.Sprintf(`Extract job posting information from this HTML content. 
-Return a JSON object with the following fields:
+	prompt := fmt.Sprintf(`Extract the job postings from this HTML content. The page is either a single job posting
+or a page listing several postings (job board search results, a company careers page).
+Return a JSON object {"jobs": [...]} with one entry per posting (at most %d), each with the following fields:
 
 {
   "title": "Job title",
   "company": "Company name",
+  "url": "Link to the posting's own page if the page lists several postings (as written in the HTML), otherwise empty",
   "description": "Job description (summarize if very long, max 500 chars)",
   "location": "Job location",
   "work_type": "full_time|part_time|contract|internship|freelance",
   "site_setting": "WFH|WFO|Hybrid|Unknown",
   "salary": "Salary range if mentioned",
-  "date_posted": "Date posted if available",
+  "date_posted": "Date posted as YYYY-MM-DD if shown, otherwise as written (e.g. \"3 days ago\")",
   "requirements": "Key requirements (summarize, max 300 chars)",
   "benefits": "Benefits if mentioned",
   "experience_level": "entry|mid|senior|lead",
-  "tags": ["relevant", "keywords", "technologies"]
+  "tags": ["relevant", "keywords", "technologies"],
+  "language": "ISO 639-1 code of the posting's language (e.g. \"en\", \"id\")",
+  "valid_through": "Application deadline as YYYY-MM-DD if shown, otherwise empty",
+  "expired": true if the page says the posting is closed or no longer accepting applications, otherwise false
 }
 
+For a listing, use only what the listing shows for each posting; leave unknown fields empty.
+%s
+The postings may be written in English or Bahasa Indonesia. Keep title, company and description in the
+posting's original language, but map Indonesian terms to the enum values above:
+- "Penuh Waktu" / "Karyawan Tetap" = full_time, "Paruh Waktu" = part_time, "Kontrak" / "PKWT" = contract,
+  "Magang" = internship, "Pekerja Lepas" = freelance
+- "Kerja dari Rumah" / "WFH" / "Remote" = WFH, "Kerja di Kantor" / "WFO" = WFO
+- "Fresh Graduate" / "Lulusan Baru" = entry
+- Salaries such as "Rp 8 - 12 juta" or "8-12jt" mean IDR 8,000,000 - 12,000,000
+- Relative dates such as "3 hari yang lalu" mean 3 days ago
+- "Lowongan ditutup" / "Sudah tidak menerima lamaran" mean the posting is expired
+
 URL: %s
 
 HTML CONTENT:
 %s
 
-Return ONLY the JSON object. If this is not a job posting page, return {"error": "not_a_job_posting"}.`, url, html)
+Return ONLY the JSON object. If the page has no job postings, return {"jobs": []}.`, maxJobsPerPage, extractionGuidance(PromptVariantFromContext(ctx)), pageURL, html)
 
-	resp, err := c.model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, genai.Text(prompt))
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate content: %w", err)
 	}
@@ -251,35 +415,148 @@ Return ONLY the JSON object. If this is not a job posting page, return {"error":
 	text := extractText(resp)
 	text = cleanJSON(text)
 
-	// Check for error response
-	var errResp map[string]string
-	if err := json.Unmarshal([]byte(text), &errResp); err == nil {
-		if errResp["error"] == "not_a_job_posting" {
-			return nil, fmt.Errorf("not a job posting page")
+	var result struct {
+		Jobs []models.JobPosting `json:"jobs"`
+	}
+	if err := c.decodeJSON(ctx, text, &result); err != nil {
+		log.Printf("Failed to parse job response (request %s): %s", utils.RequestIDFromContext(ctx), text)
+		return nil, fmt.Errorf("failed to parse job JSON: %w", err)
+	}
+
+	jobs := make([]models.JobPosting, 0, len(result.Jobs))
+	for _, job := range result.Jobs {
+		if job.Title == "" {
+			continue
 		}
+
+		// Normalize fields
+		job.URL = resolveJobURL(pageURL, job.URL)
+		job.Source = "web"
+		job.WorkType = models.NormalizeWorkType(job.WorkType)
+		job.SiteSetting = models.NormalizeSiteSetting(job.SiteSetting)
+		jobs = append(jobs, job)
+		if len(jobs) == maxJobsPerPage {
+			break
+		}
+	}
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("not a job posting page")
 	}
 
-	var job models.JobPosting
-	if err := json.Unmarshal([]byte(text), &job); err != nil {
-		log.Printf("Failed to parse job response: %s", text)
-		return nil, fmt.Errorf("failed to parse job JSON: %w", err)
+	return jobs, nil
+}
+
+// SummarizeJob condenses a posting page (HTML or plain text) into bullets for small screens:
+// responsibilities, must-have and nice-to-have skills, and red flags
+func (c *Client) SummarizeJob(ctx context.Context, content, pageURL string) (*models.JobSummary, error) {
+	maxLen := 50000
+	if len(content) > maxLen {
+		content = content[:maxLen]
+	}
+
+	prompt := fmt.Sprintf(`Summarize this job posting for a candidate reading it on a phone.
+Return a JSON object with the following fields:
+
+{
+  "title": "Job title",
+  "company": "Company name",
+  "overview": "One sentence on what the role is about",
+  "responsibilities": ["3-6 short bullets, the main duties"],
+  "must_have_skills": ["Required skills, technologies, degrees or years of experience"],
+  "nice_to_have_skills": ["Preferred but optional skills"],
+  "red_flags": ["Warning signs, only if present"],
+  "language": "ISO 639-1 code of the posting's language (e.g. \"en\", \"id\")"
+}
+
+Keep each bullet under 12 words and write in the posting's language. Only use what the posting says.
+Red flags are things a candidate should know before applying: unpaid trial work, fees or deposits asked of
+applicants, salary "negotiable" with no range alongside very broad duties, unrealistic requirements for the
+level (e.g. 5+ years for a junior role), penalty clauses, or missing company details. Return an empty list
+when there are none. If the content is not a job posting, return empty strings and lists.
+
+URL: %s
+
+POSTING:
+%s
+
+Return ONLY the JSON object, no markdown formatting, no explanation.`, pageURL, content)
+
+	resp, err := c.generate(ctx, genai.Text(prompt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	text := extractText(resp)
+	text = cleanJSON(text)
+
+	var summary models.JobSummary
+	if err := c.decodeJSON(ctx, text, &summary); err != nil {
+		log.Printf("Failed to parse job summary (request %s): %s", utils.RequestIDFromContext(ctx), text)
+		return nil, fmt.Errorf("failed to parse job summary JSON: %w", err)
+	}
+
+	return &summary, nil
+}
+
+// resolveJobURL resolves a posting link found on a page against the page URL, falling back to the page URL
+func resolveJobURL(pageURL, link string) string {
+	if link == "" {
+		return pageURL
 	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return pageURL
+	}
+	ref, err := url.Parse(link)
+	if err != nil {
+		return pageURL
+	}
+	resolved := base.ResolveReference(ref)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return pageURL
+	}
+	return resolved.String()
+}
 
-	// Normalize fields
-	job.URL = url
-	job.Source = "web"
-	job.WorkType = models.NormalizeWorkType(job.WorkType)
-	job.SiteSetting = models.NormalizeSiteSetting(job.SiteSetting)
+// ScoreJobMatch scores how well a job matches a user profile, with a per-criterion breakdown.
+// feedback lists the user's ratings of earlier matches and is given to the model as examples of their preferences.
+// Use a ScoringSession to score several postings against the same profile.
+func (c *Client) ScoreJobMatch(ctx context.Context, profile *models.UserProfile, job *models.JobPosting, feedback []models.JobFeedback) (*models.ScoreJobResponse, error) {
+	session := c.NewScoringSession(ctx, profile, feedback, 1)
+	defer session.Close(ctx)
+	return session.Score(ctx, job)
+}
 
-	return &job, nil
+// formatFeedbackExamples renders the user's ratings of earlier matches as few-shot context for scoring
+func formatFeedbackExamples(feedback []models.JobFeedback) string {
+	if len(feedback) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\nThe candidate rated some earlier matches. Score postings similar to ones marked RELEVANT higher and\n")
+	sb.WriteString("postings similar to ones marked NOT RELEVANT lower, and mention it in match_reason when it matters:\n")
+	for _, f := range feedback {
+		label := "RELEVANT"
+		if f.Rating == models.FeedbackNotRelevant {
+			label = "NOT RELEVANT"
+		}
+		fmt.Fprintf(&sb, "- %s: %s at %s", label, f.Title, f.Company)
+		if len(f.Tags) > 0 {
+			fmt.Fprintf(&sb, " (%s)", strings.Join(f.Tags, ", "))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
 }
 
-// ScoreJobMatch scores how well a job matches a user profile
-func (c *Client) ScoreJobMatch(ctx context.Context, profile *models.UserProfile, job *models.JobPosting) (int, string, error) {
+// TailorCV rewrites the profile summary and reorders skills to fit a job posting.
+// Only the candidate's own skills are returned; nothing is invented.
+func (c *Client) TailorCV(ctx context.Context, profile *models.UserProfile, job *models.JobPosting) (*models.TailoredCV, error) {
 	profileJSON, _ := json.Marshal(profile)
 	jobJSON, _ := json.Marshal(job)
 
-	prompt := fmt.Sprintf(`Analyze how well this job matches the candidate's profile and return a match score.
+	prompt := fmt.Sprintf(`You are an expert CV writer. Tailor this candidate's CV to the job posting below.
 
 CANDIDATE PROFILE:
 %s
@@ -289,34 +566,35 @@ JOB POSTING:
 
 Return a JSON object with:
 {
-  "match_score": 0-100,
-  "match_reason": "1-2 sentences explaining the match or mismatch"
+  "summary": "3-4 sentence professional summary written in first person without pronouns, emphasizing experience relevant to this job",
+  "skills": ["all of the candidate's skills and technologies, most relevant to the job first"],
+  "highlights": ["up to 5 achievements or responsibilities from the work history worth emphasizing for this job"],
+  "missing_skills": ["important job requirements not found in the profile"]
 }
 
-Consider:
-- Skills alignment (most important)
-- Experience level match
-- Location and remote preferences
-- Job type preferences
-- Industry/domain relevance
+Rules:
+- Be truthful: only use experience, skills and achievements present in the profile
+- Use the job posting's terminology where the candidate's experience matches it
+- "skills" must only contain skills from the profile
 
 Return ONLY the JSON object.`, profileJSON, jobJSON)
 
-	resp, err := c.model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, genai.Text(prompt))
 	if err != nil {
-		return 0, "", fmt.Errorf("failed to generate content: %w", err)
+		return nil, fmt.Errorf("failed to generate content: %w", err)
 	}
 
 	text := extractText(resp)
 	text = cleanJSON(text)
 
-	var result models.ScoreJobResponse
-	if err := json.Unmarshal([]byte(text), &result); err != nil {
-		log.Printf("Failed to parse score response: %s", text)
-		return 0, "", fmt.Errorf("failed to parse score JSON: %w", err)
+	var tailored models.TailoredCV
+	if err := c.decodeJSON(ctx, text, &tailored); err != nil {
+		log.Printf("Failed to parse tailored CV (request %s): %s", utils.RequestIDFromContext(ctx), text)
+		return nil, fmt.Errorf("failed to parse tailored CV JSON: %w", err)
 	}
 
-	return result.MatchScore, result.MatchReason, nil
+	tailored.Skills = reorderSkills(tailored.Skills, append(append([]string{}, profile.Skills...), profile.TechnicalStack...))
+	return &tailored, nil
 }
 
 // RefineProfileWithQuery uses query to refine/supplement profile
@@ -340,7 +618,7 @@ Update the profile JSON with any new information from the query:
 Return the UPDATED profile as a JSON object (same structure as input).
 Return ONLY the JSON object.`, profileJSON, query)
 
-	resp, err := c.model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, genai.Text(prompt))
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate content: %w", err)
 	}
@@ -349,8 +627,8 @@ Return ONLY the JSON object.`, profileJSON, query)
 	text = cleanJSON(text)
 
 	var updatedProfile models.UserProfile
-	if err := json.Unmarshal([]byte(text), &updatedProfile); err != nil {
-		log.Printf("Failed to parse refined profile: %s", text)
+	if err := c.decodeJSON(ctx, text, &updatedProfile); err != nil {
+		log.Printf("Failed to parse refined profile (request %s): %s", utils.RequestIDFromContext(ctx), text)
 		return profile, nil // Return original on error
 	}
 
@@ -377,7 +655,7 @@ Return a JSON object with relevant fields:
 Only include fields that can be reasonably inferred from the query.
 Return ONLY the JSON object.`, query)
 
-	resp, err := c.model.GenerateContent(ctx, genai.Text(prompt))
+	resp, err := c.generate(ctx, genai.Text(prompt))
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate content: %w", err)
 	}
@@ -386,18 +664,199 @@ Return ONLY the JSON object.`, query)
 	text = cleanJSON(text)
 
 	var profile models.UserProfile
-	if err := json.Unmarshal([]byte(text), &profile); err != nil {
-		log.Printf("Failed to parse derived profile: %s", text)
+	if err := c.decodeJSON(ctx, text, &profile); err != nil {
+		log.Printf("Failed to parse derived profile (request %s): %s", utils.RequestIDFromContext(ctx), text)
 		return &models.UserProfile{}, nil
 	}
 
 	return &profile, nil
 }
 
+// profileSearchTerms holds the profile fields used to build search queries
+type profileSearchTerms struct {
+	Title          string   `json:"title,omitempty"`
+	Skills         []string `json:"skills,omitempty"`
+	PreferredRoles []string `json:"preferred_roles,omitempty"`
+	WorkTitles     []string `json:"work_titles,omitempty"`
+}
+
+// TranslateProfile translates the search-relevant fields of a profile (title, skills, roles,
+// work history titles) to English so PSE queries match English job postings.
+// Other fields such as names, companies and descriptions are left untouched.
+func (c *Client) TranslateProfile(ctx context.Context, profile *models.UserProfile) (*models.UserProfile, error) {
+	terms := profileSearchTerms{
+		Title:          profile.Title,
+		Skills:         profile.Skills,
+		PreferredRoles: profile.PreferredRoles,
+	}
+	for _, work := range profile.WorkHistory {
+		terms.WorkTitles = append(terms.WorkTitles, work.Title)
+	}
+	termsJSON, _ := json.Marshal(terms)
+
+	prompt := fmt.Sprintf(`Translate these job search terms from a CV written in language "%s" into English.
+
+TERMS:
+%s
+
+Rules:
+- Use the standard English job title or skill name a recruiter would search for
+- Keep technology, product and certification names unchanged (e.g. "Go", "Kubernetes", "SAP")
+- Keep every array the same length and order as the input
+- Return the same JSON structure
+
+Return ONLY the JSON object.`, profile.Language, termsJSON)
+
+	resp, err := c.generate(ctx, genai.Text(prompt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	text := extractText(resp)
+	text = cleanJSON(text)
+
+	var translated profileSearchTerms
+	if err := c.decodeJSON(ctx, text, &translated); err != nil {
+		log.Printf("Failed to parse translated profile terms (request %s): %s", utils.RequestIDFromContext(ctx), text)
+		return nil, fmt.Errorf("failed to parse translation JSON: %w", err)
+	}
+
+	result := *profile
+	if translated.Title != "" {
+		result.Title = translated.Title
+	}
+	if len(translated.Skills) == len(profile.Skills) {
+		result.Skills = translated.Skills
+	}
+	if len(translated.PreferredRoles) == len(profile.PreferredRoles) {
+		result.PreferredRoles = translated.PreferredRoles
+	}
+	if len(translated.WorkTitles) == len(profile.WorkHistory) {
+		result.WorkHistory = make([]models.WorkExperience, len(profile.WorkHistory))
+		copy(result.WorkHistory, profile.WorkHistory)
+		for i := range result.WorkHistory {
+			result.WorkHistory[i].Title = translated.WorkTitles[i]
+		}
+	}
+	result.Translated = true
+
+	log.Printf("[Gemini] Translated profile terms from %s: title=%q, skills=%d", profile.Language, result.Title, len(result.Skills))
+	return &result, nil
+}
+
+// PlanSearchQueries generates up to n diverse web search queries for a profile, covering alternative
+// role titles, synonyms and Bahasa Indonesia variants of the base query
+func (c *Client) PlanSearchQueries(ctx context.Context, profile *models.UserProfile, baseQuery string, n int) ([]string, error) {
+	terms := profileSearchTerms{
+		Title:          profile.Title,
+		Skills:         profile.Skills,
+		PreferredRoles: profile.PreferredRoles,
+	}
+	for _, work := range profile.WorkHistory {
+		terms.WorkTitles = append(terms.WorkTitles, work.Title)
+	}
+	termsJSON, _ := json.Marshal(terms)
+
+	prompt := fmt.Sprintf(`Plan web searches for job postings that fit this candidate.
+
+BASE QUERY: %s
+
+CANDIDATE:
+%s
+
+Generate %d search queries that are different from the base query and from each other, to find postings the base query would miss:
+- Alternative job titles for the same work (e.g. "backend engineer", "software engineer golang", "platform engineer")
+- Synonyms and seniority variants that match the candidate's experience
+- At least one Bahasa Indonesia variant (e.g. "lowongan backend developer", "loker programmer golang")
+
+Rules:
+- 2-6 words each, plain keywords only
+- No locations, site: operators, quotes or boolean operators (these are added separately)
+
+Return a JSON object:
+{"queries": ["query 1", "query 2"]}
+
+Return ONLY the JSON object.`, baseQuery, termsJSON, n)
+
+	resp, err := c.generate(ctx, genai.Text(prompt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	text := extractText(resp)
+	text = cleanJSON(text)
+
+	var plan struct {
+		Queries []string `json:"queries"`
+	}
+	if err := c.decodeJSON(ctx, text, &plan); err != nil {
+		log.Printf("Failed to parse query plan (request %s): %s", utils.RequestIDFromContext(ctx), text)
+		return nil, fmt.Errorf("failed to parse query plan JSON: %w", err)
+	}
+
+	queries := make([]string, 0, n)
+	for _, q := range plan.Queries {
+		if q = strings.TrimSpace(q); q != "" && len(queries) < n {
+			queries = append(queries, q)
+		}
+	}
+	return queries, nil
+}
+
+// SummarizeCompany builds a company profile from web search results about the company.
+// Fields the results don't support are left empty rather than guessed.
+func (c *Client) SummarizeCompany(ctx context.Context, companyName string, results []models.JobSearchResult) (*models.Company, error) {
+	resultsJSON, _ := json.Marshal(results)
+
+	prompt := fmt.Sprintf(`Summarize what these web search results say about the company "%s".
+
+SEARCH RESULTS:
+%s
+
+Return a JSON object:
+{
+  "name": "Company name as commonly written",
+  "website": "Official website URL",
+  "industry": "Industry, e.g. \"Fintech\" or \"E-commerce\"",
+  "size": "Employee count range, e.g. \"51-200\" or \"1001-5000\"",
+  "rating": 0.0,
+  "summary": "1-2 sentences on what the company does"
+}
+
+Rules:
+- Use only information in the search results; leave a field empty ("" or 0) if it isn't there
+- rating is the employee review rating out of 5 (e.g. from Glassdoor, JobStreet or Indeed), 0 if not shown
+- Ignore results about a different company with a similar name
+
+Return ONLY the JSON object.`, companyName, resultsJSON)
+
+	resp, err := c.generate(ctx, genai.Text(prompt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	text := extractText(resp)
+	text = cleanJSON(text)
+
+	var company models.Company
+	if err := c.decodeJSON(ctx, text, &company); err != nil {
+		log.Printf("Failed to parse company response (request %s): %s", utils.RequestIDFromContext(ctx), text)
+		return nil, fmt.Errorf("failed to parse company JSON: %w", err)
+	}
+	if company.Name == "" {
+		company.Name = companyName
+	}
+	if company.Rating < 0 || company.Rating > 5 {
+		company.Rating = 0
+	}
+
+	return &company, nil
+}
+
 // Helper functions
 
 func extractText(resp *genai.GenerateContentResponse) string {
-	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
+	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil || len(resp.Candidates[0].Content.Parts) == 0 {
 		return ""
 	}
 
@@ -410,6 +869,33 @@ func extractText(resp *genai.GenerateContentResponse) string {
 	return sb.String()
 }
 
+// reorderSkills keeps the model's ordering of the candidate's skills, drops any skill
+// not in the profile, and appends profile skills the model left out
+func reorderSkills(ordered, original []string) []string {
+	known := make(map[string]string, len(original))
+	for _, s := range original {
+		known[strings.ToLower(strings.TrimSpace(s))] = s
+	}
+
+	result := make([]string, 0, len(known))
+	used := make(map[string]bool, len(known))
+	for _, s := range ordered {
+		key := strings.ToLower(strings.TrimSpace(s))
+		if skill, ok := known[key]; ok && !used[key] {
+			used[key] = true
+			result = append(result, skill)
+		}
+	}
+	for _, s := range original {
+		key := strings.ToLower(strings.TrimSpace(s))
+		if key != "" && !used[key] {
+			used[key] = true
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
 func cleanJSON(text string) string {
 	// Remove markdown code blocks if present
 	text = strings.TrimSpace(text)
@@ -0,0 +1,131 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"cloud.google.com/go/vertexai/genai"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// DebugStore persists recorded Gemini calls for diagnosing bad model output
+type DebugStore interface {
+	SaveLLMDebugRecord(ctx context.Context, record *models.LLMDebugRecord) error
+}
+
+// maxDebugFieldChars bounds each recorded text, keeping records well under Firestore's 1 MiB document limit
+const maxDebugFieldChars = 100000
+
+// debugSaveTimeout bounds writing a record, which happens in the background
+const debugSaveTimeout = 10 * time.Second
+
+// personalFieldPattern matches the name, email and phone fields of JSON profiles in prompts and responses
+var personalFieldPattern = regexp.MustCompile(`("(?:name|email|phone)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// SetDebugStore records the prompt and raw response of every Gemini call made for an HTTP request
+// (identified by the request ID in the context) to store, keeping records for ttl. Calls made by
+// background workers have no request ID and are not recorded.
+func (c *Client) SetDebugStore(store DebugStore, ttl time.Duration) {
+	c.debugStore = store
+	c.debugTTL = ttl
+}
+
+// recordCall stores a redacted record of a Gemini call in the background, if debug recording is enabled
+func (c *Client) recordCall(ctx context.Context, model *genai.GenerativeModel, prompt []genai.Part, response string, usage *genai.UsageMetadata, callErr error, started time.Time) {
+	if c.debugStore == nil {
+		return
+	}
+	requestID := utils.RequestIDFromContext(ctx)
+	if requestID == "" {
+		return
+	}
+
+	now := time.Now()
+	record := &models.LLMDebugRecord{
+		RequestID:         requestID,
+		Model:             model.Name(),
+		SystemInstruction: redactDebugText(systemInstructionText(model)),
+		Prompt:            redactDebugText(partsText(prompt)),
+		Response:          redactDebugText(response),
+		DurationMs:        now.Sub(started).Milliseconds(),
+		CreatedAt:         now,
+		ExpiresAt:         now.Add(c.debugTTL),
+	}
+	if callErr != nil {
+		record.Error = callErr.Error()
+	}
+	if usage != nil {
+		record.PromptTokens = int(usage.PromptTokenCount)
+		record.OutputTokens = int(usage.CandidatesTokenCount)
+	}
+
+	go func() {
+		saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), debugSaveTimeout)
+		defer cancel()
+		if err := c.debugStore.SaveLLMDebugRecord(saveCtx, record); err != nil {
+			log.Printf("[Gemini] Failed to save debug record for request %s: %v", requestID, err)
+		}
+	}()
+}
+
+// redactDebugText removes email addresses, phone numbers and profile name/email/phone fields, and bounds the length
+func redactDebugText(text string) string {
+	text = personalFieldPattern.ReplaceAllString(text, `$1"[redacted]"`)
+	text = utils.RedactText(text)
+	if len(text) > maxDebugFieldChars {
+		// Cut at a character boundary, Firestore refuses strings that aren't valid UTF-8
+		end := maxDebugFieldChars
+		for end > 0 && !utf8.RuneStart(text[end]) {
+			end--
+		}
+		text = text[:end] + "\n[truncated]"
+	}
+	return text
+}
+
+// systemInstructionText returns the model's system instruction, or a note if it lives in a context cache
+func systemInstructionText(model *genai.GenerativeModel) string {
+	if model.CachedContentName != "" {
+		return "[context cache " + model.CachedContentName + "]"
+	}
+	if model.SystemInstruction == nil {
+		return ""
+	}
+	return partsText(model.SystemInstruction.Parts)
+}
+
+// partsText renders request or response parts as text; binary data is summarized by type and size
+func partsText(parts []genai.Part) string {
+	texts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		switch p := part.(type) {
+		case genai.Text:
+			texts = append(texts, string(p))
+		case genai.Blob:
+			texts = append(texts, fmt.Sprintf("[%s, %d bytes]", p.MIMEType, len(p.Data)))
+		default:
+			encoded, err := json.Marshal(p)
+			if err != nil {
+				encoded = []byte(fmt.Sprintf("[%T]", p))
+			}
+			texts = append(texts, string(encoded))
+		}
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+// responseText renders the first candidate of a response, including any function calls
+func responseText(resp *genai.GenerateContentResponse) string {
+	if resp == nil || len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+		return ""
+	}
+	return partsText(resp.Candidates[0].Content.Parts)
+}
@@ -0,0 +1,112 @@
+package gemini
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	aiplatform "cloud.google.com/go/aiplatform/apiv1"
+	"google.golang.org/api/option"
+
+	"github.com/myjobmatch/backend/config"
+)
+
+// Embedding task types tell the model how the text will be used
+const (
+	EmbeddingTaskDocument = "RETRIEVAL_DOCUMENT" // Texts being searched, e.g. job postings
+	EmbeddingTaskQuery    = "RETRIEVAL_QUERY"    // Texts searched with, e.g. a profile
+)
+
+// maxEmbeddingBatch is how many texts are sent per prediction request
+const maxEmbeddingBatch = 25
+
+// ErrEmbeddingsDisabled is returned when embeddings are requested without EMBEDDINGS_ENABLED
+var ErrEmbeddingsDisabled = errors.New("embeddings are not enabled")
+
+// newEmbeddingClient creates a prediction client for the configured embedding model,
+// returning the client and the model's endpoint name
+func newEmbeddingClient(ctx context.Context, cfg *config.Config) (*aiplatform.PredictionClient, string, error) {
+	apiEndpoint := fmt.Sprintf("%s-aiplatform.googleapis.com:443", cfg.Location)
+	client, err := aiplatform.NewPredictionClient(ctx, option.WithEndpoint(apiEndpoint))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create embedding client: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("projects/%s/locations/%s/publishers/google/models/%s", cfg.ProjectID, cfg.Location, cfg.EmbeddingModel)
+	return client, endpoint, nil
+}
+
+// EmbedText returns the embedding of a single text
+func (c *Client) EmbedText(ctx context.Context, text, taskType string) ([]float32, error) {
+	embeddings, err := c.EmbedTexts(ctx, []string{text}, taskType)
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// EmbedTexts returns one embedding per text, in order. Texts embedded recently with the same task type
+// are served from the client's cache; the rest are sent in batches.
+// Embedding calls are not counted against the request's LLM budget.
+func (c *Client) EmbedTexts(ctx context.Context, texts []string, taskType string) ([][]float32, error) {
+	if c.embeddingCache == nil {
+		return nil, ErrEmbeddingsDisabled
+	}
+
+	embeddings := make([][]float32, len(texts))
+	var missing []int
+	for i, text := range texts {
+		if vector, ok := c.embeddingCache.Get(embeddingCacheKey(text, taskType)); ok {
+			embeddings[i] = vector
+		} else {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) == 0 {
+		return embeddings, nil
+	}
+
+	missingTexts := make([]string, len(missing))
+	for i, idx := range missing {
+		missingTexts[i] = texts[idx]
+	}
+	embedded, err := c.embedBatches(ctx, missingTexts, taskType)
+	if err != nil {
+		return nil, err
+	}
+	for i, idx := range missing {
+		embeddings[idx] = embedded[i]
+		c.embeddingCache.Put(embeddingCacheKey(texts[idx], taskType), embedded[i])
+	}
+
+	return embeddings, nil
+}
+
+// embeddingCacheKey identifies a text embedded for a task type
+func embeddingCacheKey(text, taskType string) string {
+	sum := sha256.Sum256([]byte(taskType + "\x00" + text))
+	return hex.EncodeToString(sum[:])
+}
+
+// embedBatches embeds texts with the model, maxEmbeddingBatch texts per request
+func (c *Client) embedBatches(ctx context.Context, texts []string, taskType string) ([][]float32, error) {
+	embeddings := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += maxEmbeddingBatch {
+		end := min(start+maxEmbeddingBatch, len(texts))
+
+		// Embeddings are served by the LLM provider too, so they share its circuit breaker
+		if err := c.breaker.Allow(); err != nil {
+			return nil, err
+		}
+		batch, err := c.provider.embed(ctx, texts[start:end], taskType)
+		c.breaker.Record(err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed texts: %w", err)
+		}
+		embeddings = append(embeddings, batch...)
+	}
+
+	return embeddings, nil
+}
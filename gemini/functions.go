@@ -0,0 +1,247 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/vertexai/genai"
+)
+
+// FunctionDeclaration describes a function the model may call; Parameters is a JSON schema object,
+// or nil for a function without parameters
+type FunctionDeclaration struct {
+	Name        string
+	Description string
+	Parameters  map[string]interface{}
+}
+
+// FunctionCall is a function call requested by the model, with its arguments as a JSON object
+type FunctionCall struct {
+	Name      string
+	Arguments json.RawMessage
+}
+
+// FunctionResult is a function's output returned to the model
+type FunctionResult struct {
+	Name     string
+	Response map[string]interface{}
+}
+
+// ToolChat is a multi-turn conversation in which the model may call the declared functions.
+// The caller runs the requested calls and sends their results back until the model answers in text.
+type ToolChat struct {
+	client  *Client
+	model   *genai.GenerativeModel
+	history []*genai.Content // Turns sent and received so far
+
+	// Object parameters without declared properties, which Gemini can't describe, are passed as
+	// JSON-encoded strings; opaque lists them per function so they are decoded back into objects
+	opaque map[string]map[string]bool
+}
+
+// NewToolChat starts a conversation with the given system instruction and callable functions
+func (c *Client) NewToolChat(systemInstruction string, functions []FunctionDeclaration) *ToolChat {
+	model := *c.model
+	model.SystemInstruction = genai.NewUserContent(genai.Text(systemInstruction))
+
+	chat := &ToolChat{client: c, model: &model, opaque: make(map[string]map[string]bool)}
+	declarations := make([]*genai.FunctionDeclaration, 0, len(functions))
+	for _, fn := range functions {
+		opaque := make(map[string]bool)
+		declaration := &genai.FunctionDeclaration{Name: fn.Name, Description: fn.Description}
+		if fn.Parameters != nil {
+			declaration.Parameters = schemaFromJSON(fn.Parameters, opaque)
+		}
+		declarations = append(declarations, declaration)
+		chat.opaque[fn.Name] = opaque
+	}
+	if len(declarations) > 0 {
+		model.Tools = []*genai.Tool{{FunctionDeclarations: declarations}}
+	}
+
+	return chat
+}
+
+// ChatTurn is a text turn of an earlier conversation
+type ChatTurn struct {
+	Role string // "user" or "model"
+	Text string
+}
+
+// SetHistory resumes an earlier conversation from its text turns. It must be called before the first message is sent.
+func (t *ToolChat) SetHistory(turns []ChatTurn) {
+	history := make([]*genai.Content, 0, len(turns))
+	for _, turn := range turns {
+		if turn.Text == "" {
+			continue
+		}
+		history = append(history, &genai.Content{Role: turn.Role, Parts: []genai.Part{genai.Text(turn.Text)}})
+	}
+	t.history = history
+}
+
+// Send sends a user message and returns the model's text and the function calls it requested
+func (t *ToolChat) Send(ctx context.Context, message string) (string, []FunctionCall, error) {
+	return t.send(ctx, genai.Text(message))
+}
+
+// SendResults returns function results to the model, optionally with a user message,
+// and returns the model's text and any further function calls
+func (t *ToolChat) SendResults(ctx context.Context, results []FunctionResult, message string) (string, []FunctionCall, error) {
+	parts := make([]genai.Part, 0, len(results)+1)
+	for _, r := range results {
+		parts = append(parts, genai.FunctionResponse{Name: r.Name, Response: r.Response})
+	}
+	if message != "" {
+		parts = append(parts, genai.Text(message))
+	}
+	return t.send(ctx, parts...)
+}
+
+// send sends parts in the conversation, enforcing and recording the request budget attached to ctx (if any)
+func (t *ToolChat) send(ctx context.Context, parts ...genai.Part) (string, []FunctionCall, error) {
+	breaker := t.client.breaker
+	if err := breaker.Allow(); err != nil {
+		return "", nil, err
+	}
+	budget := BudgetFromContext(ctx)
+	if budget != nil {
+		if err := budget.reserve(); err != nil {
+			breaker.Release()
+			return "", nil, err
+		}
+	}
+	if err := t.client.limiter.Acquire(ctx); err != nil {
+		breaker.Release()
+		return "", nil, err
+	}
+
+	started := time.Now()
+	resp, err := t.client.provider.generateContent(ctx, t.model, t.history, parts)
+	breaker.Record(err)
+	if err != nil {
+		err = blockedError(err)
+		t.client.recordCall(ctx, t.model, parts, "", nil, err, started)
+		return "", nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+	t.history = append(t.history, genai.NewUserContent(parts...))
+	if len(resp.Candidates) > 0 && resp.Candidates[0].Content != nil {
+		t.history = append(t.history, resp.Candidates[0].Content)
+	}
+	t.client.recordCall(ctx, t.model, parts, responseText(resp), resp.UsageMetadata, nil, started)
+	t.client.recordUsage(budget, resp.UsageMetadata)
+
+	var calls []FunctionCall
+	for _, fc := range functionCalls(resp) {
+		args := fc.Args
+		for name := range t.opaque[fc.Name] {
+			if encoded, ok := args[name].(string); ok {
+				var decoded interface{}
+				if json.Unmarshal([]byte(encoded), &decoded) == nil {
+					args[name] = decoded
+				}
+			}
+		}
+		argsJSON, err := json.Marshal(args)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to encode %s arguments: %w", fc.Name, err)
+		}
+		calls = append(calls, FunctionCall{Name: fc.Name, Arguments: argsJSON})
+	}
+
+	return extractText(resp), calls, nil
+}
+
+// functionCalls returns the function calls in the response's first candidate
+func functionCalls(resp *genai.GenerateContentResponse) []genai.FunctionCall {
+	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+		return nil
+	}
+
+	var calls []genai.FunctionCall
+	for _, part := range resp.Candidates[0].Content.Parts {
+		if fc, ok := part.(genai.FunctionCall); ok {
+			calls = append(calls, fc)
+		}
+	}
+	return calls
+}
+
+// schemaFromJSON converts a JSON schema object to a Gemini schema. Top-level object properties
+// that declare no properties of their own become JSON-encoded strings and are added to opaque.
+func schemaFromJSON(schema map[string]interface{}, opaque map[string]bool) *genai.Schema {
+	s := convertSchema(schema)
+	for name, prop := range s.Properties {
+		if prop.Type == genai.TypeObject && len(prop.Properties) == 0 {
+			s.Properties[name] = &genai.Schema{
+				Type:        genai.TypeString,
+				Description: prop.Description + " (JSON-encoded object)",
+			}
+			opaque[name] = true
+		}
+	}
+	return s
+}
+
+// convertSchema converts the JSON schema keywords Gemini supports
+func convertSchema(schema map[string]interface{}) *genai.Schema {
+	s := &genai.Schema{}
+	if description, ok := schema["description"].(string); ok {
+		s.Description = description
+	}
+
+	switch schema["type"] {
+	case "string":
+		s.Type = genai.TypeString
+	case "number":
+		s.Type = genai.TypeNumber
+	case "integer":
+		s.Type = genai.TypeInteger
+	case "boolean":
+		s.Type = genai.TypeBoolean
+	case "array":
+		s.Type = genai.TypeArray
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			s.Items = convertSchema(items)
+		} else {
+			s.Items = &genai.Schema{Type: genai.TypeString}
+		}
+	default:
+		s.Type = genai.TypeObject
+	}
+
+	switch enum := schema["enum"].(type) {
+	case []string:
+		s.Enum = enum
+	case []interface{}:
+		for _, v := range enum {
+			if str, ok := v.(string); ok {
+				s.Enum = append(s.Enum, str)
+			}
+		}
+	}
+
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		s.Properties = make(map[string]*genai.Schema, len(properties))
+		for name, prop := range properties {
+			if propSchema, ok := prop.(map[string]interface{}); ok {
+				s.Properties[name] = convertSchema(propSchema)
+			}
+		}
+	}
+
+	switch required := schema["required"].(type) {
+	case []string:
+		s.Required = required
+	case []interface{}:
+		for _, v := range required {
+			if str, ok := v.(string); ok {
+				s.Required = append(s.Required, str)
+			}
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,111 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"cloud.google.com/go/vertexai/genai"
+
+	"github.com/myjobmatch/backend/utils"
+)
+
+// maxRepairInputBytes bounds how much malformed output is sent back to the model for repair
+const maxRepairInputBytes = 30000
+
+// decodeJSON decodes a model's JSON response into v. Malformed JSON is first repaired locally (surrounding
+// prose and trailing commas removed); if it still doesn't decode, the model is asked once to fix its own
+// output, so a single malformed response doesn't fail the whole call. The original parse error is
+// returned if both repairs fail.
+func (c *Client) decodeJSON(ctx context.Context, text string, v interface{}) error {
+	err := json.Unmarshal([]byte(text), v)
+	if err == nil {
+		return nil
+	}
+
+	if repaired := repairJSON(text); repaired != text && json.Unmarshal([]byte(repaired), v) == nil {
+		log.Printf("[Gemini] Repaired malformed JSON locally (request %s)", utils.RequestIDFromContext(ctx))
+		return nil
+	}
+
+	fixed, repairErr := c.askToFixJSON(ctx, text, err)
+	if repairErr != nil {
+		log.Printf("[Gemini] JSON repair call failed (request %s): %v", utils.RequestIDFromContext(ctx), repairErr)
+		return err
+	}
+	if json.Unmarshal([]byte(fixed), v) != nil {
+		return err
+	}
+	log.Printf("[Gemini] Model repaired its malformed JSON (request %s)", utils.RequestIDFromContext(ctx))
+	return nil
+}
+
+// askToFixJSON sends malformed JSON back to the model with the parse error and returns its corrected JSON
+func (c *Client) askToFixJSON(ctx context.Context, text string, parseErr error) (string, error) {
+	if len(text) > maxRepairInputBytes {
+		return "", fmt.Errorf("malformed output too long to repair (%d bytes)", len(text))
+	}
+
+	prompt := fmt.Sprintf(`The following text was supposed to be a single valid JSON value, but parsing it failed with: %v
+
+Fix the JSON syntax only (quotes, commas, brackets, escaping, truncation). Keep every field name and value
+unchanged, and do not add or remove data.
+
+TEXT:
+%s
+
+Return ONLY the corrected JSON, no markdown formatting, no explanation.`, parseErr, text)
+
+	resp, err := c.generate(ctx, genai.Text(prompt))
+	if err != nil {
+		return "", err
+	}
+	return repairJSON(cleanJSON(extractText(resp))), nil
+}
+
+// repairJSON fixes common syntax slips in model output: text around the JSON value and
+// trailing commas before a closing bracket
+func repairJSON(text string) string {
+	if start := strings.IndexAny(text, "{["); start > 0 {
+		text = text[start:]
+	}
+	if end := strings.LastIndexAny(text, "}]"); end >= 0 && end < len(text)-1 {
+		text = text[:end+1]
+	}
+	return stripTrailingCommas(text)
+}
+
+// stripTrailingCommas removes commas directly followed (ignoring whitespace) by '}' or ']', outside strings
+func stripTrailingCommas(text string) string {
+	var sb strings.Builder
+	sb.Grow(len(text))
+	inString, escaped := false, false
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == '"':
+				inString = false
+			}
+			sb.WriteByte(ch)
+			continue
+		}
+
+		if ch == '"' {
+			inString = true
+		} else if ch == ',' {
+			next := strings.TrimLeft(text[i+1:], " \t\r\n")
+			if strings.HasPrefix(next, "}") || strings.HasPrefix(next, "]") {
+				continue
+			}
+		}
+		sb.WriteByte(ch)
+	}
+	return sb.String()
+}
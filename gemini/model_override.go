@@ -0,0 +1,61 @@
+package gemini
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/vertexai/genai"
+)
+
+// ErrModelNotAllowed is returned when a request asks for a model that isn't in GEMINI_ALLOWED_MODELS
+var ErrModelNotAllowed = errors.New("model not allowed")
+
+type modelContextKey struct{}
+
+// WithModel selects the model used for Gemini calls made with the context. An empty name keeps the default
+// model; the name must have been checked with ValidateModel.
+func WithModel(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, modelContextKey{}, name)
+}
+
+// ValidateModel checks that a requested model may be used; the default model and GEMINI_ALLOWED_MODELS are allowed
+func (c *Client) ValidateModel(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, ok := c.models[name]; !ok {
+		return fmt.Errorf("%w: %q (allowed: %s)", ErrModelNotAllowed, name, strings.Join(c.AllowedModels(), ", "))
+	}
+	return nil
+}
+
+// AllowedModels lists the models requests may select, the default model first
+func (c *Client) AllowedModels() []string {
+	names := []string{c.modelName}
+	for _, name := range c.allowedModels {
+		if name != c.modelName {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// modelFor returns the model selected for the context, or the default model
+func (c *Client) modelFor(ctx context.Context) *genai.GenerativeModel {
+	return c.models[c.modelNameFor(ctx)]
+}
+
+// modelNameFor returns the name of the model selected for the context, or the default model's name
+func (c *Client) modelNameFor(ctx context.Context) string {
+	if name, ok := ctx.Value(modelContextKey{}).(string); ok {
+		if _, allowed := c.models[name]; allowed {
+			return name
+		}
+	}
+	return c.modelName
+}
@@ -0,0 +1,38 @@
+package gemini
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// vertexOutage reports whether a failed Gemini call indicates that Vertex AI (or the local LLM server)
+// is failing, as opposed to a problem with the request (invalid input, blocked content), the caller going away
+// or a local limit (the request budget, the client-side rate limit) refusing the call
+func vertexOutage(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, ErrContentBlocked) ||
+		errors.Is(err, ErrBudgetExhausted) || errors.Is(err, ErrCapacityExceeded) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var statusErr *ProviderStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500 || statusErr.StatusCode == 429
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.ResourceExhausted, codes.Unknown:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package gemini
+
+import (
+	"context"
+	"sort"
+)
+
+// Prompt variants compared by prompt experiments. A variant changes the scoring and extraction prompts together.
+const (
+	// PromptVariantControl is the default prompts
+	PromptVariantControl = "control"
+	// PromptVariantStrict scores with an explicit weighted rubric that penalizes missing must-have
+	// requirements, and extracts only fields the page states instead of inferring them
+	PromptVariantStrict = "strict"
+)
+
+// promptVariants lists the defined variants
+var promptVariants = map[string]bool{
+	PromptVariantControl: true,
+	PromptVariantStrict:  true,
+}
+
+// IsPromptVariant reports whether name is a defined prompt variant
+func IsPromptVariant(name string) bool {
+	return promptVariants[name]
+}
+
+// PromptVariants lists the defined prompt variants in alphabetical order
+func PromptVariants() []string {
+	names := make([]string, 0, len(promptVariants))
+	for name := range promptVariants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type promptVariantContextKey struct{}
+
+// WithPromptVariant selects the prompt variant used for scoring and extraction calls made with the context.
+// An empty or unknown name keeps the control prompts.
+func WithPromptVariant(ctx context.Context, variant string) context.Context {
+	if variant == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, promptVariantContextKey{}, variant)
+}
+
+// PromptVariantFromContext returns the prompt variant selected for the context, or the control variant
+func PromptVariantFromContext(ctx context.Context) string {
+	if variant, ok := ctx.Value(promptVariantContextKey{}).(string); ok && promptVariants[variant] {
+		return variant
+	}
+	return PromptVariantControl
+}
+
+// scoringCriteria returns the scoring prompt's criteria section for a variant
+func scoringCriteria(variant string) string {
+	if variant == PromptVariantStrict {
+		return `Score with this rubric, weighting each criterion as shown:
+- Skills alignment (40%): required skills and technologies the candidate has
+- Experience level match (25%): years and seniority the posting asks for
+- Location and remote preferences (15%)
+- Job type preferences (10%)
+- Industry/domain relevance (10%)
+If the posting lists a must-have requirement the candidate clearly lacks (a core technology, a license,
+a degree or a minimum years of experience far above theirs), keep match_score below 50.`
+	}
+	return `Consider:
+- Skills alignment (most important)
+- Experience level match
+- Location and remote preferences
+- Job type preferences
+- Industry/domain relevance`
+}
+
+// extractionGuidance returns extra extraction instructions for a variant (empty for the control prompt)
+func extractionGuidance(variant string) string {
+	if variant == PromptVariantStrict {
+		return `
+Only fill a field when the page states it. Do not infer work_type, site_setting, experience_level or salary
+from the company, the job title or typical postings; leave them empty (site_setting "Unknown") instead.
+`
+	}
+	return ""
+}
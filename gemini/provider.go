@@ -0,0 +1,153 @@
+package gemini
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	aiplatform "cloud.google.com/go/aiplatform/apiv1"
+	"cloud.google.com/go/aiplatform/apiv1/aiplatformpb"
+	"cloud.google.com/go/vertexai/genai"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/myjobmatch/backend/config"
+)
+
+// provider serves the client's model calls. Requests and responses use the genai types whatever the
+// backend: a model handle carries the system instruction, tools and generation config of a call.
+type provider interface {
+	// generateContent sends history followed by a user turn made of parts
+	generateContent(ctx context.Context, model *genai.GenerativeModel, history []*genai.Content, parts []genai.Part) (*genai.GenerateContentResponse, error)
+	// generateContentStream is generateContent without history, passing the response to onChunk as it arrives.
+	// An error returned by onChunk stops the generation and is returned as is.
+	generateContentStream(ctx context.Context, model *genai.GenerativeModel, parts []genai.Part, onChunk func(*genai.GenerateContentResponse) error) error
+	// embed returns one embedding per text, in order; texts holds at most maxEmbeddingBatch texts
+	embed(ctx context.Context, texts []string, taskType string) ([][]float32, error)
+	// ping checks that the backend is reachable without generating anything
+	ping(ctx context.Context, model *genai.GenerativeModel) error
+	close()
+}
+
+// newProvider creates the provider selected by LLM_PROVIDER
+func newProvider(ctx context.Context, cfg *config.Config) (provider, error) {
+	switch cfg.LLMProvider {
+	case config.LLMProviderOpenAI:
+		return newOpenAIProvider(cfg), nil
+	case config.LLMProviderMock:
+		return mockProvider{}, nil
+	}
+
+	p := &vertexProvider{}
+	if cfg.EmbeddingsEnabled {
+		var err error
+		p.embedder, p.embeddingEndpoint, err = newEmbeddingClient(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// providerName names an LLM provider in circuit breaker errors
+func providerName(provider string) string {
+	switch provider {
+	case config.LLMProviderOpenAI:
+		return "local LLM"
+	case config.LLMProviderMock:
+		return "mock LLM"
+	}
+	return "Vertex AI"
+}
+
+// vertexProvider sends calls to Gemini on Vertex AI
+type vertexProvider struct {
+	embedder          *aiplatform.PredictionClient // nil unless EMBEDDINGS_ENABLED
+	embeddingEndpoint string
+}
+
+// ping counts the tokens of a short text, which checks credentials and the model without billing a generation
+func (p *vertexProvider) ping(ctx context.Context, model *genai.GenerativeModel) error {
+	_, err := model.CountTokens(ctx, genai.Text("ping"))
+	return err
+}
+
+func (p *vertexProvider) generateContent(ctx context.Context, model *genai.GenerativeModel, history []*genai.Content, parts []genai.Part) (*genai.GenerateContentResponse, error) {
+	if len(history) == 0 {
+		return model.GenerateContent(ctx, parts...)
+	}
+	session := model.StartChat()
+	session.History = slices.Clip(history)
+	return session.SendMessage(ctx, parts...)
+}
+
+func (p *vertexProvider) generateContentStream(ctx context.Context, model *genai.GenerativeModel, parts []genai.Part, onChunk func(*genai.GenerateContentResponse) error) error {
+	iter := model.GenerateContentStream(ctx, parts...)
+	for {
+		resp, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := onChunk(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *vertexProvider) embed(ctx context.Context, texts []string, taskType string) ([][]float32, error) {
+	instances := make([]*structpb.Value, 0, len(texts))
+	for _, text := range texts {
+		instance, err := structpb.NewValue(map[string]interface{}{
+			"content":   text,
+			"task_type": taskType,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build embedding request: %w", err)
+		}
+		instances = append(instances, instance)
+	}
+
+	resp, err := p.embedder.Predict(ctx, &aiplatformpb.PredictRequest{
+		Endpoint:  p.embeddingEndpoint,
+		Instances: instances,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Predictions) != len(texts) {
+		return nil, fmt.Errorf("embedding model returned %d embeddings for %d texts", len(resp.Predictions), len(texts))
+	}
+
+	embeddings := make([][]float32, 0, len(texts))
+	for _, prediction := range resp.Predictions {
+		values := prediction.GetStructValue().GetFields()["embeddings"].GetStructValue().GetFields()["values"].GetListValue().GetValues()
+		if len(values) == 0 {
+			return nil, errors.New("embedding model returned an empty embedding")
+		}
+		vector := make([]float32, len(values))
+		for i, v := range values {
+			vector[i] = float32(v.GetNumberValue())
+		}
+		embeddings = append(embeddings, vector)
+	}
+	return embeddings, nil
+}
+
+func (p *vertexProvider) close() {
+	if p.embedder != nil {
+		p.embedder.Close()
+	}
+}
+
+// streamWhole implements generateContentStream for providers that don't stream: the whole response is one chunk
+func streamWhole(ctx context.Context, p provider, model *genai.GenerativeModel, parts []genai.Part, onChunk func(*genai.GenerateContentResponse) error) error {
+	resp, err := p.generateContent(ctx, model, nil, parts)
+	if err != nil {
+		return err
+	}
+	return onChunk(resp)
+}
@@ -0,0 +1,256 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"cloud.google.com/go/vertexai/genai"
+
+	"github.com/myjobmatch/backend/matching"
+	"github.com/myjobmatch/backend/models"
+)
+
+// mockEmbeddingDimensions is the size of the mock provider's embeddings
+const mockEmbeddingDimensions = 64
+
+// mockProfile is the profile the mock provider parses from every CV
+var mockProfile = models.UserProfile{
+	Name:                 "Dewi Lestari",
+	Email:                "dewi@example.com",
+	Summary:              "Backend engineer building payment and logistics APIs in Go.",
+	Title:                "Backend Engineer",
+	Experience:           4,
+	Skills:               []string{"Go", "PostgreSQL", "REST APIs", "Docker"},
+	TechnicalStack:       []string{"Go", "PostgreSQL", "Redis", "Docker", "Kubernetes"},
+	Languages:            []string{"English", "Indonesian"},
+	PreferredRoles:       []string{"Backend Engineer", "Software Engineer"},
+	PreferredLocations:   []string{"Jakarta", "Remote"},
+	PreferredRemoteModes: []string{"WFH", "Hybrid"},
+	PreferredJobTypes:    []string{"full_time"},
+	Language:             "en",
+}
+
+var (
+	htmlTitlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	mockWordPattern  = regexp.MustCompile(`[\pL\pN]+`)
+)
+
+// mockProvider answers every call with a canned response chosen from the prompt, so the pipeline can run
+// (and be tested) without a model. Responses depend only on the request: the same prompt always gets the
+// same answer. Scores come from the heuristic scorer and embeddings from hashed words.
+type mockProvider struct{}
+
+func (mockProvider) generateContent(ctx context.Context, model *genai.GenerativeModel, history []*genai.Content, parts []genai.Part) (*genai.GenerateContentResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Match on the text parts only; a CV's PDF blob comes before the prompt
+	var texts []string
+	for _, part := range parts {
+		if t, ok := part.(genai.Text); ok {
+			texts = append(texts, string(t))
+		}
+	}
+	prompt := strings.Join(texts, "\n\n")
+	text := mockResponse(systemInstructionText(model), prompt, len(model.Tools) > 0)
+	return &genai.GenerateContentResponse{
+		Candidates: []*genai.Candidate{{
+			Content:      &genai.Content{Role: "model", Parts: []genai.Part{genai.Text(text)}},
+			FinishReason: genai.FinishReasonStop,
+		}},
+		UsageMetadata: &genai.UsageMetadata{
+			PromptTokenCount:     int32(len(prompt) / 4),
+			CandidatesTokenCount: int32(len(text) / 4),
+			TotalTokenCount:      int32((len(prompt) + len(text)) / 4),
+		},
+	}, nil
+}
+
+func (p mockProvider) generateContentStream(ctx context.Context, model *genai.GenerativeModel, parts []genai.Part, onChunk func(*genai.GenerateContentResponse) error) error {
+	return streamWhole(ctx, p, model, parts, onChunk)
+}
+
+// embed hashes each word of a text into a normalized vector, so texts sharing words are similar
+func (mockProvider) embed(ctx context.Context, texts []string, taskType string) ([][]float32, error) {
+	embeddings := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		vector := make([]float32, mockEmbeddingDimensions)
+		for _, word := range mockWordPattern.FindAllString(strings.ToLower(text), -1) {
+			h := fnv.New32a()
+			h.Write([]byte(word))
+			vector[h.Sum32()%mockEmbeddingDimensions]++
+		}
+
+		var norm float64
+		for _, v := range vector {
+			norm += float64(v) * float64(v)
+		}
+		if norm > 0 {
+			scale := float32(1 / math.Sqrt(norm))
+			for i := range vector {
+				vector[i] *= scale
+			}
+		}
+		embeddings = append(embeddings, vector)
+	}
+	return embeddings, nil
+}
+
+func (mockProvider) ping(ctx context.Context, model *genai.GenerativeModel) error {
+	return nil
+}
+
+func (mockProvider) close() {}
+
+// mockResponse picks the canned response for a prompt by the task its opening line describes
+func mockResponse(systemInstruction, prompt string, withTools bool) string {
+	switch {
+	case withTools:
+		return "This is a mock reply from the local development model. You asked: " + truncateMock(lastLine(prompt), 200)
+
+	case strings.HasPrefix(prompt, "JOB POSTING:") && strings.Contains(systemInstruction, "CANDIDATE PROFILE:"):
+		return mockScore(systemInstruction, prompt)
+
+	case strings.HasPrefix(prompt, "Analyze this CV/resume") || strings.HasPrefix(prompt, "Analyze the following CV/resume"):
+		return mockJSON(mockProfile)
+
+	case strings.HasPrefix(prompt, "Extract the job postings from this HTML"):
+		return mockJobs(promptField(prompt, "URL: "), section(prompt, "HTML CONTENT:\n", "\n\nReturn ONLY"))
+
+	case strings.HasPrefix(prompt, "Summarize this job posting"):
+		return mockJSON(models.JobSummary{
+			Title:            "Backend Engineer",
+			Company:          mockCompany(promptField(prompt, "URL: ")),
+			Overview:         "Build and run the APIs behind the company's products.",
+			Responsibilities: []string{"Design and build REST APIs in Go", "Maintain PostgreSQL schemas", "Review code and mentor peers"},
+			MustHaveSkills:   []string{"Go", "PostgreSQL", "3+ years of backend experience"},
+			NiceToHaveSkills: []string{"Kubernetes"},
+			RedFlags:         []string{},
+			Language:         "en",
+		})
+
+	case strings.HasPrefix(prompt, "You are an expert CV writer"):
+		return mockJSON(models.TailoredCV{
+			Summary:       "Backend engineer with several years building APIs in Go, focused on reliable, well-tested services.",
+			Highlights:    []string{"Built and operated production REST APIs"},
+			MissingSkills: []string{},
+		})
+
+	case strings.HasPrefix(prompt, "Given this user profile and their search query"):
+		return section(prompt, "EXISTING PROFILE:\n", "\n\nSEARCH QUERY:")
+
+	case strings.HasPrefix(prompt, "Extract job search preferences from this search query"):
+		query := promptField(prompt, "SEARCH QUERY: ")
+		return mockJSON(models.UserProfile{Title: query, PreferredRoles: []string{query}})
+
+	case strings.HasPrefix(prompt, "Translate these job search terms"):
+		return section(prompt, "TERMS:\n", "\n\nRules:")
+
+	case strings.HasPrefix(prompt, "Plan web searches"):
+		base := promptField(prompt, "BASE QUERY: ")
+		return mockJSON(map[string][]string{"queries": {base + " developer", "lowongan " + base}})
+
+	case strings.HasPrefix(prompt, "Summarize what these web search results say about the company"):
+		name := section(prompt, `the company "`, `".`)
+		return mockJSON(models.Company{Name: name, Industry: "Technology", Summary: name + " builds software products."})
+
+	case strings.HasPrefix(prompt, "The following text was supposed to be a single valid JSON value"):
+		return section(prompt, "TEXT:\n", "\n\nReturn ONLY")
+
+	case strings.Contains(prompt, "Return ONLY the JSON"):
+		return "{}"
+	}
+	return "This is a mock response from the local development model."
+}
+
+// mockScore scores the posting in prompt against the profile in the scoring instruction with the heuristic scorer
+func mockScore(systemInstruction, prompt string) string {
+	var profile models.UserProfile
+	_ = json.Unmarshal([]byte(section(systemInstruction, "CANDIDATE PROFILE:\n", "\n\nReturn a JSON object")), &profile)
+	var job models.JobPosting
+	_ = json.Unmarshal([]byte(section(prompt, "JOB POSTING:\n", "\n\nReturn ONLY")), &job)
+
+	result := matching.Score(&profile, &job)
+	breakdown := result.Breakdown
+	breakdown.Domain, breakdown.Title = breakdown.Title, 0
+	return mockJSON(models.ScoreJobResponse{MatchScore: result.Score, MatchReason: result.Reason, Breakdown: &breakdown})
+}
+
+// mockJobs returns one posting for the page, titled after the page's <title> when it has one
+func mockJobs(pageURL, html string) string {
+	title := "Software Engineer"
+	if m := htmlTitlePattern.FindStringSubmatch(html); m != nil {
+		if t := strings.Join(strings.Fields(m[1]), " "); t != "" {
+			title = truncateMock(t, 100)
+		}
+	}
+	return mockJSON(map[string][]models.JobPosting{"jobs": {{
+		Title:           title,
+		Company:         mockCompany(pageURL),
+		Description:     "Mock posting extracted from " + pageURL,
+		Location:        "Jakarta",
+		WorkType:        "full_time",
+		SiteSetting:     "Hybrid",
+		ExperienceLevel: models.ExperienceLevelMid,
+		Tags:            []string{"Go", "PostgreSQL", "Docker"},
+		Language:        "en",
+	}}})
+}
+
+// mockCompany names a posting's company after its page's host
+func mockCompany(pageURL string) string {
+	u, err := url.Parse(pageURL)
+	if err != nil || u.Hostname() == "" {
+		return "Example Company"
+	}
+	return strings.TrimPrefix(u.Hostname(), "www.")
+}
+
+// section returns the text between the first start marker and the following end marker
+func section(text, start, end string) string {
+	i := strings.Index(text, start)
+	if i < 0 {
+		return ""
+	}
+	text = text[i+len(start):]
+	if j := strings.Index(text, end); j >= 0 {
+		text = text[:j]
+	}
+	return strings.TrimSpace(text)
+}
+
+// promptField returns the rest of the line starting with prefix
+func promptField(prompt, prefix string) string {
+	for _, line := range strings.Split(prompt, "\n") {
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, prefix))
+		}
+	}
+	return ""
+}
+
+// lastLine returns the last non-empty line of text
+func lastLine(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// truncateMock shortens text to n bytes, marking the cut
+func truncateMock(text string, n int) string {
+	if len(text) > n {
+		return text[:n] + "..."
+	}
+	return text
+}
+
+// mockJSON encodes a canned response
+func mockJSON(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
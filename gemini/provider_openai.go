@@ -0,0 +1,369 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/vertexai/genai"
+
+	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// maxProviderErrorBody bounds how much of an error response is kept in the error message
+const maxProviderErrorBody = 500
+
+// openAIProvider sends calls to an OpenAI-compatible chat completions API (Ollama, vLLM, LM Studio, ...).
+// Every call uses the configured model, whichever Gemini model the request selected.
+type openAIProvider struct {
+	baseURL        string
+	apiKey         string
+	model          string
+	embeddingModel string
+	httpClient     *http.Client
+}
+
+func newOpenAIProvider(cfg *config.Config) *openAIProvider {
+	return &openAIProvider{
+		baseURL:        strings.TrimSuffix(cfg.LocalLLMBaseURL, "/"),
+		apiKey:         cfg.LocalLLMAPIKey,
+		model:          cfg.LocalLLMModel,
+		embeddingModel: cfg.LocalLLMEmbeddingModel,
+		httpClient:     &http.Client{Timeout: time.Duration(cfg.LocalLLMTimeoutSeconds) * time.Second},
+	}
+}
+
+// ProviderStatusError is returned when a local LLM server answers with an error status
+type ProviderStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *ProviderStatusError) Error() string {
+	return fmt.Sprintf("LLM provider returned status %d: %s", e.StatusCode, e.Body)
+}
+
+type openAIMessage struct {
+	Role       string           `json:"role"`
+	Content    interface{}      `json:"content,omitempty"` // A string, or a list of content parts for images
+	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+	Name       string           `json:"name,omitempty"`
+}
+
+type openAIToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type openAIChatRequest struct {
+	Model       string                   `json:"model"`
+	Messages    []openAIMessage          `json:"messages"`
+	Tools       []map[string]interface{} `json:"tools,omitempty"`
+	Temperature *float32                 `json:"temperature,omitempty"`
+	TopP        *float32                 `json:"top_p,omitempty"`
+	MaxTokens   *int32                   `json:"max_tokens,omitempty"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content   string           `json:"content"`
+			ToolCalls []openAIToolCall `json:"tool_calls"`
+		} `json:"message"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int32 `json:"prompt_tokens"`
+		CompletionTokens int32 `json:"completion_tokens"`
+		TotalTokens      int32 `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+func (p *openAIProvider) generateContent(ctx context.Context, model *genai.GenerativeModel, history []*genai.Content, parts []genai.Part) (*genai.GenerateContentResponse, error) {
+	messages, err := openAIMessages(model, history, parts)
+	if err != nil {
+		return nil, err
+	}
+	req := openAIChatRequest{
+		Model:       p.model,
+		Messages:    messages,
+		Tools:       openAITools(model.Tools),
+		Temperature: model.Temperature,
+		TopP:        model.TopP,
+		MaxTokens:   model.MaxOutputTokens,
+	}
+
+	var resp openAIChatResponse
+	if err := p.post(ctx, "/chat/completions", req, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Choices) == 0 {
+		return &genai.GenerateContentResponse{}, nil
+	}
+
+	choice := resp.Choices[0]
+	content := &genai.Content{Role: "model"}
+	if choice.Message.Content != "" {
+		content.Parts = append(content.Parts, genai.Text(choice.Message.Content))
+	}
+	for _, call := range choice.Message.ToolCalls {
+		var args map[string]interface{}
+		if err := json.Unmarshal([]byte(call.Function.Arguments), &args); err != nil {
+			return nil, fmt.Errorf("invalid arguments for %s: %w", call.Function.Name, err)
+		}
+		content.Parts = append(content.Parts, genai.FunctionCall{Name: call.Function.Name, Args: args})
+	}
+
+	return &genai.GenerateContentResponse{
+		Candidates: []*genai.Candidate{{Content: content, FinishReason: openAIFinishReason(choice.FinishReason)}},
+		UsageMetadata: &genai.UsageMetadata{
+			PromptTokenCount:     resp.Usage.PromptTokens,
+			CandidatesTokenCount: resp.Usage.CompletionTokens,
+			TotalTokenCount:      resp.Usage.TotalTokens,
+		},
+	}, nil
+}
+
+func (p *openAIProvider) generateContentStream(ctx context.Context, model *genai.GenerativeModel, parts []genai.Part, onChunk func(*genai.GenerateContentResponse) error) error {
+	return streamWhole(ctx, p, model, parts, onChunk)
+}
+
+func (p *openAIProvider) embed(ctx context.Context, texts []string, taskType string) ([][]float32, error) {
+	req := map[string]interface{}{"model": p.embeddingModel, "input": texts}
+
+	var resp struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := p.post(ctx, "/embeddings", req, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Data) != len(texts) {
+		return nil, fmt.Errorf("embedding model returned %d embeddings for %d texts", len(resp.Data), len(texts))
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for _, d := range resp.Data {
+		if d.Index < 0 || d.Index >= len(texts) || len(d.Embedding) == 0 {
+			return nil, fmt.Errorf("embedding model returned an invalid embedding at index %d", d.Index)
+		}
+		embeddings[d.Index] = d.Embedding
+	}
+	return embeddings, nil
+}
+
+// ping lists the server's models, which OpenAI-compatible servers answer without loading one
+func (p *openAIProvider) ping(ctx context.Context, model *genai.GenerativeModel) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/models", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxProviderErrorBody))
+		return &ProviderStatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+	return nil
+}
+
+func (p *openAIProvider) close() {}
+
+// post sends a JSON request to the server and decodes its JSON response into out
+func (p *openAIProvider) post(ctx context.Context, path string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxProviderErrorBody))
+		return &ProviderStatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// openAIMessages converts the system instruction, history and new user parts to chat messages.
+// Function calls get IDs from their position so the function responses that follow can refer to them.
+func openAIMessages(model *genai.GenerativeModel, history []*genai.Content, parts []genai.Part) ([]openAIMessage, error) {
+	var messages []openAIMessage
+	if model.SystemInstruction != nil {
+		messages = append(messages, openAIMessage{Role: "system", Content: partsText(model.SystemInstruction.Parts)})
+	}
+
+	contents := append(append([]*genai.Content{}, history...), genai.NewUserContent(parts...))
+	var pendingCalls map[string][]string // Function name -> IDs of the calls awaiting a response
+	for turn, content := range contents {
+		if content.Role == "model" {
+			message := openAIMessage{Role: "assistant"}
+			var texts []string
+			pendingCalls = make(map[string][]string)
+			for i, part := range content.Parts {
+				switch p := part.(type) {
+				case genai.Text:
+					texts = append(texts, string(p))
+				case genai.FunctionCall:
+					args, err := json.Marshal(p.Args)
+					if err != nil {
+						return nil, fmt.Errorf("failed to encode %s arguments: %w", p.Name, err)
+					}
+					call := openAIToolCall{ID: fmt.Sprintf("call_%d_%d", turn, i), Type: "function"}
+					call.Function.Name = p.Name
+					call.Function.Arguments = string(args)
+					message.ToolCalls = append(message.ToolCalls, call)
+					pendingCalls[p.Name] = append(pendingCalls[p.Name], call.ID)
+				}
+			}
+			message.Content = strings.Join(texts, "\n")
+			messages = append(messages, message)
+			continue
+		}
+
+		var texts []string
+		var images []map[string]interface{}
+		for _, part := range content.Parts {
+			switch p := part.(type) {
+			case genai.Text:
+				texts = append(texts, string(p))
+			case genai.FunctionResponse:
+				response, err := json.Marshal(p.Response)
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode %s response: %w", p.Name, err)
+				}
+				message := openAIMessage{Role: "tool", Content: string(response), Name: p.Name}
+				if ids := pendingCalls[p.Name]; len(ids) > 0 {
+					message.ToolCallID, pendingCalls[p.Name] = ids[0], ids[1:]
+				}
+				messages = append(messages, message)
+			case genai.Blob:
+				if !strings.HasPrefix(p.MIMEType, "image/") {
+					return nil, fmt.Errorf("%w: the local LLM provider can't read %s input; upload the CV as text or DOCX", utils.ErrUnsupportedFileType, p.MIMEType)
+				}
+				images = append(images, map[string]interface{}{
+					"type":      "image_url",
+					"image_url": map[string]string{"url": "data:" + p.MIMEType + ";base64," + base64.StdEncoding.EncodeToString(p.Data)},
+				})
+			}
+		}
+		if len(texts) == 0 && len(images) == 0 {
+			continue
+		}
+		text := strings.Join(texts, "\n\n")
+		if len(images) == 0 {
+			messages = append(messages, openAIMessage{Role: "user", Content: text})
+			continue
+		}
+		contentParts := append([]map[string]interface{}{{"type": "text", "text": text}}, images...)
+		messages = append(messages, openAIMessage{Role: "user", Content: contentParts})
+	}
+	return messages, nil
+}
+
+// openAITools converts Gemini function declarations to chat completion tools
+func openAITools(tools []*genai.Tool) []map[string]interface{} {
+	var converted []map[string]interface{}
+	for _, tool := range tools {
+		for _, fn := range tool.FunctionDeclarations {
+			function := map[string]interface{}{"name": fn.Name, "description": fn.Description}
+			if fn.Parameters != nil {
+				function["parameters"] = jsonSchema(fn.Parameters)
+			} else {
+				function["parameters"] = map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+			}
+			converted = append(converted, map[string]interface{}{"type": "function", "function": function})
+		}
+	}
+	return converted
+}
+
+// jsonSchema converts a Gemini schema back to a JSON schema object
+func jsonSchema(s *genai.Schema) map[string]interface{} {
+	schema := make(map[string]interface{})
+	switch s.Type {
+	case genai.TypeString:
+		schema["type"] = "string"
+	case genai.TypeNumber:
+		schema["type"] = "number"
+	case genai.TypeInteger:
+		schema["type"] = "integer"
+	case genai.TypeBoolean:
+		schema["type"] = "boolean"
+	case genai.TypeArray:
+		schema["type"] = "array"
+		if s.Items != nil {
+			schema["items"] = jsonSchema(s.Items)
+		}
+	default:
+		schema["type"] = "object"
+	}
+	if s.Description != "" {
+		schema["description"] = s.Description
+	}
+	if len(s.Enum) > 0 {
+		schema["enum"] = s.Enum
+	}
+	if len(s.Properties) > 0 {
+		properties := make(map[string]interface{}, len(s.Properties))
+		for name, prop := range s.Properties {
+			properties[name] = jsonSchema(prop)
+		}
+		schema["properties"] = properties
+	}
+	if len(s.Required) > 0 {
+		schema["required"] = s.Required
+	}
+	return schema
+}
+
+// openAIFinishReason maps a chat completion finish reason to Gemini's
+func openAIFinishReason(reason string) genai.FinishReason {
+	switch reason {
+	case "stop", "tool_calls", "function_call":
+		return genai.FinishReasonStop
+	case "length":
+		return genai.FinishReasonMaxTokens
+	case "content_filter":
+		return genai.FinishReasonSafety
+	}
+	return genai.FinishReasonOther
+}
@@ -0,0 +1,125 @@
+package gemini
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCapacityExceeded matches (with errors.Is) every error returned when the client-side rate limit or
+// call quota doesn't allow another Gemini call; callers should retry later
+var ErrCapacityExceeded = errors.New("Gemini capacity exceeded, retry later")
+
+// CapacityError reports which limit refused a Gemini call and when a retry can succeed
+type CapacityError struct {
+	Limit      string        // "rate limit", "calls per minute" or "calls per day"
+	RetryAfter time.Duration // Time until the limit allows another call
+}
+
+func (e *CapacityError) Error() string {
+	return fmt.Sprintf("%v (%s, retry after %s)", ErrCapacityExceeded, e.Limit, e.RetryAfter.Round(time.Second))
+}
+
+// Is makes errors.Is(err, ErrCapacityExceeded) match any CapacityError
+func (e *CapacityError) Is(target error) bool {
+	return target == ErrCapacityExceeded
+}
+
+// RateLimiter keeps the process's Gemini calls within the Vertex quota: a token bucket smooths bursts
+// (callers wait up to maxWait for a token), and fixed per-minute and per-day windows cap the call count.
+// A nil RateLimiter allows every call. It is safe for concurrent use.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	rate    float64 // Tokens added per second (0 = no token bucket)
+	burst   float64
+	tokens  float64
+	last    time.Time
+	maxWait time.Duration
+
+	perMinute, perDay     int // Call caps (0 = no cap)
+	minuteStart, dayStart time.Time
+	minuteCalls, dayCalls int
+}
+
+// NewRateLimiter creates a limiter allowing ratePerSecond calls on average with bursts of up to burst calls,
+// and at most perMinute and perDay calls in each clock minute and UTC day. Zero values disable a limit.
+func NewRateLimiter(ratePerSecond float64, burst int, maxWait time.Duration, perMinute, perDay int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:      ratePerSecond,
+		burst:     float64(burst),
+		tokens:    float64(burst),
+		last:      time.Now(),
+		maxWait:   maxWait,
+		perMinute: perMinute,
+		perDay:    perDay,
+	}
+}
+
+// Acquire claims one call, waiting for a token if the bucket is briefly empty.
+// It returns a CapacityError without waiting if a quota is used up or the wait would exceed maxWait.
+func (l *RateLimiter) Acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if err := l.checkQuotas(now); err != nil {
+		l.mu.Unlock()
+		return err
+	}
+
+	var wait time.Duration
+	if l.rate > 0 {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+		if l.tokens < 1 {
+			wait = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+			if wait > l.maxWait {
+				l.mu.Unlock()
+				return &CapacityError{Limit: "rate limit", RetryAfter: wait}
+			}
+		}
+		// The token is taken now; a negative balance reserves it for the caller waiting below
+		l.tokens--
+	}
+	l.minuteCalls++
+	l.dayCalls++
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// checkQuotas starts new minute/day windows as needed and fails if either cap is reached. l.mu must be held.
+func (l *RateLimiter) checkQuotas(now time.Time) error {
+	if minute := now.Truncate(time.Minute); !minute.Equal(l.minuteStart) {
+		l.minuteStart, l.minuteCalls = minute, 0
+	}
+	if day := now.UTC().Truncate(24 * time.Hour); !day.Equal(l.dayStart) {
+		l.dayStart, l.dayCalls = day, 0
+	}
+
+	if l.perDay > 0 && l.dayCalls >= l.perDay {
+		return &CapacityError{Limit: "calls per day", RetryAfter: l.dayStart.Add(24 * time.Hour).Sub(now)}
+	}
+	if l.perMinute > 0 && l.minuteCalls >= l.perMinute {
+		return &CapacityError{Limit: "calls per minute", RetryAfter: l.minuteStart.Add(time.Minute).Sub(now)}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package gemini
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"cloud.google.com/go/vertexai/genai"
+)
+
+// ErrContentBlocked matches (with errors.Is) every error caused by Gemini's safety filters blocking a request or response
+var ErrContentBlocked = errors.New("content blocked by Gemini safety filters")
+
+// BlockedError reports a prompt or response blocked by Gemini, with the reason and the harm categories that triggered it
+type BlockedError struct {
+	Reason     string   // Block or finish reason, e.g. "FinishReasonSafety"
+	Categories []string // Harm categories rated as blocked, if reported
+}
+
+func (e *BlockedError) Error() string {
+	if len(e.Categories) == 0 {
+		return fmt.Sprintf("%v (%s)", ErrContentBlocked, e.Reason)
+	}
+	return fmt.Sprintf("%v (%s: %s)", ErrContentBlocked, e.Reason, strings.Join(e.Categories, ", "))
+}
+
+// Is makes errors.Is(err, ErrContentBlocked) match any BlockedError
+func (e *BlockedError) Is(target error) bool {
+	return target == ErrContentBlocked
+}
+
+// blockingFinishReasons are finish reasons meaning the response was withheld rather than completed
+var blockingFinishReasons = map[genai.FinishReason]bool{
+	genai.FinishReasonSafety:            true,
+	genai.FinishReasonRecitation:        true,
+	genai.FinishReasonBlocklist:         true,
+	genai.FinishReasonProhibitedContent: true,
+	genai.FinishReasonSpii:              true,
+}
+
+// harmThresholds maps configured threshold names to Gemini thresholds
+var harmThresholds = map[string]genai.HarmBlockThreshold{
+	"block_none":             genai.HarmBlockNone,
+	"block_only_high":        genai.HarmBlockOnlyHigh,
+	"block_medium_and_above": genai.HarmBlockMediumAndAbove,
+	"block_low_and_above":    genai.HarmBlockLowAndAbove,
+}
+
+// harmCategories maps configured category names to Gemini harm categories
+var harmCategories = map[string]genai.HarmCategory{
+	"harassment":        genai.HarmCategoryHarassment,
+	"hate_speech":       genai.HarmCategoryHateSpeech,
+	"sexually_explicit": genai.HarmCategorySexuallyExplicit,
+	"dangerous_content": genai.HarmCategoryDangerousContent,
+}
+
+// safetySettings builds the model's safety settings from a default threshold and "category=threshold" overrides.
+// An empty or "default" threshold leaves the category at Gemini's default; unknown names are logged and ignored.
+func safetySettings(threshold string, overrides []string) []*genai.SafetySetting {
+	levels := make(map[string]string, len(harmCategories))
+	for category := range harmCategories {
+		levels[category] = threshold
+	}
+	for _, override := range overrides {
+		category, level, ok := strings.Cut(override, "=")
+		category = strings.ToLower(strings.TrimSpace(category))
+		if _, known := harmCategories[category]; !ok || !known {
+			log.Printf("[Gemini] Ignoring invalid safety setting: %q", override)
+			continue
+		}
+		levels[category] = strings.TrimSpace(level)
+	}
+
+	var settings []*genai.SafetySetting
+	for category, level := range levels {
+		level = strings.ToLower(level)
+		if level == "" || level == "default" {
+			continue
+		}
+		value, ok := harmThresholds[level]
+		if !ok {
+			log.Printf("[Gemini] Ignoring unknown safety threshold %q for %s", level, category)
+			continue
+		}
+		settings = append(settings, &genai.SafetySetting{Category: harmCategories[category], Threshold: value})
+	}
+	return settings
+}
+
+// blockedError converts a genai blocked error to a BlockedError; other errors are returned unchanged
+func blockedError(err error) error {
+	var blocked *genai.BlockedError
+	if !errors.As(err, &blocked) {
+		return err
+	}
+
+	if blocked.Candidate != nil {
+		return &BlockedError{
+			Reason:     blocked.Candidate.FinishReason.String(),
+			Categories: blockedCategories(blocked.Candidate.SafetyRatings),
+		}
+	}
+	if blocked.PromptFeedback != nil {
+		return &BlockedError{
+			Reason:     blocked.PromptFeedback.BlockReason.String(),
+			Categories: blockedCategories(blocked.PromptFeedback.SafetyRatings),
+		}
+	}
+	return &BlockedError{Reason: "unknown"}
+}
+
+// emptyResponseError explains a response without content: a BlockedError if the response was withheld,
+// otherwise a generic error with the finish reason
+func emptyResponseError(resp *genai.GenerateContentResponse) error {
+	if len(resp.Candidates) == 0 {
+		return errors.New("no response from Gemini")
+	}
+
+	candidate := resp.Candidates[0]
+	if blockingFinishReasons[candidate.FinishReason] {
+		return &BlockedError{
+			Reason:     candidate.FinishReason.String(),
+			Categories: blockedCategories(candidate.SafetyRatings),
+		}
+	}
+	return fmt.Errorf("no response from Gemini (finish reason %s)", candidate.FinishReason)
+}
+
+// blockedCategories lists the harm categories rated as blocked
+func blockedCategories(ratings []*genai.SafetyRating) []string {
+	var categories []string
+	for _, rating := range ratings {
+		if rating.Blocked {
+			categories = append(categories, rating.Category.String())
+		}
+	}
+	return categories
+}
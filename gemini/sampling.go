@@ -0,0 +1,75 @@
+package gemini
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/vertexai/genai"
+)
+
+// ErrSamplingUnavailable is returned by a Sampler whose client can't take sampling requests
+var ErrSamplingUnavailable = errors.New("sampling unavailable")
+
+// Sampler asks the calling agent's model for a completion (MCP sampling), so a tool's judgment calls
+// are paid for by the agent instead of our Vertex AI quota
+type Sampler interface {
+	Sample(ctx context.Context, systemPrompt, prompt string, maxTokens int) (string, error)
+}
+
+type samplerContextKey struct{}
+
+// WithSampler delegates the text-only Gemini calls made with the context to sampler. Calls the sampler
+// can't answer fall back to the configured provider.
+func WithSampler(ctx context.Context, sampler Sampler) context.Context {
+	return context.WithValue(ctx, samplerContextKey{}, sampler)
+}
+
+// samplerFromContext returns the context's sampler, or nil
+func samplerFromContext(ctx context.Context) Sampler {
+	sampler, _ := ctx.Value(samplerContextKey{}).(Sampler)
+	return sampler
+}
+
+// sample answers a call with the context's sampler. It returns false when there is no sampler, the call
+// can't be expressed as a sampling request (PDF input, function declarations, a context cache), or the
+// sampler failed or answered with nothing; the caller then uses the provider.
+func (c *Client) sample(ctx context.Context, model *genai.GenerativeModel, parts []genai.Part) (*genai.GenerateContentResponse, bool) {
+	sampler := samplerFromContext(ctx)
+	if sampler == nil || len(model.Tools) > 0 || model.CachedContentName != "" {
+		return nil, false
+	}
+	for _, part := range parts {
+		if _, ok := part.(genai.Text); !ok {
+			return nil, false
+		}
+	}
+
+	maxTokens := 0
+	if model.MaxOutputTokens != nil {
+		maxTokens = int(*model.MaxOutputTokens)
+	}
+
+	started := time.Now()
+	text, err := sampler.Sample(ctx, systemInstructionText(model), partsText(parts), maxTokens)
+	if err == nil && strings.TrimSpace(text) == "" {
+		err = errors.New("empty sampling response")
+	}
+	if err != nil {
+		if !errors.Is(err, ErrSamplingUnavailable) && ctx.Err() == nil {
+			log.Printf("[Gemini] Sampling failed, using %s instead: %v", c.modelName, err)
+		}
+		return nil, false
+	}
+
+	resp := &genai.GenerateContentResponse{
+		Candidates: []*genai.Candidate{{
+			Content:      &genai.Content{Role: "model", Parts: []genai.Part{genai.Text(text)}},
+			FinishReason: genai.FinishReasonStop,
+		}},
+	}
+	c.recordCall(ctx, model, parts, text, nil, nil, started)
+	return resp, true
+}
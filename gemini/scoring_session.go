@@ -0,0 +1,160 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"cloud.google.com/go/vertexai/genai"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// minCachedScoringCalls is the fewest scoring calls a session must expect before an explicit
+// context cache is created; creating and storing the cache isn't worth it for a handful of calls
+const minCachedScoringCalls = 5
+
+// ScoringSession scores many postings against one profile. The scoring rules, profile and feedback
+// examples are sent once as the system instruction, so each call only sends the posting. With context
+// caching enabled the instruction is stored in a Vertex context cache and billed at the cached rate.
+type ScoringSession struct {
+	client    *Client
+	model     *genai.GenerativeModel
+	cacheName string // Empty when the session isn't backed by a context cache
+}
+
+// NewScoringSession prepares a session for scoring about expectedCalls postings against profile.
+// If the context cache can't be created (e.g. the profile is below the model's minimum cached token count),
+// the session falls back to a plain shared system instruction. Close must be called when scoring is done.
+func (c *Client) NewScoringSession(ctx context.Context, profile *models.UserProfile, feedback []models.JobFeedback, expectedCalls int) *ScoringSession {
+	instruction := genai.NewUserContent(genai.Text(scoringInstruction(profile, feedback, PromptVariantFromContext(ctx))))
+
+	if c.contextCacheTTL > 0 && expectedCalls >= minCachedScoringCalls {
+		cc, err := c.client.CreateCachedContent(ctx, &genai.CachedContent{
+			Model:             c.modelNameFor(ctx),
+			SystemInstruction: instruction,
+			Expiration:        genai.ExpireTimeOrTTL{TTL: c.contextCacheTTL},
+		})
+		if err == nil {
+			c.trackCache(cc.Name)
+			model := c.client.GenerativeModelFromCachedContent(cc)
+			model.GenerationConfig = c.modelFor(ctx).GenerationConfig
+			return &ScoringSession{client: c, model: model, cacheName: cc.Name}
+		}
+		log.Printf("[Gemini] Context cache unavailable, using a shared system instruction: %v", err)
+	}
+
+	model := *c.modelFor(ctx)
+	model.SystemInstruction = instruction
+	return &ScoringSession{client: c, model: &model}
+}
+
+// Score scores how well a job matches the session's profile
+func (s *ScoringSession) Score(ctx context.Context, job *models.JobPosting) (*models.ScoreJobResponse, error) {
+	jobJSON, _ := json.Marshal(job)
+	prompt := fmt.Sprintf("JOB POSTING:\n%s\n\nReturn ONLY the JSON object.", jobJSON)
+
+	resp, err := s.client.generateWith(ctx, s.model, genai.Text(prompt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate content: %w", err)
+	}
+
+	text := extractText(resp)
+	text = cleanJSON(text)
+
+	var result models.ScoreJobResponse
+	if err := s.client.decodeJSON(ctx, text, &result); err != nil {
+		log.Printf("Failed to parse score response (request %s): %s", utils.RequestIDFromContext(ctx), text)
+		return nil, fmt.Errorf("failed to parse score JSON: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Close deletes the session's context cache, if any. The cache would otherwise be billed for storage until its TTL ends.
+func (s *ScoringSession) Close(ctx context.Context) {
+	if s.cacheName == "" {
+		return
+	}
+	s.client.deleteCache(ctx, s.cacheName)
+	s.cacheName = ""
+}
+
+// trackCache records a context cache created by this client so Close can delete it if a session leaks
+func (c *Client) trackCache(name string) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	c.caches[name] = struct{}{}
+}
+
+// deleteCache deletes a context cache; failures are logged since the cache still expires with its TTL
+func (c *Client) deleteCache(ctx context.Context, name string) {
+	c.cacheMu.Lock()
+	delete(c.caches, name)
+	c.cacheMu.Unlock()
+
+	if err := c.client.DeleteCachedContent(ctx, name); err != nil {
+		log.Printf("[Gemini] Failed to delete context cache %s: %v", name, err)
+	}
+}
+
+// deleteAllCaches deletes every context cache still held by the client
+func (c *Client) deleteAllCaches() {
+	c.cacheMu.Lock()
+	names := make([]string, 0, len(c.caches))
+	for name := range c.caches {
+		names = append(names, name)
+	}
+	c.cacheMu.Unlock()
+
+	if len(names) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, name := range names {
+		c.deleteCache(ctx, name)
+	}
+}
+
+// scoringInstruction builds the system instruction for scoring postings against a profile, using the variant's criteria
+func scoringInstruction(profile *models.UserProfile, feedback []models.JobFeedback, variant string) string {
+	profileJSON, _ := json.Marshal(profile)
+
+	// Explain matches in the CV's language unless the CV was translated to English
+	reasonLanguage := "English"
+	if profile != nil && profile.Language == "id" && !profile.Translated {
+		reasonLanguage = "Bahasa Indonesia"
+	}
+
+	return fmt.Sprintf(`You analyze how well job postings match a candidate's profile and return a match score.
+Each message contains one job posting to score against this candidate.
+
+CANDIDATE PROFILE:
+%s
+
+Return a JSON object with:
+{
+  "match_score": 0-100,
+  "match_reason": "1-2 sentences in %s explaining the match or mismatch",
+  "breakdown": {
+    "skills": 0-100,
+    "experience": 0-100,
+    "location": 0-100,
+    "work_type": 0-100,
+    "domain": 0-100
+  }
+}
+
+%s
+
+The profile and posting may be in different languages (English or Bahasa Indonesia). Compare them by
+meaning, not wording: e.g. "Pengembang Backend" is a "Backend Developer", "Magang" is an internship
+and "Kerja dari Rumah" is remote work. Do not lower the score because of a language difference alone.
+%s
+Return ONLY the JSON object.`, profileJSON, reasonLanguage, scoringCriteria(variant), formatFeedbackExamples(feedback))
+}
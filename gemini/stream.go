@@ -0,0 +1,86 @@
+package gemini
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/vertexai/genai"
+)
+
+// StreamHandler receives generated text as it arrives; returning an error stops the generation
+type StreamHandler func(text string) error
+
+// GenerateTextStream generates free-form text (cover letters, career advice) and passes each chunk
+// to onText as the model produces it, so handlers can forward it to the client over SSE.
+// systemInstruction may be empty. It returns the full generated text.
+func (c *Client) GenerateTextStream(ctx context.Context, systemInstruction, prompt string, onText StreamHandler) (string, error) {
+	model := c.modelFor(ctx)
+	if systemInstruction != "" {
+		m := *model
+		m.SystemInstruction = genai.NewUserContent(genai.Text(systemInstruction))
+		model = &m
+	}
+
+	text, err := c.generateStream(ctx, model, onText, genai.Text(prompt))
+	if err != nil {
+		return text, fmt.Errorf("failed to generate content: %w", err)
+	}
+	return text, nil
+}
+
+// generateStream streams a response from the model, enforcing and recording the request budget attached to ctx (if any).
+// Usage is recorded from the last chunk, which carries the totals for the whole response.
+func (c *Client) generateStream(ctx context.Context, model *genai.GenerativeModel, onText StreamHandler, parts ...genai.Part) (string, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return "", err
+	}
+	budget := BudgetFromContext(ctx)
+	if budget != nil {
+		if err := budget.reserve(); err != nil {
+			c.breaker.Release()
+			return "", err
+		}
+	}
+	if err := c.limiter.Acquire(ctx); err != nil {
+		c.breaker.Release()
+		return "", err
+	}
+
+	var sb strings.Builder
+	var usage *genai.UsageMetadata
+	var handlerErr error
+	started := time.Now()
+	err := c.provider.generateContentStream(ctx, model, parts, func(resp *genai.GenerateContentResponse) error {
+		if resp.UsageMetadata != nil {
+			usage = resp.UsageMetadata
+		}
+
+		chunk := extractText(resp)
+		if chunk == "" {
+			return nil
+		}
+		sb.WriteString(chunk)
+		handlerErr = onText(chunk)
+		return handlerErr
+	})
+	if handlerErr != nil {
+		c.breaker.Record(nil)
+		c.recordCall(ctx, model, parts, sb.String(), usage, handlerErr, started)
+		c.recordUsage(budget, usage)
+		return sb.String(), handlerErr
+	}
+	if err != nil {
+		err = blockedError(err)
+		c.breaker.Record(err)
+		c.recordCall(ctx, model, parts, sb.String(), usage, err, started)
+		c.recordUsage(budget, usage)
+		return sb.String(), err
+	}
+
+	c.breaker.Record(nil)
+	c.recordCall(ctx, model, parts, sb.String(), usage, nil, started)
+	c.recordUsage(budget, usage)
+	return sb.String(), nil
+}
@@ -0,0 +1,240 @@
+package grpcapi
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/myjobmatch/backend/models"
+	pb "github.com/myjobmatch/backend/proto/myjobmatch/v1"
+)
+
+func toFilters(f *pb.JobSearchFilter) models.JobSearchFilter {
+	if f == nil {
+		return models.JobSearchFilter{}
+	}
+	filters := models.JobSearchFilter{
+		Locations:        f.GetLocations(),
+		RemoteModes:      f.GetRemoteModes(),
+		JobTypes:         f.GetJobTypes(),
+		MinSalary:        int(f.GetMinSalary()),
+		MaxSalary:        int(f.GetMaxSalary()),
+		Currency:         f.GetCurrency(),
+		DatePosted:       f.GetDatePosted(),
+		ExcludeCompanies: f.GetExcludeCompanies(),
+		ExcludeKeywords:  f.GetExcludeKeywords(),
+	}
+	if f.MinScore != nil {
+		minScore := int(f.GetMinScore())
+		filters.MinScore = &minScore
+	}
+	return filters
+}
+
+func toBudget(b *pb.LLMBudget) *models.LLMBudget {
+	if b == nil {
+		return nil
+	}
+	return &models.LLMBudget{
+		MaxCalls:   int(b.GetMaxCalls()),
+		MaxTokens:  int(b.GetMaxTokens()),
+		MaxCostUSD: b.GetMaxCostUsd(),
+	}
+}
+
+// toProfileUpdate builds the partial update of the fields named in an update mask, the fields of
+// models.UpdateStructuredProfileRequest. List fields are replaced, and cleared when left empty.
+func toProfileUpdate(p *pb.UserProfile, paths []string) (*models.UpdateStructuredProfileRequest, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("update_mask must name the fields to update")
+	}
+
+	update := &models.UpdateStructuredProfileRequest{}
+	for _, path := range paths {
+		switch path {
+		case "title":
+			update.Title = ptr(p.GetTitle())
+		case "summary":
+			update.Summary = ptr(p.GetSummary())
+		case "experience_years":
+			update.Experience = ptr(p.GetExperienceYears())
+		case "skills":
+			update.Skills = ptr(p.GetSkills())
+		case "technical_stack":
+			update.TechnicalStack = ptr(p.GetTechnicalStack())
+		case "work_history":
+			update.WorkHistory = ptr(toWorkHistory(p.GetWorkHistory()))
+		case "preferred_roles":
+			update.PreferredRoles = ptr(p.GetPreferredRoles())
+		case "preferred_locations":
+			update.PreferredLocations = ptr(p.GetPreferredLocations())
+		case "preferred_remote_modes":
+			update.PreferredRemoteModes = ptr(p.GetPreferredRemoteModes())
+		case "preferred_job_types":
+			update.PreferredJobTypes = ptr(p.GetPreferredJobTypes())
+		case "min_salary":
+			update.MinSalary = ptr(int(p.GetMinSalary()))
+		case "max_salary":
+			update.MaxSalary = ptr(int(p.GetMaxSalary()))
+		case "currency":
+			update.Currency = ptr(p.GetCurrency())
+		default:
+			return nil, fmt.Errorf("update_mask: %q can't be updated", path)
+		}
+	}
+	return update, nil
+}
+
+func toWorkHistory(history []*pb.WorkExperience) []models.WorkExperience {
+	result := make([]models.WorkExperience, len(history))
+	for i, w := range history {
+		result[i] = models.WorkExperience{
+			Title:       w.GetTitle(),
+			Company:     w.GetCompany(),
+			Location:    w.GetLocation(),
+			StartDate:   w.GetStartDate(),
+			EndDate:     w.GetEndDate(),
+			Description: w.GetDescription(),
+			Skills:      w.GetSkills(),
+		}
+	}
+	return result
+}
+
+func fromSearchResponse(r *models.SearchJobsResponse) *pb.SearchJobsResponse {
+	response := &pb.SearchJobsResponse{
+		Results:       make([]*pb.RankedJob, len(r.Results)),
+		Profile:       fromProfile(r.Profile),
+		TotalResults:  int32(r.TotalResults),
+		Message:       r.Message,
+		CvSaved:       r.CVSaved,
+		PromptVariant: r.PromptVariant,
+		SessionId:     r.SessionID,
+	}
+	for i := range r.Results {
+		response.Results[i] = fromRankedJob(&r.Results[i])
+	}
+	if r.LLMUsage != nil {
+		response.LlmUsage = &pb.LLMUsage{
+			Calls:            int32(r.LLMUsage.Calls),
+			PromptTokens:     int64(r.LLMUsage.PromptTokens),
+			OutputTokens:     int64(r.LLMUsage.OutputTokens),
+			EstimatedCostUsd: r.LLMUsage.EstimatedCostUSD,
+			BudgetExhausted:  r.LLMUsage.BudgetExhausted,
+			FallbackScored:   int32(r.LLMUsage.FallbackScored),
+		}
+	}
+	return response
+}
+
+func fromRankedJob(j *models.RankedJob) *pb.RankedJob {
+	job := &pb.RankedJob{
+		Job: &pb.JobPosting{
+			Id:              j.ID,
+			Title:           j.Title,
+			Company:         j.Company,
+			Description:     j.Description,
+			Location:        j.Location,
+			WorkType:        j.WorkType,
+			SiteSetting:     j.SiteSetting,
+			Url:             j.URL,
+			Source:          j.Source,
+			Tags:            j.Tags,
+			Salary:          j.Salary,
+			SalaryMin:       int64(j.SalaryMin),
+			SalaryMax:       int64(j.SalaryMax),
+			SalaryCurrency:  j.SalaryCurrency,
+			SalaryPeriod:    j.SalaryPeriod,
+			DatePosted:      j.DatePosted,
+			ApplicationUrl:  j.ApplicationURL,
+			Requirements:    j.Requirements,
+			Benefits:        j.Benefits,
+			ExperienceLevel: j.ExperienceLevel,
+			Language:        j.Language,
+			ValidThrough:    j.ValidThrough,
+			Expired:         j.Expired,
+		},
+		MatchScore:     int32(j.MatchScore),
+		MatchReason:    j.MatchReason,
+		ScoreMethod:    j.ScoreMethod,
+		AiScore:        int32(j.AIScore),
+		HeuristicScore: int32(j.HeuristicScore),
+		SkillsMatch:    int32(j.SkillsMatch),
+	}
+	if b := j.ScoreBreakdown; b != nil {
+		job.ScoreBreakdown = &pb.ScoreBreakdown{
+			Skills:     int32(b.Skills),
+			Experience: int32(b.Experience),
+			Location:   int32(b.Location),
+			WorkType:   int32(b.WorkType),
+			Domain:     int32(b.Domain),
+			Title:      int32(b.Title),
+		}
+	}
+	return job
+}
+
+func fromStructuredProfile(p *models.StructuredProfile) *pb.StructuredProfile {
+	profile := &pb.StructuredProfile{
+		Profile: fromProfile(&p.Profile),
+		Edited:  p.Edited,
+	}
+	if !p.UpdatedAt.IsZero() {
+		profile.UpdatedAt = timestamppb.New(p.UpdatedAt)
+	}
+	return profile
+}
+
+func fromProfile(p *models.UserProfile) *pb.UserProfile {
+	if p == nil {
+		return nil
+	}
+	profile := &pb.UserProfile{
+		Name:                 p.Name,
+		Email:                p.Email,
+		Phone:                p.Phone,
+		Summary:              p.Summary,
+		Title:                p.Title,
+		ExperienceYears:      p.Experience,
+		Skills:               p.Skills,
+		TechnicalStack:       p.TechnicalStack,
+		Languages:            p.Languages,
+		PreferredRoles:       p.PreferredRoles,
+		PreferredLocations:   p.PreferredLocations,
+		PreferredRemoteModes: p.PreferredRemoteModes,
+		PreferredJobTypes:    p.PreferredJobTypes,
+		MinSalary:            int64(p.MinSalary),
+		MaxSalary:            int64(p.MaxSalary),
+		Currency:             p.Currency,
+		Education:            make([]*pb.Education, len(p.Education)),
+		WorkHistory:          make([]*pb.WorkExperience, len(p.WorkHistory)),
+		Certifications:       p.Certifications,
+		Achievements:         p.Achievements,
+		Language:             p.Language,
+		Translated:           p.Translated,
+	}
+	for i, e := range p.Education {
+		profile.Education[i] = &pb.Education{
+			Degree:      e.Degree,
+			Field:       e.Field,
+			Institution: e.Institution,
+			Year:        int32(e.Year),
+		}
+	}
+	for i, w := range p.WorkHistory {
+		profile.WorkHistory[i] = &pb.WorkExperience{
+			Title:       w.Title,
+			Company:     w.Company,
+			Location:    w.Location,
+			StartDate:   w.StartDate,
+			EndDate:     w.EndDate,
+			Description: w.Description,
+			Skills:      w.Skills,
+		}
+	}
+	return profile
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,78 @@
+package grpcapi
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/utils"
+	"github.com/myjobmatch/backend/validation"
+)
+
+// defaultRetryAfter is the retry delay suggested when a capacity or circuit breaker error doesn't carry one
+const defaultRetryAfter = 30 * time.Second
+
+// errShuttingDown tells the caller to retry a search on another instance
+var errShuttingDown = retryable(codes.Unavailable, "The server is restarting, please retry", time.Second)
+
+// aiError converts the error of a failed call that depends on Gemini to a status, like the REST API's
+// responses: ResourceExhausted when the Gemini rate limit or quota is reached and Unavailable while a
+// dependency's circuit breaker is open, both with RetryInfo; InvalidArgument when the CV file has no
+// readable text or the safety filters blocked the content; and Internal with message otherwise.
+func aiError(err error, message string) error {
+	if errors.Is(err, gemini.ErrCapacityExceeded) {
+		retryAfter := defaultRetryAfter
+		var capacity *gemini.CapacityError
+		if errors.As(err, &capacity) && capacity.RetryAfter > 0 {
+			retryAfter = capacity.RetryAfter
+		}
+		return retryable(codes.ResourceExhausted, "The AI service is at capacity, please retry later: "+err.Error(), retryAfter)
+	}
+
+	if errors.Is(err, utils.ErrCircuitOpen) {
+		retryAfter := defaultRetryAfter
+		var open *utils.CircuitOpenError
+		if errors.As(err, &open) && open.RetryAfter > 0 {
+			retryAfter = open.RetryAfter
+		}
+		return retryable(codes.Unavailable, "The AI service is temporarily unavailable, please retry later: "+err.Error(), retryAfter)
+	}
+
+	if errors.Is(err, utils.ErrNoDocumentText) || errors.Is(err, utils.ErrUnsupportedFileType) {
+		return status.Error(codes.InvalidArgument, "The CV file could not be read: "+err.Error())
+	}
+	if errors.Is(err, gemini.ErrContentBlocked) {
+		return status.Error(codes.InvalidArgument, "The content was blocked by the AI safety filters: "+err.Error())
+	}
+
+	return status.Error(codes.Internal, message+": "+err.Error())
+}
+
+// retryable returns a status telling the caller when to retry, the gRPC counterpart of Retry-After
+func retryable(code codes.Code, message string, retryAfter time.Duration) error {
+	st := status.New(code, message)
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// invalidFields returns an InvalidArgument status listing the fields that failed validation as
+// BadRequest field violations
+func invalidFields(errs validation.Errors) error {
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(errs))
+	for i, fieldErr := range errs {
+		violations[i] = &errdetails.BadRequest_FieldViolation{Field: fieldErr.Field, Description: fieldErr.Message}
+	}
+
+	st := status.New(codes.InvalidArgument, "Invalid request: "+errs.Error())
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
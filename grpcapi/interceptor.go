@@ -0,0 +1,159 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/auth"
+	pb "github.com/myjobmatch/backend/proto/myjobmatch/v1"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// Request metadata, named like the REST API's headers
+const (
+	authorizationKey = "authorization"
+	requestIDKey     = "x-request-id"
+	tenantKey        = "x-tenant-id"
+)
+
+// validRequestID limits caller-supplied IDs to a safe length and character set, as for X-Request-ID
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._\-]{1,64}$`)
+
+// methodAuth is how a method authenticates: the personal access token scope it accepts ("" for JWTs
+// only), and whether callers without a token are served, with anonymous limits
+type methodAuth struct {
+	scope     string
+	anonymous bool
+}
+
+// methodAuths mirrors the auth middleware of the matching REST routes
+var methodAuths = map[string]methodAuth{
+	pb.JobMatch_SearchJobs_FullMethodName:    {scope: auth.ScopeSearch, anonymous: true},
+	pb.JobMatch_ParseCV_FullMethodName:       {scope: auth.ScopeSearch, anonymous: true},
+	pb.JobMatch_GetProfile_FullMethodName:    {scope: auth.ScopeReadProfile},
+	pb.JobMatch_UpdateProfile_FullMethodName: {},
+}
+
+type claimsContextKey struct{}
+
+// claimsFromContext returns the authenticated caller, or nil for anonymous requests
+func claimsFromContext(ctx context.Context) *auth.Claims {
+	claims, _ := ctx.Value(claimsContextKey{}).(*auth.Claims)
+	return claims
+}
+
+// intercept does for every call what the REST API's middleware does for every request: it assigns a
+// request ID, attaches the tenant, authenticates the caller and writes one structured log record. Unlike
+// the REST API's optional auth, an invalid token is refused rather than ignored on methods that serve
+// anonymous callers, so services don't silently fall back to anonymous limits.
+func (s *Server) intercept(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	start := time.Now()
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	id := firstValue(md, requestIDKey)
+	if !validRequestID.MatchString(id) {
+		id = utils.NewRequestID()
+	}
+	ctx = utils.WithRequestID(ctx, id)
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+
+	clientIP := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		clientIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(clientIP); err == nil {
+			clientIP = host
+		}
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			grpcLog.ErrorContext(ctx, "Panic serving request", "panic", r, "stack", string(debug.Stack()))
+			resp, err = nil, status.Error(codes.Internal, "Internal server error")
+		}
+
+		code := status.Code(err)
+		level := slog.LevelInfo
+		switch code {
+		case codes.OK:
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented:
+			level = slog.LevelError
+		default:
+			level = slog.LevelWarn
+		}
+		grpcLog.Log(ctx, level, "Request completed",
+			"method", info.FullMethod,
+			"code", code.String(),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"client_ip", clientIP,
+		)
+	}()
+
+	if tenantID := firstValue(md, tenantKey); tenantID != "" {
+		if !s.tenants[tenantID] {
+			return nil, status.Error(codes.InvalidArgument, "Unknown tenant")
+		}
+		ctx = utils.WithTenant(ctx, tenantID)
+	}
+
+	ctx, err = s.authenticate(ctx, md, info.FullMethod, clientIP)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authenticate checks the caller's bearer token against the method's auth rules and attaches the caller
+// to the context
+func (s *Server) authenticate(ctx context.Context, md metadata.MD, method, clientIP string) (context.Context, error) {
+	rules, ok := methodAuths[method]
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "Unknown method")
+	}
+
+	header := firstValue(md, authorizationKey)
+	if header == "" {
+		if rules.anonymous {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "Authorization metadata required")
+	}
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return nil, status.Error(codes.Unauthenticated, "Invalid authorization metadata format")
+	}
+
+	claims, token, code, message := auth.Authenticate(ctx, s.jwtService, s.store, parts[1], rules.scope)
+	if claims == nil {
+		if code == http.StatusForbidden {
+			return nil, status.Error(codes.PermissionDenied, message)
+		}
+		return nil, status.Error(codes.Unauthenticated, message)
+	}
+
+	caller := utils.Caller{Email: claims.Email, ClientIP: clientIP}
+	if token != nil {
+		caller.APITokenID = token.ID
+	}
+	ctx = utils.WithCaller(ctx, caller)
+	return context.WithValue(ctx, claimsContextKey{}, claims), nil
+}
+
+// firstValue returns the first value of a metadata key, or ""
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
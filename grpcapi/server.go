@@ -0,0 +1,206 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/profile"
+	pb "github.com/myjobmatch/backend/proto/myjobmatch/v1"
+	"github.com/myjobmatch/backend/search"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/utils"
+	"github.com/myjobmatch/backend/validation"
+	"github.com/myjobmatch/backend/worker"
+)
+
+// messageOverhead is the room left in a request message for its fields besides the CV file
+const messageOverhead = 1 << 20
+
+var grpcLog = logging.Component("GRPC")
+
+// Server implements the JobMatch gRPC service for internal services, on the same search and profile
+// services as the REST API
+type Server struct {
+	pb.UnimplementedJobMatchServer
+
+	jwtService *auth.JWTService
+	store      storage.Store
+	search     *search.Service
+	profiles   *profile.Service
+	searches   *worker.SearchRegistry
+	tenants    map[string]bool
+	maxCVBytes int64
+}
+
+// NewServer creates the gRPC server with the JobMatch service registered. Requests are authenticated,
+// attributed to a tenant and request ID, and logged by its interceptor.
+func NewServer(
+	jwtService *auth.JWTService,
+	store storage.Store,
+	searchService *search.Service,
+	profiles *profile.Service,
+	searches *worker.SearchRegistry,
+	tenantIDs []string,
+	maxCVBytes int64,
+) *grpc.Server {
+	s := &Server{
+		jwtService: jwtService,
+		store:      store,
+		search:     searchService,
+		profiles:   profiles,
+		searches:   searches,
+		tenants:    make(map[string]bool, len(tenantIDs)),
+		maxCVBytes: maxCVBytes,
+	}
+	for _, id := range tenantIDs {
+		s.tenants[id] = true
+	}
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(s.intercept),
+		grpc.MaxRecvMsgSize(int(maxCVBytes)+messageOverhead),
+	)
+	pb.RegisterJobMatchServer(server, s)
+	return server
+}
+
+// SearchJobs runs a synchronous job search
+func (s *Server) SearchJobs(ctx context.Context, req *pb.SearchJobsRequest) (*pb.SearchJobsResponse, error) {
+	if err := s.checkCVFile(req.GetCvFile(), req.GetCvFileName()); err != nil {
+		return nil, err
+	}
+
+	params := search.Params{
+		CVText:     req.GetCvText(),
+		CVFileData: req.GetCvFile(),
+		CVFileName: req.GetCvFileName(),
+		Query:      req.GetQuery(),
+		Filters:    toFilters(req.GetFilters()),
+		SaveCV:     req.GetSaveCv(),
+		Budget:     toBudget(req.GetBudget()),
+		Incognito:  req.GetIncognito(),
+		MaxResults: int(req.GetMaxResults()),
+		MaxPages:   int(req.GetMaxPagesToProcess()),
+		Model:      req.GetModel(),
+	}
+	claims := claimsFromContext(ctx)
+	if claims != nil {
+		params.UserID = claims.UserID
+	}
+
+	prepared, err := s.search.Prepare(ctx, params)
+	var invalid validation.Errors
+	switch {
+	case errors.Is(err, gemini.ErrModelNotAllowed):
+		return nil, status.Error(codes.InvalidArgument, "Unsupported model: "+err.Error())
+	case errors.As(err, &invalid):
+		return nil, invalidFields(invalid)
+	case errors.Is(err, search.ErrNoInput):
+		if claims != nil {
+			return nil, status.Error(codes.InvalidArgument, "Please provide a search query or upload your CV in your profile")
+		}
+		return nil, status.Error(codes.InvalidArgument, "Please provide a CV file, CV text, or search query")
+	case err != nil:
+		grpcLog.ErrorContext(ctx, "Failed to prepare search", "error", err)
+		return nil, status.Error(codes.Internal, "Failed to prepare search")
+	}
+
+	ctx, done, err := s.searches.Track(ctx, pb.JobMatch_SearchJobs_FullMethodName, nil)
+	if err != nil {
+		return nil, errShuttingDown
+	}
+	defer done()
+
+	response, err := s.search.Run(ctx, prepared)
+	if err != nil {
+		if worker.Interrupted(ctx) {
+			return nil, errShuttingDown
+		}
+		grpcLog.ErrorContext(ctx, "Job search failed", "error", err)
+		return nil, aiError(err, "Job search failed")
+	}
+
+	grpcLog.InfoContext(ctx, "Job search succeeded", "results", response.TotalResults, "cv_saved", response.CVSaved)
+	return fromSearchResponse(response), nil
+}
+
+// ParseCV extracts a structured profile from a CV without saving it
+func (s *Server) ParseCV(ctx context.Context, req *pb.ParseCVRequest) (*pb.ParseCVResponse, error) {
+	if err := s.checkCVFile(req.GetCvFile(), req.GetCvFileName()); err != nil {
+		return nil, err
+	}
+
+	parsed, err := s.profiles.Parse(ctx, req.GetCvText(), req.GetCvFile(), req.GetCvFileName(), req.GetModel())
+	if err != nil {
+		switch {
+		case errors.Is(err, gemini.ErrModelNotAllowed):
+			return nil, status.Error(codes.InvalidArgument, "Unsupported model: "+err.Error())
+		case errors.Is(err, profile.ErrNoCV):
+			return nil, status.Error(codes.InvalidArgument, "CV text or file is required")
+		}
+		grpcLog.ErrorContext(ctx, "Failed to parse CV", "error", err)
+		return nil, aiError(err, "CV parsing failed")
+	}
+
+	return &pb.ParseCVResponse{Profile: fromProfile(parsed)}, nil
+}
+
+// GetProfile returns the caller's structured profile
+func (s *Server) GetProfile(ctx context.Context, _ *pb.GetProfileRequest) (*pb.StructuredProfile, error) {
+	saved, err := s.profiles.ForUser(ctx, claimsFromContext(ctx).UserID)
+	if err != nil {
+		if errors.Is(err, profile.ErrNoProfile) {
+			return nil, status.Error(codes.NotFound, "No profile found. Upload your CV first.")
+		}
+		grpcLog.ErrorContext(ctx, "Failed to load profile", "error", err)
+		return nil, status.Error(codes.Internal, "Failed to load profile")
+	}
+
+	return fromStructuredProfile(saved), nil
+}
+
+// UpdateProfile applies the fields named in the update mask to the caller's structured profile
+func (s *Server) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.StructuredProfile, error) {
+	update, err := toProfileUpdate(req.GetProfile(), req.GetUpdateMask().GetPaths())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// The same binding rules as the REST API's PATCH /api/profile/structured
+	if err := binding.Validator.ValidateStruct(update); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid profile: "+err.Error())
+	}
+
+	saved, err := s.profiles.Update(ctx, claimsFromContext(ctx).UserID, update)
+	if err != nil {
+		if errors.Is(err, profile.ErrInvalidProfile) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		grpcLog.ErrorContext(ctx, "Failed to update profile", "error", err)
+		return nil, status.Error(codes.Internal, "Failed to update profile")
+	}
+
+	grpcLog.InfoContext(ctx, "Structured profile updated")
+	return fromStructuredProfile(saved), nil
+}
+
+// checkCVFile applies the REST API's upload limits to a CV file sent in a request
+func (s *Server) checkCVFile(data []byte, fileName string) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if int64(len(data)) > s.maxCVBytes {
+		return status.Errorf(codes.InvalidArgument, "CV file is too large: maximum file size is %d MB", s.maxCVBytes>>20)
+	}
+	if err := utils.ValidateCVContent(fileName, data); err != nil {
+		return status.Error(codes.InvalidArgument, "Invalid CV file: "+err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/analytics"
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/tools"
+	"github.com/myjobmatch/backend/worker"
+)
+
+var adminLog = logging.Component("AdminHandler")
+
+// AdminHandler handles administrator requests
+type AdminHandler struct {
+	store        storage.Store
+	toolRegistry *tools.ToolRegistry
+	toolSwitches *tools.ToolSwitches
+	backups      *worker.Backup // nil unless BACKUP_BUCKET_NAME is set
+	suspensions  *auth.SuspensionChecker
+}
+
+// NewAdminHandler creates a new admin handler; toolRegistry is the MCP registry, whose switches are toolSwitches.
+// backups may be nil when backups are not configured. suspensions is told about suspended and reactivated users.
+func NewAdminHandler(store storage.Store, toolRegistry *tools.ToolRegistry, toolSwitches *tools.ToolSwitches, backups *worker.Backup, suspensions *auth.SuspensionChecker) *AdminHandler {
+	return &AdminHandler{
+		store:        store,
+		toolRegistry: toolRegistry,
+		toolSwitches: toolSwitches,
+		backups:      backups,
+		suspensions:  suspensions,
+	}
+}
+
+// GetLLMDebugRecords returns the Gemini calls recorded for a request
+// @Summary Get LLM debug records
+// @Description Get the redacted prompts and raw Gemini responses recorded for a request, oldest first. The request ID is returned in the X-Request-ID header of every response. Records are only kept when LLM_DEBUG_LOG_ENABLED=true, for LLM_DEBUG_LOG_TTL_HOURS. Requires an administrator (ADMIN_EMAILS).
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param requestId path string true "Request ID (X-Request-ID)"
+// @Success 200 {object} models.LLMDebugResponse "Recorded Gemini calls"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Not an administrator"
+// @Failure 404 {object} models.ErrorResponse "No records for the request"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/llm-debug/{requestId} [get]
+func (h *AdminHandler) GetLLMDebugRecords(c *gin.Context) {
+	requestID := c.Param("requestId")
+
+	records, err := h.store.ListLLMDebugRecords(c.Request.Context(), requestID)
+	if err != nil {
+		adminLog.ErrorContext(c.Request.Context(), "Failed to list LLM debug records", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to get debug records",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if len(records) == 0 {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: "No debug records for this request",
+			Code:  http.StatusNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.LLMDebugResponse{
+		RequestID: requestID,
+		Records:   records,
+	})
+}
+
+// GetPromptExperiments compares the prompt experiment variants
+// @Summary Get prompt experiment report
+// @Description Compare the scoring and extraction prompt variants searches were assigned to (PROMPT_EXPERIMENT_VARIANTS) over the last N days: searches, extraction error rate, Gemini score distribution, heuristic fallback rate and user feedback on the jobs each variant scored. Requires an administrator (ADMIN_EMAILS).
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Days to cover (default 30, max 180)"
+// @Success 200 {object} models.PromptExperimentReport "Metrics per prompt variant"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Not an administrator"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/prompt-experiments [get]
+func (h *AdminHandler) GetPromptExperiments(c *gin.Context) {
+	days := defaultInsightsDays
+	if v, err := strconv.Atoi(c.Query("days")); err == nil && v > 0 {
+		days = min(v, maxInsightsDays)
+	}
+
+	ctx := c.Request.Context()
+	to := time.Now()
+	from := to.AddDate(0, 0, -days)
+
+	records, err := h.store.ListPromptExperimentRecords(ctx, from)
+	if err != nil {
+		adminLog.ErrorContext(ctx, "Failed to list prompt experiment records", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to build experiment report",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+	feedback, err := h.store.ListExperimentFeedback(ctx, from)
+	if err != nil {
+		adminLog.ErrorContext(ctx, "Failed to list experiment feedback", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to build experiment report",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics.BuildPromptExperimentReport(records, feedback, from, to))
+}
+
+// Tool audit query limits
+const (
+	defaultToolAuditLimit = 100
+	maxToolAuditLimit     = 1000
+)
+
+// GetToolAudit returns recorded tool calls
+// @Summary Get tool audit log
+// @Description Get recorded MCP and agent tool calls, newest first, with caller, duration, outcome and redacted arguments, plus call totals per tool. Filter by tool, caller email or API token. Calls are recorded when TOOL_AUDIT_ENABLED=true, for TOOL_AUDIT_TTL_DAYS. Requires an administrator (ADMIN_EMAILS).
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param tool query string false "Tool name"
+// @Param email query string false "Caller email"
+// @Param token query string false "API token ID"
+// @Param days query int false "Days to cover (default 30, max 180)"
+// @Param limit query int false "Records to return (default 100, max 1000)"
+// @Success 200 {object} models.ToolAuditResponse "Recorded tool calls"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Not an administrator"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/tool-audit [get]
+func (h *AdminHandler) GetToolAudit(c *gin.Context) {
+	days := defaultInsightsDays
+	if v, err := strconv.Atoi(c.Query("days")); err == nil && v > 0 {
+		days = min(v, maxInsightsDays)
+	}
+	limit := defaultToolAuditLimit
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil && v > 0 {
+		limit = min(v, maxToolAuditLimit)
+	}
+
+	records, err := h.store.ListToolAuditRecords(c.Request.Context(), models.ToolAuditQuery{
+		Tool:        c.Query("tool"),
+		CallerEmail: c.Query("email"),
+		APITokenID:  c.Query("token"),
+		Since:       time.Now().AddDate(0, 0, -days),
+		Limit:       limit,
+	})
+	if err != nil {
+		adminLog.ErrorContext(c.Request.Context(), "Failed to list tool audit records", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to get tool audit log",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ToolAuditResponse{
+		Records: records,
+		Totals:  toolAuditTotals(records),
+	})
+}
+
+// toolAuditTotals sums calls, failures and durations per tool, most called first
+func toolAuditTotals(records []models.ToolAuditRecord) []models.ToolAuditTotal {
+	byTool := make(map[string]*models.ToolAuditTotal)
+	for _, record := range records {
+		total, ok := byTool[record.Tool]
+		if !ok {
+			total = &models.ToolAuditTotal{Tool: record.Tool}
+			byTool[record.Tool] = total
+		}
+		total.Calls++
+		if !record.Success {
+			total.Failures++
+		}
+		total.TotalDurationMs += record.DurationMs
+	}
+
+	totals := make([]models.ToolAuditTotal, 0, len(byTool))
+	for _, total := range byTool {
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Calls != totals[j].Calls {
+			return totals[i].Calls > totals[j].Calls
+		}
+		return totals[i].Tool < totals[j].Tool
+	})
+	return totals
+}
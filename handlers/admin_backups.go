@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/worker"
+)
+
+// CreateBackup exports the database to the backup bucket now
+// @Summary Take a backup
+// @Description Export the users (with the indexes that find them), profiles and jobs collections to BACKUP_BUCKET_NAME as JSONL, in a folder named by the backup ID. Runs to completion even if the client disconnects; backups past BACKUP_RETENTION_DAYS are deleted afterwards. Requires an administrator (ADMIN_EMAILS).
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} models.BackupManifest "Completed backup"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Not an administrator"
+// @Failure 409 {object} models.ErrorResponse "A backup or restore is already running"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Failure 501 {object} models.ErrorResponse "Backups are not configured"
+// @Router /admin/backups [post]
+func (h *AdminHandler) CreateBackup(c *gin.Context) {
+	if h.backups == nil {
+		respondBackupsDisabled(c)
+		return
+	}
+
+	manifest, err := h.backups.RunOnce(context.WithoutCancel(c.Request.Context()))
+	if err != nil {
+		respondBackupError(c, "Failed to take backup", err)
+		return
+	}
+	c.JSON(http.StatusCreated, manifest)
+}
+
+// ListBackups returns the completed backups
+// @Summary List backups
+// @Description List the completed backups in BACKUP_BUCKET_NAME, newest first, with the documents exported per collection. Requires an administrator (ADMIN_EMAILS).
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.BackupListResponse "Completed backups"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Not an administrator"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Failure 501 {object} models.ErrorResponse "Backups are not configured"
+// @Router /admin/backups [get]
+func (h *AdminHandler) ListBackups(c *gin.Context) {
+	if h.backups == nil {
+		respondBackupsDisabled(c)
+		return
+	}
+
+	backups, err := h.backups.List(c.Request.Context())
+	if err != nil {
+		respondBackupError(c, "Failed to list backups", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.BackupListResponse{Backups: backups})
+}
+
+// RestoreBackup writes a backup's documents back to the database
+// @Summary Restore a backup
+// @Description Write the documents of a backup's collections (all of them unless listed) back to the database, replacing documents with the same IDs; documents created since the backup are kept. Restore users together with user_emails and user_google_ids on Firestore, or restored users can't sign in. Backups restore only into the storage backend they were taken from. Requires an administrator (ADMIN_EMAILS).
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Backup ID"
+// @Param request body models.RestoreBackupRequest false "Collections to restore"
+// @Success 200 {object} models.RestoreBackupResponse "Documents restored"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body, or a collection the backup doesn't hold"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Not an administrator"
+// @Failure 404 {object} models.ErrorResponse "Backup not found"
+// @Failure 409 {object} models.ErrorResponse "A backup or restore is already running, or the backup is from another storage backend"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Failure 501 {object} models.ErrorResponse "Backups are not configured"
+// @Router /admin/backups/{id}/restore [post]
+func (h *AdminHandler) RestoreBackup(c *gin.Context) {
+	if h.backups == nil {
+		respondBackupsDisabled(c)
+		return
+	}
+
+	var req models.RestoreBackupRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.backups.Restore(context.WithoutCancel(c.Request.Context()), c.Param("id"), req.Collections)
+	if err != nil {
+		if result != nil {
+			adminLog.WarnContext(c.Request.Context(), "Backup restore stopped", "backup_id", c.Param("id"), "restored", result.Restored)
+		}
+		respondBackupError(c, "Failed to restore backup", err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// respondBackupError maps backup errors to status codes
+func respondBackupError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, worker.ErrBackupNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, worker.ErrBackupRunning), errors.Is(err, worker.ErrBackupBackend):
+		status = http.StatusConflict
+	case errors.Is(err, storage.ErrNotBackedUp):
+		status = http.StatusBadRequest
+	default:
+		adminLog.ErrorContext(c.Request.Context(), message, "error", err)
+	}
+
+	c.JSON(status, models.ErrorResponse{
+		Error:   message,
+		Code:    status,
+		Details: err.Error(),
+	})
+}
+
+func respondBackupsDisabled(c *gin.Context) {
+	c.JSON(http.StatusNotImplemented, models.ErrorResponse{
+		Error:   "Backups are not configured",
+		Code:    http.StatusNotImplemented,
+		Details: "Set BACKUP_BUCKET_NAME to enable backups",
+	})
+}
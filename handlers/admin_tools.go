@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/models"
+)
+
+// ListTools returns the registered tools and whether each is enabled
+// @Summary List tool switches
+// @Description Get every registered MCP tool with its version and whether it is enabled, with the reason for disabled tools. Requires an administrator (ADMIN_EMAILS).
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.ToolStatusResponse "Registered tools"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Not an administrator"
+// @Router /admin/tools [get]
+func (h *AdminHandler) ListTools(c *gin.Context) {
+	registered := h.toolRegistry.List()
+
+	statuses := make([]models.ToolStatus, 0, len(registered))
+	for _, tool := range registered {
+		statuses = append(statuses, models.ToolStatus{
+			Name:    tool.Name(),
+			Version: tool.Version(),
+			Enabled: h.toolRegistry.Enabled(tool.Name()),
+			Reason:  h.toolRegistry.DisabledReason(tool.Name()),
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	c.JSON(http.StatusOK, models.ToolStatusResponse{
+		Tools: statuses,
+	})
+}
+
+// UpdateTool turns a tool on or off
+// @Summary Enable or disable a tool
+// @Description Turn a tool on or off for MCP clients, agent search and the career assistant without redeploying, e.g. stop fetch_page_html when a job board objects to scraping. The switch overrides DISABLED_TOOLS, applies immediately on this instance and reaches the others within TOOL_SETTINGS_REFRESH_SECONDS. Calls to a disabled tool fail with the tool_disabled code. Requires an administrator (ADMIN_EMAILS).
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Tool name"
+// @Param request body models.UpdateToolSettingRequest true "Whether the tool is enabled, and why"
+// @Success 200 {object} models.ToolSetting "Updated switch"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Not an administrator"
+// @Failure 404 {object} models.ErrorResponse "Tool not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/tools/{name} [put]
+func (h *AdminHandler) UpdateTool(c *gin.Context) {
+	name := c.Param("name")
+	if _, ok := h.toolRegistry.Get(name); !ok {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: "Tool not found",
+			Code:  http.StatusNotFound,
+		})
+		return
+	}
+
+	var req models.UpdateToolSettingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	setting := &models.ToolSetting{
+		Name:      name,
+		Enabled:   *req.Enabled,
+		Reason:    req.Reason,
+		UpdatedAt: time.Now(),
+	}
+	if claims := auth.GetAuthClaims(c); claims != nil {
+		setting.UpdatedBy = claims.Email
+	}
+
+	ctx := c.Request.Context()
+	if err := h.store.SaveToolSetting(ctx, setting); err != nil {
+		adminLog.ErrorContext(ctx, "Failed to save tool setting", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to update tool",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	// Apply every stored switch now rather than at the next refresh
+	settings, err := h.store.ListToolSettings(ctx)
+	if err != nil {
+		adminLog.WarnContext(ctx, "Failed to reload tool settings", "error", err)
+	} else {
+		h.toolSwitches.Apply(settings)
+	}
+
+	adminLog.InfoContext(ctx, "Tool switch changed", "tool", name, "enabled", setting.Enabled, "reason", setting.Reason)
+	c.JSON(http.StatusOK, setting)
+}
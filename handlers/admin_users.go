@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+)
+
+const (
+	defaultAdminUsersLimit = 50
+	maxAdminUsersLimit     = 200
+)
+
+// ListUsers returns a page of user accounts
+// @Summary List users
+// @Description List user accounts, newest first, filtered by sign-in provider, creation date, whether a CV is uploaded and whether the account is suspended. Pass next_cursor from a response as cursor to get the next page. Requires an administrator (ADMIN_EMAILS).
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param provider query string false "Sign-in provider" Enums(email, google)
+// @Param created_after query string false "Created on or after this date (YYYY-MM-DD, UTC)"
+// @Param created_before query string false "Created on or before this date (YYYY-MM-DD, UTC)"
+// @Param has_cv query bool false "Only users with (true) or without (false) a CV"
+// @Param suspended query bool false "Only suspended (true) or active (false) users"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Users per page (default 50, max 200)"
+// @Success 200 {object} models.AdminUserListResponse "Users"
+// @Failure 400 {object} models.ErrorResponse "Invalid filter or cursor"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Not an administrator"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/users [get]
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	query, err := parseUserQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid filter",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	users, nextCursor, err := h.store.ListUsers(c.Request.Context(), query)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error: "Invalid cursor",
+				Code:  http.StatusBadRequest,
+			})
+			return
+		}
+		adminLog.ErrorContext(c.Request.Context(), "Failed to list users", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to list users",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.AdminUserListResponse{
+		Users:      users,
+		NextCursor: nextCursor,
+	})
+}
+
+// parseUserQuery reads the user listing filters from the query string
+func parseUserQuery(c *gin.Context) (models.UserQuery, error) {
+	query := models.UserQuery{
+		Cursor: c.Query("cursor"),
+		Limit:  defaultAdminUsersLimit,
+	}
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil && v > 0 {
+		query.Limit = min(v, maxAdminUsersLimit)
+	}
+
+	switch provider := c.Query("provider"); provider {
+	case "", "email", "google":
+		query.Provider = provider
+	default:
+		return query, errors.New("provider must be email or google")
+	}
+
+	if v := c.Query("created_after"); v != "" {
+		date, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return query, errors.New("created_after must be a YYYY-MM-DD date")
+		}
+		query.CreatedAfter = date
+	}
+	if v := c.Query("created_before"); v != "" {
+		date, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return query, errors.New("created_before must be a YYYY-MM-DD date")
+		}
+		// Inclusive of the whole day
+		query.CreatedBefore = date.AddDate(0, 0, 1)
+	}
+
+	for _, filter := range []struct {
+		name   string
+		target **bool
+	}{
+		{"has_cv", &query.HasCV},
+		{"suspended", &query.Suspended},
+	} {
+		if v := c.Query(filter.name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return query, errors.New(filter.name + " must be true or false")
+			}
+			*filter.target = &b
+		}
+	}
+	return query, nil
+}
+
+// SuspendUser suspends a user account
+// @Summary Suspend user
+// @Description Suspend a user account: it can no longer sign in, and its sessions and API tokens are refused with 403 (on other instances within SUSPENSION_CHECK_CACHE_SECONDS). The account's data is kept. Administrators can't suspend themselves. Requires an administrator (ADMIN_EMAILS).
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param request body models.SuspendUserRequest false "Why the account is suspended"
+// @Success 200 {object} models.User "Suspended user"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body or own account"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Not an administrator"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/suspend [post]
+func (h *AdminHandler) SuspendUser(c *gin.Context) {
+	var req models.SuspendUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	user, ok := h.getUser(c)
+	if !ok {
+		return
+	}
+	if claims := auth.GetAuthClaims(c); claims != nil && strings.EqualFold(claims.Email, user.Email) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "You can't suspend your own account",
+			Code:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	now := time.Now()
+	h.setSuspension(c, user, map[string]interface{}{
+		"suspended":       true,
+		"suspendedAt":     now,
+		"suspendedReason": req.Reason,
+	})
+}
+
+// ReactivateUser lifts the suspension of a user account
+// @Summary Reactivate user
+// @Description Lift the suspension of a user account, so it can sign in and use its API tokens again. Requires an administrator (ADMIN_EMAILS).
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} models.User "Reactivated user"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Not an administrator"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/reactivate [post]
+func (h *AdminHandler) ReactivateUser(c *gin.Context) {
+	user, ok := h.getUser(c)
+	if !ok {
+		return
+	}
+
+	h.setSuspension(c, user, map[string]interface{}{
+		"suspended":       false,
+		"suspendedAt":     nil,
+		"suspendedReason": "",
+	})
+}
+
+// getUser loads the user named by the id path parameter, writing the error response if that fails
+func (h *AdminHandler) getUser(c *gin.Context) (*models.User, bool) {
+	user, err := h.store.GetUserByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "User not found",
+				Code:  http.StatusNotFound,
+			})
+			return nil, false
+		}
+		adminLog.ErrorContext(c.Request.Context(), "Failed to get user", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to get user",
+			Code:  http.StatusInternalServerError,
+		})
+		return nil, false
+	}
+	return user, true
+}
+
+// setSuspension stores a user's suspension fields and responds with the updated user
+func (h *AdminHandler) setSuspension(c *gin.Context, user *models.User, updates map[string]interface{}) {
+	ctx := c.Request.Context()
+	if err := h.store.UpdateUser(ctx, user.Email, updates); err != nil {
+		adminLog.ErrorContext(ctx, "Failed to update suspension", "user_id", user.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to update user",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+	h.suspensions.Forget(ctx, user.ID)
+
+	updated, err := h.store.GetUserByID(ctx, user.ID)
+	if err != nil {
+		adminLog.ErrorContext(ctx, "Failed to get user", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to get user",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	adminLog.InfoContext(ctx, "Suspension updated", "user_id", updated.ID, "suspended", updated.Suspended)
+	c.JSON(http.StatusOK, updated)
+}
+
+// MigrateUserIDs moves users still keyed by email to random IDs
+// @Summary Migrate user IDs
+// @Description Move user accounts created before user IDs (whose documents are keyed by their email) to random IDs with an email index entry. Safe to run repeatedly and while the API is serving; accounts are also moved when their email changes. Requires an administrator (ADMIN_EMAILS).
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.UserIDMigrationResponse "Users moved"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Not an administrator"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/users/migrate-ids [post]
+func (h *AdminHandler) MigrateUserIDs(c *gin.Context) {
+	migrated, err := h.store.MigrateUserIDs(c.Request.Context())
+	if err != nil {
+		adminLog.ErrorContext(c.Request.Context(), "Failed to migrate user IDs", "migrated", migrated, "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to migrate user IDs",
+			Code:    http.StatusInternalServerError,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	adminLog.InfoContext(c.Request.Context(), "Moved users to user IDs", "migrated", migrated)
+	c.JSON(http.StatusOK, models.UserIDMigrationResponse{Migrated: migrated})
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// defaultRetryAfter is suggested to clients when a capacity error doesn't say when to retry
+const defaultRetryAfter = 30 * time.Second
+
+// respondAIError writes the response for a failed request that depends on Gemini: 422 when the safety filters
+// blocked the content, so the user can tell it apart from an outage, 429 with Retry-After when the Gemini rate
+// limit or quota is reached, 503 with Retry-After while a dependency's circuit breaker is open, 400 when the CV file has no readable text, and 500 with message otherwise
+func respondAIError(c *gin.Context, err error, message string) {
+	if errors.Is(err, gemini.ErrCapacityExceeded) {
+		retryAfter := defaultRetryAfter
+		var capacity *gemini.CapacityError
+		if errors.As(err, &capacity) && capacity.RetryAfter > 0 {
+			retryAfter = capacity.RetryAfter
+		}
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, models.ErrorResponse{
+			Error:   "The AI service is at capacity, please retry later",
+			Code:    http.StatusTooManyRequests,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if errors.Is(err, utils.ErrCircuitOpen) {
+		retryAfter := defaultRetryAfter
+		var open *utils.CircuitOpenError
+		if errors.As(err, &open) && open.RetryAfter > 0 {
+			retryAfter = open.RetryAfter
+		}
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error:   "The AI service is temporarily unavailable, please retry later",
+			Code:    http.StatusServiceUnavailable,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if errors.Is(err, utils.ErrNoDocumentText) || errors.Is(err, utils.ErrUnsupportedFileType) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "The CV file could not be read",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, gemini.ErrContentBlocked) {
+		c.JSON(http.StatusUnprocessableEntity, models.ErrorResponse{
+			Error:   "The content was blocked by the AI safety filters",
+			Code:    http.StatusUnprocessableEntity,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		Error:   message,
+		Code:    http.StatusInternalServerError,
+		Details: err.Error(),
+	})
+}
+
+// respondModelError rejects a request for a Gemini model that isn't allowed, listing the allowed models
+func respondModelError(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		Error:   "Unsupported model",
+		Code:    http.StatusBadRequest,
+		Details: err.Error(),
+	})
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/profile"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/validation"
+)
+
+var alertLog = logging.Component("AlertHandler")
+
+// AlertHandler handles job alert subscription requests
+type AlertHandler struct {
+	store           storage.Store
+	profiles        *profile.Service
+	defaultMinScore int
+	maxPerUser      int
+}
+
+// NewAlertHandler creates a new job alert handler
+func NewAlertHandler(store storage.Store, profiles *profile.Service, defaultMinScore, maxPerUser int) *AlertHandler {
+	return &AlertHandler{
+		store:           store,
+		profiles:        profiles,
+		defaultMinScore: defaultMinScore,
+		maxPerUser:      maxPerUser,
+	}
+}
+
+// ListAlerts returns the user's job alerts
+// @Summary List job alerts
+// @Description Get the authenticated user's saved searches that are rerun on a schedule
+// @Tags Alerts
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.JobAlertResponse "Job alerts"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /alerts [get]
+func (h *AlertHandler) ListAlerts(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	alerts, err := h.store.ListAlerts(c.Request.Context(), claims.UserID)
+	if err != nil {
+		alertLog.ErrorContext(c.Request.Context(), "Failed to list alerts", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load job alerts",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.JobAlertResponse{
+		Alerts: alerts,
+	})
+}
+
+// CreateAlert saves a search as a job alert
+// @Summary Create a job alert
+// @Description Save a query and filters to be rerun daily or weekly. Each run is scored against the saved CV and only postings not seen in earlier runs, scoring at or above minScore, are emailed. The query may be omitted if the user has a saved CV.
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateJobAlertRequest true "Search to save"
+// @Success 201 {object} models.JobAlertResponse "Job alert created"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body or alert limit reached"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /alerts [post]
+func (h *AlertHandler) CreateAlert(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.CreateJobAlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+	if err := validation.Search(&req.Query, &req.Filters); err != nil {
+		respondValidationError(c, err.(validation.Errors))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Without a query the alert searches with the saved profile, so one must exist
+	if req.Query == "" {
+		if _, err := h.profiles.ForUser(ctx, claims.UserID); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid request body",
+				Code:    http.StatusBadRequest,
+				Details: "query is required when no CV has been uploaded",
+			})
+			return
+		}
+	}
+
+	existing, err := h.store.ListAlerts(ctx, claims.UserID)
+	if err != nil {
+		alertLog.ErrorContext(ctx, "Failed to list alerts", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to create job alert",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if len(existing) >= h.maxPerUser {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Job alert limit reached",
+			Code:    http.StatusBadRequest,
+			Details: "Delete an existing alert before creating a new one",
+		})
+		return
+	}
+
+	minScore := req.MinScore
+	if minScore == 0 {
+		minScore = h.defaultMinScore
+	}
+
+	// The first run happens on the scheduler's next check
+	alert := &models.JobAlert{
+		UserID:    claims.UserID,
+		Name:      req.Name,
+		Query:     req.Query,
+		Filters:   req.Filters,
+		Frequency: req.Frequency,
+		MinScore:  minScore,
+		SeenURLs:  []string{},
+		NextRunAt: time.Now(),
+	}
+
+	if err := h.store.CreateAlert(ctx, alert); err != nil {
+		alertLog.ErrorContext(ctx, "Failed to create alert", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to create job alert",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	alertLog.InfoContext(ctx, "Job alert created", "alert_id", alert.ID, "frequency", alert.Frequency, "name", alert.Name)
+	c.JSON(http.StatusCreated, models.JobAlertResponse{
+		Alerts:  []models.JobAlert{*alert},
+		Message: "Job alert created",
+	})
+}
+
+// DeleteAlert removes a job alert
+// @Summary Delete a job alert
+// @Description Stop a saved search from being rerun
+// @Tags Alerts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job alert ID"
+// @Success 200 {object} models.JobAlertResponse "Job alert deleted"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Job alert not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /alerts/{id} [delete]
+func (h *AlertHandler) DeleteAlert(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.store.DeleteAlert(c.Request.Context(), claims.UserID, c.Param("id")); err != nil {
+		if errors.Is(err, storage.ErrAlertNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Job alert not found",
+				Code:  http.StatusNotFound,
+			})
+			return
+		}
+		alertLog.ErrorContext(c.Request.Context(), "Failed to delete alert", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to delete job alert",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.JobAlertResponse{
+		Alerts:  []models.JobAlert{},
+		Message: "Job alert deleted",
+	})
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// maxAPITokensPerUser caps how many personal access tokens a user can hold
+const maxAPITokensPerUser = 20
+
+var apiTokenLog = logging.Component("APITokenHandler")
+
+// APITokenHandler handles personal access token management requests
+type APITokenHandler struct {
+	store storage.Store
+}
+
+// NewAPITokenHandler creates a new API token handler
+func NewAPITokenHandler(store storage.Store) *APITokenHandler {
+	return &APITokenHandler{
+		store: store,
+	}
+}
+
+// ListAPITokens returns the user's personal access tokens
+// @Summary List API tokens
+// @Description Get the authenticated user's personal access tokens with their scopes, expiry and last use. Secrets are never returned.
+// @Tags API Tokens
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APITokenListResponse "API tokens"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /tokens [get]
+func (h *APITokenHandler) ListAPITokens(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	tokens, err := h.store.ListAPITokens(c.Request.Context(), claims.UserID)
+	if err != nil {
+		apiTokenLog.ErrorContext(c.Request.Context(), "Failed to list tokens", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load API tokens",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APITokenListResponse{
+		Tokens: tokens,
+	})
+}
+
+// CreateAPIToken mints a new personal access token
+// @Summary Create API token
+// @Description Mint a personal access token for scripting against the API. Send it as "Authorization: Bearer <token>". Scopes: search (POST /search-jobs), read-profile (GET /profile/structured), mcp (the /mcp endpoints, optionally limited to mcpTools and mcpRateLimitPerMinute). The token is shown only once.
+// @Tags API Tokens
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateAPITokenRequest true "Token name, scopes and expiry"
+// @Success 201 {object} models.CreateAPITokenResponse "Token created"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body or token limit reached"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /tokens [post]
+func (h *APITokenHandler) CreateAPIToken(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	existing, err := h.store.ListAPITokens(c.Request.Context(), claims.UserID)
+	if err != nil {
+		apiTokenLog.ErrorContext(c.Request.Context(), "Failed to list tokens", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to create API token",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if len(existing) >= maxAPITokensPerUser {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Token limit reached",
+			Code:    http.StatusBadRequest,
+			Details: "Revoke an existing token before creating a new one",
+		})
+		return
+	}
+
+	secret, tokenHash, err := auth.GenerateAPIToken()
+	if err != nil {
+		apiTokenLog.ErrorContext(c.Request.Context(), "Failed to generate token", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to create API token",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	token := &models.APIToken{
+		UserID:    claims.UserID,
+		TokenHash: tokenHash,
+		Name:      req.Name,
+		Prefix:    secret[:len(auth.APITokenPrefix)+4],
+		Scopes:    utils.DedupeStrings(req.Scopes),
+	}
+	if token.HasScope(auth.ScopeMCP) {
+		token.MCPTools = utils.DedupeStrings(req.MCPTools)
+		token.MCPRateLimitPerMinute = req.MCPRateLimitPerMinute
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err := h.store.CreateAPIToken(c.Request.Context(), token); err != nil {
+		apiTokenLog.ErrorContext(c.Request.Context(), "Failed to save token", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to create API token",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	apiTokenLog.InfoContext(c.Request.Context(), "API token created", "token_id", token.ID, "name", token.Name, "scopes", token.Scopes)
+	c.JSON(http.StatusCreated, models.CreateAPITokenResponse{
+		Token:   secret,
+		Details: token,
+		Message: "Store this token securely, it will not be shown again",
+	})
+}
+
+// RevokeAPIToken deletes one of the user's personal access tokens
+// @Summary Revoke API token
+// @Description Revoke a personal access token; requests using it are rejected immediately
+// @Tags API Tokens
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Token ID"
+// @Success 200 {object} models.APITokenListResponse "Token revoked"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Token not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /tokens/{id} [delete]
+func (h *APITokenHandler) RevokeAPIToken(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.store.DeleteAPIToken(c.Request.Context(), claims.UserID, c.Param("id")); err != nil {
+		if errors.Is(err, storage.ErrAPITokenNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "API token not found",
+				Code:  http.StatusNotFound,
+			})
+			return
+		}
+		apiTokenLog.ErrorContext(c.Request.Context(), "Failed to revoke token", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to revoke API token",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	apiTokenLog.InfoContext(c.Request.Context(), "API token revoked", "token_id", c.Param("id"))
+	c.JSON(http.StatusOK, models.APITokenListResponse{
+		Tokens:  []models.APIToken{},
+		Message: "API token revoked",
+	})
+}
@@ -0,0 +1,393 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+)
+
+var applicationLog = logging.Component("ApplicationHandler")
+
+// ApplicationHandler handles job application tracker requests
+type ApplicationHandler struct {
+	store storage.Store
+}
+
+// NewApplicationHandler creates a new application tracker handler
+func NewApplicationHandler(store storage.Store) *ApplicationHandler {
+	return &ApplicationHandler{
+		store: store,
+	}
+}
+
+// ListApplications returns the user's tracked applications
+// @Summary List applications
+// @Description Get the authenticated user's tracked job applications, most recently updated first
+// @Tags Applications
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status" Enums(applied, interviewing, offer, rejected)
+// @Success 200 {object} models.ApplicationListResponse "Applications"
+// @Failure 400 {object} models.ErrorResponse "Invalid status"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /applications [get]
+func (h *ApplicationHandler) ListApplications(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	statusFilter := c.Query("status")
+	if statusFilter != "" && !isApplicationStatus(statusFilter) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid status",
+			Code:    http.StatusBadRequest,
+			Details: "status must be one of applied, interviewing, offer, rejected",
+		})
+		return
+	}
+
+	apps, err := h.store.ListApplications(c.Request.Context(), claims.UserID)
+	if err != nil {
+		applicationLog.ErrorContext(c.Request.Context(), "Failed to list applications", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load applications",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if statusFilter != "" {
+		filtered := make([]models.Application, 0, len(apps))
+		for _, app := range apps {
+			if app.Status == statusFilter {
+				filtered = append(filtered, app)
+			}
+		}
+		apps = filtered
+	}
+
+	c.JSON(http.StatusOK, models.ApplicationListResponse{
+		Applications: apps,
+	})
+}
+
+// GetApplicationBoard returns the user's applications grouped by status
+// @Summary Application board
+// @Description Get the authenticated user's applications grouped into one column per status (applied, interviewing, offer, rejected), suitable for a kanban view
+// @Tags Applications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.ApplicationBoardResponse "Applications grouped by status"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /applications/board [get]
+func (h *ApplicationHandler) GetApplicationBoard(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	apps, err := h.store.ListApplications(c.Request.Context(), claims.UserID)
+	if err != nil {
+		applicationLog.ErrorContext(c.Request.Context(), "Failed to list applications", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load applications",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	// Every status gets a column, even when empty, so clients can render a fixed layout
+	byStatus := make(map[string][]models.Application, len(models.ApplicationStatuses))
+	for _, app := range apps {
+		byStatus[app.Status] = append(byStatus[app.Status], app)
+	}
+
+	columns := make([]models.ApplicationBoardColumn, 0, len(models.ApplicationStatuses))
+	for _, s := range models.ApplicationStatuses {
+		column := byStatus[s]
+		if column == nil {
+			column = []models.Application{}
+		}
+		columns = append(columns, models.ApplicationBoardColumn{
+			Status:       s,
+			Count:        len(column),
+			Applications: column,
+		})
+	}
+
+	c.JSON(http.StatusOK, models.ApplicationBoardResponse{
+		Columns: columns,
+		Total:   len(apps),
+	})
+}
+
+// CreateApplication starts tracking a job application
+// @Summary Track an application
+// @Description Record a job the user has applied to. Status defaults to applied and appliedAt defaults to now.
+// @Tags Applications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateApplicationRequest true "Application to track"
+// @Success 201 {object} models.ApplicationResponse "Application created"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /applications [post]
+func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.CreateApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	status := req.Status
+	if status == "" {
+		status = models.ApplicationStatusApplied
+	}
+	appliedAt := req.AppliedAt
+	if appliedAt == nil {
+		appliedAt = &now
+	}
+
+	app := &models.Application{
+		UserID:     claims.UserID,
+		JobTitle:   req.JobTitle,
+		Company:    req.Company,
+		JobURL:     req.JobURL,
+		Location:   req.Location,
+		MatchScore: req.MatchScore,
+		Status:     status,
+		Notes:      req.Notes,
+		AppliedAt:  appliedAt,
+		NextStepAt: req.NextStepAt,
+		StatusHistory: []models.ApplicationStatusChange{
+			{Status: status, ChangedAt: now},
+		},
+	}
+
+	if err := h.store.CreateApplication(c.Request.Context(), app); err != nil {
+		applicationLog.ErrorContext(c.Request.Context(), "Failed to create application", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to create application",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	applicationLog.InfoContext(c.Request.Context(), "Application tracked", "application_id", app.ID, "job", app.JobTitle, "company", app.Company)
+	c.JSON(http.StatusCreated, models.ApplicationResponse{
+		Application: *app,
+		Message:     "Application created",
+	})
+}
+
+// GetApplication returns a single tracked application
+// @Summary Get an application
+// @Description Get one of the authenticated user's tracked applications, including its status history
+// @Tags Applications
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Application ID"
+// @Success 200 {object} models.ApplicationResponse "Application"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Application not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /applications/{id} [get]
+func (h *ApplicationHandler) GetApplication(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	app, ok := h.loadApplication(c, claims.UserID)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApplicationResponse{
+		Application: *app,
+	})
+}
+
+// UpdateApplication changes an application's status, notes or dates
+// @Summary Update an application
+// @Description Move an application to a new status or edit its notes and dates. Only fields present in the body are changed; status changes are appended to the status history.
+// @Tags Applications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Application ID"
+// @Param request body models.UpdateApplicationRequest true "Fields to update"
+// @Success 200 {object} models.ApplicationResponse "Application updated"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Application not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /applications/{id} [patch]
+func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.UpdateApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	app, ok := h.loadApplication(c, claims.UserID)
+	if !ok {
+		return
+	}
+
+	if req.Status != nil && *req.Status != app.Status {
+		app.Status = *req.Status
+		app.StatusHistory = append(app.StatusHistory, models.ApplicationStatusChange{
+			Status:    *req.Status,
+			ChangedAt: time.Now(),
+		})
+	}
+	if req.Notes != nil {
+		app.Notes = *req.Notes
+	}
+	if req.AppliedAt != nil {
+		app.AppliedAt = req.AppliedAt
+	}
+	if req.NextStepAt != nil {
+		app.NextStepAt = req.NextStepAt
+	}
+
+	if err := h.store.SaveApplication(c.Request.Context(), app); err != nil {
+		applicationLog.ErrorContext(c.Request.Context(), "Failed to update application", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to update application",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApplicationResponse{
+		Application: *app,
+		Message:     "Application updated",
+	})
+}
+
+// DeleteApplication stops tracking an application
+// @Summary Delete an application
+// @Description Remove an application from the authenticated user's tracker
+// @Tags Applications
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Application ID"
+// @Success 200 {object} models.ApplicationListResponse "Application deleted"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Application not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /applications/{id} [delete]
+func (h *ApplicationHandler) DeleteApplication(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.store.DeleteApplication(c.Request.Context(), claims.UserID, c.Param("id")); err != nil {
+		if errors.Is(err, storage.ErrApplicationNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Application not found",
+				Code:  http.StatusNotFound,
+			})
+			return
+		}
+		applicationLog.ErrorContext(c.Request.Context(), "Failed to delete application", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to delete application",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ApplicationListResponse{
+		Applications: []models.Application{},
+		Message:      "Application deleted",
+	})
+}
+
+// loadApplication fetches the application named in the path, writing the error response on failure
+func (h *ApplicationHandler) loadApplication(c *gin.Context, userID string) (*models.Application, bool) {
+	app, err := h.store.GetApplication(c.Request.Context(), userID, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrApplicationNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Application not found",
+				Code:  http.StatusNotFound,
+			})
+			return nil, false
+		}
+		applicationLog.ErrorContext(c.Request.Context(), "Failed to load application", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load application",
+			Code:  http.StatusInternalServerError,
+		})
+		return nil, false
+	}
+	return app, true
+}
+
+func isApplicationStatus(s string) bool {
+	for _, status := range models.ApplicationStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
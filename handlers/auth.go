@@ -1,33 +1,51 @@
 package handlers
 
 import (
-	"log"
+	"context"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
 	"github.com/myjobmatch/backend/models"
 	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/utils"
 )
 
+// avatarSize is the width and height in pixels of stored profile photos
+const avatarSize = 256
+
+var authLog = logging.Component("AuthHandler")
+
 // AuthHandler handles authentication requests
 type AuthHandler struct {
-	firestoreClient *storage.FirestoreClient
-	jwtService      *auth.JWTService
-	googleAuth      *auth.GoogleAuthService
+	store         storage.Store
+	jwtService    *auth.JWTService
+	googleAuth    *auth.GoogleAuthService
+	maxCVBytes    int64
+	maxPhotoBytes int64
+	uploadURLTTL  time.Duration // How long direct CV upload URLs stay valid
 }
 
 // NewAuthHandler creates a new auth handler
 func NewAuthHandler(
-	firestoreClient *storage.FirestoreClient,
+	store storage.Store,
 	jwtService *auth.JWTService,
 	googleAuth *auth.GoogleAuthService,
+	maxCVBytes int64,
+	maxPhotoBytes int64,
+	uploadURLTTL time.Duration,
 ) *AuthHandler {
 	return &AuthHandler{
-		firestoreClient: firestoreClient,
-		jwtService:      jwtService,
-		googleAuth:      googleAuth,
+		store:         store,
+		jwtService:    jwtService,
+		googleAuth:    googleAuth,
+		maxCVBytes:    maxCVBytes,
+		maxPhotoBytes: maxPhotoBytes,
+		uploadURLTTL:  uploadURLTTL,
 	}
 }
 
@@ -38,9 +56,11 @@ func NewAuthHandler(
 // @Accept json
 // @Produce json
 // @Param request body models.RegisterRequest true "Registration request"
+// @Param Idempotency-Key header string false "Key that makes a retry of this request return a new token for the account it created"
 // @Success 201 {object} models.AuthResponse "Registration successful"
 // @Failure 400 {object} models.ErrorResponse "Invalid request body"
-// @Failure 409 {object} models.ErrorResponse "User already exists"
+// @Failure 409 {object} models.ErrorResponse "User already exists, or a request with the same Idempotency-Key is running"
+// @Failure 422 {object} models.ErrorResponse "Idempotency-Key already used for a different request"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Router /auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
@@ -57,7 +77,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	// Hash password
 	hashedPassword, err := auth.HashPassword(req.Password)
 	if err != nil {
-		log.Printf("[AuthHandler] Failed to hash password: %v", err)
+		authLog.ErrorContext(c.Request.Context(), "Failed to hash password", "error", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error: "Failed to process registration",
 			Code:  http.StatusInternalServerError,
@@ -74,20 +94,74 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		CVUrl:    "",
 	}
 
-	if err := h.firestoreClient.CreateUser(c.Request.Context(), user); err != nil {
-		log.Printf("[AuthHandler] Failed to create user: %v", err)
+	if err := h.store.CreateUser(c.Request.Context(), user); err != nil {
+		authLog.ErrorContext(c.Request.Context(), "Failed to create user", "error", err)
+		if errors.Is(err, storage.ErrUserExists) {
+			c.JSON(http.StatusConflict, models.ErrorResponse{
+				Error:   "Registration failed",
+				Code:    http.StatusConflict,
+				Details: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to process registration",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	// Generate JWT token
+	token, err := h.jwtService.GenerateToken(user)
+	if err != nil {
+		authLog.ErrorContext(c.Request.Context(), "Failed to generate token", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to generate token",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	authLog.InfoContext(c.Request.Context(), "User registered", "email", user.Email)
+	c.JSON(http.StatusCreated, models.AuthResponse{
+		Token:   token,
+		User:    user,
+		Message: "Registration successful",
+	})
+}
+
+// ReissueRegistration answers a registration retried with the Idempotency-Key of a successful one. The
+// first response's token isn't stored, so a new one is issued for the account it created, provided the
+// retry still holds that account's password.
+func (h *AuthHandler) ReissueRegistration(c *gin.Context) {
+	var req models.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	user, err := h.store.GetUserByEmail(c.Request.Context(), req.Email)
+	if err != nil || user.Provider != "email" || !auth.CheckPassword(req.Password, user.Password) {
+		// The account was since deleted or its password changed: the retry can't stand for it anymore
 		c.JSON(http.StatusConflict, models.ErrorResponse{
 			Error:   "Registration failed",
 			Code:    http.StatusConflict,
-			Details: err.Error(),
+			Details: "Idempotency-Key belongs to a registration whose account has changed; log in instead",
 		})
 		return
 	}
+	if user.Suspended {
+		respondAccountSuspended(c)
+		return
+	}
 
-	// Generate JWT token
 	token, err := h.jwtService.GenerateToken(user)
 	if err != nil {
-		log.Printf("[AuthHandler] Failed to generate token: %v", err)
+		authLog.ErrorContext(c.Request.Context(), "Failed to generate token", "error", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error: "Failed to generate token",
 			Code:  http.StatusInternalServerError,
@@ -95,7 +169,6 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	log.Printf("[AuthHandler] User registered: %s", user.Email)
 	c.JSON(http.StatusCreated, models.AuthResponse{
 		Token:   token,
 		User:    user,
@@ -113,6 +186,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Success 200 {object} models.AuthResponse "Login successful"
 // @Failure 400 {object} models.ErrorResponse "Invalid request body"
 // @Failure 401 {object} models.ErrorResponse "Invalid credentials"
+// @Failure 403 {object} models.ErrorResponse "Account suspended"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -127,7 +201,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	// Get user by email
-	user, err := h.firestoreClient.GetUserByEmail(c.Request.Context(), req.Email)
+	user, err := h.store.GetUserByEmail(c.Request.Context(), req.Email)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
 			Error: "Invalid email or password",
@@ -153,11 +227,15 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		})
 		return
 	}
+	if user.Suspended {
+		respondAccountSuspended(c)
+		return
+	}
 
 	// Generate JWT token
 	token, err := h.jwtService.GenerateToken(user)
 	if err != nil {
-		log.Printf("[AuthHandler] Failed to generate token: %v", err)
+		authLog.ErrorContext(c.Request.Context(), "Failed to generate token", "error", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error: "Failed to generate token",
 			Code:  http.StatusInternalServerError,
@@ -165,7 +243,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	log.Printf("[AuthHandler] User logged in: %s", user.Email)
+	authLog.InfoContext(c.Request.Context(), "User logged in", "email", user.Email)
 	c.JSON(http.StatusOK, models.AuthResponse{
 		Token:   token,
 		User:    user,
@@ -183,6 +261,8 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Success 200 {object} models.AuthResponse "Login successful"
 // @Failure 400 {object} models.ErrorResponse "Invalid request body"
 // @Failure 401 {object} models.ErrorResponse "Invalid Google token"
+// @Failure 403 {object} models.ErrorResponse "Account suspended"
+// @Failure 409 {object} models.ErrorResponse "Google account is already linked to another user"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Router /auth/google [post]
 func (h *AuthHandler) GoogleLogin(c *gin.Context) {
@@ -199,7 +279,7 @@ func (h *AuthHandler) GoogleLogin(c *gin.Context) {
 	// Verify Google ID token
 	googleUser, err := h.googleAuth.VerifyIDToken(c.Request.Context(), req.IDToken)
 	if err != nil {
-		log.Printf("[AuthHandler] Failed to verify Google token: %v", err)
+		authLog.ErrorContext(c.Request.Context(), "Failed to verify Google token", "error", err)
 		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
 			Error:   "Invalid Google token",
 			Code:    http.StatusUnauthorized,
@@ -208,8 +288,11 @@ func (h *AuthHandler) GoogleLogin(c *gin.Context) {
 		return
 	}
 
-	// Check if user exists
-	user, err := h.firestoreClient.GetUserByEmail(c.Request.Context(), googleUser.Email)
+	// Check if user exists, by Google account first since the account's email may have been changed
+	user, err := h.store.GetUserByGoogleID(c.Request.Context(), googleUser.GoogleID)
+	if err != nil {
+		user, err = h.store.GetUserByEmail(c.Request.Context(), googleUser.Email)
+	}
 	if err != nil {
 		// User doesn't exist, create new user
 		user = &models.User{
@@ -221,8 +304,17 @@ func (h *AuthHandler) GoogleLogin(c *gin.Context) {
 			CVUrl:    "",
 		}
 
-		if err := h.firestoreClient.CreateUser(c.Request.Context(), user); err != nil {
-			log.Printf("[AuthHandler] Failed to create Google user: %v", err)
+		err := h.store.CreateUser(c.Request.Context(), user)
+		if errors.Is(err, storage.ErrUserExists) {
+			// Created by a concurrent login with the same account
+			user, err = h.store.GetUserByEmail(c.Request.Context(), googleUser.Email)
+		}
+		if err != nil {
+			authLog.ErrorContext(c.Request.Context(), "Failed to create Google user", "error", err)
+			if errors.Is(err, storage.ErrGoogleIDTaken) {
+				respondGoogleIDTaken(c)
+				return
+			}
 			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 				Error:   "Failed to create account",
 				Code:    http.StatusInternalServerError,
@@ -230,21 +322,26 @@ func (h *AuthHandler) GoogleLogin(c *gin.Context) {
 			})
 			return
 		}
-		log.Printf("[AuthHandler] New Google user created: %s", user.Email)
-	} else {
-		// User exists, update Google ID if not set
-		if user.GoogleID == "" {
-			h.firestoreClient.UpdateUser(c.Request.Context(), user.Email, map[string]interface{}{
-				"googleId": googleUser.GoogleID,
-				"provider": "google",
-			})
+		authLog.InfoContext(c.Request.Context(), "New Google user created", "email", user.Email)
+	} else if user.GoogleID == "" {
+		// User exists, link the Google account
+		if err := h.store.LinkGoogleID(c.Request.Context(), user.Email, googleUser.GoogleID); err != nil {
+			authLog.ErrorContext(c.Request.Context(), "Failed to link Google account", "error", err)
+			if errors.Is(err, storage.ErrGoogleIDTaken) {
+				respondGoogleIDTaken(c)
+				return
+			}
 		}
 	}
+	if user.Suspended {
+		respondAccountSuspended(c)
+		return
+	}
 
 	// Generate JWT token
 	token, err := h.jwtService.GenerateToken(user)
 	if err != nil {
-		log.Printf("[AuthHandler] Failed to generate token: %v", err)
+		authLog.ErrorContext(c.Request.Context(), "Failed to generate token", "error", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error: "Failed to generate token",
 			Code:  http.StatusInternalServerError,
@@ -252,7 +349,7 @@ func (h *AuthHandler) GoogleLogin(c *gin.Context) {
 		return
 	}
 
-	log.Printf("[AuthHandler] Google user logged in: %s", user.Email)
+	authLog.InfoContext(c.Request.Context(), "Google user logged in", "email", user.Email)
 	c.JSON(http.StatusOK, models.AuthResponse{
 		Token:   token,
 		User:    user,
@@ -280,7 +377,7 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	user, err := h.firestoreClient.GetUserByEmail(c.Request.Context(), claims.Email)
+	user, err := h.store.GetUserByID(c.Request.Context(), claims.UserID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse{
 			Error: "User not found",
@@ -296,7 +393,7 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 
 // UpdateProfile updates the current user's profile
 // @Summary Update user profile
-// @Description Update the authenticated user's profile (name)
+// @Description Update the authenticated user's profile (name, incognito search setting)
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -328,8 +425,8 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 	}
 
 	// Update profile
-	if err := h.firestoreClient.UpdateUserProfile(c.Request.Context(), claims.Email, req.Nama); err != nil {
-		log.Printf("[AuthHandler] Failed to update profile: %v", err)
+	if err := h.store.UpdateUserProfile(c.Request.Context(), claims.Email, req.Nama, req.Incognito); err != nil {
+		authLog.ErrorContext(c.Request.Context(), "Failed to update profile", "error", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error: "Failed to update profile",
 			Code:  http.StatusInternalServerError,
@@ -338,7 +435,7 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 	}
 
 	// Get updated user
-	user, err := h.firestoreClient.GetUserByEmail(c.Request.Context(), claims.Email)
+	user, err := h.store.GetUserByID(c.Request.Context(), claims.UserID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, models.ErrorResponse{
 			Error: "User not found",
@@ -347,7 +444,7 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	log.Printf("[AuthHandler] Profile updated: %s", claims.Email)
+	authLog.InfoContext(c.Request.Context(), "Profile updated")
 	c.JSON(http.StatusOK, models.ProfileResponse{
 		User:    user,
 		Message: "Profile updated successfully",
@@ -356,18 +453,20 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 
 // UploadCV uploads a CV file for the authenticated user
 // @Summary Upload CV
-// @Description Upload a CV file (PDF, DOC, DOCX) to user's profile
+// @Description Upload a CV file (PDF, DOC, DOCX, TXT) to user's profile. The file content must match its extension.
 // @Tags Auth
 // @Accept multipart/form-data
 // @Produce json
 // @Security BearerAuth
-// @Param cv_file formData file true "CV file (PDF, DOC, DOCX)"
+// @Param cv_file formData file true "CV file (PDF, DOC, DOCX, TXT)"
 // @Success 200 {object} models.CVUploadResponse "CV uploaded successfully"
 // @Failure 400 {object} models.ErrorResponse "Invalid file"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 413 {object} models.ErrorResponse "File too large"
+// @Failure 415 {object} models.ErrorResponse "Unsupported file type"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Router /auth/cv [post]
-func (h *AuthHandler) UploadCV(c *gin.Context, storageClient *storage.CloudStorageClient) {
+func (h *AuthHandler) UploadCV(c *gin.Context, storageClient storage.CVStorage) {
 	claims := auth.GetAuthClaims(c)
 	if claims == nil {
 		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
@@ -377,9 +476,14 @@ func (h *AuthHandler) UploadCV(c *gin.Context, storageClient *storage.CloudStora
 		return
 	}
 
-	// Get file from form
-	file, header, err := c.Request.FormFile("cv_file")
+	// Read and validate file from form
+	limitUploadBody(c, h.maxCVBytes)
+	data, header, err := readCVUpload(c, h.maxCVBytes)
 	if err != nil {
+		if isUploadError(err) {
+			respondUploadError(c, err, "CV", h.maxCVBytes)
+			return
+		}
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "CV file is required",
 			Code:    http.StatusBadRequest,
@@ -387,12 +491,11 @@ func (h *AuthHandler) UploadCV(c *gin.Context, storageClient *storage.CloudStora
 		})
 		return
 	}
-	defer file.Close()
 
 	// Upload to Cloud Storage
-	cvUrl, err := storageClient.UploadCV(c.Request.Context(), claims.Email, file, header)
+	cvUrl, err := storageClient.UploadCVFromBytes(c.Request.Context(), claims.UserID, data, header.Filename)
 	if err != nil {
-		log.Printf("[AuthHandler] Failed to upload CV: %v", err)
+		authLog.ErrorContext(c.Request.Context(), "Failed to upload CV", "error", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error:   "Failed to upload CV",
 			Code:    http.StatusInternalServerError,
@@ -401,9 +504,8 @@ func (h *AuthHandler) UploadCV(c *gin.Context, storageClient *storage.CloudStora
 		return
 	}
 
-	// Update user's CV URL in Firestore
-	if err := h.firestoreClient.UpdateUserCVUrl(c.Request.Context(), claims.Email, cvUrl); err != nil {
-		log.Printf("[AuthHandler] Failed to update CV URL: %v", err)
+	if err := h.linkCV(c.Request.Context(), claims, cvUrl); err != nil {
+		authLog.ErrorContext(c.Request.Context(), "Failed to update CV URL", "error", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
 			Error: "Failed to save CV reference",
 			Code:  http.StatusInternalServerError,
@@ -411,9 +513,179 @@ func (h *AuthHandler) UploadCV(c *gin.Context, storageClient *storage.CloudStora
 		return
 	}
 
-	log.Printf("[AuthHandler] CV uploaded for user: %s", claims.Email)
+	authLog.InfoContext(c.Request.Context(), "CV uploaded")
 	c.JSON(http.StatusOK, models.CVUploadResponse{
 		CVUrl:   cvUrl,
 		Message: "CV uploaded successfully",
 	})
 }
+
+// respondGoogleIDTaken writes the 409 response for a Google account linked to another user
+func respondGoogleIDTaken(c *gin.Context) {
+	c.JSON(http.StatusConflict, models.ErrorResponse{
+		Error: "Google account is already linked to another user",
+		Code:  http.StatusConflict,
+	})
+}
+
+// respondAccountSuspended writes the 403 response for a login to a suspended account
+func respondAccountSuspended(c *gin.Context) {
+	c.JSON(http.StatusForbidden, models.ErrorResponse{
+		Error: "Account suspended",
+		Code:  http.StatusForbidden,
+	})
+}
+
+// linkCV makes an uploaded CV the user's current CV
+func (h *AuthHandler) linkCV(ctx context.Context, claims *auth.Claims, cvUrl string) error {
+	if err := h.store.UpdateUserCVUrl(ctx, claims.Email, cvUrl); err != nil {
+		return err
+	}
+
+	// The structured profile was extracted from the previous CV; it is re-parsed on next use
+	if err := h.store.DeleteStructuredProfile(ctx, claims.UserID); err != nil {
+		authLog.ErrorContext(ctx, "Failed to reset structured profile", "error", err)
+	}
+	return nil
+}
+
+// UploadPhoto uploads a profile photo for the authenticated user
+// @Summary Upload profile photo
+// @Description Upload a profile photo (JPEG, PNG, GIF). The image is center-cropped and resized to a square JPEG avatar; any previous photo is replaced.
+// @Tags Auth
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param photo formData file true "Photo file (JPEG, PNG, GIF)"
+// @Success 200 {object} models.PhotoUploadResponse "Photo uploaded successfully"
+// @Failure 400 {object} models.ErrorResponse "Invalid file"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 413 {object} models.ErrorResponse "File too large"
+// @Failure 415 {object} models.ErrorResponse "Unsupported file type"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/photo [post]
+func (h *AuthHandler) UploadPhoto(c *gin.Context, storageClient storage.CVStorage) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	limitUploadBody(c, h.maxPhotoBytes)
+	file, header, err := c.Request.FormFile("photo")
+	if err != nil {
+		if isUploadError(err) {
+			respondUploadError(c, err, "Photo", h.maxPhotoBytes)
+			return
+		}
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Photo file is required",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	data, err := utils.ReadUploadedFile(file, header, h.maxPhotoBytes)
+	if err == nil {
+		data, err = utils.ResizeAvatar(data, avatarSize)
+	}
+	if err != nil {
+		respondUploadError(c, err, "Photo", h.maxPhotoBytes)
+		return
+	}
+
+	user, err := h.store.GetUserByID(c.Request.Context(), claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: "User not found",
+			Code:  http.StatusNotFound,
+		})
+		return
+	}
+
+	photoUrl, err := storageClient.UploadPhoto(c.Request.Context(), claims.UserID, data)
+	if err != nil {
+		authLog.ErrorContext(c.Request.Context(), "Failed to upload photo", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to upload photo",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if err := h.store.UpdateUserPhotoUrl(c.Request.Context(), claims.Email, photoUrl); err != nil {
+		authLog.ErrorContext(c.Request.Context(), "Failed to update photo URL", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to save photo reference",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	// Remove the replaced photo; a leftover object is harmless, so failures are only logged
+	if user.PhotoURL != "" {
+		if err := storageClient.DeletePhoto(c.Request.Context(), user.PhotoURL); err != nil {
+			authLog.WarnContext(c.Request.Context(), "Failed to delete previous photo", "error", err)
+		}
+	}
+
+	authLog.InfoContext(c.Request.Context(), "Photo uploaded")
+	c.JSON(http.StatusOK, models.PhotoUploadResponse{
+		PhotoURL: photoUrl,
+		Message:  "Photo uploaded successfully",
+	})
+}
+
+// DeletePhoto removes the authenticated user's profile photo
+// @Summary Delete profile photo
+// @Description Remove the authenticated user's profile photo
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.PhotoUploadResponse "Photo removed"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "No photo to remove"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/photo [delete]
+func (h *AuthHandler) DeletePhoto(c *gin.Context, storageClient storage.CVStorage) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	user, err := h.store.GetUserByID(c.Request.Context(), claims.UserID)
+	if err != nil || user.PhotoURL == "" {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: "No profile photo to remove",
+			Code:  http.StatusNotFound,
+		})
+		return
+	}
+
+	if err := h.store.UpdateUserPhotoUrl(c.Request.Context(), claims.Email, ""); err != nil {
+		authLog.ErrorContext(c.Request.Context(), "Failed to clear photo URL", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to remove photo",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if err := storageClient.DeletePhoto(c.Request.Context(), user.PhotoURL); err != nil {
+		authLog.WarnContext(c.Request.Context(), "Failed to delete photo object", "error", err)
+	}
+
+	authLog.InfoContext(c.Request.Context(), "Photo removed")
+	c.JSON(http.StatusOK, models.PhotoUploadResponse{
+		Message: "Photo removed successfully",
+	})
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/utils"
+)
+
+var blocklistLog = logging.Component("BlocklistHandler")
+
+// BlocklistHandler handles the per-user company blocklist
+type BlocklistHandler struct {
+	store storage.Store
+}
+
+// NewBlocklistHandler creates a new company blocklist handler
+func NewBlocklistHandler(store storage.Store) *BlocklistHandler {
+	return &BlocklistHandler{
+		store: store,
+	}
+}
+
+// GetBlockedCompanies returns the user's company blocklist
+// @Summary Get blocked companies
+// @Description Get the companies hidden from the authenticated user's searches and alerts
+// @Tags Blocklist
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.BlockedCompaniesResponse "Blocked companies"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Router /blocked-companies [get]
+func (h *BlocklistHandler) GetBlockedCompanies(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	user, err := h.store.GetUserByID(c.Request.Context(), claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: "User not found",
+			Code:  http.StatusNotFound,
+		})
+		return
+	}
+
+	companies := user.BlockedCompanies
+	if companies == nil {
+		companies = []string{}
+	}
+	c.JSON(http.StatusOK, models.BlockedCompaniesResponse{
+		Companies: companies,
+	})
+}
+
+// UpdateBlockedCompanies replaces the user's company blocklist
+// @Summary Update blocked companies
+// @Description Replace the list of companies hidden from the authenticated user's searches and alerts, e.g. a current employer. Postings from these companies are dropped before scoring. Names match ignoring case and legal suffixes such as "PT" or "Tbk".
+// @Tags Blocklist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.BlockedCompaniesRequest true "Companies to block"
+// @Success 200 {object} models.BlockedCompaniesResponse "Blocked companies updated"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /blocked-companies [put]
+func (h *BlocklistHandler) UpdateBlockedCompanies(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.BlockedCompaniesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	companies := utils.DedupeStrings(req.Companies)
+	if companies == nil {
+		companies = []string{}
+	}
+
+	if err := h.store.UpdateUserBlockedCompanies(c.Request.Context(), claims.Email, companies); err != nil {
+		blocklistLog.ErrorContext(c.Request.Context(), "Failed to update blocked companies", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to update blocked companies",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.BlockedCompaniesResponse{
+		Companies: companies,
+		Message:   "Blocked companies updated",
+	})
+}
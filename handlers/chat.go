@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/agent"
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/profile"
+	"github.com/myjobmatch/backend/storage"
+)
+
+// maxStoredChatMessages bounds a stored conversation; older messages are dropped so the document stays small
+const maxStoredChatMessages = 200
+
+var chatLog = logging.Component("ChatHandler")
+
+// ChatHandler handles career assistant conversations
+type ChatHandler struct {
+	agent           *agent.JobAgent
+	store           storage.Store
+	profiles        *profile.Service
+	historyMessages int           // Earlier messages sent to Gemini with each turn
+	sessionTTL      time.Duration // How long an idle conversation is kept
+}
+
+// NewChatHandler creates a new chat handler
+func NewChatHandler(jobAgent *agent.JobAgent, store storage.Store, profiles *profile.Service, historyMessages int, sessionTTL time.Duration) *ChatHandler {
+	return &ChatHandler{
+		agent:           jobAgent,
+		store:           store,
+		profiles:        profiles,
+		historyMessages: historyMessages,
+		sessionTTL:      sessionTTL,
+	}
+}
+
+// Chat answers a message to the career assistant
+// @Summary Chat with the career assistant
+// @Description Send a message to the career assistant. Gemini answers career questions and may read the user's saved profile and saved jobs, research companies, tailor the CV and run job searches (refining the query and filters as the conversation goes) before replying. Omit session_id to start a conversation; the reply's session_id continues it. Conversations are stored for CHAT_SESSION_TTL_DAYS after the last message, and the last CHAT_HISTORY_MESSAGES messages are sent with each turn.
+// @Tags Chat
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ChatRequest true "Message"
+// @Success 200 {object} models.ChatResponse "Assistant reply"
+// @Failure 400 {object} models.ErrorResponse "Invalid request"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Conversation not found"
+// @Failure 422 {object} models.ErrorResponse "Message blocked by the AI safety filters"
+// @Failure 429 {object} models.ErrorResponse "Gemini rate limit or quota reached; see Retry-After"
+// @Failure 503 {object} models.ErrorResponse "AI service temporarily unavailable; see Retry-After"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /chat [post]
+func (h *ChatHandler) Chat(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.ChatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	session := &models.ChatSession{UserID: claims.UserID, Messages: make([]models.ChatMessage, 0)}
+	if req.SessionID != "" {
+		stored, err := h.store.GetChatSession(ctx, claims.UserID, req.SessionID)
+		if err != nil {
+			if errors.Is(err, storage.ErrChatSessionNotFound) {
+				c.JSON(http.StatusNotFound, models.ErrorResponse{
+					Error: "Conversation not found",
+					Code:  http.StatusNotFound,
+				})
+				return
+			}
+			chatLog.ErrorContext(ctx, "Failed to load chat session", "error", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error: "Failed to load conversation",
+				Code:  http.StatusInternalServerError,
+			})
+			return
+		}
+		session = stored
+	}
+
+	input := agent.ChatInput{
+		Message: req.Message,
+		History: session.Messages[max(len(session.Messages)-h.historyMessages, 0):],
+		Budget:  req.Budget,
+	}
+	if user, err := h.store.GetUserByID(ctx, claims.UserID); err == nil {
+		input.Incognito = user.Incognito
+		input.Filters = input.Filters.WithExcludedCompanies(user.BlockedCompanies)
+	}
+	if saved, err := h.profiles.ForUser(ctx, claims.UserID); err == nil {
+		input.Profile = &saved.Profile
+	} else if !errors.Is(err, profile.ErrNoProfile) {
+		chatLog.WarnContext(ctx, "Failed to load saved profile", "error", err)
+	}
+	savedJobs, err := h.store.ListSavedJobs(ctx, claims.UserID)
+	if err != nil {
+		chatLog.WarnContext(ctx, "Failed to load saved jobs", "error", err)
+	}
+	input.SavedJobs = savedJobs
+	feedback, err := h.store.ListJobFeedback(ctx, claims.UserID)
+	if err != nil {
+		chatLog.WarnContext(ctx, "Failed to load job feedback", "error", err)
+	}
+	input.Feedback = feedback
+
+	output, err := h.agent.Chat(ctx, input)
+	if err != nil {
+		chatLog.ErrorContext(ctx, "Chat turn failed", "error", err)
+		respondAIError(c, err, "The assistant failed to reply")
+		return
+	}
+
+	now := time.Now()
+	reply := models.ChatMessage{Role: models.ChatRoleAssistant, Content: output.Reply, CreatedAt: now}
+	for _, step := range output.Steps {
+		reply.Tools = append(reply.Tools, step.Tool)
+	}
+	session.Messages = append(session.Messages,
+		models.ChatMessage{Role: models.ChatRoleUser, Content: req.Message, CreatedAt: now},
+		reply,
+	)
+	if len(session.Messages) > maxStoredChatMessages {
+		session.Messages = session.Messages[len(session.Messages)-maxStoredChatMessages:]
+	}
+	if err := h.store.SaveChatSession(ctx, session, h.sessionTTL); err != nil {
+		chatLog.ErrorContext(ctx, "Failed to save chat session", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to save conversation",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ChatResponse{
+		SessionID: session.ID,
+		Reply:     output.Reply,
+		Steps:     output.Steps,
+		Jobs:      output.Jobs,
+		LLMUsage:  &output.Usage,
+	})
+}
+
+// GetChatSession returns a stored conversation
+// @Summary Get a conversation
+// @Description Get a career assistant conversation with its messages, oldest first
+// @Tags Chat
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Session ID"
+// @Success 200 {object} models.ChatSession "Conversation"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Conversation not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /chat/{id} [get]
+func (h *ChatHandler) GetChatSession(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	session, err := h.store.GetChatSession(c.Request.Context(), claims.UserID, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrChatSessionNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Conversation not found",
+				Code:  http.StatusNotFound,
+			})
+			return
+		}
+		chatLog.ErrorContext(c.Request.Context(), "Failed to load chat session", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load conversation",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
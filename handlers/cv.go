@@ -2,26 +2,35 @@ package handlers
 
 import (
 	"bytes"
+	"errors"
 	"io"
-	"log"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/myjobmatch/backend/agent"
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/logging"
 	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/profile"
+	"github.com/myjobmatch/backend/utils"
 )
 
-// CVHandler handles CV parsing requests
+var cvLog = logging.Component("CVHandler")
+
+// CVHandler handles CV parsing and tailoring requests
 type CVHandler struct {
-	agent *agent.JobAgent
+	agent    *agent.JobAgent
+	profiles *profile.Service
 }
 
 // NewCVHandler creates a new CV handler
-func NewCVHandler(jobAgent *agent.JobAgent) *CVHandler {
+func NewCVHandler(jobAgent *agent.JobAgent, profiles *profile.Service) *CVHandler {
 	return &CVHandler{
-		agent: jobAgent,
+		agent:    jobAgent,
+		profiles: profiles,
 	}
 }
 
@@ -35,12 +44,19 @@ func NewCVHandler(jobAgent *agent.JobAgent) *CVHandler {
 // @Param request body models.CVParseRequest false "CV parse request (JSON)"
 // @Param cv_file formData file false "CV file to parse"
 // @Param cv_text formData string false "CV text content"
+// @Param model formData string false "Gemini model for parsing, from GEMINI_ALLOWED_MODELS (default GEMINI_MODEL)"
 // @Success 200 {object} models.CVParseResponse "Parsed CV profile"
 // @Failure 400 {object} models.ErrorResponse "Invalid request"
+// @Failure 422 {object} models.ErrorResponse "CV blocked by the AI safety filters"
+// @Failure 429 {object} models.ErrorResponse "Gemini rate limit or quota reached; see Retry-After"
+// @Failure 503 {object} models.ErrorResponse "AI service temporarily unavailable; see Retry-After"
 // @Failure 500 {object} models.ErrorResponse "Parsing failed"
 // @Router /parse-cv [post]
 func (h *CVHandler) ParseCV(c *gin.Context) {
 	var cvText string
+	var cvFileData []byte
+	var cvFileName string
+	var model string
 
 	contentType := c.ContentType()
 
@@ -61,9 +77,20 @@ func (h *CVHandler) ParseCV(c *gin.Context) {
 				})
 				return
 			}
-			cvText = buf.String()
-			log.Printf("[CVHandler] Received CV file: %s", header.Filename)
+			if err := utils.ValidateCVContent(header.Filename, buf.Bytes()); err != nil {
+				c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Error:   "Invalid CV file",
+					Code:    http.StatusBadRequest,
+					Details: err.Error(),
+				})
+				return
+			}
+			// PDFs are parsed by Gemini directly; Word and text files are converted to text by the agent
+			cvFileData = buf.Bytes()
+			cvFileName = header.Filename
+			cvLog.InfoContext(c.Request.Context(), "Received CV file", "file", header.Filename)
 		}
+		model = c.PostForm("model")
 	} else {
 		// Handle JSON request
 		var req models.CVParseRequest
@@ -75,56 +102,111 @@ func (h *CVHandler) ParseCV(c *gin.Context) {
 			return
 		}
 		cvText = req.CVText
+		model = req.Model
+	}
+
+	parsed, err := h.profiles.Parse(c.Request.Context(), cvText, cvFileData, cvFileName, model)
+	if err != nil {
+		switch {
+		case errors.Is(err, gemini.ErrModelNotAllowed):
+			respondModelError(c, err)
+		case errors.Is(err, profile.ErrNoCV):
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error: "CV text or file is required",
+				Code:  http.StatusBadRequest,
+			})
+		default:
+			cvLog.ErrorContext(c.Request.Context(), "Failed to parse CV", "error", err)
+			respondAIError(c, err, "CV parsing failed")
+		}
+		return
 	}
 
-	if cvText == "" {
+	c.JSON(http.StatusOK, models.CVParseResponse{
+		Profile: *parsed,
+	})
+}
+
+// TailorCV rewrites the CV summary and skills section for a job posting
+// @Summary Tailor CV to a job
+// @Description Rewrite the professional summary and reorder skills to fit a job posting, and list experience highlights and missing skills. Uses cv_text when provided, otherwise the authenticated user's saved profile.
+// @Tags CV
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.TailorCVRequest true "Job posting and optional CV text"
+// @Success 200 {object} models.TailorCVResponse "Tailored CV sections"
+// @Failure 400 {object} models.ErrorResponse "Invalid request"
+// @Failure 422 {object} models.ErrorResponse "CV or posting blocked by the AI safety filters"
+// @Failure 429 {object} models.ErrorResponse "Gemini rate limit or quota reached; see Retry-After"
+// @Failure 503 {object} models.ErrorResponse "AI service temporarily unavailable; see Retry-After"
+// @Failure 500 {object} models.ErrorResponse "Tailoring failed"
+// @Router /cv/tailor [post]
+func (h *CVHandler) TailorCV(c *gin.Context) {
+	var req models.TailorCVRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error: "CV text or file is required",
-			Code:  http.StatusBadRequest,
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
 		})
 		return
 	}
 
-	// Parse CV using the agent
-	input := agent.SearchJobsInput{
-		CVText: cvText,
+	if req.Job.Title == "" && req.Job.Description == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "Job title or description is required",
+			Code:  http.StatusBadRequest,
+		})
+		return
 	}
 
-	// We only need the profile parsing part
-	output, err := h.agent.SearchJobs(c.Request.Context(), agent.SearchJobsInput{
-		CVText: cvText,
-		Query:  "any job", // Minimal query to trigger profile building
-	})
-	if err != nil {
-		log.Printf("[CVHandler] ParseCV error: %v", err)
-
-		// Try to return partial result if we have a profile
-		if output != nil && output.Profile != nil {
-			c.JSON(http.StatusOK, models.CVParseResponse{
-				Profile: *output.Profile,
+	var userProfile *models.UserProfile
+	claims := auth.GetAuthClaims(c)
+	if req.CVText != "" {
+		parsed, err := h.agent.BuildProfile(c.Request.Context(), agent.SearchJobsInput{CVText: req.CVText})
+		if err != nil {
+			cvLog.ErrorContext(c.Request.Context(), "Failed to parse CV for tailoring", "error", err)
+			respondAIError(c, err, "CV parsing failed")
+			return
+		}
+		userProfile = parsed
+	} else if claims != nil {
+		saved, err := h.profiles.ForUser(c.Request.Context(), claims.UserID)
+		if err != nil {
+			if errors.Is(err, profile.ErrNoProfile) {
+				c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Error: "Please provide CV text or upload your CV in your profile",
+					Code:  http.StatusBadRequest,
+				})
+				return
+			}
+			cvLog.ErrorContext(c.Request.Context(), "Failed to load saved profile", "error", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error: "Failed to load profile",
+				Code:  http.StatusInternalServerError,
 			})
 			return
 		}
-
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "CV parsing failed",
-			Code:    http.StatusInternalServerError,
-			Details: err.Error(),
+		userProfile = &saved.Profile
+	} else {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "CV text is required",
+			Code:  http.StatusBadRequest,
 		})
 		return
 	}
 
-	_ = input // Suppress unused variable
-
-	if output.Profile == nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error: "Failed to extract profile from CV",
-			Code:  http.StatusInternalServerError,
-		})
+	tailored, err := h.agent.TailorCV(c.Request.Context(), userProfile, &req.Job)
+	if err != nil {
+		cvLog.ErrorContext(c.Request.Context(), "Failed to tailor CV", "error", err)
+		respondAIError(c, err, "CV tailoring failed")
 		return
 	}
 
-	c.JSON(http.StatusOK, models.CVParseResponse{
-		Profile: *output.Profile,
+	cvLog.InfoContext(c.Request.Context(), "Tailored CV", "job", req.Job.Title, "company", req.Job.Company)
+	c.JSON(http.StatusOK, models.TailorCVResponse{
+		Tailored: *tailored,
+		Profile:  userProfile,
 	})
 }
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// CreateCVUploadURL issues a signed URL for uploading a CV straight to the bucket
+// @Summary Get a direct CV upload URL
+// @Description Get a signed URL that uploads a CV (PDF, DOC, DOCX, TXT) straight from the browser to Cloud Storage, so large files don't pass through the API. POST to uploadUrl with the returned headers and an empty body to start a resumable upload, PUT the file to the session URL in the response's Location header, then call POST /auth/cv/confirm with cvUrl. The bucket rejects files larger than maxBytes. Only available with the gcs CV storage driver.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CVUploadURLRequest true "CV file name"
+// @Success 200 {object} models.CVUploadURLResponse "Upload URL"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 415 {object} models.ErrorResponse "Unsupported file type"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Failure 501 {object} models.ErrorResponse "Direct uploads not supported by the CV storage"
+// @Router /auth/cv/upload-url [post]
+func (h *AuthHandler) CreateCVUploadURL(c *gin.Context, storageClient storage.CVStorage) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	uploader, ok := storageClient.(storage.DirectCVUploader)
+	if !ok {
+		respondDirectUploadUnsupported(c)
+		return
+	}
+
+	var req models.CVUploadURLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+	if err := utils.ValidateCVExtension(req.Filename); err != nil {
+		respondUploadError(c, err, "CV", h.maxCVBytes)
+		return
+	}
+
+	target, err := uploader.CreateCVUploadURL(c.Request.Context(), claims.UserID, req.Filename, h.maxCVBytes, h.uploadURLTTL)
+	if err != nil {
+		authLog.ErrorContext(c.Request.Context(), "Failed to create CV upload URL", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to create upload URL",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.CVUploadURLResponse{
+		UploadURL: target.UploadURL,
+		Headers:   target.Headers,
+		CVUrl:     target.CVUrl,
+		MaxBytes:  h.maxCVBytes,
+		ExpiresAt: target.ExpiresAt,
+	})
+}
+
+// ConfirmCVUpload validates a CV uploaded with a direct upload URL and makes it the user's CV
+// @Summary Confirm a direct CV upload
+// @Description Check a CV uploaded through POST /auth/cv/upload-url and save it to the user's profile, like POST /auth/cv. Files that are too large or whose content doesn't match their extension are deleted and rejected.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CVUploadConfirmRequest true "Uploaded CV"
+// @Success 200 {object} models.CVUploadResponse "CV uploaded successfully"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Uploaded CV not found"
+// @Failure 413 {object} models.ErrorResponse "File too large"
+// @Failure 415 {object} models.ErrorResponse "Unsupported file type"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Failure 501 {object} models.ErrorResponse "Direct uploads not supported by the CV storage"
+// @Router /auth/cv/confirm [post]
+func (h *AuthHandler) ConfirmCVUpload(c *gin.Context, storageClient storage.CVStorage) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	uploader, ok := storageClient.(storage.DirectCVUploader)
+	if !ok {
+		respondDirectUploadUnsupported(c)
+		return
+	}
+
+	var req models.CVUploadConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	size, err := uploader.CVSize(ctx, claims.UserID, req.CVUrl)
+	if err != nil {
+		if errors.Is(err, storage.ErrCVNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Uploaded CV not found",
+				Code:  http.StatusNotFound,
+			})
+			return
+		}
+		authLog.ErrorContext(ctx, "Failed to check uploaded CV", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to check uploaded CV",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	// The bucket enforces the size limit, but the content can only be checked once uploaded
+	validationErr := fmt.Errorf("%w: %d bytes exceeds the %d byte limit", utils.ErrFileTooLarge, size, h.maxCVBytes)
+	if size <= h.maxCVBytes {
+		data, err := storageClient.DownloadCV(ctx, req.CVUrl)
+		if err != nil {
+			authLog.ErrorContext(ctx, "Failed to read uploaded CV", "error", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error: "Failed to check uploaded CV",
+				Code:  http.StatusInternalServerError,
+			})
+			return
+		}
+		validationErr = utils.ValidateCVContent(path.Base(req.CVUrl), data)
+	}
+	if validationErr != nil {
+		if err := storageClient.DeleteCV(ctx, req.CVUrl); err != nil {
+			authLog.ErrorContext(ctx, "Failed to delete rejected CV", "error", err)
+		}
+		respondUploadError(c, validationErr, "CV", h.maxCVBytes)
+		return
+	}
+
+	if err := h.linkCV(ctx, claims, req.CVUrl); err != nil {
+		authLog.ErrorContext(ctx, "Failed to update CV URL", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to save CV reference",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	authLog.InfoContext(ctx, "Direct CV upload confirmed")
+	c.JSON(http.StatusOK, models.CVUploadResponse{
+		CVUrl:   req.CVUrl,
+		Message: "CV uploaded successfully",
+	})
+}
+
+// respondDirectUploadUnsupported writes the 501 response for CV storage without direct uploads
+func respondDirectUploadUnsupported(c *gin.Context) {
+	c.JSON(http.StatusNotImplemented, models.ErrorResponse{
+		Error:   "Direct CV uploads are not supported",
+		Code:    http.StatusNotImplemented,
+		Details: "Upload the file to POST /api/auth/cv instead",
+	})
+}
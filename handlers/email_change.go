@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/notify"
+	"github.com/myjobmatch/backend/storage"
+)
+
+var emailChangeLog = logging.Component("EmailChangeHandler")
+
+// EmailChangeHandler handles changing an account's email address
+type EmailChangeHandler struct {
+	store       storage.Store
+	jwtService  *auth.JWTService
+	suspensions *auth.SuspensionChecker // Forgets the user so sessions from before the change are refused right away
+	notifier    notify.Notifier
+	tokenTTL    time.Duration
+	confirmURL  string // Frontend page the verification email links to; empty sends the bare token
+}
+
+// NewEmailChangeHandler creates a new email change handler
+func NewEmailChangeHandler(store storage.Store, jwtService *auth.JWTService, suspensions *auth.SuspensionChecker,
+	notifier notify.Notifier, tokenTTL time.Duration, confirmURL string) *EmailChangeHandler {
+	return &EmailChangeHandler{
+		store:       store,
+		jwtService:  jwtService,
+		suspensions: suspensions,
+		notifier:    notifier,
+		tokenTTL:    tokenTTL,
+		confirmURL:  confirmURL,
+	}
+}
+
+// RequestEmailChange emails a verification token to the new address
+// @Summary Request an email change
+// @Description Start changing the authenticated user's email. A verification token is emailed to the new address (as a link to EMAIL_CHANGE_CONFIRM_URL when set) and the change takes effect once it is confirmed with POST /auth/email/confirm. Accounts with a password must confirm it.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ChangeEmailRequest true "New email"
+// @Success 202 {object} models.ChangeEmailResponse "Verification email sent"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized or wrong password"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 409 {object} models.ErrorResponse "Email already in use"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/email [post]
+func (h *EmailChangeHandler) RequestEmailChange(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+	if req.NewEmail == claims.Email {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "New email is the same as the current one",
+			Code:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	user, err := h.store.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: "User not found",
+			Code:  http.StatusNotFound,
+		})
+		return
+	}
+	if user.Password != "" && !auth.CheckPassword(req.Password, user.Password) {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Invalid password",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+	if _, err := h.store.GetUserByEmail(ctx, req.NewEmail); err == nil {
+		respondEmailTaken(c)
+		return
+	}
+
+	token, tokenHash, err := auth.GenerateVerificationToken()
+	if err != nil {
+		emailChangeLog.ErrorContext(ctx, "Failed to generate token", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to start email change",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	change := &models.EmailChange{
+		UserID:    claims.UserID,
+		NewEmail:  req.NewEmail,
+		ExpiresAt: time.Now().Add(h.tokenTTL),
+	}
+	if err := h.store.CreateEmailChange(ctx, tokenHash, change); err != nil {
+		emailChangeLog.ErrorContext(ctx, "Failed to save email change", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to start email change",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if err := h.notifier.Notify(ctx, req.NewEmail, "Confirm your new MyJobMatch email", h.verificationBody(token)); err != nil {
+		emailChangeLog.ErrorContext(ctx, "Failed to send verification email", "error", err)
+		if err := h.store.DeleteEmailChange(ctx, tokenHash); err != nil {
+			emailChangeLog.WarnContext(ctx, "Failed to delete email change", "error", err)
+		}
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to send verification email",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, models.ChangeEmailResponse{
+		Message:   "Verification email sent",
+		ExpiresAt: change.ExpiresAt,
+	})
+}
+
+// ConfirmEmailChange changes the user's email once the verification token is confirmed
+// @Summary Confirm an email change
+// @Description Complete an email change with the token from the verification email. The account moves to the new email and keeps its saved profile and everything it owns; a new token is returned, and sessions signed in before the change must sign in again.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ConfirmEmailChangeRequest true "Verification token"
+// @Success 200 {object} models.AuthResponse "Email changed"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Invalid or expired token"
+// @Failure 409 {object} models.ErrorResponse "Email already in use"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/email/confirm [post]
+func (h *EmailChangeHandler) ConfirmEmailChange(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.ConfirmEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tokenHash := auth.HashVerificationToken(req.Token)
+	change, err := h.store.GetEmailChange(ctx, tokenHash)
+	if err == nil && change.UserID != claims.UserID {
+		err = storage.ErrEmailChangeNotFound
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrEmailChangeNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Invalid or expired token",
+				Code:  http.StatusNotFound,
+			})
+			return
+		}
+		emailChangeLog.ErrorContext(ctx, "Failed to get email change", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to change email",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	user, err := h.store.GetUserByID(ctx, change.UserID)
+	if err != nil {
+		emailChangeLog.ErrorContext(ctx, "Failed to get user", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to change email",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+	oldEmail := user.Email
+	if err := h.store.ChangeUserEmail(ctx, oldEmail, change.NewEmail); err != nil {
+		emailChangeLog.ErrorContext(ctx, "Failed to change email", "error", err)
+		if errors.Is(err, storage.ErrUserExists) {
+			respondEmailTaken(c)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to change email",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if h.suspensions != nil {
+		h.suspensions.Forget(ctx, user.ID)
+	}
+	if err := h.store.DeleteEmailChange(ctx, tokenHash); err != nil {
+		emailChangeLog.WarnContext(ctx, "Failed to delete email change", "error", err)
+	}
+
+	// Let the previous address know, in case the change wasn't made by its owner
+	if err := h.notifier.Notify(ctx, oldEmail, "Your MyJobMatch email was changed",
+		fmt.Sprintf("The email of your MyJobMatch account was changed to %s. If you didn't make this change, contact support.", change.NewEmail)); err != nil {
+		emailChangeLog.WarnContext(ctx, "Failed to notify previous email", "error", err)
+	}
+
+	// Users still keyed by email were moved to an ID by the change
+	user, err = h.store.GetUserByEmail(ctx, change.NewEmail)
+	if err != nil {
+		emailChangeLog.ErrorContext(ctx, "Failed to get user", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to change email",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+	token, err := h.jwtService.GenerateToken(user)
+	if err != nil {
+		emailChangeLog.ErrorContext(ctx, "Failed to generate token", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to generate token",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	emailChangeLog.InfoContext(ctx, "Email changed", "user_id", user.ID)
+	c.JSON(http.StatusOK, models.AuthResponse{
+		Token:   token,
+		User:    user,
+		Message: "Email changed",
+	})
+}
+
+// verificationBody writes the verification email, linking to the confirm page when one is configured
+func (h *EmailChangeHandler) verificationBody(token string) string {
+	expires := fmt.Sprintf("It expires in %d minutes.", int(h.tokenTTL.Minutes()))
+	if h.confirmURL == "" {
+		return fmt.Sprintf("Use this code to confirm your new MyJobMatch email:\n\n%s\n\n%s If you didn't ask to change your email, ignore this message.", token, expires)
+	}
+	return fmt.Sprintf("Open this link to confirm your new MyJobMatch email:\n\n%s?token=%s\n\n%s If you didn't ask to change your email, ignore this message.",
+		h.confirmURL, url.QueryEscape(token), expires)
+}
+
+// respondEmailTaken writes the 409 response for an email that belongs to another user
+func respondEmailTaken(c *gin.Context) {
+	c.JSON(http.StatusConflict, models.ErrorResponse{
+		Error: "Email already in use",
+		Code:  http.StatusConflict,
+	})
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// Dependency statuses and overall health reported by the health endpoints
+const (
+	dependencyUp   = "up"
+	dependencyDown = "down"
+
+	healthHealthy   = "healthy"   // Every dependency is up
+	healthDegraded  = "degraded"  // A non-critical dependency (LLM, PSE, Redis) is down
+	healthUnhealthy = "unhealthy" // A critical dependency (database, CV storage) is down
+)
+
+// Dependency is a service the API relies on, probed by the health endpoints
+type Dependency struct {
+	Name     string
+	Critical bool          // Readiness fails while a critical dependency is down
+	CacheTTL time.Duration // How long a probe result is reused, so health checks don't load the dependency
+	Probe    func(ctx context.Context) error
+}
+
+// dependencyCheck holds the latest probe of a dependency. Its lock is held while probing, so concurrent
+// health checks wait for one probe instead of each starting their own.
+type dependencyCheck struct {
+	Dependency
+
+	mu     sync.Mutex
+	status models.DependencyStatus
+}
+
+// HealthHandler serves the liveness and readiness endpoints
+type HealthHandler struct {
+	checks  []*dependencyCheck
+	timeout time.Duration
+}
+
+// NewHealthHandler creates a health handler probing deps, giving each probe up to timeout
+func NewHealthHandler(deps []Dependency, timeout time.Duration) *HealthHandler {
+	checks := make([]*dependencyCheck, len(deps))
+	for i, dep := range deps {
+		checks[i] = &dependencyCheck{Dependency: dep}
+	}
+	return &HealthHandler{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+// HealthCheck reports the status of the server and each dependency
+// @Summary Health check
+// @Description Report the server's health and the latest probe of each dependency (database, CV storage, LLM provider, PSE, Redis). Probe results are cached briefly. Always 200; status is degraded when a non-critical dependency is down and unhealthy when a critical one is.
+// @Tags System
+// @Produce json
+// @Success 200 {object} models.HealthResponse "Server and dependency health"
+// @Router /health [get]
+func (h *HealthHandler) HealthCheck(c *gin.Context) {
+	c.JSON(http.StatusOK, h.report(c.Request.Context()))
+}
+
+// Live reports that the process is serving requests, without probing dependencies
+// @Summary Liveness probe
+// @Description Check that the server is running. Dependencies are not probed, so an outage elsewhere doesn't get instances restarted.
+// @Tags System
+// @Produce json
+// @Success 200 {object} models.HealthResponse "Server is running"
+// @Router /health/live [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, models.HealthResponse{
+		Status:    healthHealthy,
+		Version:   "1.0.0",
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// Ready reports whether the server can serve traffic, failing while a critical dependency is down
+// @Summary Readiness probe
+// @Description Probe the dependencies (results are cached briefly) and fail with 503 while a critical dependency (database, CV storage) is down. A non-critical outage (LLM provider, PSE, Redis) is reported as degraded with 200.
+// @Tags System
+// @Produce json
+// @Success 200 {object} models.HealthResponse "Ready to serve traffic"
+// @Failure 503 {object} models.HealthResponse "A critical dependency is down"
+// @Router /health/ready [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.report(c.Request.Context())
+	if report.Status == healthUnhealthy {
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// report probes the dependencies in parallel and summarizes their status
+func (h *HealthHandler) report(ctx context.Context) models.HealthResponse {
+	statuses := make([]models.DependencyStatus, len(h.checks))
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func(i int, check *dependencyCheck) {
+			defer wg.Done()
+			statuses[i] = check.run(ctx, h.timeout)
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := models.HealthResponse{
+		Status:       healthHealthy,
+		Version:      "1.0.0",
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+		Dependencies: make(map[string]models.DependencyStatus, len(h.checks)),
+	}
+	for i, check := range h.checks {
+		status := statuses[i]
+		report.Dependencies[check.Name] = status
+		if status.Status == dependencyUp {
+			continue
+		}
+		if status.Critical {
+			report.Status = healthUnhealthy
+		} else if report.Status == healthHealthy {
+			report.Status = healthDegraded
+		}
+	}
+	return report
+}
+
+// run returns the cached probe result, probing again once it is older than the dependency's cache TTL
+func (d *dependencyCheck) run(ctx context.Context, timeout time.Duration) models.DependencyStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.status.CheckedAt.IsZero() && time.Since(d.status.CheckedAt) < d.CacheTTL {
+		return d.status
+	}
+
+	// The probe outlives a cancelled health check request, so its result can still be cached
+	probeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	started := time.Now()
+	err := d.Probe(probeCtx)
+	d.status = models.DependencyStatus{
+		Status:    dependencyUp,
+		Critical:  d.Critical,
+		LatencyMs: time.Since(started).Milliseconds(),
+		CheckedAt: started,
+	}
+	if err != nil {
+		d.status.Status = dependencyDown
+		d.status.Error = err.Error()
+	}
+	return d.status
+}
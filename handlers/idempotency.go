@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+)
+
+// IdempotencyKeyHeader lets clients retry a request safely: a retry with the same key gets the first
+// request's response instead of running it again
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set on responses replayed from an earlier request with the same key
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// idempotencyLockTimeout is how long a running request holds its key, so a key whose request died with
+// its instance can be used again
+const idempotencyLockTimeout = 15 * time.Minute
+
+// anonymousIdempotencyScope prefixes the scope of requests made without signing in, which is per client IP
+const anonymousIdempotencyScope = "anonymous:"
+
+// validIdempotencyKey limits keys to a safe length and character set (UUIDs and the like)
+var validIdempotencyKey = regexp.MustCompile(`^[A-Za-z0-9._:\-]{1,255}$`)
+
+var idempotencyLog = logging.Component("Idempotency")
+
+// IdempotencyMiddleware replays the stored response of a request retried with the same Idempotency-Key
+// header, for up to ttl, so a duplicated request doesn't search twice. Keys are scoped to the signed-in
+// user, so it runs after the auth middleware, or to the client IP for anonymous requests. A key reused
+// for a different request is refused, as is a retry while the first request is still running. Server
+// errors and rate limit responses aren't stored, so those requests can be retried. Requests without the
+// header, and all requests when ttl is 0, run as usual. maxFileBytes caps the body, like the upload
+// handlers.
+//
+// Responses are stored as they are, so it must not be used on routes whose responses carry credentials;
+// see CredentialIdempotencyMiddleware. Request bodies are only kept as an HMAC keyed by fingerprintKey.
+func IdempotencyMiddleware(store storage.Store, ttl time.Duration, maxFileBytes int64, fingerprintKey []byte) gin.HandlerFunc {
+	return idempotencyMiddleware(store, ttl, maxFileBytes, fingerprintKey, nil)
+}
+
+// CredentialIdempotencyMiddleware is IdempotencyMiddleware for routes whose successful responses carry
+// credentials, such as registration. A successful response is stored only as a marker, and a retry of
+// it runs reissue, which answers with fresh credentials for what the first request created. Error
+// responses are stored and replayed as usual.
+func CredentialIdempotencyMiddleware(store storage.Store, ttl time.Duration, fingerprintKey []byte, reissue gin.HandlerFunc) gin.HandlerFunc {
+	// Credential routes take no file uploads, so bodies get only the upload limit's overhead allowance
+	return idempotencyMiddleware(store, ttl, 0, fingerprintKey, reissue)
+}
+
+// idempotencyMiddleware implements both middlewares; reissue is nil when responses are stored as they are
+func idempotencyMiddleware(store storage.Store, ttl time.Duration, maxFileBytes int64, fingerprintKey []byte, reissue gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || ttl <= 0 {
+			c.Next()
+			return
+		}
+		if !validIdempotencyKey.MatchString(key) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid Idempotency-Key header",
+				Code:    http.StatusBadRequest,
+				Details: "Keys are 1-255 letters, digits and . _ : - characters",
+			})
+			c.Abort()
+			return
+		}
+
+		// The body is hashed, then handed to the handler as if unread
+		limitUploadBody(c, maxFileBytes)
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			if isUploadError(err) {
+				respondUploadError(c, err, "CV", maxFileBytes)
+			} else {
+				c.JSON(http.StatusBadRequest, models.ErrorResponse{
+					Error:   "Failed to read request body",
+					Code:    http.StatusBadRequest,
+					Details: err.Error(),
+				})
+			}
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := anonymousIdempotencyScope + c.ClientIP()
+		if claims := auth.GetAuthClaims(c); claims != nil {
+			scope = claims.UserID
+		}
+		record := &models.IdempotencyRecord{
+			Scope:       scope,
+			Key:         key,
+			Fingerprint: requestFingerprint(c, body, fingerprintKey),
+			ExpiresAt:   time.Now().Add(idempotencyLockTimeout),
+		}
+
+		// Storage writes outlive the request, so a client hanging up doesn't leave its key reserved
+		ctx := context.WithoutCancel(c.Request.Context())
+		existing, err := store.ReserveIdempotencyKey(ctx, record)
+		if err != nil {
+			// Running the request unprotected beats refusing it while the database is unavailable
+			idempotencyLog.ErrorContext(ctx, "Failed to reserve idempotency key", "error", err)
+			c.Next()
+			return
+		}
+		if existing != nil {
+			replayIdempotentResponse(c, existing, record.Fingerprint, reissue)
+			return
+		}
+
+		recorder := &idempotencyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := c.Writer.Status()
+		if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+			if err := store.ReleaseIdempotencyKey(ctx, scope, key); err != nil {
+				idempotencyLog.ErrorContext(ctx, "Failed to release idempotency key", "error", err)
+			}
+			return
+		}
+
+		record.Status = status
+		if reissue == nil || status >= http.StatusMultipleChoices {
+			record.ContentType = c.Writer.Header().Get("Content-Type")
+			record.Body = recorder.body.Bytes()
+		}
+		record.ExpiresAt = time.Now().Add(ttl)
+		if err := store.CompleteIdempotencyKey(ctx, record); err != nil {
+			idempotencyLog.ErrorContext(ctx, "Failed to store idempotent response", "error", err)
+			// Without its response the key would refuse retries until the reservation lapses
+			if err := store.ReleaseIdempotencyKey(ctx, scope, key); err != nil {
+				idempotencyLog.ErrorContext(ctx, "Failed to release idempotency key", "error", err)
+			}
+		}
+	}
+}
+
+// replayIdempotentResponse answers a request whose key is already taken: with the stored response (or
+// reissue, for a successful request whose response carried credentials) if the key's request completed
+// and matches, else with a conflict
+func replayIdempotentResponse(c *gin.Context, existing *models.IdempotencyRecord, fingerprint string, reissue gin.HandlerFunc) {
+	defer c.Abort()
+
+	if existing.Fingerprint != fingerprint {
+		c.JSON(http.StatusUnprocessableEntity, models.ErrorResponse{
+			Error:   "Idempotency-Key was already used for a different request",
+			Code:    http.StatusUnprocessableEntity,
+			Details: "Use a new key for each new request",
+		})
+		return
+	}
+	if !existing.Completed {
+		c.Header("Retry-After", "1")
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error: "A request with this Idempotency-Key is still running",
+			Code:  http.StatusConflict,
+		})
+		return
+	}
+
+	c.Header(IdempotentReplayedHeader, "true")
+	if reissue != nil && existing.Status < http.StatusMultipleChoices {
+		reissue(c)
+		return
+	}
+	c.Data(existing.Status, existing.ContentType, existing.Body)
+}
+
+// requestFingerprint hashes what makes a request the same request: its method, route and body, with an
+// HMAC so stored fingerprints can't be used to guess bodies. Multipart boundaries are left out, as
+// clients may pick a new one when they rebuild a retried request.
+func requestFingerprint(c *gin.Context, body []byte, key []byte) string {
+	mediaType, params, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if err == nil && strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "" {
+		body = bytes.ReplaceAll(body, []byte(params["boundary"]), nil)
+	}
+
+	hash := hmac.New(sha256.New, key)
+	hash.Write([]byte(c.Request.Method + " " + c.FullPath() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// idempotencyRecorder keeps a copy of the response body as it is written
+type idempotencyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/analytics"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+)
+
+const (
+	defaultInsightsDays = 30
+	maxInsightsDays     = 180
+	// maxInsightsJobs bounds how many stored jobs a report reads
+	maxInsightsJobs = 5000
+)
+
+var insightsLog = logging.Component("InsightsHandler")
+
+// InsightsHandler handles job market insights requests
+type InsightsHandler struct {
+	store storage.Store
+}
+
+// NewInsightsHandler creates a new insights handler
+func NewInsightsHandler(store storage.Store) *InsightsHandler {
+	return &InsightsHandler{
+		store: store,
+	}
+}
+
+// GetInsights returns job market insights aggregated from jobs seen in recent searches
+// @Summary Get job market insights
+// @Description Aggregate jobs returned by searches in the last N days into the most demanded skills, salary ranges by role and location, weekly posting volume and work type / site setting mix. Optionally narrowed by role (title keyword) and location.
+// @Tags Insights
+// @Produce json
+// @Param days query int false "Days to cover (default 30, max 180)"
+// @Param role query string false "Only jobs whose title contains this text, e.g. backend"
+// @Param location query string false "Only jobs whose location contains this text, e.g. jakarta"
+// @Success 200 {object} models.InsightsReport "Insights report"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /insights [get]
+func (h *InsightsHandler) GetInsights(c *gin.Context) {
+	days := defaultInsightsDays
+	if v, err := strconv.Atoi(c.Query("days")); err == nil && v > 0 {
+		days = min(v, maxInsightsDays)
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -days)
+
+	jobs, err := h.store.ListJobsSeenSince(c.Request.Context(), from, maxInsightsJobs)
+	if err != nil {
+		insightsLog.ErrorContext(c.Request.Context(), "Failed to load jobs", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to build insights",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	filter := analytics.Filter{
+		Role:     strings.TrimSpace(c.Query("role")),
+		Location: strings.TrimSpace(c.Query("location")),
+	}
+	c.JSON(http.StatusOK, analytics.BuildInsights(jobs, filter, from, to))
+}
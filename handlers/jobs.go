@@ -0,0 +1,382 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/agent"
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/utils"
+)
+
+const (
+	defaultSimilarJobsLimit = 10
+	maxSimilarJobsLimit     = 50
+	// similarCandidateLimit bounds how many stored jobs are compared when looking for similar jobs
+	similarCandidateLimit = 200
+
+	defaultListJobsLimit = 20
+	maxListJobsLimit     = 100
+	defaultListJobsDays  = 30
+	maxListJobsDays      = 180
+)
+
+var jobLog = logging.Component("JobHandler")
+
+// JobHandler handles stored job requests
+type JobHandler struct {
+	agent      *agent.JobAgent
+	store      storage.Store
+	summaryTTL time.Duration // How long job summaries are cached (0 disables the cache)
+}
+
+// NewJobHandler creates a new stored job handler
+func NewJobHandler(jobAgent *agent.JobAgent, store storage.Store, summaryTTL time.Duration) *JobHandler {
+	return &JobHandler{
+		agent:      jobAgent,
+		store:      store,
+		summaryTTL: summaryTTL,
+	}
+}
+
+// ListJobs returns stored jobs matching the filters
+// @Summary List stored jobs
+// @Description List jobs from the stored corpus of extracted postings seen in the last N days, most recently seen first. Company, work type, site setting and source must match exactly; location matches postings whose location contains the text. With url, only the posting stored for that URL is returned.
+// @Tags Jobs
+// @Produce json
+// @Param url query string false "Posting URL"
+// @Param company query string false "Company name"
+// @Param location query string false "Text the location contains, e.g. jakarta"
+// @Param work_type query string false "full_time, part_time, contract or internship"
+// @Param site_setting query string false "WFH, WFO or Hybrid"
+// @Param source query string false "Source, e.g. web or greenhouse"
+// @Param days query int false "Days to cover (default 30, max 180)"
+// @Param limit query int false "Maximum jobs to return (default 20, max 100)"
+// @Success 200 {object} models.JobListResponse "Stored jobs"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /jobs [get]
+func (h *JobHandler) ListJobs(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if url := strings.TrimSpace(c.Query("url")); url != "" {
+		jobs := make([]models.StoredJob, 0, 1)
+		stored, err := h.store.GetJobByURL(ctx, url)
+		switch {
+		case err == nil:
+			jobs = append(jobs, *stored)
+		case !errors.Is(err, storage.ErrJobNotFound):
+			jobLog.ErrorContext(ctx, "Failed to get job by URL", "error", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error: "Failed to list jobs",
+				Code:  http.StatusInternalServerError,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, models.JobListResponse{Jobs: jobs, Total: len(jobs)})
+		return
+	}
+
+	days := defaultListJobsDays
+	if v, err := strconv.Atoi(c.Query("days")); err == nil && v > 0 {
+		days = min(v, maxListJobsDays)
+	}
+	limit := defaultListJobsLimit
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil && v > 0 {
+		limit = min(v, maxListJobsLimit)
+	}
+
+	jobs, err := h.store.QueryJobs(ctx, models.JobQuery{
+		Company:     strings.TrimSpace(c.Query("company")),
+		Location:    strings.TrimSpace(c.Query("location")),
+		WorkType:    strings.TrimSpace(c.Query("work_type")),
+		SiteSetting: strings.TrimSpace(c.Query("site_setting")),
+		Source:      strings.TrimSpace(c.Query("source")),
+		Since:       time.Now().AddDate(0, 0, -days),
+		Limit:       limit,
+	})
+	if err != nil {
+		jobLog.ErrorContext(ctx, "Failed to query jobs", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to list jobs",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.JobListResponse{Jobs: jobs, Total: len(jobs)})
+}
+
+// GetJob returns a stored job by its stable ID
+// @Summary Get a job
+// @Description Get the full posting of a job returned by a search, by the stable ID in its "id" field. Authenticated users also get their latest match score and score breakdown for the job.
+// @Tags Jobs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Success 200 {object} models.JobDetailResponse "Job details"
+// @Failure 404 {object} models.ErrorResponse "Job not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /jobs/{id} [get]
+func (h *JobHandler) GetJob(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	stored, err := h.store.GetJob(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrJobNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Job not found",
+				Code:  http.StatusNotFound,
+			})
+			return
+		}
+		jobLog.ErrorContext(ctx, "Failed to get job", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load job",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	response := models.JobDetailResponse{StoredJob: *stored}
+
+	if claims := auth.GetAuthClaims(c); claims != nil {
+		score, err := h.store.GetJobScore(ctx, claims.UserID, stored.Job.ID)
+		switch {
+		case err == nil:
+			response.Score = score
+		case !errors.Is(err, storage.ErrJobScoreNotFound):
+			// The posting is still useful without the score
+			jobLog.WarnContext(ctx, "Failed to get job score", "error", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SubmitJobFeedback records the user's rating of a job match
+// @Summary Rate a job match
+// @Description Mark a job from search results as relevant, not_relevant or already_applied. Jobs marked not relevant or already applied are hidden from later searches, and recent ratings are used as examples when scoring new matches. Rating a job again replaces the earlier rating.
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Param request body models.JobFeedbackRequest true "Rating"
+// @Success 200 {object} models.JobFeedbackResponse "Feedback recorded"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Job not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /jobs/{id}/feedback [post]
+func (h *JobHandler) SubmitJobFeedback(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.JobFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	stored, err := h.store.GetJob(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrJobNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Job not found",
+				Code:  http.StatusNotFound,
+			})
+			return
+		}
+		jobLog.ErrorContext(ctx, "Failed to get job", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to record feedback",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	feedback := &models.JobFeedback{
+		JobID:   stored.Job.ID,
+		UserID:  claims.UserID,
+		Rating:  req.Rating,
+		Title:   stored.Job.Title,
+		Company: stored.Job.Company,
+		Tags:    stored.Job.Tags,
+	}
+	// Attribute the rating to the prompt experiment variant that scored the job for this user, if any
+	if score, err := h.store.GetJobScore(ctx, claims.UserID, stored.Job.ID); err == nil {
+		feedback.PromptVariant = score.PromptVariant
+	}
+	if err := h.store.SaveJobFeedback(ctx, feedback); err != nil {
+		jobLog.ErrorContext(ctx, "Failed to save feedback", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to record feedback",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	jobLog.InfoContext(ctx, "Job rated", "job_id", feedback.JobID, "rating", feedback.Rating)
+	c.JSON(http.StatusOK, models.JobFeedbackResponse{
+		Feedback: feedback,
+		Message:  "Feedback recorded",
+	})
+}
+
+// GetSimilarJobs returns stored jobs similar to a job
+// @Summary Get similar jobs
+// @Description Find other jobs seen in searches that share skills/tags and title keywords with a job, most similar first
+// @Tags Jobs
+// @Produce json
+// @Param id path string true "Job ID"
+// @Param limit query int false "Maximum jobs to return (default 10, max 50)"
+// @Success 200 {object} models.SimilarJobsResponse "Similar jobs"
+// @Failure 404 {object} models.ErrorResponse "Job not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /jobs/{id}/similar [get]
+func (h *JobHandler) GetSimilarJobs(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	limit := defaultSimilarJobsLimit
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil && v > 0 {
+		limit = min(v, maxSimilarJobsLimit)
+	}
+
+	stored, err := h.store.GetJob(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrJobNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Job not found",
+				Code:  http.StatusNotFound,
+			})
+			return
+		}
+		jobLog.ErrorContext(ctx, "Failed to get job", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load similar jobs",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	// Jobs sharing a tag are the likely matches; untagged jobs can only be compared by title
+	var candidates []models.StoredJob
+	if len(stored.Job.Tags) > 0 {
+		candidates, err = h.store.FindJobsByTags(ctx, stored.Job.Tags, similarCandidateLimit)
+	} else {
+		candidates, err = h.store.ListRecentJobs(ctx, similarCandidateLimit)
+	}
+	if err != nil {
+		jobLog.ErrorContext(ctx, "Failed to load candidate jobs", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load similar jobs",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	postings := make([]models.JobPosting, len(candidates))
+	for i, candidate := range candidates {
+		postings[i] = candidate.Job
+	}
+
+	c.JSON(http.StatusOK, models.SimilarJobsResponse{
+		Jobs: agent.RankSimilarJobs(stored.Job, postings, limit),
+	})
+}
+
+// SummarizeJob condenses a job posting into bullets
+// @Summary Summarize a job posting
+// @Description Condense a long posting into a one-sentence overview and short bullets (responsibilities, must-have and nice-to-have skills, red flags) for mobile display. The page is fetched unless description holds the posting's text. Summaries are cached for JOB_SUMMARY_CACHE_TTL_HOURS by URL, and by URL and text when description is given.
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Param request body models.JobSummaryRequest true "Posting to summarize"
+// @Success 200 {object} models.JobSummaryResponse "Posting summary"
+// @Failure 400 {object} models.ErrorResponse "Invalid request"
+// @Failure 422 {object} models.ErrorResponse "Posting blocked by the AI safety filters"
+// @Failure 429 {object} models.ErrorResponse "Gemini rate limit or quota reached; see Retry-After"
+// @Failure 502 {object} models.ErrorResponse "Posting page could not be fetched"
+// @Failure 503 {object} models.ErrorResponse "AI service temporarily unavailable; see Retry-After"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /jobs/summarize [post]
+func (h *JobHandler) SummarizeJob(c *gin.Context) {
+	var req models.JobSummaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	key := summaryCacheKey(&req)
+	if h.summaryTTL > 0 {
+		summary, err := h.store.GetJobSummary(ctx, key)
+		if err == nil {
+			c.JSON(http.StatusOK, models.JobSummaryResponse{URL: req.URL, Summary: *summary, Cached: true})
+			return
+		}
+		if !errors.Is(err, storage.ErrJobSummaryNotFound) {
+			jobLog.WarnContext(ctx, "Failed to read job summary cache", "error", err)
+		}
+	}
+
+	summary, err := h.agent.SummarizeJob(ctx, req.URL, req.Description)
+	if err != nil {
+		jobLog.ErrorContext(ctx, "Failed to summarize job", "error", err)
+		if errors.Is(err, agent.ErrPageUnavailable) {
+			c.JSON(http.StatusBadGateway, models.ErrorResponse{
+				Error:   "The job posting could not be fetched",
+				Code:    http.StatusBadGateway,
+				Details: err.Error(),
+			})
+			return
+		}
+		respondAIError(c, err, "Job summarization failed")
+		return
+	}
+
+	if h.summaryTTL > 0 {
+		if err := h.store.CacheJobSummary(ctx, key, summary, h.summaryTTL); err != nil {
+			jobLog.WarnContext(ctx, "Failed to cache job summary", "error", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, models.JobSummaryResponse{URL: req.URL, Summary: *summary})
+}
+
+// summaryCacheKey is the cache key of a posting's summary: its canonical URL, plus the hash of the posting
+// text when the caller supplied it, so a summary of caller-supplied text is only served for the same text
+func summaryCacheKey(req *models.JobSummaryRequest) string {
+	key := utils.CanonicalURL(req.URL)
+	if req.Description != "" {
+		sum := sha256.Sum256([]byte(req.Description))
+		key += "#" + hex.EncodeToString(sum[:])
+	}
+	return key
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/i18n"
+	"github.com/myjobmatch/backend/models"
+)
+
+// LocalizeErrorsMiddleware adds the stable error_code to error responses and translates their message
+// to the language of the Accept-Language header (English or Bahasa Indonesia), reported in
+// Content-Language. Details and field messages stay in English. Handlers keep writing English
+// messages; the error response body is held back until the handler returns so it can be rewritten.
+func LocalizeErrorsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		localizer := &errorLocalizer{ResponseWriter: c.Writer}
+		c.Writer = localizer
+		c.Next()
+
+		if !localizer.held {
+			return
+		}
+		lang := i18n.Language(c.GetHeader("Accept-Language"))
+		body, ok := localizeErrorBody(localizer.body.Bytes(), lang, localizer.Status())
+		if ok {
+			localizer.Header().Set("Content-Language", lang)
+			localizer.Header().Add("Vary", "Accept-Language")
+		}
+		localizer.ResponseWriter.Write(body)
+	}
+}
+
+// localizeErrorBody rewrites an ErrorResponse body with its error code and translated message,
+// reporting whether it did. Other bodies, such as the MCP endpoint's own error objects, are returned
+// unchanged.
+func localizeErrorBody(body []byte, lang string, status int) ([]byte, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	var resp models.ErrorResponse
+	if err := decoder.Decode(&resp); err != nil || resp.Error == "" {
+		return body, false
+	}
+
+	if resp.ErrorCode == "" {
+		resp.ErrorCode = i18n.Code(resp.Error, status)
+	}
+	resp.Error = i18n.Translate(lang, resp.Error)
+	localized, err := json.Marshal(resp)
+	if err != nil {
+		return body, false
+	}
+	return localized, true
+}
+
+// errorLocalizer holds back the body of JSON error responses, passing everything else through
+type errorLocalizer struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+	held bool
+}
+
+func (w *errorLocalizer) Write(data []byte) (int, error) {
+	if w.holds() {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *errorLocalizer) WriteString(s string) (int, error) {
+	if w.holds() {
+		return w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// holds reports whether the response being written is a JSON error, which is held back
+func (w *errorLocalizer) holds() bool {
+	if !w.held && !w.ResponseWriter.Written() {
+		w.held = w.Status() >= http.StatusBadRequest &&
+			strings.HasPrefix(w.Header().Get("Content-Type"), "application/json")
+	}
+	return w.held
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/profile"
+	"github.com/myjobmatch/backend/storage"
+)
+
+var profileLog = logging.Component("ProfileHandler")
+
+// ProfileHandler handles structured profile requests
+type ProfileHandler struct {
+	store    storage.Store
+	profiles *profile.Service
+}
+
+// NewProfileHandler creates a new structured profile handler
+func NewProfileHandler(store storage.Store, profiles *profile.Service) *ProfileHandler {
+	return &ProfileHandler{
+		store:    store,
+		profiles: profiles,
+	}
+}
+
+// GetStructuredProfile returns the user's saved structured profile
+// @Summary Get structured profile
+// @Description Get the structured profile extracted from the user's CV, including any corrections made by the user
+// @Tags Profile
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.StructuredProfileResponse "Structured profile"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "No profile or CV saved"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /profile/structured [get]
+func (h *ProfileHandler) GetStructuredProfile(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	saved, err := h.profiles.ForUser(c.Request.Context(), claims.UserID)
+	if err != nil {
+		if errors.Is(err, profile.ErrNoProfile) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "No profile found. Upload your CV first.",
+				Code:  http.StatusNotFound,
+			})
+			return
+		}
+		profileLog.ErrorContext(c.Request.Context(), "Failed to load profile", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load profile",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.StructuredProfileResponse{
+		StructuredProfile: *saved,
+	})
+}
+
+// UpdateStructuredProfile applies a partial update to the user's structured profile
+// @Summary Update structured profile
+// @Description Correct AI-extracted profile fields. Only fields present in the body are updated; list fields replace the stored list. Edited profiles are used in searches instead of re-parsing the CV.
+// @Tags Profile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.UpdateStructuredProfileRequest true "Fields to update"
+// @Success 200 {object} models.StructuredProfileResponse "Profile updated"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /profile/structured [patch]
+func (h *ProfileHandler) UpdateStructuredProfile(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.UpdateStructuredProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	saved, err := h.profiles.Update(c.Request.Context(), claims.UserID, &req)
+	if err != nil {
+		if errors.Is(err, profile.ErrInvalidProfile) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error:   "Invalid profile",
+				Code:    http.StatusBadRequest,
+				Details: err.Error(),
+			})
+			return
+		}
+		profileLog.ErrorContext(c.Request.Context(), "Failed to update profile", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to update profile",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	profileLog.InfoContext(c.Request.Context(), "Structured profile updated")
+	c.JSON(http.StatusOK, models.StructuredProfileResponse{
+		StructuredProfile: *saved,
+		Message:           "Profile updated successfully",
+	})
+}
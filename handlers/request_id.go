@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/utils"
+)
+
+// RequestIDHeader carries the request ID: accepted from the client or proxy, and always returned in the response
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits client-supplied IDs to a safe length and character set
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._\-]{1,64}$`)
+
+// RequestIDMiddleware assigns every request an ID, reusing a valid X-Request-ID header, and attaches it to
+// the request context so LLM debug records and logs can be matched to the request
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = utils.NewRequestID()
+		}
+
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(utils.WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
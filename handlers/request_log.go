@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/logging"
+)
+
+var requestLog = logging.Component("HTTP")
+
+// RequestLogMiddleware writes one structured record per request: server errors at error level, client
+// errors at warning level and the rest at info level. It runs after RequestIDMiddleware so the record
+// carries the request ID, and reads the context after the handlers so it also carries the user and tenant.
+func RequestLogMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		requestLog.Log(c.Request.Context(), level, "Request completed",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"route", c.FullPath(),
+			"status", status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"client_ip", c.ClientIP(),
+		)
+	}
+}
@@ -0,0 +1,322 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/agent"
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/profile"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// maxSavedJobsPerUser caps how many jobs a user can bookmark (bounds the cost of re-scoring)
+const maxSavedJobsPerUser = 100
+
+// maxRescoredJobs caps how many saved jobs one rescore request sends to Gemini, least recently scored first
+const maxRescoredJobs = 25
+
+// rescoreBudget caps the Gemini usage of one rescore request; jobs past it keep their previous score
+var rescoreBudget = models.LLMBudget{MaxCalls: maxRescoredJobs, MaxTokens: 300000}
+
+var savedJobLog = logging.Component("SavedJobHandler")
+
+// SavedJobHandler handles saved job requests
+type SavedJobHandler struct {
+	agent    *agent.JobAgent
+	store    storage.Store
+	profiles *profile.Service
+}
+
+// NewSavedJobHandler creates a new saved job handler
+func NewSavedJobHandler(jobAgent *agent.JobAgent, store storage.Store, profiles *profile.Service) *SavedJobHandler {
+	return &SavedJobHandler{
+		agent:    jobAgent,
+		store:    store,
+		profiles: profiles,
+	}
+}
+
+// ListSavedJobs returns the user's saved jobs
+// @Summary List saved jobs
+// @Description Get the jobs the authenticated user bookmarked, most recently saved first
+// @Tags Saved Jobs
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.SavedJobsResponse "Saved jobs"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /jobs/saved [get]
+func (h *SavedJobHandler) ListSavedJobs(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	jobs, err := h.store.ListSavedJobs(c.Request.Context(), claims.UserID)
+	if err != nil {
+		savedJobLog.ErrorContext(c.Request.Context(), "Failed to list saved jobs", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load saved jobs",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SavedJobsResponse{
+		Jobs: jobs,
+	})
+}
+
+// SaveJob bookmarks a job from search results
+// @Summary Save a job
+// @Description Bookmark a job as returned by a search, including its match score. Saving a job already saved (same URL) returns the existing entry.
+// @Tags Saved Jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.SaveJobRequest true "Job to save"
+// @Success 201 {object} models.SavedJobsResponse "Job saved"
+// @Success 200 {object} models.SavedJobsResponse "Job already saved"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body or saved job limit reached"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /jobs/saved [post]
+func (h *SavedJobHandler) SaveJob(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.SaveJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+	if strings.TrimSpace(req.Job.Title) == "" || strings.TrimSpace(req.Job.URL) == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: "job.title and job.url are required",
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	existing, err := h.store.ListSavedJobs(ctx, claims.UserID)
+	if err != nil {
+		savedJobLog.ErrorContext(ctx, "Failed to list saved jobs", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to save job",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	canonical := utils.CanonicalURL(req.Job.URL)
+	for _, saved := range existing {
+		if utils.CanonicalURL(saved.Job.URL) == canonical {
+			c.JSON(http.StatusOK, models.SavedJobsResponse{
+				Jobs:    []models.SavedJob{saved},
+				Message: "Job already saved",
+			})
+			return
+		}
+	}
+	if len(existing) >= maxSavedJobsPerUser {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Saved job limit reached",
+			Code:    http.StatusBadRequest,
+			Details: "Remove a saved job before saving another",
+		})
+		return
+	}
+
+	saved := &models.SavedJob{
+		UserID: claims.UserID,
+		Job:    req.Job,
+	}
+	if err := h.store.SaveJob(ctx, saved); err != nil {
+		savedJobLog.ErrorContext(ctx, "Failed to save job", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to save job",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SavedJobsResponse{
+		Jobs:    []models.SavedJob{*saved},
+		Message: "Job saved",
+	})
+}
+
+// DeleteSavedJob removes a saved job
+// @Summary Remove a saved job
+// @Description Remove a job from the authenticated user's saved jobs
+// @Tags Saved Jobs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Saved job ID"
+// @Success 200 {object} models.SavedJobsResponse "Saved job removed"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Saved job not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /jobs/saved/{id} [delete]
+func (h *SavedJobHandler) DeleteSavedJob(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.store.DeleteSavedJob(c.Request.Context(), claims.UserID, c.Param("id")); err != nil {
+		if errors.Is(err, storage.ErrSavedJobNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Saved job not found",
+				Code:  http.StatusNotFound,
+			})
+			return
+		}
+		savedJobLog.ErrorContext(c.Request.Context(), "Failed to delete saved job", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to remove saved job",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SavedJobsResponse{
+		Jobs:    []models.SavedJob{},
+		Message: "Saved job removed",
+	})
+}
+
+// RescoreSavedJobs re-scores the least recently scored saved jobs against the user's current profile
+// @Summary Re-score saved jobs
+// @Description Re-run match scoring for saved jobs against the current structured profile, e.g. after uploading a new CV or editing the profile. Each request re-scores up to 25 jobs, least recently scored first; call again while remaining is above 0. Jobs that fail to score keep their previous score.
+// @Tags Saved Jobs
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.RescoreSavedJobsResponse "Saved jobs re-scored"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "No profile or CV saved"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /jobs/saved/rescore [post]
+func (h *SavedJobHandler) RescoreSavedJobs(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	saved, err := h.profiles.ForUser(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, profile.ErrNoProfile) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "No profile found. Upload your CV first.",
+				Code:  http.StatusNotFound,
+			})
+			return
+		}
+		savedJobLog.ErrorContext(ctx, "Failed to load profile", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load profile",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	userProfile := &saved.Profile
+	if user, err := h.store.GetUserByID(ctx, claims.UserID); err == nil && user.Incognito {
+		userProfile = utils.RedactProfile(userProfile)
+	}
+
+	jobs, err := h.store.ListSavedJobs(ctx, claims.UserID)
+	if err != nil {
+		savedJobLog.ErrorContext(ctx, "Failed to list saved jobs", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load saved jobs",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	// Jobs scored longest ago go first, so repeated requests work through every saved job
+	order := make([]int, len(jobs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return jobs[order[a]].ScoredAt.Before(jobs[order[b]].ScoredAt)
+	})
+	if len(order) > maxRescoredJobs {
+		order = order[:maxRescoredJobs]
+	}
+
+	postings := make([]models.JobPosting, len(order))
+	for n, i := range order {
+		postings[n] = jobs[i].Job.JobPosting
+	}
+
+	// Scores come back in completion order; match them to saved jobs by URL
+	scoreCtx := gemini.WithBudget(ctx, gemini.NewBudget(rescoreBudget))
+	scores := make(map[string]models.RankedJob, len(postings))
+	for _, ranked := range h.agent.ScoreJobs(scoreCtx, userProfile, postings) {
+		scores[ranked.URL] = ranked
+	}
+
+	rescored, failed := 0, 0
+	for _, i := range order {
+		ranked, ok := scores[jobs[i].Job.URL]
+		if !ok || ranked.ScoreMethod != models.ScoreMethodAI {
+			failed++
+			continue
+		}
+
+		jobs[i].Job = ranked
+		if err := h.store.UpdateSavedJobScore(ctx, &jobs[i]); err != nil {
+			savedJobLog.WarnContext(ctx, "Failed to update saved job", "saved_job_id", jobs[i].ID, "error", err)
+			failed++
+			continue
+		}
+		rescored++
+	}
+
+	remaining := len(jobs) - len(order)
+	savedJobLog.InfoContext(ctx, "Re-scored saved jobs", "rescored", rescored, "failed", failed, "remaining", remaining)
+	c.JSON(http.StatusOK, models.RescoreSavedJobsResponse{
+		Jobs:      jobs,
+		Rescored:  rescored,
+		Failed:    failed,
+		Remaining: remaining,
+		Message:   "Saved jobs re-scored",
+	})
+}
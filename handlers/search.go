@@ -1,37 +1,61 @@
 package handlers
 
 import (
-	"bytes"
-	"io"
-	"log"
+	"context"
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/myjobmatch/backend/agent"
 	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/logging"
 	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/notify"
+	"github.com/myjobmatch/backend/profile"
+	"github.com/myjobmatch/backend/search"
 	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/validation"
+	"github.com/myjobmatch/backend/worker"
 )
 
+var searchLog = logging.Component("SearchHandler")
+
 // SearchHandler handles job search requests
 type SearchHandler struct {
-	agent           *agent.JobAgent
-	firestoreClient *storage.FirestoreClient
-	storageClient   *storage.CloudStorageClient
+	agent      *agent.JobAgent
+	store      storage.Store
+	profiles   *profile.Service
+	service    *search.Service
+	maxCVBytes int64
+	queue      *worker.Queue          // Runs background (async) searches
+	searches   *worker.SearchRegistry // Running searches, drained on shutdown
+	webhooks   *notify.Webhooks       // Report finished background searches
 }
 
 // NewSearchHandler creates a new search handler
 func NewSearchHandler(
 	jobAgent *agent.JobAgent,
-	firestoreClient *storage.FirestoreClient,
-	storageClient *storage.CloudStorageClient,
+	store storage.Store,
+	profiles *profile.Service,
+	service *search.Service,
+	maxCVBytes int64,
+	queue *worker.Queue,
+	searches *worker.SearchRegistry,
+	webhooks *notify.Webhooks,
 ) *SearchHandler {
 	return &SearchHandler{
-		agent:           jobAgent,
-		firestoreClient: firestoreClient,
-		storageClient:   storageClient,
+		agent:      jobAgent,
+		store:      store,
+		profiles:   profiles,
+		service:    service,
+		maxCVBytes: maxCVBytes,
+		queue:      queue,
+		searches:   searches,
+		webhooks:   webhooks,
 	}
 }
 
@@ -51,24 +75,109 @@ func NewSearchHandler(
 // @Param locations formData []string false "Location filters"
 // @Param remote_modes formData []string false "Remote mode filters (remote, hybrid, onsite)"
 // @Param job_types formData []string false "Job type filters (full-time, part-time, contract)"
+// @Param incognito formData bool false "Strip name, email and phone from the profile before scoring"
+// @Param max_gemini_calls formData int false "Max Gemini calls for this search"
+// @Param max_tokens formData int false "Max Gemini tokens for this search"
+// @Param max_cost_usd formData number false "Max estimated Gemini cost (USD) for this search"
+// @Param max_results formData int false "Max results to return (capped by server limit)"
+// @Param max_pages_to_process formData int false "Max pages to fetch and extract (capped by server limit)"
+// @Param model formData string false "Gemini model for this search, from GEMINI_ALLOWED_MODELS (default GEMINI_MODEL)"
+// @Param Idempotency-Key header string false "Key that makes retries of this request replay its response"
 // @Success 200 {object} models.SearchJobsResponse "Search results"
-// @Failure 400 {object} models.ErrorResponse "Invalid request"
+// @Failure 400 {object} models.ErrorResponse "Invalid request or model not allowed"
+// @Failure 409 {object} models.ErrorResponse "A request with the same Idempotency-Key is running"
+// @Failure 413 {object} models.ErrorResponse "CV file too large"
+// @Failure 415 {object} models.ErrorResponse "Unsupported CV file type"
+// @Failure 422 {object} models.ErrorResponse "CV or query blocked by the AI safety filters, or Idempotency-Key already used for a different request"
+// @Failure 429 {object} models.ErrorResponse "Gemini rate limit or quota reached; see Retry-After"
+// @Failure 503 {object} models.ErrorResponse "AI service temporarily unavailable; see Retry-After"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Router /search-jobs [post]
 func (h *SearchHandler) SearchJobs(c *gin.Context) {
+	req, ok := h.parseSearchRequest(c)
+	if !ok {
+		return
+	}
+
+	ctx, done, ok := h.trackSearch(c)
+	if !ok {
+		return
+	}
+	defer done()
+
+	response, err := h.service.Run(ctx, req.Request)
+	if err != nil {
+		if worker.Interrupted(ctx) {
+			respondShuttingDown(c)
+			return
+		}
+		searchLog.ErrorContext(ctx, "Job search failed", "error", err)
+		respondAIError(c, err, "Job search failed")
+		return
+	}
+
+	searchLog.InfoContext(c.Request.Context(), "Job search succeeded", "results", response.TotalResults, "cv_saved", response.CVSaved)
+	c.JSON(http.StatusOK, response)
+}
+
+// searchRequest is a parsed and validated search, ready to run synchronously or in the background
+type searchRequest struct {
+	*search.Request
+	callbackURL string // Background searches only
+}
+
+// trackSearch registers a search run for the request with the search registry, so shutdown waits for it.
+// If the server is shutting down it writes the error response and returns false.
+func (h *SearchHandler) trackSearch(c *gin.Context) (context.Context, func(), bool) {
+	ctx, done, err := h.searches.Track(c.Request.Context(), c.Request.Method+" "+c.FullPath(), nil)
+	if err != nil {
+		respondShuttingDown(c)
+		return nil, nil, false
+	}
+	return ctx, done, true
+}
+
+// respondShuttingDown tells the client to retry a search on another instance
+func respondShuttingDown(c *gin.Context) {
+	c.Header("Retry-After", "1")
+	c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+		Error: "The server is restarting, please retry",
+		Code:  http.StatusServiceUnavailable,
+	})
+}
+
+// parseSearchRequest reads a JSON or multipart search request and resolves its input with the search service.
+// On failure it writes the error response and returns false.
+func (h *SearchHandler) parseSearchRequest(c *gin.Context) (*searchRequest, bool) {
 	var cvText string
 	var cvFileData []byte
 	var cvFileName string
 	var query string
 	var filters models.JobSearchFilter
 	var saveCV bool
-	var useProfileCV bool
+	var budget *models.LLMBudget
+	var incognito bool
+	var maxResults, maxPages int
+	var model string
+	var callbackURL string
 
 	contentType := c.ContentType()
 
 	if strings.Contains(contentType, "multipart/form-data") {
 		// Handle file upload
-		cvText, cvFileData, cvFileName, query, filters, saveCV = h.parseMultipartRequest(c)
+		limitUploadBody(c, h.maxCVBytes)
+		var err error
+		cvText, cvFileData, cvFileName, query, filters, saveCV, err = h.parseMultipartRequest(c)
+		if err != nil {
+			respondUploadError(c, err, "CV", h.maxCVBytes)
+			return nil, false
+		}
+		budget = parseMultipartBudget(c)
+		incognito = parseFormBool(c.PostForm("incognito"))
+		maxResults, _ = strconv.Atoi(c.PostForm("max_results"))
+		maxPages, _ = strconv.Atoi(c.PostForm("max_pages_to_process"))
+		model = c.PostForm("model")
+		callbackURL = c.PostForm("callback_url")
 	} else {
 		// Handle JSON request
 		var req models.SearchJobsRequest
@@ -77,122 +186,85 @@ func (h *SearchHandler) SearchJobs(c *gin.Context) {
 				Error: "Invalid request body",
 				Code:  http.StatusBadRequest,
 			})
-			return
+			return nil, false
 		}
 		cvText = req.CVText
 		query = req.Query
 		filters = req.Filters
 		saveCV = req.SaveCV
+		budget = req.Budget
+		incognito = req.Incognito
+		maxResults = req.MaxResults
+		maxPages = req.MaxPagesToProcess
+		model = req.Model
+		callbackURL = req.CallbackURL
 	}
 
-	// Check if user is authenticated
+	params := search.Params{
+		CVText:     cvText,
+		CVFileData: cvFileData,
+		CVFileName: cvFileName,
+		Query:      query,
+		Filters:    filters,
+		SaveCV:     saveCV,
+		Budget:     budget,
+		Incognito:  incognito,
+		MaxResults: maxResults,
+		MaxPages:   maxPages,
+		Model:      model,
+	}
 	claims := auth.GetAuthClaims(c)
-
-	// If no CV provided, try to use saved CV from profile
-	if claims != nil && cvText == "" && len(cvFileData) == 0 {
-		user, err := h.firestoreClient.GetUserByEmail(c.Request.Context(), claims.Email)
-		if err == nil && user.CVUrl != "" {
-			// Download CV from Cloud Storage
-			cvContent, err := h.storageClient.DownloadCV(c.Request.Context(), user.CVUrl)
-			if err == nil {
-				cvText = string(cvContent)
-				useProfileCV = true
-				log.Printf("[Handler] Using saved CV for user: %s", claims.Email)
-			} else {
-				log.Printf("[Handler] Failed to download saved CV: %v", err)
-			}
-		}
+	if claims != nil {
+		params.UserID = claims.UserID
 	}
 
-	// Validate that at least one input is provided
-	if cvText == "" && len(cvFileData) == 0 && query == "" {
+	req, err := h.service.Prepare(c.Request.Context(), params)
+	var invalid validation.Errors
+	switch {
+	case errors.Is(err, gemini.ErrModelNotAllowed):
+		respondModelError(c, err)
+		return nil, false
+	case errors.As(err, &invalid):
+		respondValidationError(c, invalid)
+		return nil, false
+	case errors.Is(err, search.ErrNoInput):
 		// If user is logged in but has no CV, provide helpful message
+		message := "Please provide a CV file, CV text, or search query"
 		if claims != nil {
-			c.JSON(http.StatusBadRequest, models.ErrorResponse{
-				Error: "Please provide a search query or upload your CV in your profile",
-				Code:  http.StatusBadRequest,
-			})
-			return
+			message = "Please provide a search query or upload your CV in your profile"
 		}
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
-			Error: "Please provide a CV file, CV text, or search query",
+			Error: message,
 			Code:  http.StatusBadRequest,
 		})
-		return
-	}
-
-	log.Printf("[Handler] SearchJobs request: query=%q, hasCVText=%v, hasCVFile=%v, useProfileCV=%v, saveCV=%v, filters=%+v",
-		query, cvText != "", len(cvFileData) > 0, useProfileCV, saveCV, filters)
-
-	// Execute job search - pass PDF data directly to agent for Gemini multimodal parsing
-	input := agent.SearchJobsInput{
-		CVText:     cvText,
-		CVFileData: cvFileData,
-		CVFileName: cvFileName,
-		Query:      query,
-		Filters:    filters,
-	}
-
-	output, err := h.agent.SearchJobs(c.Request.Context(), input)
-	if err != nil {
-		log.Printf("[Handler] SearchJobs error: %v", err)
+		return nil, false
+	case err != nil:
+		searchLog.ErrorContext(c.Request.Context(), "Failed to prepare search", "error", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Job search failed",
-			Code:    http.StatusInternalServerError,
-			Details: err.Error(),
+			Error: "Failed to prepare search",
+			Code:  http.StatusInternalServerError,
 		})
-		return
-	}
-
-	// Save CV to profile if authenticated and requested
-	var cvSaved bool
-	if saveCV && claims != nil && len(cvFileData) > 0 && h.storageClient != nil {
-		cvUrl, err := h.storageClient.UploadCVFromBytes(c.Request.Context(), claims.Email, cvFileData, cvFileName)
-		if err != nil {
-			log.Printf("[Handler] Failed to save CV to profile: %v", err)
-		} else {
-			// Update user's CV URL in Firestore
-			if err := h.firestoreClient.UpdateUserCVUrl(c.Request.Context(), claims.Email, cvUrl); err != nil {
-				log.Printf("[Handler] Failed to update CV URL in Firestore: %v", err)
-			} else {
-				cvSaved = true
-				log.Printf("[Handler] CV saved to profile for user: %s", claims.Email)
-			}
-		}
+		return nil, false
 	}
-
-	response := models.SearchJobsResponse{
-		Results:      output.Results,
-		Profile:      output.Profile,
-		TotalResults: len(output.Results),
-		Message:      h.buildResultMessage(output.Stats),
-		CVSaved:      cvSaved,
-	}
-
-	log.Printf("[Handler] SearchJobs success: returning %d results, cvSaved=%v", len(output.Results), cvSaved)
-	c.JSON(http.StatusOK, response)
+	return &searchRequest{Request: req, callbackURL: callbackURL}, true
 }
 
 // parseMultipartRequest parses a multipart/form-data request
-// Returns: cvText, cvFileData, cvFileName, query, filters, saveCV
-func (h *SearchHandler) parseMultipartRequest(c *gin.Context) (string, []byte, string, string, models.JobSearchFilter, bool) {
+// Returns: cvText, cvFileData, cvFileName, query, filters, saveCV, and an error if the CV file is rejected
+func (h *SearchHandler) parseMultipartRequest(c *gin.Context) (string, []byte, string, string, models.JobSearchFilter, bool, error) {
 	var cvText, query, cvFileName string
 	var cvFileData []byte
 	var filters models.JobSearchFilter
 	var saveCV bool
 
 	// Get CV file if present
-	file, header, err := c.Request.FormFile("cv_file")
-	if err == nil && file != nil {
-		defer file.Close()
-
-		// Read file content into bytes
-		buf := new(bytes.Buffer)
-		if _, err := io.Copy(buf, file); err == nil {
-			cvFileData = buf.Bytes()
-			cvFileName = header.Filename
-			log.Printf("[Handler] Received CV file: %s, size: %d bytes", header.Filename, header.Size)
-		}
+	data, header, err := readCVUpload(c, h.maxCVBytes)
+	if err == nil {
+		cvFileData = data
+		cvFileName = header.Filename
+		searchLog.InfoContext(c.Request.Context(), "Received CV file", "file", header.Filename, "size_bytes", header.Size)
+	} else if !errors.Is(err, http.ErrMissingFile) {
+		return "", nil, "", "", filters, false, err
 	}
 
 	// Get CV text if provided directly
@@ -204,9 +276,7 @@ func (h *SearchHandler) parseMultipartRequest(c *gin.Context) (string, []byte, s
 	query = c.PostForm("query")
 
 	// Get save_cv flag
-	if saveCVStr := c.PostForm("save_cv"); saveCVStr == "true" || saveCVStr == "1" {
-		saveCV = true
-	}
+	saveCV = parseFormBool(c.PostForm("save_cv"))
 
 	// Parse filters from form
 	if locations := c.PostFormArray("locations"); len(locations) > 0 {
@@ -227,36 +297,41 @@ func (h *SearchHandler) parseMultipartRequest(c *gin.Context) (string, []byte, s
 		filters.JobTypes = strings.Split(jt, ",")
 	}
 
-	return cvText, cvFileData, cvFileName, query, filters, saveCV
+	return cvText, cvFileData, cvFileName, query, filters, saveCV, nil
 }
 
-// buildResultMessage creates a human-readable message about the search results
-func (h *SearchHandler) buildResultMessage(stats agent.SearchStats) string {
-	if stats.JobsReturned == 0 {
-		return "No matching jobs found. Try adjusting your search criteria."
-	}
-
-	return ""
+// parseFormBool interprets "true" or "1" form values as true
+func parseFormBool(value string) bool {
+	return value == "true" || value == "1"
 }
 
-// HealthCheck returns server health status
-// @Summary Health check
-// @Description Check if the server is running and healthy
-// @Tags System
-// @Produce json
-// @Success 200 {object} models.HealthResponse "Server is healthy"
-// @Router /health [get]
-func HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, models.HealthResponse{
-		Status:    "healthy",
-		Version:   "1.0.0",
-		Timestamp: c.Request.Header.Get("Date"),
-	})
+// parseMultipartBudget reads optional LLM budget caps from form fields
+func parseMultipartBudget(c *gin.Context) *models.LLMBudget {
+	var budget models.LLMBudget
+	var set bool
+
+	if v, err := strconv.Atoi(c.PostForm("max_gemini_calls")); err == nil {
+		budget.MaxCalls = v
+		set = true
+	}
+	if v, err := strconv.Atoi(c.PostForm("max_tokens")); err == nil {
+		budget.MaxTokens = v
+		set = true
+	}
+	if v, err := strconv.ParseFloat(c.PostForm("max_cost_usd"), 64); err == nil {
+		budget.MaxCostUSD = v
+		set = true
+	}
+
+	if !set {
+		return nil
+	}
+	return &budget
 }
 
 // GetTools returns available MCP tools
 // @Summary List available tools
-// @Description Get a list of all available MCP tools for AI agents
+// @Description Get a list of all available MCP tools for AI agents, with each tool's version and, for deprecated tools, the deprecation notice
 // @Tags Tools
 // @Produce json
 // @Success 200 {object} map[string]interface{} "List of tools"
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/agent"
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/profile"
+	"github.com/myjobmatch/backend/validation"
+	"github.com/myjobmatch/backend/worker"
+)
+
+// AgentSearch answers an open-ended job search request by letting Gemini decide which tools to call
+// @Summary Agent job search
+// @Description Answer an open-ended request (e.g. "compare my fit for fintech vs e-commerce roles") by letting Gemini call the search, fetch, extract, scoring and company research tools in a loop, instead of running the fixed search pipeline. The loop is bounded by max_steps (capped by AGENT_MAX_STEPS) and the LLM budget. Authenticated users' saved profile and feedback are used unless cvText is given.
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.AgentSearchRequest true "Agent search request"
+// @Param Idempotency-Key header string false "Key that makes retries of this request replay its response"
+// @Success 200 {object} models.AgentSearchResponse "Answer and tool calls"
+// @Failure 400 {object} models.ErrorResponse "Invalid request"
+// @Failure 409 {object} models.ErrorResponse "A request with the same Idempotency-Key is running"
+// @Failure 422 {object} models.ErrorResponse "Request blocked by the AI safety filters, or Idempotency-Key already used for a different request"
+// @Failure 429 {object} models.ErrorResponse "Gemini rate limit or quota reached; see Retry-After"
+// @Failure 503 {object} models.ErrorResponse "AI service temporarily unavailable; see Retry-After"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /search-jobs/agent [post]
+func (h *SearchHandler) AgentSearch(c *gin.Context) {
+	var req models.AgentSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "Invalid request body",
+			Code:  http.StatusBadRequest,
+		})
+		return
+	}
+	if err := validation.Search(&req.Query, nil); err != nil {
+		respondValidationError(c, err.(validation.Errors))
+		return
+	}
+
+	ctx := c.Request.Context()
+	input := agent.SearchJobsInput{
+		CVText:    req.CVText,
+		Query:     req.Query,
+		Incognito: req.Incognito,
+	}
+
+	claims := auth.GetAuthClaims(c)
+	if claims != nil {
+		input.UserID = claims.UserID
+		if user, err := h.store.GetUserByID(ctx, claims.UserID); err == nil {
+			input.Incognito = input.Incognito || user.Incognito
+		}
+		feedback, err := h.store.ListJobFeedback(ctx, claims.UserID)
+		if err != nil {
+			searchLog.WarnContext(ctx, "Failed to load job feedback", "error", err)
+		}
+		input.Feedback = feedback
+
+		if req.CVText == "" {
+			saved, err := h.profiles.ForUser(ctx, claims.UserID)
+			if err == nil {
+				input.Profile = &saved.Profile
+			} else if !errors.Is(err, profile.ErrNoProfile) {
+				searchLog.WarnContext(ctx, "Failed to load saved profile", "error", err)
+			}
+		}
+	}
+	input.Budget = h.service.EffectiveBudget(req.Budget, claims != nil)
+
+	ctx, done, ok := h.trackSearch(c)
+	if !ok {
+		return
+	}
+	defer done()
+
+	output, err := h.agent.Orchestrate(ctx, agent.OrchestrateInput{Search: input, MaxSteps: req.MaxSteps})
+	if err != nil {
+		if worker.Interrupted(ctx) {
+			respondShuttingDown(c)
+			return
+		}
+		searchLog.ErrorContext(ctx, "Agent search failed", "error", err)
+		respondAIError(c, err, "Agent search failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.AgentSearchResponse{
+		Answer:         output.Answer,
+		Steps:          output.Steps,
+		Profile:        output.Profile,
+		StepsExhausted: output.StepsExhausted,
+		LLMUsage:       &output.Usage,
+	})
+}
@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/notify"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/utils"
+	"github.com/myjobmatch/backend/worker"
+)
+
+// statusWriteTimeout bounds the final status update, which must succeed even if the search timed out
+const statusWriteTimeout = 10 * time.Second
+
+// SearchJobsAsync queues a job search and returns its ID immediately
+// @Summary Start a background job search
+// @Description Same input as POST /search-jobs, but returns a search ID immediately and runs the search in the background. Poll GET /search-jobs/{id} for status and results, or have the finished search posted to callback_url (signed with the returned callback_secret) or, without one, to the user's webhook.
+// @Tags Jobs
+// @Accept json
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.SearchJobsRequest false "Search request (JSON)"
+// @Param cv_file formData file false "CV file (PDF, DOC, DOCX, TXT) - processed by AI"
+// @Param cv_text formData string false "CV text content"
+// @Param query formData string false "Search query"
+// @Param callback_url formData string false "URL the finished search is posted to"
+// @Param Idempotency-Key header string false "Key that makes retries of this request replay its response"
+// @Success 202 {object} models.AsyncSearchResponse "Search queued"
+// @Failure 400 {object} models.ErrorResponse "Invalid request or callback URL"
+// @Failure 409 {object} models.ErrorResponse "A request with the same Idempotency-Key is running"
+// @Failure 413 {object} models.ErrorResponse "CV file too large"
+// @Failure 415 {object} models.ErrorResponse "Unsupported CV file type"
+// @Failure 422 {object} models.ErrorResponse "Idempotency-Key already used for a different request"
+// @Failure 503 {object} models.ErrorResponse "Too many searches in progress"
+// @Router /search-jobs/async [post]
+func (h *SearchHandler) SearchJobsAsync(c *gin.Context) {
+	req, ok := h.parseSearchRequest(c)
+	if !ok {
+		return
+	}
+
+	search := &models.AsyncSearch{
+		UserID:     req.UserID,
+		Query:      req.Input.Query,
+		Checkpoint: req.Checkpoint(),
+	}
+	if req.callbackURL != "" {
+		if err := h.webhooks.ValidateURL(req.callbackURL); err != nil {
+			respondInvalidWebhookURL(c, err)
+			return
+		}
+		secret, err := notify.NewWebhookSecret()
+		if err != nil {
+			searchLog.ErrorContext(c.Request.Context(), "Failed to generate callback secret", "error", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+				Error: "Failed to start search",
+				Code:  http.StatusInternalServerError,
+			})
+			return
+		}
+		search.CallbackURL = req.callbackURL
+		search.CallbackSecret = secret
+	}
+	if err := h.store.CreateAsyncSearch(c.Request.Context(), search); err != nil {
+		searchLog.ErrorContext(c.Request.Context(), "Failed to create async search", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to start search",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if !h.queueAsyncSearch(c, search.ID, req, search.Checkpoint != nil) {
+		return
+	}
+	c.JSON(http.StatusAccepted, models.AsyncSearchResponse{
+		SearchID:       search.ID,
+		Status:         models.SearchStatusPending,
+		StatusURL:      "/api/search-jobs/" + search.ID,
+		CallbackSecret: search.CallbackSecret,
+	})
+}
+
+// ResumeSearch queues an interrupted background search again from its checkpoint
+// @Summary Resume an interrupted background search
+// @Description Searches still running when an instance shuts down are stopped with status "interrupted". Those with "resumable" set can be queued again under the same ID with the input they were started with; poll GET /search-jobs/{id} as before. Searches with an uploaded CV file, and incognito searches with CV text, are not resumable.
+// @Tags Jobs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Search ID"
+// @Success 202 {object} models.AsyncSearchResponse "Search queued"
+// @Failure 404 {object} models.ErrorResponse "Search not found"
+// @Failure 409 {object} models.ErrorResponse "Search is not resumable"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Failure 503 {object} models.ErrorResponse "Too many searches in progress, or the server is shutting down"
+// @Router /search-jobs/{id}/resume [post]
+func (h *SearchHandler) ResumeSearch(c *gin.Context) {
+	search, ok := h.loadVisibleSearch(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.store.ResumeAsyncSearch(ctx, search.ID); err != nil {
+		if errors.Is(err, storage.ErrSearchNotResumable) || errors.Is(err, storage.ErrSearchNotFound) {
+			c.JSON(http.StatusConflict, models.ErrorResponse{
+				Error: "Search is not resumable",
+				Code:  http.StatusConflict,
+			})
+			return
+		}
+		searchLog.ErrorContext(ctx, "Failed to resume search", "search_id", search.ID, "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to resume search",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if !h.queueAsyncSearch(c, search.ID, &searchRequest{Request: h.service.FromCheckpoint(ctx, search)}, true) {
+		return
+	}
+	c.JSON(http.StatusAccepted, models.AsyncSearchResponse{
+		SearchID:  search.ID,
+		Status:    models.SearchStatusPending,
+		StatusURL: "/api/search-jobs/" + search.ID,
+	})
+}
+
+// queueAsyncSearch registers a stored search with the search registry and queues it. If the search is
+// still running when shutdown's drain deadline passes, it's recorded as interrupted (resumable if it has
+// a checkpoint). On failure the search is recorded as failed, the error response is written and it
+// returns false.
+func (h *SearchHandler) queueAsyncSearch(c *gin.Context, id string, req *searchRequest, resumable bool) bool {
+	ctx := c.Request.Context()
+	requestID := utils.RequestIDFromContext(ctx)
+	tenantID := utils.TenantFromContext(ctx)
+
+	tracked, done, err := h.searches.Track(context.Background(), "search "+id, func(ctx context.Context) {
+		ctx = utils.WithTenant(utils.WithRequestID(ctx, requestID), tenantID)
+		if err := h.store.InterruptAsyncSearch(ctx, id, resumable); err != nil {
+			searchLog.ErrorContext(ctx, "Failed to checkpoint interrupted search", "search_id", id, "error", err)
+		}
+	})
+	if err != nil {
+		h.finishAsyncSearch(ctx, id, nil, err)
+		respondShuttingDown(c)
+		return false
+	}
+
+	err = h.queue.Submit("search "+id, func(ctx context.Context) {
+		defer done()
+		ctx, cancel := worker.WithInterrupt(ctx, tracked)
+		defer cancel()
+		ctx = utils.WithTenant(utils.WithRequestID(ctx, requestID), tenantID)
+		h.runAsyncSearch(ctx, id, req)
+	})
+	if err != nil {
+		done()
+		searchLog.WarnContext(ctx, "Failed to queue search", "search_id", id, "error", err)
+		h.finishAsyncSearch(ctx, id, nil, err)
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse{
+			Error: "Too many searches in progress, please retry shortly",
+			Code:  http.StatusServiceUnavailable,
+		})
+		return false
+	}
+
+	searchLog.InfoContext(ctx, "Queued async search", "search_id", id)
+	return true
+}
+
+// GetSearchStatus returns the status and, once completed, the results of a background search
+// @Summary Get background search status
+// @Description Poll a background search. Results are included once status is "completed"; error is set if it "failed". Searches started by an authenticated user are only visible to that user.
+// @Tags Jobs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Search ID"
+// @Success 200 {object} models.AsyncSearch "Search status"
+// @Failure 404 {object} models.ErrorResponse "Search not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /search-jobs/{id} [get]
+func (h *SearchHandler) GetSearchStatus(c *gin.Context) {
+	search, ok := h.loadVisibleSearch(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, search)
+}
+
+// loadVisibleSearch loads the background search named by the "id" path parameter if the caller may see it.
+// On failure it writes the error response and returns false.
+func (h *SearchHandler) loadVisibleSearch(c *gin.Context) (*models.AsyncSearch, bool) {
+	search, err := h.store.GetAsyncSearch(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrSearchNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Search not found",
+				Code:  http.StatusNotFound,
+			})
+			return nil, false
+		}
+		searchLog.ErrorContext(c.Request.Context(), "Failed to get async search", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load search",
+			Code:  http.StatusInternalServerError,
+		})
+		return nil, false
+	}
+
+	// Don't reveal other users' searches
+	if search.UserID != "" {
+		claims := auth.GetAuthClaims(c)
+		if claims == nil || claims.UserID != search.UserID {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Search not found",
+				Code:  http.StatusNotFound,
+			})
+			return nil, false
+		}
+	}
+
+	return search, true
+}
+
+// runAsyncSearch executes a queued search and persists its outcome
+func (h *SearchHandler) runAsyncSearch(ctx context.Context, id string, req *searchRequest) {
+	if worker.Interrupted(ctx) {
+		return // Interrupted while queued
+	}
+	if err := h.store.MarkAsyncSearchRunning(ctx, id); err != nil {
+		searchLog.WarnContext(ctx, "Failed to mark search running", "search_id", id, "error", err)
+	}
+
+	response, err := h.service.Run(ctx, req.Request)
+	if err != nil && worker.Interrupted(ctx) {
+		// The search registry has already recorded the interruption
+		searchLog.WarnContext(ctx, "Async search interrupted by shutdown", "search_id", id)
+		return
+	}
+	if err != nil {
+		searchLog.ErrorContext(ctx, "Async search failed", "search_id", id, "error", err)
+	} else {
+		searchLog.InfoContext(ctx, "Async search completed", "search_id", id, "results", response.TotalResults)
+	}
+	h.finishAsyncSearch(ctx, id, response, err)
+	h.notifyWebhook(ctx, id)
+}
+
+// finishAsyncSearch stores the final result or error with a fresh deadline, so it's recorded even when the
+// search timed out
+func (h *SearchHandler) finishAsyncSearch(ctx context.Context, id string, response *models.SearchJobsResponse, searchErr error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), statusWriteTimeout)
+	defer cancel()
+
+	var err error
+	if searchErr != nil {
+		err = h.store.FailAsyncSearch(ctx, id, searchErr)
+	} else {
+		err = h.store.CompleteAsyncSearch(ctx, id, response)
+	}
+	if err != nil {
+		searchLog.ErrorContext(ctx, "Failed to store outcome of search", "search_id", id, "error", err)
+	}
+}
+
+// notifyWebhook posts a finished search to its callback URL or, without one, to its user's webhook. It
+// runs on the search's worker, which keeps the worker busy while deliveries are retried but also lets
+// shutdown's drain wait for them.
+func (h *SearchHandler) notifyWebhook(ctx context.Context, id string) {
+	ctx = context.WithoutCancel(ctx)
+	search, err := h.store.GetAsyncSearch(ctx, id)
+	if err != nil {
+		searchLog.ErrorContext(ctx, "Failed to load search for webhook", "search_id", id, "error", err)
+		return
+	}
+
+	webhookURL, secret := search.CallbackURL, search.CallbackSecret
+	if webhookURL == "" && search.UserID != "" {
+		user, err := h.store.GetUserByID(ctx, search.UserID)
+		if err != nil {
+			searchLog.WarnContext(ctx, "Failed to load user for webhook", "search_id", id, "error", err)
+			return
+		}
+		webhookURL, secret = user.WebhookURL, user.WebhookSecret
+	}
+	if webhookURL == "" {
+		return
+	}
+
+	event := &models.WebhookEvent{
+		ID:        uuid.NewString(),
+		CreatedAt: time.Now(),
+		Search:    search,
+	}
+	switch search.Status {
+	case models.SearchStatusCompleted:
+		event.Type = models.WebhookEventSearchCompleted
+	case models.SearchStatusFailed:
+		event.Type = models.WebhookEventSearchFailed
+	default:
+		return
+	}
+	if err := h.webhooks.Send(ctx, webhookURL, secret, event); err != nil {
+		searchLog.WarnContext(ctx, "Failed to deliver webhook", "search_id", id, "error", err)
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// utf8BOM makes Excel open exported CSV files as UTF-8 (company names and reasons may be non-ASCII)
+const utf8BOM = "\ufeff"
+
+// csvExportHeader lists the exported columns
+var csvExportHeader = []string{
+	"Title", "Company", "Location", "Work Type", "Site Setting", "Salary", "Match Score", "Match Reason", "URL",
+}
+
+// ExportSearchResults downloads the ranked results of a completed background search
+// @Summary Export search results
+// @Description Download the ranked results of a completed background search as a CSV file (title, company, location, work type, site setting, salary, score, reason, URL) for tracking applications in a spreadsheet. The file opens directly in Excel and Google Sheets.
+// @Tags Jobs
+// @Produce text/csv
+// @Security BearerAuth
+// @Param id path string true "Search ID"
+// @Param format query string false "Export format (only csv is supported)" default(csv)
+// @Success 200 {file} file "CSV file"
+// @Failure 400 {object} models.ErrorResponse "Unsupported format"
+// @Failure 404 {object} models.ErrorResponse "Search not found"
+// @Failure 409 {object} models.ErrorResponse "Search has not completed"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /search-jobs/{id}/export [get]
+func (h *SearchHandler) ExportSearchResults(c *gin.Context) {
+	if format := c.DefaultQuery("format", "csv"); format != "csv" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Unsupported export format",
+			Code:    http.StatusBadRequest,
+			Details: "format must be csv",
+		})
+		return
+	}
+
+	search, ok := h.loadVisibleSearch(c)
+	if !ok {
+		return
+	}
+	if search.Status != models.SearchStatusCompleted || search.Result == nil {
+		c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error:   "Search has not completed",
+			Code:    http.StatusConflict,
+			Details: fmt.Sprintf("search status is %s", search.Status),
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="myjobmatch-%s.csv"`, search.ID))
+	c.Status(http.StatusOK)
+
+	if _, err := c.Writer.WriteString(utf8BOM); err != nil {
+		return
+	}
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write(csvExportHeader)
+	for _, job := range search.Result.Results {
+		_ = w.Write([]string{
+			job.Title,
+			job.Company,
+			job.Location,
+			job.WorkType,
+			job.SiteSetting,
+			job.Salary,
+			strconv.Itoa(job.MatchScore),
+			job.MatchReason,
+			job.URL,
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		searchLog.ErrorContext(c.Request.Context(), "Failed to write CSV export", "search_id", search.ID, "error", err)
+	}
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+)
+
+const (
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 100
+)
+
+var searchHistoryLog = logging.Component("SearchHistoryHandler")
+
+// SearchHistoryHandler handles search history requests
+type SearchHistoryHandler struct {
+	store storage.Store
+}
+
+// NewSearchHistoryHandler creates a new search history handler
+func NewSearchHistoryHandler(store storage.Store) *SearchHistoryHandler {
+	return &SearchHistoryHandler{
+		store: store,
+	}
+}
+
+// ListSearchHistory returns the user's past searches
+// @Summary List search history
+// @Description Get the authenticated user's past searches (query, filters, stats and result count), newest first. Rerun a search by posting its query and filters to /search-jobs.
+// @Tags Search History
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Maximum entries to return (default 20, max 100)"
+// @Success 200 {object} models.SearchHistoryResponse "Search history"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /search-history [get]
+func (h *SearchHistoryHandler) ListSearchHistory(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	limit := defaultHistoryLimit
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil && v > 0 {
+		limit = min(v, maxHistoryLimit)
+	}
+
+	entries, err := h.store.ListSearchHistory(c.Request.Context(), claims.UserID, limit)
+	if err != nil {
+		searchHistoryLog.ErrorContext(c.Request.Context(), "Failed to list search history", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load search history",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SearchHistoryResponse{
+		Searches: entries,
+	})
+}
+
+// DeleteSearchHistory removes an entry from the user's search history
+// @Summary Delete search history entry
+// @Description Remove a past search from the authenticated user's history
+// @Tags Search History
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Search history entry ID"
+// @Success 200 {object} models.SearchHistoryResponse "Entry deleted"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Entry not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /search-history/{id} [delete]
+func (h *SearchHistoryHandler) DeleteSearchHistory(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.store.DeleteSearchHistory(c.Request.Context(), claims.UserID, c.Param("id")); err != nil {
+		if errors.Is(err, storage.ErrSearchHistoryNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Search history entry not found",
+				Code:  http.StatusNotFound,
+			})
+			return
+		}
+		searchHistoryLog.ErrorContext(c.Request.Context(), "Failed to delete search history", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to delete search history entry",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SearchHistoryResponse{
+		Searches: []models.SearchHistoryEntry{},
+		Message:  "Search history entry deleted",
+	})
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+)
+
+const (
+	defaultSessionResultsLimit = 10
+	maxSessionResultsLimit     = 50
+)
+
+// GetSearchSession returns a recorded search
+// @Summary Get a search session
+// @Description Get what went into a search and what came out of it: the input, the resolved profile, the planned web queries, pipeline stats, LLM usage and the returned jobs in rank order with their scores and reasons. The session ID is returned as session_id by searches. Sessions of searches by an authenticated user are only visible to that user.
+// @Tags Search History
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Search session ID"
+// @Success 200 {object} models.SearchSession "Search session"
+// @Failure 404 {object} models.ErrorResponse "Search session not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /search-sessions/{id} [get]
+func (h *SearchHistoryHandler) GetSearchSession(c *gin.Context) {
+	session, ok := h.loadVisibleSession(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// ListSearchSessionResults returns a page of a recorded search's results
+// @Summary Page through a search session's results
+// @Description Get the returned jobs of a recorded search in rank order, with the scores they had in that search. Postings are read from the stored jobs, so results whose posting is no longer stored are skipped.
+// @Tags Search History
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Search session ID"
+// @Param offset query int false "Results to skip (default 0)"
+// @Param limit query int false "Maximum results to return (default 10, max 50)"
+// @Success 200 {object} models.SearchSessionResultsResponse "Page of results"
+// @Failure 404 {object} models.ErrorResponse "Search session not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /search-sessions/{id}/results [get]
+func (h *SearchHistoryHandler) ListSearchSessionResults(c *gin.Context) {
+	session, ok := h.loadVisibleSession(c)
+	if !ok {
+		return
+	}
+
+	offset := 0
+	if v, err := strconv.Atoi(c.Query("offset")); err == nil && v > 0 {
+		offset = min(v, len(session.Results))
+	}
+	limit := defaultSessionResultsLimit
+	if v, err := strconv.Atoi(c.Query("limit")); err == nil && v > 0 {
+		limit = min(v, maxSessionResultsLimit)
+	}
+	page := session.Results[offset:min(offset+limit, len(session.Results))]
+
+	ids := make([]string, len(page))
+	for i, result := range page {
+		ids[i] = result.JobID
+	}
+	stored, err := h.store.GetJobs(c.Request.Context(), ids)
+	if err != nil {
+		searchHistoryLog.ErrorContext(c.Request.Context(), "Failed to load session jobs", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load search session results",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	results := make([]models.RankedJob, 0, len(page))
+	for _, result := range page {
+		job, ok := stored[result.JobID]
+		if !ok {
+			continue
+		}
+		results = append(results, models.RankedJob{
+			JobPosting:  job.Job,
+			MatchScore:  result.MatchScore,
+			MatchReason: result.MatchReason,
+			ScoreMethod: result.ScoreMethod,
+		})
+	}
+
+	c.JSON(http.StatusOK, models.SearchSessionResultsResponse{
+		SessionID: session.ID,
+		Results:   results,
+		Total:     len(session.Results),
+		Offset:    offset,
+		Limit:     limit,
+	})
+}
+
+// loadVisibleSession loads the search session named by the "id" path parameter if the caller may see it.
+// On failure it writes the error response and returns false.
+func (h *SearchHistoryHandler) loadVisibleSession(c *gin.Context) (*models.SearchSession, bool) {
+	session, err := h.store.GetSearchSession(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, storage.ErrSearchSessionNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Search session not found",
+				Code:  http.StatusNotFound,
+			})
+			return nil, false
+		}
+		searchHistoryLog.ErrorContext(c.Request.Context(), "Failed to get search session", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load search session",
+			Code:  http.StatusInternalServerError,
+		})
+		return nil, false
+	}
+
+	// Don't reveal other users' searches
+	if session.UserID != "" {
+		claims := auth.GetAuthClaims(c)
+		if claims == nil || claims.UserID != session.UserID {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Search session not found",
+				Code:  http.StatusNotFound,
+			})
+			return nil, false
+		}
+	}
+
+	return session, true
+}
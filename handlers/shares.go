@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/profile"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/utils"
+)
+
+const (
+	// maxSharesPerUser caps how many share links a user can have at once
+	maxSharesPerUser = 50
+	// defaultShareExpiryDays applies when the request doesn't set an expiry
+	defaultShareExpiryDays = 30
+)
+
+var shareLog = logging.Component("ShareHandler")
+
+// ShareHandler handles shareable search result links
+type ShareHandler struct {
+	store    storage.Store
+	profiles *profile.Service
+}
+
+// NewShareHandler creates a new share link handler
+func NewShareHandler(store storage.Store, profiles *profile.Service) *ShareHandler {
+	return &ShareHandler{
+		store:    store,
+		profiles: profiles,
+	}
+}
+
+// ListSharedSearches returns the user's share links
+// @Summary List share links
+// @Description Get the search result snapshots the authenticated user has shared, newest first
+// @Tags Sharing
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.SharedSearchListResponse "Share links"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /shared [get]
+func (h *ShareHandler) ListSharedSearches(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	shares, err := h.store.ListSharedSearches(c.Request.Context(), claims.UserID)
+	if err != nil {
+		shareLog.ErrorContext(c.Request.Context(), "Failed to list share links", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load share links",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SharedSearchListResponse{
+		Shares: shares,
+	})
+}
+
+// CreateSharedSearch publishes a snapshot of search results under a random share token
+// @Summary Share search results
+// @Description Publish a read-only snapshot of ranked jobs (by the "id" of jobs returned by a search) that anyone with the link can view, e.g. a mentor. Scores are the user's latest scores for each job. With includeProfile, skills and experience are included without name, contact details or salary expectations.
+// @Tags Sharing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateSharedSearchRequest true "Jobs to share"
+// @Success 201 {object} models.SharedSearchResponse "Share link created"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body, unknown jobs or share limit reached"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /shared [post]
+func (h *ShareHandler) CreateSharedSearch(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.CreateSharedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	existing, err := h.store.ListSharedSearches(ctx, claims.UserID)
+	if err != nil {
+		shareLog.ErrorContext(ctx, "Failed to list share links", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to create share link",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if len(existing) >= maxSharesPerUser {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Share link limit reached",
+			Code:    http.StatusBadRequest,
+			Details: "Delete an existing share link before creating a new one",
+		})
+		return
+	}
+
+	jobIDs := utils.DedupeStrings(req.JobIDs)
+	jobs, err := h.store.GetJobs(ctx, jobIDs)
+	if err != nil {
+		shareLog.ErrorContext(ctx, "Failed to load jobs", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to create share link",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if len(jobs) == 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: "none of the jobIds match a stored job",
+		})
+		return
+	}
+
+	scores, err := h.store.GetJobScores(ctx, claims.UserID, jobIDs)
+	if err != nil {
+		shareLog.ErrorContext(ctx, "Failed to load job scores", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to create share link",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	// Match reasons may mention the user by name; scrub them along with contact details
+	var userProfile *models.UserProfile
+	if saved, err := h.profiles.ForUser(ctx, claims.UserID); err == nil {
+		userProfile = &saved.Profile
+	} else if !errors.Is(err, profile.ErrNoProfile) {
+		shareLog.WarnContext(ctx, "Failed to load profile", "error", err)
+	}
+	var names []string
+	if userProfile != nil {
+		names = strings.Fields(userProfile.Name)
+	}
+
+	results := make([]models.RankedJob, 0, len(jobs))
+	for _, id := range jobIDs {
+		stored, ok := jobs[id]
+		if !ok {
+			continue
+		}
+		ranked := models.RankedJob{JobPosting: stored.Job}
+		if score, ok := scores[id]; ok {
+			ranked.MatchScore = score.MatchScore
+			ranked.MatchReason = utils.RedactText(score.MatchReason, names...)
+			ranked.ScoreMethod = score.ScoreMethod
+			ranked.ScoreBreakdown = score.ScoreBreakdown
+		}
+		results = append(results, ranked)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].MatchScore > results[j].MatchScore
+	})
+
+	token, err := generateShareToken()
+	if err != nil {
+		shareLog.ErrorContext(ctx, "Failed to generate share token", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to create share link",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	expiresInDays := req.ExpiresInDays
+	if expiresInDays == 0 {
+		expiresInDays = defaultShareExpiryDays
+	}
+
+	share := &models.SharedSearch{
+		Token:     token,
+		UserID:    claims.UserID,
+		Title:     strings.TrimSpace(req.Title),
+		Query:     strings.TrimSpace(req.Query),
+		Results:   results,
+		ExpiresAt: time.Now().AddDate(0, 0, expiresInDays),
+	}
+	if req.IncludeProfile && userProfile != nil {
+		share.Profile = shareableProfile(userProfile)
+	}
+
+	if err := h.store.CreateSharedSearch(ctx, share); err != nil {
+		shareLog.ErrorContext(ctx, "Failed to create share link", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to create share link",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	shareLog.InfoContext(ctx, "Jobs shared", "jobs", len(results))
+	c.JSON(http.StatusCreated, models.SharedSearchResponse{
+		Share:   share,
+		URL:     "/api/shared/" + token,
+		Message: "Share link created",
+	})
+}
+
+// GetSharedSearch returns a shared snapshot to anyone with the link
+// @Summary View shared search results
+// @Description Get a shared snapshot of search results by its token. No authentication required.
+// @Tags Sharing
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} models.SharedSearch "Shared results"
+// @Failure 404 {object} models.ErrorResponse "Share link not found or expired"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /shared/{token} [get]
+func (h *ShareHandler) GetSharedSearch(c *gin.Context) {
+	share, err := h.store.GetSharedSearch(c.Request.Context(), c.Param("token"))
+	if err != nil && !errors.Is(err, storage.ErrSharedSearchNotFound) {
+		shareLog.ErrorContext(c.Request.Context(), "Failed to get share link", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load shared results",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if err != nil || share.IsExpired() {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: "Share link not found or expired",
+			Code:  http.StatusNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, share)
+}
+
+// DeleteSharedSearch revokes a share link
+// @Summary Delete share link
+// @Description Revoke one of the authenticated user's share links; the link stops working immediately
+// @Tags Sharing
+// @Produce json
+// @Security BearerAuth
+// @Param token path string true "Share token"
+// @Success 200 {object} models.SharedSearchListResponse "Share link deleted"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Share link not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /shared/{token} [delete]
+func (h *ShareHandler) DeleteSharedSearch(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.store.DeleteSharedSearch(c.Request.Context(), claims.UserID, c.Param("token")); err != nil {
+		if errors.Is(err, storage.ErrSharedSearchNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Share link not found",
+				Code:  http.StatusNotFound,
+			})
+			return
+		}
+		shareLog.ErrorContext(c.Request.Context(), "Failed to delete share link", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to delete share link",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SharedSearchListResponse{
+		Shares:  []models.SharedSearch{},
+		Message: "Share link deleted",
+	})
+}
+
+// generateShareToken returns a random URL-safe token that is infeasible to guess
+func generateShareToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// shareableProfile strips identifying and private details from a profile before it is made public
+func shareableProfile(p *models.UserProfile) *models.UserProfile {
+	shared := utils.RedactProfile(p)
+	shared.MinSalary = 0
+	shared.MaxSalary = 0
+	shared.Currency = ""
+	return shared
+}
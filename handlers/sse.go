@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/logging"
+)
+
+var sseLog = logging.Component("SSE")
+
+// streamGeneration runs a streaming Gemini generation and forwards it to the client as server-sent events:
+// a "token" event per chunk ({"text": ...}), then "done" with the full text or "error" if generation failed.
+// A client disconnect stops the generation.
+func streamGeneration(c *gin.Context, generate func(onText gemini.StreamHandler) (string, error)) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable proxy buffering (nginx, Cloud Run) so tokens arrive as generated
+
+	ctx := c.Request.Context()
+	text, err := generate(func(chunk string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c.SSEvent("token", gin.H{"text": chunk})
+		c.Writer.Flush()
+		return nil
+	})
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		sseLog.ErrorContext(ctx, "Generation failed", "error", err)
+		c.SSEvent("error", gin.H{"error": "Generation failed"})
+	} else {
+		c.SSEvent("done", gin.H{"text": text})
+	}
+	c.Writer.Flush()
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// TenantHeader names the tenant a request belongs to, for frontends that share the API's host
+const TenantHeader = "X-Tenant-ID"
+
+// TenantMiddleware attaches the request's tenant to the request context: the tenant named by the X-Tenant-ID
+// header, else the tenant mapped to the request's host, else the default tenant. Unknown tenants are refused,
+// so a typo can't create a new, empty partition.
+func TenantMiddleware(tenantIDs []string, hosts map[string]string) gin.HandlerFunc {
+	known := make(map[string]bool, len(tenantIDs))
+	for _, id := range tenantIDs {
+		known[id] = true
+	}
+
+	return func(c *gin.Context) {
+		tenantID := c.GetHeader(TenantHeader)
+		if tenantID != "" && !known[tenantID] {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error: "Unknown tenant",
+				Code:  http.StatusBadRequest,
+			})
+			c.Abort()
+			return
+		}
+		if tenantID == "" {
+			host := c.Request.Host
+			if h, _, err := net.SplitHostPort(host); err == nil {
+				host = h
+			}
+			tenantID = hosts[strings.ToLower(host)]
+		}
+
+		if tenantID != "" {
+			c.Request = c.Request.WithContext(utils.WithTenant(c.Request.Context(), tenantID))
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// multipartOverhead is the allowance for form fields and multipart framing on top of the CV size limit
+const multipartOverhead = 1 << 20
+
+// limitUploadBody caps the request body so oversized uploads are rejected before they are buffered
+func limitUploadBody(c *gin.Context, maxFileBytes int64) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxFileBytes+multipartOverhead)
+}
+
+// readCVUpload reads and validates the "cv_file" form file.
+// Returns http.ErrMissingFile if the request has no CV file.
+func readCVUpload(c *gin.Context, maxCVBytes int64) ([]byte, *multipart.FileHeader, error) {
+	file, header, err := c.Request.FormFile("cv_file")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	data, err := utils.ReadCVFile(file, header, maxCVBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, header, nil
+}
+
+// isUploadError reports whether err is a size or type rejection of an uploaded file
+func isUploadError(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr) ||
+		errors.Is(err, utils.ErrFileTooLarge) ||
+		errors.Is(err, utils.ErrUnsupportedFileType)
+}
+
+// respondUploadError writes a 413/415/400 response for a rejected upload; label names the file ("CV", "Photo")
+func respondUploadError(c *gin.Context, err error, label string, maxBytes int64) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr) || errors.Is(err, utils.ErrFileTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, models.ErrorResponse{
+			Error:   label + " file is too large",
+			Code:    http.StatusRequestEntityTooLarge,
+			Details: fmt.Sprintf("Maximum file size is %d MB", maxBytes>>20),
+		})
+	case errors.Is(err, utils.ErrUnsupportedFileType):
+		c.JSON(http.StatusUnsupportedMediaType, models.ErrorResponse{
+			Error:   "Unsupported " + label + " file type",
+			Code:    http.StatusUnsupportedMediaType,
+			Details: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid " + label + " file",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/validation"
+)
+
+// respondValidationError writes a 400 response listing the request fields that failed validation
+func respondValidationError(c *gin.Context, errs validation.Errors) {
+	c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		Error:   "Invalid request",
+		Code:    http.StatusBadRequest,
+		Details: errs.Error(),
+		Fields:  errs,
+	})
+}
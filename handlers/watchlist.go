@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/utils"
+)
+
+var watchlistLog = logging.Component("WatchlistHandler")
+
+// WatchlistHandler handles company watchlist requests
+type WatchlistHandler struct {
+	store           storage.Store
+	defaultMinScore int
+}
+
+// NewWatchlistHandler creates a new watchlist handler
+func NewWatchlistHandler(store storage.Store, defaultMinScore int) *WatchlistHandler {
+	return &WatchlistHandler{
+		store:           store,
+		defaultMinScore: defaultMinScore,
+	}
+}
+
+// ListWatchlist returns the companies the user follows
+// @Summary List watched companies
+// @Description Get the companies the authenticated user follows for new-posting alerts
+// @Tags Watchlist
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.WatchlistResponse "Watched companies"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /watchlist [get]
+func (h *WatchlistHandler) ListWatchlist(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	companies, err := h.store.ListWatchedCompanies(c.Request.Context(), claims.UserID)
+	if err != nil {
+		watchlistLog.ErrorContext(c.Request.Context(), "Failed to list watchlist", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to load watchlist",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.WatchlistResponse{
+		Companies: companies,
+	})
+}
+
+// WatchCompany adds a company to the user's watchlist
+// @Summary Follow a company
+// @Description Follow a company by careers page URL or ATS board (greenhouse, lever, workable). New postings scoring at or above minScore are sent as alerts.
+// @Tags Watchlist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.WatchCompanyRequest true "Company to follow"
+// @Success 201 {object} models.WatchlistResponse "Company added"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /watchlist [post]
+func (h *WatchlistHandler) WatchCompany(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.WatchCompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	hasATS := req.ATSProvider != "" && req.ATSToken != ""
+	if !hasATS && req.CareersURL == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: "careersUrl or atsProvider and atsToken are required",
+		})
+		return
+	}
+
+	if req.CareersURL != "" && !utils.IsPublicHTTPURL(req.CareersURL) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: "careersUrl must be a public http or https URL",
+		})
+		return
+	}
+
+	minScore := h.defaultMinScore
+	if req.MinScore != nil {
+		minScore = *req.MinScore
+	}
+
+	company := &models.WatchedCompany{
+		UserID:      claims.UserID,
+		CompanyName: req.CompanyName,
+		CareersURL:  req.CareersURL,
+		ATSProvider: req.ATSProvider,
+		ATSToken:    req.ATSToken,
+		MinScore:    minScore,
+		SeenURLs:    []string{},
+	}
+
+	if err := h.store.AddWatchedCompany(c.Request.Context(), company); err != nil {
+		watchlistLog.ErrorContext(c.Request.Context(), "Failed to add watched company", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to add company to watchlist",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	watchlistLog.InfoContext(c.Request.Context(), "Company followed", "company", company.CompanyName)
+	c.JSON(http.StatusCreated, models.WatchlistResponse{
+		Companies: []models.WatchedCompany{*company},
+		Message:   "Company added to watchlist",
+	})
+}
+
+// UnwatchCompany removes a company from the user's watchlist
+// @Summary Unfollow a company
+// @Description Remove a company from the authenticated user's watchlist
+// @Tags Watchlist
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Watched company ID"
+// @Success 200 {object} models.WatchlistResponse "Company removed"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Watched company not found"
+// @Router /watchlist/{id} [delete]
+func (h *WatchlistHandler) UnwatchCompany(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.store.DeleteWatchedCompany(c.Request.Context(), claims.UserID, c.Param("id")); err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error:   "Watched company not found",
+			Code:    http.StatusNotFound,
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.WatchlistResponse{
+		Companies: []models.WatchedCompany{},
+		Message:   "Company removed from watchlist",
+	})
+}
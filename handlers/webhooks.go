@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/notify"
+	"github.com/myjobmatch/backend/storage"
+)
+
+var webhookLog = logging.Component("WebhookHandler")
+
+// WebhookHandler handles the per-user webhook that finished background searches are posted to
+type WebhookHandler struct {
+	store    storage.Store
+	webhooks *notify.Webhooks
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(store storage.Store, webhooks *notify.Webhooks) *WebhookHandler {
+	return &WebhookHandler{
+		store:    store,
+		webhooks: webhooks,
+	}
+}
+
+// GetWebhook returns the user's webhook URL
+// @Summary Get webhook
+// @Description Get the URL the authenticated user's finished background searches are posted to. The signing secret is only returned when the webhook is set.
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.WebhookResponse "Webhook"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "No webhook set"
+// @Router /webhook [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	user, err := h.store.GetUserByID(c.Request.Context(), claims.UserID)
+	if err != nil || user.WebhookURL == "" {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: "No webhook set",
+			Code:  http.StatusNotFound,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.WebhookResponse{
+		URL: user.WebhookURL,
+	})
+}
+
+// SetWebhook sets the user's webhook and creates a new signing secret for it
+// @Summary Set webhook
+// @Description Post the authenticated user's finished background searches (POST /search-jobs/async without a callback_url) to a URL, e.g. a Zapier catch hook. Each delivery is a JSON event signed with the returned secret in the X-MyJobMatch-Signature header. Setting the webhook again replaces the secret.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.WebhookRequest true "Webhook URL"
+// @Success 200 {object} models.WebhookResponse "Webhook set, with its signing secret"
+// @Failure 400 {object} models.ErrorResponse "Invalid request body or webhook URL"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /webhook [put]
+func (h *WebhookHandler) SetWebhook(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req models.WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid request body",
+			Code:    http.StatusBadRequest,
+			Details: err.Error(),
+		})
+		return
+	}
+	if err := h.webhooks.ValidateURL(req.URL); err != nil {
+		respondInvalidWebhookURL(c, err)
+		return
+	}
+
+	secret, err := notify.NewWebhookSecret()
+	if err != nil {
+		webhookLog.ErrorContext(c.Request.Context(), "Failed to generate webhook secret", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to set webhook",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+	if err := h.store.UpdateUserWebhook(c.Request.Context(), claims.Email, req.URL, secret); err != nil {
+		webhookLog.ErrorContext(c.Request.Context(), "Failed to set webhook", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to set webhook",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.WebhookResponse{
+		URL:     req.URL,
+		Secret:  secret,
+		Message: "Webhook set",
+	})
+}
+
+// DeleteWebhook removes the user's webhook
+// @Summary Delete webhook
+// @Description Stop posting the authenticated user's finished background searches
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.WebhookResponse "Webhook deleted"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /webhook [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	claims := auth.GetAuthClaims(c)
+	if claims == nil {
+		c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "Unauthorized",
+			Code:  http.StatusUnauthorized,
+		})
+		return
+	}
+
+	if err := h.store.UpdateUserWebhook(c.Request.Context(), claims.Email, "", ""); err != nil {
+		webhookLog.ErrorContext(c.Request.Context(), "Failed to delete webhook", "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error: "Failed to delete webhook",
+			Code:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.WebhookResponse{
+		Message: "Webhook deleted",
+	})
+}
+
+// respondInvalidWebhookURL writes a 400 response for a URL webhooks can't be delivered to
+func respondInvalidWebhookURL(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, models.ErrorResponse{
+		Error:   "Invalid webhook URL",
+		Code:    http.StatusBadRequest,
+		Details: err.Error(),
+	})
+}
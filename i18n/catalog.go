@@ -0,0 +1,189 @@
+package i18n
+
+// entry is an error message's stable code and its Bahasa Indonesia translation
+type entry struct {
+	code string
+	id   string
+}
+
+// catalog holds the API's error messages by their English text. A message's code must never change
+// once released, even if its wording does; add new messages here with a new code.
+var catalog = map[string]entry{
+	// Authentication and access
+	"Unauthorized":                                                {"unauthorized", "Tidak terautentikasi"},
+	"Authorization header required":                               {"authorization_required", "Header Authorization wajib diisi"},
+	"Invalid authorization header format":                         {"invalid_authorization_header", "Format header Authorization tidak valid"},
+	"Invalid or expired token":                                    {"invalid_token", "Token tidak valid atau sudah kedaluwarsa"},
+	"Administrator access required":                               {"admin_required", "Memerlukan akses administrator"},
+	"Account suspended":                                           {"account_suspended", "Akun ditangguhkan"},
+	"Invalid API token":                                           {"invalid_api_token", "Token API tidak valid"},
+	"API token has expired":                                       {"api_token_expired", "Token API sudah kedaluwarsa"},
+	"API token is missing the required scope":                     {"api_token_missing_scope", "Token API tidak memiliki cakupan yang diperlukan"},
+	"API tokens can't be used for this request":                   {"api_token_not_allowed", "Token API tidak dapat digunakan untuk permintaan ini"},
+	"Unknown tenant":                                              {"unknown_tenant", "Tenant tidak dikenal"},
+	"Invalid email or password":                                   {"invalid_credentials", "Email atau kata sandi salah"},
+	"Invalid password":                                            {"invalid_password", "Kata sandi salah"},
+	"Invalid Google token":                                        {"invalid_google_token", "Token Google tidak valid"},
+	"This account uses Google Sign-In. Please login with Google.": {"google_sign_in_required", "Akun ini menggunakan Google Sign-In. Silakan masuk dengan Google."},
+	"Google account is already linked to another user":            {"google_account_taken", "Akun Google sudah terhubung dengan pengguna lain"},
+	"Email already in use":                                        {"email_taken", "Email sudah digunakan"},
+	"New email is the same as the current one":                    {"email_unchanged", "Email baru sama dengan email saat ini"},
+	"Registration failed":                                         {"registration_failed", "Pendaftaran gagal"},
+	"Failed to create account":                                    {"create_account_failed", "Gagal membuat akun"},
+	"Failed to process registration":                              {"registration_processing_failed", "Gagal memproses pendaftaran"},
+	"Failed to generate token":                                    {"generate_token_failed", "Gagal membuat token"},
+	"Failed to send verification email":                           {"send_verification_email_failed", "Gagal mengirim email verifikasi"},
+	"Failed to start email change":                                {"start_email_change_failed", "Gagal memulai penggantian email"},
+	"Failed to change email":                                      {"change_email_failed", "Gagal mengganti email"},
+	"You can't suspend your own account":                          {"cannot_suspend_self", "Anda tidak dapat menangguhkan akun Anda sendiri"},
+
+	// Request validation
+	"Invalid request":                {"invalid_request", "Permintaan tidak valid"},
+	"Invalid request body":           {"invalid_request_body", "Isi permintaan tidak valid"},
+	"Failed to read request body":    {"read_request_body_failed", "Gagal membaca isi permintaan"},
+	"Invalid cursor":                 {"invalid_cursor", "Cursor tidak valid"},
+	"Invalid filter":                 {"invalid_filter", "Filter tidak valid"},
+	"Invalid status":                 {"invalid_status", "Status tidak valid"},
+	"Invalid profile":                {"invalid_profile", "Profil tidak valid"},
+	"Unsupported model":              {"unsupported_model", "Model tidak didukung"},
+	"Unsupported export format":      {"unsupported_export_format", "Format ekspor tidak didukung"},
+	"Invalid Idempotency-Key header": {"invalid_idempotency_key", "Header Idempotency-Key tidak valid"},
+	"Idempotency-Key was already used for a different request": {"idempotency_key_reused", "Idempotency-Key sudah digunakan untuk permintaan lain"},
+	"A request with this Idempotency-Key is still running":     {"idempotency_key_in_progress", "Permintaan dengan Idempotency-Key ini masih berjalan"},
+	"Invalid webhook URL": {"invalid_webhook_url", "URL webhook tidak valid"},
+
+	// CVs and photos
+	"CV file is required":                 {"cv_file_required", "File CV wajib diunggah"},
+	"CV text is required":                 {"cv_text_required", "Teks CV wajib diisi"},
+	"CV text or file is required":         {"cv_required", "Teks atau file CV wajib diisi"},
+	"CV file is too large":                {"cv_file_too_large", "File CV terlalu besar"},
+	"Unsupported CV file type":            {"unsupported_cv_file_type", "Jenis file CV tidak didukung"},
+	"Invalid CV file":                     {"invalid_cv_file", "File CV tidak valid"},
+	"Failed to read CV file":              {"read_cv_file_failed", "Gagal membaca file CV"},
+	"Failed to upload CV":                 {"upload_cv_failed", "Gagal mengunggah CV"},
+	"Failed to save CV reference":         {"save_cv_failed", "Gagal menyimpan CV"},
+	"Failed to check uploaded CV":         {"check_uploaded_cv_failed", "Gagal memeriksa CV yang diunggah"},
+	"Failed to create upload URL":         {"create_upload_url_failed", "Gagal membuat URL unggahan"},
+	"Uploaded CV not found":               {"uploaded_cv_not_found", "CV yang diunggah tidak ditemukan"},
+	"Direct CV uploads are not supported": {"direct_upload_unsupported", "Unggah CV langsung tidak didukung"},
+	"The CV file could not be read":       {"cv_unreadable", "File CV tidak dapat dibaca"},
+	"Photo file is required":              {"photo_file_required", "File foto wajib diunggah"},
+	"Photo file is too large":             {"photo_file_too_large", "File foto terlalu besar"},
+	"Unsupported Photo file type":         {"unsupported_photo_file_type", "Jenis file foto tidak didukung"},
+	"Invalid Photo file":                  {"invalid_photo_file", "File foto tidak valid"},
+	"Failed to upload photo":              {"upload_photo_failed", "Gagal mengunggah foto"},
+	"Failed to save photo reference":      {"save_photo_failed", "Gagal menyimpan foto"},
+	"Failed to remove photo":              {"remove_photo_failed", "Gagal menghapus foto"},
+	"No profile photo to remove":          {"no_profile_photo", "Tidak ada foto profil untuk dihapus"},
+
+	// Profiles
+	"No profile found. Upload your CV first.": {"profile_not_found", "Profil tidak ditemukan. Unggah CV Anda terlebih dahulu."},
+	"Failed to load profile":                  {"load_profile_failed", "Gagal memuat profil"},
+	"Failed to update profile":                {"update_profile_failed", "Gagal memperbarui profil"},
+	"Failed to update blocked companies":      {"update_blocked_companies_failed", "Gagal memperbarui daftar perusahaan yang diblokir"},
+
+	// Searches
+	"Please provide a CV file, CV text, or search query":              {"search_input_required", "Harap sertakan file CV, teks CV, atau kata kunci pencarian"},
+	"Please provide a search query or upload your CV in your profile": {"search_query_or_cv_required", "Harap isi kata kunci pencarian atau unggah CV di profil Anda"},
+	"Please provide CV text or upload your CV in your profile":        {"cv_text_or_profile_required", "Harap isi teks CV atau unggah CV di profil Anda"},
+	"Failed to prepare search":                                        {"prepare_search_failed", "Gagal menyiapkan pencarian"},
+	"Failed to start search":                                          {"start_search_failed", "Gagal memulai pencarian"},
+	"Failed to resume search":                                         {"resume_search_failed", "Gagal melanjutkan pencarian"},
+	"Failed to load search":                                           {"load_search_failed", "Gagal memuat pencarian"},
+	"Search not found":                                                {"search_not_found", "Pencarian tidak ditemukan"},
+	"Search has not completed":                                        {"search_not_completed", "Pencarian belum selesai"},
+	"Search is not resumable":                                         {"search_not_resumable", "Pencarian tidak dapat dilanjutkan"},
+	"Too many searches in progress, please retry shortly":             {"too_many_searches", "Terlalu banyak pencarian sedang berjalan, silakan coba lagi sebentar lagi"},
+	"The server is restarting, please retry":                          {"server_restarting", "Server sedang dimulai ulang, silakan coba lagi"},
+	"Job search failed":                                               {"job_search_failed", "Pencarian lowongan gagal"},
+	"Agent search failed":                                             {"agent_search_failed", "Pencarian dengan agen gagal"},
+	"Search session not found":                                        {"search_session_not_found", "Sesi pencarian tidak ditemukan"},
+	"Failed to load search session":                                   {"load_search_session_failed", "Gagal memuat sesi pencarian"},
+	"Failed to load search session results":                           {"load_search_session_results_failed", "Gagal memuat hasil sesi pencarian"},
+	"Search history entry not found":                                  {"search_history_not_found", "Riwayat pencarian tidak ditemukan"},
+	"Failed to load search history":                                   {"load_search_history_failed", "Gagal memuat riwayat pencarian"},
+	"Failed to delete search history entry":                           {"delete_search_history_failed", "Gagal menghapus riwayat pencarian"},
+
+	// AI service
+	"The AI service is at capacity, please retry later":             {"ai_capacity_exceeded", "Layanan AI sedang penuh, silakan coba lagi nanti"},
+	"The AI service is temporarily unavailable, please retry later": {"ai_unavailable", "Layanan AI sedang tidak tersedia, silakan coba lagi nanti"},
+	"The content was blocked by the AI safety filters":              {"content_blocked", "Konten diblokir oleh filter keamanan AI"},
+	"CV parsing failed":             {"cv_parsing_failed", "Gagal membaca CV"},
+	"CV tailoring failed":           {"cv_tailoring_failed", "Gagal menyesuaikan CV"},
+	"Job summarization failed":      {"job_summarization_failed", "Gagal meringkas lowongan"},
+	"The assistant failed to reply": {"assistant_reply_failed", "Asisten gagal membalas"},
+
+	// Jobs
+	"Job not found":                        {"job_not_found", "Lowongan tidak ditemukan"},
+	"Failed to load job":                   {"load_job_failed", "Gagal memuat lowongan"},
+	"Failed to list jobs":                  {"list_jobs_failed", "Gagal memuat daftar lowongan"},
+	"Failed to load similar jobs":          {"load_similar_jobs_failed", "Gagal memuat lowongan serupa"},
+	"Job title or description is required": {"job_title_or_description_required", "Judul atau deskripsi lowongan wajib diisi"},
+	"The job posting could not be fetched": {"job_posting_unavailable", "Lowongan tidak dapat diambil"},
+	"Failed to record feedback":            {"record_feedback_failed", "Gagal menyimpan penilaian"},
+	"Failed to build insights":             {"build_insights_failed", "Gagal menyusun wawasan pasar kerja"},
+	"Saved job not found":                  {"saved_job_not_found", "Lowongan tersimpan tidak ditemukan"},
+	"Saved job limit reached":              {"saved_job_limit_reached", "Batas lowongan tersimpan tercapai"},
+	"Failed to save job":                   {"save_job_failed", "Gagal menyimpan lowongan"},
+	"Failed to load saved jobs":            {"load_saved_jobs_failed", "Gagal memuat lowongan tersimpan"},
+	"Failed to remove saved job":           {"remove_saved_job_failed", "Gagal menghapus lowongan tersimpan"},
+
+	// Applications
+	"Application not found":        {"application_not_found", "Lamaran tidak ditemukan"},
+	"Failed to create application": {"create_application_failed", "Gagal membuat lamaran"},
+	"Failed to load application":   {"load_application_failed", "Gagal memuat lamaran"},
+	"Failed to load applications":  {"load_applications_failed", "Gagal memuat daftar lamaran"},
+	"Failed to update application": {"update_application_failed", "Gagal memperbarui lamaran"},
+	"Failed to delete application": {"delete_application_failed", "Gagal menghapus lamaran"},
+
+	// Job alerts and watchlist
+	"Job alert not found":                {"job_alert_not_found", "Notifikasi lowongan tidak ditemukan"},
+	"Job alert limit reached":            {"job_alert_limit_reached", "Batas notifikasi lowongan tercapai"},
+	"Failed to create job alert":         {"create_job_alert_failed", "Gagal membuat notifikasi lowongan"},
+	"Failed to load job alerts":          {"load_job_alerts_failed", "Gagal memuat notifikasi lowongan"},
+	"Failed to delete job alert":         {"delete_job_alert_failed", "Gagal menghapus notifikasi lowongan"},
+	"Watched company not found":          {"watched_company_not_found", "Perusahaan yang dipantau tidak ditemukan"},
+	"Failed to add company to watchlist": {"add_watched_company_failed", "Gagal menambahkan perusahaan ke daftar pantauan"},
+	"Failed to load watchlist":           {"load_watchlist_failed", "Gagal memuat daftar pantauan"},
+
+	// Sharing
+	"Share link not found":            {"share_link_not_found", "Tautan berbagi tidak ditemukan"},
+	"Share link not found or expired": {"share_link_expired", "Tautan berbagi tidak ditemukan atau sudah kedaluwarsa"},
+	"Share link limit reached":        {"share_link_limit_reached", "Batas tautan berbagi tercapai"},
+	"Failed to create share link":     {"create_share_link_failed", "Gagal membuat tautan berbagi"},
+	"Failed to load share links":      {"load_share_links_failed", "Gagal memuat tautan berbagi"},
+	"Failed to delete share link":     {"delete_share_link_failed", "Gagal menghapus tautan berbagi"},
+	"Failed to load shared results":   {"load_shared_results_failed", "Gagal memuat hasil yang dibagikan"},
+
+	// Career assistant
+	"Conversation not found":      {"conversation_not_found", "Percakapan tidak ditemukan"},
+	"Failed to load conversation": {"load_conversation_failed", "Gagal memuat percakapan"},
+	"Failed to save conversation": {"save_conversation_failed", "Gagal menyimpan percakapan"},
+
+	// API tokens and webhooks
+	"API token not found":        {"api_token_not_found", "Token API tidak ditemukan"},
+	"Token limit reached":        {"api_token_limit_reached", "Batas token API tercapai"},
+	"Failed to create API token": {"create_api_token_failed", "Gagal membuat token API"},
+	"Failed to load API tokens":  {"load_api_tokens_failed", "Gagal memuat token API"},
+	"Failed to revoke API token": {"revoke_api_token_failed", "Gagal mencabut token API"},
+	"No webhook set":             {"webhook_not_set", "Webhook belum diatur"},
+	"Failed to set webhook":      {"set_webhook_failed", "Gagal mengatur webhook"},
+	"Failed to delete webhook":   {"delete_webhook_failed", "Gagal menghapus webhook"},
+
+	// Administration
+	"User not found":                    {"user_not_found", "Pengguna tidak ditemukan"},
+	"Failed to get user":                {"get_user_failed", "Gagal memuat pengguna"},
+	"Failed to list users":              {"list_users_failed", "Gagal memuat daftar pengguna"},
+	"Failed to update user":             {"update_user_failed", "Gagal memperbarui pengguna"},
+	"Failed to migrate user IDs":        {"migrate_user_ids_failed", "Gagal memigrasikan ID pengguna"},
+	"Failed to get debug records":       {"get_debug_records_failed", "Gagal memuat catatan debug"},
+	"No debug records for this request": {"debug_records_not_found", "Tidak ada catatan debug untuk permintaan ini"},
+	"Failed to build experiment report": {"build_experiment_report_failed", "Gagal menyusun laporan eksperimen"},
+	"Failed to get tool audit log":      {"get_tool_audit_log_failed", "Gagal memuat log audit alat"},
+	"Tool not found":                    {"tool_not_found", "Alat tidak ditemukan"},
+	"Failed to update tool":             {"update_tool_failed", "Gagal memperbarui alat"},
+	"Backups are not configured":        {"backups_not_configured", "Pencadangan belum dikonfigurasi"},
+	"Failed to list backups":            {"list_backups_failed", "Gagal memuat daftar cadangan"},
+	"Failed to take backup":             {"take_backup_failed", "Gagal membuat cadangan"},
+	"Failed to restore backup":          {"restore_backup_failed", "Gagal memulihkan cadangan"},
+}
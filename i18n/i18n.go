@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Supported response languages
+const (
+	English    = "en"
+	Indonesian = "id"
+)
+
+// statusCodeReplacer turns a status text into a code: "I'm a teapot" into "im_a_teapot"
+var statusCodeReplacer = strings.NewReplacer(" ", "_", "-", "_", "'", "")
+
+// matcher picks the supported language closest to an Accept-Language header, English by default
+var matcher = language.NewMatcher([]language.Tag{language.English, language.Indonesian})
+
+// Language returns the supported language that best matches an Accept-Language header
+func Language(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return English
+	}
+	tag, _ := language.MatchStrings(matcher, acceptLanguage)
+	if base, _ := tag.Base(); base.String() == Indonesian {
+		return Indonesian
+	}
+	return English
+}
+
+// Code returns the stable machine-readable code of an error message, for clients that show their own
+// translations. Messages missing from the catalog get a code named after the status, e.g. "bad_request".
+func Code(message string, status int) string {
+	if entry, _, ok := lookup(message); ok {
+		return entry.code
+	}
+	return strings.ToLower(statusCodeReplacer.Replace(http.StatusText(status)))
+}
+
+// Translate returns an error message in lang. Messages missing from the catalog, and all messages in
+// English, are returned as they are.
+func Translate(lang, message string) string {
+	if lang != Indonesian {
+		return message
+	}
+	entry, suffix, ok := lookup(message)
+	if !ok {
+		return message
+	}
+	return entry.id + suffix
+}
+
+// lookup finds the catalog entry of a message. A message that isn't in the catalog as a whole, like
+// "API token is missing the required scope: search", is looked up by the text before its first ": ",
+// and the rest is returned as the suffix to keep.
+func lookup(message string) (entry, string, bool) {
+	if e, ok := catalog[message]; ok {
+		return e, "", true
+	}
+	if i := strings.Index(message, ": "); i > 0 {
+		if e, ok := catalog[message[:i]]; ok {
+			return e, message[i:], true
+		}
+	}
+	return entry{}, "", false
+}
@@ -0,0 +1,114 @@
+// Package logging sets up structured logging with log/slog. Records are written as JSON lines whose
+// severity and message fields Cloud Logging recognizes, and records made with a request's context carry
+// its request ID, user and tenant, so a request's logs can be filtered together.
+package logging
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+
+	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// Setup makes slog's default logger write records at or above LOG_LEVEL in LOG_FORMAT. The standard log
+// package is routed through it too, so remaining log.Printf calls are written as info records.
+func Setup(cfg *config.Config) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+		level = slog.LevelInfo
+	}
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: cloudLoggingAttr}
+
+	var handler slog.Handler
+	if cfg.LogFormat == config.LogFormatText {
+		handler = slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})
+	} else {
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	}
+	slog.SetDefault(slog.New(contextHandler{handler}))
+	log.SetFlags(0) // slog adds the time
+}
+
+// cloudLoggingAttr renames the level and message to the severity and message fields of Cloud Logging's
+// structured logs
+func cloudLoggingAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+	switch a.Key {
+	case slog.LevelKey:
+		severity := a.Value.String()
+		if severity == slog.LevelWarn.String() {
+			severity = "WARNING"
+		}
+		return slog.String("severity", severity)
+	case slog.MessageKey:
+		return slog.Attr{Key: "message", Value: a.Value}
+	}
+	return a
+}
+
+// contextHandler adds the request ID, user and tenant attached to a record's context
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := utils.RequestIDFromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if email := utils.CallerFromContext(ctx).Email; email != "" {
+		r.AddAttrs(slog.String("user", email))
+	}
+	if tenantID := utils.TenantFromContext(ctx); tenantID != "" {
+		r.AddAttrs(slog.String("tenant", tenantID))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// Component returns a logger whose records carry the name of the component making them. It may be created
+// before Setup runs, e.g. in a package variable: records go to the default logger current when they are made.
+func Component(name string) *slog.Logger {
+	return slog.New(componentHandler{
+		with: func(h slog.Handler) slog.Handler {
+			return h.WithAttrs([]slog.Attr{slog.String("component", name)})
+		},
+	})
+}
+
+// componentHandler passes records to the default handler, after adding the component and any attributes
+// and groups added to the logger
+type componentHandler struct {
+	with func(slog.Handler) slog.Handler
+}
+
+func (h componentHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return slog.Default().Handler().Enabled(ctx, level)
+}
+
+func (h componentHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.with(slog.Default().Handler()).Handle(ctx, r)
+}
+
+func (h componentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return componentHandler{with: func(base slog.Handler) slog.Handler {
+		return h.with(base).WithAttrs(attrs)
+	}}
+}
+
+func (h componentHandler) WithGroup(name string) slog.Handler {
+	return componentHandler{with: func(base slog.Handler) slog.Handler {
+		return h.with(base).WithGroup(name)
+	}}
+}
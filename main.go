@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,16 +15,25 @@ import (
 	"github.com/joho/godotenv"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"google.golang.org/grpc"
 
 	"github.com/myjobmatch/backend/agent"
 	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/cache"
 	"github.com/myjobmatch/backend/config"
 	_ "github.com/myjobmatch/backend/docs"
 	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/grpcapi"
 	"github.com/myjobmatch/backend/handlers"
+	"github.com/myjobmatch/backend/logging"
 	"github.com/myjobmatch/backend/mcp"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/notify"
+	"github.com/myjobmatch/backend/profile"
+	"github.com/myjobmatch/backend/search"
 	"github.com/myjobmatch/backend/storage"
 	"github.com/myjobmatch/backend/tools"
+	"github.com/myjobmatch/backend/worker"
 )
 
 // @title MyJobMatch API
@@ -45,6 +55,11 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 
+// shutdownGrace is how long requests may still take after the search drain deadline on shutdown
+const shutdownGrace = 5 * time.Second
+
+var serverLog = logging.Component("Server")
+
 func main() {
 	// Load .env file if present (for local development)
 	if err := godotenv.Load(); err != nil {
@@ -58,6 +73,7 @@ func main() {
 	if err := cfg.Validate(); err != nil {
 		log.Fatalf("Configuration error: %v", err)
 	}
+	logging.Setup(cfg)
 
 	// Set Gin mode based on debug setting
 	if cfg.Debug {
@@ -69,87 +85,268 @@ func main() {
 	// Create context for initialization
 	ctx := context.Background()
 
-	// Initialize Firestore client
-	log.Println("Initializing Firestore client...")
-	firestoreClient, err := storage.NewFirestoreClient(ctx, cfg)
+	// Initialize the database (Firestore, or Postgres when self-hosting)
+	log.Printf("Initializing %s storage...", cfg.StorageBackend)
+	store, err := storage.NewStore(ctx, cfg)
 	if err != nil {
-		log.Fatalf("Failed to initialize Firestore client: %v", err)
+		log.Fatalf("Failed to initialize %s storage: %v", cfg.StorageBackend, err)
+	}
+	defer store.Close()
+	log.Println("Storage initialized successfully")
+
+	// Connect to the optional Redis cache; without it caches and rate limits stay per instance
+	var redisCache *cache.RedisCache
+	if cfg.RedisURL != "" {
+		redisCache, err = cache.NewRedisCache(ctx, cfg)
+		if err != nil {
+			log.Printf("Redis unavailable, continuing without it: %v", err)
+		} else {
+			defer redisCache.Close()
+			log.Println("Redis cache initialized successfully")
+		}
 	}
-	defer firestoreClient.Close()
-	log.Println("Firestore client initialized successfully")
 
-	// Initialize Cloud Storage client
-	log.Println("Initializing Cloud Storage client...")
-	storageClient, err := storage.NewCloudStorageClient(ctx, cfg)
+	// Initialize CV and photo storage (Cloud Storage, or a local directory for development)
+	log.Printf("Initializing %s CV storage...", cfg.CVStorageBackend)
+	storageClient, err := storage.NewCVStorage(ctx, cfg)
 	if err != nil {
-		log.Fatalf("Failed to initialize Cloud Storage client: %v", err)
+		log.Fatalf("Failed to initialize %s CV storage: %v", cfg.CVStorageBackend, err)
 	}
 	defer storageClient.Close()
-	log.Println("Cloud Storage client initialized successfully")
+	log.Println("CV storage initialized successfully")
+	if gcs, ok := storageClient.(*storage.CloudStorageClient); ok && cfg.CVLifecycleRulesEnabled {
+		// Cleanup rules are not needed to serve requests; a missing bucket permission is only logged
+		if err := gcs.ApplyLifecycleRules(ctx, cfg.CVVersionRetentionDays); err != nil {
+			log.Printf("Failed to apply CV bucket lifecycle rules: %v", err)
+		} else {
+			log.Println("CV bucket lifecycle rules applied")
+		}
+	}
 
 	// Initialize auth services
 	jwtService := auth.NewJWTService(cfg)
+	suspensions := auth.NewSuspensionChecker(store, time.Duration(cfg.SuspensionCheckCacheSeconds)*time.Second)
+	jwtService.CheckSuspensions(suspensions)
 	googleAuthService := auth.NewGoogleAuthService(cfg)
 
 	// Initialize the job agent
 	log.Println("Initializing job agent...")
-	jobAgent, err := agent.NewJobAgent(ctx, cfg)
+	var jobCache agent.JobCache
+	if cfg.JobCacheTTLHours > 0 {
+		jobCache = store
+		if redisCache != nil {
+			jobCache = cache.NewJobCache(redisCache, store)
+		}
+	}
+	var jobIndex agent.JobIndex
+	if cfg.JobIndexMaxAgeHours > 0 {
+		jobIndex = store
+	}
+	var companyCache agent.CompanyCache
+	if cfg.CompanyCacheTTLDays > 0 {
+		companyCache = store
+	}
+	var llmDebugStore gemini.DebugStore
+	if cfg.LLMDebugLogEnabled {
+		llmDebugStore = store
+	}
+	jobAgent, err := agent.NewJobAgent(ctx, cfg, jobCache, jobIndex, companyCache, llmDebugStore)
 	if err != nil {
 		log.Fatalf("Failed to initialize job agent: %v", err)
 	}
 	defer jobAgent.Close()
+	if cfg.JobCorpusEnabled {
+		jobAgent.SetJobStore(store)
+	}
+	if cfg.SearchSessionTTLDays > 0 {
+		jobAgent.SetSearchSessionStore(store, time.Duration(cfg.SearchSessionTTLDays)*24*time.Hour)
+	}
+	if redisCache != nil {
+		jobAgent.SetToolResultCache(redisCache)
+	}
 	log.Println("Job agent initialized successfully")
 
-	// Create handlers
-	searchHandler := handlers.NewSearchHandler(jobAgent, firestoreClient, storageClient)
-	cvHandler := handlers.NewCVHandler(jobAgent)
-	authHandler := handlers.NewAuthHandler(firestoreClient, jwtService, googleAuthService)
+	// Initialize profile service (saved structured profiles)
+	profileService := profile.NewService(jobAgent, store, storageClient)
 
-	// Create MCP server with tool registry
-	geminiClient, err := gemini.NewClient(ctx, cfg)
-	if err != nil {
-		log.Fatalf("Failed to create Gemini client for MCP: %v", err)
+	// Initialize search service (shared by the REST and gRPC APIs)
+	searchService := search.NewService(jobAgent, store, storageClient, profileService, models.LLMBudget{
+		MaxCalls:   cfg.AnonMaxGeminiCalls,
+		MaxTokens:  cfg.AnonMaxTokens,
+		MaxCostUSD: cfg.AnonMaxCostUSD,
+	})
+
+	// Background work runs until shutdown
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	searchQueue := worker.NewQueue(cfg.AsyncSearchWorkers, cfg.AsyncSearchQueueSize,
+		time.Duration(cfg.AsyncSearchTimeoutMinutes)*time.Minute)
+	searchQueue.Start(workerCtx)
+	searches := worker.NewSearchRegistry()
+	webhooks := notify.NewWebhooks(cfg)
+
+	// Create handlers
+	maxCVBytes := int64(cfg.MaxCVFileSizeMB) << 20
+	searchHandler := handlers.NewSearchHandler(jobAgent, store, profileService, searchService, maxCVBytes, searchQueue, searches, webhooks)
+	cvHandler := handlers.NewCVHandler(jobAgent, profileService)
+	authHandler := handlers.NewAuthHandler(store, jwtService, googleAuthService, maxCVBytes, int64(cfg.MaxPhotoFileSizeMB)<<20,
+		time.Duration(cfg.CVUploadURLTTLMinutes)*time.Minute)
+	watchlistHandler := handlers.NewWatchlistHandler(store, cfg.WatchlistMinScore)
+	profileHandler := handlers.NewProfileHandler(store, profileService)
+	apiTokenHandler := handlers.NewAPITokenHandler(store)
+	searchHistoryHandler := handlers.NewSearchHistoryHandler(store)
+	applicationHandler := handlers.NewApplicationHandler(store)
+	blocklistHandler := handlers.NewBlocklistHandler(store)
+	webhookHandler := handlers.NewWebhookHandler(store, webhooks)
+	savedJobHandler := handlers.NewSavedJobHandler(jobAgent, store, profileService)
+	jobHandler := handlers.NewJobHandler(jobAgent, store, time.Duration(cfg.JobSummaryCacheTTLHours)*time.Hour)
+	shareHandler := handlers.NewShareHandler(store, profileService)
+	insightsHandler := handlers.NewInsightsHandler(store)
+	alertHandler := handlers.NewAlertHandler(store, profileService, cfg.JobAlertMinScore, cfg.MaxJobAlertsPerUser)
+	notifier := notify.NewNotifier(cfg)
+	emailChangeHandler := handlers.NewEmailChangeHandler(store, jwtService, suspensions, notifier,
+		time.Duration(cfg.EmailChangeTokenTTLMinutes)*time.Minute, cfg.EmailChangeConfirmURL)
+	idempotent := handlers.IdempotencyMiddleware(store, time.Duration(cfg.IdempotencyKeyTTLHours)*time.Hour, maxCVBytes, []byte(cfg.JWTSecret))
+	idempotentRegistration := handlers.CredentialIdempotencyMiddleware(store, time.Duration(cfg.IdempotencyKeyTTLHours)*time.Hour,
+		[]byte(cfg.JWTSecret), authHandler.ReissueRegistration)
+	chatHandler := handlers.NewChatHandler(jobAgent, store, profileService, cfg.ChatHistoryMessages, time.Duration(cfg.ChatSessionTTLDays)*24*time.Hour)
+
+	// Start background workers
+	if cfg.WatchlistWorkerEnabled {
+		watchlistWorker := worker.NewWatchlistWorker(cfg, jobAgent, store, profileService, notifier)
+		go watchlistWorker.Start(workerCtx)
+	}
+	if cfg.JobAlertsEnabled {
+		alertScheduler := worker.NewAlertScheduler(cfg, jobAgent, store, profileService, notifier)
+		go alertScheduler.Start(workerCtx)
+	}
+	if cfg.CrawlerEnabled {
+		crawler := worker.NewCrawler(cfg, jobAgent, store)
+		go crawler.Start(workerCtx)
+	}
+	if cfg.CVRetentionEnabled {
+		cvRetention := worker.NewCVRetention(cfg, store, storageClient)
+		go cvRetention.Start(workerCtx)
+	}
+	var backups *worker.Backup
+	if cfg.BackupBucketName != "" {
+		backupBucket, err := storage.NewBackupBucket(ctx, cfg)
+		if err != nil {
+			log.Fatalf("Failed to initialize backup bucket: %v", err)
+		}
+		defer backupBucket.Close()
+		backups = worker.NewBackup(cfg, store, backupBucket)
+		if cfg.BackupEnabled {
+			go backups.Start(workerCtx)
+		}
 	}
-	defer geminiClient.Close()
+
+	// Create MCP server with tool registry. The tools share the agent's Gemini client, so the
+	// rate limit and quotas apply to all Gemini calls made by this process.
+	geminiClient := jobAgent.GeminiClient()
 
 	toolRegistry := tools.NewToolRegistry()
-	toolRegistry.Register(tools.NewSearchWebTool(cfg))
+	mcpSearchTool := tools.NewSearchWebTool(cfg)
+	toolRegistry.Register(mcpSearchTool)
 	toolRegistry.Register(tools.NewFetchPageTool(cfg))
 	toolRegistry.Register(tools.NewExtractJobTool(geminiClient))
 	toolRegistry.Register(tools.NewScoreJobTool(geminiClient))
 	toolRegistry.Register(tools.NewParseCVTool(geminiClient))
+	toolRegistry.Register(tools.NewTailorCVTool(geminiClient))
+	toolRegistry.Register(tools.NewATSBoardsTool(cfg))
+	toolRegistry.Register(tools.NewRemoteBoardsTool(cfg))
+	toolRegistry.Register(tools.NewCompanyResearchTool(mcpSearchTool, geminiClient))
+	toolRegistry.Register(agent.NewSearchJobsTool(jobAgent))
+	if err := toolRegistry.SetTimeouts(cfg); err != nil {
+		log.Fatalf("Failed to configure tool timeouts: %v", err)
+	}
+	if err := toolRegistry.SetCaches(cfg); err != nil {
+		log.Fatalf("Failed to configure tool caches: %v", err)
+	}
+	if redisCache != nil {
+		toolRegistry.SetSharedCache(redisCache)
+	}
+	// Quotas apply to MCP clients; agent search is bounded by its step limit and LLM budget
+	if err := toolRegistry.SetQuotas(cfg); err != nil {
+		log.Fatalf("Failed to configure tool quotas: %v", err)
+	}
+	// Tool switches are shared by the MCP and agent registries and reloaded from Firestore by every instance
+	toolSwitches := tools.NewToolSwitches(cfg.DisabledTools)
+	toolRegistry.SetSwitches(toolSwitches)
+	jobAgent.SetToolSwitches(toolSwitches)
+	go toolSwitches.Watch(workerCtx, store, time.Duration(cfg.ToolSettingsRefreshSeconds)*time.Second)
+
+	if cfg.ToolAuditEnabled {
+		auditTTL := time.Duration(cfg.ToolAuditTTLDays) * 24 * time.Hour
+		toolRegistry.SetAuditStore(store, models.ToolAuditSourceMCP, auditTTL)
+		jobAgent.SetToolAuditStore(store, auditTTL)
+	}
 
-	mcpServer := mcp.NewServer(toolRegistry)
+	mcpServer := mcp.NewServer(toolRegistry, cfg)
+	if redisCache != nil {
+		mcpServer.SetRateLimitCounter(redisCache)
+	}
+	adminHandler := handlers.NewAdminHandler(store, toolRegistry, toolSwitches, backups, suspensions)
+
+	// Dependencies probed by the health endpoints; only the database and CV storage fail readiness,
+	// since searches degrade gracefully without the others
+	healthCacheTTL := time.Duration(cfg.HealthCheckCacheSeconds) * time.Second
+	healthDeps := []handlers.Dependency{
+		{Name: "database", Critical: true, CacheTTL: healthCacheTTL, Probe: store.Ping},
+		{Name: "cv_storage", Critical: true, CacheTTL: healthCacheTTL, Probe: storageClient.Ping},
+		{Name: "llm", CacheTTL: healthCacheTTL, Probe: geminiClient.Ping},
+	}
+	if cfg.HealthCheckPSECacheMinutes > 0 {
+		healthDeps = append(healthDeps, handlers.Dependency{
+			Name: "pse", CacheTTL: time.Duration(cfg.HealthCheckPSECacheMinutes) * time.Minute, Probe: mcpSearchTool.Ping,
+		})
+	}
+	if redisCache != nil {
+		healthDeps = append(healthDeps, handlers.Dependency{Name: "redis", CacheTTL: healthCacheTTL, Probe: redisCache.Ping})
+	}
+	healthHandler := handlers.NewHealthHandler(healthDeps, time.Duration(cfg.HealthCheckTimeoutSeconds)*time.Second)
 
 	// Create Gin router
 	router := gin.New()
 
 	// Add middleware
 	router.Use(gin.Recovery())
-	router.Use(gin.Logger())
+	router.Use(handlers.RequestIDMiddleware())
+	router.Use(handlers.RequestLogMiddleware())
+	router.Use(handlers.LocalizeErrorsMiddleware())
 
-	// Configure CORS for Vue frontend
+	// Configure CORS for the Vue frontends of this environment (CORS_ALLOWED_ORIGINS)
 	router.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"http://localhost:3000", "http://localhost:5173", "*"},
+		AllowOrigins:     cfg.CORSAllowedOrigins,
+		AllowWildcard:    true,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", handlers.RequestIDHeader, handlers.TenantHeader, handlers.IdempotencyKeyHeader},
+		ExposeHeaders:    []string{"Content-Length", handlers.RequestIDHeader, handlers.IdempotentReplayedHeader},
+		AllowCredentials: cfg.CORSAllowCredentials,
 		MaxAge:           12 * time.Hour,
 	}))
+	router.Use(handlers.TenantMiddleware(cfg.TenantIDs(), cfg.TenantHosts()))
 
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// Register routes
-	router.GET("/health", handlers.HealthCheck)
+	router.GET("/health", healthHandler.HealthCheck)
+	router.GET("/health/live", healthHandler.Live)
+	router.GET("/health/ready", healthHandler.Ready)
+
+	// Profile photos kept on local disk are served by the API itself; CVs are never served
+	if local, ok := storageClient.(*storage.LocalStorageClient); ok {
+		router.StaticFS(local.PhotoURLPath(), gin.Dir(local.PhotoDir(), false))
+	}
 
 	api := router.Group("/api")
 	{
 		// Auth endpoints (public)
 		authGroup := api.Group("/auth")
 		{
-			authGroup.POST("/register", authHandler.Register)
+			authGroup.POST("/register", idempotentRegistration, authHandler.Register)
 			authGroup.POST("/login", authHandler.Login)
 			authGroup.POST("/google", authHandler.GoogleLogin)
 		}
@@ -160,22 +357,186 @@ func main() {
 		{
 			authProtected.GET("/profile", authHandler.GetProfile)
 			authProtected.PUT("/profile", authHandler.UpdateProfile)
+			authProtected.POST("/email", emailChangeHandler.RequestEmailChange)
+			authProtected.POST("/email/confirm", emailChangeHandler.ConfirmEmailChange)
 			authProtected.POST("/cv", func(c *gin.Context) {
 				authHandler.UploadCV(c, storageClient)
 			})
+			authProtected.POST("/cv/upload-url", func(c *gin.Context) {
+				authHandler.CreateCVUploadURL(c, storageClient)
+			})
+			authProtected.POST("/cv/confirm", func(c *gin.Context) {
+				authHandler.ConfirmCVUpload(c, storageClient)
+			})
+			authProtected.POST("/photo", func(c *gin.Context) {
+				authHandler.UploadPhoto(c, storageClient)
+			})
+			authProtected.DELETE("/photo", func(c *gin.Context) {
+				authHandler.DeletePhoto(c, storageClient)
+			})
 		}
 
-		// Job search endpoint (optional auth - uses saved CV if authenticated)
-		api.POST("/search-jobs", auth.OptionalAuthMiddleware(jwtService), searchHandler.SearchJobs)
+		// Structured profile endpoints (require authentication; reads also accept API tokens)
+		profileGroup := api.Group("/profile")
+		{
+			profileGroup.GET("/structured", auth.TokenAuthMiddleware(jwtService, store, auth.ScopeReadProfile), profileHandler.GetStructuredProfile)
+			profileGroup.PATCH("/structured", auth.AuthMiddleware(jwtService), profileHandler.UpdateStructuredProfile)
+		}
+
+		// Personal access token management (require a login session, not an API token)
+		tokens := api.Group("/tokens")
+		tokens.Use(auth.AuthMiddleware(jwtService))
+		{
+			tokens.GET("", apiTokenHandler.ListAPITokens)
+			tokens.POST("", apiTokenHandler.CreateAPIToken)
+			tokens.DELETE("/:id", apiTokenHandler.RevokeAPIToken)
+		}
+
+		// Company watchlist endpoints (require authentication)
+		watchlist := api.Group("/watchlist")
+		watchlist.Use(auth.AuthMiddleware(jwtService))
+		{
+			watchlist.GET("", watchlistHandler.ListWatchlist)
+			watchlist.POST("", watchlistHandler.WatchCompany)
+			watchlist.DELETE("/:id", watchlistHandler.UnwatchCompany)
+		}
+
+		// Company blocklist endpoints (require authentication)
+		blockedCompanies := api.Group("/blocked-companies")
+		blockedCompanies.Use(auth.AuthMiddleware(jwtService))
+		{
+			blockedCompanies.GET("", blocklistHandler.GetBlockedCompanies)
+			blockedCompanies.PUT("", blocklistHandler.UpdateBlockedCompanies)
+		}
+
+		// Webhook endpoints (require authentication)
+		webhook := api.Group("/webhook")
+		webhook.Use(auth.AuthMiddleware(jwtService))
+		{
+			webhook.GET("", webhookHandler.GetWebhook)
+			webhook.PUT("", webhookHandler.SetWebhook)
+			webhook.DELETE("", webhookHandler.DeleteWebhook)
+		}
+
+		// Saved job endpoints (require authentication)
+		savedJobs := api.Group("/jobs/saved")
+		savedJobs.Use(auth.AuthMiddleware(jwtService))
+		{
+			savedJobs.GET("", savedJobHandler.ListSavedJobs)
+			savedJobs.POST("", savedJobHandler.SaveJob)
+			savedJobs.POST("/rescore", savedJobHandler.RescoreSavedJobs)
+			savedJobs.DELETE("/:id", savedJobHandler.DeleteSavedJob)
+		}
+
+		// Posting summaries for mobile display (public, cached by URL)
+		api.POST("/jobs/summarize", jobHandler.SummarizeJob)
+
+		// Stored job corpus, job details (optional auth - includes the user's score if authenticated) and match feedback
+		api.GET("/jobs", jobHandler.ListJobs)
+		api.GET("/jobs/:id", auth.OptionalAuthMiddleware(jwtService), jobHandler.GetJob)
+		api.POST("/jobs/:id/feedback", auth.AuthMiddleware(jwtService), jobHandler.SubmitJobFeedback)
+		api.GET("/jobs/:id/similar", jobHandler.GetSimilarJobs)
+
+		// Share link management (require authentication); shared results are public
+		shared := api.Group("/shared")
+		{
+			shared.GET("", auth.AuthMiddleware(jwtService), shareHandler.ListSharedSearches)
+			shared.POST("", auth.AuthMiddleware(jwtService), shareHandler.CreateSharedSearch)
+			shared.GET("/:token", shareHandler.GetSharedSearch)
+			shared.DELETE("/:token", auth.AuthMiddleware(jwtService), shareHandler.DeleteSharedSearch)
+		}
+
+		// Job alert endpoints (require authentication)
+		alerts := api.Group("/alerts")
+		alerts.Use(auth.AuthMiddleware(jwtService))
+		{
+			alerts.GET("", alertHandler.ListAlerts)
+			alerts.POST("", alertHandler.CreateAlert)
+			alerts.DELETE("/:id", alertHandler.DeleteAlert)
+		}
+
+		// Search history endpoints (require authentication)
+		searchHistory := api.Group("/search-history")
+		searchHistory.Use(auth.AuthMiddleware(jwtService))
+		{
+			searchHistory.GET("", searchHistoryHandler.ListSearchHistory)
+			searchHistory.DELETE("/:id", searchHistoryHandler.DeleteSearchHistory)
+		}
+
+		// Recorded search sessions (optional auth - sessions of authenticated searches are only visible to their owner)
+		api.GET("/search-sessions/:id", auth.OptionalTokenAuthMiddleware(jwtService, store, auth.ScopeSearch), searchHistoryHandler.GetSearchSession)
+		api.GET("/search-sessions/:id/results", auth.OptionalTokenAuthMiddleware(jwtService, store, auth.ScopeSearch), searchHistoryHandler.ListSearchSessionResults)
+
+		// Application tracker endpoints (require authentication)
+		applications := api.Group("/applications")
+		applications.Use(auth.AuthMiddleware(jwtService))
+		{
+			applications.GET("", applicationHandler.ListApplications)
+			applications.GET("/board", applicationHandler.GetApplicationBoard)
+			applications.POST("", applicationHandler.CreateApplication)
+			applications.GET("/:id", applicationHandler.GetApplication)
+			applications.PATCH("/:id", applicationHandler.UpdateApplication)
+			applications.DELETE("/:id", applicationHandler.DeleteApplication)
+		}
+
+		// Job search endpoint (optional auth - uses saved CV if authenticated; accepts API tokens with the search scope)
+		api.POST("/search-jobs", auth.OptionalTokenAuthMiddleware(jwtService, store, auth.ScopeSearch), idempotent, searchHandler.SearchJobs)
+
+		// Agent search: Gemini decides which tools to call for open-ended requests
+		api.POST("/search-jobs/agent", auth.OptionalTokenAuthMiddleware(jwtService, store, auth.ScopeSearch), idempotent, searchHandler.AgentSearch)
+
+		// Background job search (returns a search ID to poll)
+		api.POST("/search-jobs/async", auth.OptionalTokenAuthMiddleware(jwtService, store, auth.ScopeSearch), idempotent, searchHandler.SearchJobsAsync)
+		api.GET("/search-jobs/:id", auth.OptionalTokenAuthMiddleware(jwtService, store, auth.ScopeSearch), searchHandler.GetSearchStatus)
+		api.POST("/search-jobs/:id/resume", auth.OptionalTokenAuthMiddleware(jwtService, store, auth.ScopeSearch), searchHandler.ResumeSearch)
+		api.GET("/search-jobs/:id/export", auth.OptionalTokenAuthMiddleware(jwtService, store, auth.ScopeSearch), searchHandler.ExportSearchResults)
 
 		// CV parsing endpoint
 		api.POST("/parse-cv", cvHandler.ParseCV)
 
+		// CV tailoring endpoint (optional auth - uses saved profile if authenticated)
+		api.POST("/cv/tailor", auth.OptionalAuthMiddleware(jwtService), cvHandler.TailorCV)
+
+		// Career assistant conversations (require authentication)
+		chat := api.Group("/chat")
+		chat.Use(auth.AuthMiddleware(jwtService))
+		{
+			chat.POST("", chatHandler.Chat)
+			chat.GET("/:id", chatHandler.GetChatSession)
+		}
+
+		// Job market insights (public, aggregated from stored jobs)
+		api.GET("/insights", insightsHandler.GetInsights)
+
 		// Tools introspection endpoint
 		api.GET("/tools", searchHandler.GetTools)
 
-		// MCP endpoints for external AI agents
-		mcpServer.RegisterRoutes(api)
+		// Admin endpoints (require an administrator listed in ADMIN_EMAILS)
+		admin := api.Group("/admin")
+		admin.Use(auth.AuthMiddleware(jwtService), auth.AdminMiddleware(cfg.AdminEmails))
+		{
+			admin.GET("/llm-debug/:requestId", adminHandler.GetLLMDebugRecords)
+			admin.GET("/prompt-experiments", adminHandler.GetPromptExperiments)
+			admin.GET("/tool-audit", adminHandler.GetToolAudit)
+			admin.GET("/tools", adminHandler.ListTools)
+			admin.PUT("/tools/:name", adminHandler.UpdateTool)
+			admin.GET("/users", adminHandler.ListUsers)
+			admin.POST("/users/migrate-ids", adminHandler.MigrateUserIDs)
+			admin.POST("/users/:id/suspend", adminHandler.SuspendUser)
+			admin.POST("/users/:id/reactivate", adminHandler.ReactivateUser)
+			admin.GET("/backups", adminHandler.ListBackups)
+			admin.POST("/backups", adminHandler.CreateBackup)
+			admin.POST("/backups/:id/restore", adminHandler.RestoreBackup)
+		}
+
+		// MCP endpoints for external AI agents (require a session or an API token with the mcp scope)
+		mcpGroup := api.Group("")
+		if cfg.MCPAuthRequired {
+			mcpGroup.Use(auth.TokenAuthMiddleware(jwtService, store, auth.ScopeMCP))
+		} else {
+			log.Println("MCP_AUTH_REQUIRED is off: the MCP endpoints are open to anyone")
+		}
+		mcpServer.RegisterRoutes(mcpGroup)
 	}
 
 	// Create HTTP server
@@ -195,6 +556,22 @@ func main() {
 		}
 	}()
 
+	// Start the gRPC API for internal services alongside the REST API, if enabled
+	var grpcServer *grpc.Server
+	if cfg.GRPCPort != "" {
+		listener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			log.Fatalf("Failed to listen on gRPC port %s: %v", cfg.GRPCPort, err)
+		}
+		grpcServer = grpcapi.NewServer(jwtService, store, searchService, profileService, searches, cfg.TenantIDs(), maxCVBytes)
+		go func() {
+			serverLog.Info("Starting gRPC server", "port", cfg.GRPCPort)
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("gRPC server error: %v", err)
+			}
+		}()
+	}
+
 	// Wait for interrupt signal for graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -202,13 +579,40 @@ func main() {
 
 	log.Println("Shutting down server...")
 
-	// Give outstanding requests 30 seconds to complete
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Running searches get the drain period to finish; the rest are checkpointed and cancelled. Background
+	// workers keep running until then, so queued searches are drained too.
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownDrainSeconds)*time.Second)
+	defer cancelDrain()
+	interrupted := make(chan int, 1)
+	go func() { interrupted <- searches.Drain(drainCtx) }()
+
+	// Outstanding requests get a few more seconds, so interrupted searches can answer
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownDrainSeconds)*time.Second+shutdownGrace)
 	defer cancel()
 
+	// gRPC calls are drained alongside HTTP requests, and cut off at the same deadline
+	grpcStopped := make(chan struct{})
+	if grpcServer != nil {
+		go func() {
+			grpcServer.GracefulStop()
+			close(grpcStopped)
+		}()
+	}
+
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
+	if grpcServer != nil {
+		select {
+		case <-grpcStopped:
+		case <-ctx.Done():
+			grpcServer.Stop()
+		}
+	}
+	if n := <-interrupted; n > 0 {
+		serverLog.Warn("Interrupted searches still running at the drain deadline", "searches", n)
+	}
+	stopWorkers()
 
 	log.Println("Server exited gracefully")
 }
@@ -0,0 +1,357 @@
+// Package matching scores jobs against a profile deterministically, without calling an LLM
+package matching
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// Component weights; components without data on either side are left out and the rest renormalized
+const (
+	weightSkills     = 0.45
+	weightTitle      = 0.20
+	weightExperience = 0.15
+	weightLocation   = 0.10
+	weightWorkType   = 0.10
+)
+
+// neutralScore is returned when neither profile nor job has anything to compare
+const neutralScore = 50
+
+// titleStopWords are ignored when comparing titles so seniority and filler words don't count as overlap
+var titleStopWords = map[string]bool{
+	"senior": true, "sr": true, "junior": true, "jr": true, "mid": true, "middle": true, "level": true,
+	"lead": true, "principal": true, "staff": true, "intern": true, "internship": true, "magang": true,
+	"i": true, "ii": true, "iii": true, "iv": true, "and": true, "of": true, "the": true, "dan": true,
+}
+
+// experienceRanges maps a job's experience level to the years of experience it usually expects
+var experienceRanges = map[string][2]float64{
+	models.ExperienceLevelEntry:  {0, 2},
+	models.ExperienceLevelMid:    {2, 5},
+	models.ExperienceLevelSenior: {5, 10},
+	models.ExperienceLevelLead:   {8, 40},
+}
+
+// Result is a heuristic match score with its per-criterion breakdown
+type Result struct {
+	Score       int
+	Reason      string
+	Breakdown   models.ScoreBreakdown
+	SkillsMatch int // Percentage of profile skills mentioned in the posting (0 without profile skills)
+}
+
+// component is one weighted criterion; known is false when there was nothing to compare
+type component struct {
+	score  int
+	weight float64
+	known  bool
+	note   string
+}
+
+// Score rates how well a job fits a profile from skill overlap, title similarity,
+// experience fit, location and work type
+func Score(profile *models.UserProfile, job *models.JobPosting) Result {
+	skills, skillsMatch := skillComponent(profile, job)
+	title := titleComponent(profile, job)
+	experience := experienceComponent(profile, job)
+	location := locationComponent(profile, job)
+	workType := workTypeComponent(profile, job)
+
+	components := []component{skills, title, experience, location, workType}
+
+	total, weights := 0.0, 0.0
+	notes := make([]string, 0, len(components))
+	for _, c := range components {
+		if !c.known {
+			continue
+		}
+		total += float64(c.score) * c.weight
+		weights += c.weight
+		if c.note != "" {
+			notes = append(notes, c.note)
+		}
+	}
+
+	result := Result{
+		Score:       neutralScore,
+		SkillsMatch: skillsMatch,
+		Breakdown: models.ScoreBreakdown{
+			Skills:     skills.score,
+			Title:      title.score,
+			Experience: experience.score,
+			Location:   location.score,
+			WorkType:   workType.score,
+		},
+	}
+	if weights == 0 {
+		result.Reason = "Not enough profile or job details to estimate a match"
+		return result
+	}
+
+	result.Score = int(total/weights + 0.5)
+	result.Reason = strings.Join(notes, "; ")
+	return result
+}
+
+// Blend combines an AI score with a heuristic score using the given weights.
+// Weights are normalized; if both are zero the AI score is used as is.
+func Blend(aiScore, heuristicScore int, aiWeight, heuristicWeight float64) int {
+	aiWeight, heuristicWeight = max(aiWeight, 0), max(heuristicWeight, 0)
+	if aiWeight+heuristicWeight == 0 {
+		return aiScore
+	}
+	blended := (float64(aiScore)*aiWeight + float64(heuristicScore)*heuristicWeight) / (aiWeight + heuristicWeight)
+	return int(blended + 0.5)
+}
+
+// Rank orders jobs by heuristic score, best first, keeping the input order for ties
+func Rank(profile *models.UserProfile, jobs []models.JobPosting) []models.JobPosting {
+	scores := make([]int, len(jobs))
+	order := make([]int, len(jobs))
+	for i := range jobs {
+		scores[i] = Score(profile, &jobs[i]).Score
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[order[a]] > scores[order[b]]
+	})
+
+	ranked := make([]models.JobPosting, len(jobs))
+	for i, idx := range order {
+		ranked[i] = jobs[idx]
+	}
+	return ranked
+}
+
+// skillComponent scores the share of profile skills mentioned in the posting,
+// also returning that share as a percentage
+func skillComponent(profile *models.UserProfile, job *models.JobPosting) (component, int) {
+	c := component{weight: weightSkills}
+
+	skills := uniqueLower(append(append([]string{}, profile.Skills...), profile.TechnicalStack...))
+	if len(skills) == 0 {
+		return c, 0
+	}
+
+	text := strings.ToLower(strings.Join([]string{
+		job.Title, job.Description, job.Requirements, strings.Join(job.Tags, " "),
+	}, " "))
+
+	matched := 0
+	for _, skill := range skills {
+		if mentions(text, skill) {
+			matched++
+		}
+	}
+
+	// A posting rarely lists every skill a candidate has, so two thirds of the profile skills counts as a full match
+	ratio := float64(matched) / float64(len(skills))
+	c.score = min(100, int(ratio*150+0.5))
+	c.known = true
+	c.note = fmt.Sprintf("%d of %d profile skills mentioned", matched, len(skills))
+	return c, int(ratio*100 + 0.5)
+}
+
+// titleComponent compares the job title with the profile's title, preferred roles and most recent position
+func titleComponent(profile *models.UserProfile, job *models.JobPosting) component {
+	c := component{weight: weightTitle}
+
+	jobWords := titleWords(job.Title)
+	if len(jobWords) == 0 {
+		return c
+	}
+
+	candidates := append([]string{profile.Title}, profile.PreferredRoles...)
+	if len(profile.WorkHistory) > 0 {
+		candidates = append(candidates, profile.WorkHistory[0].Title)
+	}
+
+	best, bestTitle := 0.0, ""
+	for _, title := range candidates {
+		words := titleWords(title)
+		if len(words) == 0 {
+			continue
+		}
+		c.known = true
+		if sim := overlap(words, jobWords); sim > best {
+			best, bestTitle = sim, title
+		}
+	}
+	if !c.known {
+		return c
+	}
+
+	c.score = int(best*100 + 0.5)
+	if best >= 0.5 {
+		c.note = fmt.Sprintf("title similar to %q", bestTitle)
+	} else {
+		c.note = "title differs from target roles"
+	}
+	return c
+}
+
+// experienceComponent compares the profile's years of experience with the job's seniority
+func experienceComponent(profile *models.UserProfile, job *models.JobPosting) component {
+	c := component{weight: weightExperience}
+
+	expected, ok := experienceRanges[strings.ToLower(job.ExperienceLevel)]
+	if !ok || profile.Experience <= 0 {
+		return c
+	}
+	c.known = true
+
+	years := profile.Experience
+	var gap float64
+	switch {
+	case years < expected[0]:
+		gap = expected[0] - years
+		c.note = fmt.Sprintf("%.0f years of experience, below %s level", years, job.ExperienceLevel)
+	case years > expected[1]:
+		// Being over-qualified is penalized less than being under-qualified
+		gap = (years - expected[1]) / 2
+		c.note = fmt.Sprintf("%.0f years of experience, above %s level", years, job.ExperienceLevel)
+	default:
+		c.note = fmt.Sprintf("experience fits %s level", job.ExperienceLevel)
+	}
+
+	c.score = max(0, 100-int(gap*25+0.5))
+	return c
+}
+
+// locationComponent checks the job location and site setting against the profile's preferences
+func locationComponent(profile *models.UserProfile, job *models.JobPosting) component {
+	c := component{weight: weightLocation}
+
+	remote := job.SiteSetting == models.SiteSettingWFH
+	hasLocations := len(profile.PreferredLocations) > 0
+	hasModes := len(profile.PreferredRemoteModes) > 0
+	if !hasLocations && !hasModes {
+		return c
+	}
+
+	if hasLocations {
+		jobLocation := strings.ToLower(job.Location)
+		switch {
+		case jobLocation == "" && !remote:
+			// Unknown location, fall through to the site setting check
+		case remote:
+			c.known = true
+			c.score = 90
+			c.note = "remote position"
+		default:
+			c.known = true
+			c.score = 20
+			c.note = "location outside preferred areas"
+			for _, loc := range profile.PreferredLocations {
+				loc = strings.ToLower(strings.TrimSpace(loc))
+				if loc != "" && strings.Contains(jobLocation, loc) {
+					c.score = 100
+					c.note = fmt.Sprintf("located in %s", job.Location)
+					break
+				}
+			}
+		}
+	}
+
+	if hasModes && job.SiteSetting != "" && job.SiteSetting != models.SiteSettingUnknown {
+		preferred := false
+		for _, mode := range profile.PreferredRemoteModes {
+			if models.NormalizeSiteSetting(mode) == job.SiteSetting {
+				preferred = true
+				break
+			}
+		}
+		switch {
+		case !c.known:
+			c.known = true
+			c.score = 100
+			if !preferred {
+				c.score = 30
+			}
+		case !preferred:
+			c.score = min(c.score, 40)
+		}
+		if !preferred {
+			c.note = fmt.Sprintf("%s is not a preferred work mode", job.SiteSetting)
+		}
+	}
+
+	return c
+}
+
+// workTypeComponent checks the job's employment type against the profile's preferred job types
+func workTypeComponent(profile *models.UserProfile, job *models.JobPosting) component {
+	c := component{weight: weightWorkType}
+
+	workType := models.NormalizeWorkType(job.WorkType)
+	if len(profile.PreferredJobTypes) == 0 || workType == "" {
+		return c
+	}
+	c.known = true
+
+	c.score = 20
+	for _, preferred := range profile.PreferredJobTypes {
+		if models.NormalizeWorkType(preferred) == workType {
+			c.score = 100
+			return c
+		}
+	}
+	c.note = fmt.Sprintf("%s is not a preferred job type", workType)
+	return c
+}
+
+// mentions reports whether a lowercase skill appears in text as a whole word or phrase,
+// so "go" does not match "good" and "java" does not match "javascript"
+func mentions(text, skill string) bool {
+	pattern := `(^|[^a-z0-9+#])` + regexp.QuoteMeta(skill) + `($|[^a-z0-9+#])`
+	matched, err := regexp.MatchString(pattern, text)
+	return err == nil && matched
+}
+
+// titleWords splits a title into lowercase words, dropping seniority and filler words
+func titleWords(title string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	}) {
+		if !titleStopWords[w] {
+			words[w] = true
+		}
+	}
+	return words
+}
+
+// overlap is the share of the smaller word set found in the other (overlap coefficient)
+func overlap(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(min(len(a), len(b)))
+}
+
+// uniqueLower trims, lowercases and de-duplicates values, dropping empty ones
+func uniqueLower(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package matching
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// Neighbor is a vector index entry with its similarity to a query vector
+type Neighbor struct {
+	ID         string
+	Similarity float64
+}
+
+// VectorIndex is an in-memory store of embeddings searched by cosine similarity.
+// It keeps at most capacity vectors, evicting the oldest first, and is safe for concurrent use.
+type VectorIndex struct {
+	mu       sync.RWMutex
+	vectors  map[string][]float32
+	order    []string // Insertion order, oldest first
+	capacity int
+}
+
+// NewVectorIndex creates an index holding up to capacity vectors
+func NewVectorIndex(capacity int) *VectorIndex {
+	return &VectorIndex{
+		vectors:  make(map[string][]float32),
+		capacity: capacity,
+	}
+}
+
+// Get returns the vector stored under id
+func (x *VectorIndex) Get(id string) ([]float32, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	v, ok := x.vectors[id]
+	return v, ok
+}
+
+// Put stores or replaces the vector under id
+func (x *VectorIndex) Put(id string, vector []float32) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if _, exists := x.vectors[id]; !exists {
+		x.order = append(x.order, id)
+	}
+	x.vectors[id] = vector
+
+	for len(x.order) > x.capacity {
+		delete(x.vectors, x.order[0])
+		x.order = x.order[1:]
+	}
+}
+
+// Nearest returns up to k stored vectors most similar to query, most similar first.
+// If filter is non-nil, only ids it accepts are considered.
+func (x *VectorIndex) Nearest(query []float32, k int, filter func(id string) bool) []Neighbor {
+	x.mu.RLock()
+	neighbors := make([]Neighbor, 0, len(x.vectors))
+	for id, v := range x.vectors {
+		if filter != nil && !filter(id) {
+			continue
+		}
+		neighbors = append(neighbors, Neighbor{ID: id, Similarity: Cosine(query, v)})
+	}
+	x.mu.RUnlock()
+
+	sort.Slice(neighbors, func(i, j int) bool {
+		return neighbors[i].Similarity > neighbors[j].Similarity
+	})
+	if len(neighbors) > k {
+		neighbors = neighbors[:k]
+	}
+	return neighbors
+}
+
+// Cosine returns the cosine similarity of two vectors (0 if their lengths differ or either is zero)
+func Cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,150 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+
+	"github.com/myjobmatch/backend/auth"
+	"github.com/myjobmatch/backend/tools"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// codeRateLimited is the JSON-RPC error returned when a caller exceeds its tool call rate limit
+const codeRateLimited = -32029
+
+// RateCounter counts calls in fixed windows shared across instances, such as cache.RedisCache
+type RateCounter interface {
+	// Incr increments a counter that expires window after its first increment, returning the new count
+	Incr(ctx context.Context, key string, window time.Duration) (int64, error)
+}
+
+// callLimiter rate-limits tool calls per caller with a token bucket refilled every minute, or with
+// per-minute counters in a shared RateCounter when one is set. It is safe for concurrent use by request goroutines.
+type callLimiter struct {
+	mu        sync.Mutex
+	limiters  map[string]*rate.Limiter
+	perMinute int
+	counter   RateCounter
+}
+
+func newCallLimiter(perMinute int) *callLimiter {
+	return &callLimiter{
+		limiters:  make(map[string]*rate.Limiter),
+		perMinute: perMinute,
+	}
+}
+
+// reserve takes a call from key's bucket, whose size is perMinute (or the server limit when 0 or
+// higher). It returns 0 when the call may proceed, otherwise how long until the next one can.
+func (l *callLimiter) reserve(ctx context.Context, key string, perMinute int) time.Duration {
+	if l.perMinute <= 0 {
+		return 0
+	}
+	if perMinute <= 0 || perMinute > l.perMinute {
+		perMinute = l.perMinute
+	}
+
+	now := time.Now()
+	if l.counter != nil {
+		window := now.Truncate(time.Minute)
+		count, err := l.counter.Incr(ctx, fmt.Sprintf("ratelimit:%s:%d", key, window.Unix()), time.Minute)
+		if err == nil {
+			if count > int64(perMinute) {
+				return window.Add(time.Minute).Sub(now)
+			}
+			return 0
+		}
+		// Keep limiting per instance rather than failing calls
+		log.Printf("[MCP] Warning: shared rate limit counter failed, using memory: %v", err)
+	}
+
+	l.mu.Lock()
+	limiter, ok := l.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(float64(perMinute)/60), perMinute)
+		l.limiters[key] = limiter
+	}
+	l.mu.Unlock()
+
+	r := limiter.ReserveN(now, 1)
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return delay
+	}
+	return 0
+}
+
+// callerKey identifies who a request counts against: the API token, the signed-in user, or
+// the client IP when MCP_AUTH_REQUIRED is off
+func callerKey(c *gin.Context) string {
+	if token := auth.GetAPIToken(c); token != nil {
+		return "token:" + token.ID
+	}
+	if claims := auth.GetAuthClaims(c); claims != nil {
+		return "user:" + claims.UserID
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// callerContext returns the request context, attributing anonymous calls (MCP_AUTH_REQUIRED off) to the
+// client IP in the audit log; authenticated callers are attached by the auth middleware
+func callerContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+	if utils.CallerFromContext(ctx).Email == "" {
+		ctx = utils.WithCaller(ctx, utils.Caller{ClientIP: c.ClientIP()})
+	}
+	return ctx
+}
+
+// allowTool reports whether the request's API token may use the tool; sessions may use every tool
+func allowTool(c *gin.Context, name string) bool {
+	token := auth.GetAPIToken(c)
+	return token == nil || token.AllowsMCPTool(name)
+}
+
+// reserveCall applies the caller's rate limit to a tool call. When the limit is reached it returns
+// false along with the wait in seconds, for the Retry-After header.
+func (s *Server) reserveCall(c *gin.Context) (bool, int) {
+	perMinute := 0
+	if token := auth.GetAPIToken(c); token != nil {
+		perMinute = token.MCPRateLimitPerMinute
+	}
+
+	delay := s.limiter.reserve(c.Request.Context(), callerKey(c), perMinute)
+	if delay == 0 {
+		return true, 0
+	}
+	return false, retryAfterSeconds(delay)
+}
+
+// retryAfterSeconds rounds a wait up to whole seconds for Retry-After
+func retryAfterSeconds(delay time.Duration) int {
+	return int(math.Ceil(delay.Seconds()))
+}
+
+// rateLimitedResponse answers a JSON-RPC tool call refused by the rate limit; sent alone, it gets 429
+func rateLimitedResponse(id interface{}, retryAfter int) MCPResponse {
+	return errorResponse(id, codeRateLimited, "Rate limit exceeded", map[string]interface{}{"retryAfterSeconds": retryAfter})
+}
+
+// quotaExceededResponse answers a JSON-RPC tool call refused by the caller's quota for the tool; sent alone, it gets 429
+func quotaExceededResponse(id interface{}, quotaErr *tools.QuotaError) MCPResponse {
+	return errorResponse(id, codeRateLimited, "Tool quota exceeded", map[string]interface{}{
+		"retryAfterSeconds": retryAfterSeconds(quotaErr.RetryAfter),
+		"tool":              quotaErr.Tool,
+		"limit":             quotaErr.Limit,
+	})
+}
+
+// setRetryAfter sets the Retry-After header for a response refused by the rate limit
+func setRetryAfter(c *gin.Context, retryAfter int) {
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+}
@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBatchSize caps the messages in a JSON-RPC batch
+const maxBatchSize = 20
+
+// isBatch reports whether a request body is a JSON-RPC batch (an array)
+func isBatch(body []byte) bool {
+	trimmed := bytes.TrimLeft(body, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+// handleBatch answers a JSON-RPC batch with an array holding a response for each request, in order.
+// Notifications get no entry; a batch of notifications only is acknowledged with 202. Messages run
+// one after another, and tool calls in a batch don't stream progress.
+func (s *Server) handleBatch(c *gin.Context, body []byte) {
+	responses, failure := s.runBatch(c, body)
+	if failure != nil {
+		s.sendResponse(c, *failure)
+		return
+	}
+
+	if len(responses) == 0 {
+		c.Status(http.StatusAccepted)
+		return
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// runBatch answers the messages of a batch in order. It returns a single error response instead when
+// the batch itself is malformed, empty or too large.
+func (s *Server) runBatch(c *gin.Context, body []byte) ([]MCPResponse, *MCPResponse) {
+	var messages []json.RawMessage
+	if err := json.Unmarshal(body, &messages); err != nil {
+		failure := errorResponse(nil, codeParseError, "Parse error", err.Error())
+		return nil, &failure
+	}
+	if len(messages) == 0 {
+		failure := errorResponse(nil, codeInvalidRequest, "Invalid Request", "empty batch")
+		return nil, &failure
+	}
+	if len(messages) > maxBatchSize {
+		failure := errorResponse(nil, codeInvalidRequest, "Invalid Request", fmt.Sprintf("batches are limited to %d messages", maxBatchSize))
+		return nil, &failure
+	}
+
+	responses := make([]MCPResponse, 0, len(messages))
+	for _, message := range messages {
+		var req MCPRequest
+		if err := json.Unmarshal(message, &req); err != nil {
+			responses = append(responses, errorResponse(nil, codeInvalidRequest, "Invalid Request", err.Error()))
+			continue
+		}
+		if resp := s.handleRequest(c, req, nil); resp != nil {
+			responses = append(responses, *resp)
+		}
+	}
+	return responses, nil
+}
@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CancelledParams represents the parameters of notifications/cancelled
+type CancelledParams struct {
+	RequestID interface{} `json:"requestId"`
+	Reason    string      `json:"reason,omitempty"`
+}
+
+// callTracker keeps the cancel functions of in-flight tool calls so notifications/cancelled can stop them.
+// Calls are keyed by caller and request ID, so a caller can only cancel its own calls. Each request is a
+// separate HTTP request, so a cancellation only reaches calls running on the same instance.
+type callTracker struct {
+	mu    sync.Mutex
+	calls map[string]context.CancelFunc
+}
+
+func newCallTracker() *callTracker {
+	return &callTracker{
+		calls: make(map[string]context.CancelFunc),
+	}
+}
+
+// start registers a call and returns its context; done must be called when the call finishes
+func (t *callTracker) start(ctx context.Context, caller string, id interface{}) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	key := callKey(caller, id)
+
+	t.mu.Lock()
+	t.calls[key] = cancel
+	t.mu.Unlock()
+
+	return ctx, func() {
+		t.mu.Lock()
+		delete(t.calls, key)
+		t.mu.Unlock()
+		cancel()
+	}
+}
+
+// cancel stops an in-flight call and reports whether one was found
+func (t *callTracker) cancel(caller string, id interface{}) bool {
+	t.mu.Lock()
+	cancel, ok := t.calls[callKey(caller, id)]
+	t.mu.Unlock()
+
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+// callKey identifies a call; IDs are compared by their JSON form, so 7 and "7" are different calls
+func callKey(caller string, id interface{}) string {
+	data, _ := json.Marshal(id)
+	return fmt.Sprintf("%s/%s", caller, data)
+}
+
+// handleCancelled stops the call named by a notifications/cancelled message. Unknown or finished
+// calls are ignored, as the protocol allows the notification to race with the response.
+func (s *Server) handleCancelled(c *gin.Context, req MCPRequest) {
+	var params CancelledParams
+	if err := json.Unmarshal(req.Params, &params); err != nil || params.RequestID == nil {
+		return
+	}
+	if s.calls.cancel(callerKey(c), params.RequestID) {
+		log.Printf("[MCP] Call %v cancelled by the client: %s", params.RequestID, params.Reason)
+	}
+}
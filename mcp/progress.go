@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProgressParams represents the parameters of notifications/progress
+type ProgressParams struct {
+	ProgressToken interface{} `json:"progressToken"`
+	Progress      int         `json:"progress"`
+	Message       string      `json:"message,omitempty"`
+}
+
+// MCPNotification represents a JSON-RPC notification sent by the server
+type MCPNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// progressSink delivers a tool call's progress notifications and then its response to the client
+type progressSink interface {
+	notify(message string)
+	finish(resp MCPResponse)
+}
+
+// progressOpener starts a progress sink for a tool call that asked for progress, or returns nil when
+// the call should be answered with a plain response
+type progressOpener func(c *gin.Context, meta *RequestMeta) progressSink
+
+// progressNotification builds the notifications/progress message for a call's progress token
+func progressNotification(token interface{}, progress int, message string) MCPNotification {
+	return MCPNotification{
+		JSONRPC: "2.0",
+		Method:  "notifications/progress",
+		Params: ProgressParams{
+			ProgressToken: token,
+			Progress:      progress,
+			Message:       message,
+		},
+	}
+}
+
+// progressStream answers a tool call as an SSE stream: notifications/progress messages while the tool
+// runs, then the JSON-RPC response. Progress is reported from the tool's goroutines, so writes are serialized.
+type progressStream struct {
+	mu       sync.Mutex
+	c        *gin.Context
+	token    interface{}
+	progress int
+	done     bool
+}
+
+// startProgressStream switches the response to SSE when the client asked for progress on a call and
+// accepts event streams; otherwise it returns nil and the call is answered with plain JSON
+func startProgressStream(c *gin.Context, meta *RequestMeta) *progressStream {
+	if meta == nil || meta.ProgressToken == nil || !strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		return nil
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+	return &progressStream{c: c, token: meta.ProgressToken}
+}
+
+// openProgressStream is the progressOpener of the Streamable HTTP endpoint
+func openProgressStream(c *gin.Context, meta *RequestMeta) progressSink {
+	if stream := startProgressStream(c, meta); stream != nil {
+		return stream
+	}
+	return nil
+}
+
+// notify sends a progress notification. The progress value counts the notifications, so it increases
+// with each one as the protocol requires; the total is unknown as tools move through several stages.
+func (s *progressStream) notify(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done {
+		return
+	}
+
+	s.progress++
+	s.c.SSEvent("message", progressNotification(s.token, s.progress, message))
+	s.c.Writer.Flush()
+}
+
+// finish sends the response to the call and ends the stream; later progress is dropped
+func (s *progressStream) finish(resp MCPResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.done = true
+	s.c.SSEvent("message", resp)
+	s.c.Writer.Flush()
+}
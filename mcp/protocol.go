@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// supportedProtocolVersions are the MCP revisions the server speaks, newest first
+var supportedProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// protocolVersionHeader carries the negotiated protocol version on requests after initialization
+const protocolVersionHeader = "MCP-Protocol-Version"
+
+// serverName and serverVersion identify the server during initialization
+const (
+	serverName    = "myjobmatch"
+	serverVersion = "1.0.0"
+)
+
+// serverInstructions tell clients how the tools fit together
+const serverInstructions = `Job search tools for the Indonesian job market. search_jobs runs a complete search in one
+call (query, CV text or profile in, ranked jobs out). For finer control: parse_cv to get a profile,
+search_web_for_jobs (or search_ats_boards / search_remote_boards) to find postings, fetch_page_html and
+extract_job_from_html to read them, then score_job_match to rank them against the profile.`
+
+// InitializeParams represents the parameters of initialize
+type InitializeParams struct {
+	ProtocolVersion string                 `json:"protocolVersion"`
+	Capabilities    map[string]interface{} `json:"capabilities"`
+	ClientInfo      Implementation         `json:"clientInfo"`
+}
+
+// Implementation names an MCP client or server
+type Implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// InitializeResult represents the result of initialize
+type InitializeResult struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ServerCapabilities `json:"capabilities"`
+	ServerInfo      Implementation     `json:"serverInfo"`
+	Instructions    string             `json:"instructions,omitempty"`
+}
+
+// ServerCapabilities lists the features the server offers
+type ServerCapabilities struct {
+	Tools *ToolsCapability `json:"tools,omitempty"`
+}
+
+// ToolsCapability describes the server's tool support
+type ToolsCapability struct {
+	ListChanged bool `json:"listChanged"` // The tool list is fixed while the server runs
+}
+
+func (s *Server) handleInitialize(req MCPRequest) MCPResponse {
+	var params InitializeParams
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return errorResponse(req.ID, codeInvalidParams, "Invalid params", err.Error())
+		}
+	}
+
+	// Answer with the client's version if supported, otherwise with the latest one; the client
+	// disconnects if it can't speak the version offered
+	version := supportedProtocolVersions[0]
+	if isSupportedProtocolVersion(params.ProtocolVersion) {
+		version = params.ProtocolVersion
+	}
+	log.Printf("[MCP] Initialize from %s %s (requested protocol %q, using %s)",
+		params.ClientInfo.Name, params.ClientInfo.Version, params.ProtocolVersion, version)
+
+	return resultResponse(req.ID, InitializeResult{
+		ProtocolVersion: version,
+		Capabilities:    ServerCapabilities{Tools: &ToolsCapability{}},
+		ServerInfo:      Implementation{Name: serverName, Version: serverVersion},
+		Instructions:    serverInstructions,
+	})
+}
+
+func (s *Server) handlePing(req MCPRequest) MCPResponse {
+	return resultResponse(req.ID, struct{}{})
+}
+
+// HandleGet rejects GET /mcp: the server doesn't open server-initiated SSE streams
+func (s *Server) HandleGet(c *gin.Context) {
+	c.Header("Allow", http.MethodPost)
+	c.Status(http.StatusMethodNotAllowed)
+}
+
+// checkProtocolVersion rejects requests whose MCP-Protocol-Version header names an unsupported revision.
+// Requests without the header are accepted, as older clients don't send it.
+func checkProtocolVersion(c *gin.Context) bool {
+	version := c.GetHeader(protocolVersionHeader)
+	if version == "" || isSupportedProtocolVersion(version) {
+		return true
+	}
+	c.JSON(http.StatusBadRequest, errorResponse(nil, codeInvalidRequest, "Unsupported protocol version",
+		map[string]interface{}{"requested": version, "supported": supportedProtocolVersions}))
+	return false
+}
+
+func isSupportedProtocolVersion(version string) bool {
+	for _, v := range supportedProtocolVersions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/myjobmatch/backend/gemini"
+)
+
+const (
+	// defaultSamplingMaxTokens is requested when the model call sets no output limit
+	defaultSamplingMaxTokens = 4096
+	// maxSamplingWait bounds the wait for the client's answer; clients may ask their user to approve a request
+	maxSamplingWait = 60 * time.Second
+)
+
+// SamplingMessage represents a message in a sampling/createMessage request or result
+type SamplingMessage struct {
+	Role    string          `json:"role"`
+	Content SamplingContent `json:"content"`
+}
+
+// SamplingContent represents the content of a sampling message; only text is used
+type SamplingContent struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+}
+
+// CreateMessageParams represents the parameters of sampling/createMessage
+type CreateMessageParams struct {
+	Messages       []SamplingMessage `json:"messages"`
+	SystemPrompt   string            `json:"systemPrompt,omitempty"`
+	IncludeContext string            `json:"includeContext,omitempty"`
+	MaxTokens      int               `json:"maxTokens"`
+}
+
+// CreateMessageResult represents the result of sampling/createMessage
+type CreateMessageResult struct {
+	Role       string          `json:"role"`
+	Content    SamplingContent `json:"content"`
+	Model      string          `json:"model"`
+	StopReason string          `json:"stopReason,omitempty"`
+}
+
+// wsReply is a client's response to a request sent by the server
+type wsReply struct {
+	Result json.RawMessage
+	Error  *MCPError
+}
+
+type sessionSamplerKey struct{}
+
+// withSampler delegates the tool's Gemini calls to the client's model when sampling is enabled for the
+// tool and the connection can carry sampling requests (WebSocket connections only)
+func (s *Server) withSampler(ctx context.Context, tool string) context.Context {
+	sampler, ok := ctx.Value(sessionSamplerKey{}).(gemini.Sampler)
+	if !ok || !s.samplingTools[tool] {
+		return ctx
+	}
+	return gemini.WithSampler(ctx, sampler)
+}
+
+// Sample sends a sampling/createMessage request to the client and waits for its answer. It fails with
+// gemini.ErrSamplingUnavailable when the client didn't declare the sampling capability.
+func (w *wsSession) Sample(ctx context.Context, systemPrompt, prompt string, maxTokens int) (string, error) {
+	if !w.sampling.Load() {
+		return "", gemini.ErrSamplingUnavailable
+	}
+	if maxTokens <= 0 {
+		maxTokens = defaultSamplingMaxTokens
+	}
+
+	params, err := json.Marshal(CreateMessageParams{
+		Messages:       []SamplingMessage{{Role: "user", Content: SamplingContent{Type: "text", Text: prompt}}},
+		SystemPrompt:   systemPrompt,
+		IncludeContext: "none",
+		MaxTokens:      maxTokens,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode sampling request: %w", err)
+	}
+
+	id, replies := w.expectReply()
+	defer w.dropReply(id)
+	w.send(MCPRequest{JSONRPC: "2.0", ID: id, Method: "sampling/createMessage", Params: params})
+
+	timer := time.NewTimer(maxSamplingWait)
+	defer timer.Stop()
+
+	var reply wsReply
+	select {
+	case reply = <-replies:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-timer.C:
+		return "", fmt.Errorf("no sampling response within %s", maxSamplingWait)
+	}
+
+	if reply.Error != nil {
+		return "", fmt.Errorf("client refused sampling request: %s", reply.Error.Message)
+	}
+	var result CreateMessageResult
+	if err := json.Unmarshal(reply.Result, &result); err != nil {
+		return "", fmt.Errorf("invalid sampling response: %w", err)
+	}
+	if result.Content.Type != "text" {
+		return "", fmt.Errorf("sampling response has %s content, expected text", result.Content.Type)
+	}
+	return result.Content.Text, nil
+}
+
+// expectReply allocates the ID of a request to the client and the channel its response is delivered on
+func (w *wsSession) expectReply() (string, chan wsReply) {
+	w.pendingMu.Lock()
+	defer w.pendingMu.Unlock()
+
+	w.nextID++
+	id := fmt.Sprintf("sampling-%d", w.nextID)
+	replies := make(chan wsReply, 1)
+	w.pending[id] = replies
+	return id, replies
+}
+
+func (w *wsSession) dropReply(id string) {
+	w.pendingMu.Lock()
+	delete(w.pending, id)
+	w.pendingMu.Unlock()
+}
+
+// deliverReply hands a client's response to the request waiting for it; responses to unknown or
+// abandoned requests are dropped
+func (w *wsSession) deliverReply(id interface{}, reply wsReply) {
+	key, ok := id.(string)
+	if !ok {
+		return
+	}
+
+	w.pendingMu.Lock()
+	replies, ok := w.pending[key]
+	w.pendingMu.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case replies <- reply:
+	default: // A duplicate response
+	}
+}
@@ -3,29 +3,56 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/myjobmatch/backend/config"
 	"github.com/myjobmatch/backend/tools"
+	"github.com/myjobmatch/backend/utils"
 )
 
 // Server represents an MCP (Model Context Protocol) server
 // This allows the tools to be used by external AI agents
 type Server struct {
-	registry *tools.ToolRegistry
+	registry  *tools.ToolRegistry
+	limiter   *callLimiter
+	calls     *callTracker
+	webSocket bool // Serve the WebSocket transport (MCP_WEBSOCKET_ENABLED)
+
+	samplingEnabled bool            // Delegate tools' Gemini calls to WebSocket clients' models (MCP_SAMPLING_ENABLED)
+	samplingTools   map[string]bool // Tools whose Gemini calls are delegated (MCP_SAMPLING_TOOLS)
 }
 
-// NewServer creates a new MCP server
-func NewServer(registry *tools.ToolRegistry) *Server {
+// NewServer creates a new MCP server. Tool calls are limited to MCP_RATE_LIMIT_PER_MINUTE per caller;
+// authentication is applied by the router group the routes are registered on.
+func NewServer(registry *tools.ToolRegistry, cfg *config.Config) *Server {
+	samplingTools := make(map[string]bool, len(cfg.MCPSamplingTools))
+	for _, name := range cfg.MCPSamplingTools {
+		samplingTools[name] = true
+	}
+
 	return &Server{
-		registry: registry,
+		registry:  registry,
+		limiter:   newCallLimiter(cfg.MCPRateLimitPerMinute),
+		calls:     newCallTracker(),
+		webSocket: cfg.MCPWebSocketEnabled,
+
+		samplingEnabled: cfg.MCPSamplingEnabled && cfg.MCPWebSocketEnabled,
+		samplingTools:   samplingTools,
 	}
 }
 
-// MCPRequest represents an incoming MCP tool call request
+// SetRateLimitCounter counts tool calls against MCP_RATE_LIMIT_PER_MINUTE in a counter shared across
+// instances, so the limit holds however many instances serve a caller
+func (s *Server) SetRateLimitCounter(counter RateCounter) {
+	s.limiter.counter = counter
+}
+
+// MCPRequest represents an incoming MCP JSON-RPC request, or a notification when it has no ID
 type MCPRequest struct {
 	JSONRPC string          `json:"jsonrpc"`
 	ID      interface{}     `json:"id"`
@@ -58,12 +85,26 @@ type ToolDefinition struct {
 	Name        string                 `json:"name"`
 	Description string                 `json:"description"`
 	InputSchema map[string]interface{} `json:"inputSchema"`
+	Meta        ToolMeta               `json:"_meta"`
+}
+
+// ToolMeta carries a tool's version and deprecation, so agents can detect schema changes
+type ToolMeta struct {
+	Version     string             `json:"version"`
+	Deprecation *tools.Deprecation `json:"deprecation,omitempty"`
 }
 
 // ToolCallParams represents parameters for tools/call
 type ToolCallParams struct {
 	Name      string          `json:"name"`
 	Arguments json.RawMessage `json:"arguments"`
+	Meta      *RequestMeta    `json:"_meta,omitempty"`
+}
+
+// RequestMeta carries the _meta field of a request's params
+type RequestMeta struct {
+	ProgressToken interface{} `json:"progressToken,omitempty"` // String or integer chosen by the client
+	ToolVersion   string      `json:"toolVersion,omitempty"`   // Tool version the caller was written against; the major version must match
 }
 
 // ToolCallResult represents the result of tools/call
@@ -81,43 +122,84 @@ type ContentItem struct {
 // RegisterRoutes registers MCP endpoints on the given router group
 func (s *Server) RegisterRoutes(router *gin.RouterGroup) {
 	router.POST("/mcp", s.HandleMCP)
+	router.GET("/mcp", s.HandleGet)
 	router.POST("/mcp/tools/list", s.HandleToolsList)
 	router.POST("/mcp/tools/call", s.HandleToolsCall)
+	if s.webSocket {
+		router.GET("/mcp/ws", s.HandleWebSocket)
+	}
 }
 
-// HandleMCP handles MCP JSON-RPC requests
+// HandleMCP handles MCP JSON-RPC requests (the Streamable HTTP transport's POST endpoint).
+// The body is a single request or notification, or a batch array of them.
 func (s *Server) HandleMCP(c *gin.Context) {
+	if !checkProtocolVersion(c) {
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		s.sendResponse(c, errorResponse(nil, codeParseError, "Parse error", err.Error()))
+		return
+	}
+	if isBatch(body) {
+		s.handleBatch(c, body)
+		return
+	}
+
 	var req MCPRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		s.sendError(c, nil, -32700, "Parse error", err.Error())
+	if err := json.Unmarshal(body, &req); err != nil {
+		s.sendResponse(c, errorResponse(nil, codeParseError, "Parse error", err.Error()))
 		return
 	}
 
+	// Notifications (notifications/initialized, notifications/cancelled, ...) get no response
+	resp := s.handleRequest(c, req, openProgressStream)
+	if resp == nil {
+		if req.ID == nil {
+			c.Status(http.StatusAccepted)
+		}
+		return
+	}
+	s.sendResponse(c, *resp)
+}
+
+// handleRequest answers one JSON-RPC message and returns its response, or nil for notifications.
+// When openProgress starts a progress sink for a tool call, the call is answered through it and also
+// returns nil; with a nil openProgress, calls don't report progress.
+func (s *Server) handleRequest(c *gin.Context, req MCPRequest, openProgress progressOpener) *MCPResponse {
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp := errorResponse(req.ID, codeInvalidRequest, "Invalid Request", "jsonrpc must be \"2.0\" and method is required")
+		return &resp
+	}
+
+	if req.ID == nil {
+		if req.Method == "notifications/cancelled" {
+			s.handleCancelled(c, req)
+		}
+		return nil
+	}
+
+	var resp MCPResponse
 	switch req.Method {
+	case "initialize":
+		resp = s.handleInitialize(req)
+	case "ping":
+		resp = s.handlePing(req)
 	case "tools/list":
-		s.handleToolsList(c, req)
+		resp = s.handleToolsList(c, req)
 	case "tools/call":
-		s.handleToolsCall(c, req)
+		return s.handleToolsCall(c, req, openProgress)
 	default:
-		s.sendError(c, req.ID, -32601, "Method not found", nil)
+		resp = errorResponse(req.ID, codeMethodNotFound, "Method not found", req.Method)
 	}
+	return &resp
 }
 
 // HandleToolsList handles GET /mcp/tools/list
 func (s *Server) HandleToolsList(c *gin.Context) {
-	tools := s.registry.List()
-
-	definitions := make([]ToolDefinition, 0, len(tools))
-	for _, tool := range tools {
-		definitions = append(definitions, ToolDefinition{
-			Name:        tool.Name(),
-			Description: tool.Description(),
-			InputSchema: tool.InputSchema(),
-		})
-	}
-
 	c.JSON(http.StatusOK, ToolsListResult{
-		Tools: definitions,
+		Tools: s.toolDefinitions(c),
 	})
 }
 
@@ -128,8 +210,28 @@ func (s *Server) HandleToolsCall(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
+	if !allowTool(c, params.Name) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "API token is not allowed to call tool: " + params.Name})
+		return
+	}
+	if mismatch := s.versionMismatch(params); mismatch != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Incompatible tool version", "details": mismatch})
+		return
+	}
+	if ok, retryAfter := s.reserveCall(c); !ok {
+		setRetryAfter(c, retryAfter)
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded", "retryAfterSeconds": retryAfter})
+		return
+	}
 
-	result, err := s.executeTool(c.Request.Context(), params.Name, params.Arguments)
+	result, err := s.executeTool(callerContext(c), params.Name, params.Arguments)
+	var quotaErr *tools.QuotaError
+	if errors.As(err, &quotaErr) {
+		retryAfter := retryAfterSeconds(quotaErr.RetryAfter)
+		setRetryAfter(c, retryAfter)
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Tool quota exceeded", "tool": quotaErr.Tool, "limit": quotaErr.Limit, "retryAfterSeconds": retryAfter})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusOK, ToolCallResult{
 			Content: []ContentItem{{Type: "text", Text: err.Error()}},
@@ -143,52 +245,112 @@ func (s *Server) HandleToolsCall(c *gin.Context) {
 	})
 }
 
-func (s *Server) handleToolsList(c *gin.Context, req MCPRequest) {
+func (s *Server) handleToolsList(c *gin.Context, req MCPRequest) MCPResponse {
+	return resultResponse(req.ID, ToolsListResult{
+		Tools: s.toolDefinitions(c),
+	})
+}
+
+// toolDefinitions lists the tools the request's API token may call
+func (s *Server) toolDefinitions(c *gin.Context) []ToolDefinition {
 	tools := s.registry.List()
 
 	definitions := make([]ToolDefinition, 0, len(tools))
 	for _, tool := range tools {
+		if !allowTool(c, tool.Name()) || !s.registry.Enabled(tool.Name()) {
+			continue
+		}
 		definitions = append(definitions, ToolDefinition{
 			Name:        tool.Name(),
-			Description: tool.Description(),
+			Description: s.registry.Describe(tool),
 			InputSchema: tool.InputSchema(),
+			Meta: ToolMeta{
+				Version:     tool.Version(),
+				Deprecation: s.registry.Deprecation(tool.Name()),
+			},
 		})
 	}
-
-	s.sendResult(c, req.ID, ToolsListResult{
-		Tools: definitions,
-	})
+	return definitions
 }
 
-func (s *Server) handleToolsCall(c *gin.Context, req MCPRequest) {
+func (s *Server) handleToolsCall(c *gin.Context, req MCPRequest, openProgress progressOpener) *MCPResponse {
 	var params ToolCallParams
 	if err := json.Unmarshal(req.Params, &params); err != nil {
-		s.sendError(c, req.ID, -32602, "Invalid params", err.Error())
-		return
+		resp := errorResponse(req.ID, codeInvalidParams, "Invalid params", err.Error())
+		return &resp
+	}
+	// Tools outside the token's allowlist are reported as unknown, as they aren't listed to it either
+	if _, ok := s.registry.Get(params.Name); !ok || !allowTool(c, params.Name) {
+		resp := errorResponse(req.ID, codeInvalidParams, "Unknown tool", params.Name)
+		return &resp
+	}
+	if mismatch := s.versionMismatch(params); mismatch != nil {
+		resp := errorResponse(req.ID, codeInvalidParams, "Incompatible tool version", mismatch)
+		return &resp
+	}
+	if ok, retryAfter := s.reserveCall(c); !ok {
+		resp := rateLimitedResponse(req.ID, retryAfter)
+		return &resp
 	}
 
-	result, err := s.executeTool(c.Request.Context(), params.Name, params.Arguments)
-	if err != nil {
-		s.sendResult(c, req.ID, ToolCallResult{
+	// The call stops when the client disconnects or cancels it with notifications/cancelled
+	ctx, done := s.calls.start(callerContext(c), callerKey(c), req.ID)
+	defer done()
+	ctx = s.withSampler(ctx, params.Name)
+
+	// With a progress token, the tool's progress is sent to the client before the result
+	var sink progressSink
+	if openProgress != nil {
+		sink = openProgress(c, params.Meta)
+	}
+	if sink != nil {
+		ctx = utils.WithProgress(ctx, sink.notify)
+	}
+
+	var resp MCPResponse
+	var quotaErr *tools.QuotaError
+	result, err := s.executeTool(ctx, params.Name, params.Arguments)
+	switch {
+	case errors.As(err, &quotaErr):
+		resp = quotaExceededResponse(req.ID, quotaErr)
+	case err != nil:
+		resp = resultResponse(req.ID, ToolCallResult{
 			Content: []ContentItem{{Type: "text", Text: err.Error()}},
 			IsError: true,
 		})
-		return
+	default:
+		resp = resultResponse(req.ID, ToolCallResult{
+			Content: []ContentItem{{Type: "text", Text: string(result)}},
+		})
 	}
 
-	s.sendResult(c, req.ID, ToolCallResult{
-		Content: []ContentItem{{Type: "text", Text: string(result)}},
-	})
+	if sink != nil {
+		sink.finish(resp)
+		return nil
+	}
+	return &resp
+}
+
+// versionMismatch checks the tool version the caller expects (_meta.toolVersion), returning details of
+// the mismatch when the tool's major version differs, or nil when the call may proceed
+func (s *Server) versionMismatch(params ToolCallParams) map[string]string {
+	tool, ok := s.registry.Get(params.Name)
+	if !ok || params.Meta == nil || params.Meta.ToolVersion == "" || tools.CompatibleVersion(tool.Version(), params.Meta.ToolVersion) {
+		return nil
+	}
+	return map[string]string{"tool": params.Name, "requested": params.Meta.ToolVersion, "current": tool.Version()}
 }
 
 func (s *Server) executeTool(ctx context.Context, name string, args json.RawMessage) (json.RawMessage, error) {
-	tool, ok := s.registry.Get(name)
-	if !ok {
+	if _, ok := s.registry.Get(name); !ok {
 		return nil, fmt.Errorf("tool not found: %s", name)
 	}
 
+	if s.registry.Deprecation(name) != nil {
+		log.Printf("[MCP] Deprecated tool called: %s", name)
+	}
 	log.Printf("[MCP] Executing tool: %s", name)
-	result, err := tool.Execute(ctx, args)
+	result, err := s.registry.Execute(ctx, name, args)
 	if err != nil {
 		log.Printf("[MCP] Tool %s error: %v", name, err)
 		return nil, err
@@ -198,16 +360,30 @@ func (s *Server) executeTool(ctx context.Context, name string, args json.RawMess
 	return result, nil
 }
 
-func (s *Server) sendResult(c *gin.Context, id interface{}, result interface{}) {
-	c.JSON(http.StatusOK, MCPResponse{
+// sendResponse writes a single JSON-RPC response; calls refused by the rate limit get 429 and Retry-After
+func (s *Server) sendResponse(c *gin.Context, resp MCPResponse) {
+	status := http.StatusOK
+	if resp.Error != nil && resp.Error.Code == codeRateLimited {
+		status = http.StatusTooManyRequests
+		if data, ok := resp.Error.Data.(map[string]interface{}); ok {
+			if retryAfter, ok := data["retryAfterSeconds"].(int); ok {
+				setRetryAfter(c, retryAfter)
+			}
+		}
+	}
+	c.JSON(status, resp)
+}
+
+func resultResponse(id interface{}, result interface{}) MCPResponse {
+	return MCPResponse{
 		JSONRPC: "2.0",
 		ID:      id,
 		Result:  result,
-	})
+	}
 }
 
-func (s *Server) sendError(c *gin.Context, id interface{}, code int, message string, data interface{}) {
-	c.JSON(http.StatusOK, MCPResponse{
+func errorResponse(id interface{}, code int, message string, data interface{}) MCPResponse {
+	return MCPResponse{
 		JSONRPC: "2.0",
 		ID:      id,
 		Error: &MCPError{
@@ -215,5 +391,5 @@ func (s *Server) sendError(c *gin.Context, id interface{}, code int, message str
 			Message: message,
 			Data:    data,
 		},
-	})
+	}
 }
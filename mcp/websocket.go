@@ -0,0 +1,253 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+
+	"github.com/myjobmatch/backend/gemini"
+)
+
+const (
+	// webSocketSubprotocol is the Sec-WebSocket-Protocol value MCP clients offer
+	webSocketSubprotocol = "mcp"
+	// maxWebSocketMessageBytes caps the size of a message received over a WebSocket
+	maxWebSocketMessageBytes = 1 << 20
+	// maxWebSocketCalls caps the requests running at once on a connection; further requests are refused
+	maxWebSocketCalls = 8
+)
+
+// HandleWebSocket serves the MCP dispatcher over a WebSocket (GET /mcp/ws) for agent frameworks that
+// don't speak HTTP. Each text message is a JSON-RPC request, notification or batch, answered with the
+// same messages the HTTP endpoint would send. The upgrade request is authenticated like the other routes.
+func (s *Server) HandleWebSocket(c *gin.Context) {
+	if !checkProtocolVersion(c) {
+		return
+	}
+
+	// Authentication uses the Authorization header rather than cookies, so the origin isn't checked
+	server := websocket.Server{
+		Handshake: selectSubprotocol,
+		Handler: func(ws *websocket.Conn) {
+			s.serveWebSocket(c, ws)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// selectSubprotocol accepts the mcp subprotocol when the client offers it; clients offering none are accepted too
+func selectSubprotocol(config *websocket.Config, req *http.Request) error {
+	offered := config.Protocol
+	config.Protocol = nil
+	for _, protocol := range offered {
+		if protocol == webSocketSubprotocol {
+			config.Protocol = []string{webSocketSubprotocol}
+			return nil
+		}
+	}
+	if len(offered) > 0 {
+		return websocket.ErrBadWebSocketProtocol
+	}
+	return nil
+}
+
+// serveWebSocket reads messages until the connection closes. Requests run concurrently, so pings,
+// notifications/cancelled and the client's answers to sampling requests are handled while a long tool
+// call runs; calls still running when the connection closes are cancelled.
+func (s *Server) serveWebSocket(c *gin.Context, ws *websocket.Conn) {
+	defer ws.Close()
+	ws.MaxPayloadBytes = maxWebSocketMessageBytes
+
+	// The server's write timeout was set for the upgrade request; the connection lives longer
+	if err := ws.SetDeadline(time.Time{}); err != nil {
+		log.Printf("[MCP] Failed to clear WebSocket deadline: %v", err)
+	}
+
+	session := &wsSession{ws: ws, pending: make(map[string]chan wsReply)}
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	if s.samplingEnabled {
+		ctx = context.WithValue(ctx, sessionSamplerKey{}, gemini.Sampler(session))
+	}
+	c.Request = c.Request.WithContext(ctx)
+
+	slots := make(chan struct{}, maxWebSocketCalls)
+	var wg sync.WaitGroup
+
+	log.Printf("[MCP] WebSocket connection opened by %s", callerKey(c))
+	for {
+		var message []byte
+		if err := websocket.Message.Receive(ws, &message); err != nil {
+			if errors.Is(err, websocket.ErrFrameTooLarge) {
+				session.send(errorResponse(nil, codeInvalidRequest, "Invalid Request", "message too large"))
+				continue
+			}
+			if !errors.Is(err, io.EOF) {
+				log.Printf("[MCP] WebSocket read failed: %v", err)
+			}
+			break
+		}
+
+		// The reader never waits for a slot: it must stay free to read cancellations and sampling answers
+		if isBatch(message) {
+			if !acquireSlot(slots) {
+				session.send(errorResponse(nil, codeRateLimited, "Too many concurrent requests", map[string]interface{}{"retryAfterSeconds": 1}))
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-slots }()
+				session.answerBatch(s, c, message)
+			}()
+			continue
+		}
+
+		var msg wsMessage
+		if err := json.Unmarshal(message, &msg); err != nil {
+			session.send(errorResponse(nil, codeParseError, "Parse error", err.Error()))
+			continue
+		}
+		req := msg.MCPRequest
+
+		// Responses to the server's own requests (sampling) go to the call waiting for them
+		if req.Method == "" && req.ID != nil && (msg.Result != nil || msg.Error != nil) {
+			session.deliverReply(req.ID, wsReply{Result: msg.Result, Error: msg.Error})
+			continue
+		}
+		if req.Method == "initialize" {
+			session.sampling.Store(declaresSampling(req.Params))
+		}
+
+		// Notifications are handled as they arrive
+		if req.ID == nil {
+			if resp := s.handleRequest(c, req, nil); resp != nil {
+				session.send(*resp)
+			}
+			continue
+		}
+
+		if !acquireSlot(slots) {
+			session.send(errorResponse(req.ID, codeRateLimited, "Too many concurrent requests", map[string]interface{}{"retryAfterSeconds": 1}))
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if resp := s.handleRequest(c, req, session.openProgress); resp != nil {
+				session.send(*resp)
+			}
+		}()
+	}
+
+	cancel()
+	wg.Wait()
+	log.Printf("[MCP] WebSocket connection closed by %s", callerKey(c))
+}
+
+// acquireSlot takes a request slot if one is free
+func acquireSlot(slots chan struct{}) bool {
+	select {
+	case slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// wsMessage is a message read from a WebSocket: a request or notification, or a response from the client
+type wsMessage struct {
+	MCPRequest
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *MCPError       `json:"error,omitempty"`
+}
+
+// declaresSampling reports whether initialize params declare the client's sampling capability
+func declaresSampling(params json.RawMessage) bool {
+	var initialize InitializeParams
+	if err := json.Unmarshal(params, &initialize); err != nil {
+		return false
+	}
+	_, ok := initialize.Capabilities["sampling"]
+	return ok
+}
+
+// wsSession is one MCP WebSocket connection. Responses and progress come from several goroutines,
+// so writes are serialized.
+type wsSession struct {
+	mu sync.Mutex
+	ws *websocket.Conn
+
+	sampling  atomic.Bool // The client declared the sampling capability in initialize
+	pendingMu sync.Mutex
+	pending   map[string]chan wsReply // Requests sent to the client awaiting a response, by ID
+	nextID    int
+}
+
+// send writes a JSON message; a failed write means the connection is closing, which the read loop notices
+func (w *wsSession) send(v interface{}) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := websocket.JSON.Send(w.ws, v); err != nil {
+		log.Printf("[MCP] Failed to send WebSocket message: %v", err)
+	}
+}
+
+// answerBatch answers a batch with an array of responses, or nothing when it held only notifications
+func (w *wsSession) answerBatch(s *Server, c *gin.Context, body []byte) {
+	responses, failure := s.runBatch(c, body)
+	if failure != nil {
+		w.send(*failure)
+		return
+	}
+	if len(responses) > 0 {
+		w.send(responses)
+	}
+}
+
+// openProgress is the progressOpener of WebSocket connections: progress is sent as notifications on
+// the connection whenever the call carries a progress token
+func (w *wsSession) openProgress(c *gin.Context, meta *RequestMeta) progressSink {
+	if meta == nil || meta.ProgressToken == nil {
+		return nil
+	}
+	return &wsProgress{session: w, token: meta.ProgressToken}
+}
+
+// wsProgress sends one tool call's progress notifications, then its response, over a WebSocket
+type wsProgress struct {
+	mu       sync.Mutex
+	session  *wsSession
+	token    interface{}
+	progress int
+	done     bool
+}
+
+func (p *wsProgress) notify(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		return
+	}
+
+	p.progress++
+	p.session.send(progressNotification(p.token, p.progress, message))
+}
+
+func (p *wsProgress) finish(resp MCPResponse) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done = true
+	p.session.send(resp)
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// UserQuery filters and pages the admin user listing, newest accounts first
+type UserQuery struct {
+	Provider      string    // "email" or "google"; empty for both
+	CreatedAfter  time.Time // Zero for no lower bound
+	CreatedBefore time.Time // Zero for no upper bound
+	HasCV         *bool     // nil for users with and without a CV
+	Suspended     *bool     // nil for suspended and active users
+	Cursor        string    // ID of the last user of the previous page
+	Limit         int
+}
+
+// AdminUserListResponse is a page of users
+// @Description Users matching the filters, newest first; pass next_cursor as cursor to get the next page
+type AdminUserListResponse struct {
+	Users      []User `json:"users"`
+	NextCursor string `json:"next_cursor,omitempty" example:"7b0e3c5a-2f4d-4c1e-9a8b-5d6f7e8a9b0c"` // Empty on the last page
+}
+
+// SuspendUserRequest is the body of a suspension
+// @Description Why the account is suspended
+type SuspendUserRequest struct {
+	Reason string `json:"reason" binding:"max=500" example:"Scraping the API with shared credentials"`
+}
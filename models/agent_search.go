@@ -0,0 +1,31 @@
+package models
+
+import "encoding/json"
+
+// AgentSearchRequest is an open-ended request answered by Gemini choosing which tools to call
+type AgentSearchRequest struct {
+	Query     string     `json:"query" binding:"required" example:"Compare my fit for fintech vs e-commerce backend roles in Jakarta"`
+	CVText    string     `json:"cvText,omitempty"`                                          // Optional; authenticated users' saved profile is used otherwise
+	Budget    *LLMBudget `json:"budget,omitempty"`                                          // Optional caps on Gemini usage
+	Incognito bool       `json:"incognito,omitempty" example:"false"`                       // Strip name, email and phone before the profile is sent to the model
+	MaxSteps  int        `json:"max_steps,omitempty" binding:"omitempty,min=1" example:"6"` // Tool-calling turns (capped by server limit)
+}
+
+// AgentStep is a tool call made by the model during an agent search
+// @Description Tool call made during an agent search
+type AgentStep struct {
+	Tool      string          `json:"tool" example:"search_web_for_jobs"`
+	Arguments json.RawMessage `json:"arguments" swaggertype:"object"`
+	Success   bool            `json:"success"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// AgentSearchResponse is the model's answer with the tool calls it made
+// @Description Agent search answer and tool calls
+type AgentSearchResponse struct {
+	Answer         string       `json:"answer"`
+	Steps          []AgentStep  `json:"steps"`
+	Profile        *UserProfile `json:"profile,omitempty"`
+	StepsExhausted bool         `json:"steps_exhausted"` // True if the step budget ran out before the model finished
+	LLMUsage       *LLMUsage    `json:"llm_usage,omitempty"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Job alert frequencies
+const (
+	AlertFrequencyDaily  = "daily"
+	AlertFrequencyWeekly = "weekly"
+)
+
+// JobAlert is a saved search that is rerun on a schedule, emailing new matches
+// @Description Saved search with scheduled re-runs
+type JobAlert struct {
+	ID        string          `json:"id" firestore:"-" example:"k2J9x0Qm"`
+	UserID    string          `json:"-" firestore:"userId"`
+	Name      string          `json:"name" firestore:"name" example:"Golang roles in Jakarta"`
+	Query     string          `json:"query,omitempty" firestore:"query,omitempty" example:"golang developer jakarta"`
+	Filters   JobSearchFilter `json:"filters" firestore:"filters"`
+	Frequency string          `json:"frequency" firestore:"frequency" example:"daily"` // daily, weekly
+	MinScore  int             `json:"minScore" firestore:"minScore" example:"70"`
+	SeenURLs  []string        `json:"-" firestore:"seenUrls"`
+	LastRunAt time.Time       `json:"lastRunAt,omitempty" firestore:"lastRunAt"`
+	NextRunAt time.Time       `json:"nextRunAt" firestore:"nextRunAt"`
+	CreatedAt time.Time       `json:"createdAt" firestore:"createdAt"`
+}
+
+// Interval returns how long to wait between runs of the alert
+func (a *JobAlert) Interval() time.Duration {
+	if a.Frequency == AlertFrequencyWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// CreateJobAlertRequest represents a request to save a search as an alert
+// @Description Save a query and filters to be rerun on a schedule
+type CreateJobAlertRequest struct {
+	Name      string          `json:"name" binding:"required,max=100" example:"Golang roles in Jakarta"`
+	Query     string          `json:"query,omitempty" binding:"max=500" example:"golang developer jakarta"`
+	Filters   JobSearchFilter `json:"filters,omitempty"`
+	Frequency string          `json:"frequency" binding:"required,oneof=daily weekly" example:"daily"`
+	MinScore  int             `json:"minScore,omitempty" binding:"omitempty,min=0,max=100" example:"70"`
+}
+
+// JobAlertResponse lists job alerts
+// @Description Job alerts
+type JobAlertResponse struct {
+	Alerts  []JobAlert `json:"alerts"`
+	Message string     `json:"message,omitempty" example:"Job alert created"`
+}
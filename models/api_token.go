@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// APIToken is a personal access token that lets a user script against the API
+// @Description Personal access token metadata (the secret is only returned on creation)
+type APIToken struct {
+	ID                    string     `json:"id" firestore:"-" example:"3f2a9c1e"`
+	UserID                string     `json:"-" firestore:"userId"`
+	TokenHash             string     `json:"-" firestore:"tokenHash"` // SHA-256 of the secret; the secret itself is never stored
+	Name                  string     `json:"name" firestore:"name" example:"nightly search script"`
+	Prefix                string     `json:"prefix" firestore:"prefix" example:"mjm_pat_Ab3x"` // First characters of the token, for identification
+	Scopes                []string   `json:"scopes" firestore:"scopes" example:"search,read-profile"`
+	MCPTools              []string   `json:"mcpTools,omitempty" firestore:"mcpTools,omitempty" example:"search_web_for_jobs,score_job_match"` // MCP tools the token may call; empty allows all
+	MCPRateLimitPerMinute int        `json:"mcpRateLimitPerMinute,omitempty" firestore:"mcpRateLimitPerMinute,omitempty" example:"10"`        // MCP tool calls per minute; 0 uses the server limit
+	ExpiresAt             *time.Time `json:"expiresAt,omitempty" firestore:"expiresAt,omitempty"`
+	LastUsedAt            *time.Time `json:"lastUsedAt,omitempty" firestore:"lastUsedAt,omitempty"`
+	CreatedAt             time.Time  `json:"createdAt" firestore:"createdAt"`
+}
+
+// HasScope reports whether the token grants the given scope
+func (t *APIToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsMCPTool reports whether the token may list and call the given MCP tool
+func (t *APIToken) AllowsMCPTool(name string) bool {
+	if len(t.MCPTools) == 0 {
+		return true
+	}
+	for _, tool := range t.MCPTools {
+		if tool == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired reports whether the token has expired
+func (t *APIToken) IsExpired() bool {
+	return t.ExpiresAt != nil && time.Now().After(*t.ExpiresAt)
+}
+
+// CreateAPITokenRequest represents a request to mint a personal access token
+// @Description Personal access token creation request
+type CreateAPITokenRequest struct {
+	Name          string   `json:"name" binding:"required,max=100" example:"nightly search script"`
+	Scopes        []string `json:"scopes" binding:"required,min=1,dive,oneof=search read-profile mcp" example:"search,read-profile"`
+	ExpiresInDays int      `json:"expiresInDays,omitempty" binding:"omitempty,min=1,max=365" example:"90"` // Omit for a non-expiring token
+	// MCP tool allowlist and rate limit; only meaningful with the mcp scope
+	MCPTools              []string `json:"mcpTools,omitempty" binding:"omitempty,max=20,dive,required,max=100" example:"search_web_for_jobs,score_job_match"` // Omit to allow every tool
+	MCPRateLimitPerMinute int      `json:"mcpRateLimitPerMinute,omitempty" binding:"omitempty,min=1,max=600" example:"10"`                                    // Omit for the server limit; capped by it
+}
+
+// CreateAPITokenResponse returns a newly minted token; the secret is shown only once
+// @Description Newly created personal access token
+type CreateAPITokenResponse struct {
+	Token   string    `json:"token" example:"mjm_pat_Ab3xQ9..."`
+	Details *APIToken `json:"details"`
+	Message string    `json:"message,omitempty" example:"Store this token securely, it will not be shown again"`
+}
+
+// APITokenListResponse lists a user's personal access tokens
+// @Description Personal access tokens
+type APITokenListResponse struct {
+	Tokens  []APIToken `json:"tokens"`
+	Message string     `json:"message,omitempty"`
+}
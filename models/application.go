@@ -0,0 +1,95 @@
+package models
+
+import "time"
+
+// Application statuses, in pipeline order
+const (
+	ApplicationStatusApplied      = "applied"
+	ApplicationStatusInterviewing = "interviewing"
+	ApplicationStatusOffer        = "offer"
+	ApplicationStatusRejected     = "rejected"
+)
+
+// ApplicationStatuses lists the statuses in board column order
+var ApplicationStatuses = []string{
+	ApplicationStatusApplied,
+	ApplicationStatusInterviewing,
+	ApplicationStatusOffer,
+	ApplicationStatusRejected,
+}
+
+// Application tracks a user's application to a job
+// @Description Job application in the user's tracker
+type Application struct {
+	ID            string                    `json:"id" firestore:"-" example:"f3a9c1e2"`
+	UserID        string                    `json:"-" firestore:"userId"`
+	JobTitle      string                    `json:"jobTitle" firestore:"jobTitle" example:"Senior Golang Backend Engineer"`
+	Company       string                    `json:"company" firestore:"company" example:"TechCorp"`
+	JobURL        string                    `json:"jobUrl,omitempty" firestore:"jobUrl,omitempty" example:"https://example.com/job/123"`
+	Location      string                    `json:"location,omitempty" firestore:"location,omitempty" example:"Jakarta"`
+	MatchScore    int                       `json:"matchScore,omitempty" firestore:"matchScore,omitempty" example:"92"`
+	Status        string                    `json:"status" firestore:"status" example:"interviewing"` // applied, interviewing, offer, rejected
+	Notes         string                    `json:"notes,omitempty" firestore:"notes,omitempty" example:"Referred by Budi; tech interview with the payments team"`
+	AppliedAt     *time.Time                `json:"appliedAt,omitempty" firestore:"appliedAt,omitempty"`
+	NextStepAt    *time.Time                `json:"nextStepAt,omitempty" firestore:"nextStepAt,omitempty"` // Next interview or follow-up date
+	StatusHistory []ApplicationStatusChange `json:"statusHistory" firestore:"statusHistory"`
+	CreatedAt     time.Time                 `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt     time.Time                 `json:"updatedAt" firestore:"updatedAt"`
+}
+
+// ApplicationStatusChange records when an application moved to a status
+type ApplicationStatusChange struct {
+	Status    string    `json:"status" firestore:"status" example:"interviewing"`
+	ChangedAt time.Time `json:"changedAt" firestore:"changedAt"`
+}
+
+// CreateApplicationRequest represents a request to track a job application
+// @Description Track a new job application
+type CreateApplicationRequest struct {
+	JobTitle   string     `json:"jobTitle" binding:"required,max=200" example:"Senior Golang Backend Engineer"`
+	Company    string     `json:"company" binding:"required,max=200" example:"TechCorp"`
+	JobURL     string     `json:"jobUrl,omitempty" binding:"omitempty,url" example:"https://example.com/job/123"`
+	Location   string     `json:"location,omitempty" example:"Jakarta"`
+	MatchScore int        `json:"matchScore,omitempty" binding:"omitempty,min=0,max=100" example:"92"`
+	Status     string     `json:"status,omitempty" binding:"omitempty,oneof=applied interviewing offer rejected" example:"applied"` // Defaults to applied
+	Notes      string     `json:"notes,omitempty" binding:"max=5000" example:"Applied via referral"`
+	AppliedAt  *time.Time `json:"appliedAt,omitempty"` // Defaults to now
+	NextStepAt *time.Time `json:"nextStepAt,omitempty"`
+}
+
+// UpdateApplicationRequest updates an application; only fields present are changed
+// @Description Partial application update
+type UpdateApplicationRequest struct {
+	Status     *string    `json:"status,omitempty" binding:"omitempty,oneof=applied interviewing offer rejected" example:"interviewing"`
+	Notes      *string    `json:"notes,omitempty" binding:"omitempty,max=5000" example:"Tech interview scheduled"`
+	AppliedAt  *time.Time `json:"appliedAt,omitempty"`
+	NextStepAt *time.Time `json:"nextStepAt,omitempty"`
+}
+
+// ApplicationResponse wraps a single application
+// @Description Job application
+type ApplicationResponse struct {
+	Application Application `json:"application"`
+	Message     string      `json:"message,omitempty" example:"Application updated"`
+}
+
+// ApplicationListResponse lists applications
+// @Description Job applications
+type ApplicationListResponse struct {
+	Applications []Application `json:"applications"`
+	Message      string        `json:"message,omitempty"`
+}
+
+// ApplicationBoardColumn is one status column of the application board
+type ApplicationBoardColumn struct {
+	Status       string        `json:"status" example:"interviewing"`
+	Count        int           `json:"count" example:"3"`
+	Applications []Application `json:"applications"`
+}
+
+// ApplicationBoardResponse groups applications by status for a kanban view
+// @Description Applications grouped by status, in pipeline order
+type ApplicationBoardResponse struct {
+	Columns []ApplicationBoardColumn `json:"columns"`
+	Total   int                      `json:"total" example:"12"`
+}
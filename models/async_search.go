@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// Async search statuses
+const (
+	SearchStatusPending   = "pending"
+	SearchStatusRunning   = "running"
+	SearchStatusCompleted = "completed"
+	SearchStatusFailed    = "failed"
+
+	// The instance running the search shut down before it finished; resumable searches can be resumed
+	SearchStatusInterrupted = "interrupted"
+)
+
+// AsyncSearch is a job search running in the background, polled by ID
+// @Description Background job search status and results
+type AsyncSearch struct {
+	ID          string              `json:"id" firestore:"-" example:"Kq3xN0aB7cD9eF1gH2iJ"`
+	UserID      string              `json:"-" firestore:"userId,omitempty"`
+	TenantID    string              `json:"-" firestore:"tenantId,omitempty"`
+	Status      string              `json:"status" firestore:"status" example:"completed"` // pending, running, completed, failed, interrupted
+	Query       string              `json:"query,omitempty" firestore:"query,omitempty" example:"golang developer jakarta"`
+	Result      *SearchJobsResponse `json:"result,omitempty" firestore:"result,omitempty"`
+	Error       string              `json:"error,omitempty" firestore:"error,omitempty"`
+	Resumable   bool                `json:"resumable,omitempty" firestore:"resumable,omitempty"` // Interrupted, and can be resumed with POST /search-jobs/{id}/resume
+	CreatedAt   time.Time           `json:"created_at" firestore:"createdAt"`
+	StartedAt   *time.Time          `json:"started_at,omitempty" firestore:"startedAt,omitempty"`
+	CompletedAt *time.Time          `json:"completed_at,omitempty" firestore:"completedAt,omitempty"`
+
+	// Finished searches are posted to CallbackURL, signed with CallbackSecret; without one, to the user's webhook
+	CallbackURL    string `json:"-" firestore:"callbackUrl,omitempty"`
+	CallbackSecret string `json:"-" firestore:"callbackSecret,omitempty"`
+
+	// Checkpoint is the search input, kept until the search finishes so it can be resumed if interrupted.
+	// Searches with an uploaded CV file have none.
+	Checkpoint *AsyncSearchCheckpoint `json:"-" firestore:"checkpoint,omitempty"`
+}
+
+// AsyncSearchCheckpoint is the input of a background search, as resolved when it was queued
+type AsyncSearchCheckpoint struct {
+	Profile    *UserProfile    `firestore:"profile,omitempty"` // Saved structured profile the search used
+	CVText     string          `firestore:"cvText,omitempty"`
+	Query      string          `firestore:"query,omitempty"`
+	Filters    JobSearchFilter `firestore:"filters"`
+	Budget     *LLMBudget      `firestore:"budget,omitempty"`
+	Incognito  bool            `firestore:"incognito,omitempty"`
+	MaxResults int             `firestore:"maxResults,omitempty"`
+	MaxPages   int             `firestore:"maxPages,omitempty"`
+	Model      string          `firestore:"model,omitempty"`
+}
+
+// AsyncSearchResponse is returned when a background search is queued
+// @Description Queued background search
+type AsyncSearchResponse struct {
+	SearchID  string `json:"search_id" example:"Kq3xN0aB7cD9eF1gH2iJ"`
+	Status    string `json:"status" example:"pending"`
+	StatusURL string `json:"status_url" example:"/api/search-jobs/Kq3xN0aB7cD9eF1gH2iJ"`
+
+	// Signs the delivery to the request's callback_url; only returned when one was given
+	CallbackSecret string `json:"callback_secret,omitempty" example:"whsec_q1w2e3r4t5y6u7i8o9p0a1s2d3f4g5h6j7k8l9z0x1c"`
+}
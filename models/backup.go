@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// BackupManifest describes a completed backup. It is written after every collection, so only complete
+// backups have one.
+// @Description A database backup: documents exported per collection
+type BackupManifest struct {
+	ID          string         `json:"id" example:"20260115T030000Z"` // Start time (UTC), also the backup's folder
+	Backend     string         `json:"backend" example:"firestore"`   // STORAGE_BACKEND the backup restores into
+	Collections map[string]int `json:"collections"`                   // Documents exported per collection
+	StartedAt   time.Time      `json:"startedAt"`
+	CompletedAt time.Time      `json:"completedAt"`
+}
+
+// BackupListResponse lists the completed backups
+// @Description Completed backups, newest first
+type BackupListResponse struct {
+	Backups []BackupManifest `json:"backups"`
+}
+
+// RestoreBackupRequest selects what to restore from a backup
+// @Description Collections to restore; all of the backup's collections when empty
+type RestoreBackupRequest struct {
+	Collections []string `json:"collections,omitempty" example:"users,user_emails"`
+}
+
+// RestoreBackupResponse reports what a restore wrote
+// @Description Documents restored per collection
+type RestoreBackupResponse struct {
+	ID       string         `json:"id" example:"20260115T030000Z"`
+	Restored map[string]int `json:"restored"`
+}
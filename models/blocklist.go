@@ -0,0 +1,14 @@
+package models
+
+// BlockedCompaniesRequest replaces the user's company blocklist
+// @Description Companies to hide from all searches and alerts
+type BlockedCompaniesRequest struct {
+	Companies []string `json:"companies" binding:"max=100,dive,max=200" example:"PT Current Employer Tbk"`
+}
+
+// BlockedCompaniesResponse represents the user's company blocklist
+// @Description Companies hidden from all searches and alerts
+type BlockedCompaniesResponse struct {
+	Companies []string `json:"companies" example:"PT Current Employer Tbk"`
+	Message   string   `json:"message,omitempty" example:"Blocked companies updated"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Chat message roles
+const (
+	ChatRoleUser      = "user"
+	ChatRoleAssistant = "assistant"
+)
+
+// ChatMessage is one message of a career assistant conversation
+// @Description Message in a career assistant conversation
+type ChatMessage struct {
+	Role      string    `json:"role" firestore:"role" example:"assistant"` // user or assistant
+	Content   string    `json:"content" firestore:"content" example:"Here are three backend roles in Jakarta that fit your Go experience..."`
+	Tools     []string  `json:"tools,omitempty" firestore:"tools,omitempty" example:"search_jobs"` // Tools the assistant called to answer
+	CreatedAt time.Time `json:"created_at" firestore:"createdAt"`
+}
+
+// ChatSession is a stored career assistant conversation
+// @Description Career assistant conversation
+type ChatSession struct {
+	ID        string        `json:"id" firestore:"-" example:"c4Hq8ZpR2mXy"`
+	UserID    string        `json:"-" firestore:"userId"`
+	Messages  []ChatMessage `json:"messages" firestore:"messages"`
+	CreatedAt time.Time     `json:"created_at" firestore:"createdAt"`
+	UpdatedAt time.Time     `json:"updated_at" firestore:"updatedAt"`
+	ExpiresAt time.Time     `json:"-" firestore:"expiresAt"` // Firestore TTL policy field
+}
+
+// ChatRequest is a message to the career assistant
+// @Description Message to the career assistant; omit session_id to start a new conversation
+type ChatRequest struct {
+	SessionID string     `json:"session_id,omitempty" example:"c4Hq8ZpR2mXy"`
+	Message   string     `json:"message" binding:"required" example:"Find me remote Go roles, but skip crypto companies"`
+	Budget    *LLMBudget `json:"budget,omitempty"` // Optional caps on Gemini usage for this message
+}
+
+// ChatResponse is the assistant's reply
+// @Description Career assistant reply
+type ChatResponse struct {
+	SessionID string      `json:"session_id" example:"c4Hq8ZpR2mXy"`
+	Reply     string      `json:"reply"`
+	Steps     []AgentStep `json:"steps"`          // Tool calls made to answer
+	Jobs      []RankedJob `json:"jobs,omitempty"` // Results of the last job search the assistant ran, if any
+	LLMUsage  *LLMUsage   `json:"llm_usage,omitempty"`
+}
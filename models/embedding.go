@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Embedding kinds
+const (
+	EmbeddingKindProfile = "profile" // A user's profile, by user ID
+	EmbeddingKindJob     = "job"     // A posting, by job ID
+)
+
+// Embedding is the stored embedding vector of a profile or posting
+type Embedding struct {
+	Kind        string // EmbeddingKindProfile or EmbeddingKindJob
+	ID          string // User email for profiles, job ID for postings
+	Model       string // Embedding model; only vectors of the same model are compared
+	ContentHash string // Hash of the embedded text, to tell when the vector is stale
+	Vector      []float32
+	UpdatedAt   time.Time // Set when stored
+}
+
+// EmbeddingQuery finds the stored embeddings most similar to a vector
+type EmbeddingQuery struct {
+	Kind   string
+	Model  string
+	Vector []float32
+	Limit  int // At most 1000
+}
+
+// EmbeddingMatch is a stored embedding found by an EmbeddingQuery
+type EmbeddingMatch struct {
+	ID         string
+	Similarity float64 // Cosine similarity to the query vector, from -1 to 1
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Job feedback ratings
+const (
+	FeedbackRelevant       = "relevant"
+	FeedbackNotRelevant    = "not_relevant"
+	FeedbackAlreadyApplied = "already_applied"
+)
+
+// JobFeedback is a user's rating of a job returned by a search.
+// Title, company and tags are copied from the job so feedback can be used without looking jobs up.
+// @Description User feedback on a job match
+type JobFeedback struct {
+	JobID     string    `json:"jobId" firestore:"jobId" example:"4f2a9c0e7b1d3a5f8e6c2b9d0a1f3e7c"`
+	UserID    string    `json:"-" firestore:"userId"`
+	Rating    string    `json:"rating" firestore:"rating" example:"not_relevant"` // relevant, not_relevant, already_applied
+	Title     string    `json:"title" firestore:"title" example:"Senior Golang Backend Engineer"`
+	Company   string    `json:"company" firestore:"company" example:"TechCorp"`
+	Tags      []string  `json:"tags,omitempty" firestore:"tags,omitempty" example:"golang,backend"`
+	UpdatedAt time.Time `json:"updatedAt" firestore:"updatedAt"`
+
+	// Prompt experiment variant that scored the rated job, so feedback can be attributed to it
+	PromptVariant string `json:"-" firestore:"promptVariant,omitempty"`
+}
+
+// JobFeedbackRequest rates a job
+// @Description Feedback on a job match
+type JobFeedbackRequest struct {
+	Rating string `json:"rating" binding:"required,oneof=relevant not_relevant already_applied" example:"not_relevant"`
+}
+
+// JobFeedbackResponse represents recorded feedback
+// @Description Recorded job feedback
+type JobFeedbackResponse struct {
+	Feedback *JobFeedback `json:"feedback"`
+	Message  string       `json:"message,omitempty" example:"Feedback recorded"`
+}
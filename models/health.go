@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// HealthResponse represents health check response
+// @Description Server health status
+type HealthResponse struct {
+	Status       string                      `json:"status" example:"healthy" enums:"healthy,degraded,unhealthy"`
+	Version      string                      `json:"version" example:"1.0.0"`
+	Timestamp    string                      `json:"timestamp" example:"2024-01-15T10:30:00Z"`
+	Dependencies map[string]DependencyStatus `json:"dependencies,omitempty"`
+}
+
+// DependencyStatus is the latest probe of one dependency
+// @Description Result of probing a dependency (database, cv_storage, llm, pse or redis)
+type DependencyStatus struct {
+	Status    string    `json:"status" example:"up" enums:"up,down"`
+	Critical  bool      `json:"critical"` // Readiness fails while a critical dependency is down
+	LatencyMs int64     `json:"latencyMs" example:"42"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
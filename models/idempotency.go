@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// IdempotencyRecord is a request made with an Idempotency-Key header: reserved while the request runs,
+// then holding its response so retries with the same key are answered without running it again
+type IdempotencyRecord struct {
+	Scope       string    `firestore:"scope"` // ID of the signed-in user, or "anonymous:" and the client IP
+	Key         string    `firestore:"key"`
+	Fingerprint string    `firestore:"fingerprint"` // Hash of the route and body, so a key can't be reused for another request
+	Completed   bool      `firestore:"completed"`
+	Status      int       `firestore:"status,omitempty"`
+	ContentType string    `firestore:"contentType,omitempty"`
+	Body        []byte    `firestore:"body,omitempty"`
+	CreatedAt   time.Time `firestore:"createdAt"`
+	ExpiresAt   time.Time `firestore:"expiresAt"` // While running, when the reservation lapses; once completed, when the response is forgotten
+}
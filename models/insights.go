@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// InsightsReport summarizes the job market seen in recent searches
+// @Description Job market insights aggregated from stored jobs
+type InsightsReport struct {
+	From          time.Time       `json:"from"`
+	To            time.Time       `json:"to"`
+	Role          string          `json:"role,omitempty" example:"backend"`
+	Location      string          `json:"location,omitempty" example:"jakarta"`
+	TotalJobs     int             `json:"total_jobs" example:"412"`
+	TopSkills     []SkillDemand   `json:"top_skills"`
+	SalaryRanges  []SalaryInsight `json:"salary_ranges"`
+	PostingVolume []VolumePoint   `json:"posting_volume"`
+	WorkTypes     map[string]int  `json:"work_types"`
+	SiteSettings  map[string]int  `json:"site_settings"`
+}
+
+// SkillDemand is how often a skill or technology appears in postings
+type SkillDemand struct {
+	Skill string  `json:"skill" example:"golang"`
+	Count int     `json:"count" example:"87"`
+	Share float64 `json:"share" example:"0.21"` // Fraction of postings mentioning the skill
+}
+
+// SalaryInsight is the typical advertised monthly salary for a role in a location
+type SalaryInsight struct {
+	Role       string `json:"role" example:"backend engineer"`
+	Location   string `json:"location" example:"Jakarta"`
+	Currency   string `json:"currency" example:"IDR"`
+	MedianMin  int    `json:"median_min" example:"12000000"`
+	MedianMax  int    `json:"median_max" example:"20000000"`
+	LowestMin  int    `json:"lowest_min" example:"8000000"`
+	HighestMax int    `json:"highest_max" example:"35000000"`
+	SampleSize int    `json:"sample_size" example:"14"`
+}
+
+// VolumePoint is the number of new postings first seen in a week
+type VolumePoint struct {
+	WeekStart string `json:"week_start" example:"2025-01-06"` // Monday, YYYY-MM-DD
+	Count     int    `json:"count" example:"58"`
+}
@@ -1,6 +1,9 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+)
 
 // FlexibleStringSlice can unmarshal from either a string or []string
 type FlexibleStringSlice []string
@@ -31,6 +34,7 @@ func (f *FlexibleStringSlice) UnmarshalJSON(data []byte) error {
 
 // JobPosting represents a job posting extracted from a webpage
 type JobPosting struct {
+	ID          string   `json:"id,omitempty"` // Stable ID derived from the canonical URL
 	Title       string   `json:"title"`
 	Company     string   `json:"company"`
 	Description string   `json:"description"`
@@ -43,20 +47,70 @@ type JobPosting struct {
 
 	// Optional fields
 	Salary          string              `json:"salary,omitempty"`
+	SalaryMin       int                 `json:"salary_min,omitempty"`      // Parsed from Salary
+	SalaryMax       int                 `json:"salary_max,omitempty"`      // Parsed from Salary
+	SalaryCurrency  string              `json:"salary_currency,omitempty"` // ISO 4217, e.g. IDR
+	SalaryPeriod    string              `json:"salary_period,omitempty"`   // hour, day, week, month, year
 	DatePosted      string              `json:"date_posted,omitempty"`
 	ApplicationURL  string              `json:"application_url,omitempty"`
 	Requirements    string              `json:"requirements,omitempty"`
 	Benefits        FlexibleStringSlice `json:"benefits,omitempty"`
 	ExperienceLevel string              `json:"experience_level,omitempty"` // entry, mid, senior, lead
+	Language        string              `json:"language,omitempty"`         // ISO 639-1 language of the posting, e.g. id
+	ValidThrough    string              `json:"valid_through,omitempty"`    // Application deadline (YYYY-MM-DD), when the page states one
+	Expired         bool                `json:"expired,omitempty"`          // The page says the posting no longer accepts applications
+
+	// Links lists every portal the posting was found on when duplicates were merged
+	Links []JobLink `json:"links,omitempty"`
+
+	// CompanyInfo is added by the company enrichment step when enabled
+	CompanyInfo *Company `json:"company_info,omitempty"`
+}
+
+// Company describes the employer behind a posting, researched from the web
+type Company struct {
+	Name     string  `json:"name"`
+	Website  string  `json:"website,omitempty"`
+	Industry string  `json:"industry,omitempty"`
+	Size     string  `json:"size,omitempty"`   // Employee count range, e.g. "51-200"
+	Rating   float64 `json:"rating,omitempty"` // Employee review rating out of 5, when found
+	Summary  string  `json:"summary,omitempty"`
+}
+
+// JobLink is one place a job posting was found
+type JobLink struct {
+	Source string `json:"source"` // web, linkedin, etc.
+	URL    string `json:"url"`
 }
 
 // RankedJob is a JobPosting with match scoring
 type RankedJob struct {
 	JobPosting
-	MatchScore  int    `json:"match_score"`  // 0-100
-	MatchReason string `json:"match_reason"` // 1-2 sentence explanation
+	MatchScore     int             `json:"match_score"`  // 0-100, composite of the AI and heuristic scores
+	MatchReason    string          `json:"match_reason"` // 1-2 sentence explanation
+	ScoreMethod    string          `json:"score_method,omitempty"`
+	ScoreBreakdown *ScoreBreakdown `json:"score_breakdown,omitempty"`
+	AIScore        int             `json:"ai_score,omitempty"`     // Gemini's judgment, 0-100 (absent when not scored by Gemini)
+	HeuristicScore int             `json:"heuristic_score"`        // Deterministic score, 0-100
+	SkillsMatch    int             `json:"skills_match,omitempty"` // Percentage of profile skills mentioned in the posting
 }
 
+// ScoreBreakdown rates each matching criterion separately (0-100 each)
+type ScoreBreakdown struct {
+	Skills     int `json:"skills"`
+	Experience int `json:"experience"`
+	Location   int `json:"location"`
+	WorkType   int `json:"work_type"`
+	Domain     int `json:"domain,omitempty"` // Rated by Gemini only
+	Title      int `json:"title,omitempty"`  // Rated by the heuristic scorer only
+}
+
+// ScoreMethod constants describe how a match score was produced
+const (
+	ScoreMethodAI        = "ai"        // Scored by Gemini
+	ScoreMethodEstimated = "estimated" // Heuristic estimate (Gemini failed or LLM budget reached)
+)
+
 // JobSearchResult represents a single search result from PSE
 type JobSearchResult struct {
 	Title   string `json:"title"`
@@ -73,6 +127,15 @@ const (
 	WorkTypeFreelance  = "freelance"
 )
 
+// SalaryPeriod constants: the period a posting's salary amounts are paid per
+const (
+	SalaryPeriodHour  = "hour"
+	SalaryPeriodDay   = "day"
+	SalaryPeriodWeek  = "week"
+	SalaryPeriodMonth = "month"
+	SalaryPeriodYear  = "year"
+)
+
 // SiteSetting constants
 const (
 	SiteSettingWFH     = "WFH"
@@ -89,32 +152,32 @@ const (
 	ExperienceLevelLead   = "lead"
 )
 
-// NormalizeWorkType normalizes various work type strings to standard values
+// NormalizeWorkType normalizes various work type strings (English or Bahasa Indonesia) to standard values
 func NormalizeWorkType(raw string) string {
-	switch raw {
-	case "full-time", "Full-Time", "Full Time", "fulltime", "FULL_TIME":
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "full_time", "full-time", "full time", "fulltime", "permanent", "penuh waktu", "karyawan tetap", "tetap":
 		return WorkTypeFullTime
-	case "part-time", "Part-Time", "Part Time", "parttime", "PART_TIME":
+	case "part_time", "part-time", "part time", "parttime", "paruh waktu":
 		return WorkTypePartTime
-	case "contract", "Contract", "CONTRACT", "contractor":
+	case "contract", "contractor", "kontrak", "pkwt":
 		return WorkTypeContract
-	case "internship", "Internship", "INTERNSHIP", "intern":
+	case "internship", "intern", "magang":
 		return WorkTypeInternship
-	case "freelance", "Freelance", "FREELANCE":
+	case "freelance", "freelancer", "lepas", "pekerja lepas":
 		return WorkTypeFreelance
 	default:
 		return raw
 	}
 }
 
-// NormalizeSiteSetting normalizes various site setting strings to standard values
+// NormalizeSiteSetting normalizes various site setting strings (English or Bahasa Indonesia) to standard values
 func NormalizeSiteSetting(raw string) string {
-	switch raw {
-	case "remote", "Remote", "REMOTE", "work from home", "Work From Home", "wfh":
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "wfh", "remote", "work from home", "fully remote", "kerja dari rumah", "jarak jauh":
 		return SiteSettingWFH
-	case "onsite", "Onsite", "ONSITE", "on-site", "On-Site", "office", "Office", "wfo", "work from office":
+	case "wfo", "onsite", "on-site", "on site", "office", "work from office", "kerja di kantor", "di kantor":
 		return SiteSettingWFO
-	case "hybrid", "Hybrid", "HYBRID", "flexible":
+	case "hybrid", "flexible", "hibrida", "fleksibel":
 		return SiteSettingHybrid
 	default:
 		return SiteSettingUnknown
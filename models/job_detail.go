@@ -0,0 +1,105 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// StoredJob is an extracted job posting kept in the jobs collection under its stable ID
+type StoredJob struct {
+	Job         JobPosting `json:"job" firestore:"job"`
+	TenantID    string     `json:"-" firestore:"tenantId,omitempty"` // Tenant whose searches found the posting first
+	FirstSeenAt time.Time  `json:"first_seen_at" firestore:"firstSeenAt"`
+	LastSeenAt  time.Time  `json:"last_seen_at" firestore:"lastSeenAt"` // Last time a search or the crawler returned the posting
+	Keywords    []string   `json:"-" firestore:"keywords,omitempty"`    // Title words and tags, for job index lookups
+}
+
+// JobKeywords returns the keywords a posting is found by in the job index
+func JobKeywords(job JobPosting) []string {
+	return IndexKeywords(append([]string{job.Title}, job.Tags...)...)
+}
+
+// IndexKeywords returns the distinct lower-cased words of the texts ("C++"/"C#" are kept whole)
+func IndexKeywords(texts ...string) []string {
+	seen := make(map[string]bool)
+	keywords := make([]string, 0)
+	for _, text := range texts {
+		words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+		})
+		for _, w := range words {
+			if len(w) < 2 || seen[w] {
+				continue
+			}
+			seen[w] = true
+			keywords = append(keywords, w)
+		}
+	}
+	return keywords
+}
+
+// JobScore is a user's latest match score for a stored job
+type JobScore struct {
+	JobID          string          `json:"job_id" firestore:"jobId"`
+	UserID         string          `json:"-" firestore:"userId"`
+	MatchScore     int             `json:"match_score" firestore:"matchScore"`
+	MatchReason    string          `json:"match_reason" firestore:"matchReason"`
+	ScoreMethod    string          `json:"score_method,omitempty" firestore:"scoreMethod"`
+	ScoreBreakdown *ScoreBreakdown `json:"score_breakdown,omitempty" firestore:"scoreBreakdown"`
+	AIScore        int             `json:"ai_score,omitempty" firestore:"aiScore"`
+	HeuristicScore int             `json:"heuristic_score" firestore:"heuristicScore"`
+	SkillsMatch    int             `json:"skills_match,omitempty" firestore:"skillsMatch"`
+	PromptVariant  string          `json:"-" firestore:"promptVariant,omitempty"` // Prompt experiment variant that scored the job
+	ScoredAt       time.Time       `json:"scored_at" firestore:"scoredAt"`
+}
+
+// JobDetailResponse is a stored job with the authenticated user's latest match score
+// @Description Full job posting; score is included when the user has seen the job in a search
+type JobDetailResponse struct {
+	StoredJob
+	Score *JobScore `json:"score,omitempty"`
+}
+
+// SimilarJob is a stored posting ranked by its similarity to another posting
+type SimilarJob struct {
+	JobPosting
+	Similarity float64  `json:"similarity" example:"0.42"` // 0-1, from shared tags and title words
+	SharedTags []string `json:"shared_tags,omitempty" example:"golang,kubernetes"`
+}
+
+// SimilarJobsResponse lists postings similar to a job
+// @Description Jobs similar to a stored job, most similar first
+type SimilarJobsResponse struct {
+	Jobs []SimilarJob `json:"jobs"`
+}
+
+// JobQuery filters the stored jobs. Company, work type, site setting and source must match exactly;
+// location matches when it contains the given text (case-insensitive).
+type JobQuery struct {
+	Company     string
+	Location    string
+	WorkType    string
+	SiteSetting string
+	Source      string
+	Since       time.Time // Only jobs seen since this time
+	Limit       int
+}
+
+// JobListResponse lists stored jobs, most recently seen first
+// @Description Stored jobs matching the filters, most recently seen first
+type JobListResponse struct {
+	Jobs  []StoredJob `json:"jobs"`
+	Total int         `json:"total" example:"25"`
+}
+
+// Matches reports whether a stored job passes the query's filters
+func (q JobQuery) Matches(stored StoredJob) bool {
+	job := stored.Job
+	return !stored.LastSeenAt.Before(q.Since) &&
+		(q.Company == "" || job.Company == q.Company) &&
+		(q.WorkType == "" || job.WorkType == q.WorkType) &&
+		(q.SiteSetting == "" || job.SiteSetting == q.SiteSetting) &&
+		(q.Source == "" || job.Source == q.Source) &&
+		(q.Location == "" || strings.Contains(strings.ToLower(job.Location), strings.ToLower(q.Location)))
+}
@@ -0,0 +1,29 @@
+package models
+
+// JobSummaryRequest asks for a short summary of a job posting
+// @Description Posting to summarize; the page is fetched unless its text is given
+type JobSummaryRequest struct {
+	URL         string `json:"url" binding:"required,url" example:"https://www.jobstreet.co.id/job/12345678"`
+	Description string `json:"description,omitempty"` // Posting text, if the client already has it; skips fetching the page
+}
+
+// JobSummary condenses a posting into bullets for small screens
+// @Description Bullet-point summary of a job posting
+type JobSummary struct {
+	Title            string   `json:"title" firestore:"title" example:"Senior Golang Backend Engineer"`
+	Company          string   `json:"company" firestore:"company" example:"TechCorp"`
+	Overview         string   `json:"overview" firestore:"overview" example:"Build payment APIs for a fast-growing fintech."` // One sentence
+	Responsibilities []string `json:"responsibilities" firestore:"responsibilities" example:"Design and build payment APIs in Go"`
+	MustHaveSkills   []string `json:"must_have_skills" firestore:"mustHaveSkills" example:"Go,PostgreSQL"`
+	NiceToHaveSkills []string `json:"nice_to_have_skills,omitempty" firestore:"niceToHaveSkills" example:"Kubernetes"`
+	RedFlags         []string `json:"red_flags,omitempty" firestore:"redFlags" example:"Unpaid trial period"` // Warning signs such as unpaid trials or fees
+	Language         string   `json:"language,omitempty" firestore:"language" example:"en"`
+}
+
+// JobSummaryResponse is a posting's summary
+// @Description Job posting summary
+type JobSummaryResponse struct {
+	URL     string     `json:"url" example:"https://www.jobstreet.co.id/job/12345678"`
+	Summary JobSummary `json:"summary"`
+	Cached  bool       `json:"cached"` // True if the summary was served from the cache
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// LLMDebugRecord is a recorded Gemini call, with personal details redacted from the prompt and response
+// @Description A recorded Gemini prompt and raw response
+type LLMDebugRecord struct {
+	ID                string    `json:"id" firestore:"-" example:"a1B2c3D4e5F6g7H8i9J0"`
+	RequestID         string    `json:"request_id" firestore:"requestId" example:"5f2b8c0e9a1d4e7f6a3b2c1d"`
+	Model             string    `json:"model" firestore:"model" example:"gemini-2.5-flash"`
+	SystemInstruction string    `json:"system_instruction,omitempty" firestore:"systemInstruction,omitempty"`
+	Prompt            string    `json:"prompt" firestore:"prompt"`
+	Response          string    `json:"response,omitempty" firestore:"response,omitempty"` // Raw model output, before JSON cleanup
+	Error             string    `json:"error,omitempty" firestore:"error,omitempty"`
+	PromptTokens      int       `json:"prompt_tokens" firestore:"promptTokens" example:"5200"`
+	OutputTokens      int       `json:"output_tokens" firestore:"outputTokens" example:"350"`
+	DurationMs        int64     `json:"duration_ms" firestore:"durationMs" example:"2300"`
+	CreatedAt         time.Time `json:"created_at" firestore:"createdAt"`
+	ExpiresAt         time.Time `json:"-" firestore:"expiresAt"`
+}
+
+// LLMDebugResponse lists the Gemini calls recorded for a request, oldest first
+// @Description Recorded Gemini calls of a request
+type LLMDebugResponse struct {
+	RequestID string           `json:"request_id" example:"5f2b8c0e9a1d4e7f6a3b2c1d"`
+	Records   []LLMDebugRecord `json:"records"`
+}
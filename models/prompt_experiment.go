@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// PromptExperimentRecord records the outcome of one search run with a prompt experiment variant
+type PromptExperimentRecord struct {
+	Variant       string    `json:"variant" firestore:"variant"`
+	Source        string    `json:"source" firestore:"source"` // query, cv_text, cv_file, saved_profile
+	JobsExtracted int       `json:"jobs_extracted" firestore:"jobsExtracted"`
+	ExtractErrors int       `json:"extract_errors" firestore:"extractErrors"`
+	AIScores      []int     `json:"ai_scores" firestore:"aiScores"`  // Gemini scores of the returned jobs
+	Estimated     int       `json:"estimated" firestore:"estimated"` // Returned jobs scored by the heuristic fallback
+	CreatedAt     time.Time `json:"created_at" firestore:"createdAt"`
+}
+
+// PromptExperimentReport compares prompt variants over a period
+// @Description Outcome metrics of each prompt experiment variant
+type PromptExperimentReport struct {
+	From     time.Time            `json:"from"`
+	To       time.Time            `json:"to"`
+	Variants []PromptVariantStats `json:"variants"`
+}
+
+// PromptVariantStats summarizes the searches run with one prompt variant and the feedback on their results
+type PromptVariantStats struct {
+	Variant             string         `json:"variant" example:"strict"`
+	Searches            int            `json:"searches" example:"120"`
+	JobsExtracted       int            `json:"jobs_extracted" example:"1840"`
+	ExtractErrorRate    float64        `json:"extract_error_rate" example:"0.04"` // Failed extractions per extracted job
+	JobsScored          int            `json:"jobs_scored" example:"1020"`        // Returned jobs scored by Gemini
+	EstimatedRate       float64        `json:"estimated_rate" example:"0.02"`     // Share of returned jobs that fell back to heuristic scores
+	MeanScore           float64        `json:"mean_score" example:"67.4"`
+	MedianScore         int            `json:"median_score" example:"70"`
+	ScoreDistribution   map[string]int `json:"score_distribution"` // Gemini scores in 20-point buckets, e.g. "60-79"
+	FeedbackRelevant    int            `json:"feedback_relevant" example:"84"`
+	FeedbackNotRelevant int            `json:"feedback_not_relevant" example:"21"`
+	FeedbackApplied     int            `json:"feedback_already_applied" example:"9"`
+	RelevantRate        float64        `json:"relevant_rate" example:"0.8"` // relevant / (relevant + not_relevant)
+}
@@ -3,10 +3,40 @@ package models
 // SearchJobsRequest represents the API request for job search
 // @Description Job search request with CV and/or query
 type SearchJobsRequest struct {
-	CVText  string          `json:"cvText,omitempty" form:"cv_text" example:"John Doe\nSoftware Engineer with 5 years experience..."`
-	Query   string          `json:"query,omitempty" form:"query" example:"golang developer jakarta"`
-	Filters JobSearchFilter `json:"filters,omitempty" form:"filters"`
-	SaveCV  bool            `json:"saveCV,omitempty" form:"save_cv" example:"false"` // Save CV to profile if authenticated
+	CVText    string          `json:"cvText,omitempty" form:"cv_text" example:"John Doe\nSoftware Engineer with 5 years experience..."`
+	Query     string          `json:"query,omitempty" form:"query" example:"golang developer jakarta"`
+	Filters   JobSearchFilter `json:"filters,omitempty" form:"filters"`
+	SaveCV    bool            `json:"saveCV,omitempty" form:"save_cv" example:"false"`      // Save CV to profile if authenticated
+	Budget    *LLMBudget      `json:"budget,omitempty"`                                     // Optional caps on Gemini usage for this search
+	Incognito bool            `json:"incognito,omitempty" form:"incognito" example:"false"` // Strip name, email and phone before scoring (always on if enabled in account settings)
+
+	// Search depth: fewer results/pages for a quick search, more for a deep one (capped by server limits)
+	MaxResults        int `json:"max_results,omitempty" form:"max_results" binding:"omitempty,min=1" example:"20"`
+	MaxPagesToProcess int `json:"max_pages_to_process,omitempty" form:"max_pages_to_process" binding:"omitempty,min=1" example:"10"`
+
+	Model string `json:"model,omitempty" form:"model" example:"gemini-2.5-pro"` // Gemini model for this search, from GEMINI_ALLOWED_MODELS (default GEMINI_MODEL)
+
+	// Background searches only: the finished search is posted here instead of to the user's webhook
+	CallbackURL string `json:"callback_url,omitempty" form:"callback_url" binding:"omitempty,max=2048" example:"https://example.com/hooks/myjobmatch"`
+}
+
+// LLMBudget caps Gemini usage for a single request. Zero values mean no cap.
+// @Description Per-request Gemini usage caps
+type LLMBudget struct {
+	MaxCalls   int     `json:"max_calls,omitempty" example:"20"`      // Max Gemini calls
+	MaxTokens  int     `json:"max_tokens,omitempty" example:"200000"` // Max total (prompt + output) tokens
+	MaxCostUSD float64 `json:"max_cost_usd,omitempty" example:"0.05"` // Max estimated cost in USD
+}
+
+// LLMUsage reports Gemini usage for a request
+// @Description Gemini usage and budget status for a request
+type LLMUsage struct {
+	Calls            int     `json:"calls" example:"14"`
+	PromptTokens     int     `json:"prompt_tokens" example:"120000"`
+	OutputTokens     int     `json:"output_tokens" example:"3000"`
+	EstimatedCostUSD float64 `json:"estimated_cost_usd" example:"0.0435"`
+	BudgetExhausted  bool    `json:"budget_exhausted"`
+	FallbackScored   int     `json:"fallback_scored,omitempty" example:"6"` // Jobs scored deterministically after the budget ran out
 }
 
 // SearchJobsResponse represents the API response for job search
@@ -17,28 +47,37 @@ type SearchJobsResponse struct {
 	TotalResults int          `json:"total_results" example:"10"`
 	Message      string       `json:"message,omitempty" example:"Found 10 matching jobs"`
 	CVSaved      bool         `json:"cvSaved,omitempty"` // True if CV was saved to profile
+	LLMUsage     *LLMUsage    `json:"llm_usage,omitempty"`
+
+	// Prompt experiment variant the search was assigned to, if an experiment is running
+	PromptVariant string `json:"prompt_variant,omitempty" example:"strict"`
+
+	// Recorded search session, for reviewing the search later (GET /search-sessions/{id})
+	SessionID string `json:"session_id,omitempty" example:"s7Kq2ZpR4mXy9Ab1Cd3E"`
 }
 
 // ErrorResponse represents an API error response
 // @Description Standard error response
 type ErrorResponse struct {
-	Error   string `json:"error" example:"Invalid request body"`
-	Code    int    `json:"code" example:"400"`
-	Details string `json:"details,omitempty" example:"email is required"`
+	Error     string       `json:"error" example:"Invalid request body"`
+	ErrorCode string       `json:"error_code,omitempty" example:"invalid_request_body"` // Stable code of the error, for clients that translate messages themselves
+	Code      int          `json:"code" example:"400"`
+	Details   string       `json:"details,omitempty" example:"email is required"`
+	Fields    []FieldError `json:"fields,omitempty"` // Fields that failed validation, when the request was rejected for them
 }
 
-// HealthResponse represents health check response
-// @Description Server health status
-type HealthResponse struct {
-	Status    string `json:"status" example:"healthy"`
-	Version   string `json:"version" example:"1.0.0"`
-	Timestamp string `json:"timestamp" example:"2024-01-15T10:30:00Z"`
+// FieldError is a request field that failed validation
+// @Description Request field that failed validation
+type FieldError struct {
+	Field   string `json:"field" example:"filters.remote_modes[0]"` // Path of the field, with list indexes
+	Message string `json:"message" example:"must be one of WFH, WFO, Hybrid"`
 }
 
 // CVParseRequest represents request to parse CV
 // @Description CV parsing request
 type CVParseRequest struct {
 	CVText string `json:"cv_text" example:"John Doe\nSoftware Engineer\nExperience: 5 years in Go, Python..."`
+	Model  string `json:"model,omitempty" example:"gemini-2.5-pro"` // Gemini model for parsing, from GEMINI_ALLOWED_MODELS (default GEMINI_MODEL)
 }
 
 // CVParseResponse represents response from CV parsing
@@ -48,6 +87,29 @@ type CVParseResponse struct {
 	Raw     string      `json:"raw,omitempty"` // Raw Gemini response for debugging
 }
 
+// TailorCVRequest represents a request to tailor a CV to a job posting
+// @Description CV tailoring request. Uses the saved profile when authenticated and cv_text is omitted.
+type TailorCVRequest struct {
+	CVText string     `json:"cv_text,omitempty" example:"John Doe\nSoftware Engineer\nExperience: 5 years in Go, Python..."`
+	Job    JobPosting `json:"job"`
+}
+
+// TailoredCV is a CV summary and skills section rewritten for a specific job
+// @Description CV sections optimized for a job posting
+type TailoredCV struct {
+	Summary       string   `json:"summary" example:"Backend engineer with 5 years building high-throughput payment APIs in Go..."`
+	Skills        []string `json:"skills" example:"Go,PostgreSQL,Kafka,Docker"`                                        // Candidate's skills, most relevant to the job first
+	Highlights    []string `json:"highlights,omitempty" example:"Led migration of payment ledger to Go microservices"` // Experience worth emphasizing for this job
+	MissingSkills []string `json:"missing_skills,omitempty" example:"Kubernetes"`                                      // Job requirements not found in the CV
+}
+
+// TailorCVResponse represents the response from CV tailoring
+// @Description Tailored CV sections
+type TailorCVResponse struct {
+	Tailored TailoredCV   `json:"tailored"`
+	Profile  *UserProfile `json:"profile,omitempty"`
+}
+
 // WebSearchRequest represents request for web search tool
 type WebSearchRequest struct {
 	Query   string          `json:"query"`
@@ -68,9 +130,11 @@ type FetchPageRequest struct {
 
 // FetchPageResponse represents response from page fetch
 type FetchPageResponse struct {
-	HTML  string `json:"html"`
-	URL   string `json:"url"`
-	Error string `json:"error,omitempty"`
+	HTML           string   `json:"html"`
+	URL            string   `json:"url"`
+	StructuredData []string `json:"structured_data,omitempty"` // JSON-LD blocks, read before scripts are stripped from HTML
+	StatusCode     int      `json:"status_code,omitempty"`     // Final HTTP status (0 if no response was received)
+	Error          string   `json:"error,omitempty"`
 }
 
 // ExtractJobRequest represents request to extract job from HTML
@@ -81,8 +145,9 @@ type ExtractJobRequest struct {
 
 // ExtractJobResponse represents response from job extraction
 type ExtractJobResponse struct {
-	Job   *JobPosting `json:"job,omitempty"`
-	Error string      `json:"error,omitempty"`
+	Job   *JobPosting  `json:"job,omitempty"`  // First posting found
+	Jobs  []JobPosting `json:"jobs,omitempty"` // Every posting found; several for listing pages
+	Error string       `json:"error,omitempty"`
 }
 
 // ScoreJobRequest represents request to score a job match
@@ -93,6 +158,7 @@ type ScoreJobRequest struct {
 
 // ScoreJobResponse represents response from job scoring
 type ScoreJobResponse struct {
-	MatchScore  int    `json:"match_score"`
-	MatchReason string `json:"match_reason"`
+	MatchScore  int             `json:"match_score"`
+	MatchReason string          `json:"match_reason"`
+	Breakdown   *ScoreBreakdown `json:"breakdown,omitempty"`
 }
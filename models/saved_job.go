@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// SavedJob is a job posting a user bookmarked from search results
+// @Description Bookmarked job with its match score against the user's profile
+type SavedJob struct {
+	ID       string    `json:"id" firestore:"-" example:"p7Qw2LmZ"`
+	UserID   string    `json:"-" firestore:"userId"`
+	Job      RankedJob `json:"job" firestore:"job"`
+	SavedAt  time.Time `json:"savedAt" firestore:"savedAt"`
+	ScoredAt time.Time `json:"scoredAt" firestore:"scoredAt"` // When MatchScore was last computed
+}
+
+// SaveJobRequest represents a request to bookmark a job from search results
+// @Description Job to bookmark, as returned by a search
+type SaveJobRequest struct {
+	Job RankedJob `json:"job"`
+}
+
+// SavedJobsResponse lists saved jobs
+// @Description Saved jobs
+type SavedJobsResponse struct {
+	Jobs    []SavedJob `json:"jobs"`
+	Message string     `json:"message,omitempty" example:"Job saved"`
+}
+
+// RescoreSavedJobsResponse reports the result of re-scoring saved jobs
+// @Description Saved jobs re-scored against the current profile
+type RescoreSavedJobsResponse struct {
+	Jobs      []SavedJob `json:"jobs"`
+	Rescored  int        `json:"rescored" example:"12"`
+	Failed    int        `json:"failed" example:"0"`    // Jobs whose previous score was kept because scoring failed
+	Remaining int        `json:"remaining" example:"0"` // Jobs left for the next request, past the per-request cap
+	Message   string     `json:"message,omitempty" example:"Re-scored 12 saved jobs"`
+}
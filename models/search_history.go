@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Search input sources recorded in history
+const (
+	SearchSourceQuery        = "query"
+	SearchSourceCVText       = "cv_text"
+	SearchSourceCVFile       = "cv_file"
+	SearchSourceSavedProfile = "saved_profile"
+)
+
+// SearchHistoryEntry records an authenticated search so it can be reviewed and rerun
+// @Description A past job search
+type SearchHistoryEntry struct {
+	ID            string          `json:"id" firestore:"-" example:"a1B2c3D4e5F6g7H8i9J0"`
+	UserID        string          `json:"-" firestore:"userId"`
+	TenantID      string          `json:"-" firestore:"tenantId,omitempty"`
+	Query         string          `json:"query,omitempty" firestore:"query,omitempty" example:"golang developer jakarta"`
+	Filters       JobSearchFilter `json:"filters" firestore:"filters"`
+	Source        string          `json:"source" firestore:"source" example:"saved_profile"` // query, cv_text, cv_file, saved_profile
+	Incognito     bool            `json:"incognito,omitempty" firestore:"incognito,omitempty"`
+	URLsFound     int             `json:"urls_found" firestore:"urlsFound" example:"25"`
+	JobsExtracted int             `json:"jobs_extracted" firestore:"jobsExtracted" example:"18"`
+	JobsScored    int             `json:"jobs_scored" firestore:"jobsScored" example:"18"`
+	CacheHits     int             `json:"cache_hits" firestore:"cacheHits" example:"6"` // Postings reused from the job cache
+	ResultCount   int             `json:"result_count" firestore:"resultCount" example:"10"`
+	LLMCalls      int             `json:"llm_calls" firestore:"llmCalls" example:"29"`
+	PromptVariant string          `json:"prompt_variant,omitempty" firestore:"promptVariant,omitempty" example:"strict"`       // Prompt experiment variant, if any
+	SessionID     string          `json:"session_id,omitempty" firestore:"sessionId,omitempty" example:"s7Kq2ZpR4mXy9Ab1Cd3E"` // Recorded search session, while it is kept
+	CreatedAt     time.Time       `json:"created_at" firestore:"createdAt"`
+}
+
+// SearchHistoryResponse lists past searches, newest first
+// @Description Search history
+type SearchHistoryResponse struct {
+	Searches []SearchHistoryEntry `json:"searches"`
+	Message  string               `json:"message,omitempty"`
+}
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// SearchStats provides statistics about the search
+type SearchStats struct {
+	QueriesSearched  int `json:"queries_searched" firestore:"queriesSearched"`
+	URLsFound        int `json:"urls_found" firestore:"urlsFound"`
+	PagesFetched     int `json:"pages_fetched" firestore:"pagesFetched"`
+	JobsExtracted    int `json:"jobs_extracted" firestore:"jobsExtracted"`
+	StructuredJobs   int `json:"structured_jobs" firestore:"structuredJobs"` // Extracted from JSON-LD or by a site adapter, without Gemini
+	JobsScored       int `json:"jobs_scored" firestore:"jobsScored"`
+	JobsReturned     int `json:"jobs_returned" firestore:"jobsReturned"`
+	FetchErrors      int `json:"fetch_errors" firestore:"fetchErrors"`
+	ExtractErrors    int `json:"extract_errors" firestore:"extractErrors"`
+	ATSJobsFound     int `json:"ats_jobs_found" firestore:"atsJobsFound"`
+	RemoteJobsFound  int `json:"remote_jobs_found" firestore:"remoteJobsFound"`
+	IndexedJobs      int `json:"indexed_jobs" firestore:"indexedJobs"` // Read from the local job index before live search
+	CacheHits        int `json:"cache_hits" firestore:"cacheHits"`
+	CacheMisses      int `json:"cache_misses" firestore:"cacheMisses"`
+	DuplicatesMerged int `json:"duplicates_merged" firestore:"duplicatesMerged"`
+	FilteredOut      int `json:"filtered_out" firestore:"filteredOut"`
+	ExpiredJobs      int `json:"expired_jobs" firestore:"expiredJobs"` // Closed, past their deadline or older than JOB_MAX_AGE_DAYS
+	HiddenByFeedback int `json:"hidden_by_feedback" firestore:"hiddenByFeedback"`
+	JobsEnriched     int `json:"jobs_enriched" firestore:"jobsEnriched"`
+}
+
+// SearchSessionResult is how one returned job was scored in a search session
+type SearchSessionResult struct {
+	JobID       string `json:"job_id" firestore:"jobId" example:"3f9a1c2b7d4e8f60"`
+	MatchScore  int    `json:"match_score" firestore:"matchScore" example:"85"`
+	MatchReason string `json:"match_reason,omitempty" firestore:"matchReason,omitempty" example:"Strong Go and Kubernetes overlap"`
+	ScoreMethod string `json:"score_method,omitempty" firestore:"scoreMethod,omitempty" example:"ai"`
+}
+
+// SearchSession records what went into a search and what came out of it: the input, the profile it
+// resolved to, the queries run, the pipeline stats and the returned jobs in rank order
+// @Description A recorded job search, for reviewing why it returned its results
+type SearchSession struct {
+	ID             string                `json:"id" firestore:"-" example:"s7Kq2ZpR4mXy9Ab1Cd3E"`
+	UserID         string                `json:"-" firestore:"userId,omitempty"` // Empty for anonymous searches
+	Query          string                `json:"query,omitempty" firestore:"query,omitempty" example:"golang developer jakarta"`
+	EffectiveQuery string                `json:"effective_query" firestore:"effectiveQuery" example:"golang developer jakarta"` // Query used when none was given
+	Queries        []string              `json:"queries,omitempty" firestore:"queries,omitempty"`                               // Web search variants planned from it
+	Filters        JobSearchFilter       `json:"filters" firestore:"filters"`
+	Incognito      bool                  `json:"incognito,omitempty" firestore:"incognito,omitempty"`
+	Model          string                `json:"model,omitempty" firestore:"model,omitempty" example:"gemini-2.5-flash"`
+	Profile        *UserProfile          `json:"profile,omitempty" firestore:"profile,omitempty"` // Redacted for incognito searches
+	Stats          SearchStats           `json:"stats" firestore:"stats"`
+	Usage          LLMUsage              `json:"usage" firestore:"usage"`
+	PromptVariant  string                `json:"prompt_variant,omitempty" firestore:"promptVariant,omitempty"`
+	Results        []SearchSessionResult `json:"results" firestore:"results"`
+	DurationMs     int64                 `json:"duration_ms" firestore:"durationMs" example:"18250"`
+	CreatedAt      time.Time             `json:"created_at" firestore:"createdAt"`
+	ExpiresAt      time.Time             `json:"-" firestore:"expiresAt"` // Firestore TTL policy field
+}
+
+// SearchSessionResultsResponse is a page of a search session's results
+// @Description Returned jobs of a recorded search in rank order, with the scores they had in that search
+type SearchSessionResultsResponse struct {
+	SessionID string      `json:"session_id" example:"s7Kq2ZpR4mXy9Ab1Cd3E"`
+	Results   []RankedJob `json:"results"`
+	Total     int         `json:"total" example:"25"`
+	Offset    int         `json:"offset" example:"0"`
+	Limit     int         `json:"limit" example:"10"`
+}
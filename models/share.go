@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// SharedSearch is a read-only snapshot of ranked search results published under a random share token
+// @Description Shared snapshot of search results
+type SharedSearch struct {
+	Token     string       `json:"token" firestore:"-" example:"q3xN0aB7cD9eF1gH2iJkLmN4oP5qR6sT"`
+	UserID    string       `json:"-" firestore:"userId"`
+	Title     string       `json:"title,omitempty" firestore:"title,omitempty" example:"Golang roles in Jakarta"`
+	Query     string       `json:"query,omitempty" firestore:"query,omitempty" example:"golang developer jakarta"`
+	Results   []RankedJob  `json:"results" firestore:"results"`
+	Profile   *UserProfile `json:"profile,omitempty" firestore:"profile,omitempty"` // Redacted; only included when requested
+	CreatedAt time.Time    `json:"createdAt" firestore:"createdAt"`
+	ExpiresAt time.Time    `json:"expiresAt" firestore:"expiresAt"`
+}
+
+// IsExpired reports whether the share link has expired
+func (s *SharedSearch) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
+
+// CreateSharedSearchRequest publishes search results by job ID
+// @Description Results to share, by the "id" of jobs returned by a search
+type CreateSharedSearchRequest struct {
+	JobIDs         []string `json:"jobIds" binding:"required,min=1,max=50" example:"4f2a9c0e7b1d3a5f8e6c2b9d0a1f3e7c"`
+	Title          string   `json:"title,omitempty" binding:"max=200" example:"Golang roles in Jakarta"`
+	Query          string   `json:"query,omitempty" binding:"max=500" example:"golang developer jakarta"`
+	IncludeProfile bool     `json:"includeProfile,omitempty"`                                               // Include skills and experience, without name, contact details or salary expectations
+	ExpiresInDays  int      `json:"expiresInDays,omitempty" binding:"omitempty,min=1,max=365" example:"30"` // Defaults to 30 days
+}
+
+// SharedSearchResponse represents a published share link
+// @Description Shared search snapshot and its public URL
+type SharedSearchResponse struct {
+	Share   *SharedSearch `json:"share"`
+	URL     string        `json:"url" example:"/api/shared/q3xN0aB7cD9eF1gH2iJkLmN4oP5qR6sT"`
+	Message string        `json:"message,omitempty" example:"Share link created"`
+}
+
+// SharedSearchListResponse lists a user's share links
+// @Description Share links published by the user
+type SharedSearchListResponse struct {
+	Shares  []SharedSearch `json:"shares"`
+	Message string         `json:"message,omitempty"`
+}
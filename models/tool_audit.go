@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Tool call sources recorded in the audit log
+const (
+	ToolAuditSourceMCP   = "mcp"   // Called by an external agent through the MCP endpoints
+	ToolAuditSourceAgent = "agent" // Called by Gemini during agent search or the career assistant
+)
+
+// ToolAuditRecord is a recorded tool call, kept for abuse investigation and cost attribution
+// @Description A recorded MCP or agent tool call
+type ToolAuditRecord struct {
+	ID          string    `json:"id" firestore:"-" example:"a1B2c3D4e5F6g7H8i9J0"`
+	Tool        string    `json:"tool" firestore:"tool" example:"search_web_for_jobs"`
+	Source      string    `json:"source" firestore:"source" example:"mcp"`
+	CallerEmail string    `json:"caller_email,omitempty" firestore:"callerEmail,omitempty" example:"user@example.com"` // Empty for anonymous calls
+	APITokenID  string    `json:"api_token_id,omitempty" firestore:"apiTokenId,omitempty" example:"3f2a9c1e"`
+	ClientIP    string    `json:"client_ip,omitempty" firestore:"clientIp,omitempty" example:"203.0.113.7"`
+	RequestID   string    `json:"request_id,omitempty" firestore:"requestId,omitempty" example:"5f2b8c0e9a1d4e7f6a3b2c1d"`
+	Arguments   string    `json:"arguments,omitempty" firestore:"arguments,omitempty"` // Redacted and truncated
+	Success     bool      `json:"success" firestore:"success"`
+	ErrorCode   string    `json:"error_code,omitempty" firestore:"errorCode,omitempty" example:"capacity_exceeded"`
+	Error       string    `json:"error,omitempty" firestore:"error,omitempty"`
+	DurationMs  int64     `json:"duration_ms" firestore:"durationMs" example:"2300"`
+	CreatedAt   time.Time `json:"created_at" firestore:"createdAt"`
+	ExpiresAt   time.Time `json:"-" firestore:"expiresAt"`
+}
+
+// ToolAuditQuery filters the tool audit log
+type ToolAuditQuery struct {
+	Tool        string
+	CallerEmail string
+	APITokenID  string
+	Since       time.Time
+	Limit       int
+}
+
+// ToolAuditResponse lists recorded tool calls, newest first
+// @Description Recorded tool calls with totals per tool
+type ToolAuditResponse struct {
+	Records []ToolAuditRecord `json:"records"`
+	Totals  []ToolAuditTotal  `json:"totals"` // Over the returned records
+}
+
+// ToolAuditTotal sums the returned calls of one tool
+type ToolAuditTotal struct {
+	Tool            string `json:"tool" example:"search_jobs"`
+	Calls           int    `json:"calls" example:"42"`
+	Failures        int    `json:"failures" example:"3"`
+	TotalDurationMs int64  `json:"total_duration_ms" example:"950000"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// ToolSetting turns a tool on or off at runtime, overriding DISABLED_TOOLS
+// @Description Runtime on/off switch for a tool
+type ToolSetting struct {
+	Name      string    `json:"name" firestore:"-" example:"fetch_page_html"`
+	Enabled   bool      `json:"enabled" firestore:"enabled" example:"false"`
+	Reason    string    `json:"reason,omitempty" firestore:"reason,omitempty" example:"Job board asked us to stop scraping"`
+	UpdatedBy string    `json:"updatedBy,omitempty" firestore:"updatedBy,omitempty" example:"admin@example.com"`
+	UpdatedAt time.Time `json:"updatedAt" firestore:"updatedAt"`
+}
+
+// UpdateToolSettingRequest turns a tool on or off
+// @Description Tool switch update
+type UpdateToolSettingRequest struct {
+	Enabled *bool  `json:"enabled" binding:"required" example:"false"`
+	Reason  string `json:"reason,omitempty" binding:"max=500" example:"Job board asked us to stop scraping"`
+}
+
+// ToolStatus describes a registered tool and whether it is enabled
+// @Description Registered tool with its on/off state
+type ToolStatus struct {
+	Name    string `json:"name" example:"fetch_page_html"`
+	Version string `json:"version" example:"1.0.0"`
+	Enabled bool   `json:"enabled" example:"true"`
+	Reason  string `json:"reason,omitempty" example:"Job board asked us to stop scraping"` // Why the tool is disabled
+}
+
+// ToolStatusResponse lists the registered tools with their on/off state
+// @Description Registered tools
+type ToolStatusResponse struct {
+	Tools []ToolStatus `json:"tools"`
+}
@@ -1,5 +1,10 @@
 package models
 
+import (
+	"strings"
+	"time"
+)
+
 // UserProfile represents the extracted profile from CV or query
 type UserProfile struct {
 	// Personal Information
@@ -35,6 +40,11 @@ type UserProfile struct {
 	// Additional
 	Certifications []string `json:"certifications,omitempty"`
 	Achievements   []string `json:"achievements,omitempty"`
+
+	// Language of the CV (ISO 639-1, e.g. "en", "id"). Translated is true when
+	// title, skills and roles were translated to English for searching.
+	Language   string `json:"language,omitempty"`
+	Translated bool   `json:"translated,omitempty"`
 }
 
 // Education represents educational background
@@ -61,10 +71,39 @@ type JobSearchFilter struct {
 	Locations   []string `json:"locations,omitempty"`
 	RemoteModes []string `json:"remote_modes,omitempty"` // WFH, WFO, Hybrid
 	JobTypes    []string `json:"job_types,omitempty"`    // full_time, part_time, contract, internship
-	MinSalary   int      `json:"min_salary,omitempty"`
-	MaxSalary   int      `json:"max_salary,omitempty"`
+	MinSalary   int      `json:"min_salary,omitempty"`   // Monthly amount; postings paid per hour, day, week or year are converted
+	MaxSalary   int      `json:"max_salary,omitempty"`   // Monthly amount
 	Currency    string   `json:"currency,omitempty"`
-	DatePosted  string   `json:"date_posted,omitempty"` // last_24h, last_week, last_month
+	DatePosted  string   `json:"date_posted,omitempty"`                                 // last_24h, last_week, last_month
+	MinScore    *int     `json:"min_score,omitempty" binding:"omitempty,min=0,max=100"` // Minimum match score to return (default 50; 0 returns everything)
+
+	// Postings from these companies are never scored or returned (matched ignoring case and "PT"/"Tbk"/"Inc")
+	ExcludeCompanies []string `json:"exclude_companies,omitempty"`
+
+	// Postings mentioning any of these words in the title, company, description or requirements are dropped;
+	// they are also sent to web search as negative terms ("-gambling")
+	ExcludeKeywords []string `json:"exclude_keywords,omitempty"`
+}
+
+// WithExcludedCompanies returns a copy of the filter that also excludes the given companies
+func (f JobSearchFilter) WithExcludedCompanies(companies []string) JobSearchFilter {
+	if len(companies) == 0 {
+		return f
+	}
+
+	excluded := make([]string, 0, len(f.ExcludeCompanies)+len(companies))
+	seen := make(map[string]bool)
+	for _, c := range append(append([]string{}, f.ExcludeCompanies...), companies...) {
+		key := strings.ToLower(strings.TrimSpace(c))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		excluded = append(excluded, strings.TrimSpace(c))
+	}
+
+	f.ExcludeCompanies = excluded
+	return f
 }
 
 // SearchJobsInput is the unified input for the job search agent
@@ -147,3 +186,81 @@ func (p *UserProfile) GenerateSearchQuery() string {
 
 	return query
 }
+
+// StructuredProfile is the saved structured profile of an authenticated user.
+// It starts as the AI-extracted profile and can be corrected by the user.
+// @Description Saved structured profile
+type StructuredProfile struct {
+	Profile   UserProfile `json:"profile" firestore:"profile"`
+	Edited    bool        `json:"edited" firestore:"edited"` // True once the user has corrected any field
+	UpdatedAt time.Time   `json:"updatedAt" firestore:"updatedAt"`
+}
+
+// UpdateStructuredProfileRequest is a partial update of the structured profile.
+// Only non-null fields are applied; list fields replace the stored list.
+// @Description Partial structured profile update
+type UpdateStructuredProfileRequest struct {
+	Title                *string           `json:"title,omitempty" binding:"omitempty,max=200" example:"Backend Engineer"`
+	Summary              *string           `json:"summary,omitempty" binding:"omitempty,max=2000"`
+	Experience           *float64          `json:"experience_years,omitempty" binding:"omitempty,min=0,max=60" example:"5"`
+	Skills               *[]string         `json:"skills,omitempty" binding:"omitempty,max=100,dive,min=1,max=100"`
+	TechnicalStack       *[]string         `json:"technical_stack,omitempty" binding:"omitempty,max=100,dive,min=1,max=100"`
+	WorkHistory          *[]WorkExperience `json:"work_history,omitempty" binding:"omitempty,max=50"`
+	PreferredRoles       *[]string         `json:"preferred_roles,omitempty" binding:"omitempty,max=20,dive,min=1,max=100"`
+	PreferredLocations   *[]string         `json:"preferred_locations,omitempty" binding:"omitempty,max=20,dive,min=1,max=100"`
+	PreferredRemoteModes *[]string         `json:"preferred_remote_modes,omitempty" binding:"omitempty,dive,oneof=WFH WFO Hybrid"`
+	PreferredJobTypes    *[]string         `json:"preferred_job_types,omitempty" binding:"omitempty,dive,oneof=full_time part_time contract internship freelance"`
+	MinSalary            *int              `json:"min_salary,omitempty" binding:"omitempty,min=0"`
+	MaxSalary            *int              `json:"max_salary,omitempty" binding:"omitempty,min=0"`
+	Currency             *string           `json:"currency,omitempty" binding:"omitempty,len=3" example:"IDR"`
+}
+
+// StructuredProfileResponse represents the structured profile response
+// @Description Structured profile response
+type StructuredProfileResponse struct {
+	StructuredProfile
+	Message string `json:"message,omitempty" example:"Profile updated successfully"`
+}
+
+// Apply applies the non-null fields of a partial update to the profile
+func (r *UpdateStructuredProfileRequest) Apply(p *UserProfile) {
+	if r.Title != nil {
+		p.Title = *r.Title
+	}
+	if r.Summary != nil {
+		p.Summary = *r.Summary
+	}
+	if r.Experience != nil {
+		p.Experience = *r.Experience
+	}
+	if r.Skills != nil {
+		p.Skills = *r.Skills
+	}
+	if r.TechnicalStack != nil {
+		p.TechnicalStack = *r.TechnicalStack
+	}
+	if r.WorkHistory != nil {
+		p.WorkHistory = *r.WorkHistory
+	}
+	if r.PreferredRoles != nil {
+		p.PreferredRoles = *r.PreferredRoles
+	}
+	if r.PreferredLocations != nil {
+		p.PreferredLocations = *r.PreferredLocations
+	}
+	if r.PreferredRemoteModes != nil {
+		p.PreferredRemoteModes = *r.PreferredRemoteModes
+	}
+	if r.PreferredJobTypes != nil {
+		p.PreferredJobTypes = *r.PreferredJobTypes
+	}
+	if r.MinSalary != nil {
+		p.MinSalary = *r.MinSalary
+	}
+	if r.MaxSalary != nil {
+		p.MaxSalary = *r.MaxSalary
+	}
+	if r.Currency != nil {
+		p.Currency = *r.Currency
+	}
+}
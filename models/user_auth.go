@@ -5,15 +5,25 @@ import "time"
 // User represents a user in Firestore
 // @Description User account information
 type User struct {
-	ID        string    `json:"id" firestore:"-" example:"user@example.com"`
-	Email     string    `json:"email" firestore:"email" example:"user@example.com"`
-	Nama      string    `json:"nama" firestore:"nama" example:"John Doe"`
-	Password  string    `json:"-" firestore:"password"` // Hashed password, never sent to client
-	CVUrl     string    `json:"cvUrl" firestore:"cvUrl" example:"gs://bucket/cvs/user@example.com/resume.pdf"`
-	Provider  string    `json:"provider" firestore:"provider" example:"email"` // "email" or "google"
-	GoogleID  string    `json:"-" firestore:"googleId,omitempty"`
-	CreatedAt time.Time `json:"createdAt" firestore:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt" firestore:"updatedAt"`
+	ID               string     `json:"id" firestore:"-" example:"7b0e3c5a-2f4d-4c1e-9a8b-5d6f7e8a9b0c"`
+	TenantID         string     `json:"tenantId,omitempty" firestore:"tenantId,omitempty" example:"acme"` // Empty for the default tenant
+	Email            string     `json:"email" firestore:"email" example:"user@example.com"`
+	Nama             string     `json:"nama" firestore:"nama" example:"John Doe"`
+	Password         string     `json:"-" firestore:"password"` // Hashed password, never sent to client
+	CVUrl            string     `json:"cvUrl" firestore:"cvUrl" example:"gs://bucket/cvs/user@example.com/resume.pdf"`
+	PhotoURL         string     `json:"photoUrl,omitempty" firestore:"photoUrl,omitempty" example:"https://storage.googleapis.com/bucket/photos/6f1c2a9e-3b7d-4e5f-8a10-2c4d6e8f0a1b/1700000000.jpg"`
+	Provider         string     `json:"provider" firestore:"provider" example:"email"` // "email" or "google"
+	GoogleID         string     `json:"-" firestore:"googleId,omitempty"`
+	Incognito        bool       `json:"incognito" firestore:"incognito"`                                   // Strip name, email and phone from profiles sent to the model
+	BlockedCompanies []string   `json:"blockedCompanies,omitempty" firestore:"blockedCompanies,omitempty"` // Never shown in search results or alerts
+	WebhookURL       string     `json:"webhookUrl,omitempty" firestore:"webhookUrl,omitempty"`             // Receives the user's finished background searches
+	WebhookSecret    string     `json:"-" firestore:"webhookSecret,omitempty"`                             // Signs webhook deliveries
+	Suspended        bool       `json:"suspended,omitempty" firestore:"suspended,omitempty"`               // Suspended users can't sign in or use their sessions and tokens
+	SuspendedAt      *time.Time `json:"suspendedAt,omitempty" firestore:"suspendedAt,omitempty"`
+	SuspendedReason  string     `json:"suspendedReason,omitempty" firestore:"suspendedReason,omitempty"`
+	EmailChangedAt   *time.Time `json:"-" firestore:"emailChangedAt,omitempty"` // Sessions issued before it are revoked
+	CreatedAt        time.Time  `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt" firestore:"updatedAt"`
 }
 
 // RegisterRequest represents registration request
@@ -40,7 +50,8 @@ type GoogleAuthRequest struct {
 // UpdateProfileRequest represents profile update request
 // @Description Profile update request
 type UpdateProfileRequest struct {
-	Nama string `json:"nama,omitempty" example:"John Smith"`
+	Nama      string `json:"nama,omitempty" example:"John Smith"`
+	Incognito *bool  `json:"incognito,omitempty" example:"true"` // Search without sending name, email and phone to the model
 }
 
 // AuthResponse represents authentication response
@@ -65,6 +76,69 @@ type CVUploadResponse struct {
 	Message string `json:"message" example:"CV uploaded successfully"`
 }
 
+// CVUploadURLRequest asks for a URL to upload a CV to directly
+// @Description Name of the CV file to upload directly to storage
+type CVUploadURLRequest struct {
+	Filename string `json:"filename" binding:"required" example:"resume.pdf"` // .pdf, .doc, .docx or .txt
+}
+
+// CVUploadURLResponse is a signed URL the browser uploads a CV to directly
+// @Description Start a resumable upload by POSTing to uploadUrl with the headers and an empty body, upload the file to the session URL returned in the Location header, then confirm with cvUrl
+type CVUploadURLResponse struct {
+	UploadURL string            `json:"uploadUrl" example:"https://storage.googleapis.com/bucket/cvs/6f1c2a9e-3b7d-4e5f-8a10-2c4d6e8f0a1b/1700000000.pdf?X-Goog-Signature=..."`
+	Headers   map[string]string `json:"headers"`
+	CVUrl     string            `json:"cvUrl" example:"https://storage.googleapis.com/bucket/cvs/6f1c2a9e-3b7d-4e5f-8a10-2c4d6e8f0a1b/1700000000.pdf"`
+	MaxBytes  int64             `json:"maxBytes" example:"5242880"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+}
+
+// CVUploadConfirmRequest confirms a finished direct CV upload
+// @Description The cvUrl returned with the upload URL
+type CVUploadConfirmRequest struct {
+	CVUrl string `json:"cvUrl" binding:"required" example:"https://storage.googleapis.com/bucket/cvs/6f1c2a9e-3b7d-4e5f-8a10-2c4d6e8f0a1b/1700000000.pdf"`
+}
+
+// ChangeEmailRequest starts changing the account's email
+// @Description New email address; accounts with a password must confirm it
+type ChangeEmailRequest struct {
+	NewEmail string `json:"newEmail" binding:"required,email" example:"new@example.com"`
+	Password string `json:"password,omitempty" example:"password123"`
+}
+
+// ChangeEmailResponse reports that a verification email was sent
+// @Description Verification email sent to the new address
+type ChangeEmailResponse struct {
+	Message   string    `json:"message" example:"Verification email sent"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// ConfirmEmailChangeRequest completes an email change
+// @Description Token from the verification email
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" binding:"required" example:"Zk3p9Q..."`
+}
+
+// UserIDMigrationResponse reports how many users were moved to user IDs
+// @Description Number of users moved from email-keyed documents to user IDs
+type UserIDMigrationResponse struct {
+	Migrated int `json:"migrated" example:"42"`
+}
+
+// EmailChange is a pending email change, stored under the hash of its verification token
+type EmailChange struct {
+	UserID    string    `firestore:"userId"`
+	NewEmail  string    `firestore:"newEmail"`
+	ExpiresAt time.Time `firestore:"expiresAt"`
+	CreatedAt time.Time `firestore:"createdAt"`
+}
+
+// PhotoUploadResponse represents profile photo upload response
+// @Description Profile photo upload response
+type PhotoUploadResponse struct {
+	PhotoURL string `json:"photoUrl" example:"https://storage.googleapis.com/bucket/photos/6f1c2a9e-3b7d-4e5f-8a10-2c4d6e8f0a1b/1700000000.jpg"`
+	Message  string `json:"message" example:"Photo uploaded successfully"`
+}
+
 // TokenClaims represents JWT token claims
 type TokenClaims struct {
 	UserID string `json:"userId"`
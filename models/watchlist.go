@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// WatchedCompany represents a company a user follows for new job postings
+// @Description Company on the user's watchlist
+type WatchedCompany struct {
+	ID            string    `json:"id" firestore:"-" example:"c8f1a2b3"`
+	UserID        string    `json:"-" firestore:"userId"`
+	CompanyName   string    `json:"companyName" firestore:"companyName" example:"Xendit"`
+	CareersURL    string    `json:"careersUrl,omitempty" firestore:"careersUrl,omitempty" example:"https://www.xendit.co/en/careers/"`
+	ATSProvider   string    `json:"atsProvider,omitempty" firestore:"atsProvider,omitempty" example:"lever"` // greenhouse, lever, workable
+	ATSToken      string    `json:"atsToken,omitempty" firestore:"atsToken,omitempty" example:"xendit"`
+	MinScore      int       `json:"minScore" firestore:"minScore" example:"70"`
+	SeenURLs      []string  `json:"-" firestore:"seenUrls"`
+	LastCheckedAt time.Time `json:"lastCheckedAt,omitempty" firestore:"lastCheckedAt"`
+	CreatedAt     time.Time `json:"createdAt" firestore:"createdAt"`
+}
+
+// WatchCompanyRequest represents a request to follow a company
+// @Description Follow a company by careers page or ATS board
+type WatchCompanyRequest struct {
+	CompanyName string `json:"companyName" binding:"required" example:"Xendit"`
+	CareersURL  string `json:"careersUrl,omitempty" binding:"omitempty,url" example:"https://www.xendit.co/en/careers/"`
+	ATSProvider string `json:"atsProvider,omitempty" binding:"omitempty,oneof=greenhouse lever workable" example:"lever"`
+	ATSToken    string `json:"atsToken,omitempty" example:"xendit"`
+	MinScore    *int   `json:"minScore,omitempty" binding:"omitempty,min=0,max=100" example:"70"` // WATCHLIST_MIN_SCORE when omitted; 0 alerts on every posting
+}
+
+// WatchlistResponse represents the user's watchlist
+// @Description Followed companies
+type WatchlistResponse struct {
+	Companies []WatchedCompany `json:"companies"`
+	Message   string           `json:"message,omitempty" example:"Company added to watchlist"`
+}
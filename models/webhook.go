@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Webhook event types
+const (
+	WebhookEventSearchCompleted = "search.completed"
+	WebhookEventSearchFailed    = "search.failed"
+)
+
+// WebhookEvent is the body of a webhook delivery
+// @Description Event posted to a webhook when a background search finishes
+type WebhookEvent struct {
+	ID        string       `json:"id" example:"3f1c2b7a-9d4e-4a6b-8c5d-2e1f0a9b8c7d"` // Same for every attempt of a delivery
+	Type      string       `json:"type" example:"search.completed"`                   // search.completed or search.failed
+	CreatedAt time.Time    `json:"created_at"`
+	Search    *AsyncSearch `json:"search"` // The finished search, with its ranked results when completed
+}
+
+// WebhookRequest sets the URL the user's background searches are reported to
+// @Description Webhook URL to set
+type WebhookRequest struct {
+	URL string `json:"url" binding:"required,max=2048" example:"https://hooks.zapier.com/hooks/catch/123456/abcdef/"`
+}
+
+// WebhookResponse describes the user's webhook
+// @Description The user's webhook
+type WebhookResponse struct {
+	URL     string `json:"url" example:"https://hooks.zapier.com/hooks/catch/123456/abcdef/"`
+	Secret  string `json:"secret,omitempty" example:"whsec_q1w2e3r4t5y6u7i8o9p0a1s2d3f4g5h6j7k8l9z0x1c"` // Signing secret, only returned when the webhook is set
+	Message string `json:"message,omitempty" example:"Webhook set"`
+}
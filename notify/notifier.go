@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"strings"
+
+	"github.com/myjobmatch/backend/config"
+)
+
+// Notifier delivers notifications to users
+type Notifier interface {
+	// Notify sends a message with the given subject and plain-text body to a recipient
+	Notify(ctx context.Context, to, subject, body string) error
+}
+
+// NewNotifier creates a notifier based on configuration.
+// Uses SMTP email when SMTP_HOST is configured, otherwise logs notifications.
+func NewNotifier(cfg *config.Config) Notifier {
+	if cfg.SMTPHost == "" {
+		log.Println("[Notify] SMTP not configured, notifications will only be logged")
+		return &LogNotifier{}
+	}
+
+	return &EmailNotifier{
+		host:     cfg.SMTPHost,
+		port:     cfg.SMTPPort,
+		username: cfg.SMTPUsername,
+		password: cfg.SMTPPassword,
+		from:     cfg.NotificationFrom,
+	}
+}
+
+// EmailNotifier sends notifications as plain-text email over SMTP
+type EmailNotifier struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+// Notify sends an email notification
+func (n *EmailNotifier) Notify(ctx context.Context, to, subject, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if n.username != "" {
+		auth = smtp.PlainAuth("", n.username, n.password, n.host)
+	}
+
+	msg := strings.Join([]string{
+		"From: " + n.from,
+		"To: " + to,
+		// Subjects can hold user input such as company names; encoding keeps line breaks out of the headers
+		"Subject: " + mime.QEncoding.Encode("UTF-8", subject),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"UTF-8\"",
+		"",
+		body,
+	}, "\r\n")
+
+	// The envelope sender must be a bare address, while the header may include a display name
+	envelopeFrom := n.from
+	if parsed, err := mail.ParseAddress(n.from); err == nil {
+		envelopeFrom = parsed.Address
+	}
+
+	addr := fmt.Sprintf("%s:%d", n.host, n.port)
+	if err := smtp.SendMail(addr, auth, envelopeFrom, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Printf("[Notify] Email sent to %s: %s", to, subject)
+	return nil
+}
+
+// LogNotifier writes notifications to the log (used when no delivery channel is configured)
+type LogNotifier struct{}
+
+// Notify logs the notification
+func (n *LogNotifier) Notify(ctx context.Context, to, subject, body string) error {
+	log.Printf("[Notify] To=%s Subject=%q\n%s", to, subject, body)
+	return nil
+}
@@ -0,0 +1,178 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// Webhook delivery headers. The signature is "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">",
+// keyed by the webhook's secret, so receivers can check both the sender and the delivery's age.
+const (
+	WebhookSignatureHeader = "X-MyJobMatch-Signature"
+	WebhookEventHeader     = "X-MyJobMatch-Event"
+	WebhookDeliveryHeader  = "X-MyJobMatch-Delivery"
+)
+
+// webhookSecretPrefix marks webhook signing secrets
+const webhookSecretPrefix = "whsec_"
+
+var webhookLog = logging.Component("Webhooks")
+
+// webhookRetryBaseDelay is the wait before the first retry of a failed delivery, doubled for each later one
+const webhookRetryBaseDelay = time.Second
+
+// ErrInvalidWebhookURL is returned for URLs webhooks can't be delivered to
+var ErrInvalidWebhookURL = errors.New("invalid webhook URL")
+
+// Webhooks delivers signed webhook events
+type Webhooks struct {
+	client       *http.Client
+	maxAttempts  int
+	allowPrivate bool // Allow http URLs and private addresses, for local development
+}
+
+// NewWebhooks creates a webhook sender. Unless WEBHOOK_ALLOW_PRIVATE_HOSTS is set, deliveries are refused
+// to hosts that resolve to loopback, private or link-local addresses, checked when connecting so DNS
+// can't be used to reach internal services.
+func NewWebhooks(cfg *config.Config) *Webhooks {
+	w := &Webhooks{
+		maxAttempts:  cfg.WebhookMaxAttempts,
+		allowPrivate: cfg.WebhookAllowPrivateHosts,
+	}
+	if w.maxAttempts < 1 {
+		w.maxAttempts = 1
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if !w.allowPrivate {
+		dialer.Control = utils.PublicDialControl
+	}
+	transport := &http.Transport{
+		DialContext: dialer.DialContext,
+		TLSClientConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		},
+		MaxIdleConnsPerHost: 2,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	w.client = &http.Client{
+		Timeout:   time.Duration(cfg.WebhookTimeoutSeconds) * time.Second,
+		Transport: utils.UserAgentMiddleware(transport),
+		// A redirect could point anywhere, so the receiver's response is final
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return w
+}
+
+// NewWebhookSecret creates a random webhook signing secret
+func NewWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// ValidateURL checks that webhooks can be delivered to a URL: an absolute https URL without credentials,
+// whose host isn't a private address. http URLs and private addresses are allowed with
+// WEBHOOK_ALLOW_PRIVATE_HOSTS.
+func (w *Webhooks) ValidateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || u.User != nil {
+		return fmt.Errorf("%w: must be an absolute URL without credentials", ErrInvalidWebhookURL)
+	}
+	switch {
+	case u.Scheme == "https":
+	case u.Scheme == "http" && w.allowPrivate:
+	default:
+		return fmt.Errorf("%w: must use https", ErrInvalidWebhookURL)
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil && utils.IsPrivateAddress(ip) && !w.allowPrivate {
+		return fmt.Errorf("%w: private addresses are not allowed", ErrInvalidWebhookURL)
+	}
+	return nil
+}
+
+// Send posts an event to a webhook, signed with its secret. Network errors, 429 and 5xx responses are
+// retried with exponential backoff, up to WEBHOOK_MAX_ATTEMPTS attempts in all.
+func (w *Webhooks) Send(ctx context.Context, webhookURL, secret string, event *models.WebhookEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+
+	delay := webhookRetryBaseDelay
+	for attempt := 1; ; attempt++ {
+		retry, err := w.deliver(ctx, webhookURL, secret, event, body)
+		if err == nil {
+			webhookLog.InfoContext(ctx, "Webhook delivered", "event", event.Type, "delivery_id", event.ID, "attempts", attempt)
+			return nil
+		}
+		if !retry || attempt >= w.maxAttempts {
+			return fmt.Errorf("failed to deliver webhook after %d attempts: %w", attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to deliver webhook: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// deliver makes one delivery attempt, reporting whether a failure is worth retrying
+func (w *Webhooks) deliver(ctx context.Context, webhookURL, secret string, event *models.WebhookEvent, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event.Type)
+	req.Header.Set(WebhookDeliveryHeader, event.ID)
+	req.Header.Set(WebhookSignatureHeader, signWebhook(secret, time.Now(), body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return !errors.Is(err, utils.ErrPrivateAddress), err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+	return retry, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+}
+
+// signWebhook computes the signature header of a delivery sent at t
+func signWebhook(secret string, t time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
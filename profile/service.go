@@ -0,0 +1,170 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/myjobmatch/backend/agent"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// ErrNoProfile is returned when a user has neither a saved structured profile nor a saved CV
+var ErrNoProfile = errors.New("user has no saved profile or CV")
+
+// ErrNoCV is returned when a CV to parse has neither text nor a file
+var ErrNoCV = errors.New("CV text or file is required")
+
+// ErrInvalidProfile is returned when an updated profile fails validation
+var ErrInvalidProfile = errors.New("invalid profile")
+
+// workDatePattern accepts "2020", "2020-01" or "Present"
+var workDatePattern = regexp.MustCompile(`^(\d{4}(-(0[1-9]|1[0-2]))?|[Pp]resent)$`)
+
+// Service resolves the saved structured profile of authenticated users
+type Service struct {
+	agent         *agent.JobAgent
+	store         storage.Store
+	storageClient storage.CVStorage
+}
+
+// NewService creates a new profile service
+func NewService(
+	jobAgent *agent.JobAgent,
+	store storage.Store,
+	storageClient storage.CVStorage,
+) *Service {
+	return &Service{
+		agent:         jobAgent,
+		store:         store,
+		storageClient: storageClient,
+	}
+}
+
+// ForUser returns the user's structured profile. A user-edited or previously parsed
+// profile is preferred; otherwise the saved CV is parsed once and the result stored.
+func (s *Service) ForUser(ctx context.Context, userID string) (*models.StructuredProfile, error) {
+	saved, err := s.store.GetStructuredProfile(ctx, userID)
+	if err == nil {
+		return saved, nil
+	}
+	if !errors.Is(err, storage.ErrProfileNotFound) {
+		return nil, err
+	}
+
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.CVUrl == "" {
+		return nil, ErrNoProfile
+	}
+
+	parsed, err := s.parseSavedCV(ctx, user.CVUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	saved = &models.StructuredProfile{Profile: *parsed}
+	if err := s.store.SaveStructuredProfile(ctx, userID, saved); err != nil {
+		log.Printf("[Profile] Failed to store parsed profile for %s: %v", userID, err)
+	}
+	return saved, nil
+}
+
+// Update applies a partial update to the user's structured profile and saves it as edited. Users
+// without a CV start from an empty profile. It returns an error wrapping ErrInvalidProfile when the
+// updated profile fails validation.
+func (s *Service) Update(ctx context.Context, userID string, req *models.UpdateStructuredProfileRequest) (*models.StructuredProfile, error) {
+	saved, err := s.ForUser(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, ErrNoProfile) {
+			return nil, fmt.Errorf("failed to load profile: %w", err)
+		}
+		saved = &models.StructuredProfile{}
+	}
+
+	req.Apply(&saved.Profile)
+	normalizeLists(&saved.Profile)
+	if err := validate(&saved.Profile); err != nil {
+		return nil, err
+	}
+
+	saved.Edited = true
+	if err := s.store.SaveStructuredProfile(ctx, userID, saved); err != nil {
+		return nil, fmt.Errorf("failed to save profile: %w", err)
+	}
+	return saved, nil
+}
+
+// Parse extracts a profile from CV text or a CV file. PDFs are parsed by Gemini directly; Word and
+// text files are converted to text by the agent. It returns an error wrapping
+// gemini.ErrModelNotAllowed for a model that isn't allowed, and ErrNoCV when there is nothing to parse.
+func (s *Service) Parse(ctx context.Context, cvText string, cvFileData []byte, cvFileName, model string) (*models.UserProfile, error) {
+	if err := s.agent.ValidateModel(model); err != nil {
+		return nil, err
+	}
+	if cvText == "" && len(cvFileData) == 0 {
+		return nil, ErrNoCV
+	}
+
+	return s.agent.BuildProfile(ctx, agent.SearchJobsInput{
+		CVText:     cvText,
+		CVFileData: cvFileData,
+		CVFileName: cvFileName,
+		Model:      model,
+	})
+}
+
+// Reset drops the stored profile so it is re-parsed from the user's next CV
+func (s *Service) Reset(ctx context.Context, userID string) error {
+	return s.store.DeleteStructuredProfile(ctx, userID)
+}
+
+// parseSavedCV downloads a saved CV and parses it into a profile
+func (s *Service) parseSavedCV(ctx context.Context, cvURL string) (*models.UserProfile, error) {
+	cvContent, err := s.storageClient.DownloadCV(ctx, cvURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download CV: %w", err)
+	}
+
+	// The agent parses PDFs with Gemini directly and converts Word and text files to text
+	input := agent.SearchJobsInput{CVFileData: cvContent, CVFileName: cvURL}
+
+	log.Printf("[Profile] Parsing saved CV %s", cvURL)
+	return s.agent.BuildProfile(ctx, input)
+}
+
+// validate checks cross-field constraints not expressible as binding tags
+func validate(p *models.UserProfile) error {
+	if p.MinSalary > 0 && p.MaxSalary > 0 && p.MinSalary > p.MaxSalary {
+		return fmt.Errorf("%w: min_salary must not exceed max_salary", ErrInvalidProfile)
+	}
+
+	for i, work := range p.WorkHistory {
+		if strings.TrimSpace(work.Title) == "" || strings.TrimSpace(work.Company) == "" {
+			return fmt.Errorf("%w: work_history[%d]: title and company are required", ErrInvalidProfile, i)
+		}
+		if work.StartDate != "" && !workDatePattern.MatchString(work.StartDate) {
+			return fmt.Errorf("%w: work_history[%d]: start_date must be YYYY or YYYY-MM", ErrInvalidProfile, i)
+		}
+		if work.EndDate != "" && !workDatePattern.MatchString(work.EndDate) {
+			return fmt.Errorf("%w: work_history[%d]: end_date must be YYYY, YYYY-MM or Present", ErrInvalidProfile, i)
+		}
+	}
+
+	return nil
+}
+
+// normalizeLists trims and de-duplicates user-entered list values
+func normalizeLists(p *models.UserProfile) {
+	p.Skills = utils.DedupeStrings(p.Skills)
+	p.TechnicalStack = utils.DedupeStrings(p.TechnicalStack)
+	p.PreferredRoles = utils.DedupeStrings(p.PreferredRoles)
+	p.PreferredLocations = utils.DedupeStrings(p.PreferredLocations)
+}
@@ -0,0 +1,2006 @@
+// gRPC API for internal services. It runs the same searches and profile operations as the REST API,
+// authenticated with the same JWTs and personal access tokens ("authorization: Bearer <token>"
+// metadata). Generate the Go code with:
+//
+//   protoc --go_out=. --go_opt=paths=source_relative \
+//     --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+//     proto/myjobmatch/v1/myjobmatch.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: proto/myjobmatch/v1/myjobmatch.proto
+
+package myjobmatchv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SearchJobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CvText string `protobuf:"bytes,1,opt,name=cv_text,json=cvText,proto3" json:"cv_text,omitempty"`
+	// PDF, Word or text file; PDFs are passed to Gemini directly
+	CvFile []byte `protobuf:"bytes,2,opt,name=cv_file,json=cvFile,proto3" json:"cv_file,omitempty"`
+	// Name of cv_file, used to tell its format
+	CvFileName string           `protobuf:"bytes,3,opt,name=cv_file_name,json=cvFileName,proto3" json:"cv_file_name,omitempty"`
+	Query      string           `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
+	Filters    *JobSearchFilter `protobuf:"bytes,5,opt,name=filters,proto3" json:"filters,omitempty"`
+	// Save cv_file to the caller's profile
+	SaveCv bool       `protobuf:"varint,6,opt,name=save_cv,json=saveCv,proto3" json:"save_cv,omitempty"`
+	Budget *LLMBudget `protobuf:"bytes,7,opt,name=budget,proto3" json:"budget,omitempty"`
+	// Strip name, email and phone before scoring (always on if enabled in account settings)
+	Incognito         bool  `protobuf:"varint,8,opt,name=incognito,proto3" json:"incognito,omitempty"`
+	MaxResults        int32 `protobuf:"varint,9,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`
+	MaxPagesToProcess int32 `protobuf:"varint,10,opt,name=max_pages_to_process,json=maxPagesToProcess,proto3" json:"max_pages_to_process,omitempty"`
+	// Gemini model, from GEMINI_ALLOWED_MODELS (default GEMINI_MODEL)
+	Model string `protobuf:"bytes,11,opt,name=model,proto3" json:"model,omitempty"`
+}
+
+func (x *SearchJobsRequest) Reset() {
+	*x = SearchJobsRequest{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchJobsRequest) ProtoMessage() {}
+
+func (x *SearchJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchJobsRequest.ProtoReflect.Descriptor instead.
+func (*SearchJobsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SearchJobsRequest) GetCvText() string {
+	if x != nil {
+		return x.CvText
+	}
+	return ""
+}
+
+func (x *SearchJobsRequest) GetCvFile() []byte {
+	if x != nil {
+		return x.CvFile
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetCvFileName() string {
+	if x != nil {
+		return x.CvFileName
+	}
+	return ""
+}
+
+func (x *SearchJobsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchJobsRequest) GetFilters() *JobSearchFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetSaveCv() bool {
+	if x != nil {
+		return x.SaveCv
+	}
+	return false
+}
+
+func (x *SearchJobsRequest) GetBudget() *LLMBudget {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+func (x *SearchJobsRequest) GetIncognito() bool {
+	if x != nil {
+		return x.Incognito
+	}
+	return false
+}
+
+func (x *SearchJobsRequest) GetMaxResults() int32 {
+	if x != nil {
+		return x.MaxResults
+	}
+	return 0
+}
+
+func (x *SearchJobsRequest) GetMaxPagesToProcess() int32 {
+	if x != nil {
+		return x.MaxPagesToProcess
+	}
+	return 0
+}
+
+func (x *SearchJobsRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+type JobSearchFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Locations []string `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
+	// WFH, WFO, Hybrid
+	RemoteModes []string `protobuf:"bytes,2,rep,name=remote_modes,json=remoteModes,proto3" json:"remote_modes,omitempty"`
+	// full_time, part_time, contract, internship
+	JobTypes []string `protobuf:"bytes,3,rep,name=job_types,json=jobTypes,proto3" json:"job_types,omitempty"`
+	// Monthly amount; postings paid per hour, day, week or year are converted
+	MinSalary int64 `protobuf:"varint,4,opt,name=min_salary,json=minSalary,proto3" json:"min_salary,omitempty"`
+	// Monthly amount
+	MaxSalary int64  `protobuf:"varint,5,opt,name=max_salary,json=maxSalary,proto3" json:"max_salary,omitempty"`
+	Currency  string `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	// last_24h, last_week, last_month
+	DatePosted string `protobuf:"bytes,7,opt,name=date_posted,json=datePosted,proto3" json:"date_posted,omitempty"`
+	// Minimum match score to return (default 50; 0 returns everything)
+	MinScore         *int32   `protobuf:"varint,8,opt,name=min_score,json=minScore,proto3,oneof" json:"min_score,omitempty"`
+	ExcludeCompanies []string `protobuf:"bytes,9,rep,name=exclude_companies,json=excludeCompanies,proto3" json:"exclude_companies,omitempty"`
+	ExcludeKeywords  []string `protobuf:"bytes,10,rep,name=exclude_keywords,json=excludeKeywords,proto3" json:"exclude_keywords,omitempty"`
+}
+
+func (x *JobSearchFilter) Reset() {
+	*x = JobSearchFilter{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobSearchFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobSearchFilter) ProtoMessage() {}
+
+func (x *JobSearchFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobSearchFilter.ProtoReflect.Descriptor instead.
+func (*JobSearchFilter) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *JobSearchFilter) GetLocations() []string {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+func (x *JobSearchFilter) GetRemoteModes() []string {
+	if x != nil {
+		return x.RemoteModes
+	}
+	return nil
+}
+
+func (x *JobSearchFilter) GetJobTypes() []string {
+	if x != nil {
+		return x.JobTypes
+	}
+	return nil
+}
+
+func (x *JobSearchFilter) GetMinSalary() int64 {
+	if x != nil {
+		return x.MinSalary
+	}
+	return 0
+}
+
+func (x *JobSearchFilter) GetMaxSalary() int64 {
+	if x != nil {
+		return x.MaxSalary
+	}
+	return 0
+}
+
+func (x *JobSearchFilter) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *JobSearchFilter) GetDatePosted() string {
+	if x != nil {
+		return x.DatePosted
+	}
+	return ""
+}
+
+func (x *JobSearchFilter) GetMinScore() int32 {
+	if x != nil && x.MinScore != nil {
+		return *x.MinScore
+	}
+	return 0
+}
+
+func (x *JobSearchFilter) GetExcludeCompanies() []string {
+	if x != nil {
+		return x.ExcludeCompanies
+	}
+	return nil
+}
+
+func (x *JobSearchFilter) GetExcludeKeywords() []string {
+	if x != nil {
+		return x.ExcludeKeywords
+	}
+	return nil
+}
+
+// LLMBudget caps the Gemini usage of a search; anonymous searches are also capped by server limits
+type LLMBudget struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxCalls int32 `protobuf:"varint,1,opt,name=max_calls,json=maxCalls,proto3" json:"max_calls,omitempty"`
+	// Total prompt and output tokens
+	MaxTokens  int64   `protobuf:"varint,2,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"`
+	MaxCostUsd float64 `protobuf:"fixed64,3,opt,name=max_cost_usd,json=maxCostUsd,proto3" json:"max_cost_usd,omitempty"`
+}
+
+func (x *LLMBudget) Reset() {
+	*x = LLMBudget{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LLMBudget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LLMBudget) ProtoMessage() {}
+
+func (x *LLMBudget) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LLMBudget.ProtoReflect.Descriptor instead.
+func (*LLMBudget) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LLMBudget) GetMaxCalls() int32 {
+	if x != nil {
+		return x.MaxCalls
+	}
+	return 0
+}
+
+func (x *LLMBudget) GetMaxTokens() int64 {
+	if x != nil {
+		return x.MaxTokens
+	}
+	return 0
+}
+
+func (x *LLMBudget) GetMaxCostUsd() float64 {
+	if x != nil {
+		return x.MaxCostUsd
+	}
+	return 0
+}
+
+type SearchJobsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results      []*RankedJob `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Profile      *UserProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	TotalResults int32        `protobuf:"varint,3,opt,name=total_results,json=totalResults,proto3" json:"total_results,omitempty"`
+	Message      string       `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// The CV file was saved to the caller's profile
+	CvSaved       bool      `protobuf:"varint,5,opt,name=cv_saved,json=cvSaved,proto3" json:"cv_saved,omitempty"`
+	LlmUsage      *LLMUsage `protobuf:"bytes,6,opt,name=llm_usage,json=llmUsage,proto3" json:"llm_usage,omitempty"`
+	PromptVariant string    `protobuf:"bytes,7,opt,name=prompt_variant,json=promptVariant,proto3" json:"prompt_variant,omitempty"`
+	// Session to refine the search with on the REST API
+	SessionId string `protobuf:"bytes,8,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *SearchJobsResponse) Reset() {
+	*x = SearchJobsResponse{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchJobsResponse) ProtoMessage() {}
+
+func (x *SearchJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchJobsResponse.ProtoReflect.Descriptor instead.
+func (*SearchJobsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchJobsResponse) GetResults() []*RankedJob {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchJobsResponse) GetProfile() *UserProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *SearchJobsResponse) GetTotalResults() int32 {
+	if x != nil {
+		return x.TotalResults
+	}
+	return 0
+}
+
+func (x *SearchJobsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SearchJobsResponse) GetCvSaved() bool {
+	if x != nil {
+		return x.CvSaved
+	}
+	return false
+}
+
+func (x *SearchJobsResponse) GetLlmUsage() *LLMUsage {
+	if x != nil {
+		return x.LlmUsage
+	}
+	return nil
+}
+
+func (x *SearchJobsResponse) GetPromptVariant() string {
+	if x != nil {
+		return x.PromptVariant
+	}
+	return ""
+}
+
+func (x *SearchJobsResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type RankedJob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Job *JobPosting `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	// 0-100, composite of the AI and heuristic scores
+	MatchScore  int32  `protobuf:"varint,2,opt,name=match_score,json=matchScore,proto3" json:"match_score,omitempty"`
+	MatchReason string `protobuf:"bytes,3,opt,name=match_reason,json=matchReason,proto3" json:"match_reason,omitempty"`
+	// ai or estimated
+	ScoreMethod    string          `protobuf:"bytes,4,opt,name=score_method,json=scoreMethod,proto3" json:"score_method,omitempty"`
+	ScoreBreakdown *ScoreBreakdown `protobuf:"bytes,5,opt,name=score_breakdown,json=scoreBreakdown,proto3" json:"score_breakdown,omitempty"`
+	AiScore        int32           `protobuf:"varint,6,opt,name=ai_score,json=aiScore,proto3" json:"ai_score,omitempty"`
+	HeuristicScore int32           `protobuf:"varint,7,opt,name=heuristic_score,json=heuristicScore,proto3" json:"heuristic_score,omitempty"`
+	// Percentage of profile skills mentioned in the posting
+	SkillsMatch int32 `protobuf:"varint,8,opt,name=skills_match,json=skillsMatch,proto3" json:"skills_match,omitempty"`
+}
+
+func (x *RankedJob) Reset() {
+	*x = RankedJob{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankedJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankedJob) ProtoMessage() {}
+
+func (x *RankedJob) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankedJob.ProtoReflect.Descriptor instead.
+func (*RankedJob) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RankedJob) GetJob() *JobPosting {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *RankedJob) GetMatchScore() int32 {
+	if x != nil {
+		return x.MatchScore
+	}
+	return 0
+}
+
+func (x *RankedJob) GetMatchReason() string {
+	if x != nil {
+		return x.MatchReason
+	}
+	return ""
+}
+
+func (x *RankedJob) GetScoreMethod() string {
+	if x != nil {
+		return x.ScoreMethod
+	}
+	return ""
+}
+
+func (x *RankedJob) GetScoreBreakdown() *ScoreBreakdown {
+	if x != nil {
+		return x.ScoreBreakdown
+	}
+	return nil
+}
+
+func (x *RankedJob) GetAiScore() int32 {
+	if x != nil {
+		return x.AiScore
+	}
+	return 0
+}
+
+func (x *RankedJob) GetHeuristicScore() int32 {
+	if x != nil {
+		return x.HeuristicScore
+	}
+	return 0
+}
+
+func (x *RankedJob) GetSkillsMatch() int32 {
+	if x != nil {
+		return x.SkillsMatch
+	}
+	return 0
+}
+
+type JobPosting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Stable ID derived from the canonical URL
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Company     string `protobuf:"bytes,3,opt,name=company,proto3" json:"company,omitempty"`
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Location    string `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	// full_time, part_time, contract, internship
+	WorkType string `protobuf:"bytes,6,opt,name=work_type,json=workType,proto3" json:"work_type,omitempty"`
+	// WFH, WFO, Hybrid, Unknown
+	SiteSetting string   `protobuf:"bytes,7,opt,name=site_setting,json=siteSetting,proto3" json:"site_setting,omitempty"`
+	Url         string   `protobuf:"bytes,8,opt,name=url,proto3" json:"url,omitempty"`
+	Source      string   `protobuf:"bytes,9,opt,name=source,proto3" json:"source,omitempty"`
+	Tags        []string `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	Salary      string   `protobuf:"bytes,11,opt,name=salary,proto3" json:"salary,omitempty"`
+	SalaryMin   int64    `protobuf:"varint,12,opt,name=salary_min,json=salaryMin,proto3" json:"salary_min,omitempty"`
+	SalaryMax   int64    `protobuf:"varint,13,opt,name=salary_max,json=salaryMax,proto3" json:"salary_max,omitempty"`
+	// ISO 4217, e.g. IDR
+	SalaryCurrency string `protobuf:"bytes,14,opt,name=salary_currency,json=salaryCurrency,proto3" json:"salary_currency,omitempty"`
+	// hour, day, week, month, year
+	SalaryPeriod   string   `protobuf:"bytes,15,opt,name=salary_period,json=salaryPeriod,proto3" json:"salary_period,omitempty"`
+	DatePosted     string   `protobuf:"bytes,16,opt,name=date_posted,json=datePosted,proto3" json:"date_posted,omitempty"`
+	ApplicationUrl string   `protobuf:"bytes,17,opt,name=application_url,json=applicationUrl,proto3" json:"application_url,omitempty"`
+	Requirements   string   `protobuf:"bytes,18,opt,name=requirements,proto3" json:"requirements,omitempty"`
+	Benefits       []string `protobuf:"bytes,19,rep,name=benefits,proto3" json:"benefits,omitempty"`
+	// entry, mid, senior, lead
+	ExperienceLevel string `protobuf:"bytes,20,opt,name=experience_level,json=experienceLevel,proto3" json:"experience_level,omitempty"`
+	// ISO 639-1 language of the posting
+	Language string `protobuf:"bytes,21,opt,name=language,proto3" json:"language,omitempty"`
+	// Application deadline (YYYY-MM-DD)
+	ValidThrough string `protobuf:"bytes,22,opt,name=valid_through,json=validThrough,proto3" json:"valid_through,omitempty"`
+	Expired      bool   `protobuf:"varint,23,opt,name=expired,proto3" json:"expired,omitempty"`
+}
+
+func (x *JobPosting) Reset() {
+	*x = JobPosting{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobPosting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobPosting) ProtoMessage() {}
+
+func (x *JobPosting) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobPosting.ProtoReflect.Descriptor instead.
+func (*JobPosting) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *JobPosting) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JobPosting) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *JobPosting) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *JobPosting) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *JobPosting) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *JobPosting) GetWorkType() string {
+	if x != nil {
+		return x.WorkType
+	}
+	return ""
+}
+
+func (x *JobPosting) GetSiteSetting() string {
+	if x != nil {
+		return x.SiteSetting
+	}
+	return ""
+}
+
+func (x *JobPosting) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *JobPosting) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *JobPosting) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *JobPosting) GetSalary() string {
+	if x != nil {
+		return x.Salary
+	}
+	return ""
+}
+
+func (x *JobPosting) GetSalaryMin() int64 {
+	if x != nil {
+		return x.SalaryMin
+	}
+	return 0
+}
+
+func (x *JobPosting) GetSalaryMax() int64 {
+	if x != nil {
+		return x.SalaryMax
+	}
+	return 0
+}
+
+func (x *JobPosting) GetSalaryCurrency() string {
+	if x != nil {
+		return x.SalaryCurrency
+	}
+	return ""
+}
+
+func (x *JobPosting) GetSalaryPeriod() string {
+	if x != nil {
+		return x.SalaryPeriod
+	}
+	return ""
+}
+
+func (x *JobPosting) GetDatePosted() string {
+	if x != nil {
+		return x.DatePosted
+	}
+	return ""
+}
+
+func (x *JobPosting) GetApplicationUrl() string {
+	if x != nil {
+		return x.ApplicationUrl
+	}
+	return ""
+}
+
+func (x *JobPosting) GetRequirements() string {
+	if x != nil {
+		return x.Requirements
+	}
+	return ""
+}
+
+func (x *JobPosting) GetBenefits() []string {
+	if x != nil {
+		return x.Benefits
+	}
+	return nil
+}
+
+func (x *JobPosting) GetExperienceLevel() string {
+	if x != nil {
+		return x.ExperienceLevel
+	}
+	return ""
+}
+
+func (x *JobPosting) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *JobPosting) GetValidThrough() string {
+	if x != nil {
+		return x.ValidThrough
+	}
+	return ""
+}
+
+func (x *JobPosting) GetExpired() bool {
+	if x != nil {
+		return x.Expired
+	}
+	return false
+}
+
+// ScoreBreakdown rates each matching criterion separately (0-100 each)
+type ScoreBreakdown struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Skills     int32 `protobuf:"varint,1,opt,name=skills,proto3" json:"skills,omitempty"`
+	Experience int32 `protobuf:"varint,2,opt,name=experience,proto3" json:"experience,omitempty"`
+	Location   int32 `protobuf:"varint,3,opt,name=location,proto3" json:"location,omitempty"`
+	WorkType   int32 `protobuf:"varint,4,opt,name=work_type,json=workType,proto3" json:"work_type,omitempty"`
+	Domain     int32 `protobuf:"varint,5,opt,name=domain,proto3" json:"domain,omitempty"`
+	Title      int32 `protobuf:"varint,6,opt,name=title,proto3" json:"title,omitempty"`
+}
+
+func (x *ScoreBreakdown) Reset() {
+	*x = ScoreBreakdown{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreBreakdown) ProtoMessage() {}
+
+func (x *ScoreBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreBreakdown.ProtoReflect.Descriptor instead.
+func (*ScoreBreakdown) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ScoreBreakdown) GetSkills() int32 {
+	if x != nil {
+		return x.Skills
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetExperience() int32 {
+	if x != nil {
+		return x.Experience
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetLocation() int32 {
+	if x != nil {
+		return x.Location
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetWorkType() int32 {
+	if x != nil {
+		return x.WorkType
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetDomain() int32 {
+	if x != nil {
+		return x.Domain
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetTitle() int32 {
+	if x != nil {
+		return x.Title
+	}
+	return 0
+}
+
+type LLMUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Calls            int32   `protobuf:"varint,1,opt,name=calls,proto3" json:"calls,omitempty"`
+	PromptTokens     int64   `protobuf:"varint,2,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	OutputTokens     int64   `protobuf:"varint,3,opt,name=output_tokens,json=outputTokens,proto3" json:"output_tokens,omitempty"`
+	EstimatedCostUsd float64 `protobuf:"fixed64,4,opt,name=estimated_cost_usd,json=estimatedCostUsd,proto3" json:"estimated_cost_usd,omitempty"`
+	BudgetExhausted  bool    `protobuf:"varint,5,opt,name=budget_exhausted,json=budgetExhausted,proto3" json:"budget_exhausted,omitempty"`
+	// Jobs scored deterministically after the budget ran out
+	FallbackScored int32 `protobuf:"varint,6,opt,name=fallback_scored,json=fallbackScored,proto3" json:"fallback_scored,omitempty"`
+}
+
+func (x *LLMUsage) Reset() {
+	*x = LLMUsage{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LLMUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LLMUsage) ProtoMessage() {}
+
+func (x *LLMUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LLMUsage.ProtoReflect.Descriptor instead.
+func (*LLMUsage) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LLMUsage) GetCalls() int32 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *LLMUsage) GetPromptTokens() int64 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *LLMUsage) GetOutputTokens() int64 {
+	if x != nil {
+		return x.OutputTokens
+	}
+	return 0
+}
+
+func (x *LLMUsage) GetEstimatedCostUsd() float64 {
+	if x != nil {
+		return x.EstimatedCostUsd
+	}
+	return 0
+}
+
+func (x *LLMUsage) GetBudgetExhausted() bool {
+	if x != nil {
+		return x.BudgetExhausted
+	}
+	return false
+}
+
+func (x *LLMUsage) GetFallbackScored() int32 {
+	if x != nil {
+		return x.FallbackScored
+	}
+	return 0
+}
+
+type UserProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name               string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Email              string   `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Phone              string   `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	Summary            string   `protobuf:"bytes,4,opt,name=summary,proto3" json:"summary,omitempty"`
+	Title              string   `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	ExperienceYears    float64  `protobuf:"fixed64,6,opt,name=experience_years,json=experienceYears,proto3" json:"experience_years,omitempty"`
+	Skills             []string `protobuf:"bytes,7,rep,name=skills,proto3" json:"skills,omitempty"`
+	TechnicalStack     []string `protobuf:"bytes,8,rep,name=technical_stack,json=technicalStack,proto3" json:"technical_stack,omitempty"`
+	Languages          []string `protobuf:"bytes,9,rep,name=languages,proto3" json:"languages,omitempty"`
+	PreferredRoles     []string `protobuf:"bytes,10,rep,name=preferred_roles,json=preferredRoles,proto3" json:"preferred_roles,omitempty"`
+	PreferredLocations []string `protobuf:"bytes,11,rep,name=preferred_locations,json=preferredLocations,proto3" json:"preferred_locations,omitempty"`
+	// WFH, WFO, Hybrid
+	PreferredRemoteModes []string `protobuf:"bytes,12,rep,name=preferred_remote_modes,json=preferredRemoteModes,proto3" json:"preferred_remote_modes,omitempty"`
+	// full_time, part_time, contract, internship, freelance
+	PreferredJobTypes []string          `protobuf:"bytes,13,rep,name=preferred_job_types,json=preferredJobTypes,proto3" json:"preferred_job_types,omitempty"`
+	MinSalary         int64             `protobuf:"varint,14,opt,name=min_salary,json=minSalary,proto3" json:"min_salary,omitempty"`
+	MaxSalary         int64             `protobuf:"varint,15,opt,name=max_salary,json=maxSalary,proto3" json:"max_salary,omitempty"`
+	Currency          string            `protobuf:"bytes,16,opt,name=currency,proto3" json:"currency,omitempty"`
+	Education         []*Education      `protobuf:"bytes,17,rep,name=education,proto3" json:"education,omitempty"`
+	WorkHistory       []*WorkExperience `protobuf:"bytes,18,rep,name=work_history,json=workHistory,proto3" json:"work_history,omitempty"`
+	Certifications    []string          `protobuf:"bytes,19,rep,name=certifications,proto3" json:"certifications,omitempty"`
+	Achievements      []string          `protobuf:"bytes,20,rep,name=achievements,proto3" json:"achievements,omitempty"`
+	// ISO 639-1 language of the CV
+	Language string `protobuf:"bytes,21,opt,name=language,proto3" json:"language,omitempty"`
+	// Title, skills and roles were translated to English for searching
+	Translated bool `protobuf:"varint,22,opt,name=translated,proto3" json:"translated,omitempty"`
+}
+
+func (x *UserProfile) Reset() {
+	*x = UserProfile{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserProfile) ProtoMessage() {}
+
+func (x *UserProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserProfile.ProtoReflect.Descriptor instead.
+func (*UserProfile) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UserProfile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UserProfile) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserProfile) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *UserProfile) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *UserProfile) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UserProfile) GetExperienceYears() float64 {
+	if x != nil {
+		return x.ExperienceYears
+	}
+	return 0
+}
+
+func (x *UserProfile) GetSkills() []string {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+func (x *UserProfile) GetTechnicalStack() []string {
+	if x != nil {
+		return x.TechnicalStack
+	}
+	return nil
+}
+
+func (x *UserProfile) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *UserProfile) GetPreferredRoles() []string {
+	if x != nil {
+		return x.PreferredRoles
+	}
+	return nil
+}
+
+func (x *UserProfile) GetPreferredLocations() []string {
+	if x != nil {
+		return x.PreferredLocations
+	}
+	return nil
+}
+
+func (x *UserProfile) GetPreferredRemoteModes() []string {
+	if x != nil {
+		return x.PreferredRemoteModes
+	}
+	return nil
+}
+
+func (x *UserProfile) GetPreferredJobTypes() []string {
+	if x != nil {
+		return x.PreferredJobTypes
+	}
+	return nil
+}
+
+func (x *UserProfile) GetMinSalary() int64 {
+	if x != nil {
+		return x.MinSalary
+	}
+	return 0
+}
+
+func (x *UserProfile) GetMaxSalary() int64 {
+	if x != nil {
+		return x.MaxSalary
+	}
+	return 0
+}
+
+func (x *UserProfile) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *UserProfile) GetEducation() []*Education {
+	if x != nil {
+		return x.Education
+	}
+	return nil
+}
+
+func (x *UserProfile) GetWorkHistory() []*WorkExperience {
+	if x != nil {
+		return x.WorkHistory
+	}
+	return nil
+}
+
+func (x *UserProfile) GetCertifications() []string {
+	if x != nil {
+		return x.Certifications
+	}
+	return nil
+}
+
+func (x *UserProfile) GetAchievements() []string {
+	if x != nil {
+		return x.Achievements
+	}
+	return nil
+}
+
+func (x *UserProfile) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *UserProfile) GetTranslated() bool {
+	if x != nil {
+		return x.Translated
+	}
+	return false
+}
+
+type Education struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Degree      string `protobuf:"bytes,1,opt,name=degree,proto3" json:"degree,omitempty"`
+	Field       string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Institution string `protobuf:"bytes,3,opt,name=institution,proto3" json:"institution,omitempty"`
+	Year        int32  `protobuf:"varint,4,opt,name=year,proto3" json:"year,omitempty"`
+}
+
+func (x *Education) Reset() {
+	*x = Education{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Education) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Education) ProtoMessage() {}
+
+func (x *Education) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Education.ProtoReflect.Descriptor instead.
+func (*Education) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Education) GetDegree() string {
+	if x != nil {
+		return x.Degree
+	}
+	return ""
+}
+
+func (x *Education) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Education) GetInstitution() string {
+	if x != nil {
+		return x.Institution
+	}
+	return ""
+}
+
+func (x *Education) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+type WorkExperience struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title    string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Company  string `protobuf:"bytes,2,opt,name=company,proto3" json:"company,omitempty"`
+	Location string `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	// YYYY or YYYY-MM
+	StartDate string `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// YYYY, YYYY-MM or Present
+	EndDate     string   `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Description string   `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Skills      []string `protobuf:"bytes,7,rep,name=skills,proto3" json:"skills,omitempty"`
+}
+
+func (x *WorkExperience) Reset() {
+	*x = WorkExperience{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkExperience) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkExperience) ProtoMessage() {}
+
+func (x *WorkExperience) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkExperience.ProtoReflect.Descriptor instead.
+func (*WorkExperience) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WorkExperience) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *WorkExperience) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *WorkExperience) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *WorkExperience) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *WorkExperience) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *WorkExperience) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *WorkExperience) GetSkills() []string {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+type ParseCVRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CvText string `protobuf:"bytes,1,opt,name=cv_text,json=cvText,proto3" json:"cv_text,omitempty"`
+	// PDF, Word or text file; PDFs are passed to Gemini directly
+	CvFile []byte `protobuf:"bytes,2,opt,name=cv_file,json=cvFile,proto3" json:"cv_file,omitempty"`
+	// Name of cv_file, used to tell its format
+	CvFileName string `protobuf:"bytes,3,opt,name=cv_file_name,json=cvFileName,proto3" json:"cv_file_name,omitempty"`
+	// Gemini model, from GEMINI_ALLOWED_MODELS (default GEMINI_MODEL)
+	Model string `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+}
+
+func (x *ParseCVRequest) Reset() {
+	*x = ParseCVRequest{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseCVRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseCVRequest) ProtoMessage() {}
+
+func (x *ParseCVRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseCVRequest.ProtoReflect.Descriptor instead.
+func (*ParseCVRequest) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ParseCVRequest) GetCvText() string {
+	if x != nil {
+		return x.CvText
+	}
+	return ""
+}
+
+func (x *ParseCVRequest) GetCvFile() []byte {
+	if x != nil {
+		return x.CvFile
+	}
+	return nil
+}
+
+func (x *ParseCVRequest) GetCvFileName() string {
+	if x != nil {
+		return x.CvFileName
+	}
+	return ""
+}
+
+func (x *ParseCVRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+type ParseCVResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Profile *UserProfile `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+}
+
+func (x *ParseCVResponse) Reset() {
+	*x = ParseCVResponse{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseCVResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseCVResponse) ProtoMessage() {}
+
+func (x *ParseCVResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseCVResponse.ProtoReflect.Descriptor instead.
+func (*ParseCVResponse) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ParseCVResponse) GetProfile() *UserProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type GetProfileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetProfileRequest) Reset() {
+	*x = GetProfileRequest{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileRequest) ProtoMessage() {}
+
+func (x *GetProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileRequest.ProtoReflect.Descriptor instead.
+func (*GetProfileRequest) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{13}
+}
+
+type StructuredProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Profile *UserProfile `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	// The user has corrected a field
+	Edited    bool                   `protobuf:"varint,2,opt,name=edited,proto3" json:"edited,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *StructuredProfile) Reset() {
+	*x = StructuredProfile{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StructuredProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StructuredProfile) ProtoMessage() {}
+
+func (x *StructuredProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StructuredProfile.ProtoReflect.Descriptor instead.
+func (*StructuredProfile) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StructuredProfile) GetProfile() *UserProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *StructuredProfile) GetEdited() bool {
+	if x != nil {
+		return x.Edited
+	}
+	return false
+}
+
+func (x *StructuredProfile) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type UpdateProfileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// New values of the fields named in update_mask
+	Profile *UserProfile `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	// Fields to update: title, summary, experience_years, skills, technical_stack, work_history,
+	// preferred_roles, preferred_locations, preferred_remote_modes, preferred_job_types, min_salary,
+	// max_salary, currency. List fields replace the stored list.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+}
+
+func (x *UpdateProfileRequest) Reset() {
+	*x = UpdateProfileRequest{}
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProfileRequest) ProtoMessage() {}
+
+func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateProfileRequest) GetProfile() *UserProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *UpdateProfileRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+var File_proto_myjobmatch_v1_myjobmatch_proto protoreflect.FileDescriptor
+
+var file_proto_myjobmatch_v1_myjobmatch_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x76, 0x31, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73,
+	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x88, 0x03, 0x0a, 0x11, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x63, 0x76, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x76, 0x54, 0x65, 0x78, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x76, 0x5f, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x76, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x20, 0x0a, 0x0c, 0x63, 0x76, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x76, 0x46, 0x69, 0x6c, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x38, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x79, 0x6a, 0x6f,
+	0x62, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x61, 0x76, 0x65, 0x5f, 0x63, 0x76, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x61, 0x76, 0x65, 0x43, 0x76, 0x12, 0x30, 0x0a, 0x06, 0x62,
+	0x75, 0x64, 0x67, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x79,
+	0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4d, 0x42,
+	0x75, 0x64, 0x67, 0x65, 0x74, 0x52, 0x06, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x69, 0x6e, 0x63, 0x6f, 0x67, 0x6e, 0x69, 0x74, 0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x69, 0x6e, 0x63, 0x6f, 0x67, 0x6e, 0x69, 0x74, 0x6f, 0x12, 0x1f, 0x0a, 0x0b, 0x6d,
+	0x61, 0x78, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x14,
+	0x6d, 0x61, 0x78, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x5f, 0x70, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x50,
+	0x61, 0x67, 0x65, 0x73, 0x54, 0x6f, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x22, 0xf2, 0x02, 0x0a, 0x0f, 0x4a, 0x6f, 0x62, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f,
+	0x6d, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6a, 0x6f, 0x62, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6a, 0x6f, 0x62,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x61, 0x6c,
+	0x61, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x53, 0x61,
+	0x6c, 0x61, 0x72, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x61, 0x6c, 0x61,
+	0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x53, 0x61, 0x6c,
+	0x61, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12,
+	0x1f, 0x0a, 0x0b, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x6f, 0x73, 0x74, 0x65, 0x64, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x65, 0x64,
+	0x12, 0x20, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x88,
+	0x01, 0x01, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x65,
+	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x12,
+	0x29, 0x0a, 0x10, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x77, 0x6f,
+	0x72, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6d,
+	0x69, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x69, 0x0a, 0x09, 0x4c, 0x4c, 0x4d, 0x42,
+	0x75, 0x64, 0x67, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x61, 0x6c,
+	0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x43, 0x61, 0x6c,
+	0x6c, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x73, 0x12, 0x20, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x75, 0x73,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x73, 0x74,
+	0x55, 0x73, 0x64, 0x22, 0xd4, 0x02, 0x0a, 0x12, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4a, 0x6f,
+	0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x79,
+	0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x6e, 0x6b,
+	0x65, 0x64, 0x4a, 0x6f, 0x62, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x34,
+	0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x76, 0x5f, 0x73, 0x61, 0x76, 0x65, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x76, 0x53, 0x61, 0x76, 0x65, 0x64, 0x12, 0x34,
+	0x0a, 0x09, 0x6c, 0x6c, 0x6d, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x4c, 0x4d, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6c, 0x6c, 0x6d, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x76,
+	0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72,
+	0x6f, 0x6d, 0x70, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xce, 0x02, 0x0a, 0x09, 0x52,
+	0x61, 0x6e, 0x6b, 0x65, 0x64, 0x4a, 0x6f, 0x62, 0x12, 0x2b, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x50, 0x6f, 0x73, 0x74, 0x69, 0x6e, 0x67,
+	0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x46, 0x0a, 0x0f,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x72, 0x65, 0x61, 0x6b,
+	0x64, 0x6f, 0x77, 0x6e, 0x52, 0x0e, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x72, 0x65, 0x61, 0x6b,
+	0x64, 0x6f, 0x77, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x69, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x61, 0x69, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x27, 0x0a, 0x0f, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73,
+	0x74, 0x69, 0x63, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x6b, 0x69, 0x6c,
+	0x6c, 0x73, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x22, 0xbc, 0x05, 0x0a, 0x0a,
+	0x4a, 0x6f, 0x62, 0x50, 0x6f, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x77, 0x6f, 0x72,
+	0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x69, 0x74, 0x65, 0x5f, 0x73, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x69, 0x74,
+	0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x61, 0x6c, 0x61, 0x72, 0x79,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x61, 0x6c, 0x61, 0x72, 0x79, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x61, 0x6c, 0x61, 0x72, 0x79, 0x5f, 0x6d, 0x69, 0x6e, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x73, 0x61, 0x6c, 0x61, 0x72, 0x79, 0x4d, 0x69, 0x6e, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x61, 0x6c, 0x61, 0x72, 0x79, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x73, 0x61, 0x6c, 0x61, 0x72, 0x79, 0x4d, 0x61, 0x78, 0x12, 0x27, 0x0a, 0x0f,
+	0x73, 0x61, 0x6c, 0x61, 0x72, 0x79, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x61, 0x6c, 0x61, 0x72, 0x79, 0x43, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x61, 0x6c, 0x61, 0x72, 0x79, 0x5f,
+	0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x61,
+	0x6c, 0x61, 0x72, 0x79, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x61,
+	0x74, 0x65, 0x5f, 0x70, 0x6f, 0x73, 0x74, 0x65, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x64, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x61,
+	0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x55, 0x72, 0x6c, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x65, 0x6e, 0x65,
+	0x66, 0x69, 0x74, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x62, 0x65, 0x6e, 0x65,
+	0x66, 0x69, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e,
+	0x63, 0x65, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
+	0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12,
+	0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x5f, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x54, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68,
+	0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x18, 0x17, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x22, 0xaf, 0x01, 0x0a, 0x0e, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73,
+	0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x65, 0x72,
+	0x69, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
+	0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x22, 0xec, 0x01, 0x0a,
+	0x08, 0x4c, 0x4c, 0x4d, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x61, 0x6c,
+	0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x12,
+	0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x65, 0x73, 0x74,
+	0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x75, 0x73, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64,
+	0x43, 0x6f, 0x73, 0x74, 0x55, 0x73, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x62, 0x75, 0x64, 0x67, 0x65,
+	0x74, 0x5f, 0x65, 0x78, 0x68, 0x61, 0x75, 0x73, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0f, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x45, 0x78, 0x68, 0x61, 0x75, 0x73, 0x74,
+	0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x66, 0x61, 0x6c,
+	0x6c, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x64, 0x22, 0xa3, 0x06, 0x0a, 0x0b,
+	0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75,
+	0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x65,
+	0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x79, 0x65, 0x61, 0x72, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63,
+	0x65, 0x59, 0x65, 0x61, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x12, 0x27,
+	0x0a, 0x0f, 0x74, 0x65, 0x63, 0x68, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x61, 0x63,
+	0x6b, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x65, 0x63, 0x68, 0x6e, 0x69, 0x63,
+	0x61, 0x6c, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x67, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72,
+	0x65, 0x64, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e,
+	0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x52, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x2f,
+	0x0a, 0x13, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x70, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x34, 0x0a, 0x16, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x14, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x4d, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72,
+	0x65, 0x64, 0x5f, 0x6a, 0x6f, 0x62, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x11, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x4a, 0x6f, 0x62,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x61, 0x6c,
+	0x61, 0x72, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x53, 0x61,
+	0x6c, 0x61, 0x72, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x61, 0x6c, 0x61,
+	0x72, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x53, 0x61, 0x6c,
+	0x61, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12,
+	0x36, 0x0a, 0x09, 0x65, 0x64, 0x75, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x64, 0x75, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x65, 0x64,
+	0x75, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0c, 0x77, 0x6f, 0x72, 0x6b, 0x5f,
+	0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f,
+	0x72, 0x6b, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0b, 0x77, 0x6f,
+	0x72, 0x6b, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x65, 0x72,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x63, 0x68, 0x69, 0x65, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x63, 0x68, 0x69, 0x65, 0x76, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
+	0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x16, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x65,
+	0x64, 0x22, 0x6f, 0x0a, 0x09, 0x45, 0x64, 0x75, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x64, 0x65, 0x67, 0x72, 0x65, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x64, 0x65, 0x67, 0x72, 0x65, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x20, 0x0a, 0x0b,
+	0x69, 0x6e, 0x73, 0x74, 0x69, 0x74, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x69, 0x74, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x79, 0x65, 0x61, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x79, 0x65,
+	0x61, 0x72, 0x22, 0xd0, 0x01, 0x0a, 0x0e, 0x57, 0x6f, 0x72, 0x6b, 0x45, 0x78, 0x70, 0x65, 0x72,
+	0x69, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x6b, 0x69, 0x6c, 0x6c, 0x73, 0x22, 0x7a, 0x0a, 0x0e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x43, 0x56,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x76, 0x5f, 0x74, 0x65,
+	0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x76, 0x54, 0x65, 0x78, 0x74,
+	0x12, 0x17, 0x0a, 0x07, 0x63, 0x76, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x63, 0x76, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x63, 0x76, 0x5f,
+	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x63, 0x76, 0x46, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x22, 0x47, 0x0a, 0x0f, 0x50, 0x61, 0x72, 0x73, 0x65, 0x43, 0x56, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x9c, 0x01, 0x0a, 0x11, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x64, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65,
+	0x64, 0x69, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x64, 0x69,
+	0x74, 0x65, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x89,
+	0x01, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x3b, 0x0a,
+	0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x32, 0xd1, 0x02, 0x0a, 0x08, 0x4a,
+	0x6f, 0x62, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x51, 0x0a, 0x0a, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x20, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4a, 0x6f,
+	0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x07, 0x50, 0x61,
+	0x72, 0x73, 0x65, 0x43, 0x56, 0x12, 0x1d, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x43, 0x56, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x43, 0x56, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x12, 0x20, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x64, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x56, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x23, 0x2e, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6d,
+	0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x42, 0x40,
+	0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x79, 0x6a,
+	0x6f, 0x62, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x2f, 0x76, 0x31, 0x3b, 0x6d, 0x79, 0x6a, 0x6f, 0x62, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_myjobmatch_v1_myjobmatch_proto_rawDescOnce sync.Once
+	file_proto_myjobmatch_v1_myjobmatch_proto_rawDescData = file_proto_myjobmatch_v1_myjobmatch_proto_rawDesc
+)
+
+func file_proto_myjobmatch_v1_myjobmatch_proto_rawDescGZIP() []byte {
+	file_proto_myjobmatch_v1_myjobmatch_proto_rawDescOnce.Do(func() {
+		file_proto_myjobmatch_v1_myjobmatch_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_myjobmatch_v1_myjobmatch_proto_rawDescData)
+	})
+	return file_proto_myjobmatch_v1_myjobmatch_proto_rawDescData
+}
+
+var file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_proto_myjobmatch_v1_myjobmatch_proto_goTypes = []any{
+	(*SearchJobsRequest)(nil),     // 0: myjobmatch.v1.SearchJobsRequest
+	(*JobSearchFilter)(nil),       // 1: myjobmatch.v1.JobSearchFilter
+	(*LLMBudget)(nil),             // 2: myjobmatch.v1.LLMBudget
+	(*SearchJobsResponse)(nil),    // 3: myjobmatch.v1.SearchJobsResponse
+	(*RankedJob)(nil),             // 4: myjobmatch.v1.RankedJob
+	(*JobPosting)(nil),            // 5: myjobmatch.v1.JobPosting
+	(*ScoreBreakdown)(nil),        // 6: myjobmatch.v1.ScoreBreakdown
+	(*LLMUsage)(nil),              // 7: myjobmatch.v1.LLMUsage
+	(*UserProfile)(nil),           // 8: myjobmatch.v1.UserProfile
+	(*Education)(nil),             // 9: myjobmatch.v1.Education
+	(*WorkExperience)(nil),        // 10: myjobmatch.v1.WorkExperience
+	(*ParseCVRequest)(nil),        // 11: myjobmatch.v1.ParseCVRequest
+	(*ParseCVResponse)(nil),       // 12: myjobmatch.v1.ParseCVResponse
+	(*GetProfileRequest)(nil),     // 13: myjobmatch.v1.GetProfileRequest
+	(*StructuredProfile)(nil),     // 14: myjobmatch.v1.StructuredProfile
+	(*UpdateProfileRequest)(nil),  // 15: myjobmatch.v1.UpdateProfileRequest
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil), // 17: google.protobuf.FieldMask
+}
+var file_proto_myjobmatch_v1_myjobmatch_proto_depIdxs = []int32{
+	1,  // 0: myjobmatch.v1.SearchJobsRequest.filters:type_name -> myjobmatch.v1.JobSearchFilter
+	2,  // 1: myjobmatch.v1.SearchJobsRequest.budget:type_name -> myjobmatch.v1.LLMBudget
+	4,  // 2: myjobmatch.v1.SearchJobsResponse.results:type_name -> myjobmatch.v1.RankedJob
+	8,  // 3: myjobmatch.v1.SearchJobsResponse.profile:type_name -> myjobmatch.v1.UserProfile
+	7,  // 4: myjobmatch.v1.SearchJobsResponse.llm_usage:type_name -> myjobmatch.v1.LLMUsage
+	5,  // 5: myjobmatch.v1.RankedJob.job:type_name -> myjobmatch.v1.JobPosting
+	6,  // 6: myjobmatch.v1.RankedJob.score_breakdown:type_name -> myjobmatch.v1.ScoreBreakdown
+	9,  // 7: myjobmatch.v1.UserProfile.education:type_name -> myjobmatch.v1.Education
+	10, // 8: myjobmatch.v1.UserProfile.work_history:type_name -> myjobmatch.v1.WorkExperience
+	8,  // 9: myjobmatch.v1.ParseCVResponse.profile:type_name -> myjobmatch.v1.UserProfile
+	8,  // 10: myjobmatch.v1.StructuredProfile.profile:type_name -> myjobmatch.v1.UserProfile
+	16, // 11: myjobmatch.v1.StructuredProfile.updated_at:type_name -> google.protobuf.Timestamp
+	8,  // 12: myjobmatch.v1.UpdateProfileRequest.profile:type_name -> myjobmatch.v1.UserProfile
+	17, // 13: myjobmatch.v1.UpdateProfileRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 14: myjobmatch.v1.JobMatch.SearchJobs:input_type -> myjobmatch.v1.SearchJobsRequest
+	11, // 15: myjobmatch.v1.JobMatch.ParseCV:input_type -> myjobmatch.v1.ParseCVRequest
+	13, // 16: myjobmatch.v1.JobMatch.GetProfile:input_type -> myjobmatch.v1.GetProfileRequest
+	15, // 17: myjobmatch.v1.JobMatch.UpdateProfile:input_type -> myjobmatch.v1.UpdateProfileRequest
+	3,  // 18: myjobmatch.v1.JobMatch.SearchJobs:output_type -> myjobmatch.v1.SearchJobsResponse
+	12, // 19: myjobmatch.v1.JobMatch.ParseCV:output_type -> myjobmatch.v1.ParseCVResponse
+	14, // 20: myjobmatch.v1.JobMatch.GetProfile:output_type -> myjobmatch.v1.StructuredProfile
+	14, // 21: myjobmatch.v1.JobMatch.UpdateProfile:output_type -> myjobmatch.v1.StructuredProfile
+	18, // [18:22] is the sub-list for method output_type
+	14, // [14:18] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_proto_myjobmatch_v1_myjobmatch_proto_init() }
+func file_proto_myjobmatch_v1_myjobmatch_proto_init() {
+	if File_proto_myjobmatch_v1_myjobmatch_proto != nil {
+		return
+	}
+	file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_myjobmatch_v1_myjobmatch_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_myjobmatch_v1_myjobmatch_proto_goTypes,
+		DependencyIndexes: file_proto_myjobmatch_v1_myjobmatch_proto_depIdxs,
+		MessageInfos:      file_proto_myjobmatch_v1_myjobmatch_proto_msgTypes,
+	}.Build()
+	File_proto_myjobmatch_v1_myjobmatch_proto = out.File
+	file_proto_myjobmatch_v1_myjobmatch_proto_rawDesc = nil
+	file_proto_myjobmatch_v1_myjobmatch_proto_goTypes = nil
+	file_proto_myjobmatch_v1_myjobmatch_proto_depIdxs = nil
+}
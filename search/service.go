@@ -0,0 +1,381 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/myjobmatch/backend/agent"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/profile"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/validation"
+)
+
+// storeResultsTimeout bounds the background writes that persist a search's postings and scores
+const storeResultsTimeout = 30 * time.Second
+
+// ErrNoInput is returned when a search has no CV, query or saved profile to search with
+var ErrNoInput = errors.New("search needs a CV, a query or a saved profile")
+
+var searchLog = logging.Component("Search")
+
+// Service runs job searches for the REST and gRPC APIs: it resolves a search's input from the request
+// and the user's account, runs the agent, and records the outcome
+type Service struct {
+	agent         *agent.JobAgent
+	store         storage.Store
+	storageClient storage.CVStorage
+	profiles      *profile.Service
+	anonBudget    models.LLMBudget // Budget caps applied to anonymous searches
+}
+
+// NewService creates a new search service
+func NewService(
+	jobAgent *agent.JobAgent,
+	store storage.Store,
+	storageClient storage.CVStorage,
+	profiles *profile.Service,
+	anonBudget models.LLMBudget,
+) *Service {
+	return &Service{
+		agent:         jobAgent,
+		store:         store,
+		storageClient: storageClient,
+		profiles:      profiles,
+		anonBudget:    anonBudget,
+	}
+}
+
+// Params is a search as the caller asked for it
+type Params struct {
+	UserID     string // Authenticated user; empty for anonymous searches
+	CVText     string
+	CVFileData []byte // PDFs are passed to Gemini directly; Word and text files are converted to text
+	CVFileName string
+	Query      string
+	Filters    models.JobSearchFilter
+	SaveCV     bool // Save the CV file to the user's profile
+	Budget     *models.LLMBudget
+	Incognito  bool
+	MaxResults int
+	MaxPages   int
+	Model      string
+}
+
+// Request is a validated search with its input resolved, ready to run synchronously or in the background
+type Request struct {
+	Input  agent.SearchJobsInput
+	UserID string // Authenticated user; empty for anonymous searches
+	SaveCV bool
+}
+
+// Prepare validates a search and resolves its input: the user's account settings (incognito, blocked
+// companies), job ratings and, without a CV, saved profile. It returns an error wrapping
+// gemini.ErrModelNotAllowed for a model that isn't allowed, validation.Errors for an invalid query or
+// filters, and ErrNoInput when there is nothing to search with.
+func (s *Service) Prepare(ctx context.Context, p Params) (*Request, error) {
+	if err := s.agent.ValidateModel(p.Model); err != nil {
+		return nil, err
+	}
+	if err := validation.Search(&p.Query, &p.Filters); err != nil {
+		return nil, err
+	}
+
+	// Account-level settings apply to every search: incognito, and companies the user never wants to see
+	incognito := p.Incognito
+	filters := p.Filters
+	if p.UserID != "" {
+		if user, err := s.store.GetUserByID(ctx, p.UserID); err == nil {
+			incognito = incognito || user.Incognito
+			filters = filters.WithExcludedCompanies(user.BlockedCompanies)
+		}
+	}
+
+	// Ratings of earlier matches hide rejected postings and guide scoring
+	var feedback []models.JobFeedback
+	if p.UserID != "" {
+		var err error
+		if feedback, err = s.store.ListJobFeedback(ctx, p.UserID); err != nil {
+			searchLog.WarnContext(ctx, "Failed to load job feedback", "error", err)
+		}
+	}
+
+	// If no CV provided, use the saved structured profile (user-edited, or parsed once from the saved CV)
+	var savedProfile *models.UserProfile
+	if p.UserID != "" && p.CVText == "" && len(p.CVFileData) == 0 {
+		saved, err := s.profiles.ForUser(ctx, p.UserID)
+		if err == nil {
+			savedProfile = &saved.Profile
+			searchLog.InfoContext(ctx, "Using saved profile", "edited", saved.Edited)
+		} else if !errors.Is(err, profile.ErrNoProfile) {
+			searchLog.WarnContext(ctx, "Failed to load saved profile", "error", err)
+		}
+	}
+
+	if savedProfile == nil && p.CVText == "" && len(p.CVFileData) == 0 && p.Query == "" {
+		return nil, ErrNoInput
+	}
+
+	searchLog.InfoContext(ctx, "Job search requested", "query", p.Query, "has_cv_text", p.CVText != "",
+		"has_cv_file", len(p.CVFileData) > 0, "use_profile_cv", savedProfile != nil, "save_cv", p.SaveCV,
+		"incognito", incognito, "filters", filters)
+
+	return &Request{
+		Input: agent.SearchJobsInput{
+			Profile:    savedProfile,
+			CVText:     p.CVText,
+			CVFileData: p.CVFileData,
+			CVFileName: p.CVFileName,
+			Query:      p.Query,
+			Filters:    filters,
+			Budget:     s.EffectiveBudget(p.Budget, p.UserID != ""),
+			Incognito:  incognito,
+			MaxResults: p.MaxResults,
+			MaxPages:   p.MaxPages,
+			Feedback:   feedback,
+			Model:      p.Model,
+			UserID:     p.UserID,
+		},
+		UserID: p.UserID,
+		SaveCV: p.SaveCV,
+	}, nil
+}
+
+// Checkpoint returns the input to keep with a background search so it can be resumed, or nil if it
+// can't be: uploaded CV files aren't kept, and neither is the CV text of incognito searches
+func (r *Request) Checkpoint() *models.AsyncSearchCheckpoint {
+	if len(r.Input.CVFileData) > 0 || (r.Input.Incognito && r.Input.CVText != "") {
+		return nil
+	}
+	return &models.AsyncSearchCheckpoint{
+		Profile:    r.Input.Profile,
+		CVText:     r.Input.CVText,
+		Query:      r.Input.Query,
+		Filters:    r.Input.Filters,
+		Budget:     r.Input.Budget,
+		Incognito:  r.Input.Incognito,
+		MaxResults: r.Input.MaxResults,
+		MaxPages:   r.Input.MaxPages,
+		Model:      r.Input.Model,
+	}
+}
+
+// FromCheckpoint rebuilds the request of an interrupted background search from its checkpoint. The
+// user's job ratings are reloaded, so ratings made since the search started also apply.
+func (s *Service) FromCheckpoint(ctx context.Context, search *models.AsyncSearch) *Request {
+	checkpoint := search.Checkpoint
+	req := &Request{
+		Input: agent.SearchJobsInput{
+			Profile:    checkpoint.Profile,
+			CVText:     checkpoint.CVText,
+			Query:      checkpoint.Query,
+			Filters:    checkpoint.Filters,
+			Budget:     checkpoint.Budget,
+			Incognito:  checkpoint.Incognito,
+			MaxResults: checkpoint.MaxResults,
+			MaxPages:   checkpoint.MaxPages,
+			Model:      checkpoint.Model,
+			UserID:     search.UserID,
+		},
+		UserID: search.UserID,
+	}
+	if search.UserID != "" {
+		feedback, err := s.store.ListJobFeedback(ctx, search.UserID)
+		if err != nil {
+			searchLog.WarnContext(ctx, "Failed to load job feedback", "error", err)
+		}
+		req.Input.Feedback = feedback
+	}
+	return req
+}
+
+// Run runs the agent and, if requested, saves the uploaded CV to the user's profile
+func (s *Service) Run(ctx context.Context, req *Request) (*models.SearchJobsResponse, error) {
+	output, err := s.agent.SearchJobs(ctx, req.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	s.storeResults(ctx, req.UserID, output.Results, output.PromptVariant)
+	if req.UserID != "" {
+		s.recordSearchHistory(ctx, req, output)
+	}
+	if output.PromptVariant != "" {
+		s.recordPromptExperiment(ctx, req, output)
+	}
+
+	// Save CV to profile if authenticated and requested
+	var cvSaved bool
+	if req.SaveCV && req.UserID != "" && len(req.Input.CVFileData) > 0 && s.storageClient != nil {
+		cvUrl, err := s.storageClient.UploadCVFromBytes(ctx, req.UserID, req.Input.CVFileData, req.Input.CVFileName)
+		if err != nil {
+			searchLog.WarnContext(ctx, "Failed to save CV to profile", "error", err)
+		} else {
+			// Update user's CV URL
+			if err := s.updateCVUrl(ctx, req.UserID, cvUrl); err != nil {
+				searchLog.WarnContext(ctx, "Failed to update CV URL", "error", err)
+			} else {
+				cvSaved = true
+				searchLog.InfoContext(ctx, "CV saved to profile")
+
+				// The structured profile belongs to the previous CV; re-parse on next use
+				if err := s.profiles.Reset(ctx, req.UserID); err != nil {
+					searchLog.WarnContext(ctx, "Failed to reset structured profile", "error", err)
+				}
+			}
+		}
+	}
+
+	return &models.SearchJobsResponse{
+		Results:      output.Results,
+		Profile:      output.Profile,
+		TotalResults: len(output.Results),
+		Message:      buildResultMessage(output.Stats, output.Usage),
+		CVSaved:      cvSaved,
+		LLMUsage:     &output.Usage,
+
+		PromptVariant: output.PromptVariant,
+		SessionID:     output.SessionID,
+	}, nil
+}
+
+// updateCVUrl links an uploaded CV to the user with the ID
+func (s *Service) updateCVUrl(ctx context.Context, userID, cvUrl string) error {
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.store.UpdateUserCVUrl(ctx, user.Email, cvUrl)
+}
+
+// storeResults keeps the returned postings so they can be opened by ID later, along with the user's scores
+// and the prompt variant that scored them. The writes run in the background, so they don't add to search
+// latency; failures are only logged.
+func (s *Service) storeResults(ctx context.Context, userID string, results []models.RankedJob, promptVariant string) {
+	if len(results) == 0 {
+		return
+	}
+
+	postings := make([]models.JobPosting, len(results))
+	for i, job := range results {
+		postings[i] = job.JobPosting
+	}
+
+	go func() {
+		storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), storeResultsTimeout)
+		defer cancel()
+
+		if err := s.store.SaveJobs(storeCtx, postings); err != nil {
+			searchLog.WarnContext(ctx, "Failed to store jobs", "error", err)
+		}
+		if userID != "" {
+			if err := s.store.SaveJobScores(storeCtx, userID, results, promptVariant); err != nil {
+				searchLog.WarnContext(ctx, "Failed to store job scores", "error", err)
+			}
+		}
+	}()
+}
+
+// recordSearchHistory stores a completed search in the user's history; failures are only logged
+func (s *Service) recordSearchHistory(ctx context.Context, req *Request, output *agent.SearchJobsOutput) {
+	entry := &models.SearchHistoryEntry{
+		UserID:        req.UserID,
+		Query:         req.Input.Query,
+		Filters:       req.Input.Filters,
+		Source:        req.source(),
+		Incognito:     req.Input.Incognito,
+		URLsFound:     output.Stats.URLsFound,
+		JobsExtracted: output.Stats.JobsExtracted,
+		JobsScored:    output.Stats.JobsScored,
+		CacheHits:     output.Stats.CacheHits,
+		ResultCount:   len(output.Results),
+		LLMCalls:      output.Usage.Calls,
+		PromptVariant: output.PromptVariant,
+		SessionID:     output.SessionID,
+	}
+	if err := s.store.AddSearchHistory(ctx, entry); err != nil {
+		searchLog.WarnContext(ctx, "Failed to record search history", "error", err)
+	}
+}
+
+// recordPromptExperiment stores the outcome metrics of a search run with a prompt experiment variant; failures are only logged
+func (s *Service) recordPromptExperiment(ctx context.Context, req *Request, output *agent.SearchJobsOutput) {
+	record := &models.PromptExperimentRecord{
+		Variant:       output.PromptVariant,
+		Source:        req.source(),
+		JobsExtracted: output.Stats.JobsExtracted,
+		ExtractErrors: output.Stats.ExtractErrors,
+		AIScores:      make([]int, 0, len(output.Results)),
+	}
+	for _, job := range output.Results {
+		if job.ScoreMethod == models.ScoreMethodAI {
+			record.AIScores = append(record.AIScores, job.AIScore)
+		} else {
+			record.Estimated++
+		}
+	}
+	if err := s.store.SavePromptExperimentRecord(ctx, record); err != nil {
+		searchLog.WarnContext(ctx, "Failed to record prompt experiment", "error", err)
+	}
+}
+
+// source returns the input a search was based on, as recorded in history
+func (r *Request) source() string {
+	switch {
+	case len(r.Input.CVFileData) > 0:
+		return models.SearchSourceCVFile
+	case r.Input.CVText != "":
+		return models.SearchSourceCVText
+	case r.Input.Profile != nil:
+		return models.SearchSourceSavedProfile
+	}
+	return models.SearchSourceQuery
+}
+
+// EffectiveBudget returns the requested budget; anonymous searches are additionally capped by server limits
+func (s *Service) EffectiveBudget(requested *models.LLMBudget, authenticated bool) *models.LLMBudget {
+	var budget models.LLMBudget
+	if requested != nil {
+		budget = *requested
+	}
+	if authenticated {
+		if requested == nil {
+			return nil
+		}
+		return &budget
+	}
+
+	budget.MaxCalls = capLimit(budget.MaxCalls, s.anonBudget.MaxCalls)
+	budget.MaxTokens = capLimit(budget.MaxTokens, s.anonBudget.MaxTokens)
+	if s.anonBudget.MaxCostUSD > 0 && (budget.MaxCostUSD <= 0 || budget.MaxCostUSD > s.anonBudget.MaxCostUSD) {
+		budget.MaxCostUSD = s.anonBudget.MaxCostUSD
+	}
+	return &budget
+}
+
+// capLimit applies a server limit to a requested value (0 means unlimited for both)
+func capLimit(requested, limit int) int {
+	if limit > 0 && (requested <= 0 || requested > limit) {
+		return limit
+	}
+	return requested
+}
+
+// buildResultMessage creates a human-readable message about the search results
+func buildResultMessage(stats agent.SearchStats, usage models.LLMUsage) string {
+	if stats.JobsReturned == 0 {
+		if usage.BudgetExhausted {
+			return "No matching jobs found before the AI usage budget was reached. Try a narrower search or a larger budget."
+		}
+		return "No matching jobs found. Try adjusting your search criteria."
+	}
+
+	if usage.BudgetExhausted {
+		return fmt.Sprintf("AI usage budget reached: %d job(s) were ranked with an estimated skill-overlap score.", usage.FallbackScored)
+	}
+
+	return ""
+}
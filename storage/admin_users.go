@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// GetUserByID retrieves a user by ID
+func (f *FirestoreClient) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	doc, err := f.collection(ctx, usersCollection).Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var user models.User
+	if err := doc.DataTo(&user); err != nil {
+		return nil, fmt.Errorf("failed to parse user data: %w", err)
+	}
+
+	user.ID = doc.Ref.ID
+	return &user, nil
+}
+
+// ListUsers returns a page of the users matching the query, newest first, and the cursor of the next page
+// ("" on the last page). Only the creation date is filtered in Firestore, so no composite indexes are needed;
+// the other filters are applied while reading.
+func (f *FirestoreClient) ListUsers(ctx context.Context, query models.UserQuery) ([]models.User, string, error) {
+	q := f.collection(ctx, usersCollection).OrderBy("createdAt", firestore.Desc)
+	if !query.CreatedAfter.IsZero() {
+		q = q.Where("createdAt", ">=", query.CreatedAfter)
+	}
+	if !query.CreatedBefore.IsZero() {
+		q = q.Where("createdAt", "<", query.CreatedBefore)
+	}
+	if query.Cursor != "" {
+		cursor, err := f.collection(ctx, usersCollection).Doc(query.Cursor).Get(ctx)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil, "", ErrUserNotFound
+			}
+			return nil, "", fmt.Errorf("failed to read user cursor: %w", err)
+		}
+		q = q.StartAfter(cursor)
+	}
+
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	users := make([]models.User, 0, query.Limit)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return users, "", nil
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to query users: %w", err)
+		}
+
+		var user models.User
+		if err := doc.DataTo(&user); err != nil {
+			return nil, "", fmt.Errorf("failed to parse user data: %w", err)
+		}
+		user.ID = doc.Ref.ID
+		if !userMatches(&user, query) {
+			continue
+		}
+
+		// Reading one match past the page tells whether there is a next page
+		if len(users) == query.Limit {
+			return users, users[len(users)-1].ID, nil
+		}
+		users = append(users, user)
+	}
+}
+
+// userMatches applies the filters of a user query that aren't applied by the database
+func userMatches(user *models.User, query models.UserQuery) bool {
+	if query.Provider != "" && user.Provider != query.Provider {
+		return false
+	}
+	if query.HasCV != nil && (user.CVUrl != "") != *query.HasCV {
+		return false
+	}
+	if query.Suspended != nil && user.Suspended != *query.Suspended {
+		return false
+	}
+	return true
+}
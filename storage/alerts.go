@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+const alertsCollection = "job_alerts"
+
+// ErrAlertNotFound is returned when a job alert does not exist or belongs to another user
+var ErrAlertNotFound = errors.New("job alert not found")
+
+// CreateAlert stores a new job alert
+func (f *FirestoreClient) CreateAlert(ctx context.Context, alert *models.JobAlert) error {
+	alert.CreatedAt = time.Now()
+
+	docRef := f.collection(ctx, alertsCollection).NewDoc()
+	if _, err := docRef.Set(ctx, alert); err != nil {
+		return fmt.Errorf("failed to create job alert: %w", err)
+	}
+
+	alert.ID = docRef.ID
+	return nil
+}
+
+// ListAlerts returns a user's job alerts, newest first
+func (f *FirestoreClient) ListAlerts(ctx context.Context, userID string) ([]models.JobAlert, error) {
+	alerts, err := f.queryAlerts(f.collection(ctx, alertsCollection).Where("userId", "==", userID).Documents(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].CreatedAt.After(alerts[j].CreatedAt)
+	})
+	return alerts, nil
+}
+
+// ListDueAlerts returns every alert whose next run is at or before the given time (used by the alert scheduler)
+func (f *FirestoreClient) ListDueAlerts(ctx context.Context, now time.Time) ([]models.JobAlert, error) {
+	return f.queryAlerts(f.collection(ctx, alertsCollection).Where("nextRunAt", "<=", now).Documents(ctx))
+}
+
+func (f *FirestoreClient) queryAlerts(iter *firestore.DocumentIterator) ([]models.JobAlert, error) {
+	defer iter.Stop()
+
+	alerts := make([]models.JobAlert, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query job alerts: %w", err)
+		}
+
+		var alert models.JobAlert
+		if err := doc.DataTo(&alert); err != nil {
+			return nil, fmt.Errorf("failed to parse job alert: %w", err)
+		}
+		alert.ID = doc.Ref.ID
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, nil
+}
+
+// DeleteAlert deletes one of a user's job alerts
+func (f *FirestoreClient) DeleteAlert(ctx context.Context, userID, id string) error {
+	docRef := f.collection(ctx, alertsCollection).Doc(id)
+	doc, err := docRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return ErrAlertNotFound
+		}
+		return fmt.Errorf("failed to get job alert: %w", err)
+	}
+
+	var alert models.JobAlert
+	if err := doc.DataTo(&alert); err != nil {
+		return fmt.Errorf("failed to parse job alert: %w", err)
+	}
+	if alert.UserID != userID {
+		return ErrAlertNotFound
+	}
+
+	if _, err := docRef.Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete job alert: %w", err)
+	}
+	return nil
+}
+
+// UpdateAlertRun records the result of a scheduled run: the posting URLs seen so far and when to run next
+func (f *FirestoreClient) UpdateAlertRun(ctx context.Context, id string, seenURLs []string, lastRunAt, nextRunAt time.Time) error {
+	docRef := f.collection(ctx, alertsCollection).Doc(id)
+	_, err := docRef.Set(ctx, map[string]interface{}{
+		"seenUrls":  seenURLs,
+		"lastRunAt": lastRunAt,
+		"nextRunAt": nextRunAt,
+	}, firestore.MergeAll)
+	if err != nil {
+		return fmt.Errorf("failed to update job alert: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+const apiTokensCollection = "api_tokens"
+
+// ErrAPITokenNotFound is returned when no token matches the given secret or ID
+var ErrAPITokenNotFound = errors.New("API token not found")
+
+// CreateAPIToken stores a new personal access token
+func (f *FirestoreClient) CreateAPIToken(ctx context.Context, token *models.APIToken) error {
+	token.CreatedAt = time.Now()
+
+	docRef := f.collection(ctx, apiTokensCollection).NewDoc()
+	if _, err := docRef.Set(ctx, token); err != nil {
+		return fmt.Errorf("failed to create API token: %w", err)
+	}
+
+	token.ID = docRef.ID
+	return nil
+}
+
+// GetAPITokenByHash looks up a personal access token by the hash of its secret
+func (f *FirestoreClient) GetAPITokenByHash(ctx context.Context, tokenHash string) (*models.APIToken, error) {
+	iter := f.collection(ctx, apiTokensCollection).Where("tokenHash", "==", tokenHash).Limit(1).Documents(ctx)
+	defer iter.Stop()
+
+	doc, err := iter.Next()
+	if err == iterator.Done {
+		return nil, ErrAPITokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API token: %w", err)
+	}
+
+	var token models.APIToken
+	if err := doc.DataTo(&token); err != nil {
+		return nil, fmt.Errorf("failed to parse API token: %w", err)
+	}
+
+	token.ID = doc.Ref.ID
+	return &token, nil
+}
+
+// ListAPITokens returns a user's personal access tokens
+func (f *FirestoreClient) ListAPITokens(ctx context.Context, userID string) ([]models.APIToken, error) {
+	iter := f.collection(ctx, apiTokensCollection).Where("userId", "==", userID).Documents(ctx)
+	defer iter.Stop()
+
+	tokens := make([]models.APIToken, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query API tokens: %w", err)
+		}
+
+		var token models.APIToken
+		if err := doc.DataTo(&token); err != nil {
+			return nil, fmt.Errorf("failed to parse API token: %w", err)
+		}
+		token.ID = doc.Ref.ID
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+// DeleteAPIToken revokes one of a user's tokens
+func (f *FirestoreClient) DeleteAPIToken(ctx context.Context, userID, id string) error {
+	docRef := f.collection(ctx, apiTokensCollection).Doc(id)
+
+	doc, err := docRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return ErrAPITokenNotFound
+		}
+		return fmt.Errorf("failed to get API token: %w", err)
+	}
+
+	var token models.APIToken
+	if err := doc.DataTo(&token); err != nil {
+		return fmt.Errorf("failed to parse API token: %w", err)
+	}
+	if token.UserID != userID {
+		return ErrAPITokenNotFound
+	}
+
+	if _, err := docRef.Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete API token: %w", err)
+	}
+	return nil
+}
+
+// TouchAPIToken records the time a token was last used
+func (f *FirestoreClient) TouchAPIToken(ctx context.Context, id string, usedAt time.Time) error {
+	_, err := f.collection(ctx, apiTokensCollection).Doc(id).Update(ctx, []firestore.Update{
+		{Path: "lastUsedAt", Value: usedAt},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update API token: %w", err)
+	}
+	return nil
+}
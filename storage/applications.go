@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+const applicationsCollection = "applications"
+
+// ErrApplicationNotFound is returned when an application does not exist or belongs to another user
+var ErrApplicationNotFound = errors.New("application not found")
+
+// CreateApplication stores a new tracked application
+func (f *FirestoreClient) CreateApplication(ctx context.Context, app *models.Application) error {
+	now := time.Now()
+	app.CreatedAt = now
+	app.UpdatedAt = now
+
+	docRef := f.collection(ctx, applicationsCollection).NewDoc()
+	if _, err := docRef.Set(ctx, app); err != nil {
+		return fmt.Errorf("failed to create application: %w", err)
+	}
+
+	app.ID = docRef.ID
+	return nil
+}
+
+// GetApplication returns one of a user's applications
+func (f *FirestoreClient) GetApplication(ctx context.Context, userID, id string) (*models.Application, error) {
+	doc, err := f.collection(ctx, applicationsCollection).Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrApplicationNotFound
+		}
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+
+	var app models.Application
+	if err := doc.DataTo(&app); err != nil {
+		return nil, fmt.Errorf("failed to parse application: %w", err)
+	}
+	if app.UserID != userID {
+		return nil, ErrApplicationNotFound
+	}
+
+	app.ID = doc.Ref.ID
+	return &app, nil
+}
+
+// ListApplications returns a user's applications, most recently updated first
+func (f *FirestoreClient) ListApplications(ctx context.Context, userID string) ([]models.Application, error) {
+	iter := f.collection(ctx, applicationsCollection).Where("userId", "==", userID).Documents(ctx)
+	defer iter.Stop()
+
+	apps := make([]models.Application, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query applications: %w", err)
+		}
+
+		var app models.Application
+		if err := doc.DataTo(&app); err != nil {
+			return nil, fmt.Errorf("failed to parse application: %w", err)
+		}
+		app.ID = doc.Ref.ID
+		apps = append(apps, app)
+	}
+
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].UpdatedAt.After(apps[j].UpdatedAt)
+	})
+
+	return apps, nil
+}
+
+// SaveApplication overwrites an existing application
+func (f *FirestoreClient) SaveApplication(ctx context.Context, app *models.Application) error {
+	app.UpdatedAt = time.Now()
+
+	if _, err := f.collection(ctx, applicationsCollection).Doc(app.ID).Set(ctx, app); err != nil {
+		return fmt.Errorf("failed to save application: %w", err)
+	}
+	return nil
+}
+
+// DeleteApplication deletes one of a user's applications
+func (f *FirestoreClient) DeleteApplication(ctx context.Context, userID, id string) error {
+	if _, err := f.GetApplication(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if _, err := f.collection(ctx, applicationsCollection).Doc(id).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete application: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"google.golang.org/api/iterator"
+)
+
+// ErrNotBackedUp is returned when restoring a collection backups don't hold
+var ErrNotBackedUp = errors.New("collection is not backed up")
+
+// BackupDocument is one document of a backup, written as a line of JSONL. Data is the document as the
+// backend stores it, so a backup restores into the backend it was taken from.
+type BackupDocument struct {
+	ID   string          `json:"id"`
+	Data json.RawMessage `json:"data"`
+}
+
+// firestoreBackupCollections are the collections Firestore backups hold: users with the email and Google
+// account indexes that find them, structured profiles and the job corpus
+var firestoreBackupCollections = []string{usersCollection, userEmailsCollection, googleIDsCollection, profilesCollection, jobsCollection}
+
+// postgresBackupCollections are the tables Postgres backups hold; users need no index documents there
+var postgresBackupCollections = []string{usersCollection, profilesCollection, jobsCollection}
+
+// Typed values in Firestore backups, which JSON can't tell apart from strings
+const (
+	backupTimeKey  = "$time"
+	backupBytesKey = "$bytes"
+)
+
+// BackupCollections returns the collections backups hold
+func (f *FirestoreClient) BackupCollections() []string {
+	return firestoreBackupCollections
+}
+
+// ExportDocuments calls fn with every document of a collection, encoding timestamps and bytes so they
+// restore with their types
+func (f *FirestoreClient) ExportDocuments(ctx context.Context, collection string, fn func(BackupDocument) error) error {
+	if !slices.Contains(firestoreBackupCollections, collection) {
+		return ErrNotBackedUp
+	}
+
+	iter := f.collection(ctx, collection).Documents(ctx)
+	defer iter.Stop()
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", collection, err)
+		}
+
+		data, err := json.Marshal(encodeBackupValue(doc.Data()))
+		if err != nil {
+			return fmt.Errorf("failed to encode %s/%s: %w", collection, doc.Ref.ID, err)
+		}
+		if err := fn(BackupDocument{ID: doc.Ref.ID, Data: data}); err != nil {
+			return err
+		}
+	}
+}
+
+// RestoreDocuments writes backed-up documents to a collection, replacing documents with the same IDs
+func (f *FirestoreClient) RestoreDocuments(ctx context.Context, collection string, docs []BackupDocument) error {
+	if !slices.Contains(firestoreBackupCollections, collection) {
+		return ErrNotBackedUp
+	}
+
+	for start := 0; start < len(docs); start += firestoreMaxBatchWrites {
+		batch := f.client.Batch()
+		for _, doc := range docs[start:min(start+firestoreMaxBatchWrites, len(docs))] {
+			data, err := decodeBackupDocument(doc.Data)
+			if err != nil {
+				return fmt.Errorf("failed to decode %s/%s: %w", collection, doc.ID, err)
+			}
+			batch.Set(f.collection(ctx, collection).Doc(doc.ID), data)
+		}
+		if _, err := batch.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", collection, err)
+		}
+	}
+	return nil
+}
+
+// encodeBackupValue replaces the timestamps and bytes in a Firestore value with tagged objects
+func encodeBackupValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case time.Time:
+		return map[string]interface{}{backupTimeKey: v.UTC().Format(time.RFC3339Nano)}
+	case []byte:
+		return map[string]interface{}{backupBytesKey: base64.StdEncoding.EncodeToString(v)}
+	case map[string]interface{}:
+		encoded := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			encoded[key] = encodeBackupValue(value)
+		}
+		return encoded
+	case []interface{}:
+		encoded := make([]interface{}, len(v))
+		for i, value := range v {
+			encoded[i] = encodeBackupValue(value)
+		}
+		return encoded
+	}
+	return v
+}
+
+// decodeBackupDocument parses a document encoded by encodeBackupValue, keeping integers as int64
+func decodeBackupDocument(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc map[string]interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	decoded, err := decodeBackupValue(doc)
+	if err != nil {
+		return nil, err
+	}
+	return decoded.(map[string]interface{}), nil
+}
+
+func decodeBackupValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n, nil
+		}
+		return v.Float64()
+	case map[string]interface{}:
+		if len(v) == 1 {
+			if s, ok := v[backupTimeKey].(string); ok {
+				return time.Parse(time.RFC3339Nano, s)
+			}
+			if s, ok := v[backupBytesKey].(string); ok {
+				return base64.StdEncoding.DecodeString(s)
+			}
+		}
+		decoded := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			d, err := decodeBackupValue(value)
+			if err != nil {
+				return nil, err
+			}
+			decoded[key] = d
+		}
+		return decoded, nil
+	case []interface{}:
+		decoded := make([]interface{}, len(v))
+		for i, value := range v {
+			d, err := decodeBackupValue(value)
+			if err != nil {
+				return nil, err
+			}
+			decoded[i] = d
+		}
+		return decoded, nil
+	}
+	return v, nil
+}
+
+// BackupCollections returns the tables backups hold
+func (p *PostgresClient) BackupCollections() []string {
+	return postgresBackupCollections
+}
+
+// ExportDocuments calls fn with every row of a table, its data as stored
+func (p *PostgresClient) ExportDocuments(ctx context.Context, collection string, fn func(BackupDocument) error) error {
+	if !slices.Contains(postgresBackupCollections, collection) {
+		return ErrNotBackedUp
+	}
+
+	err := p.queryDocs(ctx, fmt.Sprintf(`SELECT id, data FROM %s`, collection), nil, func(id string, data []byte) error {
+		return fn(BackupDocument{ID: id, Data: data})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", collection, err)
+	}
+	return nil
+}
+
+// RestoreDocuments writes backed-up rows to a table, replacing rows with the same IDs
+func (p *PostgresClient) RestoreDocuments(ctx context.Context, collection string, docs []BackupDocument) error {
+	if !slices.Contains(postgresBackupCollections, collection) {
+		return ErrNotBackedUp
+	}
+
+	pool, err := p.db(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s: %w", collection, err)
+	}
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s: %w", collection, err)
+	}
+	defer tx.Rollback(ctx)
+
+	statement := fmt.Sprintf(`INSERT INTO %s (id, data) VALUES ($1, $2)
+		ON CONFLICT (id) DO UPDATE SET data = EXCLUDED.data`, collection)
+	for _, doc := range docs {
+		if _, err := tx.Exec(ctx, statement, doc.ID, []byte(doc.Data)); err != nil {
+			return fmt.Errorf("failed to restore %s/%s: %w", collection, doc.ID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", collection, err)
+	}
+	return nil
+}
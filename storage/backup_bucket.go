@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	"github.com/myjobmatch/backend/config"
+)
+
+// ErrBackupObjectNotFound is returned when reading a backup file that doesn't exist
+var ErrBackupObjectNotFound = errors.New("backup object not found")
+
+// BackupBucket holds database backups in the Cloud Storage bucket BACKUP_BUCKET_NAME
+type BackupBucket struct {
+	client     *storage.Client
+	bucketName string
+}
+
+// NewBackupBucket creates a client for the backup bucket
+func NewBackupBucket(ctx context.Context, cfg *config.Config) (*BackupBucket, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cloud Storage client: %w", err)
+	}
+	// Backup files can take longer than GCS_TIMEOUT_SECONDS to transfer, so only the retries apply
+	newGCSCallPolicy(cfg).applyRetry(client)
+
+	return &BackupBucket{
+		client:     client,
+		bucketName: cfg.BackupBucketName,
+	}, nil
+}
+
+// Close closes the Cloud Storage client
+func (b *BackupBucket) Close() error {
+	return b.client.Close()
+}
+
+// NewWriter returns a writer creating an object; the object only exists once the writer is closed
+func (b *BackupBucket) NewWriter(ctx context.Context, name, contentType string) io.WriteCloser {
+	wc := b.client.Bucket(b.bucketName).Object(name).NewWriter(ctx)
+	wc.ContentType = contentType
+	return wc
+}
+
+// NewReader opens an object, or returns ErrBackupObjectNotFound
+func (b *BackupBucket) NewReader(ctx context.Context, name string) (io.ReadCloser, error) {
+	reader, err := b.client.Bucket(b.bucketName).Object(name).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrBackupObjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return reader, nil
+}
+
+// List returns the names of the objects under prefix
+func (b *BackupBucket) List(ctx context.Context, prefix string) ([]string, error) {
+	it := b.client.Bucket(b.bucketName).Objects(ctx, &storage.Query{Prefix: prefix})
+
+	names := make([]string, 0)
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list backups: %w", err)
+		}
+		names = append(names, attrs.Name)
+	}
+	return names, nil
+}
+
+// Delete deletes an object
+func (b *BackupBucket) Delete(ctx context.Context, name string) error {
+	err := b.client.Bucket(b.bucketName).Object(name).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("failed to delete %s: %w", name, err)
+	}
+	return nil
+}
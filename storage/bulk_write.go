@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+)
+
+// docWrite is a document set by bulkSet
+type docWrite struct {
+	ref  *firestore.DocumentRef
+	data interface{}
+	opts []firestore.SetOption
+}
+
+// bulkSet writes documents with a BulkWriter, which sends them in parallel batches of 20 and retries writes
+// failing with transient errors (contention, unavailable, quota) with backoff, so persisting a search's jobs
+// takes about as long as one batch however many there are. Writes are not atomic; it returns the first
+// error along with how many writes failed.
+func (f *FirestoreClient) bulkSet(ctx context.Context, writes []docWrite) error {
+	if len(writes) == 0 {
+		return nil
+	}
+
+	bw := f.client.BulkWriter(ctx)
+	jobs := make([]*firestore.BulkWriterJob, 0, len(writes))
+	var firstErr error
+	failed := 0
+	for _, w := range writes {
+		job, err := bw.Set(w.ref, w.data, w.opts...)
+		if err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	bw.End()
+
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if firstErr != nil {
+		return fmt.Errorf("%d of %d writes failed: %w", failed, len(writes), firstErr)
+	}
+	return nil
+}
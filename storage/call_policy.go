@@ -0,0 +1,242 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"log"
+	"path"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/config"
+)
+
+// maxStorageRetryWait caps the backoff between retries of a Firestore or Cloud Storage call
+const maxStorageRetryWait = 5 * time.Second
+
+// firestoreWriteMethods are the Firestore RPCs that aren't retried after timing out, since the write may
+// have been applied
+var firestoreWriteMethods = map[string]bool{
+	"/google.firestore.v1.Firestore/Commit":     true,
+	"/google.firestore.v1.Firestore/BatchWrite": true,
+}
+
+// firestoreQueryMethods are the server-streaming Firestore RPCs behind document reads and queries
+var firestoreQueryMethods = map[string]bool{
+	"/google.firestore.v1.Firestore/BatchGetDocuments":   true,
+	"/google.firestore.v1.Firestore/RunQuery":            true,
+	"/google.firestore.v1.Firestore/RunAggregationQuery": true,
+}
+
+// callPolicy bounds how long a storage call may hang and retries it when it fails transiently, so one
+// stuck call doesn't stall a request until the server times it out
+type callPolicy struct {
+	timeout    time.Duration // Per attempt; 0 for none
+	maxRetries int
+	retryBase  time.Duration
+}
+
+// backoff returns how long to wait before retry attempt+1
+func (p callPolicy) backoff(attempt int) time.Duration {
+	return min(p.retryBase<<attempt, maxStorageRetryWait)
+}
+
+// wait sleeps before retry attempt+1, returning early with the context's error if it is done
+func (p callPolicy) wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(p.backoff(attempt))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// withTimeout bounds ctx by the policy's timeout, if it has one
+func (p callPolicy) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, p.timeout)
+}
+
+// newFirestoreCallPolicy reads the Firestore call timeout and retries from config
+func newFirestoreCallPolicy(cfg *config.Config) callPolicy {
+	return callPolicy{
+		timeout:    time.Duration(cfg.FirestoreTimeoutSeconds) * time.Second,
+		maxRetries: cfg.StorageMaxRetries,
+		retryBase:  time.Duration(cfg.StorageRetryBaseMs) * time.Millisecond,
+	}
+}
+
+// newGCSCallPolicy reads the Cloud Storage operation timeout and retries from config
+func newGCSCallPolicy(cfg *config.Config) callPolicy {
+	return callPolicy{
+		timeout:    time.Duration(cfg.GCSTimeoutSeconds) * time.Second,
+		maxRetries: cfg.StorageMaxRetries,
+		retryBase:  time.Duration(cfg.StorageRetryBaseMs) * time.Millisecond,
+	}
+}
+
+// applyRetry sets the client's retry backoff and attempts. Only idempotent operations are retried, as by default.
+func (p callPolicy) applyRetry(client *storage.Client) {
+	client.SetRetry(
+		storage.WithBackoff(gax.Backoff{Initial: p.retryBase, Max: maxStorageRetryWait, Multiplier: 2}),
+		storage.WithMaxAttempts(p.maxRetries+1),
+	)
+}
+
+// firestoreOptions returns the client options applying the policy to every call of a Firestore client,
+// including those made inside transactions and by the BulkWriter
+func (p callPolicy) firestoreOptions() []option.ClientOption {
+	return []option.ClientOption{
+		option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(p.interceptUnary)),
+		option.WithGRPCDialOption(grpc.WithChainStreamInterceptor(p.interceptStream)),
+	}
+}
+
+// firestoreRetryable reports whether a failed Firestore call may succeed if retried
+func firestoreRetryable(ctx context.Context, method string, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	case codes.DeadlineExceeded:
+		return !firestoreWriteMethods[method]
+	}
+	return false
+}
+
+// interceptUnary times out each attempt of a unary call and retries transient failures
+func (p callPolicy) interceptUnary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	for attempt := 0; ; attempt++ {
+		attemptCtx, cancel := p.withTimeout(ctx)
+		err := invoker(attemptCtx, method, req, reply, cc, opts...)
+		cancel()
+		if err == nil || attempt >= p.maxRetries || !firestoreRetryable(ctx, method, err) {
+			return err
+		}
+
+		log.Printf("[Firestore] %s failed (%v), retry %d/%d", path.Base(method), err, attempt+1, p.maxRetries)
+		if p.wait(ctx, attempt) != nil {
+			return err
+		}
+	}
+}
+
+// interceptStream bounds the reads and queries; other streams, such as listeners, are long-lived and
+// left alone
+func (p callPolicy) interceptStream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if !firestoreQueryMethods[method] {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+
+	s := &boundedStream{
+		policy: p,
+		ctx:    ctx,
+		method: method,
+		open: func(ctx context.Context) (grpc.ClientStream, error) {
+			return streamer(ctx, desc, cc, method, opts...)
+		},
+	}
+	if err := s.start(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// boundedStream is a read or query stream whose every result must arrive within the policy's timeout,
+// however long the whole stream takes. Until its first result arrives, a failed stream is reopened with
+// the same request.
+type boundedStream struct {
+	grpc.ClientStream
+	policy   callPolicy
+	ctx      context.Context
+	method   string
+	open     func(ctx context.Context) (grpc.ClientStream, error)
+	cancel   context.CancelFunc
+	req      interface{} // Sent again when the stream is reopened
+	received bool
+}
+
+// start opens a new stream
+func (s *boundedStream) start() error {
+	ctx, cancel := context.WithCancel(s.ctx)
+	stream, err := s.open(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+	s.ClientStream, s.cancel = stream, cancel
+	return nil
+}
+
+// SendMsg sends the request, remembering it
+func (s *boundedStream) SendMsg(m interface{}) error {
+	s.req = m
+	return s.ClientStream.SendMsg(m)
+}
+
+// RecvMsg receives the next result, retrying the stream if the first one fails transiently
+func (s *boundedStream) RecvMsg(m interface{}) error {
+	for attempt := 0; ; attempt++ {
+		err := s.recv(m)
+		if err == nil {
+			s.received = true
+			return nil
+		}
+		if err == io.EOF || s.received || attempt >= s.policy.maxRetries || !firestoreRetryable(s.ctx, s.method, err) {
+			s.cancel()
+			return err
+		}
+
+		log.Printf("[Firestore] %s failed (%v), retry %d/%d", path.Base(s.method), err, attempt+1, s.policy.maxRetries)
+		if s.policy.wait(s.ctx, attempt) != nil {
+			s.cancel()
+			return err
+		}
+		if err := s.restart(); err != nil {
+			return err
+		}
+	}
+}
+
+// recv waits up to the timeout for the next result, reporting a timeout as DeadlineExceeded
+func (s *boundedStream) recv(m interface{}) error {
+	if s.policy.timeout <= 0 {
+		return s.ClientStream.RecvMsg(m)
+	}
+
+	timer := time.AfterFunc(s.policy.timeout, s.cancel)
+	err := s.ClientStream.RecvMsg(m)
+	if !timer.Stop() && s.ctx.Err() == nil {
+		return status.Errorf(codes.DeadlineExceeded, "no result within %s", s.policy.timeout)
+	}
+	return err
+}
+
+// restart replaces the stream with a new one sending the same request
+func (s *boundedStream) restart() error {
+	s.cancel()
+	if err := s.start(); err != nil {
+		return err
+	}
+	if err := s.ClientStream.SendMsg(s.req); err != nil {
+		s.cancel()
+		return err
+	}
+	if err := s.ClientStream.CloseSend(); err != nil {
+		s.cancel()
+		return err
+	}
+	return nil
+}
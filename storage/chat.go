@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// chatSessionsCollection holds career assistant conversations.
+// Configure a Firestore TTL policy on expiresAt to purge idle conversations automatically.
+const chatSessionsCollection = "chat_sessions"
+
+// ErrChatSessionNotFound is returned when a conversation doesn't exist or belongs to another user
+var ErrChatSessionNotFound = errors.New("chat session not found")
+
+// SaveChatSession creates or replaces a conversation, assigning an ID to new ones.
+// The conversation expires ttl after this update.
+func (f *FirestoreClient) SaveChatSession(ctx context.Context, session *models.ChatSession, ttl time.Duration) error {
+	now := time.Now()
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = now
+	}
+	session.UpdatedAt = now
+	session.ExpiresAt = now.Add(ttl)
+
+	collection := f.collection(ctx, chatSessionsCollection)
+	docRef := collection.NewDoc()
+	if session.ID != "" {
+		docRef = collection.Doc(session.ID)
+	}
+	if _, err := docRef.Set(ctx, session); err != nil {
+		return fmt.Errorf("failed to save chat session: %w", err)
+	}
+
+	session.ID = docRef.ID
+	return nil
+}
+
+// GetChatSession returns a user's conversation
+func (f *FirestoreClient) GetChatSession(ctx context.Context, userID, id string) (*models.ChatSession, error) {
+	doc, err := f.collection(ctx, chatSessionsCollection).Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrChatSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get chat session: %w", err)
+	}
+
+	var session models.ChatSession
+	if err := doc.DataTo(&session); err != nil {
+		return nil, fmt.Errorf("failed to parse chat session: %w", err)
+	}
+	if session.UserID != userID || time.Now().After(session.ExpiresAt) {
+		return nil, ErrChatSessionNotFound
+	}
+
+	session.ID = doc.Ref.ID
+	return &session, nil
+}
@@ -2,22 +2,34 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
 
 	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/utils"
 )
 
+// ErrCVNotFound is returned when a CV doesn't exist or isn't in the user's folder
+var ErrCVNotFound = errors.New("CV not found")
+
 // CloudStorageClient wraps Google Cloud Storage operations
 type CloudStorageClient struct {
 	client     *storage.Client
 	bucketName string
+	kmsKeyName string // Cloud KMS key for CV objects; empty uses the bucket default
+	calls      callPolicy
 }
 
 // NewCloudStorageClient creates a new Cloud Storage client
@@ -26,10 +38,14 @@ func NewCloudStorageClient(ctx context.Context, cfg *config.Config) (*CloudStora
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Cloud Storage client: %w", err)
 	}
+	calls := newGCSCallPolicy(cfg)
+	calls.applyRetry(client)
 
 	return &CloudStorageClient{
 		client:     client,
 		bucketName: cfg.CVBucketName,
+		kmsKeyName: cfg.CVKMSKeyName,
+		calls:      calls,
 	}, nil
 }
 
@@ -38,17 +54,27 @@ func (c *CloudStorageClient) Close() error {
 	return c.client.Close()
 }
 
+// Ping lists at most one CV, checking that the bucket is reachable with the permissions ListCVs needs
+func (c *CloudStorageClient) Ping(ctx context.Context) error {
+	ctx, cancel := c.calls.withTimeout(ctx)
+	defer cancel()
+
+	it := c.client.Bucket(c.bucketName).Objects(ctx, &storage.Query{Prefix: cvPrefix})
+	it.PageInfo().MaxSize = 1
+	if _, err := it.Next(); err != nil && err != iterator.Done {
+		return fmt.Errorf("failed to reach Cloud Storage: %w", err)
+	}
+	return nil
+}
+
 // UploadCV uploads a CV file to Cloud Storage
-func (c *CloudStorageClient) UploadCV(ctx context.Context, userEmail string, file multipart.File, header *multipart.FileHeader) (string, error) {
+func (c *CloudStorageClient) UploadCV(ctx context.Context, userID string, file multipart.File, header *multipart.FileHeader) (string, error) {
+	ctx, cancel := c.calls.withTimeout(ctx)
+	defer cancel()
+
 	// Generate unique filename
 	ext := filepath.Ext(header.Filename)
-	timestamp := time.Now().Unix()
-
-	// Sanitize email for use in path
-	sanitizedEmail := strings.ReplaceAll(userEmail, "@", "_at_")
-	sanitizedEmail = strings.ReplaceAll(sanitizedEmail, ".", "_")
-
-	objectName := fmt.Sprintf("cvs/%s/%d%s", sanitizedEmail, timestamp, ext)
+	objectName := cvObjectName(ctx, userID, header.Filename)
 
 	// Get bucket handle
 	bucket := c.client.Bucket(c.bucketName)
@@ -56,6 +82,7 @@ func (c *CloudStorageClient) UploadCV(ctx context.Context, userEmail string, fil
 
 	// Create writer
 	wc := obj.NewWriter(ctx)
+	wc.KMSKeyName = c.kmsKeyName
 	wc.ContentType = header.Header.Get("Content-Type")
 	if wc.ContentType == "" {
 		wc.ContentType = getContentType(ext)
@@ -79,19 +106,18 @@ func (c *CloudStorageClient) UploadCV(ctx context.Context, userEmail string, fil
 }
 
 // UploadCVFromBytes uploads CV content from bytes
-func (c *CloudStorageClient) UploadCVFromBytes(ctx context.Context, userEmail string, content []byte, filename string) (string, error) {
-	ext := filepath.Ext(filename)
-	timestamp := time.Now().Unix()
-
-	sanitizedEmail := strings.ReplaceAll(userEmail, "@", "_at_")
-	sanitizedEmail = strings.ReplaceAll(sanitizedEmail, ".", "_")
+func (c *CloudStorageClient) UploadCVFromBytes(ctx context.Context, userID string, content []byte, filename string) (string, error) {
+	ctx, cancel := c.calls.withTimeout(ctx)
+	defer cancel()
 
-	objectName := fmt.Sprintf("cvs/%s/%d%s", sanitizedEmail, timestamp, ext)
+	ext := filepath.Ext(filename)
+	objectName := cvObjectName(ctx, userID, filename)
 
 	bucket := c.client.Bucket(c.bucketName)
 	obj := bucket.Object(objectName)
 
 	wc := obj.NewWriter(ctx)
+	wc.KMSKeyName = c.kmsKeyName
 	wc.ContentType = getContentType(ext)
 
 	if _, err := wc.Write(content); err != nil {
@@ -107,8 +133,53 @@ func (c *CloudStorageClient) UploadCVFromBytes(ctx context.Context, userEmail st
 	return url, nil
 }
 
+// UploadPhoto uploads a processed JPEG profile photo
+func (c *CloudStorageClient) UploadPhoto(ctx context.Context, userID string, content []byte) (string, error) {
+	ctx, cancel := c.calls.withTimeout(ctx)
+	defer cancel()
+
+	objectName := photoObjectName(ctx, userID)
+
+	wc := c.client.Bucket(c.bucketName).Object(objectName).NewWriter(ctx)
+	wc.ContentType = "image/jpeg"
+	wc.CacheControl = "public, max-age=86400"
+
+	if _, err := wc.Write(content); err != nil {
+		wc.Close()
+		return "", fmt.Errorf("failed to write photo: %w", err)
+	}
+
+	if err := wc.Close(); err != nil {
+		return "", fmt.Errorf("failed to close writer: %w", err)
+	}
+
+	url := fmt.Sprintf("https://storage.googleapis.com/%s/%s", c.bucketName, objectName)
+	return url, nil
+}
+
+// DeletePhoto deletes a profile photo from Cloud Storage
+func (c *CloudStorageClient) DeletePhoto(ctx context.Context, photoUrl string) error {
+	ctx, cancel := c.calls.withTimeout(ctx)
+	defer cancel()
+
+	prefix := fmt.Sprintf("https://storage.googleapis.com/%s/", c.bucketName)
+	if !strings.HasPrefix(photoUrl, prefix) {
+		return fmt.Errorf("invalid photo URL format")
+	}
+
+	obj := c.client.Bucket(c.bucketName).Object(strings.TrimPrefix(photoUrl, prefix))
+	if err := obj.Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete photo: %w", err)
+	}
+
+	return nil
+}
+
 // DeleteCV deletes a CV file from Cloud Storage
 func (c *CloudStorageClient) DeleteCV(ctx context.Context, cvUrl string) error {
+	ctx, cancel := c.calls.withTimeout(ctx)
+	defer cancel()
+
 	// Extract object name from URL
 	prefix := fmt.Sprintf("https://storage.googleapis.com/%s/", c.bucketName)
 	if !strings.HasPrefix(cvUrl, prefix) {
@@ -127,6 +198,140 @@ func (c *CloudStorageClient) DeleteCV(ctx context.Context, cvUrl string) error {
 	return nil
 }
 
+// ListCVs returns every CV object of the context's tenant in the bucket
+func (c *CloudStorageClient) ListCVs(ctx context.Context) ([]CVObject, error) {
+	ctx, cancel := c.calls.withTimeout(ctx)
+	defer cancel()
+
+	it := c.client.Bucket(c.bucketName).Objects(ctx, &storage.Query{Prefix: cvFolder(ctx)})
+
+	objects := make([]CVObject, 0)
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list CVs: %w", err)
+		}
+
+		objects = append(objects, CVObject{
+			URL:       fmt.Sprintf("https://storage.googleapis.com/%s/%s", c.bucketName, attrs.Name),
+			Owner:     cvOwner(ctx, attrs.Name),
+			CreatedAt: attrs.Created,
+		})
+	}
+	return objects, nil
+}
+
+// ApplyLifecycleRules sets the bucket's lifecycle rules for CV and photo objects, including those of every
+// tenant: noncurrent versions (kept when object versioning is on) are deleted noncurrentDays after being
+// replaced or deleted, and incomplete multipart uploads are aborted after a day. Rules for other prefixes
+// are kept.
+func (c *CloudStorageClient) ApplyLifecycleRules(ctx context.Context, noncurrentDays int) error {
+	ctx, cancel := c.calls.withTimeout(ctx)
+	defer cancel()
+
+	bucket := c.client.Bucket(c.bucketName)
+	attrs, err := bucket.Attrs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read bucket attributes: %w", err)
+	}
+
+	managed := []string{cvPrefix, photoPrefix, tenantsPrefix}
+	rules := make([]storage.LifecycleRule, 0, len(attrs.Lifecycle.Rules)+2)
+	for _, rule := range attrs.Lifecycle.Rules {
+		// Rules set before tenants existed cover only the CV and photo prefixes
+		if !slices.Equal(rule.Condition.MatchesPrefix, managed) && !slices.Equal(rule.Condition.MatchesPrefix, managed[:2]) {
+			rules = append(rules, rule)
+		}
+	}
+	rules = append(rules,
+		storage.LifecycleRule{
+			Action: storage.LifecycleAction{Type: storage.DeleteAction},
+			Condition: storage.LifecycleCondition{
+				Liveness:                storage.Archived,
+				DaysSinceNoncurrentTime: int64(noncurrentDays),
+				MatchesPrefix:           managed,
+			},
+		},
+		storage.LifecycleRule{
+			Action: storage.LifecycleAction{Type: storage.AbortIncompleteMPUAction},
+			Condition: storage.LifecycleCondition{
+				AgeInDays:     1,
+				MatchesPrefix: managed,
+			},
+		},
+	)
+
+	if _, err := bucket.Update(ctx, storage.BucketAttrsToUpdate{Lifecycle: &storage.Lifecycle{Rules: rules}}); err != nil {
+		return fmt.Errorf("failed to update bucket lifecycle: %w", err)
+	}
+	return nil
+}
+
+// CreateCVUploadURL returns a signed URL that starts a resumable upload of a new CV for the user.
+// The bucket rejects uploads larger than maxBytes.
+func (c *CloudStorageClient) CreateCVUploadURL(ctx context.Context, userID, filename string, maxBytes int64, expires time.Duration) (*CVUploadTarget, error) {
+	objectName := cvObjectName(ctx, userID, filename)
+	headers := map[string]string{
+		"Content-Type":                getContentType(filepath.Ext(filename)),
+		"x-goog-resumable":            "start",
+		"x-goog-content-length-range": fmt.Sprintf("0,%d", maxBytes),
+	}
+	signedHeaders := []string{
+		"x-goog-resumable:" + headers["x-goog-resumable"],
+		"x-goog-content-length-range:" + headers["x-goog-content-length-range"],
+	}
+	if c.kmsKeyName != "" {
+		headers["x-goog-encryption-kms-key-name"] = c.kmsKeyName
+		signedHeaders = append(signedHeaders, "x-goog-encryption-kms-key-name:"+c.kmsKeyName)
+	}
+	expiresAt := time.Now().Add(expires)
+
+	opts := &storage.SignedURLOptions{
+		Scheme:      storage.SigningSchemeV4,
+		Method:      http.MethodPost,
+		Expires:     expiresAt,
+		ContentType: headers["Content-Type"],
+		Headers:     signedHeaders,
+	}
+	uploadURL, err := c.client.Bucket(c.bucketName).SignedURL(objectName, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign upload URL: %w", err)
+	}
+
+	return &CVUploadTarget{
+		UploadURL: uploadURL,
+		Headers:   headers,
+		CVUrl:     fmt.Sprintf("https://storage.googleapis.com/%s/%s", c.bucketName, objectName),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// CVSize returns the size of one of the user's uploaded CVs
+func (c *CloudStorageClient) CVSize(ctx context.Context, userID, cvUrl string) (int64, error) {
+	ctx, cancel := c.calls.withTimeout(ctx)
+	defer cancel()
+
+	// Only objects directly in the user's own folder are theirs; folders are one-to-one with user IDs
+	prefix := fmt.Sprintf("https://storage.googleapis.com/%s/", c.bucketName)
+	objectName := strings.TrimPrefix(cvUrl, prefix)
+	if !strings.HasPrefix(cvUrl, prefix) || path.Dir(objectName) != cvFolder(ctx)+userFolder(userID) ||
+		strings.Contains(objectName, "..") {
+		return 0, ErrCVNotFound
+	}
+
+	attrs, err := c.client.Bucket(c.bucketName).Object(objectName).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return 0, ErrCVNotFound
+		}
+		return 0, fmt.Errorf("failed to read CV attributes: %w", err)
+	}
+	return attrs.Size, nil
+}
+
 // GetSignedURL generates a signed URL for temporary access
 func (c *CloudStorageClient) GetSignedURL(ctx context.Context, objectName string, expiration time.Duration) (string, error) {
 	opts := &storage.SignedURLOptions{
@@ -145,6 +350,9 @@ func (c *CloudStorageClient) GetSignedURL(ctx context.Context, objectName string
 
 // DownloadCV downloads CV content
 func (c *CloudStorageClient) DownloadCV(ctx context.Context, cvUrl string) ([]byte, error) {
+	ctx, cancel := c.calls.withTimeout(ctx)
+	defer cancel()
+
 	prefix := fmt.Sprintf("https://storage.googleapis.com/%s/", c.bucketName)
 	if !strings.HasPrefix(cvUrl, prefix) {
 		return nil, fmt.Errorf("invalid CV URL format")
@@ -169,6 +377,50 @@ func (c *CloudStorageClient) DownloadCV(ctx context.Context, cvUrl string) ([]by
 	return data, nil
 }
 
+// Object name prefixes of CV and profile photo uploads. Uploads of tenants other than the default one are
+// kept under tenants/<id>/.
+const (
+	cvPrefix      = "cvs/"
+	photoPrefix   = "photos/"
+	tenantsPrefix = "tenants/"
+)
+
+// TenantPrefix returns the object name prefix of the context's tenant: "" for the default tenant,
+// else tenants/<id>/
+func TenantPrefix(ctx context.Context) string {
+	if tenantID := utils.TenantFromContext(ctx); tenantID != "" {
+		return tenantsPrefix + tenantID + "/"
+	}
+	return ""
+}
+
+// cvFolder returns the prefix of the context's tenant's CV objects
+func cvFolder(ctx context.Context) string {
+	return TenantPrefix(ctx) + cvPrefix
+}
+
+// cvObjectName names a CV upload: [tenants/<id>/]cvs/<user>/<unix time><extension of filename>
+func cvObjectName(ctx context.Context, userID, filename string) string {
+	return fmt.Sprintf("%s%s/%d%s", cvFolder(ctx), userFolder(userID), time.Now().Unix(), filepath.Ext(filename))
+}
+
+// cvOwner returns the user folder of one of the context's tenant's CV object names
+func cvOwner(ctx context.Context, objectName string) string {
+	owner, _, _ := strings.Cut(strings.TrimPrefix(objectName, cvFolder(ctx)), "/")
+	return owner
+}
+
+// photoObjectName names a profile photo upload: [tenants/<id>/]photos/<user>/<unix time>.jpg
+func photoObjectName(ctx context.Context, userID string) string {
+	return fmt.Sprintf("%s%s%s/%d.jpg", TenantPrefix(ctx), photoPrefix, userFolder(userID), time.Now().Unix())
+}
+
+// userFolder names the CV and photo folders of a user after their ID. The escaping is one-to-one, so no two
+// users share a folder.
+func userFolder(userID string) string {
+	return url.PathEscape(userID)
+}
+
 func getContentType(ext string) string {
 	switch strings.ToLower(ext) {
 	case ".pdf":
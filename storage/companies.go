@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+const companiesCollection = "companies"
+
+// cachedCompany is researched company information stored by normalized company name.
+// Configure a Firestore TTL policy on expiresAt to purge expired entries automatically.
+type cachedCompany struct {
+	Key       string         `firestore:"key"`
+	Company   models.Company `firestore:"company"`
+	CachedAt  time.Time      `firestore:"cachedAt"`
+	ExpiresAt time.Time      `firestore:"expiresAt"`
+}
+
+// GetCachedCompanies returns the unexpired company information for the given keys
+func (f *FirestoreClient) GetCachedCompanies(ctx context.Context, keys []string) (map[string]models.Company, error) {
+	if len(keys) == 0 {
+		return map[string]models.Company{}, nil
+	}
+
+	refs := make([]*firestore.DocumentRef, len(keys))
+	for i, key := range keys {
+		refs[i] = f.collection(ctx, companiesCollection).Doc(hashDocID(key))
+	}
+
+	docs, err := f.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read company cache: %w", err)
+	}
+
+	now := time.Now()
+	companies := make(map[string]models.Company, len(docs))
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+
+		var entry cachedCompany
+		if err := doc.DataTo(&entry); err != nil {
+			return nil, fmt.Errorf("failed to parse cached company: %w", err)
+		}
+		if now.After(entry.ExpiresAt) {
+			continue
+		}
+		companies[entry.Key] = entry.Company
+	}
+
+	return companies, nil
+}
+
+// CacheCompanies stores company information by key, replacing any existing entries
+func (f *FirestoreClient) CacheCompanies(ctx context.Context, companies map[string]models.Company, ttl time.Duration) error {
+	if len(companies) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	batch := f.client.Batch()
+	for key, company := range companies {
+		batch.Set(f.collection(ctx, companiesCollection).Doc(hashDocID(key)), cachedCompany{
+			Key:       key,
+			Company:   company,
+			CachedAt:  now,
+			ExpiresAt: now.Add(ttl),
+		})
+	}
+
+	if _, err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to write company cache: %w", err)
+	}
+	return nil
+}
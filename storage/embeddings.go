@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// embeddingsCollection holds the embedding vectors of profiles and postings. Nearest-neighbour queries need
+// a vector index on vector with kind and model (see the README).
+const embeddingsCollection = "embeddings"
+
+// maxNearestEmbeddings is the most neighbours a Firestore vector query returns
+const maxNearestEmbeddings = 1000
+
+// storedEmbedding is an embedding as stored in Firestore, with its vector in a vector field
+type storedEmbedding struct {
+	Kind        string             `firestore:"kind"`
+	ID          string             `firestore:"id"`
+	Model       string             `firestore:"model"`
+	ContentHash string             `firestore:"contentHash"`
+	Vector      firestore.Vector32 `firestore:"vector"`
+	UpdatedAt   time.Time          `firestore:"updatedAt"`
+}
+
+func (e *storedEmbedding) toModel() models.Embedding {
+	return models.Embedding{
+		Kind:        e.Kind,
+		ID:          e.ID,
+		Model:       e.Model,
+		ContentHash: e.ContentHash,
+		Vector:      e.Vector,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+// embeddingDocID is the document ID of an embedding; IDs such as emails aren't safe document IDs
+func embeddingDocID(kind, id string) string {
+	return hashDocID(kind + ":" + id)
+}
+
+// UpsertEmbeddings stores embeddings, replacing those of the same kind and ID
+func (f *FirestoreClient) UpsertEmbeddings(ctx context.Context, embeddings []models.Embedding) error {
+	now := time.Now()
+	writes := make([]docWrite, len(embeddings))
+	for i := range embeddings {
+		embeddings[i].UpdatedAt = now
+		e := &embeddings[i]
+		writes[i] = docWrite{
+			ref: f.collection(ctx, embeddingsCollection).Doc(embeddingDocID(e.Kind, e.ID)),
+			data: storedEmbedding{
+				Kind:        e.Kind,
+				ID:          e.ID,
+				Model:       e.Model,
+				ContentHash: e.ContentHash,
+				Vector:      e.Vector,
+				UpdatedAt:   now,
+			},
+		}
+	}
+
+	if err := f.bulkSet(ctx, writes); err != nil {
+		return fmt.Errorf("failed to store embeddings: %w", err)
+	}
+	return nil
+}
+
+// GetEmbeddings returns the stored embeddings of a kind for the given IDs, by ID
+func (f *FirestoreClient) GetEmbeddings(ctx context.Context, kind string, ids []string) (map[string]models.Embedding, error) {
+	if len(ids) == 0 {
+		return map[string]models.Embedding{}, nil
+	}
+
+	refs := make([]*firestore.DocumentRef, len(ids))
+	for i, id := range ids {
+		refs[i] = f.collection(ctx, embeddingsCollection).Doc(embeddingDocID(kind, id))
+	}
+
+	docs, err := f.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embeddings: %w", err)
+	}
+
+	embeddings := make(map[string]models.Embedding, len(docs))
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+
+		var stored storedEmbedding
+		if err := doc.DataTo(&stored); err != nil {
+			return nil, fmt.Errorf("failed to parse embedding: %w", err)
+		}
+		embeddings[stored.ID] = stored.toModel()
+	}
+	return embeddings, nil
+}
+
+// FindNearestEmbeddings returns the stored embeddings of the query's kind and model most similar to its
+// vector, most similar first
+func (f *FirestoreClient) FindNearestEmbeddings(ctx context.Context, query models.EmbeddingQuery) ([]models.EmbeddingMatch, error) {
+	if query.Limit <= 0 || len(query.Vector) == 0 {
+		return []models.EmbeddingMatch{}, nil
+	}
+
+	iter := f.collection(ctx, embeddingsCollection).
+		Where("kind", "==", query.Kind).
+		Where("model", "==", query.Model).
+		FindNearest("vector", firestore.Vector32(query.Vector), min(query.Limit, maxNearestEmbeddings), firestore.DistanceMeasureCosine,
+			&firestore.FindNearestOptions{DistanceResultField: "distance"}).
+		Documents(ctx)
+	defer iter.Stop()
+
+	matches := make([]models.EmbeddingMatch, 0, query.Limit)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query embeddings: %w", err)
+		}
+
+		id, _ := doc.Data()["id"].(string)
+		distance, _ := doc.Data()["distance"].(float64)
+		// Cosine distance is 1 - cosine similarity
+		matches = append(matches, models.EmbeddingMatch{ID: id, Similarity: 1 - distance})
+	}
+	return matches, nil
+}
+
+// DeleteEmbeddings deletes the stored embeddings of a kind for the given IDs
+func (f *FirestoreClient) DeleteEmbeddings(ctx context.Context, kind string, ids []string) error {
+	for start := 0; start < len(ids); start += firestoreMaxBatchWrites {
+		batch := f.client.Batch()
+		for _, id := range ids[start:min(start+firestoreMaxBatchWrites, len(ids))] {
+			batch.Delete(f.collection(ctx, embeddingsCollection).Doc(embeddingDocID(kind, id)))
+		}
+		if _, err := batch.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to delete embeddings: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/api/iterator"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+const jobFeedbackCollection = "job_feedback"
+
+// SaveJobFeedback records a user's rating of a job, replacing any earlier rating of the same job
+func (f *FirestoreClient) SaveJobFeedback(ctx context.Context, feedback *models.JobFeedback) error {
+	feedback.UpdatedAt = time.Now()
+
+	docRef := f.collection(ctx, jobFeedbackCollection).Doc(jobScoreDocID(feedback.UserID, feedback.JobID))
+	if _, err := docRef.Set(ctx, feedback); err != nil {
+		return fmt.Errorf("failed to save job feedback: %w", err)
+	}
+	return nil
+}
+
+// ListJobFeedback returns a user's job ratings, most recent first
+func (f *FirestoreClient) ListJobFeedback(ctx context.Context, userID string) ([]models.JobFeedback, error) {
+	iter := f.collection(ctx, jobFeedbackCollection).Where("userId", "==", userID).Documents(ctx)
+	defer iter.Stop()
+
+	feedback := make([]models.JobFeedback, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query job feedback: %w", err)
+		}
+
+		var entry models.JobFeedback
+		if err := doc.DataTo(&entry); err != nil {
+			return nil, fmt.Errorf("failed to parse job feedback: %w", err)
+		}
+		feedback = append(feedback, entry)
+	}
+
+	sort.Slice(feedback, func(i, j int) bool {
+		return feedback[i].UpdatedAt.After(feedback[j].UpdatedAt)
+	})
+
+	return feedback, nil
+}
@@ -7,16 +7,41 @@ import (
 	"time"
 
 	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
 	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/myjobmatch/backend/config"
 	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
 )
 
 const usersCollection = "users"
 
+// tenantsCollection holds one document per tenant, under which the tenant's collections are kept;
+// the default tenant's collections stay at the root
+const tenantsCollection = "tenants"
+
+// userEmailsCollection maps each email to the ID of its user, so emails stay unique and can change
+// without moving the user document
+const userEmailsCollection = "user_emails"
+
+// googleIDsCollection maps each linked Google account ID to the ID of its user, so a Google account
+// can't be linked to two users
+const googleIDsCollection = "user_google_ids"
+
+var (
+	// ErrUserNotFound is returned when no user has the email
+	ErrUserNotFound = errors.New("user not found")
+
+	// ErrUserExists is returned when creating a user (or changing an email) whose email is taken
+	ErrUserExists = errors.New("user with this email already exists")
+
+	// ErrGoogleIDTaken is returned when a Google account is already linked to another user
+	ErrGoogleIDTaken = errors.New("Google account is already linked to another user")
+)
+
 // FirestoreClient wraps Firestore operations
 type FirestoreClient struct {
 	client *firestore.Client
@@ -24,7 +49,7 @@ type FirestoreClient struct {
 
 // NewFirestoreClient creates a new Firestore client
 func NewFirestoreClient(ctx context.Context, cfg *config.Config) (*FirestoreClient, error) {
-	client, err := firestore.NewClient(ctx, cfg.ProjectID)
+	client, err := firestore.NewClient(ctx, cfg.ProjectID, newFirestoreCallPolicy(cfg).firestoreOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Firestore client: %w", err)
 	}
@@ -37,40 +62,177 @@ func (f *FirestoreClient) Close() error {
 	return f.client.Close()
 }
 
-// CreateUser creates a new user in Firestore
+// collection returns the named collection of the context's tenant
+func (f *FirestoreClient) collection(ctx context.Context, name string) *firestore.CollectionRef {
+	if tenantID := utils.TenantFromContext(ctx); tenantID != "" {
+		return f.client.Collection(tenantsCollection).Doc(tenantID).Collection(name)
+	}
+	return f.client.Collection(name)
+}
+
+// Ping reads a document that needn't exist, checking that Firestore is reachable with our credentials
+func (f *FirestoreClient) Ping(ctx context.Context) error {
+	_, err := f.collection(ctx, usersCollection).Doc("_health").Get(ctx)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return fmt.Errorf("failed to reach Firestore: %w", err)
+	}
+	return nil
+}
+
+// userRef returns the document of the user with the email, reading the email index with get (a plain or
+// transactional read). Users created before user IDs are keyed by email until MigrateUserIDs moves them.
+func (f *FirestoreClient) userRef(ctx context.Context, email string, get func(*firestore.DocumentRef) (*firestore.DocumentSnapshot, error)) (*firestore.DocumentRef, error) {
+	doc, err := get(f.collection(ctx, userEmailsCollection).Doc(email))
+	if status.Code(err) == codes.NotFound {
+		return f.collection(ctx, usersCollection).Doc(email), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up user: %w", err)
+	}
+
+	userID, _ := doc.Data()["userId"].(string)
+	return f.collection(ctx, usersCollection).Doc(userID), nil
+}
+
+// getUserRef resolves a user's document outside a transaction
+func (f *FirestoreClient) getUserRef(ctx context.Context, email string) (*firestore.DocumentRef, error) {
+	return f.userRef(ctx, email, func(ref *firestore.DocumentRef) (*firestore.DocumentSnapshot, error) {
+		return ref.Get(ctx)
+	})
+}
+
+// emailTaken reports in the transaction whether the email belongs to a user
+func (f *FirestoreClient) emailTaken(ctx context.Context, tx *firestore.Transaction, email string) (bool, error) {
+	for _, ref := range []*firestore.DocumentRef{
+		f.collection(ctx, userEmailsCollection).Doc(email),
+		f.collection(ctx, usersCollection).Doc(email),
+	} {
+		_, err := tx.Get(ref)
+		if err == nil {
+			return true, nil
+		}
+		if status.Code(err) != codes.NotFound {
+			return false, fmt.Errorf("failed to check user existence: %w", err)
+		}
+	}
+	return false, nil
+}
+
+// CreateUser creates a new user in Firestore under a random ID, failing with ErrUserExists when the email
+// is taken and ErrGoogleIDTaken when the user's Google account is linked to another user
 func (f *FirestoreClient) CreateUser(ctx context.Context, user *models.User) error {
+	user.TenantID = utils.TenantFromContext(ctx)
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = time.Now()
 
-	// Use email as document ID for uniqueness
-	docRef := f.client.Collection(usersCollection).Doc(user.Email)
+	userRef := f.collection(ctx, usersCollection).Doc(uuid.NewString())
 
-	// Check if user already exists
-	_, err := docRef.Get(ctx)
-	if err == nil {
-		return errors.New("user with this email already exists")
-	}
-	if status.Code(err) != codes.NotFound {
-		return fmt.Errorf("failed to check user existence: %w", err)
-	}
+	err := f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		taken, err := f.emailTaken(ctx, tx, user.Email)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrUserExists
+		}
 
-	// Create user
-	_, err = docRef.Set(ctx, user)
+		if user.GoogleID != "" {
+			if err := f.claimGoogleID(ctx, tx, user.GoogleID, userRef.ID); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(f.collection(ctx, userEmailsCollection).Doc(user.Email), map[string]interface{}{
+			"userId": userRef.ID,
+		}); err != nil {
+			return err
+		}
+		return tx.Create(userRef, user)
+	})
 	if err != nil {
+		if errors.Is(err, ErrUserExists) || errors.Is(err, ErrGoogleIDTaken) {
+			return err
+		}
+		if status.Code(err) == codes.AlreadyExists {
+			return ErrUserExists
+		}
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
-	user.ID = user.Email
+	user.ID = userRef.ID
+	return nil
+}
+
+// LinkGoogleID links a Google account to an existing user and marks it as a Google account, failing
+// with ErrGoogleIDTaken when the Google account is linked to another user
+func (f *FirestoreClient) LinkGoogleID(ctx context.Context, email, googleID string) error {
+	err := f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		userRef, err := f.userRef(ctx, email, tx.Get)
+		if err != nil {
+			return err
+		}
+		if err := f.claimGoogleID(ctx, tx, googleID, userRef.ID); err != nil {
+			return err
+		}
+		return tx.Update(userRef, []firestore.Update{
+			{Path: "googleId", Value: googleID},
+			{Path: "provider", Value: "google"},
+			{Path: "updatedAt", Value: time.Now()},
+		})
+	})
+	if err != nil {
+		if errors.Is(err, ErrGoogleIDTaken) {
+			return err
+		}
+		if status.Code(err) == codes.NotFound {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to link Google account: %w", err)
+	}
 	return nil
 }
 
+// claimGoogleID records in the transaction that a Google account belongs to the user. Users linked
+// before the claims collection existed are found by querying their googleId.
+func (f *FirestoreClient) claimGoogleID(ctx context.Context, tx *firestore.Transaction, googleID, userID string) error {
+	claimRef := f.collection(ctx, googleIDsCollection).Doc(googleID)
+	claim, err := tx.Get(claimRef)
+	if err == nil {
+		// Claims written before user IDs hold the email, which was then the user's document ID
+		owner, ok := claim.Data()["userId"].(string)
+		if !ok {
+			owner, _ = claim.Data()["email"].(string)
+		}
+		if owner != userID {
+			return ErrGoogleIDTaken
+		}
+	} else if status.Code(err) != codes.NotFound {
+		return fmt.Errorf("failed to check Google account: %w", err)
+	}
+
+	linked, err := tx.Documents(f.collection(ctx, usersCollection).Where("googleId", "==", googleID).Limit(1)).GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to check Google account: %w", err)
+	}
+	if len(linked) > 0 && linked[0].Ref.ID != userID {
+		return ErrGoogleIDTaken
+	}
+
+	return tx.Set(claimRef, map[string]interface{}{
+		"userId":    userID,
+		"createdAt": time.Now(),
+	})
+}
+
 // GetUserByEmail retrieves a user by email
 func (f *FirestoreClient) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
-	docRef := f.client.Collection(usersCollection).Doc(email)
+	docRef, err := f.getUserRef(ctx, email)
+	if err != nil {
+		return nil, err
+	}
 	doc, err := docRef.Get(ctx)
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -86,12 +248,12 @@ func (f *FirestoreClient) GetUserByEmail(ctx context.Context, email string) (*mo
 
 // GetUserByGoogleID retrieves a user by Google ID
 func (f *FirestoreClient) GetUserByGoogleID(ctx context.Context, googleID string) (*models.User, error) {
-	iter := f.client.Collection(usersCollection).Where("googleId", "==", googleID).Limit(1).Documents(ctx)
+	iter := f.collection(ctx, usersCollection).Where("googleId", "==", googleID).Limit(1).Documents(ctx)
 	defer iter.Stop()
 
 	doc, err := iter.Next()
 	if err == iterator.Done {
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to query user: %w", err)
@@ -106,13 +268,23 @@ func (f *FirestoreClient) GetUserByGoogleID(ctx context.Context, googleID string
 	return &user, nil
 }
 
-// UpdateUser updates user data
+// UpdateUser updates user data, failing with ErrUserNotFound when the user doesn't exist
 func (f *FirestoreClient) UpdateUser(ctx context.Context, email string, updates map[string]interface{}) error {
 	updates["updatedAt"] = time.Now()
 
-	docRef := f.client.Collection(usersCollection).Doc(email)
-	_, err := docRef.Set(ctx, updates, firestore.MergeAll)
+	docRef, err := f.getUserRef(ctx, email)
 	if err != nil {
+		return err
+	}
+
+	fields := make([]firestore.Update, 0, len(updates))
+	for path, value := range updates {
+		fields = append(fields, firestore.Update{Path: path, Value: value})
+	}
+	if _, err := docRef.Update(ctx, fields); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return ErrUserNotFound
+		}
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 
@@ -126,12 +298,22 @@ func (f *FirestoreClient) UpdateUserCVUrl(ctx context.Context, email, cvUrl stri
 	})
 }
 
-// UpdateUserProfile updates user's profile (nama)
-func (f *FirestoreClient) UpdateUserProfile(ctx context.Context, email string, nama string) error {
+// UpdateUserPhotoUrl updates user's profile photo URL
+func (f *FirestoreClient) UpdateUserPhotoUrl(ctx context.Context, email, photoUrl string) error {
+	return f.UpdateUser(ctx, email, map[string]interface{}{
+		"photoUrl": photoUrl,
+	})
+}
+
+// UpdateUserProfile updates user's profile (nama, incognito setting)
+func (f *FirestoreClient) UpdateUserProfile(ctx context.Context, email string, nama string, incognito *bool) error {
 	updates := map[string]interface{}{}
 	if nama != "" {
 		updates["nama"] = nama
 	}
+	if incognito != nil {
+		updates["incognito"] = *incognito
+	}
 
 	if len(updates) == 0 {
 		return nil
@@ -140,10 +322,68 @@ func (f *FirestoreClient) UpdateUserProfile(ctx context.Context, email string, n
 	return f.UpdateUser(ctx, email, updates)
 }
 
-// DeleteUser deletes a user
+// UpdateUserBlockedCompanies replaces the companies excluded from the user's searches
+func (f *FirestoreClient) UpdateUserBlockedCompanies(ctx context.Context, email string, companies []string) error {
+	return f.UpdateUser(ctx, email, map[string]interface{}{
+		"blockedCompanies": companies,
+	})
+}
+
+// UpdateUserWebhook sets the URL and signing secret of the user's webhook; empty values remove it
+func (f *FirestoreClient) UpdateUserWebhook(ctx context.Context, email, url, secret string) error {
+	return f.UpdateUser(ctx, email, map[string]interface{}{
+		"webhookUrl":    url,
+		"webhookSecret": secret,
+	})
+}
+
+// ListCVUrls returns the CV URL of every user who has one (used by the CV retention worker)
+func (f *FirestoreClient) ListCVUrls(ctx context.Context) ([]string, error) {
+	iter := f.collection(ctx, usersCollection).Where("cvUrl", ">", "").Select("cvUrl").Documents(ctx)
+	defer iter.Stop()
+
+	urls := make([]string, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query CV URLs: %w", err)
+		}
+
+		if cvUrl, ok := doc.Data()["cvUrl"].(string); ok && cvUrl != "" {
+			urls = append(urls, cvUrl)
+		}
+	}
+	return urls, nil
+}
+
+// DeleteUser deletes a user and releases their email and Google account
 func (f *FirestoreClient) DeleteUser(ctx context.Context, email string) error {
-	docRef := f.client.Collection(usersCollection).Doc(email)
-	_, err := docRef.Delete(ctx)
+	err := f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docRef, err := f.userRef(ctx, email, tx.Get)
+		if err != nil {
+			return err
+		}
+		doc, err := tx.Get(docRef)
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if googleID, _ := doc.Data()["googleId"].(string); googleID != "" {
+			if err := tx.Delete(f.collection(ctx, googleIDsCollection).Doc(googleID)); err != nil {
+				return err
+			}
+		}
+		if err := tx.Delete(f.collection(ctx, userEmailsCollection).Doc(email)); err != nil {
+			return err
+		}
+		return tx.Delete(docRef)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
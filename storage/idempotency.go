@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// idempotencyKeysCollection holds requests made with an Idempotency-Key header, keyed by the hash of
+// their scope and key.
+// Configure a Firestore TTL policy on expiresAt to purge old responses automatically.
+const idempotencyKeysCollection = "idempotency_keys"
+
+// idempotencyDocID keys a record by its scope and key
+func idempotencyDocID(scope, key string) string {
+	return hashDocID(scope + "\x00" + key)
+}
+
+// ReserveIdempotencyKey stores a running record unless an unexpired record has the same scope and key,
+// in which case it stores nothing and returns that record
+func (f *FirestoreClient) ReserveIdempotencyKey(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, error) {
+	record.Completed = false
+	record.CreatedAt = time.Now()
+
+	docRef := f.collection(ctx, idempotencyKeysCollection).Doc(idempotencyDocID(record.Scope, record.Key))
+	var existing *models.IdempotencyRecord
+	err := f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		existing = nil
+		doc, err := tx.Get(docRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			var stored models.IdempotencyRecord
+			if err := doc.DataTo(&stored); err != nil {
+				return err
+			}
+			if time.Now().Before(stored.ExpiresAt) {
+				existing = &stored
+				return nil
+			}
+		}
+		return tx.Set(docRef, record)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	return existing, nil
+}
+
+// CompleteIdempotencyKey replaces a reservation with the completed record
+func (f *FirestoreClient) CompleteIdempotencyKey(ctx context.Context, record *models.IdempotencyRecord) error {
+	record.Completed = true
+
+	docRef := f.collection(ctx, idempotencyKeysCollection).Doc(idempotencyDocID(record.Scope, record.Key))
+	if _, err := docRef.Set(ctx, record); err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey deletes a record, so the next request with its key runs again
+func (f *FirestoreClient) ReleaseIdempotencyKey(ctx context.Context, scope, key string) error {
+	if _, err := f.collection(ctx, idempotencyKeysCollection).Doc(idempotencyDocID(scope, key)).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+const jobCacheCollection = "job_cache"
+
+// cachedJob is a posting stored in the job cache.
+// Configure a Firestore TTL policy on expiresAt to purge expired entries automatically.
+type cachedJob struct {
+	Key       string            `firestore:"key"`
+	Job       models.JobPosting `firestore:"job"`
+	CachedAt  time.Time         `firestore:"cachedAt"`
+	ExpiresAt time.Time         `firestore:"expiresAt"`
+}
+
+// hashDocID derives a document ID from a key that may contain characters IDs cannot (e.g. slashes in URLs)
+func hashDocID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetCachedJobs returns the unexpired cached postings for the given keys
+func (f *FirestoreClient) GetCachedJobs(ctx context.Context, keys []string) (map[string]models.JobPosting, error) {
+	if len(keys) == 0 {
+		return map[string]models.JobPosting{}, nil
+	}
+
+	refs := make([]*firestore.DocumentRef, len(keys))
+	for i, key := range keys {
+		refs[i] = f.collection(ctx, jobCacheCollection).Doc(hashDocID(key))
+	}
+
+	docs, err := f.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job cache: %w", err)
+	}
+
+	now := time.Now()
+	jobs := make(map[string]models.JobPosting, len(docs))
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+
+		var entry cachedJob
+		if err := doc.DataTo(&entry); err != nil {
+			return nil, fmt.Errorf("failed to parse cached job: %w", err)
+		}
+		if now.After(entry.ExpiresAt) {
+			continue
+		}
+		jobs[entry.Key] = entry.Job
+	}
+
+	return jobs, nil
+}
+
+// CacheJobs stores postings in the job cache, replacing any existing entries for the same keys
+func (f *FirestoreClient) CacheJobs(ctx context.Context, jobs map[string]models.JobPosting, ttl time.Duration) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	writes := make([]docWrite, 0, len(jobs))
+	for key, job := range jobs {
+		writes = append(writes, docWrite{ref: f.collection(ctx, jobCacheCollection).Doc(hashDocID(key)), data: cachedJob{
+			Key:       key,
+			Job:       job,
+			CachedAt:  now,
+			ExpiresAt: now.Add(ttl),
+		}})
+	}
+
+	if err := f.bulkSet(ctx, writes); err != nil {
+		return fmt.Errorf("failed to write job cache: %w", err)
+	}
+	return nil
+}
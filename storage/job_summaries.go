@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// jobSummariesCollection caches job summaries by canonical URL.
+// Configure a Firestore TTL policy on expiresAt to purge expired entries automatically.
+const jobSummariesCollection = "job_summaries"
+
+// ErrJobSummaryNotFound is returned when no unexpired summary is cached for a URL
+var ErrJobSummaryNotFound = errors.New("job summary not found")
+
+// cachedJobSummary is a summary stored in the job summary cache
+type cachedJobSummary struct {
+	Key       string            `firestore:"key"`
+	Summary   models.JobSummary `firestore:"summary"`
+	CachedAt  time.Time         `firestore:"cachedAt"`
+	ExpiresAt time.Time         `firestore:"expiresAt"`
+}
+
+// GetJobSummary returns the cached summary for a key
+func (f *FirestoreClient) GetJobSummary(ctx context.Context, key string) (*models.JobSummary, error) {
+	doc, err := f.collection(ctx, jobSummariesCollection).Doc(hashDocID(key)).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrJobSummaryNotFound
+		}
+		return nil, fmt.Errorf("failed to get job summary: %w", err)
+	}
+
+	var entry cachedJobSummary
+	if err := doc.DataTo(&entry); err != nil {
+		return nil, fmt.Errorf("failed to parse job summary: %w", err)
+	}
+	if time.Now().After(entry.ExpiresAt) {
+		return nil, ErrJobSummaryNotFound
+	}
+	return &entry.Summary, nil
+}
+
+// CacheJobSummary stores a summary by key for the given TTL, replacing any existing entry
+func (f *FirestoreClient) CacheJobSummary(ctx context.Context, key string, summary *models.JobSummary, ttl time.Duration) error {
+	now := time.Now()
+	entry := cachedJobSummary{
+		Key:       key,
+		Summary:   *summary,
+		CachedAt:  now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if _, err := f.collection(ctx, jobSummariesCollection).Doc(hashDocID(key)).Set(ctx, entry); err != nil {
+		return fmt.Errorf("failed to cache job summary: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,355 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+const (
+	jobsCollection      = "jobs"
+	jobScoresCollection = "job_scores"
+)
+
+// ErrJobNotFound is returned when no job is stored under an ID
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrJobScoreNotFound is returned when a user has no score for a job
+var ErrJobScoreNotFound = errors.New("job score not found")
+
+// SaveJobs stores postings under their stable IDs, refreshing postings that are already stored.
+// Postings without an ID are skipped.
+func (f *FirestoreClient) SaveJobs(ctx context.Context, jobs []models.JobPosting) error {
+	refs := make([]*firestore.DocumentRef, 0, len(jobs))
+	byID := make(map[string]models.JobPosting, len(jobs))
+	for _, job := range jobs {
+		if job.ID == "" {
+			continue
+		}
+		if _, ok := byID[job.ID]; !ok {
+			refs = append(refs, f.collection(ctx, jobsCollection).Doc(job.ID))
+		}
+		byID[job.ID] = job
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+
+	docs, err := f.client.GetAll(ctx, refs)
+	if err != nil {
+		return fmt.Errorf("failed to read stored jobs: %w", err)
+	}
+
+	now := time.Now()
+	writes := make([]docWrite, 0, len(docs))
+	for _, doc := range docs {
+		job := byID[doc.Ref.ID]
+		if doc.Exists() {
+			// Keep firstSeenAt; replace the posting with the latest extraction
+			writes = append(writes, docWrite{ref: doc.Ref, data: map[string]interface{}{
+				"job":        job,
+				"lastSeenAt": now,
+				"keywords":   models.JobKeywords(job),
+			}, opts: []firestore.SetOption{firestore.Merge([]string{"job"}, []string{"lastSeenAt"}, []string{"keywords"})}})
+			continue
+		}
+		writes = append(writes, docWrite{ref: doc.Ref, data: models.StoredJob{
+			Job:         job,
+			TenantID:    utils.TenantFromContext(ctx),
+			FirstSeenAt: now,
+			LastSeenAt:  now,
+			Keywords:    models.JobKeywords(job),
+		}})
+	}
+
+	if err := f.bulkSet(ctx, writes); err != nil {
+		return fmt.Errorf("failed to store jobs: %w", err)
+	}
+	return nil
+}
+
+// GetJob returns a stored job by its stable ID
+func (f *FirestoreClient) GetJob(ctx context.Context, id string) (*models.StoredJob, error) {
+	doc, err := f.collection(ctx, jobsCollection).Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	var stored models.StoredJob
+	if err := doc.DataTo(&stored); err != nil {
+		return nil, fmt.Errorf("failed to parse job: %w", err)
+	}
+	stored.Job.ID = doc.Ref.ID
+
+	return &stored, nil
+}
+
+// GetJobByURL returns the stored job for a posting URL
+func (f *FirestoreClient) GetJobByURL(ctx context.Context, url string) (*models.StoredJob, error) {
+	return f.GetJob(ctx, utils.JobID(url))
+}
+
+// SaveJobScores records a user's latest match scores for ranked jobs, with the prompt experiment variant
+// that scored them (empty if none). Jobs without an ID are skipped.
+func (f *FirestoreClient) SaveJobScores(ctx context.Context, userID string, jobs []models.RankedJob, promptVariant string) error {
+	now := time.Now()
+	writes := make([]docWrite, 0, len(jobs))
+	for _, job := range jobs {
+		if job.ID == "" {
+			continue
+		}
+		writes = append(writes, docWrite{ref: f.collection(ctx, jobScoresCollection).Doc(jobScoreDocID(userID, job.ID)), data: models.JobScore{
+			JobID:          job.ID,
+			UserID:         userID,
+			MatchScore:     job.MatchScore,
+			MatchReason:    job.MatchReason,
+			ScoreMethod:    job.ScoreMethod,
+			ScoreBreakdown: job.ScoreBreakdown,
+			AIScore:        job.AIScore,
+			HeuristicScore: job.HeuristicScore,
+			SkillsMatch:    job.SkillsMatch,
+			PromptVariant:  promptVariant,
+			ScoredAt:       now,
+		}})
+	}
+
+	if err := f.bulkSet(ctx, writes); err != nil {
+		return fmt.Errorf("failed to store job scores: %w", err)
+	}
+	return nil
+}
+
+// GetJobScore returns a user's latest match score for a job
+func (f *FirestoreClient) GetJobScore(ctx context.Context, userID, jobID string) (*models.JobScore, error) {
+	doc, err := f.collection(ctx, jobScoresCollection).Doc(jobScoreDocID(userID, jobID)).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrJobScoreNotFound
+		}
+		return nil, fmt.Errorf("failed to get job score: %w", err)
+	}
+
+	var score models.JobScore
+	if err := doc.DataTo(&score); err != nil {
+		return nil, fmt.Errorf("failed to parse job score: %w", err)
+	}
+
+	return &score, nil
+}
+
+// jobScoreDocID keys per-user job records (scores, feedback) so each user has one record per job
+func jobScoreDocID(userID, jobID string) string {
+	return hashDocID(userID + "|" + jobID)
+}
+
+// GetJobs returns the stored jobs for the given IDs, keyed by ID; unknown IDs are omitted
+func (f *FirestoreClient) GetJobs(ctx context.Context, ids []string) (map[string]models.StoredJob, error) {
+	jobs := make(map[string]models.StoredJob, len(ids))
+	if len(ids) == 0 {
+		return jobs, nil
+	}
+
+	refs := make([]*firestore.DocumentRef, len(ids))
+	for i, id := range ids {
+		refs[i] = f.collection(ctx, jobsCollection).Doc(id)
+	}
+
+	docs, err := f.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+
+		var stored models.StoredJob
+		if err := doc.DataTo(&stored); err != nil {
+			return nil, fmt.Errorf("failed to parse job: %w", err)
+		}
+		stored.Job.ID = doc.Ref.ID
+		jobs[doc.Ref.ID] = stored
+	}
+
+	return jobs, nil
+}
+
+// GetJobScores returns a user's scores for the given job IDs, keyed by job ID; unscored jobs are omitted
+func (f *FirestoreClient) GetJobScores(ctx context.Context, userID string, jobIDs []string) (map[string]models.JobScore, error) {
+	scores := make(map[string]models.JobScore, len(jobIDs))
+	if len(jobIDs) == 0 {
+		return scores, nil
+	}
+
+	refs := make([]*firestore.DocumentRef, len(jobIDs))
+	for i, id := range jobIDs {
+		refs[i] = f.collection(ctx, jobScoresCollection).Doc(jobScoreDocID(userID, id))
+	}
+
+	docs, err := f.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job scores: %w", err)
+	}
+
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
+
+		var score models.JobScore
+		if err := doc.DataTo(&score); err != nil {
+			return nil, fmt.Errorf("failed to parse job score: %w", err)
+		}
+		scores[score.JobID] = score
+	}
+
+	return scores, nil
+}
+
+// maxTagQueryValues is Firestore's limit on values in an array-contains-any filter
+const maxTagQueryValues = 30
+
+// FindJobsByTags returns up to limit stored jobs sharing at least one tag with the given tags
+func (f *FirestoreClient) FindJobsByTags(ctx context.Context, tags []string, limit int) ([]models.StoredJob, error) {
+	if len(tags) == 0 {
+		return []models.StoredJob{}, nil
+	}
+	if len(tags) > maxTagQueryValues {
+		tags = tags[:maxTagQueryValues]
+	}
+
+	values := make([]interface{}, len(tags))
+	for i, tag := range tags {
+		values[i] = tag
+	}
+
+	query := f.collection(ctx, jobsCollection).Where("job.Tags", "array-contains-any", values).Limit(limit)
+	return f.queryJobs(query.Documents(ctx))
+}
+
+// ListRecentJobs returns up to limit stored jobs, most recently seen first
+func (f *FirestoreClient) ListRecentJobs(ctx context.Context, limit int) ([]models.StoredJob, error) {
+	query := f.collection(ctx, jobsCollection).OrderBy("lastSeenAt", firestore.Desc).Limit(limit)
+	return f.queryJobs(query.Documents(ctx))
+}
+
+// queryJobs reads stored jobs from a query
+func (f *FirestoreClient) queryJobs(iter *firestore.DocumentIterator) ([]models.StoredJob, error) {
+	defer iter.Stop()
+
+	jobs := make([]models.StoredJob, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query jobs: %w", err)
+		}
+
+		var stored models.StoredJob
+		if err := doc.DataTo(&stored); err != nil {
+			return nil, fmt.Errorf("failed to parse job: %w", err)
+		}
+		stored.Job.ID = doc.Ref.ID
+		jobs = append(jobs, stored)
+	}
+
+	return jobs, nil
+}
+
+// ListJobsSeenSince returns up to limit stored jobs returned by a search since the given time, most recent first
+func (f *FirestoreClient) ListJobsSeenSince(ctx context.Context, since time.Time, limit int) ([]models.StoredJob, error) {
+	query := f.collection(ctx, jobsCollection).
+		Where("lastSeenAt", ">=", since).
+		OrderBy("lastSeenAt", firestore.Desc).
+		Limit(limit)
+	return f.queryJobs(query.Documents(ctx))
+}
+
+// QueryJobs returns the stored jobs matching the query, most recently seen first
+func (f *FirestoreClient) QueryJobs(ctx context.Context, query models.JobQuery) ([]models.StoredJob, error) {
+	// Filter on one equality field in Firestore and the rest in memory, so no composite indexes are needed
+	q := f.collection(ctx, jobsCollection).Query
+	switch {
+	case query.Company != "":
+		q = q.Where("job.Company", "==", query.Company)
+	case query.Source != "":
+		q = q.Where("job.Source", "==", query.Source)
+	case query.SiteSetting != "":
+		q = q.Where("job.SiteSetting", "==", query.SiteSetting)
+	case query.WorkType != "":
+		q = q.Where("job.WorkType", "==", query.WorkType)
+	default:
+		q = q.Where("lastSeenAt", ">=", query.Since).OrderBy("lastSeenAt", firestore.Desc)
+		if query.Location == "" {
+			q = q.Limit(query.Limit)
+		}
+	}
+
+	stored, err := f.queryJobs(q.Documents(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]models.StoredJob, 0, len(stored))
+	for _, s := range stored {
+		if query.Matches(s) {
+			jobs = append(jobs, s)
+		}
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].LastSeenAt.After(jobs[j].LastSeenAt)
+	})
+	if len(jobs) > query.Limit {
+		jobs = jobs[:query.Limit]
+	}
+	return jobs, nil
+}
+
+// SearchJobIndex returns up to limit stored postings seen since the given time that have any of the
+// keywords, most recently seen first. Requires a composite index on keywords (array-contains) and lastSeenAt.
+func (f *FirestoreClient) SearchJobIndex(ctx context.Context, keywords []string, since time.Time, limit int) ([]models.JobPosting, error) {
+	if len(keywords) == 0 {
+		return []models.JobPosting{}, nil
+	}
+	if len(keywords) > maxTagQueryValues {
+		keywords = keywords[:maxTagQueryValues]
+	}
+
+	values := make([]interface{}, len(keywords))
+	for i, keyword := range keywords {
+		values[i] = keyword
+	}
+
+	query := f.collection(ctx, jobsCollection).
+		Where("keywords", "array-contains-any", values).
+		Where("lastSeenAt", ">=", since).
+		OrderBy("lastSeenAt", firestore.Desc).
+		Limit(limit)
+	stored, err := f.queryJobs(query.Documents(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]models.JobPosting, len(stored))
+	for i, s := range stored {
+		jobs[i] = s.Job
+	}
+	return jobs, nil
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"google.golang.org/api/iterator"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// llmDebugCollection holds recorded Gemini calls.
+// Configure a Firestore TTL policy on expiresAt to purge old records automatically.
+const llmDebugCollection = "llm_debug"
+
+// SaveLLMDebugRecord stores a recorded Gemini call
+func (f *FirestoreClient) SaveLLMDebugRecord(ctx context.Context, record *models.LLMDebugRecord) error {
+	docRef := f.collection(ctx, llmDebugCollection).NewDoc()
+	if _, err := docRef.Set(ctx, record); err != nil {
+		return fmt.Errorf("failed to save LLM debug record: %w", err)
+	}
+
+	record.ID = docRef.ID
+	return nil
+}
+
+// ListLLMDebugRecords returns the Gemini calls recorded for a request, oldest first
+func (f *FirestoreClient) ListLLMDebugRecords(ctx context.Context, requestID string) ([]models.LLMDebugRecord, error) {
+	iter := f.collection(ctx, llmDebugCollection).Where("requestId", "==", requestID).Documents(ctx)
+	defer iter.Stop()
+
+	records := make([]models.LLMDebugRecord, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query LLM debug records: %w", err)
+		}
+
+		var record models.LLMDebugRecord
+		if err := doc.DataTo(&record); err != nil {
+			return nil, fmt.Errorf("failed to parse LLM debug record: %w", err)
+		}
+		record.ID = doc.Ref.ID
+		records = append(records, record)
+	}
+
+	// Sorted in memory to avoid requiring a composite index on (requestId, createdAt)
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+	return records, nil
+}
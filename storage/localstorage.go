@@ -0,0 +1,178 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/myjobmatch/backend/config"
+)
+
+// LocalStorageClient stores CVs and profile photos in a local directory, laid out like the Cloud Storage
+// bucket, so development and tests need no bucket or service account. Photos are meant to be served
+// under PhotoURLPath; CVs are only read back by the server.
+type LocalStorageClient struct {
+	dir     string
+	baseURL string // URL the directory is published under, without a trailing slash
+}
+
+// NewLocalStorageClient creates the storage directory if needed
+func NewLocalStorageClient(cfg *config.Config) (*LocalStorageClient, error) {
+	if err := os.MkdirAll(cfg.LocalStorageDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create local storage directory: %w", err)
+	}
+	return &LocalStorageClient{
+		dir:     cfg.LocalStorageDir,
+		baseURL: strings.TrimSuffix(cfg.LocalStorageBaseURL, "/"),
+	}, nil
+}
+
+// Close does nothing; it satisfies CVStorage
+func (l *LocalStorageClient) Close() error {
+	return nil
+}
+
+// Ping checks that the storage directory still exists
+func (l *LocalStorageClient) Ping(ctx context.Context) error {
+	if _, err := os.Stat(l.dir); err != nil {
+		return fmt.Errorf("failed to reach local storage: %w", err)
+	}
+	return nil
+}
+
+// UploadCVFromBytes writes CV content to disk
+func (l *LocalStorageClient) UploadCVFromBytes(ctx context.Context, userID string, content []byte, filename string) (string, error) {
+	objectName := cvObjectName(ctx, userID, filename)
+	if err := l.write(objectName, content); err != nil {
+		return "", fmt.Errorf("failed to write content: %w", err)
+	}
+	return l.baseURL + "/" + objectName, nil
+}
+
+// DownloadCV reads CV content from disk
+func (l *LocalStorageClient) DownloadCV(ctx context.Context, cvUrl string) ([]byte, error) {
+	filePath, err := l.filePath(cvUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CV URL format")
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CV: %w", err)
+	}
+	return data, nil
+}
+
+// DeleteCV deletes a CV file from disk
+func (l *LocalStorageClient) DeleteCV(ctx context.Context, cvUrl string) error {
+	filePath, err := l.filePath(cvUrl)
+	if err != nil {
+		return fmt.Errorf("invalid CV URL format")
+	}
+
+	if err := os.Remove(filePath); err != nil {
+		return fmt.Errorf("failed to delete CV: %w", err)
+	}
+	return nil
+}
+
+// UploadPhoto writes a processed JPEG profile photo to disk
+func (l *LocalStorageClient) UploadPhoto(ctx context.Context, userID string, content []byte) (string, error) {
+	objectName := photoObjectName(ctx, userID)
+	if err := l.write(objectName, content); err != nil {
+		return "", fmt.Errorf("failed to write photo: %w", err)
+	}
+	return l.baseURL + "/" + objectName, nil
+}
+
+// DeletePhoto deletes a profile photo from disk
+func (l *LocalStorageClient) DeletePhoto(ctx context.Context, photoUrl string) error {
+	filePath, err := l.filePath(photoUrl)
+	if err != nil {
+		return fmt.Errorf("invalid photo URL format")
+	}
+
+	if err := os.Remove(filePath); err != nil {
+		return fmt.Errorf("failed to delete photo: %w", err)
+	}
+	return nil
+}
+
+// ListCVs returns every CV file of the context's tenant in the directory, with its modification time as creation time
+func (l *LocalStorageClient) ListCVs(ctx context.Context) ([]CVObject, error) {
+	objects := make([]CVObject, 0)
+	root := filepath.Join(l.dir, filepath.FromSlash(cvFolder(ctx)))
+	err := filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(l.dir, filePath)
+		if err != nil {
+			return err
+		}
+		objectName := filepath.ToSlash(rel)
+		objects = append(objects, CVObject{
+			URL:       l.baseURL + "/" + objectName,
+			Owner:     cvOwner(ctx, objectName),
+			CreatedAt: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CVs: %w", err)
+	}
+	return objects, nil
+}
+
+// PhotoURLPath returns the URL path photos are published under (e.g. /files/photos)
+func (l *LocalStorageClient) PhotoURLPath() string {
+	base, err := url.Parse(l.baseURL)
+	if err != nil {
+		return "/files/photos"
+	}
+	return path.Join("/", base.Path, "photos")
+}
+
+// PhotoDir returns the directory holding the photos
+func (l *LocalStorageClient) PhotoDir() string {
+	return filepath.Join(l.dir, "photos")
+}
+
+func (l *LocalStorageClient) write(objectName string, content []byte) error {
+	filePath := filepath.Join(l.dir, filepath.FromSlash(objectName))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, content, 0o644)
+}
+
+// filePath maps a URL returned by an upload back to its file, rejecting URLs outside the directory
+func (l *LocalStorageClient) filePath(fileUrl string) (string, error) {
+	prefix := l.baseURL + "/"
+	if !strings.HasPrefix(fileUrl, prefix) {
+		return "", errors.New("not a local storage URL")
+	}
+
+	objectName := path.Clean(strings.TrimPrefix(fileUrl, prefix))
+	if objectName == "." || strings.HasPrefix(objectName, "../") || objectName == ".." || path.IsAbs(objectName) {
+		return "", errors.New("path outside the storage directory")
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(objectName)), nil
+}
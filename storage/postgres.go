@@ -0,0 +1,544 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// errDocumentNotFound is returned when an update targets a row that doesn't exist
+var errDocumentNotFound = errors.New("document not found")
+
+// errUnknownTenant is returned when the context names a tenant that isn't configured in TENANTS
+var errUnknownTenant = errors.New("unknown tenant")
+
+// documentCodec encodes models with their firestore tags, so Postgres rows hold the same documents
+// (and field names) as the Firestore collections
+var documentCodec = jsoniter.Config{
+	TagKey:                 "firestore",
+	EscapeHTML:             true,
+	SortMapKeys:            true,
+	ValidateJsonRawMessage: true,
+}.Froze()
+
+// postgresTables are the Firestore collections stored as Postgres tables of (id, data) documents
+var postgresTables = []string{
+	usersCollection,
+	profilesCollection,
+	jobFeedbackCollection,
+	apiTokensCollection,
+	jobsCollection,
+	jobScoresCollection,
+	jobCacheCollection,
+	companiesCollection,
+	jobSummariesCollection,
+	searchesCollection,
+	searchHistoryCollection,
+	searchSessionsCollection,
+	sharedSearchesCollection,
+	chatSessionsCollection,
+	savedJobsCollection,
+	applicationsCollection,
+	alertsCollection,
+	watchlistCollection,
+	llmDebugCollection,
+	toolAuditCollection,
+	toolSettingsCollection,
+	promptExperimentsCollection,
+	emailChangesCollection,
+	idempotencyKeysCollection,
+}
+
+// postgresIndexes cover the lookups that run on every request or scan large tables, and keep each email
+// and Google account linked to one user
+var postgresIndexes = []string{
+	`DROP INDEX IF EXISTS users_google_id`,
+	`DROP INDEX IF EXISTS api_tokens_user_email`,
+	`DROP INDEX IF EXISTS job_feedback_user_email`,
+	`DROP INDEX IF EXISTS search_history_user_email`,
+	`DROP INDEX IF EXISTS shared_searches_user_email`,
+	`DROP INDEX IF EXISTS saved_jobs_user_email`,
+	`DROP INDEX IF EXISTS applications_user_email`,
+	`DROP INDEX IF EXISTS job_alerts_user_email`,
+	`DROP INDEX IF EXISTS watchlist_user_email`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS users_email_key ON users ((data->>'email'))`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS users_google_id_key ON users ((data->>'googleId')) WHERE data->>'googleId' <> ''`,
+	`DROP INDEX IF EXISTS users_previous_email`,
+	`CREATE INDEX IF NOT EXISTS api_tokens_token_hash ON api_tokens ((data->>'tokenHash'))`,
+	`CREATE INDEX IF NOT EXISTS api_tokens_user_id ON api_tokens ((data->>'userId'))`,
+	`CREATE INDEX IF NOT EXISTS job_feedback_user_id ON job_feedback ((data->>'userId'))`,
+	`CREATE INDEX IF NOT EXISTS jobs_keywords ON jobs USING GIN ((data->'keywords'))`,
+	`CREATE INDEX IF NOT EXISTS jobs_tags ON jobs USING GIN ((data->'job'->'Tags'))`,
+	`CREATE INDEX IF NOT EXISTS jobs_company ON jobs ((data->'job'->>'Company'))`,
+	`CREATE INDEX IF NOT EXISTS search_history_user_id ON search_history ((data->>'userId'))`,
+	`CREATE INDEX IF NOT EXISTS shared_searches_user_id ON shared_searches ((data->>'userId'))`,
+	`CREATE INDEX IF NOT EXISTS saved_jobs_user_id ON saved_jobs ((data->>'userId'))`,
+	`CREATE INDEX IF NOT EXISTS applications_user_id ON applications ((data->>'userId'))`,
+	`CREATE INDEX IF NOT EXISTS job_alerts_user_id ON job_alerts ((data->>'userId'))`,
+	`CREATE INDEX IF NOT EXISTS watchlist_user_id ON watchlist ((data->>'userId'))`,
+	`CREATE INDEX IF NOT EXISTS llm_debug_request_id ON llm_debug ((data->>'requestId'))`,
+}
+
+// PostgresClient stores the same documents as FirestoreClient in a PostgreSQL database, one JSONB table
+// per collection, so the API can run without GCP. The tables are created on startup.
+//
+// The default tenant's tables are in the public schema; each tenant in TENANTS gets the same tables in its
+// own schema (tenant_<id>), reached through a pool whose connections have that schema as their search path.
+type PostgresClient struct {
+	pool        *pgxpool.Pool
+	tenantPools map[string]*pgxpool.Pool
+}
+
+// NewPostgresClient connects to DATABASE_URL and creates any missing schemas and tables
+func NewPostgresClient(ctx context.Context, cfg *config.Config) (*PostgresClient, error) {
+	pool, err := pgxpool.New(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Postgres client: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to connect to Postgres: %w", err)
+	}
+
+	p := &PostgresClient{pool: pool, tenantPools: make(map[string]*pgxpool.Pool)}
+	if err := migrate(ctx, pool); err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	for _, tenantID := range cfg.TenantIDs() {
+		tenantPool, err := newTenantPool(ctx, pool, cfg.DatabaseURL, tenantID)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		p.tenantPools[tenantID] = tenantPool
+	}
+	return p, nil
+}
+
+// newTenantPool creates a tenant's schema and tables, returning a pool that uses them
+func newTenantPool(ctx context.Context, pool *pgxpool.Pool, databaseURL, tenantID string) (*pgxpool.Pool, error) {
+	schema := "tenant_" + tenantID
+	if _, err := pool.Exec(ctx, fmt.Sprintf(`CREATE SCHEMA IF NOT EXISTS %s`, schema)); err != nil {
+		return nil, fmt.Errorf("failed to create Postgres schema for tenant %s: %w", tenantID, err)
+	}
+
+	poolConfig, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Postgres client for tenant %s: %w", tenantID, err)
+	}
+	poolConfig.ConnConfig.RuntimeParams["search_path"] = schema
+	tenantPool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Postgres client for tenant %s: %w", tenantID, err)
+	}
+
+	if err := migrate(ctx, tenantPool); err != nil {
+		tenantPool.Close()
+		return nil, err
+	}
+	return tenantPool, nil
+}
+
+// migrate creates the tables and indexes that don't exist yet in the pool's schema
+func migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	statements := make([]string, 0, len(postgresTables)+len(postgresIndexes)+len(postgresEmbeddingsSchema))
+	for _, table := range postgresTables {
+		statements = append(statements, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (id TEXT PRIMARY KEY, data JSONB NOT NULL)`, table))
+	}
+	statements = append(statements, postgresIndexes...)
+	statements = append(statements, postgresEmbeddingsSchema...)
+
+	for _, statement := range statements {
+		if _, err := pool.Exec(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create Postgres schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// db returns the pool of the context's tenant
+func (p *PostgresClient) db(ctx context.Context) (*pgxpool.Pool, error) {
+	tenantID := utils.TenantFromContext(ctx)
+	if tenantID == "" {
+		return p.pool, nil
+	}
+	pool, ok := p.tenantPools[tenantID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnknownTenant, tenantID)
+	}
+	return pool, nil
+}
+
+// Close closes the connection pools
+func (p *PostgresClient) Close() error {
+	for _, pool := range p.tenantPools {
+		pool.Close()
+	}
+	p.pool.Close()
+	return nil
+}
+
+// Ping checks that the database accepts connections
+func (p *PostgresClient) Ping(ctx context.Context) error {
+	if err := p.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to reach Postgres: %w", err)
+	}
+	return nil
+}
+
+// newDocID returns a random 20-character ID like the ones Firestore assigns to new documents
+func newDocID() string {
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to generate document ID: %v", err))
+	}
+	for i := range b {
+		b[i] = alphabet[int(b[i])%len(alphabet)]
+	}
+	return string(b)
+}
+
+// getDoc reads a row into v, reporting whether it exists
+func (p *PostgresClient) getDoc(ctx context.Context, table, id string, v interface{}) (bool, error) {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return false, err
+	}
+	var data []byte
+	err = pool.QueryRow(ctx, fmt.Sprintf(`SELECT data FROM %s WHERE id = $1`, table), id).Scan(&data)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, documentCodec.Unmarshal(data, v)
+}
+
+// setDoc creates or replaces a row
+func (p *PostgresClient) setDoc(ctx context.Context, table, id string, v interface{}) error {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := documentCodec.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = pool.Exec(ctx, fmt.Sprintf(`INSERT INTO %s (id, data) VALUES ($1, $2)
+		ON CONFLICT (id) DO UPDATE SET data = EXCLUDED.data`, table), id, data)
+	return err
+}
+
+// createDoc inserts a row, reporting false when the ID is taken
+func (p *PostgresClient) createDoc(ctx context.Context, table, id string, v interface{}) (bool, error) {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return false, err
+	}
+	data, err := documentCodec.Marshal(v)
+	if err != nil {
+		return false, err
+	}
+	tag, err := pool.Exec(ctx, fmt.Sprintf(`INSERT INTO %s (id, data) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING`, table), id, data)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// mergeDoc sets top-level fields of a row, creating it when missing (like a Firestore MergeAll set)
+func (p *PostgresClient) mergeDoc(ctx context.Context, table, id string, fields map[string]interface{}) error {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := documentCodec.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	_, err = pool.Exec(ctx, fmt.Sprintf(`INSERT INTO %[1]s (id, data) VALUES ($1, $2)
+		ON CONFLICT (id) DO UPDATE SET data = %[1]s.data || EXCLUDED.data`, table), id, data)
+	return err
+}
+
+// updateDoc sets top-level fields of an existing row, failing with errDocumentNotFound when it's missing
+// (like a Firestore update)
+func (p *PostgresClient) updateDoc(ctx context.Context, table, id string, fields map[string]interface{}) error {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := documentCodec.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	tag, err := pool.Exec(ctx, fmt.Sprintf(`UPDATE %s SET data = data || $2::jsonb WHERE id = $1`, table), id, data)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errDocumentNotFound
+	}
+	return nil
+}
+
+// deleteDoc deletes a row; deleting a missing row is not an error
+func (p *PostgresClient) deleteDoc(ctx context.Context, table, id string) error {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = pool.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, table), id)
+	return err
+}
+
+// queryDocs runs a query selecting (id, data) and calls each for every row
+func (p *PostgresClient) queryDocs(ctx context.Context, query string, args []interface{}, each func(id string, data []byte) error) error {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var data []byte
+		if err := rows.Scan(&id, &data); err != nil {
+			return err
+		}
+		if err := each(id, data); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// setDocs creates or replaces rows in one round trip, by ID
+func (p *PostgresClient) setDocs(ctx context.Context, table string, docs map[string]interface{}) error {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return err
+	}
+	batch := &pgx.Batch{}
+	for id, v := range docs {
+		data, err := documentCodec.Marshal(v)
+		if err != nil {
+			return err
+		}
+		batch.Queue(fmt.Sprintf(`INSERT INTO %s (id, data) VALUES ($1, $2)
+			ON CONFLICT (id) DO UPDATE SET data = EXCLUDED.data`, table), id, data)
+	}
+	return pool.SendBatch(ctx, batch).Close()
+}
+
+// CreateUser creates a new user under a random ID, failing with ErrUserExists when the email is taken,
+// and ErrGoogleIDTaken when the user's Google account is linked to another user
+func (p *PostgresClient) CreateUser(ctx context.Context, user *models.User) error {
+	user.TenantID = utils.TenantFromContext(ctx)
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+
+	id := uuid.NewString()
+	created, err := p.createDoc(ctx, usersCollection, id, user)
+	if err != nil {
+		switch {
+		case isUniqueViolation(err, "users_email_key"):
+			return ErrUserExists
+		case isUniqueViolation(err, "users_google_id_key"):
+			return ErrGoogleIDTaken
+		}
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+	if !created {
+		return ErrUserExists
+	}
+
+	user.ID = id
+	return nil
+}
+
+// GetUserByEmail retrieves a user by email
+func (p *PostgresClient) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	return p.findUser(ctx, `SELECT id, data FROM users WHERE data->>'email' = $1 LIMIT 1`, email)
+}
+
+// GetUserByGoogleID retrieves a user by Google ID
+func (p *PostgresClient) GetUserByGoogleID(ctx context.Context, googleID string) (*models.User, error) {
+	return p.findUser(ctx, `SELECT id, data FROM users WHERE data->>'googleId' = $1 LIMIT 1`, googleID)
+}
+
+func (p *PostgresClient) findUser(ctx context.Context, query string, arg string) (*models.User, error) {
+	var user *models.User
+	err := p.queryDocs(ctx, query, []interface{}{arg}, func(id string, data []byte) error {
+		user = &models.User{}
+		if err := documentCodec.Unmarshal(data, user); err != nil {
+			return fmt.Errorf("failed to parse user data: %w", err)
+		}
+		user.ID = id
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// UpdateUser merges fields into a user, failing with ErrUserNotFound when the user doesn't exist
+func (p *PostgresClient) UpdateUser(ctx context.Context, email string, updates map[string]interface{}) error {
+	updates["updatedAt"] = time.Now()
+
+	if err := p.updateUserFields(ctx, email, updates); err != nil {
+		if errors.Is(err, errDocumentNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	return nil
+}
+
+// updateUserFields sets top-level fields of the user with the email, failing with errDocumentNotFound
+// when there is none
+func (p *PostgresClient) updateUserFields(ctx context.Context, email string, fields map[string]interface{}) error {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := documentCodec.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	tag, err := pool.Exec(ctx, `UPDATE users SET data = data || $2::jsonb WHERE data->>'email' = $1`, email, data)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errDocumentNotFound
+	}
+	return nil
+}
+
+// UpdateUserCVUrl updates user's CV URL
+func (p *PostgresClient) UpdateUserCVUrl(ctx context.Context, email, cvUrl string) error {
+	return p.UpdateUser(ctx, email, map[string]interface{}{
+		"cvUrl": cvUrl,
+	})
+}
+
+// UpdateUserPhotoUrl updates user's profile photo URL
+func (p *PostgresClient) UpdateUserPhotoUrl(ctx context.Context, email, photoUrl string) error {
+	return p.UpdateUser(ctx, email, map[string]interface{}{
+		"photoUrl": photoUrl,
+	})
+}
+
+// UpdateUserProfile updates user's profile (nama, incognito setting)
+func (p *PostgresClient) UpdateUserProfile(ctx context.Context, email string, nama string, incognito *bool) error {
+	updates := map[string]interface{}{}
+	if nama != "" {
+		updates["nama"] = nama
+	}
+	if incognito != nil {
+		updates["incognito"] = *incognito
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	return p.UpdateUser(ctx, email, updates)
+}
+
+// UpdateUserBlockedCompanies replaces the companies excluded from the user's searches
+func (p *PostgresClient) UpdateUserBlockedCompanies(ctx context.Context, email string, companies []string) error {
+	return p.UpdateUser(ctx, email, map[string]interface{}{
+		"blockedCompanies": companies,
+	})
+}
+
+// UpdateUserWebhook sets the URL and signing secret of the user's webhook; empty values remove it
+func (p *PostgresClient) UpdateUserWebhook(ctx context.Context, email, url, secret string) error {
+	return p.UpdateUser(ctx, email, map[string]interface{}{
+		"webhookUrl":    url,
+		"webhookSecret": secret,
+	})
+}
+
+// ListCVUrls returns the CV URL of every user who has one (used by the CV retention worker)
+func (p *PostgresClient) ListCVUrls(ctx context.Context) ([]string, error) {
+	urls := make([]string, 0)
+	err := p.queryDocs(ctx, `SELECT id, data FROM users WHERE COALESCE(data->>'cvUrl', '') <> ''`, nil,
+		func(id string, data []byte) error {
+			var user models.User
+			if err := documentCodec.Unmarshal(data, &user); err != nil {
+				return fmt.Errorf("failed to parse user: %w", err)
+			}
+			urls = append(urls, user.CVUrl)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query CV URLs: %w", err)
+	}
+	return urls, nil
+}
+
+// LinkGoogleID links a Google account to an existing user and marks it as a Google account, failing
+// with ErrGoogleIDTaken when the Google account is linked to another user
+func (p *PostgresClient) LinkGoogleID(ctx context.Context, email, googleID string) error {
+	err := p.updateUserFields(ctx, email, map[string]interface{}{
+		"googleId":  googleID,
+		"provider":  "google",
+		"updatedAt": time.Now(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errDocumentNotFound):
+			return ErrUserNotFound
+		case isUniqueViolation(err, "users_google_id_key"):
+			return ErrGoogleIDTaken
+		}
+		return fmt.Errorf("failed to link Google account: %w", err)
+	}
+	return nil
+}
+
+// isUniqueViolation reports whether a write failed because it would duplicate a value of the unique index
+func isUniqueViolation(err error, index string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == index
+}
+
+// DeleteUser deletes a user
+func (p *PostgresClient) DeleteUser(ctx context.Context, email string) error {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	if _, err := pool.Exec(ctx, `DELETE FROM users WHERE data->>'email' = $1`, email); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// SaveLLMDebugRecord stores a recorded Gemini call
+func (p *PostgresClient) SaveLLMDebugRecord(ctx context.Context, record *models.LLMDebugRecord) error {
+	id := newDocID()
+	if err := p.setDoc(ctx, llmDebugCollection, id, record); err != nil {
+		return fmt.Errorf("failed to save LLM debug record: %w", err)
+	}
+
+	record.ID = id
+	return nil
+}
+
+// ListLLMDebugRecords returns the Gemini calls recorded for a request, oldest first
+func (p *PostgresClient) ListLLMDebugRecords(ctx context.Context, requestID string) ([]models.LLMDebugRecord, error) {
+	records := make([]models.LLMDebugRecord, 0)
+	err := p.queryDocs(ctx, `SELECT id, data FROM llm_debug WHERE data->>'requestId' = $1`, []interface{}{requestID},
+		func(id string, data []byte) error {
+			var record models.LLMDebugRecord
+			if err := documentCodec.Unmarshal(data, &record); err != nil {
+				return fmt.Errorf("failed to parse LLM debug record: %w", err)
+			}
+			record.ID = id
+			records = append(records, record)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query LLM debug records: %w", err)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+	return records, nil
+}
+
+// SaveToolAuditRecord stores a recorded tool call
+func (p *PostgresClient) SaveToolAuditRecord(ctx context.Context, record *models.ToolAuditRecord) error {
+	id := newDocID()
+	if err := p.setDoc(ctx, toolAuditCollection, id, record); err != nil {
+		return fmt.Errorf("failed to save tool audit record: %w", err)
+	}
+
+	record.ID = id
+	return nil
+}
+
+// ListToolAuditRecords returns the tool calls matching the query, newest first
+func (p *PostgresClient) ListToolAuditRecords(ctx context.Context, query models.ToolAuditQuery) ([]models.ToolAuditRecord, error) {
+	conditions := []string{`(data->>'createdAt')::timestamptz >= $1`}
+	args := []interface{}{query.Since}
+	for _, filter := range []struct{ field, value string }{
+		{"tool", query.Tool},
+		{"callerEmail", query.CallerEmail},
+		{"apiTokenId", query.APITokenID},
+	} {
+		if filter.value != "" {
+			args = append(args, filter.value)
+			conditions = append(conditions, fmt.Sprintf(`data->>'%s' = $%d`, filter.field, len(args)))
+		}
+	}
+	args = append(args, query.Limit)
+	sql := fmt.Sprintf(`SELECT id, data FROM tool_audit WHERE %s
+		ORDER BY (data->>'createdAt')::timestamptz DESC LIMIT $%d`, strings.Join(conditions, " AND "), len(args))
+
+	records := make([]models.ToolAuditRecord, 0)
+	err := p.queryDocs(ctx, sql, args, func(id string, data []byte) error {
+		var record models.ToolAuditRecord
+		if err := documentCodec.Unmarshal(data, &record); err != nil {
+			return fmt.Errorf("failed to parse tool audit record: %w", err)
+		}
+		record.ID = id
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tool audit records: %w", err)
+	}
+	return records, nil
+}
+
+// ListToolSettings returns the stored tool switches, which are shared by every tenant
+func (p *PostgresClient) ListToolSettings(ctx context.Context) ([]models.ToolSetting, error) {
+	ctx = utils.WithTenant(ctx, "")
+	settings := make([]models.ToolSetting, 0)
+	err := p.queryDocs(ctx, `SELECT id, data FROM tool_settings`, nil, func(id string, data []byte) error {
+		var setting models.ToolSetting
+		if err := documentCodec.Unmarshal(data, &setting); err != nil {
+			return fmt.Errorf("failed to parse tool setting: %w", err)
+		}
+		setting.Name = id
+		settings = append(settings, setting)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tool settings: %w", err)
+	}
+	return settings, nil
+}
+
+// SaveToolSetting stores a tool switch shared by every tenant, replacing the previous one
+func (p *PostgresClient) SaveToolSetting(ctx context.Context, setting *models.ToolSetting) error {
+	ctx = utils.WithTenant(ctx, "")
+	if err := p.setDoc(ctx, toolSettingsCollection, setting.Name, setting); err != nil {
+		return fmt.Errorf("failed to save tool setting: %w", err)
+	}
+	return nil
+}
+
+// SavePromptExperimentRecord stores the outcome of a search run with a prompt experiment variant
+func (p *PostgresClient) SavePromptExperimentRecord(ctx context.Context, record *models.PromptExperimentRecord) error {
+	record.CreatedAt = time.Now()
+
+	if err := p.setDoc(ctx, promptExperimentsCollection, newDocID(), record); err != nil {
+		return fmt.Errorf("failed to save prompt experiment record: %w", err)
+	}
+	return nil
+}
+
+// ListPromptExperimentRecords returns the prompt experiment records created since the given time
+func (p *PostgresClient) ListPromptExperimentRecords(ctx context.Context, since time.Time) ([]models.PromptExperimentRecord, error) {
+	records := make([]models.PromptExperimentRecord, 0)
+	err := p.queryDocs(ctx, `SELECT id, data FROM prompt_experiments WHERE (data->>'createdAt')::timestamptz >= $1`, []interface{}{since},
+		func(id string, data []byte) error {
+			var record models.PromptExperimentRecord
+			if err := documentCodec.Unmarshal(data, &record); err != nil {
+				return fmt.Errorf("failed to parse prompt experiment record: %w", err)
+			}
+			records = append(records, record)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prompt experiment records: %w", err)
+	}
+	return records, nil
+}
+
+// ListExperimentFeedback returns job ratings updated since the given time on jobs scored by a prompt experiment variant
+func (p *PostgresClient) ListExperimentFeedback(ctx context.Context, since time.Time) ([]models.JobFeedback, error) {
+	return p.queryJobFeedback(ctx, `SELECT id, data FROM job_feedback
+		WHERE (data->>'updatedAt')::timestamptz >= $1 AND COALESCE(data->>'promptVariant', '') <> ''`, since)
+}
+
+// ReserveIdempotencyKey stores a running record unless an unexpired record has the same scope and key,
+// in which case it stores nothing and returns that record
+func (p *PostgresClient) ReserveIdempotencyKey(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, error) {
+	record.Completed = false
+	record.CreatedAt = time.Now()
+
+	pool, err := p.db(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	data, err := documentCodec.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	id := idempotencyDocID(record.Scope, record.Key)
+	// The existing record can expire or be released between the insert and the read, so try twice
+	for attempt := 0; attempt < 2; attempt++ {
+		tag, err := pool.Exec(ctx, `INSERT INTO idempotency_keys (id, data) VALUES ($1, $2)
+			ON CONFLICT (id) DO UPDATE SET data = EXCLUDED.data
+			WHERE (idempotency_keys.data->>'expiresAt')::timestamptz <= now()`, id, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+		if tag.RowsAffected() == 1 {
+			return nil, nil
+		}
+
+		var existing models.IdempotencyRecord
+		found, err := p.getDoc(ctx, idempotencyKeysCollection, id, &existing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+		if found && time.Now().Before(existing.ExpiresAt) {
+			return &existing, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to reserve idempotency key: record changed concurrently")
+}
+
+// CompleteIdempotencyKey replaces a reservation with the completed record
+func (p *PostgresClient) CompleteIdempotencyKey(ctx context.Context, record *models.IdempotencyRecord) error {
+	record.Completed = true
+
+	if err := p.setDoc(ctx, idempotencyKeysCollection, idempotencyDocID(record.Scope, record.Key), record); err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey deletes a record, so the next request with its key runs again
+func (p *PostgresClient) ReleaseIdempotencyKey(ctx context.Context, scope, key string) error {
+	if err := p.deleteDoc(ctx, idempotencyKeysCollection, idempotencyDocID(scope, key)); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/myjobmatch/backend/matching"
+	"github.com/myjobmatch/backend/models"
+)
+
+// postgresEmbeddingsSchema stores embeddings in their own table, with vectors as REAL arrays rather than
+// JSON documents, so they are read without parsing
+var postgresEmbeddingsSchema = []string{
+	`CREATE TABLE IF NOT EXISTS embeddings (
+		kind TEXT NOT NULL,
+		id TEXT NOT NULL,
+		model TEXT NOT NULL,
+		content_hash TEXT NOT NULL,
+		vector REAL[] NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (kind, id))`,
+	`CREATE INDEX IF NOT EXISTS embeddings_kind_model ON embeddings (kind, model)`,
+}
+
+// UpsertEmbeddings stores embeddings, replacing those of the same kind and ID
+func (p *PostgresClient) UpsertEmbeddings(ctx context.Context, embeddings []models.Embedding) error {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to store embeddings: %w", err)
+	}
+
+	now := time.Now()
+	batch := &pgx.Batch{}
+	for i := range embeddings {
+		embeddings[i].UpdatedAt = now
+		e := &embeddings[i]
+		batch.Queue(`INSERT INTO embeddings (kind, id, model, content_hash, vector, updated_at) VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (kind, id) DO UPDATE SET model = EXCLUDED.model, content_hash = EXCLUDED.content_hash,
+				vector = EXCLUDED.vector, updated_at = EXCLUDED.updated_at`,
+			e.Kind, e.ID, e.Model, e.ContentHash, e.Vector, now)
+	}
+	if err := pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to store embeddings: %w", err)
+	}
+	return nil
+}
+
+// GetEmbeddings returns the stored embeddings of a kind for the given IDs, by ID
+func (p *PostgresClient) GetEmbeddings(ctx context.Context, kind string, ids []string) (map[string]models.Embedding, error) {
+	embeddings := make(map[string]models.Embedding, len(ids))
+	if len(ids) == 0 {
+		return embeddings, nil
+	}
+
+	err := p.queryEmbeddings(ctx, `SELECT kind, id, model, content_hash, vector, updated_at FROM embeddings
+		WHERE kind = $1 AND id = ANY($2)`, []interface{}{kind, ids}, func(e models.Embedding) {
+		embeddings[e.ID] = e
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embeddings: %w", err)
+	}
+	return embeddings, nil
+}
+
+// FindNearestEmbeddings returns the stored embeddings of the query's kind and model most similar to its
+// vector, most similar first. Similarity is computed here over every embedding of the kind and model,
+// which is fine for the catalogues this backend is run with but scales with their size.
+func (p *PostgresClient) FindNearestEmbeddings(ctx context.Context, query models.EmbeddingQuery) ([]models.EmbeddingMatch, error) {
+	matches := make([]models.EmbeddingMatch, 0)
+	if query.Limit <= 0 || len(query.Vector) == 0 {
+		return matches, nil
+	}
+
+	err := p.queryEmbeddings(ctx, `SELECT kind, id, model, content_hash, vector, updated_at FROM embeddings
+		WHERE kind = $1 AND model = $2`, []interface{}{query.Kind, query.Model}, func(e models.Embedding) {
+		if len(e.Vector) == len(query.Vector) {
+			matches = append(matches, models.EmbeddingMatch{ID: e.ID, Similarity: matching.Cosine(query.Vector, e.Vector)})
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query embeddings: %w", err)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Similarity > matches[j].Similarity
+	})
+	if limit := min(query.Limit, maxNearestEmbeddings); len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// DeleteEmbeddings deletes the stored embeddings of a kind for the given IDs
+func (p *PostgresClient) DeleteEmbeddings(ctx context.Context, kind string, ids []string) error {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete embeddings: %w", err)
+	}
+	if _, err := pool.Exec(ctx, `DELETE FROM embeddings WHERE kind = $1 AND id = ANY($2)`, kind, ids); err != nil {
+		return fmt.Errorf("failed to delete embeddings: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresClient) queryEmbeddings(ctx context.Context, query string, args []interface{}, each func(models.Embedding)) error {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e models.Embedding
+		if err := rows.Scan(&e.Kind, &e.ID, &e.Model, &e.ContentHash, &e.Vector, &e.UpdatedAt); err != nil {
+			return err
+		}
+		each(e)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,383 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// SaveJobs stores postings under their stable IDs, refreshing postings that are already stored.
+// Postings without an ID are skipped.
+func (p *PostgresClient) SaveJobs(ctx context.Context, jobs []models.JobPosting) error {
+	now := time.Now()
+	batch := &pgx.Batch{}
+	for _, job := range jobs {
+		if job.ID == "" {
+			continue
+		}
+		data, err := documentCodec.Marshal(models.StoredJob{
+			Job:         job,
+			TenantID:    utils.TenantFromContext(ctx),
+			FirstSeenAt: now,
+			LastSeenAt:  now,
+			Keywords:    models.JobKeywords(job),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to encode job: %w", err)
+		}
+		// Keep firstSeenAt; replace the posting with the latest extraction
+		batch.Queue(`INSERT INTO jobs (id, data) VALUES ($1, $2)
+			ON CONFLICT (id) DO UPDATE SET data = jobs.data || jsonb_build_object(
+				'job', EXCLUDED.data->'job',
+				'lastSeenAt', EXCLUDED.data->'lastSeenAt',
+				'keywords', EXCLUDED.data->'keywords')`, job.ID, data)
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	pool, err := p.db(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to store jobs: %w", err)
+	}
+	if err := pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to store jobs: %w", err)
+	}
+	return nil
+}
+
+// GetJob returns a stored job by its stable ID
+func (p *PostgresClient) GetJob(ctx context.Context, id string) (*models.StoredJob, error) {
+	var stored models.StoredJob
+	found, err := p.getDoc(ctx, jobsCollection, id, &stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if !found {
+		return nil, ErrJobNotFound
+	}
+
+	stored.Job.ID = id
+	return &stored, nil
+}
+
+// GetJobByURL returns the stored job for a posting URL
+func (p *PostgresClient) GetJobByURL(ctx context.Context, url string) (*models.StoredJob, error) {
+	return p.GetJob(ctx, utils.JobID(url))
+}
+
+// SaveJobScores records a user's latest match scores for ranked jobs, with the prompt experiment variant
+// that scored them (empty if none). Jobs without an ID are skipped.
+func (p *PostgresClient) SaveJobScores(ctx context.Context, userID string, jobs []models.RankedJob, promptVariant string) error {
+	now := time.Now()
+	docs := make(map[string]interface{}, len(jobs))
+	for _, job := range jobs {
+		if job.ID == "" {
+			continue
+		}
+		docs[jobScoreDocID(userID, job.ID)] = models.JobScore{
+			JobID:          job.ID,
+			UserID:         userID,
+			MatchScore:     job.MatchScore,
+			MatchReason:    job.MatchReason,
+			ScoreMethod:    job.ScoreMethod,
+			ScoreBreakdown: job.ScoreBreakdown,
+			AIScore:        job.AIScore,
+			HeuristicScore: job.HeuristicScore,
+			SkillsMatch:    job.SkillsMatch,
+			PromptVariant:  promptVariant,
+			ScoredAt:       now,
+		}
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+
+	if err := p.setDocs(ctx, jobScoresCollection, docs); err != nil {
+		return fmt.Errorf("failed to store job scores: %w", err)
+	}
+	return nil
+}
+
+// GetJobScore returns a user's latest match score for a job
+func (p *PostgresClient) GetJobScore(ctx context.Context, userID, jobID string) (*models.JobScore, error) {
+	var score models.JobScore
+	found, err := p.getDoc(ctx, jobScoresCollection, jobScoreDocID(userID, jobID), &score)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job score: %w", err)
+	}
+	if !found {
+		return nil, ErrJobScoreNotFound
+	}
+	return &score, nil
+}
+
+// GetJobs returns the stored jobs for the given IDs, keyed by ID; unknown IDs are omitted
+func (p *PostgresClient) GetJobs(ctx context.Context, ids []string) (map[string]models.StoredJob, error) {
+	jobs := make(map[string]models.StoredJob, len(ids))
+	if len(ids) == 0 {
+		return jobs, nil
+	}
+
+	err := p.queryDocs(ctx, `SELECT id, data FROM jobs WHERE id = ANY($1)`, []interface{}{ids}, func(id string, data []byte) error {
+		var stored models.StoredJob
+		if err := documentCodec.Unmarshal(data, &stored); err != nil {
+			return fmt.Errorf("failed to parse job: %w", err)
+		}
+		stored.Job.ID = id
+		jobs[id] = stored
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// GetJobScores returns a user's scores for the given job IDs, keyed by job ID; unscored jobs are omitted
+func (p *PostgresClient) GetJobScores(ctx context.Context, userID string, jobIDs []string) (map[string]models.JobScore, error) {
+	scores := make(map[string]models.JobScore, len(jobIDs))
+	if len(jobIDs) == 0 {
+		return scores, nil
+	}
+
+	docIDs := make([]string, len(jobIDs))
+	for i, id := range jobIDs {
+		docIDs[i] = jobScoreDocID(userID, id)
+	}
+
+	err := p.queryDocs(ctx, `SELECT id, data FROM job_scores WHERE id = ANY($1)`, []interface{}{docIDs}, func(id string, data []byte) error {
+		var score models.JobScore
+		if err := documentCodec.Unmarshal(data, &score); err != nil {
+			return fmt.Errorf("failed to parse job score: %w", err)
+		}
+		scores[score.JobID] = score
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job scores: %w", err)
+	}
+	return scores, nil
+}
+
+// FindJobsByTags returns up to limit stored jobs sharing at least one tag with the given tags
+func (p *PostgresClient) FindJobsByTags(ctx context.Context, tags []string, limit int) ([]models.StoredJob, error) {
+	if len(tags) == 0 {
+		return []models.StoredJob{}, nil
+	}
+	return p.queryJobs(ctx, `SELECT id, data FROM jobs WHERE data->'job'->'Tags' ?| $1 LIMIT $2`, tags, limit)
+}
+
+// ListRecentJobs returns up to limit stored jobs, most recently seen first
+func (p *PostgresClient) ListRecentJobs(ctx context.Context, limit int) ([]models.StoredJob, error) {
+	return p.queryJobs(ctx, `SELECT id, data FROM jobs
+		ORDER BY (data->>'lastSeenAt')::timestamptz DESC LIMIT $1`, limit)
+}
+
+// ListJobsSeenSince returns up to limit stored jobs returned by a search since the given time, most recent first
+func (p *PostgresClient) ListJobsSeenSince(ctx context.Context, since time.Time, limit int) ([]models.StoredJob, error) {
+	return p.queryJobs(ctx, `SELECT id, data FROM jobs WHERE (data->>'lastSeenAt')::timestamptz >= $1
+		ORDER BY (data->>'lastSeenAt')::timestamptz DESC LIMIT $2`, since, limit)
+}
+
+// QueryJobs returns the stored jobs matching the query, most recently seen first
+func (p *PostgresClient) QueryJobs(ctx context.Context, query models.JobQuery) ([]models.StoredJob, error) {
+	conditions := []string{`(data->>'lastSeenAt')::timestamptz >= $1`}
+	args := []interface{}{query.Since}
+	for _, filter := range []struct{ field, value string }{
+		{"Company", query.Company},
+		{"WorkType", query.WorkType},
+		{"SiteSetting", query.SiteSetting},
+		{"Source", query.Source},
+	} {
+		if filter.value != "" {
+			args = append(args, filter.value)
+			conditions = append(conditions, fmt.Sprintf(`data->'job'->>'%s' = $%d`, filter.field, len(args)))
+		}
+	}
+	if query.Location != "" {
+		args = append(args, strings.ToLower(query.Location))
+		conditions = append(conditions, fmt.Sprintf(`strpos(lower(data->'job'->>'Location'), $%d) > 0`, len(args)))
+	}
+	args = append(args, query.Limit)
+	sql := fmt.Sprintf(`SELECT id, data FROM jobs WHERE %s
+		ORDER BY (data->>'lastSeenAt')::timestamptz DESC LIMIT $%d`, strings.Join(conditions, " AND "), len(args))
+
+	return p.queryJobs(ctx, sql, args...)
+}
+
+// SearchJobIndex returns up to limit stored postings seen since the given time that have any of the
+// keywords, most recently seen first
+func (p *PostgresClient) SearchJobIndex(ctx context.Context, keywords []string, since time.Time, limit int) ([]models.JobPosting, error) {
+	if len(keywords) == 0 {
+		return []models.JobPosting{}, nil
+	}
+
+	stored, err := p.queryJobs(ctx, `SELECT id, data FROM jobs
+		WHERE data->'keywords' ?| $1 AND (data->>'lastSeenAt')::timestamptz >= $2
+		ORDER BY (data->>'lastSeenAt')::timestamptz DESC LIMIT $3`, keywords, since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]models.JobPosting, len(stored))
+	for i, s := range stored {
+		jobs[i] = s.Job
+	}
+	return jobs, nil
+}
+
+// queryJobs reads stored jobs from a query
+func (p *PostgresClient) queryJobs(ctx context.Context, query string, args ...interface{}) ([]models.StoredJob, error) {
+	jobs := make([]models.StoredJob, 0)
+	err := p.queryDocs(ctx, query, args, func(id string, data []byte) error {
+		var stored models.StoredJob
+		if err := documentCodec.Unmarshal(data, &stored); err != nil {
+			return fmt.Errorf("failed to parse job: %w", err)
+		}
+		stored.Job.ID = id
+		jobs = append(jobs, stored)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// GetCachedJobs returns the unexpired cached postings for the given keys
+func (p *PostgresClient) GetCachedJobs(ctx context.Context, keys []string) (map[string]models.JobPosting, error) {
+	jobs := make(map[string]models.JobPosting, len(keys))
+	if len(keys) == 0 {
+		return jobs, nil
+	}
+
+	now := time.Now()
+	err := p.queryDocs(ctx, `SELECT id, data FROM job_cache WHERE id = ANY($1)`, []interface{}{hashDocIDs(keys)}, func(id string, data []byte) error {
+		var entry cachedJob
+		if err := documentCodec.Unmarshal(data, &entry); err != nil {
+			return fmt.Errorf("failed to parse cached job: %w", err)
+		}
+		if !now.After(entry.ExpiresAt) {
+			jobs[entry.Key] = entry.Job
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job cache: %w", err)
+	}
+	return jobs, nil
+}
+
+// CacheJobs stores postings in the job cache, replacing any existing entries for the same keys
+func (p *PostgresClient) CacheJobs(ctx context.Context, jobs map[string]models.JobPosting, ttl time.Duration) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	docs := make(map[string]interface{}, len(jobs))
+	for key, job := range jobs {
+		docs[hashDocID(key)] = cachedJob{
+			Key:       key,
+			Job:       job,
+			CachedAt:  now,
+			ExpiresAt: now.Add(ttl),
+		}
+	}
+
+	if err := p.setDocs(ctx, jobCacheCollection, docs); err != nil {
+		return fmt.Errorf("failed to write job cache: %w", err)
+	}
+	return nil
+}
+
+// GetCachedCompanies returns the unexpired company information for the given keys
+func (p *PostgresClient) GetCachedCompanies(ctx context.Context, keys []string) (map[string]models.Company, error) {
+	companies := make(map[string]models.Company, len(keys))
+	if len(keys) == 0 {
+		return companies, nil
+	}
+
+	now := time.Now()
+	err := p.queryDocs(ctx, `SELECT id, data FROM companies WHERE id = ANY($1)`, []interface{}{hashDocIDs(keys)}, func(id string, data []byte) error {
+		var entry cachedCompany
+		if err := documentCodec.Unmarshal(data, &entry); err != nil {
+			return fmt.Errorf("failed to parse cached company: %w", err)
+		}
+		if !now.After(entry.ExpiresAt) {
+			companies[entry.Key] = entry.Company
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read company cache: %w", err)
+	}
+	return companies, nil
+}
+
+// CacheCompanies stores company information by key, replacing any existing entries
+func (p *PostgresClient) CacheCompanies(ctx context.Context, companies map[string]models.Company, ttl time.Duration) error {
+	if len(companies) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	docs := make(map[string]interface{}, len(companies))
+	for key, company := range companies {
+		docs[hashDocID(key)] = cachedCompany{
+			Key:       key,
+			Company:   company,
+			CachedAt:  now,
+			ExpiresAt: now.Add(ttl),
+		}
+	}
+
+	if err := p.setDocs(ctx, companiesCollection, docs); err != nil {
+		return fmt.Errorf("failed to write company cache: %w", err)
+	}
+	return nil
+}
+
+// GetJobSummary returns the cached summary for a key
+func (p *PostgresClient) GetJobSummary(ctx context.Context, key string) (*models.JobSummary, error) {
+	var entry cachedJobSummary
+	found, err := p.getDoc(ctx, jobSummariesCollection, hashDocID(key), &entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job summary: %w", err)
+	}
+	if !found || time.Now().After(entry.ExpiresAt) {
+		return nil, ErrJobSummaryNotFound
+	}
+	return &entry.Summary, nil
+}
+
+// CacheJobSummary stores a summary by key for the given TTL, replacing any existing entry
+func (p *PostgresClient) CacheJobSummary(ctx context.Context, key string, summary *models.JobSummary, ttl time.Duration) error {
+	now := time.Now()
+	entry := cachedJobSummary{
+		Key:       key,
+		Summary:   *summary,
+		CachedAt:  now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := p.setDoc(ctx, jobSummariesCollection, hashDocID(key), entry); err != nil {
+		return fmt.Errorf("failed to cache job summary: %w", err)
+	}
+	return nil
+}
+
+// hashDocIDs returns the document IDs of cache keys
+func hashDocIDs(keys []string) []string {
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		ids[i] = hashDocID(key)
+	}
+	return ids
+}
@@ -0,0 +1,311 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// CreateAsyncSearch stores a new pending background search
+func (p *PostgresClient) CreateAsyncSearch(ctx context.Context, search *models.AsyncSearch) error {
+	search.TenantID = utils.TenantFromContext(ctx)
+	search.Status = models.SearchStatusPending
+	search.CreatedAt = time.Now()
+
+	id := newDocID()
+	if err := p.setDoc(ctx, searchesCollection, id, search); err != nil {
+		return fmt.Errorf("failed to create search: %w", err)
+	}
+
+	search.ID = id
+	return nil
+}
+
+// GetAsyncSearch retrieves a background search by ID
+func (p *PostgresClient) GetAsyncSearch(ctx context.Context, id string) (*models.AsyncSearch, error) {
+	var search models.AsyncSearch
+	found, err := p.getDoc(ctx, searchesCollection, id, &search)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search: %w", err)
+	}
+	if !found {
+		return nil, ErrSearchNotFound
+	}
+
+	search.ID = id
+	return &search, nil
+}
+
+// MarkAsyncSearchRunning records that a worker has picked up the search
+func (p *PostgresClient) MarkAsyncSearchRunning(ctx context.Context, id string) error {
+	return p.updateAsyncSearch(ctx, id, map[string]interface{}{
+		"status":    models.SearchStatusRunning,
+		"startedAt": time.Now(),
+	})
+}
+
+// CompleteAsyncSearch stores the results of a finished search
+func (p *PostgresClient) CompleteAsyncSearch(ctx context.Context, id string, result *models.SearchJobsResponse) error {
+	return p.updateAsyncSearch(ctx, id, map[string]interface{}{
+		"status":      models.SearchStatusCompleted,
+		"result":      result,
+		"completedAt": time.Now(),
+		"checkpoint":  nil,
+	})
+}
+
+// FailAsyncSearch records why a search failed
+func (p *PostgresClient) FailAsyncSearch(ctx context.Context, id string, searchErr error) error {
+	return p.updateAsyncSearch(ctx, id, map[string]interface{}{
+		"status":      models.SearchStatusFailed,
+		"error":       searchErr.Error(),
+		"completedAt": time.Now(),
+		"checkpoint":  nil,
+	})
+}
+
+// InterruptAsyncSearch records that a search was stopped by shutdown, and whether it can be resumed
+// from its checkpoint
+func (p *PostgresClient) InterruptAsyncSearch(ctx context.Context, id string, resumable bool) error {
+	return p.updateAsyncSearch(ctx, id, map[string]interface{}{
+		"status":      models.SearchStatusInterrupted,
+		"error":       interruptedSearchError,
+		"resumable":   resumable,
+		"completedAt": time.Now(),
+	})
+}
+
+// ResumeAsyncSearch puts an interrupted, resumable search back to pending. It fails with
+// ErrSearchNotResumable if the search isn't (or is no longer) resumable, so it's queued only once.
+func (p *PostgresClient) ResumeAsyncSearch(ctx context.Context, id string) error {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to resume search: %w", err)
+	}
+	data, err := documentCodec.Marshal(map[string]interface{}{
+		"status":      models.SearchStatusPending,
+		"error":       nil,
+		"resumable":   nil,
+		"startedAt":   nil,
+		"completedAt": nil,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resume search: %w", err)
+	}
+
+	tag, err := pool.Exec(ctx, `UPDATE searches SET data = data || $2::jsonb
+		WHERE id = $1 AND data->>'status' = $3 AND (data->>'resumable')::boolean AND data->'checkpoint' IS NOT NULL
+			AND data->'checkpoint' <> 'null'::jsonb`, id, data, models.SearchStatusInterrupted)
+	if err != nil {
+		return fmt.Errorf("failed to resume search: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		if _, err := p.GetAsyncSearch(ctx, id); err != nil {
+			return err
+		}
+		return ErrSearchNotResumable
+	}
+	return nil
+}
+
+func (p *PostgresClient) updateAsyncSearch(ctx context.Context, id string, updates map[string]interface{}) error {
+	if err := p.updateDoc(ctx, searchesCollection, id, updates); err != nil {
+		return fmt.Errorf("failed to update search: %w", err)
+	}
+	return nil
+}
+
+// AddSearchHistory records a completed search
+func (p *PostgresClient) AddSearchHistory(ctx context.Context, entry *models.SearchHistoryEntry) error {
+	entry.TenantID = utils.TenantFromContext(ctx)
+	entry.CreatedAt = time.Now()
+
+	id := newDocID()
+	if err := p.setDoc(ctx, searchHistoryCollection, id, entry); err != nil {
+		return fmt.Errorf("failed to add search history: %w", err)
+	}
+
+	entry.ID = id
+	return nil
+}
+
+// ListSearchHistory returns a user's most recent searches, newest first
+func (p *PostgresClient) ListSearchHistory(ctx context.Context, userID string, limit int) ([]models.SearchHistoryEntry, error) {
+	entries := make([]models.SearchHistoryEntry, 0)
+	err := p.queryDocs(ctx, `SELECT id, data FROM search_history WHERE data->>'userId' = $1`, []interface{}{userID},
+		func(id string, data []byte) error {
+			var entry models.SearchHistoryEntry
+			if err := documentCodec.Unmarshal(data, &entry); err != nil {
+				return fmt.Errorf("failed to parse search history: %w", err)
+			}
+			entry.ID = id
+			entries = append(entries, entry)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query search history: %w", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// DeleteSearchHistory deletes one of a user's history entries
+func (p *PostgresClient) DeleteSearchHistory(ctx context.Context, userID, id string) error {
+	var entry models.SearchHistoryEntry
+	found, err := p.getDoc(ctx, searchHistoryCollection, id, &entry)
+	if err != nil {
+		return fmt.Errorf("failed to get search history: %w", err)
+	}
+	if !found || entry.UserID != userID {
+		return ErrSearchHistoryNotFound
+	}
+
+	if err := p.deleteDoc(ctx, searchHistoryCollection, id); err != nil {
+		return fmt.Errorf("failed to delete search history: %w", err)
+	}
+	return nil
+}
+
+// CreateSharedSearch stores a share snapshot under its token
+func (p *PostgresClient) CreateSharedSearch(ctx context.Context, share *models.SharedSearch) error {
+	share.CreatedAt = time.Now()
+
+	created, err := p.createDoc(ctx, sharedSearchesCollection, share.Token, share)
+	if err != nil {
+		return fmt.Errorf("failed to create shared search: %w", err)
+	}
+	if !created {
+		return fmt.Errorf("failed to create shared search: token %s is taken", share.Token)
+	}
+	return nil
+}
+
+// GetSharedSearch retrieves a share snapshot by token
+func (p *PostgresClient) GetSharedSearch(ctx context.Context, token string) (*models.SharedSearch, error) {
+	var share models.SharedSearch
+	found, err := p.getDoc(ctx, sharedSearchesCollection, token, &share)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared search: %w", err)
+	}
+	if !found {
+		return nil, ErrSharedSearchNotFound
+	}
+
+	share.Token = token
+	return &share, nil
+}
+
+// ListSharedSearches returns a user's share links, newest first
+func (p *PostgresClient) ListSharedSearches(ctx context.Context, userID string) ([]models.SharedSearch, error) {
+	shares := make([]models.SharedSearch, 0)
+	err := p.queryDocs(ctx, `SELECT id, data FROM shared_searches WHERE data->>'userId' = $1`, []interface{}{userID},
+		func(id string, data []byte) error {
+			var share models.SharedSearch
+			if err := documentCodec.Unmarshal(data, &share); err != nil {
+				return fmt.Errorf("failed to parse shared search: %w", err)
+			}
+			share.Token = id
+			shares = append(shares, share)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shared searches: %w", err)
+	}
+
+	sort.Slice(shares, func(i, j int) bool {
+		return shares[i].CreatedAt.After(shares[j].CreatedAt)
+	})
+	return shares, nil
+}
+
+// DeleteSharedSearch revokes one of a user's share links
+func (p *PostgresClient) DeleteSharedSearch(ctx context.Context, userID, token string) error {
+	share, err := p.GetSharedSearch(ctx, token)
+	if err != nil {
+		return err
+	}
+	if share.UserID != userID {
+		return ErrSharedSearchNotFound
+	}
+
+	if err := p.deleteDoc(ctx, sharedSearchesCollection, token); err != nil {
+		return fmt.Errorf("failed to delete shared search: %w", err)
+	}
+	return nil
+}
+
+// SaveChatSession creates or replaces a conversation, assigning an ID to new ones.
+// The conversation expires ttl after this update.
+func (p *PostgresClient) SaveChatSession(ctx context.Context, session *models.ChatSession, ttl time.Duration) error {
+	now := time.Now()
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = now
+	}
+	session.UpdatedAt = now
+	session.ExpiresAt = now.Add(ttl)
+
+	id := session.ID
+	if id == "" {
+		id = newDocID()
+	}
+	if err := p.setDoc(ctx, chatSessionsCollection, id, session); err != nil {
+		return fmt.Errorf("failed to save chat session: %w", err)
+	}
+
+	session.ID = id
+	return nil
+}
+
+// GetChatSession returns a user's conversation
+func (p *PostgresClient) GetChatSession(ctx context.Context, userID, id string) (*models.ChatSession, error) {
+	var session models.ChatSession
+	found, err := p.getDoc(ctx, chatSessionsCollection, id, &session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat session: %w", err)
+	}
+	if !found || session.UserID != userID || time.Now().After(session.ExpiresAt) {
+		return nil, ErrChatSessionNotFound
+	}
+
+	session.ID = id
+	return &session, nil
+}
+
+// SaveSearchSession stores a recorded search, assigning its ID. The session expires ttl after creation.
+func (p *PostgresClient) SaveSearchSession(ctx context.Context, session *models.SearchSession, ttl time.Duration) error {
+	session.CreatedAt = time.Now()
+	session.ExpiresAt = session.CreatedAt.Add(ttl)
+
+	id := newDocID()
+	if err := p.setDoc(ctx, searchSessionsCollection, id, session); err != nil {
+		return fmt.Errorf("failed to save search session: %w", err)
+	}
+
+	session.ID = id
+	return nil
+}
+
+// GetSearchSession retrieves a recorded search by ID
+func (p *PostgresClient) GetSearchSession(ctx context.Context, id string) (*models.SearchSession, error) {
+	var session models.SearchSession
+	found, err := p.getDoc(ctx, searchSessionsCollection, id, &session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search session: %w", err)
+	}
+	if !found || time.Now().After(session.ExpiresAt) {
+		return nil, ErrSearchSessionNotFound
+	}
+
+	session.ID = id
+	return &session, nil
+}
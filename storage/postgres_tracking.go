@@ -0,0 +1,298 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// SaveJob bookmarks a job for a user
+func (p *PostgresClient) SaveJob(ctx context.Context, saved *models.SavedJob) error {
+	now := time.Now()
+	saved.SavedAt = now
+	saved.ScoredAt = now
+
+	id := newDocID()
+	if err := p.setDoc(ctx, savedJobsCollection, id, saved); err != nil {
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+
+	saved.ID = id
+	return nil
+}
+
+// ListSavedJobs returns a user's saved jobs, most recently saved first
+func (p *PostgresClient) ListSavedJobs(ctx context.Context, userID string) ([]models.SavedJob, error) {
+	jobs := make([]models.SavedJob, 0)
+	err := p.queryDocs(ctx, `SELECT id, data FROM saved_jobs WHERE data->>'userId' = $1`, []interface{}{userID},
+		func(id string, data []byte) error {
+			var saved models.SavedJob
+			if err := documentCodec.Unmarshal(data, &saved); err != nil {
+				return fmt.Errorf("failed to parse saved job: %w", err)
+			}
+			saved.ID = id
+			jobs = append(jobs, saved)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved jobs: %w", err)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].SavedAt.After(jobs[j].SavedAt)
+	})
+	return jobs, nil
+}
+
+// UpdateSavedJobScore stores a new match score for a saved job
+func (p *PostgresClient) UpdateSavedJobScore(ctx context.Context, saved *models.SavedJob) error {
+	saved.ScoredAt = time.Now()
+
+	if err := p.setDoc(ctx, savedJobsCollection, saved.ID, saved); err != nil {
+		return fmt.Errorf("failed to update saved job: %w", err)
+	}
+	return nil
+}
+
+// DeleteSavedJob removes one of a user's saved jobs
+func (p *PostgresClient) DeleteSavedJob(ctx context.Context, userID, id string) error {
+	var saved models.SavedJob
+	found, err := p.getDoc(ctx, savedJobsCollection, id, &saved)
+	if err != nil {
+		return fmt.Errorf("failed to get saved job: %w", err)
+	}
+	if !found || saved.UserID != userID {
+		return ErrSavedJobNotFound
+	}
+
+	if err := p.deleteDoc(ctx, savedJobsCollection, id); err != nil {
+		return fmt.Errorf("failed to delete saved job: %w", err)
+	}
+	return nil
+}
+
+// CreateApplication stores a new tracked application
+func (p *PostgresClient) CreateApplication(ctx context.Context, app *models.Application) error {
+	now := time.Now()
+	app.CreatedAt = now
+	app.UpdatedAt = now
+
+	id := newDocID()
+	if err := p.setDoc(ctx, applicationsCollection, id, app); err != nil {
+		return fmt.Errorf("failed to create application: %w", err)
+	}
+
+	app.ID = id
+	return nil
+}
+
+// GetApplication returns one of a user's applications
+func (p *PostgresClient) GetApplication(ctx context.Context, userID, id string) (*models.Application, error) {
+	var app models.Application
+	found, err := p.getDoc(ctx, applicationsCollection, id, &app)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	if !found || app.UserID != userID {
+		return nil, ErrApplicationNotFound
+	}
+
+	app.ID = id
+	return &app, nil
+}
+
+// ListApplications returns a user's applications, most recently updated first
+func (p *PostgresClient) ListApplications(ctx context.Context, userID string) ([]models.Application, error) {
+	apps := make([]models.Application, 0)
+	err := p.queryDocs(ctx, `SELECT id, data FROM applications WHERE data->>'userId' = $1`, []interface{}{userID},
+		func(id string, data []byte) error {
+			var app models.Application
+			if err := documentCodec.Unmarshal(data, &app); err != nil {
+				return fmt.Errorf("failed to parse application: %w", err)
+			}
+			app.ID = id
+			apps = append(apps, app)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applications: %w", err)
+	}
+
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].UpdatedAt.After(apps[j].UpdatedAt)
+	})
+	return apps, nil
+}
+
+// SaveApplication overwrites an existing application
+func (p *PostgresClient) SaveApplication(ctx context.Context, app *models.Application) error {
+	app.UpdatedAt = time.Now()
+
+	if err := p.setDoc(ctx, applicationsCollection, app.ID, app); err != nil {
+		return fmt.Errorf("failed to save application: %w", err)
+	}
+	return nil
+}
+
+// DeleteApplication deletes one of a user's applications
+func (p *PostgresClient) DeleteApplication(ctx context.Context, userID, id string) error {
+	if _, err := p.GetApplication(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := p.deleteDoc(ctx, applicationsCollection, id); err != nil {
+		return fmt.Errorf("failed to delete application: %w", err)
+	}
+	return nil
+}
+
+// CreateAlert stores a new job alert
+func (p *PostgresClient) CreateAlert(ctx context.Context, alert *models.JobAlert) error {
+	alert.CreatedAt = time.Now()
+
+	id := newDocID()
+	if err := p.setDoc(ctx, alertsCollection, id, alert); err != nil {
+		return fmt.Errorf("failed to create job alert: %w", err)
+	}
+
+	alert.ID = id
+	return nil
+}
+
+// ListAlerts returns a user's job alerts, newest first
+func (p *PostgresClient) ListAlerts(ctx context.Context, userID string) ([]models.JobAlert, error) {
+	alerts, err := p.queryAlerts(ctx, `SELECT id, data FROM job_alerts WHERE data->>'userId' = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].CreatedAt.After(alerts[j].CreatedAt)
+	})
+	return alerts, nil
+}
+
+// ListDueAlerts returns every alert whose next run is at or before the given time (used by the alert scheduler)
+func (p *PostgresClient) ListDueAlerts(ctx context.Context, now time.Time) ([]models.JobAlert, error) {
+	return p.queryAlerts(ctx, `SELECT id, data FROM job_alerts WHERE (data->>'nextRunAt')::timestamptz <= $1`, now)
+}
+
+func (p *PostgresClient) queryAlerts(ctx context.Context, query string, args ...interface{}) ([]models.JobAlert, error) {
+	alerts := make([]models.JobAlert, 0)
+	err := p.queryDocs(ctx, query, args, func(id string, data []byte) error {
+		var alert models.JobAlert
+		if err := documentCodec.Unmarshal(data, &alert); err != nil {
+			return fmt.Errorf("failed to parse job alert: %w", err)
+		}
+		alert.ID = id
+		alerts = append(alerts, alert)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job alerts: %w", err)
+	}
+	return alerts, nil
+}
+
+// DeleteAlert deletes one of a user's job alerts
+func (p *PostgresClient) DeleteAlert(ctx context.Context, userID, id string) error {
+	var alert models.JobAlert
+	found, err := p.getDoc(ctx, alertsCollection, id, &alert)
+	if err != nil {
+		return fmt.Errorf("failed to get job alert: %w", err)
+	}
+	if !found || alert.UserID != userID {
+		return ErrAlertNotFound
+	}
+
+	if err := p.deleteDoc(ctx, alertsCollection, id); err != nil {
+		return fmt.Errorf("failed to delete job alert: %w", err)
+	}
+	return nil
+}
+
+// UpdateAlertRun records the result of a scheduled run: the posting URLs seen so far and when to run next
+func (p *PostgresClient) UpdateAlertRun(ctx context.Context, id string, seenURLs []string, lastRunAt, nextRunAt time.Time) error {
+	err := p.mergeDoc(ctx, alertsCollection, id, map[string]interface{}{
+		"seenUrls":  seenURLs,
+		"lastRunAt": lastRunAt,
+		"nextRunAt": nextRunAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update job alert: %w", err)
+	}
+	return nil
+}
+
+// AddWatchedCompany adds a company to a user's watchlist
+func (p *PostgresClient) AddWatchedCompany(ctx context.Context, company *models.WatchedCompany) error {
+	company.CreatedAt = time.Now()
+
+	id := newDocID()
+	if err := p.setDoc(ctx, watchlistCollection, id, company); err != nil {
+		return fmt.Errorf("failed to add watched company: %w", err)
+	}
+
+	company.ID = id
+	return nil
+}
+
+// ListWatchedCompanies returns the companies a user follows
+func (p *PostgresClient) ListWatchedCompanies(ctx context.Context, userID string) ([]models.WatchedCompany, error) {
+	return p.queryWatchedCompanies(ctx, `SELECT id, data FROM watchlist WHERE data->>'userId' = $1`, userID)
+}
+
+// ListAllWatchedCompanies returns every watched company across all users (used by the watchlist worker)
+func (p *PostgresClient) ListAllWatchedCompanies(ctx context.Context) ([]models.WatchedCompany, error) {
+	return p.queryWatchedCompanies(ctx, `SELECT id, data FROM watchlist`)
+}
+
+func (p *PostgresClient) queryWatchedCompanies(ctx context.Context, query string, args ...interface{}) ([]models.WatchedCompany, error) {
+	companies := make([]models.WatchedCompany, 0)
+	err := p.queryDocs(ctx, query, args, func(id string, data []byte) error {
+		var company models.WatchedCompany
+		if err := documentCodec.Unmarshal(data, &company); err != nil {
+			return fmt.Errorf("failed to parse watched company: %w", err)
+		}
+		company.ID = id
+		companies = append(companies, company)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query watchlist: %w", err)
+	}
+	return companies, nil
+}
+
+// DeleteWatchedCompany removes a company from a user's watchlist
+func (p *PostgresClient) DeleteWatchedCompany(ctx context.Context, userID, id string) error {
+	var company models.WatchedCompany
+	found, err := p.getDoc(ctx, watchlistCollection, id, &company)
+	if err != nil {
+		return fmt.Errorf("failed to get watched company: %w", err)
+	}
+	if !found || company.UserID != userID {
+		return errors.New("watched company not found")
+	}
+
+	if err := p.deleteDoc(ctx, watchlistCollection, id); err != nil {
+		return fmt.Errorf("failed to delete watched company: %w", err)
+	}
+	return nil
+}
+
+// UpdateWatchedCompanySeen records the posting URLs already seen for a watched company
+func (p *PostgresClient) UpdateWatchedCompanySeen(ctx context.Context, id string, seenURLs []string) error {
+	err := p.mergeDoc(ctx, watchlistCollection, id, map[string]interface{}{
+		"seenUrls":      seenURLs,
+		"lastCheckedAt": time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update watched company: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,377 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// GetStructuredProfile retrieves a user's saved structured profile
+func (p *PostgresClient) GetStructuredProfile(ctx context.Context, userID string) (*models.StructuredProfile, error) {
+	var profile models.StructuredProfile
+	found, err := p.getDoc(ctx, profilesCollection, userID, &profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	if !found {
+		return nil, ErrProfileNotFound
+	}
+	return &profile, nil
+}
+
+// SaveStructuredProfile stores a user's structured profile, replacing any existing one
+func (p *PostgresClient) SaveStructuredProfile(ctx context.Context, userID string, profile *models.StructuredProfile) error {
+	profile.UpdatedAt = time.Now()
+
+	if err := p.setDoc(ctx, profilesCollection, userID, profile); err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+	return nil
+}
+
+// DeleteStructuredProfile removes a user's structured profile (e.g. after a new CV upload)
+func (p *PostgresClient) DeleteStructuredProfile(ctx context.Context, userID string) error {
+	if err := p.deleteDoc(ctx, profilesCollection, userID); err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+	return nil
+}
+
+// SaveJobFeedback records a user's rating of a job, replacing any earlier rating of the same job
+func (p *PostgresClient) SaveJobFeedback(ctx context.Context, feedback *models.JobFeedback) error {
+	feedback.UpdatedAt = time.Now()
+
+	if err := p.setDoc(ctx, jobFeedbackCollection, jobScoreDocID(feedback.UserID, feedback.JobID), feedback); err != nil {
+		return fmt.Errorf("failed to save job feedback: %w", err)
+	}
+	return nil
+}
+
+// ListJobFeedback returns a user's job ratings, most recent first
+func (p *PostgresClient) ListJobFeedback(ctx context.Context, userID string) ([]models.JobFeedback, error) {
+	feedback, err := p.queryJobFeedback(ctx, `SELECT id, data FROM job_feedback WHERE data->>'userId' = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(feedback, func(i, j int) bool {
+		return feedback[i].UpdatedAt.After(feedback[j].UpdatedAt)
+	})
+	return feedback, nil
+}
+
+func (p *PostgresClient) queryJobFeedback(ctx context.Context, query string, args ...interface{}) ([]models.JobFeedback, error) {
+	feedback := make([]models.JobFeedback, 0)
+	err := p.queryDocs(ctx, query, args, func(id string, data []byte) error {
+		var entry models.JobFeedback
+		if err := documentCodec.Unmarshal(data, &entry); err != nil {
+			return fmt.Errorf("failed to parse job feedback: %w", err)
+		}
+		feedback = append(feedback, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job feedback: %w", err)
+	}
+	return feedback, nil
+}
+
+// CreateAPIToken stores a new personal access token
+func (p *PostgresClient) CreateAPIToken(ctx context.Context, token *models.APIToken) error {
+	token.CreatedAt = time.Now()
+
+	id := newDocID()
+	if err := p.setDoc(ctx, apiTokensCollection, id, token); err != nil {
+		return fmt.Errorf("failed to create API token: %w", err)
+	}
+
+	token.ID = id
+	return nil
+}
+
+// GetAPITokenByHash looks up a personal access token by the hash of its secret
+func (p *PostgresClient) GetAPITokenByHash(ctx context.Context, tokenHash string) (*models.APIToken, error) {
+	tokens, err := p.queryAPITokens(ctx, `SELECT id, data FROM api_tokens WHERE data->>'tokenHash' = $1 LIMIT 1`, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, ErrAPITokenNotFound
+	}
+	return &tokens[0], nil
+}
+
+// ListAPITokens returns a user's personal access tokens
+func (p *PostgresClient) ListAPITokens(ctx context.Context, userID string) ([]models.APIToken, error) {
+	return p.queryAPITokens(ctx, `SELECT id, data FROM api_tokens WHERE data->>'userId' = $1`, userID)
+}
+
+func (p *PostgresClient) queryAPITokens(ctx context.Context, query string, args ...interface{}) ([]models.APIToken, error) {
+	tokens := make([]models.APIToken, 0)
+	err := p.queryDocs(ctx, query, args, func(id string, data []byte) error {
+		var token models.APIToken
+		if err := documentCodec.Unmarshal(data, &token); err != nil {
+			return fmt.Errorf("failed to parse API token: %w", err)
+		}
+		token.ID = id
+		tokens = append(tokens, token)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// DeleteAPIToken revokes one of a user's tokens
+func (p *PostgresClient) DeleteAPIToken(ctx context.Context, userID, id string) error {
+	var token models.APIToken
+	found, err := p.getDoc(ctx, apiTokensCollection, id, &token)
+	if err != nil {
+		return fmt.Errorf("failed to get API token: %w", err)
+	}
+	if !found || token.UserID != userID {
+		return ErrAPITokenNotFound
+	}
+
+	if err := p.deleteDoc(ctx, apiTokensCollection, id); err != nil {
+		return fmt.Errorf("failed to delete API token: %w", err)
+	}
+	return nil
+}
+
+// TouchAPIToken records the time a token was last used
+func (p *PostgresClient) TouchAPIToken(ctx context.Context, id string, usedAt time.Time) error {
+	if err := p.updateDoc(ctx, apiTokensCollection, id, map[string]interface{}{"lastUsedAt": usedAt}); err != nil {
+		return fmt.Errorf("failed to update API token: %w", err)
+	}
+	return nil
+}
+
+// CreateEmailChange stores a pending email change under the hash of its verification token
+func (p *PostgresClient) CreateEmailChange(ctx context.Context, tokenHash string, change *models.EmailChange) error {
+	change.CreatedAt = time.Now()
+
+	if err := p.setDoc(ctx, emailChangesCollection, tokenHash, change); err != nil {
+		return fmt.Errorf("failed to create email change: %w", err)
+	}
+	return nil
+}
+
+// GetEmailChange returns the pending email change for a verification token hash
+func (p *PostgresClient) GetEmailChange(ctx context.Context, tokenHash string) (*models.EmailChange, error) {
+	var change models.EmailChange
+	found, err := p.getDoc(ctx, emailChangesCollection, tokenHash, &change)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email change: %w", err)
+	}
+	if !found || time.Now().After(change.ExpiresAt) {
+		return nil, ErrEmailChangeNotFound
+	}
+	return &change, nil
+}
+
+// DeleteEmailChange removes a pending email change
+func (p *PostgresClient) DeleteEmailChange(ctx context.Context, tokenHash string) error {
+	if err := p.deleteDoc(ctx, emailChangesCollection, tokenHash); err != nil {
+		return fmt.Errorf("failed to delete email change: %w", err)
+	}
+	return nil
+}
+
+// ChangeUserEmail moves a user to a new email, failing with ErrUserExists when the new email is taken.
+// Everything the user owns is keyed by their ID, so only the user changes. Like on Firestore it stamps
+// emailChangedAt, revoking older sessions, and first moves a user still keyed by email to a random ID.
+func (p *PostgresClient) ChangeUserEmail(ctx context.Context, oldEmail, newEmail string) error {
+	now := time.Now()
+	userFields, err := documentCodec.Marshal(map[string]interface{}{
+		"email":          newEmail,
+		"emailChangedAt": now,
+		"updatedAt":      now,
+	})
+	if err != nil {
+		return err
+	}
+
+	pool, err := p.db(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to change user email: %w", err)
+	}
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to change user email: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var userID string
+	err = tx.QueryRow(ctx, `SELECT id FROM users WHERE data->>'email' = $1 FOR UPDATE`, oldEmail).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to change user email: %w", err)
+	}
+	if userID == oldEmail {
+		if userID, err = migrateUserID(ctx, tx, oldEmail); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE users SET data = data || $2::jsonb WHERE id = $1`, userID, userFields); err != nil {
+		if isUniqueViolation(err, "users_email_key") {
+			return ErrUserExists
+		}
+		return fmt.Errorf("failed to change user email: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to change user email: %w", err)
+	}
+	return nil
+}
+
+// MigrateUserIDs moves users still keyed by email, and everything they own, to random IDs, returning how
+// many were moved
+func (p *PostgresClient) MigrateUserIDs(ctx context.Context) (int, error) {
+	pool, err := p.db(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	rows, err := pool.Query(ctx, `SELECT id FROM users WHERE id = data->>'email'`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	legacyIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	migrated := 0
+	for _, id := range legacyIDs {
+		err := pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+			_, err := migrateUserID(ctx, tx, id)
+			return err
+		})
+		if err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+	return migrated, nil
+}
+
+// migrateUserID moves the user keyed by the legacy ID, their email, to a random ID in the transaction,
+// along with the profile, job records and every other row they own, and returns the new ID. Rows from
+// before users had IDs name their owner in userEmail rather than userId. The profile's embedding is
+// dropped; it is computed again under the new ID.
+func migrateUserID(ctx context.Context, tx pgx.Tx, legacyID string) (string, error) {
+	userID := uuid.NewString()
+	statements := []string{
+		`UPDATE users SET id = $2 WHERE id = $1`,
+		`UPDATE profiles SET id = $2 WHERE id = $1`,
+	}
+	for _, table := range userEmailCollections {
+		statements = append(statements, fmt.Sprintf(`UPDATE %s SET data = jsonb_set(data - 'userEmail', '{userId}', to_jsonb($2::text))
+			WHERE data->>'userEmail' = $1 OR data->>'userId' = $1`, table))
+	}
+	// Job records are keyed by jobScoreDocID, the SHA-256 of "owner|jobId"
+	for _, table := range userJobCollections {
+		statements = append(statements, fmt.Sprintf(`UPDATE %s SET
+			id = encode(sha256(convert_to($2::text || '|' || (data->>'jobId'), 'UTF8')), 'hex'),
+			data = jsonb_set(data - 'userEmail', '{userId}', to_jsonb($2::text))
+			WHERE data->>'userEmail' = $1 OR data->>'userId' = $1`, table))
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(ctx, statement, legacyID, userID); err != nil {
+			return "", fmt.Errorf("failed to migrate user %s: %w", legacyID, err)
+		}
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM embeddings WHERE kind = $1 AND id = $2`, models.EmbeddingKindProfile, legacyID); err != nil {
+		return "", fmt.Errorf("failed to migrate user %s: %w", legacyID, err)
+	}
+	return userID, nil
+}
+
+// GetUserByID retrieves a user by ID
+func (p *PostgresClient) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	var user models.User
+	found, err := p.getDoc(ctx, usersCollection, id, &user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !found {
+		return nil, ErrUserNotFound
+	}
+
+	user.ID = id
+	return &user, nil
+}
+
+// ListUsers returns a page of the users matching the query, newest first, and the cursor of the next page
+// ("" on the last page)
+func (p *PostgresClient) ListUsers(ctx context.Context, query models.UserQuery) ([]models.User, string, error) {
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if query.Provider != "" {
+		where(`data->>'provider' = $%d`, query.Provider)
+	}
+	if !query.CreatedAfter.IsZero() {
+		where(`(data->>'createdAt')::timestamptz >= $%d`, query.CreatedAfter)
+	}
+	if !query.CreatedBefore.IsZero() {
+		where(`(data->>'createdAt')::timestamptz < $%d`, query.CreatedBefore)
+	}
+	if query.HasCV != nil {
+		where(`(COALESCE(data->>'cvUrl', '') <> '') = $%d`, *query.HasCV)
+	}
+	if query.Suspended != nil {
+		where(`COALESCE((data->>'suspended')::boolean, false) = $%d`, *query.Suspended)
+	}
+	if query.Cursor != "" {
+		if _, err := p.GetUserByID(ctx, query.Cursor); err != nil {
+			return nil, "", err
+		}
+		where(`((data->>'createdAt')::timestamptz, id) <
+			(SELECT (data->>'createdAt')::timestamptz, id FROM users WHERE id = $%d)`, query.Cursor)
+	}
+
+	sql := `SELECT id, data FROM users`
+	if len(conditions) > 0 {
+		sql += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	// One user past the page tells whether there is a next page
+	args = append(args, query.Limit+1)
+	sql += fmt.Sprintf(` ORDER BY (data->>'createdAt')::timestamptz DESC, id DESC LIMIT $%d`, len(args))
+
+	users := make([]models.User, 0, query.Limit+1)
+	err := p.queryDocs(ctx, sql, args, func(id string, data []byte) error {
+		var user models.User
+		if err := documentCodec.Unmarshal(data, &user); err != nil {
+			return fmt.Errorf("failed to parse user data: %w", err)
+		}
+		user.ID = id
+		users = append(users, user)
+		return nil
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query users: %w", err)
+	}
+
+	if len(users) > query.Limit {
+		users = users[:query.Limit]
+		return users, users[len(users)-1].ID, nil
+	}
+	return users, "", nil
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+const profilesCollection = "profiles"
+
+// ErrProfileNotFound is returned when a user has no saved structured profile
+var ErrProfileNotFound = errors.New("structured profile not found")
+
+// GetStructuredProfile retrieves a user's saved structured profile
+func (f *FirestoreClient) GetStructuredProfile(ctx context.Context, userID string) (*models.StructuredProfile, error) {
+	doc, err := f.collection(ctx, profilesCollection).Doc(userID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrProfileNotFound
+		}
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	var profile models.StructuredProfile
+	if err := doc.DataTo(&profile); err != nil {
+		return nil, fmt.Errorf("failed to parse profile data: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// SaveStructuredProfile stores a user's structured profile, replacing any existing one
+func (f *FirestoreClient) SaveStructuredProfile(ctx context.Context, userID string, profile *models.StructuredProfile) error {
+	profile.UpdatedAt = time.Now()
+
+	if _, err := f.collection(ctx, profilesCollection).Doc(userID).Set(ctx, profile); err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+	return nil
+}
+
+// DeleteStructuredProfile removes a user's structured profile (e.g. after a new CV upload)
+func (f *FirestoreClient) DeleteStructuredProfile(ctx context.Context, userID string) error {
+	if _, err := f.collection(ctx, profilesCollection).Doc(userID).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/iterator"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+const promptExperimentsCollection = "prompt_experiments"
+
+// SavePromptExperimentRecord stores the outcome of a search run with a prompt experiment variant
+func (f *FirestoreClient) SavePromptExperimentRecord(ctx context.Context, record *models.PromptExperimentRecord) error {
+	record.CreatedAt = time.Now()
+
+	if _, err := f.collection(ctx, promptExperimentsCollection).NewDoc().Set(ctx, record); err != nil {
+		return fmt.Errorf("failed to save prompt experiment record: %w", err)
+	}
+	return nil
+}
+
+// ListPromptExperimentRecords returns the prompt experiment records created since the given time
+func (f *FirestoreClient) ListPromptExperimentRecords(ctx context.Context, since time.Time) ([]models.PromptExperimentRecord, error) {
+	iter := f.collection(ctx, promptExperimentsCollection).Where("createdAt", ">=", since).Documents(ctx)
+	defer iter.Stop()
+
+	records := make([]models.PromptExperimentRecord, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query prompt experiment records: %w", err)
+		}
+
+		var record models.PromptExperimentRecord
+		if err := doc.DataTo(&record); err != nil {
+			return nil, fmt.Errorf("failed to parse prompt experiment record: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// ListExperimentFeedback returns job ratings updated since the given time on jobs scored by a prompt experiment variant
+func (f *FirestoreClient) ListExperimentFeedback(ctx context.Context, since time.Time) ([]models.JobFeedback, error) {
+	iter := f.collection(ctx, jobFeedbackCollection).Where("updatedAt", ">=", since).Documents(ctx)
+	defer iter.Stop()
+
+	feedback := make([]models.JobFeedback, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query job feedback: %w", err)
+		}
+
+		var entry models.JobFeedback
+		if err := doc.DataTo(&entry); err != nil {
+			return nil, fmt.Errorf("failed to parse job feedback: %w", err)
+		}
+		// Filtered in memory to avoid requiring a composite index on (promptVariant, updatedAt)
+		if entry.PromptVariant != "" {
+			feedback = append(feedback, entry)
+		}
+	}
+	return feedback, nil
+}
@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/myjobmatch/backend/config"
+)
+
+// S3StorageClient stores CVs and profile photos in a bucket on S3 or an S3-compatible server such as
+// MinIO, laid out like the Cloud Storage bucket, for deployments outside GCP. Stored URLs start with the
+// bucket's public URL; photos must be readable there for the frontend, while CVs should stay private.
+type S3StorageClient struct {
+	client     *minio.Client
+	bucketName string
+	baseURL    string // URL objects are linked under, without a trailing slash
+}
+
+// NewS3StorageClient connects to the S3 endpoint with static credentials, or with the AWS environment
+// variables or instance role when none are configured
+func NewS3StorageClient(cfg *config.Config) (*S3StorageClient, error) {
+	creds := credentials.NewStaticV4(cfg.S3AccessKeyID, cfg.S3SecretAccessKey, "")
+	if cfg.S3AccessKeyID == "" {
+		creds = credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.IAM{Client: &http.Client{Transport: http.DefaultTransport}},
+		})
+	}
+
+	client, err := minio.New(cfg.S3Endpoint, &minio.Options{
+		Creds:  creds,
+		Secure: cfg.S3UseSSL,
+		Region: cfg.S3Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	baseURL := cfg.S3PublicURL
+	if baseURL == "" {
+		scheme := "https"
+		if !cfg.S3UseSSL {
+			scheme = "http"
+		}
+		baseURL = fmt.Sprintf("%s://%s/%s", scheme, cfg.S3Endpoint, cfg.CVBucketName)
+	}
+
+	return &S3StorageClient{
+		client:     client,
+		bucketName: cfg.CVBucketName,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+// Close does nothing; the client holds no connections beyond the HTTP transport's pool
+func (s *S3StorageClient) Close() error {
+	return nil
+}
+
+// Ping lists at most one CV, checking that the bucket is reachable with the permissions ListCVs needs
+func (s *S3StorageClient) Ping(ctx context.Context) error {
+	// Cancelling stops the listing goroutine once the first result is read
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for object := range s.client.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{Prefix: cvPrefix, MaxKeys: 1}) {
+		if object.Err != nil {
+			return fmt.Errorf("failed to reach S3: %w", object.Err)
+		}
+		break
+	}
+	return nil
+}
+
+// UploadCVFromBytes uploads CV content
+func (s *S3StorageClient) UploadCVFromBytes(ctx context.Context, userID string, content []byte, filename string) (string, error) {
+	objectName := cvObjectName(ctx, userID, filename)
+	if err := s.put(ctx, objectName, content, minio.PutObjectOptions{ContentType: getContentType(filepath.Ext(filename))}); err != nil {
+		return "", fmt.Errorf("failed to write content: %w", err)
+	}
+	return s.baseURL + "/" + objectName, nil
+}
+
+// DownloadCV reads a CV's content
+func (s *S3StorageClient) DownloadCV(ctx context.Context, cvUrl string) ([]byte, error) {
+	objectName, err := s.objectName(cvUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CV URL format")
+	}
+
+	object, err := s.client.GetObject(ctx, s.bucketName, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CV: %w", err)
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CV: %w", err)
+	}
+	return data, nil
+}
+
+// DeleteCV deletes a CV
+func (s *S3StorageClient) DeleteCV(ctx context.Context, cvUrl string) error {
+	objectName, err := s.objectName(cvUrl)
+	if err != nil {
+		return fmt.Errorf("invalid CV URL format")
+	}
+
+	if err := s.client.RemoveObject(ctx, s.bucketName, objectName, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete CV: %w", err)
+	}
+	return nil
+}
+
+// UploadPhoto uploads a processed JPEG profile photo
+func (s *S3StorageClient) UploadPhoto(ctx context.Context, userID string, content []byte) (string, error) {
+	objectName := photoObjectName(ctx, userID)
+	opts := minio.PutObjectOptions{ContentType: "image/jpeg", CacheControl: "public, max-age=86400"}
+	if err := s.put(ctx, objectName, content, opts); err != nil {
+		return "", fmt.Errorf("failed to write photo: %w", err)
+	}
+	return s.baseURL + "/" + objectName, nil
+}
+
+// DeletePhoto deletes a profile photo
+func (s *S3StorageClient) DeletePhoto(ctx context.Context, photoUrl string) error {
+	objectName, err := s.objectName(photoUrl)
+	if err != nil {
+		return fmt.Errorf("invalid photo URL format")
+	}
+
+	if err := s.client.RemoveObject(ctx, s.bucketName, objectName, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete photo: %w", err)
+	}
+	return nil
+}
+
+// ListCVs returns every CV object of the context's tenant in the bucket, with its last modification as creation time
+func (s *S3StorageClient) ListCVs(ctx context.Context) ([]CVObject, error) {
+	objects := make([]CVObject, 0)
+	for object := range s.client.ListObjects(ctx, s.bucketName, minio.ListObjectsOptions{Prefix: cvFolder(ctx), Recursive: true}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list CVs: %w", object.Err)
+		}
+		objects = append(objects, CVObject{
+			URL:       s.baseURL + "/" + object.Key,
+			Owner:     cvOwner(ctx, object.Key),
+			CreatedAt: object.LastModified,
+		})
+	}
+	return objects, nil
+}
+
+func (s *S3StorageClient) put(ctx context.Context, objectName string, content []byte, opts minio.PutObjectOptions) error {
+	_, err := s.client.PutObject(ctx, s.bucketName, objectName, bytes.NewReader(content), int64(len(content)), opts)
+	return err
+}
+
+// objectName maps a URL returned by an upload back to its object
+func (s *S3StorageClient) objectName(fileUrl string) (string, error) {
+	prefix := s.baseURL + "/"
+	if !strings.HasPrefix(fileUrl, prefix) || len(fileUrl) == len(prefix) {
+		return "", errors.New("not a URL of this bucket")
+	}
+	return strings.TrimPrefix(fileUrl, prefix), nil
+}
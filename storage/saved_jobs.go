@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+const savedJobsCollection = "saved_jobs"
+
+// ErrSavedJobNotFound is returned when a saved job does not exist or belongs to another user
+var ErrSavedJobNotFound = errors.New("saved job not found")
+
+// SaveJob bookmarks a job for a user
+func (f *FirestoreClient) SaveJob(ctx context.Context, saved *models.SavedJob) error {
+	now := time.Now()
+	saved.SavedAt = now
+	saved.ScoredAt = now
+
+	docRef := f.collection(ctx, savedJobsCollection).NewDoc()
+	if _, err := docRef.Set(ctx, saved); err != nil {
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+
+	saved.ID = docRef.ID
+	return nil
+}
+
+// ListSavedJobs returns a user's saved jobs, most recently saved first
+func (f *FirestoreClient) ListSavedJobs(ctx context.Context, userID string) ([]models.SavedJob, error) {
+	iter := f.collection(ctx, savedJobsCollection).Where("userId", "==", userID).Documents(ctx)
+	defer iter.Stop()
+
+	jobs := make([]models.SavedJob, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query saved jobs: %w", err)
+		}
+
+		var saved models.SavedJob
+		if err := doc.DataTo(&saved); err != nil {
+			return nil, fmt.Errorf("failed to parse saved job: %w", err)
+		}
+		saved.ID = doc.Ref.ID
+		jobs = append(jobs, saved)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].SavedAt.After(jobs[j].SavedAt)
+	})
+
+	return jobs, nil
+}
+
+// UpdateSavedJobScore stores a new match score for a saved job
+func (f *FirestoreClient) UpdateSavedJobScore(ctx context.Context, saved *models.SavedJob) error {
+	saved.ScoredAt = time.Now()
+
+	if _, err := f.collection(ctx, savedJobsCollection).Doc(saved.ID).Set(ctx, saved); err != nil {
+		return fmt.Errorf("failed to update saved job: %w", err)
+	}
+	return nil
+}
+
+// DeleteSavedJob removes one of a user's saved jobs
+func (f *FirestoreClient) DeleteSavedJob(ctx context.Context, userID, id string) error {
+	docRef := f.collection(ctx, savedJobsCollection).Doc(id)
+	doc, err := docRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return ErrSavedJobNotFound
+		}
+		return fmt.Errorf("failed to get saved job: %w", err)
+	}
+
+	var saved models.SavedJob
+	if err := doc.DataTo(&saved); err != nil {
+		return fmt.Errorf("failed to parse saved job: %w", err)
+	}
+	if saved.UserID != userID {
+		return ErrSavedJobNotFound
+	}
+
+	if _, err := docRef.Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete saved job: %w", err)
+	}
+	return nil
+}
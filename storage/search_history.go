@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+const searchHistoryCollection = "search_history"
+
+// ErrSearchHistoryNotFound is returned when a history entry does not exist or belongs to another user
+var ErrSearchHistoryNotFound = errors.New("search history entry not found")
+
+// AddSearchHistory records a completed search
+func (f *FirestoreClient) AddSearchHistory(ctx context.Context, entry *models.SearchHistoryEntry) error {
+	entry.TenantID = utils.TenantFromContext(ctx)
+	entry.CreatedAt = time.Now()
+
+	docRef := f.collection(ctx, searchHistoryCollection).NewDoc()
+	if _, err := docRef.Set(ctx, entry); err != nil {
+		return fmt.Errorf("failed to add search history: %w", err)
+	}
+
+	entry.ID = docRef.ID
+	return nil
+}
+
+// ListSearchHistory returns a user's most recent searches, newest first
+func (f *FirestoreClient) ListSearchHistory(ctx context.Context, userID string, limit int) ([]models.SearchHistoryEntry, error) {
+	iter := f.collection(ctx, searchHistoryCollection).Where("userId", "==", userID).Documents(ctx)
+	defer iter.Stop()
+
+	entries := make([]models.SearchHistoryEntry, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query search history: %w", err)
+		}
+
+		var entry models.SearchHistoryEntry
+		if err := doc.DataTo(&entry); err != nil {
+			return nil, fmt.Errorf("failed to parse search history: %w", err)
+		}
+		entry.ID = doc.Ref.ID
+		entries = append(entries, entry)
+	}
+
+	// Sorted in memory to avoid requiring a composite index on (userId, createdAt)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries, nil
+}
+
+// DeleteSearchHistory deletes one of a user's history entries
+func (f *FirestoreClient) DeleteSearchHistory(ctx context.Context, userID, id string) error {
+	docRef := f.collection(ctx, searchHistoryCollection).Doc(id)
+
+	doc, err := docRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return ErrSearchHistoryNotFound
+		}
+		return fmt.Errorf("failed to get search history: %w", err)
+	}
+
+	var entry models.SearchHistoryEntry
+	if err := doc.DataTo(&entry); err != nil {
+		return fmt.Errorf("failed to parse search history: %w", err)
+	}
+	if entry.UserID != userID {
+		return ErrSearchHistoryNotFound
+	}
+
+	if _, err := docRef.Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete search history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// searchSessionsCollection holds recorded searches.
+// Configure a Firestore TTL policy on expiresAt to purge old sessions automatically.
+const searchSessionsCollection = "search_sessions"
+
+// ErrSearchSessionNotFound is returned when a search session doesn't exist or has expired
+var ErrSearchSessionNotFound = errors.New("search session not found")
+
+// SaveSearchSession stores a recorded search, assigning its ID. The session expires ttl after creation.
+func (f *FirestoreClient) SaveSearchSession(ctx context.Context, session *models.SearchSession, ttl time.Duration) error {
+	session.CreatedAt = time.Now()
+	session.ExpiresAt = session.CreatedAt.Add(ttl)
+
+	docRef := f.collection(ctx, searchSessionsCollection).NewDoc()
+	if _, err := docRef.Set(ctx, session); err != nil {
+		return fmt.Errorf("failed to save search session: %w", err)
+	}
+
+	session.ID = docRef.ID
+	return nil
+}
+
+// GetSearchSession retrieves a recorded search by ID
+func (f *FirestoreClient) GetSearchSession(ctx context.Context, id string) (*models.SearchSession, error) {
+	doc, err := f.collection(ctx, searchSessionsCollection).Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrSearchSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get search session: %w", err)
+	}
+
+	var session models.SearchSession
+	if err := doc.DataTo(&session); err != nil {
+		return nil, fmt.Errorf("failed to parse search session: %w", err)
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return nil, ErrSearchSessionNotFound
+	}
+
+	session.ID = doc.Ref.ID
+	return &session, nil
+}
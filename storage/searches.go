@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+const searchesCollection = "searches"
+
+// ErrSearchNotFound is returned when an async search does not exist
+var ErrSearchNotFound = errors.New("search not found")
+
+// ErrSearchNotResumable is returned when resuming a search that wasn't interrupted or has no checkpoint
+var ErrSearchNotResumable = errors.New("search cannot be resumed")
+
+// interruptedSearchError is the error recorded on searches interrupted by shutdown
+const interruptedSearchError = "Interrupted by a server restart"
+
+// CreateAsyncSearch stores a new pending background search
+func (f *FirestoreClient) CreateAsyncSearch(ctx context.Context, search *models.AsyncSearch) error {
+	search.TenantID = utils.TenantFromContext(ctx)
+	search.Status = models.SearchStatusPending
+	search.CreatedAt = time.Now()
+
+	docRef := f.collection(ctx, searchesCollection).NewDoc()
+	if _, err := docRef.Set(ctx, search); err != nil {
+		return fmt.Errorf("failed to create search: %w", err)
+	}
+
+	search.ID = docRef.ID
+	return nil
+}
+
+// GetAsyncSearch retrieves a background search by ID
+func (f *FirestoreClient) GetAsyncSearch(ctx context.Context, id string) (*models.AsyncSearch, error) {
+	doc, err := f.collection(ctx, searchesCollection).Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrSearchNotFound
+		}
+		return nil, fmt.Errorf("failed to get search: %w", err)
+	}
+
+	var search models.AsyncSearch
+	if err := doc.DataTo(&search); err != nil {
+		return nil, fmt.Errorf("failed to parse search: %w", err)
+	}
+
+	search.ID = doc.Ref.ID
+	return &search, nil
+}
+
+// MarkAsyncSearchRunning records that a worker has picked up the search
+func (f *FirestoreClient) MarkAsyncSearchRunning(ctx context.Context, id string) error {
+	return f.updateAsyncSearch(ctx, id, []firestore.Update{
+		{Path: "status", Value: models.SearchStatusRunning},
+		{Path: "startedAt", Value: time.Now()},
+	})
+}
+
+// CompleteAsyncSearch stores the results of a finished search
+func (f *FirestoreClient) CompleteAsyncSearch(ctx context.Context, id string, result *models.SearchJobsResponse) error {
+	return f.updateAsyncSearch(ctx, id, []firestore.Update{
+		{Path: "status", Value: models.SearchStatusCompleted},
+		{Path: "result", Value: result},
+		{Path: "completedAt", Value: time.Now()},
+		{Path: "checkpoint", Value: firestore.Delete},
+	})
+}
+
+// FailAsyncSearch records why a search failed
+func (f *FirestoreClient) FailAsyncSearch(ctx context.Context, id string, searchErr error) error {
+	return f.updateAsyncSearch(ctx, id, []firestore.Update{
+		{Path: "status", Value: models.SearchStatusFailed},
+		{Path: "error", Value: searchErr.Error()},
+		{Path: "completedAt", Value: time.Now()},
+		{Path: "checkpoint", Value: firestore.Delete},
+	})
+}
+
+// InterruptAsyncSearch records that a search was stopped by shutdown, and whether it can be resumed
+// from its checkpoint
+func (f *FirestoreClient) InterruptAsyncSearch(ctx context.Context, id string, resumable bool) error {
+	return f.updateAsyncSearch(ctx, id, []firestore.Update{
+		{Path: "status", Value: models.SearchStatusInterrupted},
+		{Path: "error", Value: interruptedSearchError},
+		{Path: "resumable", Value: resumable},
+		{Path: "completedAt", Value: time.Now()},
+	})
+}
+
+// ResumeAsyncSearch puts an interrupted, resumable search back to pending. It fails with
+// ErrSearchNotResumable if the search isn't (or is no longer) resumable, so it's queued only once.
+func (f *FirestoreClient) ResumeAsyncSearch(ctx context.Context, id string) error {
+	docRef := f.collection(ctx, searchesCollection).Doc(id)
+	err := f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(docRef)
+		if status.Code(err) == codes.NotFound {
+			return ErrSearchNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var search models.AsyncSearch
+		if err := doc.DataTo(&search); err != nil {
+			return err
+		}
+		if search.Status != models.SearchStatusInterrupted || !search.Resumable || search.Checkpoint == nil {
+			return ErrSearchNotResumable
+		}
+		return tx.Update(docRef, []firestore.Update{
+			{Path: "status", Value: models.SearchStatusPending},
+			{Path: "error", Value: firestore.Delete},
+			{Path: "resumable", Value: firestore.Delete},
+			{Path: "startedAt", Value: firestore.Delete},
+			{Path: "completedAt", Value: firestore.Delete},
+		})
+	})
+	if errors.Is(err, ErrSearchNotFound) || errors.Is(err, ErrSearchNotResumable) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resume search: %w", err)
+	}
+	return nil
+}
+
+func (f *FirestoreClient) updateAsyncSearch(ctx context.Context, id string, updates []firestore.Update) error {
+	if _, err := f.collection(ctx, searchesCollection).Doc(id).Update(ctx, updates); err != nil {
+		return fmt.Errorf("failed to update search: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+const sharedSearchesCollection = "shared_searches"
+
+// ErrSharedSearchNotFound is returned when no share link exists for a token or it belongs to another user
+var ErrSharedSearchNotFound = errors.New("shared search not found")
+
+// CreateSharedSearch stores a share snapshot under its token
+func (f *FirestoreClient) CreateSharedSearch(ctx context.Context, share *models.SharedSearch) error {
+	share.CreatedAt = time.Now()
+
+	if _, err := f.collection(ctx, sharedSearchesCollection).Doc(share.Token).Create(ctx, share); err != nil {
+		return fmt.Errorf("failed to create shared search: %w", err)
+	}
+	return nil
+}
+
+// GetSharedSearch retrieves a share snapshot by token
+func (f *FirestoreClient) GetSharedSearch(ctx context.Context, token string) (*models.SharedSearch, error) {
+	doc, err := f.collection(ctx, sharedSearchesCollection).Doc(token).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrSharedSearchNotFound
+		}
+		return nil, fmt.Errorf("failed to get shared search: %w", err)
+	}
+
+	var share models.SharedSearch
+	if err := doc.DataTo(&share); err != nil {
+		return nil, fmt.Errorf("failed to parse shared search: %w", err)
+	}
+
+	share.Token = doc.Ref.ID
+	return &share, nil
+}
+
+// ListSharedSearches returns a user's share links, newest first
+func (f *FirestoreClient) ListSharedSearches(ctx context.Context, userID string) ([]models.SharedSearch, error) {
+	iter := f.collection(ctx, sharedSearchesCollection).Where("userId", "==", userID).Documents(ctx)
+	defer iter.Stop()
+
+	shares := make([]models.SharedSearch, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query shared searches: %w", err)
+		}
+
+		var share models.SharedSearch
+		if err := doc.DataTo(&share); err != nil {
+			return nil, fmt.Errorf("failed to parse shared search: %w", err)
+		}
+		share.Token = doc.Ref.ID
+		shares = append(shares, share)
+	}
+
+	sort.Slice(shares, func(i, j int) bool {
+		return shares[i].CreatedAt.After(shares[j].CreatedAt)
+	})
+
+	return shares, nil
+}
+
+// DeleteSharedSearch revokes one of a user's share links
+func (f *FirestoreClient) DeleteSharedSearch(ctx context.Context, userID, token string) error {
+	share, err := f.GetSharedSearch(ctx, token)
+	if err != nil {
+		return err
+	}
+	if share.UserID != userID {
+		return ErrSharedSearchNotFound
+	}
+
+	if _, err := f.collection(ctx, sharedSearchesCollection).Doc(token).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete shared search: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/models"
+)
+
+// UserStore holds accounts and what users keep about themselves: structured profiles, job ratings and
+// personal access tokens
+type UserStore interface {
+	CreateUser(ctx context.Context, user *models.User) error
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	GetUserByGoogleID(ctx context.Context, googleID string) (*models.User, error)
+	GetUserByID(ctx context.Context, id string) (*models.User, error)
+	// ListUsers returns a page of users, newest first, and the cursor of the next page ("" on the last page)
+	ListUsers(ctx context.Context, query models.UserQuery) ([]models.User, string, error)
+	UpdateUser(ctx context.Context, email string, updates map[string]interface{}) error
+	LinkGoogleID(ctx context.Context, email, googleID string) error
+	UpdateUserCVUrl(ctx context.Context, email, cvUrl string) error
+	UpdateUserPhotoUrl(ctx context.Context, email, photoUrl string) error
+	UpdateUserProfile(ctx context.Context, email string, nama string, incognito *bool) error
+	UpdateUserBlockedCompanies(ctx context.Context, email string, companies []string) error
+	UpdateUserWebhook(ctx context.Context, email, url, secret string) error
+	DeleteUser(ctx context.Context, email string) error
+	ChangeUserEmail(ctx context.Context, oldEmail, newEmail string) error
+	MigrateUserIDs(ctx context.Context) (int, error)
+	CreateEmailChange(ctx context.Context, tokenHash string, change *models.EmailChange) error
+	GetEmailChange(ctx context.Context, tokenHash string) (*models.EmailChange, error)
+	DeleteEmailChange(ctx context.Context, tokenHash string) error
+	ListCVUrls(ctx context.Context) ([]string, error)
+
+	GetStructuredProfile(ctx context.Context, userID string) (*models.StructuredProfile, error)
+	SaveStructuredProfile(ctx context.Context, userID string, profile *models.StructuredProfile) error
+	DeleteStructuredProfile(ctx context.Context, userID string) error
+
+	SaveJobFeedback(ctx context.Context, feedback *models.JobFeedback) error
+	ListJobFeedback(ctx context.Context, userID string) ([]models.JobFeedback, error)
+
+	CreateAPIToken(ctx context.Context, token *models.APIToken) error
+	GetAPITokenByHash(ctx context.Context, tokenHash string) (*models.APIToken, error)
+	ListAPITokens(ctx context.Context, userID string) ([]models.APIToken, error)
+	DeleteAPIToken(ctx context.Context, userID, id string) error
+	TouchAPIToken(ctx context.Context, id string, usedAt time.Time) error
+}
+
+// JobStore holds postings and what is derived from them: match scores, the search index and the job,
+// company and summary caches
+type JobStore interface {
+	SaveJobs(ctx context.Context, jobs []models.JobPosting) error
+	GetJob(ctx context.Context, id string) (*models.StoredJob, error)
+	GetJobByURL(ctx context.Context, url string) (*models.StoredJob, error)
+	GetJobs(ctx context.Context, ids []string) (map[string]models.StoredJob, error)
+	FindJobsByTags(ctx context.Context, tags []string, limit int) ([]models.StoredJob, error)
+	ListRecentJobs(ctx context.Context, limit int) ([]models.StoredJob, error)
+	ListJobsSeenSince(ctx context.Context, since time.Time, limit int) ([]models.StoredJob, error)
+	QueryJobs(ctx context.Context, query models.JobQuery) ([]models.StoredJob, error)
+	SearchJobIndex(ctx context.Context, keywords []string, since time.Time, limit int) ([]models.JobPosting, error)
+
+	SaveJobScores(ctx context.Context, userID string, jobs []models.RankedJob, promptVariant string) error
+	GetJobScore(ctx context.Context, userID, jobID string) (*models.JobScore, error)
+	GetJobScores(ctx context.Context, userID string, jobIDs []string) (map[string]models.JobScore, error)
+
+	GetCachedJobs(ctx context.Context, keys []string) (map[string]models.JobPosting, error)
+	CacheJobs(ctx context.Context, jobs map[string]models.JobPosting, ttl time.Duration) error
+	GetCachedCompanies(ctx context.Context, keys []string) (map[string]models.Company, error)
+	CacheCompanies(ctx context.Context, companies map[string]models.Company, ttl time.Duration) error
+	GetJobSummary(ctx context.Context, key string) (*models.JobSummary, error)
+	CacheJobSummary(ctx context.Context, key string, summary *models.JobSummary, ttl time.Duration) error
+}
+
+// SearchStore holds searches and their outcomes: background searches, history, recorded sessions,
+// share links and career assistant conversations
+type SearchStore interface {
+	CreateAsyncSearch(ctx context.Context, search *models.AsyncSearch) error
+	GetAsyncSearch(ctx context.Context, id string) (*models.AsyncSearch, error)
+	MarkAsyncSearchRunning(ctx context.Context, id string) error
+	CompleteAsyncSearch(ctx context.Context, id string, result *models.SearchJobsResponse) error
+	FailAsyncSearch(ctx context.Context, id string, searchErr error) error
+	InterruptAsyncSearch(ctx context.Context, id string, resumable bool) error
+	ResumeAsyncSearch(ctx context.Context, id string) error
+
+	AddSearchHistory(ctx context.Context, entry *models.SearchHistoryEntry) error
+	ListSearchHistory(ctx context.Context, userID string, limit int) ([]models.SearchHistoryEntry, error)
+	DeleteSearchHistory(ctx context.Context, userID, id string) error
+
+	SaveSearchSession(ctx context.Context, session *models.SearchSession, ttl time.Duration) error
+	GetSearchSession(ctx context.Context, id string) (*models.SearchSession, error)
+
+	CreateSharedSearch(ctx context.Context, share *models.SharedSearch) error
+	GetSharedSearch(ctx context.Context, token string) (*models.SharedSearch, error)
+	ListSharedSearches(ctx context.Context, userID string) ([]models.SharedSearch, error)
+	DeleteSharedSearch(ctx context.Context, userID, token string) error
+
+	SaveChatSession(ctx context.Context, session *models.ChatSession, ttl time.Duration) error
+	GetChatSession(ctx context.Context, userID, id string) (*models.ChatSession, error)
+}
+
+// TrackingStore holds what users follow: saved jobs, applications, job alerts and watched companies
+type TrackingStore interface {
+	SaveJob(ctx context.Context, saved *models.SavedJob) error
+	ListSavedJobs(ctx context.Context, userID string) ([]models.SavedJob, error)
+	UpdateSavedJobScore(ctx context.Context, saved *models.SavedJob) error
+	DeleteSavedJob(ctx context.Context, userID, id string) error
+
+	CreateApplication(ctx context.Context, app *models.Application) error
+	GetApplication(ctx context.Context, userID, id string) (*models.Application, error)
+	ListApplications(ctx context.Context, userID string) ([]models.Application, error)
+	SaveApplication(ctx context.Context, app *models.Application) error
+	DeleteApplication(ctx context.Context, userID, id string) error
+
+	CreateAlert(ctx context.Context, alert *models.JobAlert) error
+	ListAlerts(ctx context.Context, userID string) ([]models.JobAlert, error)
+	ListDueAlerts(ctx context.Context, now time.Time) ([]models.JobAlert, error)
+	DeleteAlert(ctx context.Context, userID, id string) error
+	UpdateAlertRun(ctx context.Context, id string, seenURLs []string, lastRunAt, nextRunAt time.Time) error
+
+	AddWatchedCompany(ctx context.Context, company *models.WatchedCompany) error
+	ListWatchedCompanies(ctx context.Context, userID string) ([]models.WatchedCompany, error)
+	ListAllWatchedCompanies(ctx context.Context) ([]models.WatchedCompany, error)
+	DeleteWatchedCompany(ctx context.Context, userID, id string) error
+	UpdateWatchedCompanySeen(ctx context.Context, id string, seenURLs []string) error
+}
+
+// AdminStore holds operational records: LLM and tool call logs, tool switches, prompt experiments and
+// idempotent responses
+type AdminStore interface {
+	SaveLLMDebugRecord(ctx context.Context, record *models.LLMDebugRecord) error
+	ListLLMDebugRecords(ctx context.Context, requestID string) ([]models.LLMDebugRecord, error)
+
+	SaveToolAuditRecord(ctx context.Context, record *models.ToolAuditRecord) error
+	ListToolAuditRecords(ctx context.Context, query models.ToolAuditQuery) ([]models.ToolAuditRecord, error)
+
+	ListToolSettings(ctx context.Context) ([]models.ToolSetting, error)
+	SaveToolSetting(ctx context.Context, setting *models.ToolSetting) error
+
+	SavePromptExperimentRecord(ctx context.Context, record *models.PromptExperimentRecord) error
+	ListPromptExperimentRecords(ctx context.Context, since time.Time) ([]models.PromptExperimentRecord, error)
+	ListExperimentFeedback(ctx context.Context, since time.Time) ([]models.JobFeedback, error)
+
+	// ReserveIdempotencyKey stores a running record unless an unexpired record has the same scope and key,
+	// in which case it stores nothing and returns that record
+	ReserveIdempotencyKey(ctx context.Context, record *models.IdempotencyRecord) (*models.IdempotencyRecord, error)
+	CompleteIdempotencyKey(ctx context.Context, record *models.IdempotencyRecord) error
+	ReleaseIdempotencyKey(ctx context.Context, scope, key string) error
+
+	// BackupCollections lists the collections backups hold (users, profiles and jobs)
+	BackupCollections() []string
+	// ExportDocuments calls fn with every document of a backed-up collection
+	ExportDocuments(ctx context.Context, collection string, fn func(BackupDocument) error) error
+	// RestoreDocuments writes documents to a backed-up collection, replacing those with the same IDs
+	RestoreDocuments(ctx context.Context, collection string, docs []BackupDocument) error
+}
+
+// EmbeddingStore holds the embedding vectors of profiles and postings, for matching by similarity
+type EmbeddingStore interface {
+	// UpsertEmbeddings stores embeddings, replacing those of the same kind and ID
+	UpsertEmbeddings(ctx context.Context, embeddings []models.Embedding) error
+	GetEmbeddings(ctx context.Context, kind string, ids []string) (map[string]models.Embedding, error)
+	// FindNearestEmbeddings returns the embeddings of a kind and model most similar to a vector, most similar first
+	FindNearestEmbeddings(ctx context.Context, query models.EmbeddingQuery) ([]models.EmbeddingMatch, error)
+	DeleteEmbeddings(ctx context.Context, kind string, ids []string) error
+}
+
+// Store is the application's database, implemented by FirestoreClient and PostgresClient
+type Store interface {
+	UserStore
+	JobStore
+	SearchStore
+	TrackingStore
+	AdminStore
+	EmbeddingStore
+
+	// Ping checks that the database is reachable
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// NewStore opens the database selected by STORAGE_BACKEND
+func NewStore(ctx context.Context, cfg *config.Config) (Store, error) {
+	if cfg.StorageBackend == config.StorageBackendPostgres {
+		return NewPostgresClient(ctx, cfg)
+	}
+	return NewFirestoreClient(ctx, cfg)
+}
+
+// CVStorage holds uploaded CVs and profile photos, implemented by CloudStorageClient, S3StorageClient and
+// LocalStorageClient.
+// Uploads return the URL stored on the user, which the other methods take.
+type CVStorage interface {
+	UploadCVFromBytes(ctx context.Context, userID string, content []byte, filename string) (string, error)
+	DownloadCV(ctx context.Context, cvUrl string) ([]byte, error)
+	DeleteCV(ctx context.Context, cvUrl string) error
+	UploadPhoto(ctx context.Context, userID string, content []byte) (string, error)
+	DeletePhoto(ctx context.Context, photoUrl string) error
+	ListCVs(ctx context.Context) ([]CVObject, error)
+
+	// Ping checks that CV storage is reachable
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// DirectCVUploader is implemented by CV storage that browsers can upload CVs to directly, so large files
+// don't pass through the API
+type DirectCVUploader interface {
+	// CreateCVUploadURL returns a signed URL that starts a resumable upload of a new CV of at most maxBytes
+	CreateCVUploadURL(ctx context.Context, userID, filename string, maxBytes int64, expires time.Duration) (*CVUploadTarget, error)
+	// CVSize returns the size of one of the user's uploaded CVs, or ErrCVNotFound
+	CVSize(ctx context.Context, userID, cvUrl string) (int64, error)
+}
+
+// CVUploadTarget is where and how a browser uploads a CV directly
+type CVUploadTarget struct {
+	UploadURL string            // Signed URL the browser POSTs to, with Headers, to start a resumable upload
+	Headers   map[string]string // Headers the upload-starting request must send
+	CVUrl     string            // URL of the CV once uploaded, used to confirm the upload
+	ExpiresAt time.Time
+}
+
+// CVObject is a stored CV file
+type CVObject struct {
+	URL       string // As returned by the upload, so it can be compared with users' CV URLs and deleted
+	Owner     string // Folder of the uploader, named after their ID
+	CreatedAt time.Time
+}
+
+// NewCVStorage opens the CV and photo storage selected by CV_STORAGE_BACKEND
+func NewCVStorage(ctx context.Context, cfg *config.Config) (CVStorage, error) {
+	switch cfg.CVStorageBackend {
+	case config.CVStorageLocal:
+		return NewLocalStorageClient(cfg)
+	case config.CVStorageS3:
+		return NewS3StorageClient(cfg)
+	}
+	return NewCloudStorageClient(ctx, cfg)
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// toolAuditCollection holds recorded tool calls.
+// Configure a Firestore TTL policy on expiresAt to purge old records automatically.
+const toolAuditCollection = "tool_audit"
+
+// SaveToolAuditRecord stores a recorded tool call
+func (f *FirestoreClient) SaveToolAuditRecord(ctx context.Context, record *models.ToolAuditRecord) error {
+	docRef := f.collection(ctx, toolAuditCollection).NewDoc()
+	if _, err := docRef.Set(ctx, record); err != nil {
+		return fmt.Errorf("failed to save tool audit record: %w", err)
+	}
+
+	record.ID = docRef.ID
+	return nil
+}
+
+// ListToolAuditRecords returns the tool calls matching the query, newest first
+func (f *FirestoreClient) ListToolAuditRecords(ctx context.Context, query models.ToolAuditQuery) ([]models.ToolAuditRecord, error) {
+	// Filter on one equality field in Firestore and the rest in memory, so no composite indexes are needed
+	q := f.collection(ctx, toolAuditCollection).Query
+	switch {
+	case query.APITokenID != "":
+		q = q.Where("apiTokenId", "==", query.APITokenID)
+	case query.CallerEmail != "":
+		q = q.Where("callerEmail", "==", query.CallerEmail)
+	case query.Tool != "":
+		q = q.Where("tool", "==", query.Tool)
+	default:
+		q = q.Where("createdAt", ">=", query.Since).OrderBy("createdAt", firestore.Desc).Limit(query.Limit)
+	}
+
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	records := make([]models.ToolAuditRecord, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query tool audit records: %w", err)
+		}
+
+		var record models.ToolAuditRecord
+		if err := doc.DataTo(&record); err != nil {
+			return nil, fmt.Errorf("failed to parse tool audit record: %w", err)
+		}
+		if record.CreatedAt.Before(query.Since) ||
+			(query.Tool != "" && record.Tool != query.Tool) ||
+			(query.CallerEmail != "" && record.CallerEmail != query.CallerEmail) {
+			continue
+		}
+		record.ID = doc.Ref.ID
+		records = append(records, record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreatedAt.After(records[j].CreatedAt)
+	})
+	if len(records) > query.Limit {
+		records = records[:query.Limit]
+	}
+	return records, nil
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/iterator"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// toolSettingsCollection holds runtime tool switches, one document per tool name. The switches apply to
+// the whole deployment, so they're kept with the default tenant whichever tenant reads or writes them.
+const toolSettingsCollection = "tool_settings"
+
+// ListToolSettings returns the stored tool switches
+func (f *FirestoreClient) ListToolSettings(ctx context.Context) ([]models.ToolSetting, error) {
+	iter := f.client.Collection(toolSettingsCollection).Documents(ctx)
+	defer iter.Stop()
+
+	settings := make([]models.ToolSetting, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query tool settings: %w", err)
+		}
+
+		var setting models.ToolSetting
+		if err := doc.DataTo(&setting); err != nil {
+			return nil, fmt.Errorf("failed to parse tool setting: %w", err)
+		}
+		setting.Name = doc.Ref.ID
+		settings = append(settings, setting)
+	}
+	return settings, nil
+}
+
+// SaveToolSetting stores a tool switch, replacing the previous one
+func (f *FirestoreClient) SaveToolSetting(ctx context.Context, setting *models.ToolSetting) error {
+	if _, err := f.client.Collection(toolSettingsCollection).Doc(setting.Name).Set(ctx, setting); err != nil {
+		return fmt.Errorf("failed to save tool setting: %w", err)
+	}
+	return nil
+}
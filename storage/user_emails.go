@@ -0,0 +1,342 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// emailChangesCollection holds pending email changes keyed by the hash of their verification token.
+// Configure a Firestore TTL policy on expiresAt to purge unconfirmed changes automatically.
+const emailChangesCollection = "email_changes"
+
+// ErrEmailChangeNotFound is returned when a verification token is unknown or expired
+var ErrEmailChangeNotFound = errors.New("email change not found")
+
+// userEmailCollections hold documents that belong to a user through their userId field, which was the
+// userEmail field before users were keyed by ID
+var userEmailCollections = []string{
+	alertsCollection,
+	apiTokensCollection,
+	applicationsCollection,
+	chatSessionsCollection,
+	savedJobsCollection,
+	searchHistoryCollection,
+	searchesCollection,
+	searchSessionsCollection,
+	sharedSearchesCollection,
+	watchlistCollection,
+}
+
+// userJobCollections hold per-user job records keyed by jobScoreDocID
+var userJobCollections = []string{
+	jobFeedbackCollection,
+	jobScoresCollection,
+}
+
+// firestoreMaxBatchWrites is the most writes a Firestore batch accepts
+const firestoreMaxBatchWrites = 500
+
+// CreateEmailChange stores a pending email change under the hash of its verification token
+func (f *FirestoreClient) CreateEmailChange(ctx context.Context, tokenHash string, change *models.EmailChange) error {
+	change.CreatedAt = time.Now()
+
+	if _, err := f.collection(ctx, emailChangesCollection).Doc(tokenHash).Set(ctx, change); err != nil {
+		return fmt.Errorf("failed to create email change: %w", err)
+	}
+	return nil
+}
+
+// GetEmailChange returns the pending email change for a verification token hash
+func (f *FirestoreClient) GetEmailChange(ctx context.Context, tokenHash string) (*models.EmailChange, error) {
+	doc, err := f.collection(ctx, emailChangesCollection).Doc(tokenHash).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrEmailChangeNotFound
+		}
+		return nil, fmt.Errorf("failed to get email change: %w", err)
+	}
+
+	var change models.EmailChange
+	if err := doc.DataTo(&change); err != nil {
+		return nil, fmt.Errorf("failed to parse email change: %w", err)
+	}
+	if time.Now().After(change.ExpiresAt) {
+		return nil, ErrEmailChangeNotFound
+	}
+	return &change, nil
+}
+
+// DeleteEmailChange removes a pending email change
+func (f *FirestoreClient) DeleteEmailChange(ctx context.Context, tokenHash string) error {
+	if _, err := f.collection(ctx, emailChangesCollection).Doc(tokenHash).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete email change: %w", err)
+	}
+	return nil
+}
+
+// ChangeUserEmail moves a user to a new email, failing with ErrUserExists when the new email is taken.
+// Everything the user owns is keyed by their ID, so only the user and the email index change, in a
+// transaction that stamps emailChangedAt and so revokes older sessions. Users still keyed by email are
+// first moved to a random ID.
+func (f *FirestoreClient) ChangeUserEmail(ctx context.Context, oldEmail, newEmail string) error {
+	userRef, err := f.getUserRef(ctx, oldEmail)
+	if err != nil {
+		return err
+	}
+	if userRef.ID == oldEmail {
+		if _, err := f.migrateUserID(ctx, userRef); err != nil {
+			return err
+		}
+		if userRef, err = f.getUserRef(ctx, oldEmail); err != nil {
+			return err
+		}
+	}
+	if err := f.resumeUserDataMove(ctx, userRef); err != nil {
+		return err
+	}
+
+	err = f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(userRef)
+		if err != nil {
+			return err
+		}
+		if email, _ := doc.Data()["email"].(string); email != oldEmail {
+			return ErrUserNotFound
+		}
+		taken, err := f.emailTaken(ctx, tx, newEmail)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrUserExists
+		}
+
+		if err := tx.Create(f.collection(ctx, userEmailsCollection).Doc(newEmail), map[string]interface{}{
+			"userId": userRef.ID,
+		}); err != nil {
+			return err
+		}
+		if err := tx.Delete(f.collection(ctx, userEmailsCollection).Doc(oldEmail)); err != nil {
+			return err
+		}
+		now := time.Now()
+		return tx.Update(userRef, []firestore.Update{
+			{Path: "email", Value: newEmail},
+			{Path: "emailChangedAt", Value: now},
+			{Path: "updatedAt", Value: now},
+		})
+	})
+	if err != nil {
+		if errors.Is(err, ErrUserExists) || errors.Is(err, ErrUserNotFound) {
+			return err
+		}
+		if status.Code(err) == codes.NotFound {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to change user email: %w", err)
+	}
+	return nil
+}
+
+// moveUserData points the profile, job records and every other document owned by a user's legacy ID,
+// their email, at their new ID, and drops the profile's embedding, which is computed again under the new
+// ID. Documents from before users had IDs name their owner in userEmail rather than userId. Documents
+// already moved are skipped, so it can be repeated after a failure.
+func (f *FirestoreClient) moveUserData(ctx context.Context, legacyID, userID string) error {
+	batch := f.client.Batch()
+	writes := 0
+	flush := func(pending int) error {
+		if writes+pending <= firestoreMaxBatchWrites {
+			return nil
+		}
+		if _, err := batch.Commit(ctx); err != nil {
+			return err
+		}
+		batch = f.client.Batch()
+		writes = 0
+		return nil
+	}
+	ownedDocs := func(collection string) ([]*firestore.DocumentSnapshot, error) {
+		var docs []*firestore.DocumentSnapshot
+		for _, field := range []string{"userEmail", "userId"} {
+			found, err := f.collection(ctx, collection).Where(field, "==", legacyID).Documents(ctx).GetAll()
+			if err != nil {
+				return nil, fmt.Errorf("failed to query %s: %w", collection, err)
+			}
+			docs = append(docs, found...)
+		}
+		return docs, nil
+	}
+
+	for _, collection := range userEmailCollections {
+		docs, err := ownedDocs(collection)
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			if err := flush(1); err != nil {
+				return err
+			}
+			batch.Update(doc.Ref, []firestore.Update{
+				{Path: "userId", Value: userID},
+				{Path: "userEmail", Value: firestore.Delete},
+			})
+			writes++
+		}
+	}
+
+	// Job records are keyed by owner and job, so they are rewritten under new IDs
+	for _, collection := range userJobCollections {
+		docs, err := ownedDocs(collection)
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			data := doc.Data()
+			jobID, _ := data["jobId"].(string)
+			delete(data, "userEmail")
+			data["userId"] = userID
+
+			if err := flush(2); err != nil {
+				return err
+			}
+			batch.Set(f.collection(ctx, collection).Doc(jobScoreDocID(userID, jobID)), data)
+			batch.Delete(doc.Ref)
+			writes += 2
+		}
+	}
+
+	profile, err := f.collection(ctx, profilesCollection).Doc(legacyID).Get(ctx)
+	if err == nil {
+		if err := flush(2); err != nil {
+			return err
+		}
+		batch.Set(f.collection(ctx, profilesCollection).Doc(userID), profile.Data())
+		batch.Delete(profile.Ref)
+		writes += 2
+	} else if status.Code(err) != codes.NotFound {
+		return fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	if err := flush(1); err != nil {
+		return err
+	}
+	batch.Delete(f.collection(ctx, embeddingsCollection).Doc(embeddingDocID(models.EmbeddingKindProfile, legacyID)))
+	_, err = batch.Commit(ctx)
+	return err
+}
+
+// resumeUserDataMove finishes moving the data of a user whose legacyId marks a move that didn't complete
+func (f *FirestoreClient) resumeUserDataMove(ctx context.Context, userRef *firestore.DocumentRef) error {
+	doc, err := userRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	legacyID, _ := doc.Data()["legacyId"].(string)
+	if legacyID == "" {
+		return nil
+	}
+
+	if err := f.moveUserData(ctx, legacyID, userRef.ID); err != nil {
+		return fmt.Errorf("failed to move user data: %w", err)
+	}
+	if _, err := userRef.Update(ctx, []firestore.Update{{Path: "legacyId", Value: firestore.Delete}}); err != nil {
+		return fmt.Errorf("failed to move user data: %w", err)
+	}
+	return nil
+}
+
+// MigrateUserIDs moves users still keyed by email, and everything they own, to random IDs with an email
+// index entry, returning how many were moved. It is safe to run repeatedly and while the API is serving;
+// users whose data didn't finish moving in an earlier run are resumed.
+func (f *FirestoreClient) MigrateUserIDs(ctx context.Context) (int, error) {
+	docs, err := f.collection(ctx, usersCollection).Documents(ctx).GetAll()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	migrated := 0
+	for _, doc := range docs {
+		data := doc.Data()
+		if legacyID, _ := data["legacyId"].(string); legacyID != "" {
+			if err := f.resumeUserDataMove(ctx, doc.Ref); err != nil {
+				return migrated, fmt.Errorf("failed to migrate user %s: %w", doc.Ref.ID, err)
+			}
+			continue
+		}
+		if email, _ := data["email"].(string); email != doc.Ref.ID {
+			continue
+		}
+		moved, err := f.migrateUserID(ctx, doc.Ref)
+		if err != nil {
+			return migrated, err
+		}
+		if moved {
+			migrated++
+		}
+	}
+	return migrated, nil
+}
+
+// migrateUserID moves a user keyed by email, and everything they own, to a random ID, reporting false
+// when there was nothing to move. The new user records the old ID in legacyId until their data has moved.
+func (f *FirestoreClient) migrateUserID(ctx context.Context, legacyRef *firestore.DocumentRef) (bool, error) {
+	userRef := f.collection(ctx, usersCollection).Doc(uuid.NewString())
+	moved := false
+
+	err := f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		moved = false
+		doc, err := tx.Get(legacyRef)
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		data := doc.Data()
+		if email, _ := data["email"].(string); email != legacyRef.ID {
+			return nil
+		}
+
+		if err := tx.Set(f.collection(ctx, userEmailsCollection).Doc(legacyRef.ID), map[string]interface{}{
+			"userId": userRef.ID,
+		}); err != nil {
+			return err
+		}
+		if googleID, _ := data["googleId"].(string); googleID != "" {
+			if err := tx.Set(f.collection(ctx, googleIDsCollection).Doc(googleID), map[string]interface{}{
+				"userId":    userRef.ID,
+				"createdAt": time.Now(),
+			}); err != nil {
+				return err
+			}
+		}
+		data["legacyId"] = legacyRef.ID
+		if err := tx.Create(userRef, data); err != nil {
+			return err
+		}
+		moved = true
+		return tx.Delete(legacyRef)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to migrate user %s: %w", legacyRef.ID, err)
+	}
+	if moved {
+		if err := f.resumeUserDataMove(ctx, userRef); err != nil {
+			return true, fmt.Errorf("failed to migrate user %s: %w", legacyRef.ID, err)
+		}
+	}
+	return moved, nil
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+const watchlistCollection = "watchlist"
+
+// AddWatchedCompany adds a company to a user's watchlist
+func (f *FirestoreClient) AddWatchedCompany(ctx context.Context, company *models.WatchedCompany) error {
+	company.CreatedAt = time.Now()
+
+	docRef := f.collection(ctx, watchlistCollection).NewDoc()
+	if _, err := docRef.Set(ctx, company); err != nil {
+		return fmt.Errorf("failed to add watched company: %w", err)
+	}
+
+	company.ID = docRef.ID
+	return nil
+}
+
+// ListWatchedCompanies returns the companies a user follows
+func (f *FirestoreClient) ListWatchedCompanies(ctx context.Context, userID string) ([]models.WatchedCompany, error) {
+	return f.queryWatchedCompanies(f.collection(ctx, watchlistCollection).Where("userId", "==", userID).Documents(ctx))
+}
+
+// ListAllWatchedCompanies returns every watched company across all users (used by the watchlist worker)
+func (f *FirestoreClient) ListAllWatchedCompanies(ctx context.Context) ([]models.WatchedCompany, error) {
+	return f.queryWatchedCompanies(f.collection(ctx, watchlistCollection).Documents(ctx))
+}
+
+func (f *FirestoreClient) queryWatchedCompanies(iter *firestore.DocumentIterator) ([]models.WatchedCompany, error) {
+	defer iter.Stop()
+
+	companies := make([]models.WatchedCompany, 0)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query watchlist: %w", err)
+		}
+
+		var company models.WatchedCompany
+		if err := doc.DataTo(&company); err != nil {
+			return nil, fmt.Errorf("failed to parse watched company: %w", err)
+		}
+		company.ID = doc.Ref.ID
+		companies = append(companies, company)
+	}
+
+	return companies, nil
+}
+
+// DeleteWatchedCompany removes a company from a user's watchlist
+func (f *FirestoreClient) DeleteWatchedCompany(ctx context.Context, userID, id string) error {
+	docRef := f.collection(ctx, watchlistCollection).Doc(id)
+	doc, err := docRef.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return errors.New("watched company not found")
+		}
+		return fmt.Errorf("failed to get watched company: %w", err)
+	}
+
+	var company models.WatchedCompany
+	if err := doc.DataTo(&company); err != nil {
+		return fmt.Errorf("failed to parse watched company: %w", err)
+	}
+	if company.UserID != userID {
+		return errors.New("watched company not found")
+	}
+
+	if _, err := docRef.Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete watched company: %w", err)
+	}
+	return nil
+}
+
+// UpdateWatchedCompanySeen records the posting URLs already seen for a watched company
+func (f *FirestoreClient) UpdateWatchedCompanySeen(ctx context.Context, id string, seenURLs []string) error {
+	docRef := f.collection(ctx, watchlistCollection).Doc(id)
+	_, err := docRef.Set(ctx, map[string]interface{}{
+		"seenUrls":      seenURLs,
+		"lastCheckedAt": time.Now(),
+	}, firestore.MergeAll)
+	if err != nil {
+		return fmt.Errorf("failed to update watched company: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,627 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// ATS provider identifiers
+const (
+	ATSProviderGreenhouse = "greenhouse"
+	ATSProviderLever      = "lever"
+	ATSProviderWorkable   = "workable"
+)
+
+// boardUserAgent identifies us to job board APIs; some (e.g. RemoteOK) reject requests without one
+const boardUserAgent = "MyJobMatch/1.0 (+https://myjobmatch.com)"
+
+// validBoardToken matches board tokens, company slugs and account subdomains, which go into API URL paths
+var validBoardToken = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ATSBoard describes a company job board hosted on a public ATS
+type ATSBoard struct {
+	Provider   string   `json:"provider"`
+	Token      string   `json:"token"`                // Board token / company slug / account subdomain
+	Industries []string `json:"industries,omitempty"` // Optional industry tags used to match profiles
+}
+
+// ParseATSBoards parses board entries in the form "provider:token[:industry1|industry2]"
+func ParseATSBoards(entries []string) []ATSBoard {
+	boards := make([]ATSBoard, 0, len(entries))
+	for _, entry := range entries {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 3)
+		if len(parts) < 2 || parts[1] == "" {
+			toolsLog.Warn("Ignoring invalid ATS board entry", "tool", "search_ats_boards", "entry", entry)
+			continue
+		}
+
+		board := ATSBoard{
+			Provider: strings.ToLower(parts[0]),
+			Token:    parts[1],
+		}
+		switch board.Provider {
+		case ATSProviderGreenhouse, ATSProviderLever, ATSProviderWorkable:
+		default:
+			toolsLog.Warn("Ignoring ATS board with unknown provider", "tool", "search_ats_boards", "entry", entry)
+			continue
+		}
+		if !validBoardToken.MatchString(board.Token) {
+			toolsLog.Warn("Ignoring ATS board with invalid token", "tool", "search_ats_boards", "entry", entry)
+			continue
+		}
+
+		if len(parts) == 3 {
+			for _, industry := range strings.Split(parts[2], "|") {
+				if industry = strings.TrimSpace(industry); industry != "" {
+					board.Industries = append(board.Industries, strings.ToLower(industry))
+				}
+			}
+		}
+		boards = append(boards, board)
+	}
+	return boards
+}
+
+// ATSBoardsTool discovers job postings directly from public ATS board APIs
+// (Greenhouse, Lever, Workable) for configured companies
+type ATSBoardsTool struct {
+	boards        []ATSBoard
+	client        *http.Client
+	maxConcurrent int
+}
+
+// NewATSBoardsTool creates a new ATS board discovery tool
+func NewATSBoardsTool(cfg *config.Config) *ATSBoardsTool {
+	return &ATSBoardsTool{
+		boards: ParseATSBoards(cfg.ATSBoards),
+		client: &http.Client{
+			Timeout: time.Duration(cfg.HTTPTimeoutSeconds) * time.Second,
+		},
+		maxConcurrent: 5,
+	}
+}
+
+func (t *ATSBoardsTool) Name() string {
+	return "search_ats_boards"
+}
+
+func (t *ATSBoardsTool) Version() string {
+	return "1.0.0"
+}
+
+func (t *ATSBoardsTool) Description() string {
+	return `Search company career boards hosted on Greenhouse, Lever and Workable.
+Input should include keywords and optional industries to pick relevant companies.
+Returns structured job postings that can be scored directly without fetching or extraction.`
+}
+
+func (t *ATSBoardsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"keywords": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Keywords (roles, skills) that postings should match",
+			},
+			"industries": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Industries used to select company boards (e.g., 'fintech')",
+			},
+			"boards": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Optional explicit boards in the form 'provider:token' (greenhouse, lever, workable; tokens are letters, digits, - and _)",
+			},
+		},
+		"required": []string{"keywords"},
+	}
+}
+
+// ATSSearchInput represents the input for the ATS boards tool
+type ATSSearchInput struct {
+	Keywords   []string `json:"keywords"`
+	Industries []string `json:"industries,omitempty"`
+	Boards     []string `json:"boards,omitempty"`
+}
+
+// ATSSearchResponse represents the output of the ATS boards tool
+type ATSSearchResponse struct {
+	Jobs           []models.JobPosting `json:"jobs"`
+	BoardsSearched int                 `json:"boards_searched"`
+}
+
+func (t *ATSBoardsTool) Execute(ctx context.Context, input json.RawMessage) (json.RawMessage, error) {
+	var searchInput ATSSearchInput
+	if err := json.Unmarshal(input, &searchInput); err != nil {
+		return NewErrorResult(fmt.Sprintf("invalid input: %v", err))
+	}
+
+	boards := t.boards
+	if len(searchInput.Boards) > 0 {
+		boards = ParseATSBoards(searchInput.Boards)
+	}
+	boards = selectBoards(boards, searchInput.Industries, searchInput.Keywords)
+
+	jobs := t.searchBoards(ctx, boards, searchInput.Keywords)
+
+	return NewSuccessResult(ATSSearchResponse{
+		Jobs:           jobs,
+		BoardsSearched: len(boards),
+	})
+}
+
+// SearchWithProfile discovers ATS postings relevant to a user profile and query
+func (t *ATSBoardsTool) SearchWithProfile(ctx context.Context, profile *models.UserProfile, query string) ([]models.JobPosting, error) {
+	if len(t.boards) == 0 {
+		return nil, nil
+	}
+
+	searchInput := ATSSearchInput{
+		Keywords: profileKeywords(profile, query),
+	}
+
+	inputJSON, err := json.Marshal(searchInput)
+	if err != nil {
+		return nil, err
+	}
+
+	resultJSON, err := t.Execute(ctx, inputJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ToolResult
+	if err := json.Unmarshal(resultJSON, &result); err != nil {
+		return nil, err
+	}
+
+	if !result.Success {
+		return nil, errors.New(result.Error)
+	}
+
+	var response ATSSearchResponse
+	if err := json.Unmarshal(result.Data, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Jobs, nil
+}
+
+// searchBoards queries all boards concurrently and keeps postings matching the keywords
+func (t *ATSBoardsTool) searchBoards(ctx context.Context, boards []ATSBoard, keywords []string) []models.JobPosting {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, t.maxConcurrent)
+	jobs := make([]models.JobPosting, 0)
+
+	for _, board := range boards {
+		wg.Add(1)
+		go func(b ATSBoard) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			postings, err := t.FetchBoard(ctx, b)
+			if err != nil {
+				toolsLog.WarnContext(ctx, "Failed to fetch ATS board", "tool", t.Name(), "provider", b.Provider, "board", b.Token, "error", err)
+				return
+			}
+
+			matched := 0
+			mu.Lock()
+			for _, posting := range postings {
+				if matchesKeywords(posting, keywords) {
+					jobs = append(jobs, posting)
+					matched++
+				}
+			}
+			mu.Unlock()
+			toolsLog.InfoContext(ctx, "Fetched ATS board", "tool", t.Name(), "provider", b.Provider, "board", b.Token,
+				"postings", len(postings), "matched", matched)
+		}(board)
+	}
+
+	wg.Wait()
+	return jobs
+}
+
+// FetchBoard fetches all postings from a single ATS board
+func (t *ATSBoardsTool) FetchBoard(ctx context.Context, board ATSBoard) ([]models.JobPosting, error) {
+	if !validBoardToken.MatchString(board.Token) {
+		return nil, fmt.Errorf("invalid ATS board token: %q", board.Token)
+	}
+	switch board.Provider {
+	case ATSProviderGreenhouse:
+		return t.fetchGreenhouse(ctx, board.Token)
+	case ATSProviderLever:
+		return t.fetchLever(ctx, board.Token)
+	case ATSProviderWorkable:
+		return t.fetchWorkable(ctx, board.Token)
+	default:
+		return nil, fmt.Errorf("unsupported ATS provider: %s", board.Provider)
+	}
+}
+
+// greenhouseResponse represents the Greenhouse job board API response
+type greenhouseResponse struct {
+	Jobs []struct {
+		ID          int64  `json:"id"`
+		Title       string `json:"title"`
+		AbsoluteURL string `json:"absolute_url"`
+		UpdatedAt   string `json:"updated_at"`
+		// FirstPublished is when the posting went live; older boards only report UpdatedAt
+		FirstPublished string `json:"first_published"`
+		Content        string `json:"content"`
+		CompanyName    string `json:"company_name"`
+		Location       struct {
+			Name string `json:"name"`
+		} `json:"location"`
+		Departments []struct {
+			Name string `json:"name"`
+		} `json:"departments"`
+	} `json:"jobs"`
+}
+
+func (t *ATSBoardsTool) fetchGreenhouse(ctx context.Context, token string) ([]models.JobPosting, error) {
+	var resp greenhouseResponse
+	reqURL := fmt.Sprintf("https://boards-api.greenhouse.io/v1/boards/%s/jobs?content=true", token)
+	if err := t.getJSON(ctx, reqURL, &resp); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]models.JobPosting, 0, len(resp.Jobs))
+	for _, j := range resp.Jobs {
+		company := j.CompanyName
+		if company == "" {
+			company = token
+		}
+		description := stripTags(html.UnescapeString(j.Content))
+
+		var tags []string
+		for _, d := range j.Departments {
+			tags = append(tags, d.Name)
+		}
+
+		datePosted := j.FirstPublished
+		if datePosted == "" {
+			datePosted = j.UpdatedAt
+		}
+
+		jobs = append(jobs, models.JobPosting{
+			Title:          j.Title,
+			Company:        company,
+			Description:    truncateText(description, 500),
+			Location:       j.Location.Name,
+			WorkType:       models.WorkTypeFullTime,
+			SiteSetting:    siteSettingFromText(j.Location.Name + " " + description),
+			URL:            j.AbsoluteURL,
+			ApplicationURL: j.AbsoluteURL,
+			Source:         ATSProviderGreenhouse,
+			DatePosted:     ldDate(datePosted),
+			Tags:           tags,
+		})
+	}
+	return jobs, nil
+}
+
+// leverPosting represents a posting from the Lever postings API
+type leverPosting struct {
+	ID               string `json:"id"`
+	Text             string `json:"text"`
+	HostedURL        string `json:"hostedUrl"`
+	ApplyURL         string `json:"applyUrl"`
+	DescriptionPlain string `json:"descriptionPlain"`
+	WorkplaceType    string `json:"workplaceType"`
+	CreatedAt        int64  `json:"createdAt"`
+	Categories       struct {
+		Location   string `json:"location"`
+		Commitment string `json:"commitment"`
+		Team       string `json:"team"`
+		Department string `json:"department"`
+	} `json:"categories"`
+	// Lists holds titled sections such as "Requirements" or "What you'll do"; content is HTML
+	Lists []struct {
+		Text    string `json:"text"`
+		Content string `json:"content"`
+	} `json:"lists"`
+	SalaryRange *struct {
+		Min      float64 `json:"min"`
+		Max      float64 `json:"max"`
+		Currency string  `json:"currency"`
+		Interval string  `json:"interval"` // e.g. "per-year-salary", "per-month-salary"
+	} `json:"salaryRange"`
+}
+
+func (t *ATSBoardsTool) fetchLever(ctx context.Context, company string) ([]models.JobPosting, error) {
+	var postings []leverPosting
+	reqURL := fmt.Sprintf("https://api.lever.co/v0/postings/%s?mode=json", company)
+	if err := t.getJSON(ctx, reqURL, &postings); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]models.JobPosting, 0, len(postings))
+	for _, p := range postings {
+		var datePosted string
+		if p.CreatedAt > 0 {
+			datePosted = time.UnixMilli(p.CreatedAt).UTC().Format("2006-01-02")
+		}
+
+		siteSetting := models.NormalizeSiteSetting(p.WorkplaceType)
+		if siteSetting == models.SiteSettingUnknown {
+			siteSetting = siteSettingFromText(p.Categories.Location)
+		}
+
+		var tags []string
+		for _, tag := range []string{p.Categories.Team, p.Categories.Department} {
+			if tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+
+		job := models.JobPosting{
+			Title:          p.Text,
+			Company:        company,
+			Description:    truncateText(p.DescriptionPlain, 500),
+			Location:       p.Categories.Location,
+			WorkType:       models.NormalizeWorkType(p.Categories.Commitment),
+			SiteSetting:    siteSetting,
+			URL:            p.HostedURL,
+			ApplicationURL: p.ApplyURL,
+			Source:         ATSProviderLever,
+			DatePosted:     datePosted,
+			Tags:           tags,
+			Requirements:   truncateText(leverRequirements(p), 500),
+		}
+		if r := p.SalaryRange; r != nil && (r.Min > 0 || r.Max > 0) {
+			job.SalaryMin, job.SalaryMax = int(r.Min), int(max(r.Max, r.Min))
+			job.SalaryCurrency = strings.ToUpper(r.Currency)
+			job.SalaryPeriod = utils.NormalizeSalaryPeriod(r.Interval)
+			job.Salary = fmt.Sprintf("%s %d - %d %s", job.SalaryCurrency, job.SalaryMin, job.SalaryMax,
+				strings.ReplaceAll(strings.TrimSuffix(r.Interval, "-salary"), "-", " "))
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// leverRequirements returns the text of a posting's requirement/qualification lists
+func leverRequirements(p leverPosting) string {
+	var sections []string
+	for _, list := range p.Lists {
+		title := strings.ToLower(list.Text)
+		if strings.Contains(title, "requirement") || strings.Contains(title, "qualification") ||
+			strings.Contains(title, "you have") || strings.Contains(title, "looking for") {
+			sections = append(sections, stripTags(html.UnescapeString(list.Content)))
+		}
+	}
+	return strings.Join(sections, " ")
+}
+
+// workableResponse represents the Workable widget API response
+type workableResponse struct {
+	Name string `json:"name"`
+	Jobs []struct {
+		Title          string `json:"title"`
+		Shortcode      string `json:"shortcode"`
+		EmploymentType string `json:"employment_type"`
+		Telecommuting  bool   `json:"telecommuting"`
+		Department     string `json:"department"`
+		URL            string `json:"url"`
+		ApplicationURL string `json:"application_url"`
+		PublishedOn    string `json:"published_on"`
+		City           string `json:"city"`
+		Country        string `json:"country"`
+		Description    string `json:"description"`
+	} `json:"jobs"`
+}
+
+func (t *ATSBoardsTool) fetchWorkable(ctx context.Context, account string) ([]models.JobPosting, error) {
+	var resp workableResponse
+	reqURL := fmt.Sprintf("https://apply.workable.com/api/v1/widget/accounts/%s?details=true", account)
+	if err := t.getJSON(ctx, reqURL, &resp); err != nil {
+		return nil, err
+	}
+
+	company := resp.Name
+	if company == "" {
+		company = account
+	}
+
+	jobs := make([]models.JobPosting, 0, len(resp.Jobs))
+	for _, j := range resp.Jobs {
+		location := strings.Trim(strings.Join([]string{j.City, j.Country}, ", "), ", ")
+		description := stripTags(j.Description)
+
+		siteSetting := siteSettingFromText(location + " " + description)
+		if j.Telecommuting {
+			siteSetting = models.SiteSettingWFH
+		}
+
+		var tags []string
+		if j.Department != "" {
+			tags = append(tags, j.Department)
+		}
+
+		jobs = append(jobs, models.JobPosting{
+			Title:          j.Title,
+			Company:        company,
+			Description:    truncateText(description, 500),
+			Location:       location,
+			WorkType:       models.NormalizeWorkType(j.EmploymentType),
+			SiteSetting:    siteSetting,
+			URL:            j.URL,
+			ApplicationURL: j.ApplicationURL,
+			Source:         ATSProviderWorkable,
+			DatePosted:     j.PublishedOn,
+			Tags:           tags,
+		})
+	}
+	return jobs, nil
+}
+
+// getJSON performs a GET request and decodes the JSON response into out
+func (t *ATSBoardsTool) getJSON(ctx context.Context, reqURL string, out interface{}) error {
+	body, err := getBoardFeed(ctx, t.client, reqURL, "application/json")
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// getBoardFeed performs a GET request against a job board API and returns the response body
+func getBoardFeed(ctx context.Context, client *http.Client, reqURL, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", boardUserAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("board API returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return body, nil
+}
+
+// selectBoards keeps boards without industry tags plus those whose tags match the given industries or keywords
+func selectBoards(boards []ATSBoard, industries, keywords []string) []ATSBoard {
+	terms := make([]string, 0, len(industries)+len(keywords))
+	for _, term := range append(industries, keywords...) {
+		terms = append(terms, strings.ToLower(term))
+	}
+
+	selected := make([]ATSBoard, 0, len(boards))
+	for _, board := range boards {
+		if len(board.Industries) == 0 {
+			selected = append(selected, board)
+			continue
+		}
+		for _, industry := range board.Industries {
+			if containsTerm(terms, industry) {
+				selected = append(selected, board)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// containsTerm reports whether any term contains (or is contained in) the needle
+func containsTerm(terms []string, needle string) bool {
+	for _, term := range terms {
+		if strings.Contains(term, needle) || strings.Contains(needle, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesKeywords reports whether a posting's title or tags mention any of the keywords
+func matchesKeywords(job models.JobPosting, keywords []string) bool {
+	if len(keywords) == 0 {
+		return true
+	}
+
+	haystack := strings.ToLower(job.Title + " " + strings.Join(job.Tags, " "))
+	for _, keyword := range keywords {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		if keyword != "" && strings.Contains(haystack, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// profileKeywords collects role and skill keywords from a profile and query
+func profileKeywords(profile *models.UserProfile, query string) []string {
+	seen := make(map[string]bool)
+	var keywords []string
+
+	add := func(values ...string) {
+		for _, v := range values {
+			v = strings.ToLower(strings.TrimSpace(v))
+			if len(v) < 2 || seen[v] {
+				continue
+			}
+			seen[v] = true
+			keywords = append(keywords, v)
+		}
+	}
+
+	if profile != nil {
+		add(profile.Title)
+		add(profile.PreferredRoles...)
+		add(profile.Skills...)
+	}
+	for _, word := range strings.Fields(query) {
+		if !queryStopWords[strings.ToLower(word)] {
+			add(word)
+		}
+	}
+	return keywords
+}
+
+// queryStopWords are query words that carry no role or skill signal
+var queryStopWords = map[string]bool{
+	"job": true, "jobs": true, "in": true, "at": true, "for": true, "and": true,
+	"remote": true, "hybrid": true, "wfh": true, "wfo": true, "the": true,
+}
+
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// stripTags removes HTML tags and collapses whitespace
+func stripTags(s string) string {
+	return strings.Join(strings.Fields(tagPattern.ReplaceAllString(s, " ")), " ")
+}
+
+// truncateText shortens text to at most maxLen bytes
+func truncateText(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen]
+}
+
+// siteSettingFromText infers a site setting from free-form location/description text
+func siteSettingFromText(text string) string {
+	lower := strings.ToLower(text)
+	switch {
+	case strings.Contains(lower, "hybrid"):
+		return models.SiteSettingHybrid
+	case strings.Contains(lower, "remote"), strings.Contains(lower, "work from home"):
+		return models.SiteSettingWFH
+	default:
+		return models.SiteSettingUnknown
+	}
+}
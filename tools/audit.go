@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// AuditStore persists recorded tool calls
+type AuditStore interface {
+	SaveToolAuditRecord(ctx context.Context, record *models.ToolAuditRecord) error
+}
+
+// Bounds on recorded text, keeping records small
+const (
+	maxAuditArgumentChars = 2000
+	maxAuditErrorChars    = 500
+)
+
+// auditSaveTimeout bounds writing a record, which happens in the background
+const auditSaveTimeout = 10 * time.Second
+
+// SetAuditStore records every call made through Execute to store, attributed to source (mcp or agent)
+// and to the caller in the context, keeping records for ttl
+func (r *ToolRegistry) SetAuditStore(store AuditStore, source string, ttl time.Duration) {
+	r.auditStore = store
+	r.auditSource = source
+	r.auditTTL = ttl
+}
+
+// callOutcome is whether a tool call succeeded, read from its error or ToolResult
+type callOutcome struct {
+	success bool
+	code    string
+	message string
+}
+
+// outcomeOf reads the outcome of a tool call
+func outcomeOf(result json.RawMessage, callErr error) callOutcome {
+	var toolResult ToolResult
+	switch {
+	case callErr != nil:
+		return callOutcome{code: errorCode(callErr), message: callErr.Error()}
+	case json.Unmarshal(result, &toolResult) != nil:
+		return callOutcome{success: true} // Tools outside this package may not return a ToolResult
+	default:
+		return callOutcome{success: toolResult.Success, code: toolResult.Code, message: toolResult.Error}
+	}
+}
+
+// label names the outcome in logs: "success", or the error code of a failed call
+func (o callOutcome) label() string {
+	switch {
+	case o.success:
+		return "success"
+	case o.code != "":
+		return o.code
+	default:
+		return "error"
+	}
+}
+
+// recordCall stores an audit record of a tool call in the background, if auditing is enabled
+func (r *ToolRegistry) recordCall(ctx context.Context, name string, input json.RawMessage, outcome callOutcome, started time.Time) {
+	if r.auditStore == nil {
+		return
+	}
+
+	now := time.Now()
+	caller := utils.CallerFromContext(ctx)
+	record := &models.ToolAuditRecord{
+		Tool:        name,
+		Source:      r.auditSource,
+		CallerEmail: caller.Email,
+		APITokenID:  caller.APITokenID,
+		ClientIP:    caller.ClientIP,
+		RequestID:   utils.RequestIDFromContext(ctx),
+		Arguments:   truncateAudit(utils.RedactText(string(input)), maxAuditArgumentChars),
+		DurationMs:  now.Sub(started).Milliseconds(),
+		Success:     outcome.success,
+		ErrorCode:   outcome.code,
+		Error:       truncateAudit(outcome.message, maxAuditErrorChars),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(r.auditTTL),
+	}
+
+	go func() {
+		saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditSaveTimeout)
+		defer cancel()
+		if err := r.auditStore.SaveToolAuditRecord(saveCtx, record); err != nil {
+			toolsLog.ErrorContext(saveCtx, "Failed to save audit record", "tool", name, "error", err)
+		}
+	}()
+}
+
+// truncateAudit bounds a recorded text, marking the cut
+func truncateAudit(text string, n int) string {
+	if len(text) > n {
+		return text[:n] + "...[truncated]"
+	}
+	return text
+}
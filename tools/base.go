@@ -3,6 +3,17 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/logging"
+	"github.com/myjobmatch/backend/utils"
 )
 
 // Tool represents an MCP tool interface
@@ -10,6 +21,10 @@ type Tool interface {
 	// Name returns the tool name
 	Name() string
 
+	// Version returns the tool's semantic version. The major version changes when the input schema or
+	// result changes incompatibly (e.g. a renamed input field), the minor version when fields are added.
+	Version() string
+
 	// Description returns the tool description for the agent
 	Description() string
 
@@ -20,15 +35,39 @@ type Tool interface {
 	Execute(ctx context.Context, input json.RawMessage) (json.RawMessage, error)
 }
 
+var toolsLog = logging.Component("Tools")
+
+// ErrToolTimeout is the cause of results for tool calls that ran past their timeout
+var ErrToolTimeout = errors.New("tool call timed out")
+
 // ToolRegistry holds all available tools
 type ToolRegistry struct {
 	tools map[string]Tool
+
+	defaultTimeout time.Duration            // Applied by Execute to tools without their own timeout (0 = none)
+	timeouts       map[string]time.Duration // Per-tool timeouts by tool name
+
+	auditStore  AuditStore // Records calls made through Execute (nil = not recorded)
+	auditSource string
+	auditTTL    time.Duration
+
+	deprecations map[string]Deprecation // Deprecated tools by name
+	switches     *ToolSwitches          // Tools turned off at runtime (nil = all enabled)
+	quotas       *quotaLimiter          // Per-caller call quotas (nil = unlimited)
+}
+
+// Deprecation marks a tool that will be removed or replaced
+type Deprecation struct {
+	Message     string `json:"message"`
+	ReplacedBy  string `json:"replacedBy,omitempty"`  // Tool to use instead, if any
+	RemovalDate string `json:"removalDate,omitempty"` // YYYY-MM-DD after which the tool may be removed
 }
 
 // NewToolRegistry creates a new tool registry
 func NewToolRegistry() *ToolRegistry {
 	return &ToolRegistry{
-		tools: make(map[string]Tool),
+		tools:        make(map[string]Tool),
+		deprecations: make(map[string]Deprecation),
 	}
 }
 
@@ -43,6 +82,169 @@ func (r *ToolRegistry) Get(name string) (Tool, bool) {
 	return tool, ok
 }
 
+// SetSwitches makes the registry honor the runtime on/off switches: Execute refuses disabled tools
+func (r *ToolRegistry) SetSwitches(switches *ToolSwitches) {
+	r.switches = switches
+}
+
+// Enabled reports whether a tool is turned on
+func (r *ToolRegistry) Enabled(name string) bool {
+	return r.switches.Enabled(name)
+}
+
+// DisabledReason returns why a tool is turned off, or "" if it is enabled
+func (r *ToolRegistry) DisabledReason(name string) string {
+	return r.switches.Reason(name)
+}
+
+// Deprecate marks a registered tool as deprecated; it keeps working, and lists show the deprecation
+func (r *ToolRegistry) Deprecate(name string, deprecation Deprecation) {
+	r.deprecations[name] = deprecation
+}
+
+// Deprecation returns a tool's deprecation, or nil if it isn't deprecated
+func (r *ToolRegistry) Deprecation(name string) *Deprecation {
+	deprecation, ok := r.deprecations[name]
+	if !ok {
+		return nil
+	}
+	return &deprecation
+}
+
+// Describe returns a tool's description, prefixed with its deprecation notice so agents reading it notice
+func (r *ToolRegistry) Describe(tool Tool) string {
+	deprecation := r.Deprecation(tool.Name())
+	if deprecation == nil {
+		return tool.Description()
+	}
+
+	notice := "DEPRECATED: " + deprecation.Message
+	if deprecation.ReplacedBy != "" {
+		notice += " Use " + deprecation.ReplacedBy + " instead."
+	}
+	return notice + "\n" + tool.Description()
+}
+
+// CompatibleVersion reports whether a tool at version current satisfies a caller expecting version
+// requested: the major versions must match. "2" and "2.1.0" both request major version 2.
+func CompatibleVersion(current, requested string) bool {
+	major := func(version string) string {
+		major, _, _ := strings.Cut(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".")
+		return major
+	}
+	return major(current) == major(requested)
+}
+
+// SetTimeouts sets the execution timeouts applied by Execute: TOOL_TIMEOUT_SECONDS for every tool
+// (0 = no timeout) unless TOOL_TIMEOUTS gives the tool its own
+func (r *ToolRegistry) SetTimeouts(cfg *config.Config) error {
+	perTool, err := parseToolTimeouts(cfg.ToolTimeouts)
+	if err != nil {
+		return err
+	}
+	r.defaultTimeout = time.Duration(cfg.ToolTimeoutSeconds) * time.Second
+	r.timeouts = perTool
+	return nil
+}
+
+// SetQuotas caps each caller's calls to the tools listed in TOOL_QUOTAS (name:perMinute/perDay entries);
+// Execute refuses calls over a quota with a QuotaError
+func (r *ToolRegistry) SetQuotas(cfg *config.Config) error {
+	quotas, err := parseToolQuotas(cfg.ToolQuotas)
+	if err != nil {
+		return err
+	}
+	if len(quotas) == 0 {
+		r.quotas = nil
+		return nil
+	}
+	r.quotas = newQuotaLimiter(quotas)
+	return nil
+}
+
+// Timeout returns the execution timeout of a tool (0 = none)
+func (r *ToolRegistry) Timeout(name string) time.Duration {
+	if timeout, ok := r.timeouts[name]; ok {
+		return timeout
+	}
+	return r.defaultTimeout
+}
+
+// Execute runs a registered tool within its timeout and records the call in the audit log. The context
+// is passed down to the tool's PSE, fetch and Gemini calls, so a cancelled call stops them. A call that
+// runs past its timeout gets an error result with the timeout code, and a call to a disabled tool one
+// with the tool_disabled code. A call over the caller's quota for the tool fails with a QuotaError.
+func (r *ToolRegistry) Execute(ctx context.Context, name string, input json.RawMessage) (json.RawMessage, error) {
+	tool, ok := r.tools[name]
+	if !ok {
+		return nil, fmt.Errorf("tool not found: %s", name)
+	}
+
+	started := time.Now()
+	var result json.RawMessage
+	var err error
+	switch {
+	case !r.Enabled(name):
+		result, err = NewGenerationErrorResult(name+" is unavailable", fmt.Errorf("%w: %s", ErrToolDisabled, r.DisabledReason(name)))
+	case r.quotas != nil:
+		if err = r.quotas.acquire(name, quotaCaller(utils.CallerFromContext(ctx)), started); err != nil {
+			toolsLog.WarnContext(ctx, "Tool call refused", "tool", name, "error", err)
+			break
+		}
+		result, err = r.execute(ctx, tool, input)
+	default:
+		result, err = r.execute(ctx, tool, input)
+	}
+	outcome := outcomeOf(result, err)
+	r.logCall(ctx, name, outcome, started)
+	r.recordCall(ctx, name, input, outcome, started)
+	return result, err
+}
+
+// logCall writes a structured record of a tool call: failed calls at warning level, the rest at info level
+func (r *ToolRegistry) logCall(ctx context.Context, name string, outcome callOutcome, started time.Time) {
+	level := slog.LevelInfo
+	attrs := []any{"tool", name, "duration_ms", time.Since(started).Milliseconds(), "outcome", outcome.label()}
+	if !outcome.success {
+		level = slog.LevelWarn
+		attrs = append(attrs, "error", outcome.message)
+	}
+	toolsLog.Log(ctx, level, "Tool call completed", attrs...)
+}
+
+// execute runs a tool within its timeout
+func (r *ToolRegistry) execute(ctx context.Context, tool Tool, input json.RawMessage) (json.RawMessage, error) {
+	name := tool.Name()
+	timeout := r.Timeout(name)
+	if timeout <= 0 {
+		return tool.Execute(ctx, input)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := tool.Execute(callCtx, input)
+	if errors.Is(callCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		toolsLog.WarnContext(ctx, "Tool call timed out", "tool", name, "timeout_ms", timeout.Milliseconds())
+		return NewGenerationErrorResult(name+" did not finish in time", fmt.Errorf("%w after %s", ErrToolTimeout, timeout))
+	}
+	return result, err
+}
+
+// parseToolTimeouts parses per-tool timeouts given as name:seconds entries (e.g. "search_jobs:110")
+func parseToolTimeouts(entries []string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, ":")
+		seconds, err := strconv.Atoi(strings.TrimSpace(value))
+		if !ok || strings.TrimSpace(name) == "" || err != nil || seconds < 0 {
+			return nil, fmt.Errorf("invalid tool timeout %q, expected name:seconds", entry)
+		}
+		timeouts[strings.TrimSpace(name)] = time.Duration(seconds) * time.Second
+	}
+	return timeouts, nil
+}
+
 // List returns all registered tools
 func (r *ToolRegistry) List() []Tool {
 	tools := make([]Tool, 0, len(r.tools))
@@ -58,21 +260,75 @@ func (r *ToolRegistry) GetToolDefinitions() []map[string]interface{} {
 	for _, tool := range r.tools {
 		def := map[string]interface{}{
 			"name":        tool.Name(),
-			"description": tool.Description(),
+			"version":     tool.Version(),
+			"description": r.Describe(tool),
 			"parameters":  tool.InputSchema(),
 		}
+		if deprecation := r.Deprecation(tool.Name()); deprecation != nil {
+			def["deprecation"] = deprecation
+		}
+		if !r.Enabled(tool.Name()) {
+			def["enabled"] = false
+		}
 		definitions = append(definitions, def)
 	}
 	return definitions
 }
 
+// Machine-readable causes of tool errors
+const (
+	// ErrorCodeContentBlocked marks tool errors caused by Gemini's safety filters
+	ErrorCodeContentBlocked = "content_blocked"
+	// ErrorCodeCapacityExceeded marks tool errors caused by the Gemini rate limit or call quotas; retry later
+	ErrorCodeCapacityExceeded = "capacity_exceeded"
+	// ErrorCodeServiceUnavailable marks tool errors caused by an open circuit breaker (e.g. during a Vertex AI outage); retry later
+	ErrorCodeServiceUnavailable = "service_unavailable"
+	// ErrorCodeTimeout marks tool calls that ran past their timeout (TOOL_TIMEOUT_SECONDS / TOOL_TIMEOUTS)
+	ErrorCodeTimeout = "timeout"
+	// ErrorCodeToolDisabled marks calls to a tool turned off by an administrator or DISABLED_TOOLS
+	ErrorCodeToolDisabled = "tool_disabled"
+	// ErrorCodeQuotaExceeded marks calls over the caller's quota for the tool (TOOL_QUOTAS)
+	ErrorCodeQuotaExceeded = "quota_exceeded"
+)
+
+// errorCodeCauses maps error codes to the errors they stand for
+var errorCodeCauses = map[string]error{
+	ErrorCodeContentBlocked:     gemini.ErrContentBlocked,
+	ErrorCodeCapacityExceeded:   gemini.ErrCapacityExceeded,
+	ErrorCodeServiceUnavailable: utils.ErrCircuitOpen,
+	ErrorCodeTimeout:            ErrToolTimeout,
+	ErrorCodeToolDisabled:       ErrToolDisabled,
+	ErrorCodeQuotaExceeded:      ErrQuotaExceeded,
+}
+
 // ToolResult represents the result of a tool execution
 type ToolResult struct {
 	Success bool            `json:"success"`
 	Data    json.RawMessage `json:"data,omitempty"`
 	Error   string          `json:"error,omitempty"`
+	Code    string          `json:"code,omitempty"` // Machine-readable error cause, e.g. content_blocked or capacity_exceeded
+}
+
+// Err converts a failed result back to an error for direct tool methods.
+// Results with an error code match its error, e.g. content_blocked matches gemini.ErrContentBlocked.
+func (r ToolResult) Err() error {
+	if cause, ok := errorCodeCauses[r.Code]; ok {
+		return &codedResultError{msg: r.Error, cause: cause}
+	}
+	return errors.New(r.Error)
 }
 
+// codedResultError is a tool error with a known cause
+type codedResultError struct {
+	msg   string
+	cause error
+}
+
+func (e *codedResultError) Error() string { return e.msg }
+
+// Is makes errors.Is match the error the code stands for
+func (e *codedResultError) Is(target error) bool { return target == e.cause }
+
 // NewSuccessResult creates a successful tool result
 func NewSuccessResult(data interface{}) (json.RawMessage, error) {
 	result := ToolResult{Success: true}
@@ -92,3 +348,37 @@ func NewErrorResult(errMsg string) (json.RawMessage, error) {
 	}
 	return json.Marshal(result)
 }
+
+// NewGenerationErrorResult creates an error result for a failed Gemini call, marking content blocked by the
+// safety filters, calls refused by the rate limit or an open circuit breaker, and timed out tool calls
+func NewGenerationErrorResult(errMsg string, err error) (json.RawMessage, error) {
+	result := ToolResult{
+		Success: false,
+		Error:   fmt.Sprintf("%s: %v", errMsg, err),
+	}
+	result.Code = errorCode(err)
+	return json.Marshal(result)
+}
+
+// errorCode returns the error code standing for err, or "" if none does
+func errorCode(err error) string {
+	for code, cause := range errorCodeCauses {
+		if errors.Is(err, cause) {
+			return code
+		}
+	}
+	return ""
+}
+
+// NewErrorResultWithData creates an error tool result that also carries details about the failure
+func NewErrorResultWithData(errMsg string, data interface{}) (json.RawMessage, error) {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(ToolResult{
+		Success: false,
+		Data:    dataBytes,
+		Error:   errMsg,
+	})
+}
@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/utils"
+)
+
+var (
+	sharedBreakersOnce sync.Once
+	pseBreaker         *utils.CircuitBreaker
+	fetchBreaker       *utils.CircuitBreaker
+)
+
+// sharedBreakers returns the PSE and page fetch circuit breakers shared by every tool instance, so the
+// agent's and MCP calls trip the same breaker. Both are nil when CIRCUIT_BREAKER_THRESHOLD is 0.
+func sharedBreakers(cfg *config.Config) (pse, fetch *utils.CircuitBreaker) {
+	sharedBreakersOnce.Do(func() {
+		cooldown := time.Duration(cfg.CircuitBreakerCooldownSeconds) * time.Second
+		pseBreaker = utils.NewCircuitBreaker("PSE", cfg.CircuitBreakerThreshold, cooldown, pseOutage)
+		fetchBreaker = utils.NewCircuitBreaker("Page fetch", cfg.CircuitBreakerThreshold, cooldown, fetchOutage)
+	})
+	return pseBreaker, fetchBreaker
+}
+
+// pseOutage reports whether a PSE error means the API is failing: network errors, rate limiting and
+// server errors count, while cancelled requests and other client errors (e.g. a bad query) don't
+func pseOutage(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var se *pseStatusError
+	if errors.As(err, &se) {
+		return se.code == http.StatusTooManyRequests || se.code >= 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// fetchOutage reports whether a page fetch error means outbound fetching is failing (connection
+// errors and timeouts). Error statuses are the page's own failure and don't count, nor do refused
+// private addresses.
+func fetchOutage(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, utils.ErrPrivateAddress) {
+		return false
+	}
+	var se *statusError
+	if errors.As(err, &se) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
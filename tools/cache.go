@@ -0,0 +1,185 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/myjobmatch/backend/config"
+)
+
+// CachedTool decorates an idempotent tool with an in-memory cache of its successful results, keyed by a
+// hash of the input, so an agent retrying a call doesn't spend PSE or Gemini quota again. Failed results
+// are not cached. With a shared cache (Redis) results are shared across instances, and the in-memory cache
+// is only used when it fails. It is safe for concurrent use.
+type CachedTool struct {
+	Tool
+
+	ttl      time.Duration
+	capacity int
+	shared   ResultCache
+
+	mu      sync.Mutex
+	entries map[string]cachedResult
+	order   []string // Insertion order, oldest first
+}
+
+// ResultCache is a cache shared across instances, such as cache.RedisCache
+type ResultCache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+type cachedResult struct {
+	result    json.RawMessage
+	expiresAt time.Time
+}
+
+// NewCachedTool wraps tool with a cache holding up to capacity results for ttl each
+func NewCachedTool(tool Tool, ttl time.Duration, capacity int) *CachedTool {
+	return &CachedTool{
+		Tool:     tool,
+		ttl:      ttl,
+		capacity: capacity,
+		entries:  make(map[string]cachedResult),
+	}
+}
+
+// Execute returns the cached result for the same input, or runs the tool and caches a successful result
+func (t *CachedTool) Execute(ctx context.Context, input json.RawMessage) (json.RawMessage, error) {
+	key, err := cacheKey(input)
+	if err != nil {
+		// Invalid JSON; let the tool report it
+		return t.Tool.Execute(ctx, input)
+	}
+
+	if result, ok := t.get(ctx, key); ok {
+		toolsLog.DebugContext(ctx, "Served from cache", "tool", t.Name())
+		return result, nil
+	}
+
+	result, err := t.Tool.Execute(ctx, input)
+	if err != nil {
+		return result, err
+	}
+	var toolResult ToolResult
+	if json.Unmarshal(result, &toolResult) == nil && toolResult.Success {
+		t.put(ctx, key, result)
+	}
+	return result, nil
+}
+
+// sharedKey namespaces a result in the shared cache by tool
+func (t *CachedTool) sharedKey(key string) string {
+	return "tool:" + t.Name() + ":" + key
+}
+
+func (t *CachedTool) get(ctx context.Context, key string) (json.RawMessage, bool) {
+	if t.shared != nil {
+		result, ok, err := t.shared.Get(ctx, t.sharedKey(key))
+		if err == nil {
+			return result, ok
+		}
+		toolsLog.WarnContext(ctx, "Shared cache read failed, using memory", "tool", t.Name(), "error", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.result, true
+}
+
+func (t *CachedTool) put(ctx context.Context, key string, result json.RawMessage) {
+	if t.shared != nil {
+		err := t.shared.Set(ctx, t.sharedKey(key), result, t.ttl)
+		if err == nil {
+			return
+		}
+		toolsLog.WarnContext(ctx, "Shared cache write failed, using memory", "tool", t.Name(), "error", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, exists := t.entries[key]; !exists {
+		t.order = append(t.order, key)
+	}
+	t.entries[key] = cachedResult{result: result, expiresAt: time.Now().Add(t.ttl)}
+
+	for len(t.order) > t.capacity {
+		delete(t.entries, t.order[0])
+		t.order = t.order[1:]
+	}
+}
+
+// cacheKey hashes the input after re-encoding it, so key order and whitespace don't cause misses
+func cacheKey(input json.RawMessage) (string, error) {
+	var value interface{}
+	if err := json.Unmarshal(input, &value); err != nil {
+		return "", err
+	}
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(normalized)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// SetCaches wraps the tools listed in TOOL_CACHE_TTLS (name:minutes entries) in a CachedTool holding up to
+// TOOL_CACHE_SIZE results each. List only idempotent tools; call it after registering them.
+func (r *ToolRegistry) SetCaches(cfg *config.Config) error {
+	ttls, err := parseToolCacheTTLs(cfg.ToolCacheTTLs)
+	if err != nil {
+		return err
+	}
+	if cfg.ToolCacheSize <= 0 {
+		return nil
+	}
+
+	for name, ttl := range ttls {
+		tool, ok := r.tools[name]
+		if !ok || ttl <= 0 {
+			continue
+		}
+		if _, cached := tool.(*CachedTool); cached {
+			continue
+		}
+		r.tools[name] = NewCachedTool(tool, ttl, cfg.ToolCacheSize)
+	}
+	return nil
+}
+
+// SetSharedCache makes the cached tools keep their results in a cache shared across instances, falling
+// back to memory when it fails. Call it after SetCaches.
+func (r *ToolRegistry) SetSharedCache(shared ResultCache) {
+	for _, tool := range r.tools {
+		if cached, ok := tool.(*CachedTool); ok {
+			cached.shared = shared
+		}
+	}
+}
+
+// parseToolCacheTTLs parses per-tool cache TTLs given as name:minutes entries (e.g. "fetch_page_html:30")
+func parseToolCacheTTLs(entries []string) (map[string]time.Duration, error) {
+	ttls := make(map[string]time.Duration, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, ":")
+		minutes, err := strconv.Atoi(strings.TrimSpace(value))
+		if !ok || strings.TrimSpace(name) == "" || err != nil || minutes < 0 {
+			return nil, fmt.Errorf("invalid tool cache TTL %q, expected name:minutes", entry)
+		}
+		ttls[strings.TrimSpace(name)] = time.Duration(minutes) * time.Minute
+	}
+	return ttls, nil
+}
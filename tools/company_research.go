@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/models"
+)
+
+// companyResearchResults is how many web results are summarized per company
+const companyResearchResults = 8
+
+// CompanyResearchTool looks up company information with web search and Gemini summarization
+type CompanyResearchTool struct {
+	searchTool   *SearchWebTool
+	geminiClient *gemini.Client
+}
+
+// NewCompanyResearchTool creates a new company research tool
+func NewCompanyResearchTool(searchTool *SearchWebTool, geminiClient *gemini.Client) *CompanyResearchTool {
+	return &CompanyResearchTool{
+		searchTool:   searchTool,
+		geminiClient: geminiClient,
+	}
+}
+
+func (t *CompanyResearchTool) Name() string {
+	return "company_research"
+}
+
+func (t *CompanyResearchTool) Version() string {
+	return "1.0.0"
+}
+
+func (t *CompanyResearchTool) Description() string {
+	return `Research a company using web search and AI summarization.
+Input should include the company name and optionally its location.
+Returns the company's website, industry, size, employee rating and a short summary.`
+}
+
+func (t *CompanyResearchTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"company": map[string]interface{}{
+				"type":        "string",
+				"description": "Company name (e.g. 'PT Xendit Indonesia')",
+			},
+			"location": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional location to disambiguate companies with similar names",
+			},
+		},
+		"required": []string{"company"},
+	}
+}
+
+// CompanyResearchInput represents the input for company research
+type CompanyResearchInput struct {
+	Company  string `json:"company"`
+	Location string `json:"location,omitempty"`
+}
+
+func (t *CompanyResearchTool) Execute(ctx context.Context, input json.RawMessage) (json.RawMessage, error) {
+	var researchInput CompanyResearchInput
+	if err := json.Unmarshal(input, &researchInput); err != nil {
+		return NewErrorResult(fmt.Sprintf("invalid input: %v", err))
+	}
+	if strings.TrimSpace(researchInput.Company) == "" {
+		return NewErrorResult("company is required")
+	}
+
+	company, err := t.Research(ctx, researchInput.Company, researchInput.Location)
+	if err != nil {
+		return NewErrorResult(fmt.Sprintf("company research failed: %v", err))
+	}
+
+	return NewSuccessResult(company)
+}
+
+// Research searches the web for a company and summarizes what it finds
+func (t *CompanyResearchTool) Research(ctx context.Context, company, location string) (*models.Company, error) {
+	query := fmt.Sprintf(`"%s" company profile employees reviews`, company)
+	if location != "" {
+		query += " " + location
+	}
+
+	items, err := t.searchTool.searchPage(ctx, query, "", 1, companyResearchResults)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no web results for %s", company)
+	}
+
+	results := make([]models.JobSearchResult, len(items))
+	for i, item := range items {
+		results[i] = models.JobSearchResult{
+			Title:   item.Title,
+			URL:     item.Link,
+			Snippet: item.Snippet,
+		}
+	}
+
+	return t.geminiClient.SummarizeCompany(ctx, company, results)
+}
@@ -9,15 +9,18 @@ import (
 	"github.com/myjobmatch/backend/models"
 )
 
-// ExtractJobTool extracts job posting information from HTML using Gemini
+// ExtractJobTool extracts job posting information from HTML using structured data,
+// site adapters for known job boards, or Gemini
 type ExtractJobTool struct {
 	geminiClient *gemini.Client
+	adapters     *AdapterRegistry
 }
 
 // NewExtractJobTool creates a new job extraction tool
 func NewExtractJobTool(geminiClient *gemini.Client) *ExtractJobTool {
 	return &ExtractJobTool{
 		geminiClient: geminiClient,
+		adapters:     NewDefaultAdapterRegistry(),
 	}
 }
 
@@ -25,10 +28,17 @@ func (t *ExtractJobTool) Name() string {
 	return "extract_job_from_html"
 }
 
+func (t *ExtractJobTool) Version() string {
+	return "2.0.0"
+}
+
 func (t *ExtractJobTool) Description() string {
-	return `Extract structured job posting information from HTML content using AI.
+	return `Extract structured job posting information from HTML content.
+Reads schema.org JobPosting JSON-LD when the page has it, then known job board layouts
+(LinkedIn, JobStreet, Glints, Kalibrr, Indeed), and uses AI otherwise.
+Listing pages (job board search results, careers pages) yield one posting per listed job, with its own URL when linked.
 Input should include HTML content and the source URL.
-Returns a structured JobPosting object with title, company, description, location, etc.`
+Returns the first JobPosting as job and every posting found as jobs, with title, company, description, location, etc.`
 }
 
 func (t *ExtractJobTool) InputSchema() map[string]interface{} {
@@ -60,20 +70,30 @@ func (t *ExtractJobTool) Execute(ctx context.Context, input json.RawMessage) (js
 		return NewErrorResult(fmt.Sprintf("invalid input: %v", err))
 	}
 
-	job, err := t.geminiClient.ExtractJobFromHTML(ctx, extractInput.HTML, extractInput.URL)
+	page := models.FetchPageResponse{
+		HTML:           extractInput.HTML,
+		URL:            extractInput.URL,
+		StructuredData: ExtractJSONLD(extractInput.HTML),
+	}
+	if job := t.ExtractStructured(page); job != nil {
+		return NewSuccessResult(models.ExtractJobResponse{Job: job, Jobs: []models.JobPosting{*job}})
+	}
+
+	jobs, err := t.geminiClient.ExtractJobsFromHTML(ctx, extractInput.HTML, extractInput.URL)
 	if err != nil {
-		return NewErrorResult(fmt.Sprintf("extraction failed: %v", err))
+		return NewGenerationErrorResult("extraction failed", err)
 	}
 
 	response := models.ExtractJobResponse{
-		Job: job,
+		Job:  &jobs[0],
+		Jobs: jobs,
 	}
 
 	return NewSuccessResult(response)
 }
 
-// ExtractFromHTML is a direct method to extract job from HTML
-func (t *ExtractJobTool) ExtractFromHTML(ctx context.Context, html, url string) (*models.JobPosting, error) {
+// ExtractFromHTML is a direct method to extract the postings on a page (several for listing pages)
+func (t *ExtractJobTool) ExtractFromHTML(ctx context.Context, html, url string) ([]models.JobPosting, error) {
 	inputJSON, err := json.Marshal(ExtractJobInput{HTML: html, URL: url})
 	if err != nil {
 		return nil, err
@@ -90,7 +110,7 @@ func (t *ExtractJobTool) ExtractFromHTML(ctx context.Context, html, url string)
 	}
 
 	if !result.Success {
-		return nil, fmt.Errorf(result.Error)
+		return nil, result.Err()
 	}
 
 	var response models.ExtractJobResponse
@@ -98,5 +118,14 @@ func (t *ExtractJobTool) ExtractFromHTML(ctx context.Context, html, url string)
 		return nil, err
 	}
 
-	return response.Job, nil
+	return response.Jobs, nil
+}
+
+// ExtractStructured reads a posting without Gemini: from JSON-LD when the page has it, otherwise
+// with the site adapter for the page's domain. It returns nil if neither applies.
+func (t *ExtractJobTool) ExtractStructured(page models.FetchPageResponse) *models.JobPosting {
+	if job := ParseJobPostingJSONLD(page.StructuredData, page.URL); job != nil {
+		return job
+	}
+	return t.adapters.Extract(page.HTML, page.URL)
 }
@@ -3,26 +3,56 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/myjobmatch/backend/config"
 	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
 )
 
+// maxPageBytes limits how much of a page is read
+const maxPageBytes = 5 * 1024 * 1024
+
 // FetchPageTool fetches HTML content from a URL
 type FetchPageTool struct {
-	client *http.Client
+	client   *http.Client
+	renderer *pageRenderer         // Headless rendering for JS-rendered boards (nil if not configured)
+	limiter  *hostLimiter          // Per-host request rate limit (nil if disabled)
+	breaker  *utils.CircuitBreaker // Stops fetches while outbound requests are failing (nil if disabled)
+
+	// Retries for rate-limited (429), server error (5xx) and timed-out fetches
+	maxRetries   int
+	retryBase    time.Duration
+	maxRetryWait time.Duration
 }
 
-// NewFetchPageTool creates a new page fetcher tool
+// NewFetchPageTool creates a new page fetcher tool. Pages are only fetched from public addresses: URLs
+// come from search results, MCP clients and users, and must not reach internal services.
 func NewFetchPageTool(cfg *config.Config) *FetchPageTool {
+	_, breaker := sharedBreakers(cfg)
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   utils.PublicDialControl,
+	}).DialContext
 	return &FetchPageTool{
+		renderer:     newPageRenderer(cfg),
+		limiter:      sharedHostLimiter(cfg),
+		breaker:      breaker,
+		maxRetries:   cfg.FetchMaxRetries,
+		retryBase:    time.Duration(cfg.FetchRetryBaseMs) * time.Millisecond,
+		maxRetryWait: time.Duration(cfg.FetchRetryMaxWaitSeconds) * time.Second,
 		client: &http.Client{
-			Timeout: time.Duration(cfg.HTTPTimeoutSeconds) * time.Second,
+			Timeout:   time.Duration(cfg.HTTPTimeoutSeconds) * time.Second,
+			Transport: transport,
 			CheckRedirect: func(req *http.Request, via []*http.Request) error {
 				if len(via) >= 5 {
 					return fmt.Errorf("too many redirects")
@@ -37,6 +67,10 @@ func (t *FetchPageTool) Name() string {
 	return "fetch_page_html"
 }
 
+func (t *FetchPageTool) Version() string {
+	return "1.0.0"
+}
+
 func (t *FetchPageTool) Description() string {
 	return `Fetch HTML content from a job posting URL.
 Input should be a URL string.
@@ -67,20 +101,143 @@ func (t *FetchPageTool) Execute(ctx context.Context, input json.RawMessage) (jso
 		return NewErrorResult(fmt.Sprintf("invalid input: %v", err))
 	}
 
-	html, err := t.fetchPage(ctx, fetchInput.URL)
+	html, structuredData, status, err := t.fetchPage(ctx, fetchInput.URL)
 	if err != nil {
-		return NewErrorResult(fmt.Sprintf("fetch failed: %v", err))
+		errMsg := fmt.Sprintf("fetch failed: %v", err)
+		return NewErrorResultWithData(errMsg, models.FetchPageResponse{
+			URL:        fetchInput.URL,
+			StatusCode: status,
+			Error:      errMsg,
+		})
 	}
 
 	response := models.FetchPageResponse{
-		HTML: html,
-		URL:  fetchInput.URL,
+		HTML:           html,
+		URL:            fetchInput.URL,
+		StructuredData: structuredData,
+		StatusCode:     status,
 	}
 
 	return NewSuccessResult(response)
 }
 
-func (t *FetchPageTool) fetchPage(ctx context.Context, pageURL string) (string, error) {
+// fetchPage returns the page's cleaned HTML, its JSON-LD blocks and the final HTTP status.
+// Pages on domains configured for rendering are loaded through the headless renderer first.
+func (t *FetchPageTool) fetchPage(ctx context.Context, pageURL string) (string, []string, int, error) {
+	var html string
+	status := 0
+	if t.renderer != nil && t.renderer.handles(pageURL) {
+		// The renderer loads the page from the same host, so it shares the host's rate limit
+		if err := t.limiter.Wait(ctx, pageURL); err != nil {
+			return "", nil, 0, err
+		}
+		rendered, err := t.renderer.render(ctx, pageURL)
+		if err != nil {
+			toolsLog.WarnContext(ctx, "Rendering failed, falling back to plain fetch", "tool", t.Name(), "url", pageURL, "error", err)
+		} else {
+			html, status = rendered, http.StatusOK
+		}
+	}
+
+	if html == "" {
+		var err error
+		html, status, err = t.fetchWithRetry(ctx, pageURL)
+		if err != nil {
+			return "", nil, status, err
+		}
+	}
+
+	// Structured data lives in script tags, so read it before cleaning
+	structuredData := ExtractJSONLD(html)
+
+	// Basic HTML cleaning - remove scripts and styles for smaller payload
+	html = t.cleanHTML(html)
+
+	return html, structuredData, status, nil
+}
+
+// statusError is a fetch failure caused by a non-200 response
+type statusError struct {
+	code       int
+	retryAfter time.Duration // From the Retry-After header, 0 if absent
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("page returned status %d", e.code)
+}
+
+// fetchWithRetry downloads the page, retrying rate-limited (429), server error (5xx) and timed-out
+// requests with exponential backoff. A Retry-After header sets the wait; if it asks for longer than
+// the maximum retry wait, the fetch gives up. It returns the final HTTP status (0 if none).
+func (t *FetchPageTool) fetchWithRetry(ctx context.Context, pageURL string) (string, int, error) {
+	for attempt := 0; ; attempt++ {
+		if err := t.breaker.Allow(); err != nil {
+			return "", 0, err
+		}
+		html, err := t.fetchRaw(ctx, pageURL)
+		t.breaker.Record(err)
+		if err == nil {
+			return html, http.StatusOK, nil
+		}
+
+		status := 0
+		var retryAfter time.Duration
+		var se *statusError
+		if errors.As(err, &se) {
+			status, retryAfter = se.code, se.retryAfter
+		}
+
+		if attempt >= t.maxRetries || !retryableFetchError(status, err) {
+			return "", status, err
+		}
+
+		wait := t.retryBase << attempt
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		if wait > t.maxRetryWait {
+			return "", status, err
+		}
+
+		toolsLog.WarnContext(ctx, "Fetch failed, retrying", "tool", t.Name(), "url", pageURL, "error", err,
+			"attempt", attempt+1, "max_retries", t.maxRetries, "wait_ms", wait.Milliseconds())
+		select {
+		case <-ctx.Done():
+			return "", status, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// retryableFetchError reports whether a failed fetch may succeed if retried
+func retryableFetchError(status int, err error) bool {
+	if status == http.StatusTooManyRequests || status >= 500 {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
+
+// fetchRaw downloads the page's HTML with a plain GET request
+func (t *FetchPageTool) fetchRaw(ctx context.Context, pageURL string) (string, error) {
+	if err := t.limiter.Wait(ctx, pageURL); err != nil {
+		return "", err
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
@@ -98,24 +255,18 @@ func (t *FetchPageTool) fetchPage(ctx context.Context, pageURL string) (string,
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("page returned status %d", resp.StatusCode)
+		return "", &statusError{code: resp.StatusCode, retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
 	}
 
 	// Read body with limit
-	maxBytes := int64(5 * 1024 * 1024) // 5MB limit
-	limitedReader := io.LimitReader(resp.Body, maxBytes)
+	limitedReader := io.LimitReader(resp.Body, maxPageBytes)
 
 	body, err := io.ReadAll(limitedReader)
 	if err != nil {
 		return "", fmt.Errorf("failed to read body: %w", err)
 	}
 
-	html := string(body)
-
-	// Basic HTML cleaning - remove scripts and styles for smaller payload
-	html = t.cleanHTML(html)
-
-	return html, nil
+	return string(body), nil
 }
 
 func (t *FetchPageTool) cleanHTML(html string) string {
@@ -174,7 +325,13 @@ func (t *FetchPageTool) FetchURL(ctx context.Context, url string) (*models.Fetch
 	}
 
 	if !result.Success {
-		return &models.FetchPageResponse{URL: url, Error: result.Error}, nil
+		response := models.FetchPageResponse{URL: url, Error: result.Error}
+		if len(result.Data) > 0 {
+			if err := json.Unmarshal(result.Data, &response); err != nil {
+				return nil, err
+			}
+		}
+		return &response, nil
 	}
 
 	var response models.FetchPageResponse
@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/myjobmatch/backend/config"
+)
+
+var (
+	sharedLimiterOnce sync.Once
+	sharedLimiter     *hostLimiter
+)
+
+// hostLimiter rate-limits requests to each host with its own token bucket.
+// It is safe for concurrent use by fetch goroutines.
+type hostLimiter struct {
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	rps      rate.Limit
+	burst    int
+}
+
+// sharedHostLimiter returns the per-host limiter shared by every FetchPageTool, so the agent's
+// and MCP fetches count against the same budget. It is nil when FETCH_HOST_RPS is 0.
+func sharedHostLimiter(cfg *config.Config) *hostLimiter {
+	sharedLimiterOnce.Do(func() {
+		if cfg.FetchHostRPS > 0 {
+			sharedLimiter = &hostLimiter{
+				limiters: make(map[string]*rate.Limiter),
+				rps:      rate.Limit(cfg.FetchHostRPS),
+				burst:    max(cfg.FetchHostBurst, 1),
+			}
+		}
+	})
+	return sharedLimiter
+}
+
+// Wait blocks until a request to the URL's host is allowed or ctx is done
+func (l *hostLimiter) Wait(ctx context.Context, pageURL string) error {
+	if l == nil {
+		return nil
+	}
+
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+
+	l.mu.Lock()
+	limiter, ok := l.limiters[host]
+	if !ok {
+		limiter = rate.NewLimiter(l.rps, l.burst)
+		l.limiters[host] = limiter
+	}
+	l.mu.Unlock()
+
+	return limiter.Wait(ctx)
+}
@@ -0,0 +1,293 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// jsonLDPattern matches <script type="application/ld+json"> blocks
+var jsonLDPattern = regexp.MustCompile(`(?is)<script[^>]*type\s*=\s*["']?application/ld\+json["']?[^>]*>(.*?)</script>`)
+
+const (
+	// maxStructuredDescription bounds the description read from JSON-LD (Gemini summarizes to 500 chars)
+	maxStructuredDescription = 1000
+	// maxStructuredRequirements bounds the requirements read from JSON-LD
+	maxStructuredRequirements = 500
+)
+
+// ExtractJSONLD returns the contents of the page's JSON-LD script blocks
+func ExtractJSONLD(page string) []string {
+	matches := jsonLDPattern.FindAllStringSubmatch(page, -1)
+	blocks := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if block := strings.TrimSpace(m[1]); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// ParseJobPostingJSONLD reads the first schema.org JobPosting from JSON-LD blocks.
+// It returns nil if no block describes a job posting with at least a title and company or description.
+func ParseJobPostingJSONLD(blocks []string, pageURL string) *models.JobPosting {
+	for _, block := range blocks {
+		var data interface{}
+		if err := json.Unmarshal([]byte(block), &data); err != nil {
+			continue
+		}
+		if node := findJobPostingNode(data); node != nil {
+			if job := jobFromJSONLD(node, pageURL); job != nil {
+				return job
+			}
+		}
+	}
+	return nil
+}
+
+// findJobPostingNode searches a JSON-LD value (object, array or @graph) for a JobPosting node
+func findJobPostingNode(data interface{}) map[string]interface{} {
+	switch v := data.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if node := findJobPostingNode(item); node != nil {
+				return node
+			}
+		}
+	case map[string]interface{}:
+		for _, t := range ldStrings(v["@type"]) {
+			if strings.EqualFold(t, "JobPosting") {
+				return v
+			}
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findJobPostingNode(graph)
+		}
+	}
+	return nil
+}
+
+// jobFromJSONLD maps a schema.org JobPosting node to a JobPosting
+func jobFromJSONLD(node map[string]interface{}, pageURL string) *models.JobPosting {
+	job := &models.JobPosting{
+		Title:        ldText(node["title"]),
+		Company:      ldName(node["hiringOrganization"]),
+		Description:  truncateText(ldText(node["description"]), maxStructuredDescription),
+		Location:     ldLocation(node["jobLocation"]),
+		URL:          pageURL,
+		Source:       "web",
+		SiteSetting:  models.SiteSettingUnknown,
+		DatePosted:   ldDate(ldString(node["datePosted"])),
+		ValidThrough: ldDate(ldString(node["validThrough"])),
+	}
+	if job.Title == "" || (job.Company == "" && job.Description == "") {
+		return nil
+	}
+
+	if types := ldStrings(node["employmentType"]); len(types) > 0 {
+		job.WorkType = models.NormalizeWorkType(types[0])
+	}
+	if strings.EqualFold(ldString(node["jobLocationType"]), "TELECOMMUTE") {
+		job.SiteSetting = models.SiteSettingWFH
+	}
+
+	requirements := make([]string, 0, 3)
+	for _, field := range []string{"qualifications", "experienceRequirements", "educationRequirements"} {
+		if text := ldText(node[field]); text != "" {
+			requirements = append(requirements, text)
+		}
+	}
+	job.Requirements = truncateText(strings.Join(requirements, " "), maxStructuredRequirements)
+	job.ExperienceLevel = ldExperienceLevel(node["experienceRequirements"])
+
+	for _, skill := range ldStrings(node["skills"]) {
+		for _, s := range strings.Split(ldText(skill), ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				job.Tags = append(job.Tags, s)
+			}
+		}
+	}
+	if benefits := ldText(node["jobBenefits"]); benefits != "" {
+		job.Benefits = models.FlexibleStringSlice{benefits}
+	}
+	if lang := ldString(node["inLanguage"]); len(lang) >= 2 {
+		job.Language = strings.ToLower(lang[:2])
+	}
+
+	applyLDSalary(job, node["baseSalary"])
+	return job
+}
+
+// applyLDSalary fills the salary fields from a MonetaryAmount
+func applyLDSalary(job *models.JobPosting, raw interface{}) {
+	salary, ok := raw.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	currency := strings.ToUpper(ldString(salary["currency"]))
+	var lo, hi float64
+	unit := ""
+	switch value := salary["value"].(type) {
+	case map[string]interface{}:
+		lo, _ = ldNumber(value["minValue"])
+		hi, _ = ldNumber(value["maxValue"])
+		if v, ok := ldNumber(value["value"]); ok && lo == 0 && hi == 0 {
+			lo, hi = v, v
+		}
+		unit = strings.ToLower(ldString(value["unitText"]))
+	default:
+		if v, ok := ldNumber(value); ok {
+			lo, hi = v, v
+		}
+	}
+	if hi == 0 {
+		hi = lo
+	}
+	if lo == 0 {
+		lo = hi
+	}
+	if lo == 0 {
+		return
+	}
+
+	job.SalaryMin = int(lo)
+	job.SalaryMax = int(hi)
+	job.SalaryCurrency = currency
+	job.SalaryPeriod = utils.NormalizeSalaryPeriod(unit)
+
+	text := fmt.Sprintf("%s %.0f", currency, lo)
+	if hi != lo {
+		text += fmt.Sprintf(" - %.0f", hi)
+	}
+	if unit != "" {
+		text += " per " + unit
+	}
+	job.Salary = strings.TrimSpace(text)
+}
+
+// ldExperienceLevel maps experienceRequirements.monthsOfExperience to an experience level
+func ldExperienceLevel(raw interface{}) string {
+	req, ok := raw.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	months, ok := ldNumber(req["monthsOfExperience"])
+	if !ok {
+		return ""
+	}
+	switch {
+	case months < 24:
+		return models.ExperienceLevelEntry
+	case months < 60:
+		return models.ExperienceLevelMid
+	case months < 120:
+		return models.ExperienceLevelSenior
+	default:
+		return models.ExperienceLevelLead
+	}
+}
+
+// ldLocation formats one or more Place nodes as "City, Region, Country"; multiple places are joined with " / "
+func ldLocation(raw interface{}) string {
+	places := make([]string, 0, 1)
+	for _, item := range ldItems(raw) {
+		place, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		address, ok := place["address"].(map[string]interface{})
+		if !ok {
+			if s := ldString(place["address"]); s != "" {
+				places = append(places, s)
+			}
+			continue
+		}
+		parts := make([]string, 0, 3)
+		for _, field := range []string{"addressLocality", "addressRegion", "addressCountry"} {
+			if part := ldName(address[field]); part != "" && !containsTerm(parts, part) {
+				parts = append(parts, part)
+			}
+		}
+		if len(parts) > 0 {
+			places = append(places, strings.Join(parts, ", "))
+		}
+	}
+	return strings.Join(places, " / ")
+}
+
+// ldDate keeps the date part of an ISO 8601 timestamp
+func ldDate(s string) string {
+	if len(s) >= 10 && s[4] == '-' && s[7] == '-' {
+		return s[:10]
+	}
+	return s
+}
+
+// ldText reads a string property as plain text; descriptions are often HTML, sometimes entity-escaped
+func ldText(raw interface{}) string {
+	return stripTags(html.UnescapeString(stripTags(ldString(raw))))
+}
+
+// ldName reads a value that is either a string or a node with a name
+func ldName(raw interface{}) string {
+	if node, ok := raw.(map[string]interface{}); ok {
+		return strings.TrimSpace(ldString(node["name"]))
+	}
+	return strings.TrimSpace(ldString(raw))
+}
+
+// ldItems wraps a single value in a slice so one-or-many properties can be ranged over
+func ldItems(raw interface{}) []interface{} {
+	switch v := raw.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return v
+	default:
+		return []interface{}{v}
+	}
+}
+
+// ldStrings reads a property that may be a string or a list of strings
+func ldStrings(raw interface{}) []string {
+	values := make([]string, 0, 1)
+	for _, item := range ldItems(raw) {
+		if s := ldString(item); s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// ldString reads a string property, using the first element of a list
+func ldString(raw interface{}) string {
+	switch v := raw.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case []interface{}:
+		if len(v) > 0 {
+			return ldString(v[0])
+		}
+	}
+	return ""
+}
+
+// ldNumber reads a number that may be encoded as a JSON number or string
+func ldNumber(raw interface{}) (float64, bool) {
+	switch v := raw.(type) {
+	case float64:
+		return v, true
+	case string:
+		var f float64
+		if _, err := fmt.Sscanf(strings.ReplaceAll(v, ",", ""), "%f", &f); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
@@ -25,6 +25,10 @@ func (t *ParseCVTool) Name() string {
 	return "parse_cv"
 }
 
+func (t *ParseCVTool) Version() string {
+	return "1.0.0"
+}
+
 func (t *ParseCVTool) Description() string {
 	return `Parse CV/resume text to extract structured user profile using AI.
 Input should be the CV text content.
@@ -57,7 +61,7 @@ func (t *ParseCVTool) Execute(ctx context.Context, input json.RawMessage) (json.
 
 	profile, err := t.geminiClient.ParseCV(ctx, parseInput.CVText)
 	if err != nil {
-		return NewErrorResult(fmt.Sprintf("CV parsing failed: %v", err))
+		return NewGenerationErrorResult("CV parsing failed", err)
 	}
 
 	response := models.CVParseResponse{
@@ -85,7 +89,7 @@ func (t *ParseCVTool) ParseCV(ctx context.Context, cvText string) (*models.UserP
 	}
 
 	if !result.Success {
-		return nil, fmt.Errorf(result.Error)
+		return nil, result.Err()
 	}
 
 	var response models.CVParseResponse
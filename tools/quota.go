@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/myjobmatch/backend/utils"
+)
+
+// ErrQuotaExceeded matches (with errors.Is) every error returned when a caller has used up its quota for a tool
+var ErrQuotaExceeded = errors.New("tool quota exceeded")
+
+// QuotaError reports which quota refused a tool call and when a retry can succeed
+type QuotaError struct {
+	Tool       string
+	Limit      string        // "calls per minute" or "calls per day"
+	RetryAfter time.Duration // Time until the quota allows another call
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%v for %s (%s, retry after %s)", ErrQuotaExceeded, e.Tool, e.Limit, e.RetryAfter.Round(time.Second))
+}
+
+// Is makes errors.Is(err, ErrQuotaExceeded) match any QuotaError
+func (e *QuotaError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// ToolQuota caps one caller's calls to a tool in each clock minute and UTC day (0 = no cap)
+type ToolQuota struct {
+	PerMinute int
+	PerDay    int
+}
+
+// quotaLimiter counts calls per caller and tool in fixed minute and day windows, like the Gemini
+// limiter does for the whole process. Counts are kept per instance. It is safe for concurrent use.
+type quotaLimiter struct {
+	mu      sync.Mutex
+	quotas  map[string]ToolQuota
+	day     time.Time               // UTC day the counts belong to; the counts are dropped when it changes
+	windows map[string]*quotaWindow // By caller and tool
+}
+
+type quotaWindow struct {
+	minuteStart time.Time
+	minuteCalls int
+	dayCalls    int
+}
+
+func newQuotaLimiter(quotas map[string]ToolQuota) *quotaLimiter {
+	return &quotaLimiter{
+		quotas:  quotas,
+		windows: make(map[string]*quotaWindow),
+	}
+}
+
+// acquire counts a call by caller to a tool, or returns a QuotaError if the caller's quota is used up.
+// Tools without a quota and calls without a known caller are not counted.
+func (l *quotaLimiter) acquire(tool, caller string, now time.Time) error {
+	quota, ok := l.quotas[tool]
+	if !ok || caller == "" {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if day := now.UTC().Truncate(24 * time.Hour); !day.Equal(l.day) {
+		l.day = day
+		l.windows = make(map[string]*quotaWindow)
+	}
+	key := caller + "/" + tool
+	window, ok := l.windows[key]
+	if !ok {
+		window = &quotaWindow{}
+		l.windows[key] = window
+	}
+	if minute := now.Truncate(time.Minute); !minute.Equal(window.minuteStart) {
+		window.minuteStart, window.minuteCalls = minute, 0
+	}
+
+	if quota.PerDay > 0 && window.dayCalls >= quota.PerDay {
+		return &QuotaError{Tool: tool, Limit: "calls per day", RetryAfter: l.day.Add(24 * time.Hour).Sub(now)}
+	}
+	if quota.PerMinute > 0 && window.minuteCalls >= quota.PerMinute {
+		return &QuotaError{Tool: tool, Limit: "calls per minute", RetryAfter: window.minuteStart.Add(time.Minute).Sub(now)}
+	}
+	window.minuteCalls++
+	window.dayCalls++
+	return nil
+}
+
+// quotaCaller identifies who a call counts against: the API token, the signed-in user or the client IP
+func quotaCaller(caller utils.Caller) string {
+	switch {
+	case caller.APITokenID != "":
+		return "token:" + caller.APITokenID
+	case caller.Email != "":
+		return "user:" + caller.Email
+	case caller.ClientIP != "":
+		return "ip:" + caller.ClientIP
+	}
+	return ""
+}
+
+// parseToolQuotas parses per-tool quotas given as name:perMinute/perDay entries (e.g. "search_jobs:3/30")
+func parseToolQuotas(entries []string) (map[string]ToolQuota, error) {
+	quotas := make(map[string]ToolQuota, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, ":")
+		perMinute, perDay, slash := strings.Cut(value, "/")
+		minuteCalls, minuteErr := strconv.Atoi(strings.TrimSpace(perMinute))
+		dayCalls, dayErr := strconv.Atoi(strings.TrimSpace(perDay))
+		if !ok || !slash || strings.TrimSpace(name) == "" || minuteErr != nil || dayErr != nil || minuteCalls < 0 || dayCalls < 0 {
+			return nil, fmt.Errorf("invalid tool quota %q, expected name:perMinute/perDay", entry)
+		}
+		quotas[strings.TrimSpace(name)] = ToolQuota{PerMinute: minuteCalls, PerDay: dayCalls}
+	}
+	return quotas, nil
+}
@@ -0,0 +1,391 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/models"
+)
+
+// Remote job board identifiers
+const (
+	RemoteBoardRemoteOK       = "remoteok"
+	RemoteBoardRemotive       = "remotive"
+	RemoteBoardWeWorkRemotely = "weworkremotely"
+)
+
+// remoteBoardResultLimit bounds the postings requested from boards that support a limit
+const remoteBoardResultLimit = 100
+
+// RemoteBoardsTool discovers remote job postings from the public RemoteOK,
+// Remotive and We Work Remotely feeds
+type RemoteBoardsTool struct {
+	boards []string
+	client *http.Client
+}
+
+// NewRemoteBoardsTool creates a new remote job board discovery tool
+func NewRemoteBoardsTool(cfg *config.Config) *RemoteBoardsTool {
+	return &RemoteBoardsTool{
+		boards: parseRemoteBoards(cfg.RemoteBoards),
+		client: &http.Client{
+			Timeout: time.Duration(cfg.HTTPTimeoutSeconds) * time.Second,
+		},
+	}
+}
+
+// parseRemoteBoards keeps the known board identifiers from a configured list
+func parseRemoteBoards(entries []string) []string {
+	boards := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		board := strings.ToLower(strings.TrimSpace(entry))
+		switch board {
+		case RemoteBoardRemoteOK, RemoteBoardRemotive, RemoteBoardWeWorkRemotely:
+			boards = append(boards, board)
+		default:
+			toolsLog.Warn("Ignoring unknown remote board", "tool", "search_remote_boards", "board", entry)
+		}
+	}
+	return boards
+}
+
+func (t *RemoteBoardsTool) Name() string {
+	return "search_remote_boards"
+}
+
+func (t *RemoteBoardsTool) Version() string {
+	return "1.0.0"
+}
+
+func (t *RemoteBoardsTool) Description() string {
+	return `Search remote-only job boards (RemoteOK, Remotive, We Work Remotely).
+Input should include keywords (roles, skills) that postings should match.
+Returns structured WFH job postings that can be scored directly without fetching or extraction.`
+}
+
+func (t *RemoteBoardsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"keywords": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Keywords (roles, skills) that postings should match",
+			},
+			"boards": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Optional boards to search (remoteok, remotive, weworkremotely); defaults to the configured boards",
+			},
+		},
+		"required": []string{"keywords"},
+	}
+}
+
+// RemoteSearchInput represents the input for the remote boards tool
+type RemoteSearchInput struct {
+	Keywords []string `json:"keywords"`
+	Boards   []string `json:"boards,omitempty"`
+}
+
+// RemoteSearchResponse represents the output of the remote boards tool
+type RemoteSearchResponse struct {
+	Jobs           []models.JobPosting `json:"jobs"`
+	BoardsSearched int                 `json:"boards_searched"`
+}
+
+func (t *RemoteBoardsTool) Execute(ctx context.Context, input json.RawMessage) (json.RawMessage, error) {
+	var searchInput RemoteSearchInput
+	if err := json.Unmarshal(input, &searchInput); err != nil {
+		return NewErrorResult(fmt.Sprintf("invalid input: %v", err))
+	}
+
+	boards := t.boards
+	if len(searchInput.Boards) > 0 {
+		boards = parseRemoteBoards(searchInput.Boards)
+	}
+
+	jobs := t.searchBoards(ctx, boards, searchInput.Keywords)
+
+	return NewSuccessResult(RemoteSearchResponse{
+		Jobs:           jobs,
+		BoardsSearched: len(boards),
+	})
+}
+
+// SearchWithProfile discovers remote postings relevant to a user profile and query
+func (t *RemoteBoardsTool) SearchWithProfile(ctx context.Context, profile *models.UserProfile, query string) ([]models.JobPosting, error) {
+	if len(t.boards) == 0 {
+		return nil, nil
+	}
+
+	searchInput := RemoteSearchInput{
+		Keywords: profileKeywords(profile, query),
+	}
+
+	inputJSON, err := json.Marshal(searchInput)
+	if err != nil {
+		return nil, err
+	}
+
+	resultJSON, err := t.Execute(ctx, inputJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ToolResult
+	if err := json.Unmarshal(resultJSON, &result); err != nil {
+		return nil, err
+	}
+
+	if !result.Success {
+		return nil, fmt.Errorf(result.Error)
+	}
+
+	var response RemoteSearchResponse
+	if err := json.Unmarshal(result.Data, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Jobs, nil
+}
+
+// searchBoards queries all boards concurrently and keeps postings matching the keywords
+func (t *RemoteBoardsTool) searchBoards(ctx context.Context, boards []string, keywords []string) []models.JobPosting {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make([]models.JobPosting, 0)
+
+	for _, board := range boards {
+		wg.Add(1)
+		go func(board string) {
+			defer wg.Done()
+
+			postings, err := t.fetchBoard(ctx, board, keywords)
+			if err != nil {
+				toolsLog.WarnContext(ctx, "Failed to fetch remote board", "tool", t.Name(), "board", board, "error", err)
+				return
+			}
+
+			matched := 0
+			mu.Lock()
+			for _, posting := range postings {
+				if matchesKeywords(posting, keywords) {
+					jobs = append(jobs, posting)
+					matched++
+				}
+			}
+			mu.Unlock()
+			toolsLog.InfoContext(ctx, "Fetched remote board", "tool", t.Name(), "board", board, "postings", len(postings), "matched", matched)
+		}(board)
+	}
+
+	wg.Wait()
+	return jobs
+}
+
+// fetchBoard fetches the current postings of a single remote board
+func (t *RemoteBoardsTool) fetchBoard(ctx context.Context, board string, keywords []string) ([]models.JobPosting, error) {
+	switch board {
+	case RemoteBoardRemoteOK:
+		return t.fetchRemoteOK(ctx)
+	case RemoteBoardRemotive:
+		return t.fetchRemotive(ctx, keywords)
+	case RemoteBoardWeWorkRemotely:
+		return t.fetchWeWorkRemotely(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported remote board: %s", board)
+	}
+}
+
+// remoteOKJob represents a posting from the RemoteOK API.
+// The first element of the response is a legal notice without a position and is skipped.
+type remoteOKJob struct {
+	Date        string   `json:"date"`
+	Company     string   `json:"company"`
+	Position    string   `json:"position"`
+	Tags        []string `json:"tags"`
+	Description string   `json:"description"`
+	Location    string   `json:"location"`
+	SalaryMin   int      `json:"salary_min"`
+	SalaryMax   int      `json:"salary_max"`
+	URL         string   `json:"url"`
+	ApplyURL    string   `json:"apply_url"`
+}
+
+func (t *RemoteBoardsTool) fetchRemoteOK(ctx context.Context) ([]models.JobPosting, error) {
+	body, err := getBoardFeed(ctx, t.client, "https://remoteok.com/api", "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	var postings []remoteOKJob
+	if err := json.Unmarshal(body, &postings); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	jobs := make([]models.JobPosting, 0, len(postings))
+	for _, p := range postings {
+		if p.Position == "" || p.URL == "" {
+			continue
+		}
+
+		job := models.JobPosting{
+			Title:          p.Position,
+			Company:        p.Company,
+			Description:    truncateText(stripTags(html.UnescapeString(p.Description)), 500),
+			Location:       remoteLocation(p.Location),
+			WorkType:       models.WorkTypeFullTime,
+			SiteSetting:    models.SiteSettingWFH,
+			URL:            p.URL,
+			ApplicationURL: p.ApplyURL,
+			Source:         RemoteBoardRemoteOK,
+			DatePosted:     ldDate(p.Date),
+			Tags:           p.Tags,
+		}
+		// RemoteOK salaries are yearly USD amounts
+		if p.SalaryMin > 0 || p.SalaryMax > 0 {
+			job.SalaryMin, job.SalaryMax = p.SalaryMin, max(p.SalaryMax, p.SalaryMin)
+			job.SalaryCurrency = "USD"
+			job.SalaryPeriod = models.SalaryPeriodYear
+			job.Salary = fmt.Sprintf("USD %d - %d per year", job.SalaryMin, job.SalaryMax)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// remotiveResponse represents the Remotive remote jobs API response
+type remotiveResponse struct {
+	Jobs []struct {
+		URL                       string   `json:"url"`
+		Title                     string   `json:"title"`
+		CompanyName               string   `json:"company_name"`
+		Category                  string   `json:"category"`
+		Tags                      []string `json:"tags"`
+		JobType                   string   `json:"job_type"`
+		PublicationDate           string   `json:"publication_date"`
+		CandidateRequiredLocation string   `json:"candidate_required_location"`
+		Salary                    string   `json:"salary"`
+		Description               string   `json:"description"`
+	} `json:"jobs"`
+}
+
+func (t *RemoteBoardsTool) fetchRemotive(ctx context.Context, keywords []string) ([]models.JobPosting, error) {
+	params := url.Values{}
+	params.Set("limit", fmt.Sprint(remoteBoardResultLimit))
+	// Remotive searches a single term, so use the most specific keyword (title or first role)
+	if len(keywords) > 0 {
+		params.Set("search", keywords[0])
+	}
+
+	body, err := getBoardFeed(ctx, t.client, "https://remotive.com/api/remote-jobs?"+params.Encode(), "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp remotiveResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	jobs := make([]models.JobPosting, 0, len(resp.Jobs))
+	for _, j := range resp.Jobs {
+		tags := j.Tags
+		if j.Category != "" {
+			tags = append([]string{j.Category}, tags...)
+		}
+
+		jobs = append(jobs, models.JobPosting{
+			Title:          j.Title,
+			Company:        j.CompanyName,
+			Description:    truncateText(stripTags(html.UnescapeString(j.Description)), 500),
+			Location:       remoteLocation(j.CandidateRequiredLocation),
+			WorkType:       models.NormalizeWorkType(j.JobType),
+			SiteSetting:    models.SiteSettingWFH,
+			URL:            j.URL,
+			ApplicationURL: j.URL,
+			Source:         RemoteBoardRemotive,
+			DatePosted:     ldDate(j.PublicationDate),
+			Salary:         j.Salary,
+			Tags:           tags,
+		})
+	}
+	return jobs, nil
+}
+
+// weWorkRemotelyFeed represents the We Work Remotely RSS feed
+type weWorkRemotelyFeed struct {
+	Items []struct {
+		Title       string `xml:"title"` // "Company: Job Title"
+		Region      string `xml:"region"`
+		Category    string `xml:"category"`
+		Type        string `xml:"type"`
+		Description string `xml:"description"`
+		PubDate     string `xml:"pubDate"`
+		Link        string `xml:"link"`
+	} `xml:"channel>item"`
+}
+
+func (t *RemoteBoardsTool) fetchWeWorkRemotely(ctx context.Context) ([]models.JobPosting, error) {
+	body, err := getBoardFeed(ctx, t.client, "https://weworkremotely.com/remote-jobs.rss", "application/rss+xml")
+	if err != nil {
+		return nil, err
+	}
+
+	var feed weWorkRemotelyFeed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return nil, fmt.Errorf("failed to parse feed: %w", err)
+	}
+
+	jobs := make([]models.JobPosting, 0, len(feed.Items))
+	for _, item := range feed.Items {
+		company, title, found := strings.Cut(item.Title, ":")
+		if !found {
+			company, title = "", item.Title
+		}
+
+		var datePosted string
+		if published, err := time.Parse(time.RFC1123Z, item.PubDate); err == nil {
+			datePosted = published.UTC().Format("2006-01-02")
+		}
+
+		var tags []string
+		if item.Category != "" {
+			tags = append(tags, item.Category)
+		}
+
+		jobs = append(jobs, models.JobPosting{
+			Title:          strings.TrimSpace(title),
+			Company:        strings.TrimSpace(company),
+			Description:    truncateText(stripTags(html.UnescapeString(item.Description)), 500),
+			Location:       remoteLocation(item.Region),
+			WorkType:       models.NormalizeWorkType(item.Type),
+			SiteSetting:    models.SiteSettingWFH,
+			URL:            item.Link,
+			ApplicationURL: item.Link,
+			Source:         RemoteBoardWeWorkRemotely,
+			DatePosted:     datePosted,
+			Tags:           tags,
+		})
+	}
+	return jobs, nil
+}
+
+// remoteLocation describes where a remote posting can be worked from, e.g. "Remote (Anywhere in the World)"
+func remoteLocation(region string) string {
+	region = strings.TrimSpace(region)
+	if region == "" {
+		return "Remote"
+	}
+	return fmt.Sprintf("Remote (%s)", region)
+}
@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/myjobmatch/backend/config"
+)
+
+// pageRenderer loads pages through a headless-browser rendering service, for job boards
+// that return skeleton HTML to plain GET requests. The service must accept
+// POST {"url": "..."} and respond with the rendered HTML (e.g. browserless /content).
+type pageRenderer struct {
+	client   *http.Client
+	endpoint string
+	token    string
+	domains  []string
+}
+
+// newPageRenderer creates a renderer from configuration, or returns nil if none is configured
+func newPageRenderer(cfg *config.Config) *pageRenderer {
+	if cfg.RenderServiceURL == "" || len(cfg.RenderDomains) == 0 {
+		return nil
+	}
+
+	domains := make([]string, 0, len(cfg.RenderDomains))
+	for _, d := range cfg.RenderDomains {
+		domains = append(domains, strings.TrimPrefix(strings.ToLower(d), "www."))
+	}
+
+	return &pageRenderer{
+		client:   &http.Client{Timeout: time.Duration(cfg.RenderTimeoutSeconds) * time.Second},
+		endpoint: cfg.RenderServiceURL,
+		token:    cfg.RenderServiceToken,
+		domains:  domains,
+	}
+}
+
+// handles reports whether the URL's host is, or is a subdomain of, a domain configured for rendering
+func (r *pageRenderer) handles(pageURL string) bool {
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	for _, d := range r.domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// render returns the page's HTML after its scripts have run
+func (r *pageRenderer) render(ctx context.Context, pageURL string) (string, error) {
+	payload, err := json.Marshal(map[string]string{"url": pageURL})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create render request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to render page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("render service returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read rendered page: %w", err)
+	}
+	return string(body), nil
+}
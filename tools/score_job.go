@@ -25,10 +25,14 @@ func (t *ScoreJobTool) Name() string {
 	return "score_job_match"
 }
 
+func (t *ScoreJobTool) Version() string {
+	return "1.1.0"
+}
+
 func (t *ScoreJobTool) Description() string {
 	return `Score how well a job posting matches a user's profile using AI.
 Input should include the user profile and job posting.
-Returns a match score (0-100) and a reason explaining the match.`
+Returns a match score (0-100), a reason explaining the match and a per-criterion breakdown.`
 }
 
 func (t *ScoreJobTool) InputSchema() map[string]interface{} {
@@ -43,6 +47,11 @@ func (t *ScoreJobTool) InputSchema() map[string]interface{} {
 				"type":        "object",
 				"description": "Job posting to score against the profile",
 			},
+			"feedback": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "object"},
+				"description": "Optional ratings of earlier matches ({title, company, tags, rating: relevant|not_relevant}) used as examples of the user's preferences",
+			},
 		},
 		"required": []string{"profile", "job"},
 	}
@@ -50,8 +59,9 @@ func (t *ScoreJobTool) InputSchema() map[string]interface{} {
 
 // ScoreJobInput represents the input for job scoring
 type ScoreJobInput struct {
-	Profile models.UserProfile `json:"profile"`
-	Job     models.JobPosting  `json:"job"`
+	Profile  models.UserProfile   `json:"profile"`
+	Job      models.JobPosting    `json:"job"`
+	Feedback []models.JobFeedback `json:"feedback,omitempty"`
 }
 
 func (t *ScoreJobTool) Execute(ctx context.Context, input json.RawMessage) (json.RawMessage, error) {
@@ -60,44 +70,45 @@ func (t *ScoreJobTool) Execute(ctx context.Context, input json.RawMessage) (json
 		return NewErrorResult(fmt.Sprintf("invalid input: %v", err))
 	}
 
-	score, reason, err := t.geminiClient.ScoreJobMatch(ctx, &scoreInput.Profile, &scoreInput.Job)
+	response, err := t.geminiClient.ScoreJobMatch(ctx, &scoreInput.Profile, &scoreInput.Job, scoreInput.Feedback)
 	if err != nil {
-		return NewErrorResult(fmt.Sprintf("scoring failed: %v", err))
-	}
-
-	response := models.ScoreJobResponse{
-		MatchScore:  score,
-		MatchReason: reason,
+		return NewGenerationErrorResult("scoring failed", err)
 	}
 
 	return NewSuccessResult(response)
 }
 
-// ScoreJob is a direct method to score a job
-func (t *ScoreJobTool) ScoreJob(ctx context.Context, profile *models.UserProfile, job *models.JobPosting) (int, string, error) {
-	inputJSON, err := json.Marshal(ScoreJobInput{Profile: *profile, Job: *job})
+// NewSession starts a session for scoring several postings against the same profile,
+// which sends the profile to Gemini once instead of with every posting; close it when done
+func (t *ScoreJobTool) NewSession(ctx context.Context, profile *models.UserProfile, feedback []models.JobFeedback, expectedCalls int) *gemini.ScoringSession {
+	return t.geminiClient.NewScoringSession(ctx, profile, feedback, expectedCalls)
+}
+
+// ScoreJob is a direct method to score a job; feedback may be nil
+func (t *ScoreJobTool) ScoreJob(ctx context.Context, profile *models.UserProfile, job *models.JobPosting, feedback []models.JobFeedback) (*models.ScoreJobResponse, error) {
+	inputJSON, err := json.Marshal(ScoreJobInput{Profile: *profile, Job: *job, Feedback: feedback})
 	if err != nil {
-		return 0, "", err
+		return nil, err
 	}
 
 	resultJSON, err := t.Execute(ctx, inputJSON)
 	if err != nil {
-		return 0, "", err
+		return nil, err
 	}
 
 	var result ToolResult
 	if err := json.Unmarshal(resultJSON, &result); err != nil {
-		return 0, "", err
+		return nil, err
 	}
 
 	if !result.Success {
-		return 0, "", fmt.Errorf(result.Error)
+		return nil, result.Err()
 	}
 
 	var response models.ScoreJobResponse
 	if err := json.Unmarshal(result.Data, &response); err != nil {
-		return 0, "", err
+		return nil, err
 	}
 
-	return response.MatchScore, response.MatchReason, nil
+	return &response, nil
 }
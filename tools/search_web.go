@@ -3,16 +3,18 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/myjobmatch/backend/config"
 	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
 )
 
 // SearchWebTool searches for job postings using Google Programmable Search Engine
@@ -20,11 +22,14 @@ type SearchWebTool struct {
 	apiKey   string
 	engineID string
 	client   *http.Client
+	breaker  *utils.CircuitBreaker // Stops PSE calls during an outage (nil if disabled)
 }
 
 // NewSearchWebTool creates a new web search tool
 func NewSearchWebTool(cfg *config.Config) *SearchWebTool {
+	breaker, _ := sharedBreakers(cfg)
 	return &SearchWebTool{
+		breaker:  breaker,
 		apiKey:   cfg.PSEAPIKey,
 		engineID: cfg.PSEEngineID,
 		client: &http.Client{
@@ -37,6 +42,10 @@ func (t *SearchWebTool) Name() string {
 	return "search_web_for_jobs"
 }
 
+func (t *SearchWebTool) Version() string {
+	return "1.0.0"
+}
+
 func (t *SearchWebTool) Description() string {
 	return `Search the web for job postings using Google Programmable Search Engine.
 Input should include a query string and optional filters.
@@ -61,6 +70,21 @@ func (t *SearchWebTool) InputSchema() map[string]interface{} {
 				"items":       map[string]interface{}{"type": "string"},
 				"description": "Remote modes: WFH, WFO, Hybrid",
 			},
+			"exclude_keywords": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string"},
+				"description": "Words that must not appear in results (e.g. 'gambling', 'crypto')",
+			},
+			"language": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"en", "id"},
+				"description": "Query language; detected from the query when omitted (e.g. 'lowongan backend Jakarta' is Bahasa Indonesia)",
+			},
+			"date_posted": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"last_24h", "last_week", "last_month"},
+				"description": "Only return pages indexed within this period",
+			},
 		},
 		"required": []string{"query"},
 	}
@@ -68,9 +92,12 @@ func (t *SearchWebTool) InputSchema() map[string]interface{} {
 
 // SearchInput represents the input for the search tool
 type SearchInput struct {
-	Query       string   `json:"query"`
-	Locations   []string `json:"locations,omitempty"`
-	RemoteModes []string `json:"remote_modes,omitempty"`
+	Query           string   `json:"query"`
+	Locations       []string `json:"locations,omitempty"`
+	RemoteModes     []string `json:"remote_modes,omitempty"`
+	DatePosted      string   `json:"date_posted,omitempty"` // last_24h, last_week, last_month
+	ExcludeKeywords []string `json:"exclude_keywords,omitempty"`
+	Language        string   `json:"language,omitempty"` // en, id; detected from the query when empty
 }
 
 // PSEResponse represents the Google PSE API response
@@ -95,7 +122,7 @@ func (t *SearchWebTool) Execute(ctx context.Context, input json.RawMessage) (jso
 	query := t.buildQuery(searchInput)
 
 	// Call PSE API
-	results, err := t.search(ctx, query)
+	results, err := t.search(ctx, query, dateRestrict(searchInput.DatePosted))
 	if err != nil {
 		return NewErrorResult(fmt.Sprintf("search failed: %v", err))
 	}
@@ -138,8 +165,15 @@ func (t *SearchWebTool) buildQuery(input SearchInput) string {
 	// Add main query
 	parts = append(parts, input.Query)
 
-	// Add "job" keyword if not present
-	if !strings.Contains(strings.ToLower(input.Query), "job") {
+	// Add the job keyword in the query's language if not present
+	indonesian := input.Language == LanguageIndonesian ||
+		(input.Language == "" && IsIndonesianQuery(input.Query))
+	lower := strings.ToLower(input.Query)
+	if indonesian {
+		if !strings.Contains(lower, "lowongan") && !strings.Contains(lower, "loker") {
+			parts = append(parts, "lowongan kerja")
+		}
+	} else if !strings.Contains(lower, "job") {
 		parts = append(parts, "job")
 	}
 
@@ -148,40 +182,100 @@ func (t *SearchWebTool) buildQuery(input SearchInput) string {
 		parts = append(parts, input.Locations[0])
 	}
 
-	// Add remote mode hints
+	// Add remote mode hints; Indonesian postings commonly say "WFH" or "remote"
 	if len(input.RemoteModes) > 0 {
 		mode := input.RemoteModes[0]
-		switch mode {
-		case "WFH":
+		switch {
+		case mode == "WFH" && indonesian:
+			parts = append(parts, "(remote OR WFH)")
+		case mode == "WFH":
 			parts = append(parts, "remote")
-		case "Hybrid":
+		case mode == "Hybrid":
 			parts = append(parts, "hybrid")
 		}
 	}
 
+	// Add negative terms; multi-word terms are quoted as phrases
+	for _, keyword := range input.ExcludeKeywords {
+		keyword = strings.TrimSpace(keyword)
+		switch {
+		case keyword == "":
+		case strings.Contains(keyword, " "):
+			parts = append(parts, `-"`+keyword+`"`)
+		default:
+			parts = append(parts, "-"+keyword)
+		}
+	}
+
 	return strings.Join(parts, " ")
 }
 
-func (t *SearchWebTool) search(ctx context.Context, query string) ([]PSEItem, error) {
+// Supported query languages
+const (
+	LanguageEnglish    = "en"
+	LanguageIndonesian = "id"
+)
+
+// indonesianQueryWords are common Bahasa Indonesia words in job searches
+var indonesianQueryWords = map[string]bool{
+	"lowongan": true, "loker": true, "kerja": true, "pekerjaan": true, "karyawan": true,
+	"magang": true, "gaji": true, "paruh": true, "penuh": true, "waktu": true,
+	"kantor": true, "rumah": true, "dicari": true, "staf": true, "tenaga": true,
+	"lulusan": true, "pengembang": true, "di": true, "dan": true,
+	"untuk": true, "terbaru": true, "pengalaman": true,
+}
+
+// IsIndonesianQuery reports whether a search query appears to be written in Bahasa Indonesia
+func IsIndonesianQuery(query string) bool {
+	for _, word := range strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		if indonesianQueryWords[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// dateRestrict maps a date_posted filter to the PSE dateRestrict parameter ("" for no restriction)
+func dateRestrict(datePosted string) string {
+	switch datePosted {
+	case "last_24h":
+		return "d1"
+	case "last_week":
+		return "w1"
+	case "last_month":
+		return "m1"
+	default:
+		return ""
+	}
+}
+
+func (t *SearchWebTool) search(ctx context.Context, query, restrict string) ([]PSEItem, error) {
 	var allItems []PSEItem
 	seen := make(map[string]bool) // Deduplicate URLs
 
-	log.Printf("[Search] Starting search with base query: %s", query)
+	toolsLog.InfoContext(ctx, "Starting web search", "tool", t.Name(), "query", query)
 
 	// Search each job portal separately for better results
 	for _, siteFilter := range jobPortalSites {
 		siteQuery := query + " " + siteFilter
-		log.Printf("[Search] Searching: %s", siteQuery)
+		toolsLog.DebugContext(ctx, "Searching site", "tool", t.Name(), "query", siteQuery)
 
 		// Get up to 50 results per site (multiple pages)
 		for start := 1; start <= 50; start += 10 {
-			items, err := t.searchPage(ctx, siteQuery, start, 10)
+			items, err := t.searchPage(ctx, siteQuery, restrict, start, 10)
+			if errors.Is(err, utils.ErrCircuitOpen) {
+				// PSE is down; return what was found rather than waiting on every remaining site
+				toolsLog.WarnContext(ctx, "Skipping remaining sites", "tool", t.Name(), "error", err, "urls", len(allItems))
+				return allItems, nil
+			}
 			if err != nil {
-				log.Printf("[Search] Error for %s: %v", siteFilter, err)
+				toolsLog.WarnContext(ctx, "Site search failed", "tool", t.Name(), "site", siteFilter, "error", err)
 				break
 			}
 
-			log.Printf("[Search] Got %d results from %s (page %d)", len(items), siteFilter, (start/10)+1)
+			toolsLog.DebugContext(ctx, "Got site search results", "tool", t.Name(), "site", siteFilter, "page", (start/10)+1, "results", len(items))
 
 			for _, item := range items {
 				if !seen[item.Link] && isPreferredDetailURL(item.Link) {
@@ -196,7 +290,7 @@ func (t *SearchWebTool) search(ctx context.Context, query string) ([]PSEItem, er
 		}
 	}
 
-	log.Printf("[Search] Total unique URLs found: %d", len(allItems))
+	toolsLog.InfoContext(ctx, "Web search finished", "tool", t.Name(), "urls", len(allItems))
 	return allItems, nil
 }
 
@@ -228,8 +322,16 @@ func isPreferredDetailURL(link string) bool {
 	return true
 }
 
+// Ping runs a one-result search, checking the API key and engine ID. It uses a query of the daily quota.
+func (t *SearchWebTool) Ping(ctx context.Context) error {
+	if _, err := t.searchPage(ctx, "jobs", "", 1, 1); err != nil {
+		return fmt.Errorf("failed to reach Programmable Search Engine: %w", err)
+	}
+	return nil
+}
+
 // searchPage fetches a single page of results
-func (t *SearchWebTool) searchPage(ctx context.Context, query string, start, num int) ([]PSEItem, error) {
+func (t *SearchWebTool) searchPage(ctx context.Context, query, restrict string, start, num int) ([]PSEItem, error) {
 	baseURL := "https://www.googleapis.com/customsearch/v1"
 	params := url.Values{}
 	params.Set("key", t.apiKey)
@@ -237,6 +339,9 @@ func (t *SearchWebTool) searchPage(ctx context.Context, query string, start, num
 	params.Set("q", query)
 	params.Set("num", fmt.Sprintf("%d", num))
 	params.Set("start", fmt.Sprintf("%d", start))
+	if restrict != "" {
+		params.Set("dateRestrict", restrict)
+	}
 
 	reqURL := baseURL + "?" + params.Encode()
 
@@ -245,6 +350,25 @@ func (t *SearchWebTool) searchPage(ctx context.Context, query string, start, num
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	if err := t.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	body, err := t.do(req)
+	t.breaker.Record(err)
+	if err != nil {
+		return nil, err
+	}
+
+	var pseResp PSEResponse
+	if err := json.Unmarshal(body, &pseResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return pseResp.Items, nil
+}
+
+// do executes a PSE request and returns the response body
+func (t *SearchWebTool) do(req *http.Request) ([]byte, error) {
 	resp, err := t.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
@@ -253,29 +377,40 @@ func (t *SearchWebTool) searchPage(ctx context.Context, query string, start, num
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("PSE API error (status %d): %s", resp.StatusCode, string(body))
+		return nil, &pseStatusError{code: resp.StatusCode, body: string(body)}
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
+	return body, nil
+}
 
-	var pseResp PSEResponse
-	if err := json.Unmarshal(body, &pseResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
+// pseStatusError is a PSE failure caused by a non-200 response
+type pseStatusError struct {
+	code int
+	body string
+}
 
-	return pseResp.Items, nil
+func (e *pseStatusError) Error() string {
+	return fmt.Sprintf("PSE API error (status %d): %s", e.code, e.body)
 }
 
 // SearchWithProfile performs a search using a user profile
 func (t *SearchWebTool) SearchWithProfile(ctx context.Context, profile *models.UserProfile, query string, filters models.JobSearchFilter) (*models.WebSearchResponse, error) {
 	// Build search input from profile and filters
 	searchInput := SearchInput{
-		Query:       query,
-		Locations:   filters.Locations,
-		RemoteModes: filters.RemoteModes,
+		Query:           query,
+		Locations:       filters.Locations,
+		RemoteModes:     filters.RemoteModes,
+		DatePosted:      filters.DatePosted,
+		ExcludeKeywords: filters.ExcludeKeywords,
+	}
+
+	// Profile-generated queries follow the CV language unless the CV was translated to English
+	if query == "" && profile != nil && profile.Language == LanguageIndonesian && !profile.Translated {
+		searchInput.Language = LanguageIndonesian
 	}
 
 	// If query is empty, generate from profile
@@ -0,0 +1,357 @@
+package tools
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// maxAdapterDescription bounds the description read by site adapters
+const maxAdapterDescription = 1000
+
+// SiteAdapter extracts a posting from the page structure of a known job site
+type SiteAdapter interface {
+	// Name identifies the adapter in logs
+	Name() string
+	// Domains lists the hosts the adapter handles; subdomains match too
+	Domains() []string
+	// Extract reads the posting from the parsed page, returning nil if the page doesn't have the expected structure
+	Extract(doc *html.Node, pageURL string) *models.JobPosting
+}
+
+// AdapterRegistry selects a site adapter by the page's domain
+type AdapterRegistry struct {
+	byDomain map[string]SiteAdapter
+}
+
+// NewAdapterRegistry creates a registry with the given adapters
+func NewAdapterRegistry(adapters ...SiteAdapter) *AdapterRegistry {
+	r := &AdapterRegistry{byDomain: make(map[string]SiteAdapter)}
+	for _, a := range adapters {
+		r.Register(a)
+	}
+	return r
+}
+
+// NewDefaultAdapterRegistry creates a registry with adapters for the job boards we search most
+func NewDefaultAdapterRegistry() *AdapterRegistry {
+	return NewAdapterRegistry(
+		&linkedInAdapter{},
+		&jobStreetAdapter{},
+		&glintsAdapter{},
+		&kalibrrAdapter{},
+		&indeedAdapter{},
+	)
+}
+
+// Register adds an adapter for each of its domains, replacing any existing one
+func (r *AdapterRegistry) Register(a SiteAdapter) {
+	for _, domain := range a.Domains() {
+		r.byDomain[strings.ToLower(domain)] = a
+	}
+}
+
+// ForURL returns the adapter registered for the URL's host or a parent domain, or nil
+func (r *AdapterRegistry) ForURL(pageURL string) SiteAdapter {
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	for host != "" {
+		if a, ok := r.byDomain[host]; ok {
+			return a
+		}
+		dot := strings.Index(host, ".")
+		if dot == -1 {
+			break
+		}
+		host = host[dot+1:]
+	}
+	return nil
+}
+
+// Extract reads a posting with the adapter for the page's domain.
+// It returns nil if no adapter handles the domain or the page didn't match the adapter's structure.
+func (r *AdapterRegistry) Extract(page, pageURL string) *models.JobPosting {
+	adapter := r.ForURL(pageURL)
+	if adapter == nil {
+		return nil
+	}
+
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		return nil
+	}
+
+	job := adapter.Extract(doc, pageURL)
+	if job == nil || job.Title == "" || (job.Company == "" && job.Description == "") {
+		toolsLog.Debug("Site adapter found no posting", "tool", "extract_job_from_html", "adapter", adapter.Name(), "url", pageURL)
+		return nil
+	}
+
+	job.URL = pageURL
+	job.Source = "web"
+	job.Description = truncateText(job.Description, maxAdapterDescription)
+	job.WorkType = models.NormalizeWorkType(job.WorkType)
+	if job.SiteSetting == "" || job.SiteSetting == models.SiteSettingUnknown {
+		job.SiteSetting = siteSettingFromText(job.Location + " " + job.WorkType)
+	}
+	return job
+}
+
+// linkedInAdapter reads LinkedIn's public (logged-out) job view
+type linkedInAdapter struct{}
+
+func (a *linkedInAdapter) Name() string      { return "linkedin" }
+func (a *linkedInAdapter) Domains() []string { return []string{"linkedin.com"} }
+
+func (a *linkedInAdapter) Extract(doc *html.Node, pageURL string) *models.JobPosting {
+	job := &models.JobPosting{
+		Title:       nodeText(findNode(doc, withClass("top-card-layout__title"))),
+		Company:     nodeText(findNode(doc, withClass("topcard__org-name-link"))),
+		Location:    nodeText(findNode(doc, withClass("topcard__flavor--bullet"))),
+		Description: nodeText(findNode(doc, withClass("show-more-less-html__markup"))),
+	}
+
+	// Criteria are listed as "Seniority level" / "Employment type" pairs
+	for _, item := range findAll(doc, withClass("description__job-criteria-item")) {
+		label := strings.ToLower(nodeText(findNode(item, withClass("description__job-criteria-subheader"))))
+		value := nodeText(findNode(item, withClass("description__job-criteria-text")))
+		switch label {
+		case "employment type":
+			job.WorkType = value
+		case "seniority level":
+			job.ExperienceLevel = seniorityLevel(value)
+		}
+	}
+	return job
+}
+
+// jobStreetAdapter reads JobStreet (SEEK platform) job pages, which label fields with data-automation attributes
+type jobStreetAdapter struct{}
+
+func (a *jobStreetAdapter) Name() string { return "jobstreet" }
+func (a *jobStreetAdapter) Domains() []string {
+	return []string{"jobstreet.co.id", "jobstreet.com", "jobstreet.com.my", "jobstreet.com.sg", "jobstreet.com.ph"}
+}
+
+func (a *jobStreetAdapter) Extract(doc *html.Node, pageURL string) *models.JobPosting {
+	field := func(name string) string {
+		return nodeText(findNode(doc, withAttr("data-automation", name)))
+	}
+	return &models.JobPosting{
+		Title:       field("job-detail-title"),
+		Company:     field("advertiser-name"),
+		Location:    field("job-detail-location"),
+		WorkType:    field("job-detail-work-type"),
+		Salary:      field("job-detail-salary"),
+		Description: field("jobAdDetails"),
+	}
+}
+
+// glintsAdapter reads Glints job pages; class names carry build hashes, so they are matched by prefix
+type glintsAdapter struct{}
+
+func (a *glintsAdapter) Name() string      { return "glints" }
+func (a *glintsAdapter) Domains() []string { return []string{"glints.com", "glints.id"} }
+
+func (a *glintsAdapter) Extract(doc *html.Node, pageURL string) *models.JobPosting {
+	job := &models.JobPosting{
+		Title:       nodeText(findNode(doc, withClassPrefix("TopFoldsc__JobOverViewTitle"))),
+		Company:     nodeText(findNode(doc, withClassPrefix("TopFoldsc__JobOverViewCompanyName"))),
+		Description: nodeText(findNode(doc, withClassPrefix("JobDescriptionsc__DescriptionContainer"))),
+	}
+
+	// The overview info rows hold location, salary and work type/setting in no fixed order
+	for _, info := range findAll(doc, withClassPrefix("TopFoldsc__JobOverViewInfo")) {
+		text := nodeText(info)
+		lower := strings.ToLower(text)
+		switch {
+		case strings.HasPrefix(lower, "idr") || strings.HasPrefix(lower, "rp"):
+			job.Salary = text
+		case models.NormalizeWorkType(text) != text:
+			job.WorkType = text
+		case models.NormalizeSiteSetting(text) != models.SiteSettingUnknown:
+			job.SiteSetting = models.NormalizeSiteSetting(text)
+		case job.Location == "":
+			job.Location = text
+		}
+	}
+	return job
+}
+
+// kalibrrAdapter reads Kalibrr job pages via their schema.org microdata (itemprop attributes)
+type kalibrrAdapter struct{}
+
+func (a *kalibrrAdapter) Name() string      { return "kalibrr" }
+func (a *kalibrrAdapter) Domains() []string { return []string{"kalibrr.com", "kalibrr.id"} }
+
+func (a *kalibrrAdapter) Extract(doc *html.Node, pageURL string) *models.JobPosting {
+	field := func(name string) string {
+		return nodeText(findNode(doc, withAttr("itemprop", name)))
+	}
+	return &models.JobPosting{
+		Title:       field("title"),
+		Company:     field("hiringOrganization"),
+		Location:    field("jobLocation"),
+		WorkType:    field("employmentType"),
+		Salary:      field("baseSalary"),
+		DatePosted:  ldDate(nodeAttr(findNode(doc, withAttr("itemprop", "datePosted")), "content")),
+		Description: field("description"),
+	}
+}
+
+// indeedAdapter reads Indeed job view pages
+type indeedAdapter struct{}
+
+func (a *indeedAdapter) Name() string      { return "indeed" }
+func (a *indeedAdapter) Domains() []string { return []string{"indeed.com"} }
+
+func (a *indeedAdapter) Extract(doc *html.Node, pageURL string) *models.JobPosting {
+	field := func(testID string) string {
+		return nodeText(findNode(doc, withAttr("data-testid", testID)))
+	}
+	job := &models.JobPosting{
+		Title:       field("jobsearch-JobInfoHeader-title"),
+		Company:     field("inlineHeader-companyName"),
+		Location:    field("inlineHeader-companyLocation"),
+		Description: nodeText(findNode(doc, withAttr("id", "jobDescriptionText"))),
+	}
+	if job.Title == "" {
+		job.Title = nodeText(findNode(doc, withClass("jobsearch-JobInfoHeader-title")))
+	}
+
+	// Salary and job type share one block, e.g. "Rp 8.000.000 - Rp 10.000.000 a month - Full-time"
+	if info := nodeText(findNode(doc, withAttr("id", "salaryInfoAndJobType"))); info != "" {
+		parts := strings.Split(info, " - ")
+		last := strings.TrimSpace(parts[len(parts)-1])
+		if models.NormalizeWorkType(last) != last {
+			job.WorkType = last
+			info = strings.TrimSpace(strings.Join(parts[:len(parts)-1], " - "))
+		}
+		job.Salary = info
+	}
+	return job
+}
+
+// seniorityLevel maps LinkedIn-style seniority labels to experience levels
+func seniorityLevel(label string) string {
+	lower := strings.ToLower(label)
+	switch {
+	case strings.Contains(lower, "intern"), strings.Contains(lower, "entry"):
+		return models.ExperienceLevelEntry
+	case strings.Contains(lower, "associate"), strings.Contains(lower, "mid"):
+		return models.ExperienceLevelMid
+	case strings.Contains(lower, "senior"):
+		return models.ExperienceLevelSenior
+	case strings.Contains(lower, "director"), strings.Contains(lower, "executive"), strings.Contains(lower, "lead"):
+		return models.ExperienceLevelLead
+	default:
+		return ""
+	}
+}
+
+// nodeMatcher selects DOM elements
+type nodeMatcher func(n *html.Node) bool
+
+// withClass matches elements having the class
+func withClass(class string) nodeMatcher {
+	return func(n *html.Node) bool {
+		for _, c := range strings.Fields(nodeAttr(n, "class")) {
+			if c == class {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// withClassPrefix matches elements having a class that starts with prefix (for generated class names)
+func withClassPrefix(prefix string) nodeMatcher {
+	return func(n *html.Node) bool {
+		for _, c := range strings.Fields(nodeAttr(n, "class")) {
+			if strings.HasPrefix(c, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// withAttr matches elements whose attribute equals value
+func withAttr(key, value string) nodeMatcher {
+	return func(n *html.Node) bool {
+		return nodeAttr(n, key) == value
+	}
+}
+
+// findNode returns the first element under root matching m, or nil
+func findNode(root *html.Node, m nodeMatcher) *html.Node {
+	if root == nil {
+		return nil
+	}
+	if root.Type == html.ElementNode && m(root) {
+		return root
+	}
+	for c := root.FirstChild; c != nil; c = c.NextSibling {
+		if n := findNode(c, m); n != nil {
+			return n
+		}
+	}
+	return nil
+}
+
+// findAll returns every element under root matching m, outermost first; matches inside a match are skipped
+func findAll(root *html.Node, m nodeMatcher) []*html.Node {
+	var nodes []*html.Node
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && m(n) {
+			nodes = append(nodes, n)
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(root)
+	return nodes
+}
+
+// nodeAttr returns an element's attribute value, or "" if absent
+func nodeAttr(n *html.Node, key string) string {
+	if n == nil {
+		return ""
+	}
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// nodeText returns an element's text content with whitespace collapsed
+func nodeText(n *html.Node) string {
+	if n == nil {
+		return ""
+	}
+	var sb strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+			sb.WriteString(" ")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
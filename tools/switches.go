@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// ErrToolDisabled is the cause of results for calls to a tool turned off at runtime
+var ErrToolDisabled = errors.New("tool is disabled")
+
+// ToolSettingsStore loads the tool switches set by administrators
+type ToolSettingsStore interface {
+	ListToolSettings(ctx context.Context) ([]models.ToolSetting, error)
+}
+
+// ToolSwitches records which tools are turned off. One set is shared by the MCP and agent registries, so
+// turning a tool off applies to every caller. It is safe for concurrent use by request goroutines.
+type ToolSwitches struct {
+	mu       sync.RWMutex
+	defaults map[string]bool   // Tools disabled by DISABLED_TOOLS
+	disabled map[string]string // Disabled tools with the reason
+}
+
+// NewToolSwitches creates switches with the given tools disabled (DISABLED_TOOLS)
+func NewToolSwitches(disabled []string) *ToolSwitches {
+	s := &ToolSwitches{
+		defaults: make(map[string]bool, len(disabled)),
+		disabled: make(map[string]string, len(disabled)),
+	}
+	for _, name := range disabled {
+		s.defaults[name] = true
+		s.disabled[name] = "disabled by configuration"
+	}
+	return s
+}
+
+// Enabled reports whether a tool is turned on
+func (s *ToolSwitches) Enabled(name string) bool {
+	if s == nil {
+		return true
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, disabled := s.disabled[name]
+	return !disabled
+}
+
+// Reason returns why a tool is turned off, or "" if it is enabled
+func (s *ToolSwitches) Reason(name string) string {
+	if s == nil {
+		return ""
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.disabled[name]
+}
+
+// Apply turns tools on or off as the stored settings say; tools without a setting keep their DISABLED_TOOLS default
+func (s *ToolSwitches) Apply(settings []models.ToolSetting) {
+	disabled := make(map[string]string, len(s.defaults))
+	for name := range s.defaults {
+		disabled[name] = "disabled by configuration"
+	}
+	for _, setting := range settings {
+		if setting.Enabled {
+			delete(disabled, setting.Name)
+			continue
+		}
+		reason := setting.Reason
+		if reason == "" {
+			reason = "disabled by an administrator"
+		}
+		disabled[setting.Name] = reason
+	}
+
+	s.mu.Lock()
+	s.disabled = disabled
+	s.mu.Unlock()
+}
+
+// Watch reloads the stored settings every interval until the context is cancelled, so a switch changed
+// on one instance reaches the others
+func (s *ToolSwitches) Watch(ctx context.Context, store ToolSettingsStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		settings, err := store.ListToolSettings(ctx)
+		if err != nil {
+			toolsLog.ErrorContext(ctx, "Failed to load tool settings", "error", err)
+		} else {
+			s.Apply(settings)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
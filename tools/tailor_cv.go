@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/myjobmatch/backend/gemini"
+	"github.com/myjobmatch/backend/models"
+)
+
+// TailorCVTool rewrites CV sections for a specific job using Gemini
+type TailorCVTool struct {
+	geminiClient *gemini.Client
+}
+
+// NewTailorCVTool creates a new CV tailoring tool
+func NewTailorCVTool(geminiClient *gemini.Client) *TailorCVTool {
+	return &TailorCVTool{
+		geminiClient: geminiClient,
+	}
+}
+
+func (t *TailorCVTool) Name() string {
+	return "tailor_cv"
+}
+
+func (t *TailorCVTool) Version() string {
+	return "1.0.0"
+}
+
+func (t *TailorCVTool) Description() string {
+	return `Tailor a CV to a specific job posting using AI.
+Input should include the user profile and job posting.
+Returns a rewritten professional summary, the candidate's skills reordered by relevance,
+experience highlights to emphasize, and job requirements missing from the CV.`
+}
+
+func (t *TailorCVTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"profile": map[string]interface{}{
+				"type":        "object",
+				"description": "User profile with summary, skills and work history",
+			},
+			"job": map[string]interface{}{
+				"type":        "object",
+				"description": "Job posting to tailor the CV for",
+			},
+		},
+		"required": []string{"profile", "job"},
+	}
+}
+
+// TailorCVInput represents the input for CV tailoring
+type TailorCVInput struct {
+	Profile models.UserProfile `json:"profile"`
+	Job     models.JobPosting  `json:"job"`
+}
+
+func (t *TailorCVTool) Execute(ctx context.Context, input json.RawMessage) (json.RawMessage, error) {
+	var tailorInput TailorCVInput
+	if err := json.Unmarshal(input, &tailorInput); err != nil {
+		return NewErrorResult(fmt.Sprintf("invalid input: %v", err))
+	}
+
+	tailored, err := t.geminiClient.TailorCV(ctx, &tailorInput.Profile, &tailorInput.Job)
+	if err != nil {
+		return NewGenerationErrorResult("CV tailoring failed", err)
+	}
+
+	return NewSuccessResult(tailored)
+}
+
+// TailorCV is a direct method to tailor a CV to a job
+func (t *TailorCVTool) TailorCV(ctx context.Context, profile *models.UserProfile, job *models.JobPosting) (*models.TailoredCV, error) {
+	inputJSON, err := json.Marshal(TailorCVInput{Profile: *profile, Job: *job})
+	if err != nil {
+		return nil, err
+	}
+
+	resultJSON, err := t.Execute(ctx, inputJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ToolResult
+	if err := json.Unmarshal(resultJSON, &result); err != nil {
+		return nil, err
+	}
+
+	if !result.Success {
+		return nil, result.Err()
+	}
+
+	var tailored models.TailoredCV
+	if err := json.Unmarshal(result.Data, &tailored); err != nil {
+		return nil, err
+	}
+
+	return &tailored, nil
+}
@@ -0,0 +1,23 @@
+package utils
+
+import "context"
+
+type callerContextKey struct{}
+
+// Caller identifies who work is done for, so tool calls can be attributed in the audit log
+type Caller struct {
+	Email      string // Signed-in user, empty for anonymous requests
+	APITokenID string // Personal access token used, if any
+	ClientIP   string
+}
+
+// WithCaller attaches the caller's identity to the context
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+// CallerFromContext returns the caller attached to the context, or the zero Caller if there is none
+func CallerFromContext(ctx context.Context) Caller {
+	caller, _ := ctx.Value(callerContextKey{}).(Caller)
+	return caller
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen matches (with errors.Is) every error returned while a dependency's circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitOpenError reports a call refused because its dependency is failing
+type CircuitOpenError struct {
+	Dependency string
+	RetryAfter time.Duration // Time until a trial call is allowed
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s unavailable: %v (retry after %s)", e.Dependency, ErrCircuitOpen, e.RetryAfter.Round(time.Second))
+}
+
+// Is makes errors.Is(err, ErrCircuitOpen) match any CircuitOpenError
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitBreaker stops calls to a dependency after consecutive failures, so an outage fails calls
+// immediately instead of tying up goroutines until each call times out. After the cooldown one trial
+// call is let through: success closes the breaker, failure keeps it open for another cooldown.
+// A nil CircuitBreaker allows every call. It is safe for concurrent use.
+type CircuitBreaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+	isFailure func(error) bool // Whether an error indicates the dependency is failing
+
+	mu       sync.Mutex
+	failures int // Consecutive failures
+	open     bool
+	openedAt time.Time
+	probing  bool // A trial call is in flight
+}
+
+// NewCircuitBreaker creates a breaker that opens after threshold consecutive failures, as classified by
+// isFailure, and allows a trial call after cooldown. It returns nil (no breaker) if threshold is not positive.
+func NewCircuitBreaker(name string, threshold int, cooldown time.Duration, isFailure func(error) bool) *CircuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &CircuitBreaker{name: name, threshold: threshold, cooldown: cooldown, isFailure: isFailure}
+}
+
+// Allow reports whether a call may proceed, returning a CircuitOpenError if not.
+// Every allowed call must be followed by Record with its outcome, or by Release if it was not made.
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return nil
+	}
+
+	if wait := b.cooldown - time.Since(b.openedAt); wait > 0 {
+		return &CircuitOpenError{Dependency: b.name, RetryAfter: wait}
+	}
+	if b.probing {
+		return &CircuitOpenError{Dependency: b.name, RetryAfter: b.cooldown}
+	}
+	b.probing = true
+	return nil
+}
+
+// Release gives back an allowed call that was not made, e.g. because a local limit refused it.
+// The breaker's state is unchanged, but a trial call can be let through again.
+func (b *CircuitBreaker) Release() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// Record records the outcome of an allowed call. Errors that don't indicate a failing dependency
+// (e.g. a cancelled request) leave the breaker's state unchanged.
+func (b *CircuitBreaker) Record(err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	wasProbing := b.probing
+	b.probing = false
+
+	if err == nil {
+		if b.open {
+			log.Printf("[Breaker] %s recovered, closing circuit", b.name)
+		}
+		b.failures = 0
+		b.open = false
+		return
+	}
+	if !b.isFailure(err) {
+		return
+	}
+
+	b.failures++
+	switch {
+	case b.open && wasProbing:
+		b.openedAt = time.Now()
+		log.Printf("[Breaker] %s trial call failed, circuit stays open for %s: %v", b.name, b.cooldown, err)
+	case !b.open && b.failures >= b.threshold:
+		b.open = true
+		b.openedAt = time.Now()
+		log.Printf("[Breaker] %s failed %d times in a row, opening circuit for %s: %v", b.name, b.failures, b.cooldown, err)
+	}
+}
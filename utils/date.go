@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// postedAgoPattern matches relative dates like "3 days ago", "30+ days ago", "2 minggu yang lalu"
+var postedAgoPattern = regexp.MustCompile(`(\d+)\+?\s*(minute|min|hour|hr|day|week|month|year|menit|jam|hari|minggu|bulan|tahun)s?\b`)
+
+// absoluteDateLayouts are the absolute posting date formats seen on job portals
+var absoluteDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"02/01/2006",
+	"2 January 2006",
+	"2 Jan 2006",
+	"January 2, 2006",
+	"Jan 2, 2006",
+}
+
+// ParsePostedDate parses a posting date as found on job pages, either absolute ("2024-05-01",
+// "1 May 2024") or relative to now ("3 days ago", "yesterday", "2 hari yang lalu").
+// ok is false if the text isn't a recognizable date.
+func ParsePostedDate(text string, now time.Time) (time.Time, bool) {
+	s := strings.TrimSpace(text)
+	if s == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range absoluteDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	lower := strings.ToLower(s)
+	switch {
+	case strings.Contains(lower, "just now"), strings.Contains(lower, "today"), strings.Contains(lower, "hari ini"):
+		return now, true
+	case strings.Contains(lower, "yesterday"), strings.Contains(lower, "kemarin"):
+		return now.AddDate(0, 0, -1), true
+	}
+
+	m := postedAgoPattern.FindStringSubmatch(lower)
+	if m == nil {
+		return time.Time{}, false
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	switch m[2] {
+	case "minute", "min", "menit":
+		return now.Add(-time.Duration(n) * time.Minute), true
+	case "hour", "hr", "jam":
+		return now.Add(-time.Duration(n) * time.Hour), true
+	case "day", "hari":
+		return now.AddDate(0, 0, -n), true
+	case "week", "minggu":
+		return now.AddDate(0, 0, -7*n), true
+	case "month", "bulan":
+		return now.AddDate(0, -n, 0), true
+	default:
+		return now.AddDate(-n, 0, 0), true
+	}
+}
+
+// PostedWithin returns the maximum posting age for a date_posted filter value (last_24h, last_week, last_month)
+func PostedWithin(datePosted string) (time.Duration, bool) {
+	switch datePosted {
+	case "last_24h":
+		return 24 * time.Hour, true
+	case "last_week":
+		return 7 * 24 * time.Hour, true
+	case "last_month":
+		return 30 * 24 * time.Hour, true
+	default:
+		return 0, false
+	}
+}
@@ -41,12 +41,7 @@ func (e *DocumentExtractor) ExtractText(file multipart.File, header *multipart.F
 		return e.extractPDFBasic(content)
 
 	case ".doc", ".docx":
-		// For Word documents, you would integrate a docx library
-		// For now, return basic extraction
-		// In production, consider using:
-		// - github.com/unidoc/unioffice
-		// - github.com/nguyenthenguyen/docx
-		return e.extractDocxBasic(content)
+		return ExtractCVText(header.Filename, content)
 
 	default:
 		// Try treating as plain text
@@ -86,35 +81,6 @@ func (e *DocumentExtractor) extractPDFBasic(content []byte) (string, error) {
 	return string(content), nil
 }
 
-// extractDocxBasic provides basic DOCX text extraction
-// In production, use a proper DOCX library
-func (e *DocumentExtractor) extractDocxBasic(content []byte) (string, error) {
-	// DOCX files are ZIP archives containing XML
-	// The main text is usually in word/document.xml
-
-	// For basic extraction, look for text content
-	text := string(content)
-
-	// Check if it looks like a DOCX/ZIP file
-	if len(content) > 4 && content[0] == 'P' && content[1] == 'K' {
-		// This is a ZIP file (DOCX)
-		// For now, extract any readable text between XML tags
-		// A proper implementation would unzip and parse XML
-
-		return "[DOCX document - please paste CV text directly for best results]", nil
-	}
-
-	// Legacy .doc format
-	var cleanText strings.Builder
-	for _, r := range text {
-		if r >= 32 && r <= 126 || r == '\n' || r == '\r' || r == '\t' {
-			cleanText.WriteRune(r)
-		}
-	}
-
-	return cleanText.String(), nil
-}
-
 // IsSupportedFormat checks if the file format is supported
 func (e *DocumentExtractor) IsSupportedFormat(filename string) bool {
 	ext := strings.ToLower(filepath.Ext(filename))
@@ -0,0 +1,237 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ErrNoDocumentText is returned when no readable text can be extracted from a document
+var ErrNoDocumentText = errors.New("no readable text found in document")
+
+// maxDocumentXMLBytes bounds the decompressed size of a DOCX part, guarding against zip bombs
+const maxDocumentXMLBytes = 20 << 20
+
+// minDocTextRun is the shortest run of printable characters kept from a legacy DOC file
+const minDocTextRun = 4
+
+// docxTextParts matches the DOCX parts holding CV text; headers often carry the name and contact details
+var docxTextParts = regexp.MustCompile(`^word/(header\d*|document|footer\d*)\.xml$`)
+
+// ExtractCVText converts a DOC, DOCX or TXT CV to plain text for CV text parsing.
+// PDFs are not handled here since they are parsed by Gemini directly.
+func ExtractCVText(filename string, data []byte) (string, error) {
+	var text string
+	var err error
+
+	switch ext := strings.ToLower(filepath.Ext(filename)); ext {
+	case ".docx":
+		text, err = ExtractDocxText(data)
+	case ".doc":
+		text, err = ExtractDocText(data)
+	case ".txt":
+		if !utf8.Valid(data) {
+			return "", fmt.Errorf("%w: text file is not valid UTF-8", ErrUnsupportedFileType)
+		}
+		text = string(data)
+	default:
+		return "", fmt.Errorf("%w: %q cannot be converted to text", ErrUnsupportedFileType, ext)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", ErrNoDocumentText
+	}
+	return text, nil
+}
+
+// ExtractDocxText extracts the text of a DOCX document (headers, body and footers),
+// keeping paragraphs, line breaks and tabs
+func ExtractDocxText(data []byte) (string, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to open DOCX archive: %w", err)
+	}
+
+	parts := make(map[string]*zip.File)
+	var headers, footers []string
+	for _, f := range reader.File {
+		match := docxTextParts.FindStringSubmatch(f.Name)
+		if match == nil {
+			continue
+		}
+		parts[f.Name] = f
+		switch {
+		case strings.HasPrefix(match[1], "header"):
+			headers = append(headers, f.Name)
+		case strings.HasPrefix(match[1], "footer"):
+			footers = append(footers, f.Name)
+		}
+	}
+	if parts["word/document.xml"] == nil {
+		return "", fmt.Errorf("%w: DOCX archive has no word/document.xml", ErrUnsupportedFileType)
+	}
+
+	order := append(append(headers, "word/document.xml"), footers...)
+	var sb strings.Builder
+	for _, name := range order {
+		text, err := docxPartText(parts[name])
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if text = strings.TrimSpace(text); text != "" {
+			sb.WriteString(text)
+			sb.WriteString("\n\n")
+		}
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// docxPartText collects the text runs of a WordprocessingML part
+func docxPartText(f *zip.File) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	decoder := xml.NewDecoder(io.LimitReader(rc, maxDocumentXMLBytes))
+	var sb strings.Builder
+	inText := false
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				sb.WriteByte('\t')
+			case "br", "cr":
+				sb.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				sb.WriteByte('\n')
+			case "tc":
+				sb.WriteByte('\t')
+			}
+		case xml.CharData:
+			if inText {
+				sb.Write(t)
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+// ExtractDocText extracts readable text from a legacy Word 97-2003 (.doc) file.
+// The binary format isn't parsed: runs of printable UTF-16LE or 8-bit text are collected instead,
+// and whichever encoding yields more text is used. This recovers the body text of typical CVs,
+// along with a few stray style and font names.
+func ExtractDocText(data []byte) (string, error) {
+	if !bytes.HasPrefix(data, oleMagic) {
+		return "", fmt.Errorf("%w: not a Word 97-2003 document", ErrUnsupportedFileType)
+	}
+
+	wide := docTextRuns(utf16Runes(data))
+	narrow := docTextRuns(latin1Runes(data))
+	if len(narrow) > len(wide) {
+		return narrow, nil
+	}
+	return wide, nil
+}
+
+// utf16Runes decodes data as UTF-16LE
+func utf16Runes(data []byte) []rune {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(data[2*i:])
+	}
+	return utf16.Decode(units)
+}
+
+// latin1Runes decodes data as Latin-1, which Word uses for 8-bit text
+func latin1Runes(data []byte) []rune {
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return runes
+}
+
+// docTextRuns joins the runs of at least minDocTextRun printable characters that read like words, one per line.
+// Word marks paragraph ends with '\r' and cells with '\a', so both end a run.
+func docTextRuns(runes []rune) string {
+	var sb strings.Builder
+	var run []rune
+	flush := func() {
+		text := strings.TrimSpace(string(run))
+		if utf8.RuneCountInString(text) >= minDocTextRun && isWordLike(text) {
+			sb.WriteString(text)
+			sb.WriteByte('\n')
+		}
+		run = run[:0]
+	}
+
+	for _, r := range runes {
+		if isDocTextRune(r) {
+			run = append(run, r)
+		} else {
+			flush()
+		}
+	}
+	flush()
+	return sb.String()
+}
+
+// isDocTextRune reports whether r may be part of CV text: Latin letters, digits, punctuation, and the
+// dashes, quotes and bullets Word inserts. Other scripts are excluded because binary data decoded
+// as UTF-16 mostly lands in them.
+func isDocTextRune(r rune) bool {
+	switch {
+	case r == '\t':
+		return true
+	case r >= 0x20 && r < 0x7f, r >= 0xa0 && r < 0x250:
+		return true
+	case r >= 0x2010 && r <= 0x2027, r == 0x20ac:
+		return true
+	}
+	return false
+}
+
+// isWordLike reports whether at least half of text is letters and spaces, which drops
+// runs of symbols that happen to be printable
+func isWordLike(text string) bool {
+	letters, total := 0, 0
+	for _, r := range text {
+		total++
+		if unicode.IsLetter(r) || r == ' ' {
+			letters++
+		}
+	}
+	return letters*2 >= total
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // Register GIF decoder
+	"image/jpeg"
+	_ "image/png" // Register PNG decoder
+)
+
+// maxImagePixels guards against decompression bombs (tiny files that decode to huge images)
+const maxImagePixels = 40_000_000
+
+// ResizeAvatar decodes a JPEG, PNG or GIF image, crops it to a centered square,
+// scales it down to size x size and re-encodes it as JPEG
+func ResizeAvatar(data []byte, size int) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: not a JPEG, PNG or GIF image", ErrUnsupportedFileType)
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return nil, fmt.Errorf("%w: image dimensions %dx%d are too large", ErrFileTooLarge, cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	// Center-crop to a square
+	b := src.Bounds()
+	side := b.Dx()
+	if b.Dy() < side {
+		side = b.Dy()
+	}
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+
+	if side < size {
+		size = side
+	}
+	dst := scaleDown(src, image.Rect(x0, y0, x0+side, y0+side), size)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// scaleDown resamples the square region r of src to size x size by averaging the source
+// pixels covered by each destination pixel. Transparent areas are composited onto white.
+func scaleDown(src image.Image, r image.Rectangle, size int) *image.RGBA {
+	// Flatten onto an opaque white background first so JPEG encoding doesn't turn transparency black
+	flat := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, r.Min, draw.Over)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	side := r.Dx()
+	for dy := 0; dy < size; dy++ {
+		sy0, sy1 := dy*side/size, (dy+1)*side/size
+		for dx := 0; dx < size; dx++ {
+			sx0, sx1 := dx*side/size, (dx+1)*side/size
+
+			var rs, gs, bs, n uint32
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					i := flat.PixOffset(sx, sy)
+					rs += uint32(flat.Pix[i])
+					gs += uint32(flat.Pix[i+1])
+					bs += uint32(flat.Pix[i+2])
+					n++
+				}
+			}
+			if n == 0 {
+				continue
+			}
+
+			j := dst.PixOffset(dx, dy)
+			dst.Pix[j] = uint8(rs / n)
+			dst.Pix[j+1] = uint8(gs / n)
+			dst.Pix[j+2] = uint8(bs / n)
+			dst.Pix[j+3] = 0xff
+		}
+	}
+	return dst
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"syscall"
+)
+
+// ErrPrivateAddress is returned when connecting to a host that resolves to a private address
+var ErrPrivateAddress = errors.New("host resolves to a private address")
+
+// IsPrivateAddress reports whether ip belongs to this machine or a private network, including the
+// link-local range cloud metadata servers live in
+func IsPrivateAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast()
+}
+
+// IsPublicHTTPURL reports whether raw is an absolute http or https URL without credentials whose host
+// isn't a private IP address. Hostnames are checked when connecting, by PublicDialControl.
+func IsPublicHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || u.User != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	ip := net.ParseIP(u.Hostname())
+	return ip == nil || !IsPrivateAddress(ip)
+}
+
+// PublicDialControl is a net.Dialer Control function that refuses connections to private addresses.
+// It runs on the resolved address, so DNS can't be used to reach internal services, redirects included.
+func PublicDialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || IsPrivateAddress(ip) {
+		return ErrPrivateAddress
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+)
+
+type progressContextKey struct{}
+
+// ProgressFunc receives human-readable progress messages from long-running work, such as
+// "12/30 pages fetched". It may be called from several goroutines at once.
+type ProgressFunc func(message string)
+
+// WithProgress attaches a progress reporter to the context, e.g. to relay progress to an MCP client
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressContextKey{}, fn)
+}
+
+// ReportProgress sends a progress message to the reporter attached to the context, if any
+func ReportProgress(ctx context.Context, format string, args ...interface{}) {
+	fn, _ := ctx.Value(progressContextKey{}).(ProgressFunc)
+	if fn == nil {
+		return
+	}
+	fn(fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+// redactedPlaceholder replaces personal details removed from free text
+const redactedPlaceholder = "[redacted]"
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// Phone numbers: optional +country code, then 8+ digits possibly separated by spaces, dots, dashes or parentheses
+	phonePattern = regexp.MustCompile(`\+?\(?\d[\d\s().\-]{6,}\d`)
+	// Date ranges such as "2019 - 2021" or "2019-01 - 2021-12" also match phonePattern
+	dateRangePattern = regexp.MustCompile(`^(19|20)\d{2}([\s./\-]+\d{1,4})+$`)
+)
+
+// RedactProfile returns a copy of the profile without the candidate's name, email and phone,
+// also scrubbing them from free-text fields. Used for incognito searches so identifying
+// details are never sent to the model.
+func RedactProfile(p *models.UserProfile) *models.UserProfile {
+	if p == nil {
+		return nil
+	}
+
+	redacted := *p
+	names := nameParts(p.Name)
+
+	redacted.Name = ""
+	redacted.Email = ""
+	redacted.Phone = ""
+	redacted.Summary = RedactText(p.Summary, names...)
+	redacted.Achievements = redactList(p.Achievements, names)
+
+	if p.WorkHistory != nil {
+		redacted.WorkHistory = make([]models.WorkExperience, len(p.WorkHistory))
+		for i, work := range p.WorkHistory {
+			work.Description = RedactText(work.Description, names...)
+			redacted.WorkHistory[i] = work
+		}
+	}
+
+	return &redacted
+}
+
+// RedactText removes email addresses, phone numbers and the given names from text
+func RedactText(text string, names ...string) string {
+	if text == "" {
+		return text
+	}
+
+	text = emailPattern.ReplaceAllString(text, redactedPlaceholder)
+	text = phonePattern.ReplaceAllStringFunc(text, func(match string) string {
+		digits := 0
+		for _, r := range match {
+			if r >= '0' && r <= '9' {
+				digits++
+			}
+		}
+		if digits < 8 || dateRangePattern.MatchString(strings.TrimSpace(match)) {
+			return match
+		}
+		return redactedPlaceholder
+	})
+
+	for _, name := range names {
+		re := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(name) + `\b`)
+		text = re.ReplaceAllString(text, redactedPlaceholder)
+	}
+
+	return text
+}
+
+func redactList(values []string, names []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = RedactText(v, names...)
+	}
+	return result
+}
+
+// nameParts splits a full name into the words worth redacting (skipping initials)
+func nameParts(name string) []string {
+	parts := make([]string, 0)
+	for _, part := range strings.Fields(name) {
+		part = strings.Trim(part, ".,")
+		if len(part) > 2 {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
@@ -0,0 +1,216 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/myjobmatch/backend/models"
+)
+
+var (
+	// salaryNumberPattern matches an amount with optional thousands separators/decimals and a magnitude suffix
+	salaryNumberPattern = regexp.MustCompile(`(\d+(?:[.,]\d+)*)\s*(k|rb|ribu|jt|juta|mio|million|m)?\b`)
+	// thousandsGroupsPattern matches amounts whose separators group thousands ("10.000.000", "5,000")
+	thousandsGroupsPattern = regexp.MustCompile(`^\d{1,3}([.,]\d{3})+$`)
+)
+
+var (
+	// salaryCurrencyCodePattern matches currency codes and abbreviations as whole words ("Rp10jt", not "perm")
+	salaryCurrencyCodePattern = regexp.MustCompile(`(?:^|[^a-z])(idr|rp|sgd|myr|rm|eur|usd)(?:[^a-z]|$)`)
+
+	salaryCurrencyCodes = map[string]string{
+		"idr": "IDR",
+		"rp":  "IDR",
+		"sgd": "SGD",
+		"myr": "MYR",
+		"rm":  "MYR",
+		"eur": "EUR",
+		"usd": "USD",
+	}
+)
+
+// detectSalaryCurrency returns the ISO 4217 code of the first currency marker in the text
+func detectSalaryCurrency(lower string) string {
+	if m := salaryCurrencyCodePattern.FindStringSubmatch(lower); m != nil {
+		return salaryCurrencyCodes[m[1]]
+	}
+	switch {
+	case strings.Contains(lower, "s$"):
+		return "SGD"
+	case strings.Contains(lower, "€"):
+		return "EUR"
+	case strings.Contains(lower, "$"):
+		return "USD"
+	}
+	return ""
+}
+
+// ParseSalary extracts a numeric range and currency from a free-text salary such as
+// "Rp 10.000.000 - 15.000.000", "IDR 10-15 juta", "$5,000 - $7,000 / month" or "8jt".
+// A single amount is returned as both min and max. ok is false if no amount was found.
+func ParseSalary(text string) (min, max int, currency string, ok bool) {
+	lower := strings.ToLower(text)
+
+	currency = detectSalaryCurrency(lower)
+
+	matches := salaryNumberPattern.FindAllStringSubmatch(lower, -1)
+	amounts := make([]float64, 0, 2)
+	suffixes := make([]string, 0, 2)
+	for _, m := range matches {
+		value, ok := parseSalaryNumber(m[1])
+		if !ok || value == 0 {
+			continue
+		}
+		amounts = append(amounts, value)
+		suffixes = append(suffixes, m[2])
+		if len(amounts) == 2 {
+			break
+		}
+	}
+	if len(amounts) == 0 {
+		return 0, 0, currency, false
+	}
+
+	// "10-15 juta": a suffix on the upper bound applies to the lower bound too
+	if len(amounts) == 2 && suffixes[0] == "" && suffixes[1] != "" {
+		suffixes[0] = suffixes[1]
+	}
+	for i := range amounts {
+		amounts[i] *= salaryMultiplier(suffixes[i])
+	}
+
+	min = int(amounts[0])
+	max = min
+	if len(amounts) == 2 && int(amounts[1]) >= min {
+		max = int(amounts[1])
+	}
+	return min, max, currency, true
+}
+
+// salaryPeriodPatterns match period markers such as "/bulan", "per month", "a year", "p.a." or "hourly"
+var salaryPeriodPatterns = []struct {
+	pattern *regexp.Regexp
+	period  string
+}{
+	{regexp.MustCompile(`(?:/|\bper\s+|\ba\s+|\bse)(?:hour|hr|jam)\b|\bhourly\b`), models.SalaryPeriodHour},
+	{regexp.MustCompile(`(?:/|\bper\s+|\ba\s+)(?:day|hari)\b|\bdaily\b|\bharian\b`), models.SalaryPeriodDay},
+	{regexp.MustCompile(`(?:/|\bper\s+|\ba\s+)(?:week|wk|minggu)\b|\bweekly\b|\bmingguan\b`), models.SalaryPeriodWeek},
+	{regexp.MustCompile(`(?:/|\bper\s+|\ba\s+)(?:month|mo|mth|bulan|bln)\b|\bmonthly\b|\bbulanan\b|\bsebulan\b`), models.SalaryPeriodMonth},
+	{regexp.MustCompile(`(?:/|\bper\s+|\ba\s+)(?:year|yr|annum|tahun|thn)\b|\b(?:yearly|annual|annually|tahunan|setahun)\b|\bp\.a\.?`), models.SalaryPeriodYear},
+}
+
+// ParseSalaryPeriod returns the period a salary is paid per. Without a marker in the text the period is
+// inferred from the amount: IDR salaries up to 150 million are monthly, other currencies' amounts of
+// 20,000 or more are yearly, under 200 hourly, and monthly otherwise. Returns "" if amount is 0.
+func ParseSalaryPeriod(text string, amount int, currency string) string {
+	lower := strings.ToLower(text)
+	for _, p := range salaryPeriodPatterns {
+		if p.pattern.MatchString(lower) {
+			return p.period
+		}
+	}
+
+	switch {
+	case amount <= 0:
+		return ""
+	case currency == "IDR":
+		if amount > 150_000_000 {
+			return models.SalaryPeriodYear
+		}
+		return models.SalaryPeriodMonth
+	case amount >= 20_000:
+		return models.SalaryPeriodYear
+	case amount < 200:
+		return models.SalaryPeriodHour
+	default:
+		return models.SalaryPeriodMonth
+	}
+}
+
+// NormalizeSalaryPeriod maps period labels such as "MONTH", "per-year-salary" or "hourly" to a SalaryPeriod constant,
+// returning "" if the label isn't recognized
+func NormalizeSalaryPeriod(label string) string {
+	lower := strings.ToLower(label)
+	switch {
+	case strings.Contains(lower, "hour"):
+		return models.SalaryPeriodHour
+	case strings.Contains(lower, "day"), strings.Contains(lower, "daily"):
+		return models.SalaryPeriodDay
+	case strings.Contains(lower, "week"):
+		return models.SalaryPeriodWeek
+	case strings.Contains(lower, "month"):
+		return models.SalaryPeriodMonth
+	case strings.Contains(lower, "year"), strings.Contains(lower, "annual"):
+		return models.SalaryPeriodYear
+	default:
+		return ""
+	}
+}
+
+// MonthlySalary converts an amount paid per period to a monthly amount, assuming full-time hours
+// (40 hours and 5 days a week). Amounts with an unknown period are returned unchanged.
+func MonthlySalary(amount int, period string) int {
+	switch period {
+	case models.SalaryPeriodHour:
+		return amount * 40 * 52 / 12
+	case models.SalaryPeriodDay:
+		return amount * 5 * 52 / 12
+	case models.SalaryPeriodWeek:
+		return amount * 52 / 12
+	case models.SalaryPeriodYear:
+		return amount / 12
+	default:
+		return amount
+	}
+}
+
+// SalaryInRange reports whether a posting's salary range overlaps the requested monthly range.
+// The posting's range is converted to monthly amounts using its period. Zero bounds are open,
+// and ranges in different currencies are not comparable (treated as in range).
+func SalaryInRange(jobMin, jobMax int, jobCurrency, jobPeriod string, wantMin, wantMax int, wantCurrency string) bool {
+	if jobMin == 0 && jobMax == 0 {
+		return true
+	}
+	if jobCurrency != "" && wantCurrency != "" && !strings.EqualFold(jobCurrency, wantCurrency) {
+		return true
+	}
+	if jobMax == 0 {
+		jobMax = jobMin
+	}
+	jobMin, jobMax = MonthlySalary(jobMin, jobPeriod), MonthlySalary(jobMax, jobPeriod)
+
+	if wantMin > 0 && jobMax < wantMin {
+		return false
+	}
+	if wantMax > 0 && jobMin > wantMax {
+		return false
+	}
+	return true
+}
+
+// parseSalaryNumber parses "10.000.000" and "5,000" as thousands-grouped integers and "7.5" or "7,5" as decimals
+func parseSalaryNumber(s string) (float64, bool) {
+	if thousandsGroupsPattern.MatchString(s) {
+		s = strings.NewReplacer(".", "", ",", "").Replace(s)
+	} else {
+		s = strings.Replace(s, ",", ".", 1)
+	}
+
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+func salaryMultiplier(suffix string) float64 {
+	switch suffix {
+	case "k", "rb", "ribu":
+		return 1e3
+	case "jt", "juta", "mio", "million", "m":
+		return 1e6
+	default:
+		return 1
+	}
+}
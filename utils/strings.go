@@ -0,0 +1,24 @@
+package utils
+
+import "strings"
+
+// DedupeStrings trims user-entered values and drops empty and case-insensitively repeated ones,
+// keeping the first spelling of each
+func DedupeStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		key := strings.ToLower(v)
+		if v == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, v)
+	}
+	return result
+}
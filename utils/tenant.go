@@ -0,0 +1,16 @@
+package utils
+
+import "context"
+
+type tenantContextKey struct{}
+
+// WithTenant attaches the tenant a request belongs to, so storage reads and writes stay within its partition
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant attached to the context, or "" for the default tenant
+func TenantFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(tenantContextKey{}).(string)
+	return id
+}
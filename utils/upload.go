@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrFileTooLarge is returned when an uploaded file exceeds the size limit
+	ErrFileTooLarge = errors.New("file is too large")
+	// ErrUnsupportedFileType is returned when an uploaded file's extension or content is not allowed
+	ErrUnsupportedFileType = errors.New("unsupported file type")
+)
+
+// Magic byte signatures of accepted CV formats
+var (
+	pdfMagic = []byte("%PDF-")
+	zipMagic = []byte("PK\x03\x04")                       // DOCX is a ZIP container
+	oleMagic = []byte("\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1") // Legacy DOC (OLE2 compound file)
+)
+
+// ReadUploadedFile reads an uploaded file into memory, failing with ErrFileTooLarge above maxBytes.
+// At most maxBytes+1 bytes are read, so oversized uploads are rejected without buffering them.
+func ReadUploadedFile(file multipart.File, header *multipart.FileHeader, maxBytes int64) ([]byte, error) {
+	if header.Size > maxBytes {
+		return nil, fmt.Errorf("%w: %d bytes exceeds the %d byte limit", ErrFileTooLarge, header.Size, maxBytes)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("%w: exceeds the %d byte limit", ErrFileTooLarge, maxBytes)
+	}
+	return data, nil
+}
+
+// ReadCVFile reads an uploaded CV into memory and validates its size, extension and content
+func ReadCVFile(file multipart.File, header *multipart.FileHeader, maxBytes int64) ([]byte, error) {
+	data, err := ReadUploadedFile(file, header, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateCVContent(header.Filename, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ValidateCVExtension checks that a CV file name has an allowed extension
+func ValidateCVExtension(filename string) error {
+	switch ext := strings.ToLower(filepath.Ext(filename)); ext {
+	case ".pdf", ".docx", ".doc", ".txt":
+		return nil
+	default:
+		return fmt.Errorf("%w: %q (allowed: .pdf, .doc, .docx, .txt)", ErrUnsupportedFileType, ext)
+	}
+}
+
+// ValidateCVContent checks that a CV has an allowed extension and that its content matches it
+func ValidateCVContent(filename string, data []byte) error {
+	if err := ValidateCVExtension(filename); err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return fmt.Errorf("%w: file is empty", ErrUnsupportedFileType)
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	var ok bool
+	switch ext {
+	case ".pdf":
+		ok = bytes.HasPrefix(data, pdfMagic)
+	case ".docx":
+		ok = bytes.HasPrefix(data, zipMagic)
+	case ".doc":
+		ok = bytes.HasPrefix(data, oleMagic)
+	case ".txt":
+		ok = strings.HasPrefix(http.DetectContentType(data), "text/plain") && utf8.Valid(data)
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: content does not match the %s extension", ErrUnsupportedFileType, ext)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+)
+
+// trackingParams are query parameters that don't identify a posting and are dropped from canonical URLs
+var trackingParams = map[string]bool{
+	"ref":        true,
+	"refid":      true,
+	"trk":        true,
+	"trackingid": true,
+	"source":     true,
+	"src":        true,
+	"from":       true,
+	"gclid":      true,
+	"fbclid":     true,
+	"lipi":       true,
+	"sc_src":     true,
+	"sc_medium":  true,
+}
+
+// CanonicalURL normalizes a job posting URL so the same posting reached via different links compares equal.
+// The scheme and "www." prefix, fragments, tracking parameters and trailing slashes are dropped, the host is
+// lowercased and the remaining query parameters are sorted. Unparseable URLs are returned trimmed.
+func CanonicalURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+
+	query := u.Query()
+	for key := range query {
+		lower := strings.ToLower(key)
+		if trackingParams[lower] || strings.HasPrefix(lower, "utm_") {
+			query.Del(key)
+		}
+	}
+
+	canonical := host + strings.TrimRight(u.EscapedPath(), "/")
+	if encoded := query.Encode(); encoded != "" {
+		canonical += "?" + encoded
+	}
+	return canonical
+}
+
+// JobID derives a stable job ID from a posting URL, so the same posting gets the same ID in every search
+func JobID(rawURL string) string {
+	sum := sha256.Sum256([]byte(CanonicalURL(rawURL)))
+	return hex.EncodeToString(sum[:16])
+}
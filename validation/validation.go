@@ -0,0 +1,176 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// Limits on user-entered search terms, which end up in web search queries and LLM prompts
+const (
+	maxQueryLength    = 500
+	maxLocations      = 20
+	maxLocationLength = 100
+	maxCompanies      = 100
+	maxCompanyLength  = 200
+	maxKeywords       = 20
+	maxKeywordLength  = 50
+
+	// maxMonthlySalary is far above any real monthly pay, in IDR too, so larger amounts are typos or garbage
+	maxMonthlySalary = 10_000_000_000
+)
+
+// plainTerm matches names and keywords: letters, digits, spaces and the punctuation found in place and
+// company names and skills (C++, C#, AT&T). Quotes, colons and other web search operators are refused.
+var plainTerm = regexp.MustCompile(`^[\p{L}\p{M}\p{N} .,'&()/+#!@-]+$`)
+
+// currencyCode matches ISO 4217 codes
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// datePostedValues are the accepted date_posted filter values
+var datePostedValues = []string{"last_24h", "last_week", "last_month"}
+
+// remoteModes and jobTypes are the accepted filter values, after normalization
+var (
+	remoteModes = []string{models.SiteSettingWFH, models.SiteSettingWFO, models.SiteSettingHybrid}
+	jobTypes    = []string{models.WorkTypeFullTime, models.WorkTypePartTime, models.WorkTypeContract,
+		models.WorkTypeInternship, models.WorkTypeFreelance}
+)
+
+// Errors lists the fields of a request that failed validation
+type Errors []models.FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + ": " + fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *Errors) add(field, format string, args ...interface{}) {
+	*e = append(*e, models.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// err returns the errors as an error, or nil if there are none
+func (e Errors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Search validates a search's free-text query and its filters (which may be nil), normalizing them in
+// place: values are trimmed and de-duplicated, and remote modes and job types are mapped to their
+// standard values ("remote" to WFH, "full-time" to full_time). It returns Errors listing every invalid
+// field, so garbage is refused before it reaches web search queries and LLM prompts.
+func Search(query *string, filters *models.JobSearchFilter) error {
+	var errs Errors
+	*query = strings.TrimSpace(*query)
+	checkQuery(&errs, "query", *query)
+	if filters != nil {
+		checkFilters(&errs, "filters", filters)
+	}
+	return errs.err()
+}
+
+func checkQuery(errs *Errors, field, query string) {
+	if len([]rune(query)) > maxQueryLength {
+		errs.add(field, "must be at most %d characters", maxQueryLength)
+	}
+	if strings.IndexFunc(query, unicode.IsControl) >= 0 {
+		errs.add(field, "must not contain control characters")
+	}
+}
+
+func checkFilters(errs *Errors, field string, f *models.JobSearchFilter) {
+	f.Locations = checkTerms(errs, field+".locations", f.Locations, maxLocations, maxLocationLength)
+	f.ExcludeCompanies = checkTerms(errs, field+".exclude_companies", f.ExcludeCompanies, maxCompanies, maxCompanyLength)
+	f.ExcludeKeywords = checkTerms(errs, field+".exclude_keywords", f.ExcludeKeywords, maxKeywords, maxKeywordLength)
+
+	f.RemoteModes = checkChoices(errs, field+".remote_modes", f.RemoteModes, models.NormalizeSiteSetting, remoteModes)
+	f.JobTypes = checkChoices(errs, field+".job_types", f.JobTypes, models.NormalizeWorkType, jobTypes)
+
+	f.DatePosted = strings.ToLower(strings.TrimSpace(f.DatePosted))
+	if f.DatePosted != "" && !contains(datePostedValues, f.DatePosted) {
+		errs.add(field+".date_posted", "must be one of %s", strings.Join(datePostedValues, ", "))
+	}
+
+	checkSalary(errs, field+".min_salary", f.MinSalary)
+	checkSalary(errs, field+".max_salary", f.MaxSalary)
+	if f.MinSalary > 0 && f.MaxSalary > 0 && f.MinSalary > f.MaxSalary {
+		errs.add(field+".min_salary", "must not exceed max_salary")
+	}
+	f.Currency = strings.ToUpper(strings.TrimSpace(f.Currency))
+	if f.Currency != "" && !currencyCode.MatchString(f.Currency) {
+		errs.add(field+".currency", "must be a 3-letter ISO 4217 code, e.g. IDR")
+	}
+
+	if f.MinScore != nil && (*f.MinScore < 0 || *f.MinScore > 100) {
+		errs.add(field+".min_score", "must be between 0 and 100")
+	}
+}
+
+// checkTerms checks a list of names or keywords and returns it trimmed and de-duplicated
+func checkTerms(errs *Errors, field string, values []string, maxItems, maxLength int) []string {
+	for i, v := range values {
+		v = strings.TrimSpace(v)
+		switch {
+		case v == "":
+		case len([]rune(v)) > maxLength:
+			errs.add(fmt.Sprintf("%s[%d]", field, i), "must be at most %d characters", maxLength)
+		case !plainTerm.MatchString(v):
+			errs.add(fmt.Sprintf("%s[%d]", field, i), "must contain only letters, digits, spaces and . , ' & ( ) / + # ! @ -")
+		}
+	}
+
+	values = utils.DedupeStrings(values)
+	if len(values) > maxItems {
+		errs.add(field, "must have at most %d values", maxItems)
+	}
+	return values
+}
+
+// checkChoices maps each value of a list to its standard value with normalize and checks it is allowed
+func checkChoices(errs *Errors, field string, values []string, normalize func(string) string, allowed []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	result := make([]string, 0, len(values))
+	for i, v := range values {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		normalized := normalize(v)
+		if !contains(allowed, normalized) {
+			errs.add(fmt.Sprintf("%s[%d]", field, i), "must be one of %s", strings.Join(allowed, ", "))
+			continue
+		}
+		if !contains(result, normalized) {
+			result = append(result, normalized)
+		}
+	}
+	return result
+}
+
+func checkSalary(errs *Errors, field string, amount int) {
+	if amount < 0 {
+		errs.add(field, "must not be negative")
+	} else if amount > maxMonthlySalary {
+		errs.add(field, "must be a monthly amount of at most %d", maxMonthlySalary)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
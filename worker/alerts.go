@@ -0,0 +1,183 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/myjobmatch/backend/agent"
+	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/notify"
+	"github.com/myjobmatch/backend/profile"
+	"github.com/myjobmatch/backend/storage"
+)
+
+const (
+	// maxAlertSeenURLs caps how many result URLs are remembered per alert
+	maxAlertSeenURLs = 1000
+
+	// alertRetryDelay is how long to wait before retrying an alert whose search failed
+	alertRetryDelay = time.Hour
+)
+
+// AlertScheduler reruns saved searches when they are due and emails users only the matches they haven't seen
+type AlertScheduler struct {
+	agent           *agent.JobAgent
+	store           storage.Store
+	profiles        *profile.Service
+	notifier        notify.Notifier
+	interval        time.Duration
+	defaultMinScore int
+	tenantIDs       []string
+}
+
+// NewAlertScheduler creates a new job alert scheduler
+func NewAlertScheduler(
+	cfg *config.Config,
+	jobAgent *agent.JobAgent,
+	store storage.Store,
+	profiles *profile.Service,
+	notifier notify.Notifier,
+) *AlertScheduler {
+	return &AlertScheduler{
+		agent:           jobAgent,
+		store:           store,
+		profiles:        profiles,
+		notifier:        notifier,
+		interval:        time.Duration(cfg.JobAlertCheckMinutes) * time.Minute,
+		defaultMinScore: cfg.JobAlertMinScore,
+		tenantIDs:       cfg.TenantIDs(),
+	}
+}
+
+// Start checks every tenant for due alerts until the context is cancelled
+func (s *AlertScheduler) Start(ctx context.Context) {
+	log.Printf("[Alerts] Scheduler started, checking every %s", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := forEachTenant(ctx, s.tenantIDs, s.RunDue); err != nil {
+			log.Printf("[Alerts] Run failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("[Alerts] Scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue runs every alert of the context's tenant whose next run time has passed
+func (s *AlertScheduler) RunDue(ctx context.Context) error {
+	alerts, err := s.store.ListDueAlerts(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(alerts) == 0 {
+		return nil
+	}
+	log.Printf("[Alerts] Running %d due alerts", len(alerts))
+
+	for _, alert := range alerts {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.runAlert(ctx, alert); err != nil {
+			log.Printf("[Alerts] Alert %s for %s failed: %v", alert.ID, alert.UserID, err)
+			if err := s.store.UpdateAlertRun(ctx, alert.ID, alert.SeenURLs, alert.LastRunAt, time.Now().Add(alertRetryDelay)); err != nil {
+				log.Printf("[Alerts] Failed to reschedule alert %s: %v", alert.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// runAlert reruns one saved search, notifies the user of unseen matches and schedules the next run
+func (s *AlertScheduler) runAlert(ctx context.Context, alert models.JobAlert) error {
+	user, err := s.store.GetUserByID(ctx, alert.UserID)
+	if err != nil {
+		return err
+	}
+	userProfile, err := loadUserProfile(ctx, s.profiles, s.store, alert.UserID)
+	if err != nil {
+		return err
+	}
+	if userProfile == nil && alert.Query == "" {
+		log.Printf("[Alerts] Alert %s has no query and %s has no CV, skipping", alert.ID, alert.UserID)
+		return s.reschedule(ctx, alert, alert.SeenURLs)
+	}
+
+	output, err := s.agent.SearchJobs(ctx, agent.SearchJobsInput{
+		Profile: userProfile,
+		Query:   alert.Query,
+		Filters: alert.Filters.WithExcludedCompanies(user.BlockedCompanies),
+		UserID:  alert.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(alert.SeenURLs))
+	for _, u := range alert.SeenURLs {
+		seen[u] = true
+	}
+
+	minScore := alert.MinScore
+	if minScore <= 0 {
+		minScore = s.defaultMinScore
+	}
+
+	seenURLs := alert.SeenURLs
+	matches := make([]models.RankedJob, 0)
+	for _, job := range output.Results {
+		if job.URL == "" || seen[job.URL] {
+			continue
+		}
+		// Every new result is remembered, so lower-scored postings aren't reported on a later run either
+		seen[job.URL] = true
+		seenURLs = append(seenURLs, job.URL)
+		if job.MatchScore >= minScore {
+			matches = append(matches, job)
+		}
+	}
+	if len(seenURLs) > maxAlertSeenURLs {
+		seenURLs = seenURLs[len(seenURLs)-maxAlertSeenURLs:]
+	}
+
+	log.Printf("[Alerts] %q: %d results, %d new above score %d for %s",
+		alert.Name, len(output.Results), len(matches), minScore, alert.UserID)
+
+	if len(matches) > 0 {
+		subject := fmt.Sprintf("%d new job(s) for your alert %q", len(matches), alert.Name)
+		if err := s.notifier.Notify(ctx, user.Email, subject, formatAlertMatches(alert, matches)); err != nil {
+			return err
+		}
+	}
+
+	return s.reschedule(ctx, alert, seenURLs)
+}
+
+// reschedule records a completed run and sets the next run one interval from now
+func (s *AlertScheduler) reschedule(ctx context.Context, alert models.JobAlert, seenURLs []string) error {
+	now := time.Now()
+	return s.store.UpdateAlertRun(ctx, alert.ID, seenURLs, now, now.Add(alert.Interval()))
+}
+
+// formatAlertMatches renders new alert matches as a plain-text notification body
+func formatAlertMatches(alert models.JobAlert, matches []models.RankedJob) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "New jobs for your alert %q:\n\n", alert.Name)
+	for _, job := range matches {
+		fmt.Fprintf(&sb, "- %s at %s (%d%% match)\n  %s\n  %s\n\n", job.Title, job.Company, job.MatchScore, job.MatchReason, job.URL)
+	}
+	fmt.Fprintf(&sb, "You are receiving this %s alert because you saved this search on MyJobMatch.\n", alert.Frequency)
+	return sb.String()
+}
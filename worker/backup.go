@@ -0,0 +1,324 @@
+package worker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+)
+
+var (
+	// ErrBackupRunning is returned when a backup or restore is already in progress
+	ErrBackupRunning = errors.New("a backup or restore is already running")
+	// ErrBackupNotFound is returned when restoring a backup that doesn't exist or never completed
+	ErrBackupNotFound = errors.New("backup not found")
+	// ErrBackupBackend is returned when restoring a backup taken from another storage backend
+	ErrBackupBackend = errors.New("backup was taken from another storage backend")
+)
+
+const (
+	// backupPrefix is the folder holding one folder per backup, named by its ID (under tenants/<id>/ for
+	// tenants other than the default one)
+	backupPrefix = "backups/"
+	// backupIDLayout formats a backup's start time as its ID, so IDs sort chronologically
+	backupIDLayout = "20060102T150405Z"
+	// manifestName is written last in a backup's folder
+	manifestName = "manifest.json"
+	// restoreBatchSize is how many documents are written per restore call
+	restoreBatchSize = 500
+)
+
+// Backup exports the users, profiles and jobs collections to the backup bucket as JSONL, one folder per
+// run, so the database can be restored to the state at any kept backup
+type Backup struct {
+	store     storage.Store
+	bucket    *storage.BackupBucket
+	backend   string
+	interval  time.Duration
+	retention time.Duration // 0 = keep every backup
+	tenantIDs []string
+
+	running sync.Mutex // Held by a backup or restore
+}
+
+// NewBackup creates a backup job writing to bucket
+func NewBackup(cfg *config.Config, store storage.Store, bucket *storage.BackupBucket) *Backup {
+	return &Backup{
+		store:     store,
+		bucket:    bucket,
+		backend:   cfg.StorageBackend,
+		interval:  time.Duration(cfg.BackupIntervalHours) * time.Hour,
+		retention: time.Duration(cfg.BackupRetentionDays) * 24 * time.Hour,
+		tenantIDs: cfg.TenantIDs(),
+	}
+}
+
+// Start backs up every tenant each interval until the context is cancelled. The first backup is taken one
+// interval after startup, so deployments don't each take one.
+func (b *Backup) Start(ctx context.Context) {
+	log.Printf("[Backup] Started, backing up every %s", b.interval)
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("[Backup] Stopped")
+			return
+		case <-ticker.C:
+		}
+
+		err := forEachTenant(ctx, b.tenantIDs, func(ctx context.Context) error {
+			_, err := b.RunOnce(ctx)
+			return err
+		})
+		if err != nil {
+			log.Printf("[Backup] Run failed: %v", err)
+		}
+	}
+}
+
+// RunOnce exports every backed-up collection of the context's tenant, then deletes the tenant's backups
+// past their retention
+func (b *Backup) RunOnce(ctx context.Context) (*models.BackupManifest, error) {
+	if !b.running.TryLock() {
+		return nil, ErrBackupRunning
+	}
+	defer b.running.Unlock()
+
+	started := time.Now().UTC()
+	manifest := &models.BackupManifest{
+		ID:          started.Format(backupIDLayout),
+		Backend:     b.backend,
+		Collections: make(map[string]int),
+		StartedAt:   started,
+	}
+
+	for _, collection := range b.store.BackupCollections() {
+		count, err := b.exportCollection(ctx, manifest.ID, collection)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Collections[collection] = count
+	}
+
+	manifest.CompletedAt = time.Now().UTC()
+	if err := b.writeManifest(ctx, manifest); err != nil {
+		return nil, err
+	}
+	log.Printf("[Backup] Backup %s completed: %v", manifest.ID, manifest.Collections)
+
+	if err := b.prune(ctx, started); err != nil {
+		log.Printf("[Backup] Failed to delete expired backups: %v", err)
+	}
+	return manifest, nil
+}
+
+// exportCollection writes a collection to <backup>/<collection>.jsonl, returning how many documents it holds
+func (b *Backup) exportCollection(ctx context.Context, id, collection string) (int, error) {
+	// Cancelling the context aborts the upload, so a failed export leaves no partial object
+	writeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	writer := b.bucket.NewWriter(writeCtx, objectName(ctx, id, collection+".jsonl"), "application/x-ndjson")
+	encoder := json.NewEncoder(writer)
+
+	count := 0
+	err := b.store.ExportDocuments(ctx, collection, func(doc storage.BackupDocument) error {
+		count++
+		return encoder.Encode(doc)
+	})
+	if err != nil {
+		cancel()
+		writer.Close()
+		return 0, fmt.Errorf("failed to export %s: %w", collection, err)
+	}
+	if err := writer.Close(); err != nil {
+		return 0, fmt.Errorf("failed to write %s backup: %w", collection, err)
+	}
+	return count, nil
+}
+
+func (b *Backup) writeManifest(ctx context.Context, manifest *models.BackupManifest) error {
+	writer := b.bucket.NewWriter(ctx, objectName(ctx, manifest.ID, manifestName), "application/json")
+	if err := json.NewEncoder(writer).Encode(manifest); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write backup manifest: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to write backup manifest: %w", err)
+	}
+	return nil
+}
+
+// List returns the completed backups, newest first
+func (b *Backup) List(ctx context.Context) ([]models.BackupManifest, error) {
+	names, err := b.bucket.List(ctx, backupFolder(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]models.BackupManifest, 0)
+	for _, name := range names {
+		if !strings.HasSuffix(name, "/"+manifestName) {
+			continue
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(name, backupFolder(ctx)), "/"+manifestName)
+		manifest, err := b.readManifest(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, *manifest)
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ID > backups[j].ID
+	})
+	return backups, nil
+}
+
+func (b *Backup) readManifest(ctx context.Context, id string) (*models.BackupManifest, error) {
+	reader, err := b.bucket.NewReader(ctx, objectName(ctx, id, manifestName))
+	if errors.Is(err, storage.ErrBackupObjectNotFound) {
+		return nil, ErrBackupNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var manifest models.BackupManifest
+	if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to read backup manifest %s: %w", id, err)
+	}
+	return &manifest, nil
+}
+
+// Restore writes the documents of a backup's collections (all of them when none are given) back to the
+// database, replacing documents with the same IDs. Documents created since the backup are kept.
+func (b *Backup) Restore(ctx context.Context, id string, collections []string) (*models.RestoreBackupResponse, error) {
+	if !b.running.TryLock() {
+		return nil, ErrBackupRunning
+	}
+	defer b.running.Unlock()
+
+	if _, err := time.Parse(backupIDLayout, id); err != nil {
+		return nil, ErrBackupNotFound
+	}
+	manifest, err := b.readManifest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Backend != b.backend {
+		return nil, ErrBackupBackend
+	}
+
+	if len(collections) == 0 {
+		for collection := range manifest.Collections {
+			collections = append(collections, collection)
+		}
+		sort.Strings(collections)
+	}
+	for _, collection := range collections {
+		if _, ok := manifest.Collections[collection]; !ok || !slices.Contains(b.store.BackupCollections(), collection) {
+			return nil, fmt.Errorf("%w: %s", storage.ErrNotBackedUp, collection)
+		}
+	}
+
+	result := &models.RestoreBackupResponse{ID: id, Restored: make(map[string]int, len(collections))}
+	for _, collection := range collections {
+		count, err := b.restoreCollection(ctx, id, collection)
+		result.Restored[collection] = count
+		if err != nil {
+			return result, err
+		}
+		log.Printf("[Backup] Restored %d %s documents from backup %s", count, collection, id)
+	}
+	return result, nil
+}
+
+// restoreCollection streams a collection's JSONL back into the database, returning how many documents were written
+func (b *Backup) restoreCollection(ctx context.Context, id, collection string) (int, error) {
+	reader, err := b.bucket.NewReader(ctx, objectName(ctx, id, collection+".jsonl"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s backup: %w", collection, err)
+	}
+	defer reader.Close()
+
+	restored := 0
+	batch := make([]storage.BackupDocument, 0, restoreBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := b.store.RestoreDocuments(ctx, collection, batch); err != nil {
+			return err
+		}
+		restored += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	decoder := json.NewDecoder(bufio.NewReader(reader))
+	for decoder.More() {
+		var doc storage.BackupDocument
+		if err := decoder.Decode(&doc); err != nil {
+			return restored, fmt.Errorf("failed to parse %s backup: %w", collection, err)
+		}
+		batch = append(batch, doc)
+		if len(batch) == restoreBatchSize {
+			if err := flush(); err != nil {
+				return restored, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return restored, err
+	}
+	return restored, nil
+}
+
+// prune deletes the backups started more than the retention period before now
+func (b *Backup) prune(ctx context.Context, now time.Time) error {
+	if b.retention <= 0 {
+		return nil
+	}
+
+	names, err := b.bucket.List(ctx, backupFolder(ctx))
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		id, _, _ := strings.Cut(strings.TrimPrefix(name, backupFolder(ctx)), "/")
+		started, err := time.Parse(backupIDLayout, id)
+		if err != nil || now.Sub(started) <= b.retention {
+			continue
+		}
+		if err := b.bucket.Delete(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backupFolder returns the folder holding the context's tenant's backups
+func backupFolder(ctx context.Context) string {
+	return storage.TenantPrefix(ctx) + backupPrefix
+}
+
+// objectName returns the name of a file in one of the context's tenant's backups
+func objectName(ctx context.Context, id, file string) string {
+	return backupFolder(ctx) + id + "/" + file
+}
@@ -0,0 +1,118 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/myjobmatch/backend/agent"
+	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/storage"
+)
+
+// maxJobsPerWrite is Firestore's limit on writes in one batch
+const maxJobsPerWrite = 500
+
+// CrawlTarget is a role and location the crawler keeps the job index warm for
+type CrawlTarget struct {
+	Role     string
+	Location string // Optional
+}
+
+// ParseCrawlTargets parses entries in the form "role@location" (the location may be omitted)
+func ParseCrawlTargets(entries []string) []CrawlTarget {
+	targets := make([]CrawlTarget, 0, len(entries))
+	for _, entry := range entries {
+		role, location, _ := strings.Cut(entry, "@")
+		role = strings.TrimSpace(role)
+		if role == "" {
+			log.Printf("[Crawler] Ignoring invalid target: %q", entry)
+			continue
+		}
+		targets = append(targets, CrawlTarget{Role: role, Location: strings.TrimSpace(location)})
+	}
+	return targets
+}
+
+// Crawler periodically searches popular role and location combinations and stores the postings
+// in the jobs collection, so interactive searches can be answered from the local index
+type Crawler struct {
+	agent          *agent.JobAgent
+	store          storage.Store
+	targets        []CrawlTarget
+	interval       time.Duration
+	pagesPerTarget int
+	tenantIDs      []string
+}
+
+// NewCrawler creates a new background crawler
+func NewCrawler(cfg *config.Config, jobAgent *agent.JobAgent, store storage.Store) *Crawler {
+	return &Crawler{
+		agent:          jobAgent,
+		store:          store,
+		targets:        ParseCrawlTargets(cfg.CrawlTargets),
+		interval:       time.Duration(cfg.CrawlIntervalMinutes) * time.Minute,
+		pagesPerTarget: cfg.CrawlPagesPerTarget,
+		tenantIDs:      cfg.TenantIDs(),
+	}
+}
+
+// Start crawls every target until the context is cancelled
+func (c *Crawler) Start(ctx context.Context) {
+	log.Printf("[Crawler] Started with %d targets, crawling every %s", len(c.targets), c.interval)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.RunOnce(ctx); err != nil {
+			log.Printf("[Crawler] Run failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("[Crawler] Stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce crawls each target once and stores the postings in every tenant's jobs collection;
+// a failing target is logged and skipped
+func (c *Crawler) RunOnce(ctx context.Context) error {
+	tenantCtxs := tenantContexts(ctx, c.tenantIDs)
+	stored := 0
+	for _, target := range c.targets {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		jobs, err := c.agent.CrawlJobs(ctx, target.Role, target.Location, c.pagesPerTarget)
+		if err != nil {
+			log.Printf("[Crawler] Failed to crawl %q in %q: %v", target.Role, target.Location, err)
+			continue
+		}
+		for _, tenantCtx := range tenantCtxs {
+			if err := c.storeJobs(tenantCtx, jobs); err != nil {
+				log.Printf("[Crawler] Failed to store postings for %q in %q: %v", target.Role, target.Location, err)
+			}
+		}
+		stored += len(jobs)
+	}
+
+	log.Printf("[Crawler] Stored %d postings for %d targets", stored, len(c.targets))
+	return nil
+}
+
+// storeJobs saves crawled postings to the context's tenant, a few at a time
+func (c *Crawler) storeJobs(ctx context.Context, jobs []models.JobPosting) error {
+	for start := 0; start < len(jobs); start += maxJobsPerWrite {
+		if err := c.store.SaveJobs(ctx, jobs[start:min(start+maxJobsPerWrite, len(jobs))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/storage"
+)
+
+// CVRetention periodically deletes CV files no user links to, so the bucket doesn't grow with every
+// upload: uploads never linked to a profile after a grace period, and CVs replaced by a newer linked
+// one after the version retention period
+type CVRetention struct {
+	store            storage.Store
+	cvStorage        storage.CVStorage
+	interval         time.Duration
+	orphanGrace      time.Duration
+	versionRetention time.Duration
+	tenantIDs        []string
+}
+
+// NewCVRetention creates a new CV retention job
+func NewCVRetention(cfg *config.Config, store storage.Store, cvStorage storage.CVStorage) *CVRetention {
+	return &CVRetention{
+		store:            store,
+		cvStorage:        cvStorage,
+		interval:         time.Duration(cfg.CVRetentionIntervalHours) * time.Hour,
+		orphanGrace:      time.Duration(cfg.CVOrphanGraceHours) * time.Hour,
+		versionRetention: time.Duration(cfg.CVVersionRetentionDays) * 24 * time.Hour,
+		tenantIDs:        cfg.TenantIDs(),
+	}
+}
+
+// Start cleans up every tenant's CVs every interval until the context is cancelled
+func (r *CVRetention) Start(ctx context.Context) {
+	log.Printf("[CVRetention] Started, cleaning up every %s", r.interval)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := forEachTenant(ctx, r.tenantIDs, r.RunOnce); err != nil {
+			log.Printf("[CVRetention] Run failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("[CVRetention] Stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce deletes the context's tenant's CVs past their retention; a CV that fails to delete is logged and skipped
+func (r *CVRetention) RunOnce(ctx context.Context) error {
+	// Read the links first: a CV linked after this point is newer than the grace period
+	linkedURLs, err := r.store.ListCVUrls(ctx)
+	if err != nil {
+		return err
+	}
+	objects, err := r.cvStorage.ListCVs(ctx)
+	if err != nil {
+		return err
+	}
+
+	linked := make(map[string]bool, len(linkedURLs))
+	for _, u := range linkedURLs {
+		linked[u] = true
+	}
+
+	// Each user's linked CV; older CVs of the same user are earlier versions of it
+	current := make(map[string]time.Time, len(linkedURLs))
+	for _, object := range objects {
+		if linked[object.URL] {
+			current[object.Owner] = object.CreatedAt
+		}
+	}
+
+	now := time.Now()
+	var orphans, versions int
+	for _, object := range objects {
+		if linked[object.URL] {
+			continue
+		}
+
+		retention, isVersion := r.orphanGrace, false
+		if linkedAt, ok := current[object.Owner]; ok && object.CreatedAt.Before(linkedAt) {
+			retention, isVersion = r.versionRetention, true
+		}
+		if now.Sub(object.CreatedAt) < retention {
+			continue
+		}
+
+		if err := r.cvStorage.DeleteCV(ctx, object.URL); err != nil {
+			log.Printf("[CVRetention] Failed to delete %s: %v", object.URL, err)
+			continue
+		}
+		if isVersion {
+			versions++
+		} else {
+			orphans++
+		}
+
+		if ctx.Err() != nil {
+			return fmt.Errorf("cleanup interrupted: %w", ctx.Err())
+		}
+	}
+
+	log.Printf("[CVRetention] Deleted %d never-linked uploads and %d replaced versions of %d CVs", orphans, versions, len(objects))
+	return nil
+}
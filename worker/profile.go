@@ -0,0 +1,28 @@
+package worker
+
+import (
+	"context"
+	"errors"
+
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/profile"
+	"github.com/myjobmatch/backend/storage"
+	"github.com/myjobmatch/backend/utils"
+)
+
+// loadUserProfile returns the user's saved structured profile, redacted if the user searches incognito;
+// returns nil if the user has no profile or CV
+func loadUserProfile(ctx context.Context, profiles *profile.Service, store storage.Store, userID string) (*models.UserProfile, error) {
+	saved, err := profiles.ForUser(ctx, userID)
+	if errors.Is(err, profile.ErrNoProfile) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if user, err := store.GetUserByID(ctx, userID); err == nil && user.Incognito {
+		return utils.RedactProfile(&saved.Profile), nil
+	}
+	return &saved.Profile, nil
+}
@@ -0,0 +1,87 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// ErrQueueFull is returned when a task is submitted while all queue slots are taken
+var ErrQueueFull = errors.New("task queue is full")
+
+// Task is a unit of background work
+type Task func(ctx context.Context)
+
+type queuedTask struct {
+	name string
+	run  Task
+}
+
+// Queue runs submitted tasks on a fixed number of background goroutines
+type Queue struct {
+	tasks   chan queuedTask
+	workers int
+	timeout time.Duration
+}
+
+// NewQueue creates a queue with the given number of workers, pending-task capacity
+// and per-task timeout (0 = no timeout)
+func NewQueue(workers, size int, timeout time.Duration) *Queue {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Queue{
+		tasks:   make(chan queuedTask, size),
+		workers: workers,
+		timeout: timeout,
+	}
+}
+
+// Start launches the workers; they stop once ctx is cancelled
+func (q *Queue) Start(ctx context.Context) {
+	for i := 0; i < q.workers; i++ {
+		go q.work(ctx)
+	}
+	log.Printf("[Queue] Started %d workers", q.workers)
+}
+
+// Submit enqueues a task without blocking; returns ErrQueueFull if there is no capacity
+func (q *Queue) Submit(name string, task Task) error {
+	select {
+	case q.tasks <- queuedTask{name: name, run: task}:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+func (q *Queue) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case task := <-q.tasks:
+			q.run(ctx, task)
+		}
+	}
+}
+
+// run executes a task with the queue's timeout, recovering from panics so one task can't stop a worker
+func (q *Queue) run(ctx context.Context, task queuedTask) {
+	if q.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, q.timeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[Queue] Task %s panicked: %v", task.name, r)
+		}
+	}()
+
+	start := time.Now()
+	task.run(ctx)
+	log.Printf("[Queue] Task %s finished in %s", task.name, time.Since(start).Round(time.Millisecond))
+}
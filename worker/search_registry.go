@@ -0,0 +1,141 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/myjobmatch/backend/logging"
+)
+
+var searchesLog = logging.Component("Searches")
+
+// ErrShuttingDown is returned by Track once the registry has started draining
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// ErrSearchInterrupted is the cancellation cause of searches still running when the drain deadline passes
+var ErrSearchInterrupted = errors.New("search interrupted by server shutdown")
+
+// checkpointTimeout bounds each interrupted search's checkpoint write
+const checkpointTimeout = 5 * time.Second
+
+// SearchRegistry tracks the searches running on this instance, so shutdown can wait for them instead
+// of killing them mid-pipeline
+type SearchRegistry struct {
+	mu       sync.Mutex
+	searches map[*trackedSearch]struct{}
+	draining bool
+	idle     chan struct{} // Closed when the last search finishes during a drain
+}
+
+type trackedSearch struct {
+	name      string
+	cancel    context.CancelCauseFunc
+	interrupt func(ctx context.Context)
+}
+
+// NewSearchRegistry creates an empty registry
+func NewSearchRegistry() *SearchRegistry {
+	return &SearchRegistry{
+		searches: make(map[*trackedSearch]struct{}),
+		idle:     make(chan struct{}),
+	}
+}
+
+// Track registers a search. The returned context is cancelled with ErrSearchInterrupted if the search
+// is still running when the drain deadline passes, after interrupt (optional) has been called to
+// checkpoint it. done must be called once the search has finished. Track returns ErrShuttingDown
+// once draining has started.
+func (r *SearchRegistry) Track(ctx context.Context, name string, interrupt func(ctx context.Context)) (context.Context, func(), error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.draining {
+		return nil, nil, ErrShuttingDown
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	search := &trackedSearch{name: name, cancel: cancel, interrupt: interrupt}
+	r.searches[search] = struct{}{}
+
+	var once sync.Once
+	done := func() {
+		once.Do(func() {
+			cancel(nil)
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			delete(r.searches, search)
+			if r.draining && len(r.searches) == 0 {
+				close(r.idle)
+			}
+		})
+	}
+	return ctx, done, nil
+}
+
+// Running returns the number of tracked searches
+func (r *SearchRegistry) Running() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.searches)
+}
+
+// Drain stops accepting searches and waits for the running ones to finish. If ctx ends first, the
+// remaining searches are checkpointed and cancelled. It returns the number of searches interrupted.
+func (r *SearchRegistry) Drain(ctx context.Context) int {
+	r.mu.Lock()
+	if !r.draining {
+		r.draining = true
+		if len(r.searches) == 0 {
+			close(r.idle)
+		}
+	}
+	searchesLog.InfoContext(ctx, "Draining running searches", "searches", len(r.searches))
+	r.mu.Unlock()
+
+	select {
+	case <-r.idle:
+		return 0
+	case <-ctx.Done():
+	}
+
+	r.mu.Lock()
+	remaining := make([]*trackedSearch, 0, len(r.searches))
+	for search := range r.searches {
+		remaining = append(remaining, search)
+	}
+	r.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, search := range remaining {
+		wg.Add(1)
+		go func(search *trackedSearch) {
+			defer wg.Done()
+			if search.interrupt != nil {
+				checkpointCtx, cancel := context.WithTimeout(context.Background(), checkpointTimeout)
+				defer cancel()
+				search.interrupt(checkpointCtx)
+			}
+			search.cancel(ErrSearchInterrupted)
+			searchesLog.WarnContext(ctx, "Search interrupted", "search", search.name)
+		}(search)
+	}
+	wg.Wait()
+	return len(remaining)
+}
+
+// Interrupted reports whether ctx was cancelled because the search was interrupted by shutdown
+func Interrupted(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrSearchInterrupted)
+}
+
+// WithInterrupt returns a copy of ctx that is also cancelled, with the same cause, when tracked is.
+// Searches tracked before they are queued use it to combine the queue's context with the registry's.
+func WithInterrupt(ctx, tracked context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(tracked, func() { cancel(context.Cause(tracked)) })
+	return ctx, func() {
+		stop()
+		cancel(nil)
+	}
+}
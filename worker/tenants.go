@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/myjobmatch/backend/utils"
+)
+
+// tenantContexts returns ctx for the default tenant followed by a context for each tenant in TENANTS
+func tenantContexts(ctx context.Context, tenantIDs []string) []context.Context {
+	contexts := make([]context.Context, 0, len(tenantIDs)+1)
+	contexts = append(contexts, ctx)
+	for _, tenantID := range tenantIDs {
+		contexts = append(contexts, utils.WithTenant(ctx, tenantID))
+	}
+	return contexts
+}
+
+// forEachTenant runs fn for the default tenant and then each tenant in TENANTS, so a worker pass covers
+// every partition. A failing tenant doesn't stop the others; the failures are returned together.
+func forEachTenant(ctx context.Context, tenantIDs []string, fn func(ctx context.Context) error) error {
+	var errs []error
+	for _, tenantCtx := range tenantContexts(ctx, tenantIDs) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := fn(tenantCtx); err != nil {
+			if tenantID := utils.TenantFromContext(tenantCtx); tenantID != "" {
+				err = fmt.Errorf("tenant %s: %w", tenantID, err)
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
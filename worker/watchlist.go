@@ -0,0 +1,184 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/myjobmatch/backend/agent"
+	"github.com/myjobmatch/backend/config"
+	"github.com/myjobmatch/backend/models"
+	"github.com/myjobmatch/backend/notify"
+	"github.com/myjobmatch/backend/profile"
+	"github.com/myjobmatch/backend/storage"
+)
+
+// maxSeenURLs caps how many posting URLs are remembered per watched company
+const maxSeenURLs = 500
+
+// WatchlistWorker periodically polls watched companies and notifies users of new matching postings
+type WatchlistWorker struct {
+	agent     *agent.JobAgent
+	store     storage.Store
+	profiles  *profile.Service
+	notifier  notify.Notifier
+	interval  time.Duration
+	tenantIDs []string
+}
+
+// NewWatchlistWorker creates a new watchlist worker
+func NewWatchlistWorker(
+	cfg *config.Config,
+	jobAgent *agent.JobAgent,
+	store storage.Store,
+	profiles *profile.Service,
+	notifier notify.Notifier,
+) *WatchlistWorker {
+	return &WatchlistWorker{
+		agent:     jobAgent,
+		store:     store,
+		profiles:  profiles,
+		notifier:  notifier,
+		interval:  time.Duration(cfg.WatchlistPollMinutes) * time.Minute,
+		tenantIDs: cfg.TenantIDs(),
+	}
+}
+
+// Start runs the polling loop over every tenant until the context is cancelled
+func (w *WatchlistWorker) Start(ctx context.Context) {
+	log.Printf("[Watchlist] Worker started, polling every %s", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := forEachTenant(ctx, w.tenantIDs, w.RunOnce); err != nil {
+			log.Printf("[Watchlist] Poll failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("[Watchlist] Worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce polls every watched company of the context's tenant once
+func (w *WatchlistWorker) RunOnce(ctx context.Context) error {
+	companies, err := w.store.ListAllWatchedCompanies(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("[Watchlist] Polling %d watched companies", len(companies))
+
+	// Profiles are loaded lazily, once per user per run
+	profiles := make(map[string]*models.UserProfile)
+
+	for _, company := range companies {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := w.checkCompany(ctx, company, profiles); err != nil {
+			log.Printf("[Watchlist] Failed to check %s for %s: %v", company.CompanyName, company.UserID, err)
+		}
+	}
+
+	return nil
+}
+
+// checkCompany detects new postings for one watched company and notifies the user of good matches
+func (w *WatchlistWorker) checkCompany(ctx context.Context, company models.WatchedCompany, profiles map[string]*models.UserProfile) error {
+	jobs, err := w.agent.DiscoverCompanyJobs(ctx, company)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(company.SeenURLs))
+	for _, u := range company.SeenURLs {
+		seen[u] = true
+	}
+
+	newJobs := make([]models.JobPosting, 0)
+	for _, job := range jobs {
+		if job.URL != "" && !seen[job.URL] {
+			newJobs = append(newJobs, job)
+		}
+	}
+
+	// The first check only records a baseline so users aren't flooded with existing postings
+	firstCheck := company.LastCheckedAt.IsZero()
+	if len(newJobs) > 0 && !firstCheck {
+		if err := w.notifyMatches(ctx, company, newJobs, profiles); err != nil {
+			return err
+		}
+	}
+
+	seenURLs := company.SeenURLs
+	for _, job := range newJobs {
+		seenURLs = append(seenURLs, job.URL)
+	}
+	if len(seenURLs) > maxSeenURLs {
+		seenURLs = seenURLs[len(seenURLs)-maxSeenURLs:]
+	}
+
+	return w.store.UpdateWatchedCompanySeen(ctx, company.ID, seenURLs)
+}
+
+// notifyMatches scores new postings against the user's profile and notifies about those above the threshold
+func (w *WatchlistWorker) notifyMatches(ctx context.Context, company models.WatchedCompany, jobs []models.JobPosting, profiles map[string]*models.UserProfile) error {
+	userProfile, ok := profiles[company.UserID]
+	if !ok {
+		var err error
+		userProfile, err = w.loadProfile(ctx, company.UserID)
+		if err != nil {
+			return err
+		}
+		profiles[company.UserID] = userProfile
+	}
+	if userProfile == nil {
+		log.Printf("[Watchlist] User %s has no CV, skipping scoring for %s", company.UserID, company.CompanyName)
+		return nil
+	}
+
+	ranked := w.agent.ScoreJobs(ctx, userProfile, jobs)
+	matches := make([]models.RankedJob, 0, len(ranked))
+	for _, job := range ranked {
+		if job.MatchScore >= company.MinScore {
+			matches = append(matches, job)
+		}
+	}
+
+	log.Printf("[Watchlist] %s: %d new postings, %d above score %d for %s",
+		company.CompanyName, len(jobs), len(matches), company.MinScore, company.UserID)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	user, err := w.store.GetUserByID(ctx, company.UserID)
+	if err != nil {
+		return err
+	}
+	subject := fmt.Sprintf("%d new job(s) at %s match your profile", len(matches), company.CompanyName)
+	return w.notifier.Notify(ctx, user.Email, subject, formatMatches(company.CompanyName, matches))
+}
+
+// loadProfile returns the user's saved structured profile, redacted if the user searches incognito;
+// returns nil if the user has no profile or CV
+func (w *WatchlistWorker) loadProfile(ctx context.Context, userID string) (*models.UserProfile, error) {
+	return loadUserProfile(ctx, w.profiles, w.store, userID)
+}
+
+// formatMatches renders matching jobs as a plain-text notification body
+func formatMatches(companyName string, matches []models.RankedJob) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "New postings at %s that match your profile:\n\n", companyName)
+	for _, job := range matches {
+		fmt.Fprintf(&sb, "- %s (%d%% match)\n  %s\n  %s\n\n", job.Title, job.MatchScore, job.MatchReason, job.URL)
+	}
+	sb.WriteString("You are receiving this because you follow this company on MyJobMatch.\n")
+	return sb.String()
+}